@@ -27,7 +27,7 @@ lineage: seqs: [
 
 				#PlaylistItem: {
 					// Type of the item.
-					type: "dashboard_by_uid" | "dashboard_by_id" | "dashboard_by_tag"
+					type: "dashboard_by_uid" | "dashboard_by_id" | "dashboard_by_tag" | "dashboard_by_search"
 					// Value depends on type and describes the playlist item.
 					//
 					//  - dashboard_by_id: The value is an internal numerical identifier set by Grafana. This
@@ -36,8 +36,26 @@ lineage: seqs: [
 					//  - dashboard_by_tag: The value is a tag which is set on any number of dashboards. All
 					//  dashboards behind the tag will be added to the playlist.
 					//  - dashboard_by_uid: The value is the dashboard UID
+					//  - dashboard_by_search: The value is a URL encoded search query. All dashboards
+					//  matching every parameter will be added to the playlist. The parameters are
+					//  query (title), tag, folderUid and starredByTeamId (dashboards starred by any
+					//  member of the team), and tag and folderUid can be repeated.
 					value: string
 
+					// Time range start shown for the dashboards of the item, e.g. now-6h. Defaults to the
+					// time range saved with the dashboard.
+					timeFrom?: string
+
+					// Time range end shown for the dashboards of the item, e.g. now.
+					timeTo?: string
+
+					// Refresh interval of the dashboards of the item, e.g. 1m.
+					refresh?: string
+
+					// Kiosk mode the dashboards of the item are shown in. Defaults to the mode
+					// the playlist was started in.
+					kiosk?: "tv" | "full"
+
 					// Title is an unused property -- it will be removed in the future
     				title?: string
 				} @cuetsy(kind="interface")
@@ -188,6 +188,9 @@ export const Pages = {
       CostIncreaseCheckbox: 'data-testid public dashboard cost may increase checkbox',
       EnableSwitch: 'data-testid public dashboard on off switch',
       EnableAnnotationsSwitch: 'data-testid public dashboard on off switch for annotations',
+      ShareTypeRadio: 'data-testid public dashboard share type radio',
+      RecipientsTextArea: 'data-testid public dashboard recipients text area',
+      ExpiresSwitch: 'data-testid public dashboard expires switch',
       SaveConfigButton: 'data-testid public dashboard save config button',
       DeleteButton: 'data-testid public dashboard delete button',
       CopyUrlInput: 'data-testid public dashboard copy url input',
@@ -9,6 +9,24 @@
 // Run 'make gen-cue' from repository root to regenerate.
 
 export interface PlaylistItem {
+  /**
+   * Kiosk mode the dashboards of the item are shown in. Defaults to the mode
+   * the playlist was started in.
+   */
+  kiosk?: ('tv' | 'full');
+  /**
+   * Refresh interval of the dashboards of the item, e.g. 1m.
+   */
+  refresh?: string;
+  /**
+   * Time range start shown for the dashboards of the item, e.g. now-6h. Defaults to the
+   * time range saved with the dashboard.
+   */
+  timeFrom?: string;
+  /**
+   * Time range end shown for the dashboards of the item, e.g. now.
+   */
+  timeTo?: string;
   /**
    * Title is an unused property -- it will be removed in the future
    */
@@ -16,7 +34,7 @@ export interface PlaylistItem {
   /**
    * Type of the item.
    */
-  type: ('dashboard_by_uid' | 'dashboard_by_id' | 'dashboard_by_tag');
+  type: ('dashboard_by_uid' | 'dashboard_by_id' | 'dashboard_by_tag' | 'dashboard_by_search');
   /**
    * Value depends on type and describes the playlist item.
    * 
@@ -26,6 +44,10 @@ export interface PlaylistItem {
    *  - dashboard_by_tag: The value is a tag which is set on any number of dashboards. All
    *  dashboards behind the tag will be added to the playlist.
    *  - dashboard_by_uid: The value is the dashboard UID
+   *  - dashboard_by_search: The value is a URL encoded search query. All dashboards
+   *  matching every parameter will be added to the playlist. The parameters are
+   *  query (title), tag, folderUid and starredByTeamId (dashboards starred by any
+   *  member of the team), and tag and folderUid can be repeated.
    */
   value: string;
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/grafana/grafana/pkg/models"
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
 	"github.com/grafana/grafana/pkg/services/annotations"
+	"github.com/grafana/grafana/pkg/services/comments/commentmodel"
 	"github.com/grafana/grafana/pkg/services/dashboards"
 	"github.com/grafana/grafana/pkg/services/featuremgmt"
 	"github.com/grafana/grafana/pkg/services/guardian"
@@ -45,6 +46,11 @@ func (hs *HTTPServer) GetAnnotations(c *models.ReqContext) response.Response {
 		Tags:         c.QueryStrings("tags"),
 		Type:         c.Query("type"),
 		MatchAny:     c.QueryBool("matchAny"),
+		Severities:   c.QueryStrings("severity"),
+		Link:         c.Query("link"),
+		OwnerId:      c.QueryInt64("ownerId"),
+		Text:         c.Query("text"),
+		TagRegex:     c.Query("tagRegex"),
 		SignedInUser: c.SignedInUser,
 	}
 
@@ -65,7 +71,7 @@ func (hs *HTTPServer) GetAnnotations(c *models.ReqContext) response.Response {
 
 	items, err := hs.annotationsRepo.Find(c.Req.Context(), query)
 	if err != nil {
-		return response.Error(500, "Failed to get annotations", err)
+		return response.ErrOrFallback(500, "Failed to get annotations", err)
 	}
 
 	// since there are several annotations per dashboard, we can cache dashboard uid
@@ -89,9 +95,56 @@ func (hs *HTTPServer) GetAnnotations(c *models.ReqContext) response.Response {
 		}
 	}
 
+	if err := hs.setAnnotationCommentStats(c, items); err != nil {
+		return response.Error(500, "Failed to get annotation comments", err)
+	}
+
 	return response.JSON(http.StatusOK, items)
 }
 
+// setAnnotationCommentStats sets the number of comments and the last activity of the annotations, if the annotation
+// comments are enabled.
+func (hs *HTTPServer) setAnnotationCommentStats(c *models.ReqContext, items []*annotations.ItemDTO) error {
+	if !hs.Features.IsEnabled(featuremgmt.FlagAnnotationComments) || len(items) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		// The annotations of external stores don't have an ID.
+		if item.Id != 0 {
+			ids = append(ids, strconv.FormatInt(item.Id, 10))
+		}
+	}
+	stats, err := hs.commentsService.GetStats(c.Req.Context(), c.OrgID, commentmodel.ObjectTypeAnnotation, ids)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if s, ok := stats[strconv.FormatInt(item.Id, 10)]; ok {
+			item.CommentCount = s.Count
+			item.LastActivity = s.LastActivity * 1000
+		}
+	}
+	return nil
+}
+
+// validateAnnotationOwner returns an error response if the owner of an annotation isn't a member of the
+// organization.
+func (hs *HTTPServer) validateAnnotationOwner(c *models.ReqContext, ownerID int64) response.Response {
+	if ownerID == 0 {
+		return nil
+	}
+	owner, err := hs.userService.GetSignedInUserWithCacheCtx(c.Req.Context(), &user.GetSignedInUserQuery{UserID: ownerID, OrgID: c.OrgID})
+	if err != nil && !errors.Is(err, user.ErrUserNotFound) {
+		return response.Error(http.StatusInternalServerError, "Failed to get the owner of the annotation", err)
+	}
+	if owner == nil || owner.OrgID != c.OrgID {
+		return response.Error(http.StatusBadRequest, "The owner of the annotation must be a member of the organization", err)
+	}
+	return nil
+}
+
 type AnnotationError struct {
 	message string
 }
@@ -138,6 +191,10 @@ func (hs *HTTPServer) PostAnnotation(c *models.ReqContext) response.Response {
 		return response.Error(400, "Failed to save annotation", err)
 	}
 
+	if resp := hs.validateAnnotationOwner(c, cmd.OwnerID); resp != nil {
+		return resp
+	}
+
 	item := annotations.Item{
 		OrgId:       c.OrgID,
 		UserId:      c.UserID,
@@ -148,6 +205,9 @@ func (hs *HTTPServer) PostAnnotation(c *models.ReqContext) response.Response {
 		Text:        cmd.Text,
 		Data:        cmd.Data,
 		Tags:        cmd.Tags,
+		Severity:    cmd.Severity,
+		Links:       cmd.Links,
+		OwnerId:     cmd.OwnerID,
 	}
 
 	if err := hs.annotationsRepo.Save(c.Req.Context(), &item); err != nil {
@@ -270,6 +330,10 @@ func (hs *HTTPServer) UpdateAnnotation(c *models.ReqContext) response.Response {
 		return dashboardGuardianResponse(err)
 	}
 
+	if resp := hs.validateAnnotationOwner(c, cmd.OwnerID); resp != nil {
+		return resp
+	}
+
 	item := annotations.Item{
 		OrgId:    c.OrgID,
 		UserId:   c.UserID,
@@ -278,6 +342,9 @@ func (hs *HTTPServer) UpdateAnnotation(c *models.ReqContext) response.Response {
 		EpochEnd: cmd.TimeEnd,
 		Text:     cmd.Text,
 		Tags:     cmd.Tags,
+		Severity: cmd.Severity,
+		Links:    cmd.Links,
+		OwnerId:  cmd.OwnerID,
 	}
 
 	if err := hs.annotationsRepo.Update(c.Req.Context(), &item); err != nil {
@@ -328,12 +395,30 @@ func (hs *HTTPServer) PatchAnnotation(c *models.ReqContext) response.Response {
 		EpochEnd: annotation.TimeEnd,
 		Text:     annotation.Text,
 		Tags:     annotation.Tags,
+		Severity: annotation.Severity,
+		Links:    annotation.Links,
+		OwnerId:  annotation.OwnerId,
 	}
 
 	if cmd.Tags != nil {
 		existing.Tags = cmd.Tags
 	}
 
+	if cmd.Severity != "" {
+		existing.Severity = cmd.Severity
+	}
+
+	if cmd.Links != nil {
+		existing.Links = cmd.Links
+	}
+
+	if cmd.OwnerID != 0 && cmd.OwnerID != existing.OwnerId {
+		if resp := hs.validateAnnotationOwner(c, cmd.OwnerID); resp != nil {
+			return resp
+		}
+		existing.OwnerId = cmd.OwnerID
+	}
+
 	if cmd.Text != "" && cmd.Text != existing.Text {
 		existing.Text = cmd.Text
 	}
@@ -452,6 +537,10 @@ func (hs *HTTPServer) GetAnnotationByID(c *models.ReqContext) response.Response
 		annotation.AvatarUrl = dtos.GetGravatarUrl(annotation.Email)
 	}
 
+	if err := hs.setAnnotationCommentStats(c, []*annotations.ItemDTO{annotation}); err != nil {
+		return response.Error(500, "Failed to get annotation comments", err)
+	}
+
 	return response.JSON(200, annotation)
 }
 
@@ -703,6 +792,28 @@ type GetAnnotationsParams struct {
 	// in:query
 	// required:false
 	MatchAny bool `json:"matchAny"`
+	// Find incident annotations with any of the severities.
+	// in:query
+	// required:false
+	// type: array
+	// collectionFormat: multi
+	Severity []string `json:"severity"`
+	// Find incident annotations with a link.
+	// in:query
+	// required:false
+	Link string `json:"link"`
+	// Find incident annotations owned by a user.
+	// in:query
+	// required:false
+	OwnerID int64 `json:"ownerId"`
+	// Find annotations whose text contains all the words, ignoring case.
+	// in:query
+	// required:false
+	Text string `json:"text"`
+	// Find annotations with a tag that matches the regular expression, the tags are matched as key:value.
+	// in:query
+	// required:false
+	TagRegex string `json:"tagRegex"`
 }
 
 // swagger:parameters getAnnotationTags
@@ -162,6 +162,13 @@ func (hs *HTTPServer) registerRoutes() {
 			publicdashboardsapi.CountPublicDashboardRequest(),
 			hs.Index,
 		)
+
+		// anonymous confirmation of the magic link of a public dashboard shared with emails
+		r.Get("/public-dashboards/:accessToken/verify",
+			publicdashboardsapi.SetPublicDashboardFlag,
+			publicdashboardsapi.SetPublicDashboardOrgIdOnContext(hs.PublicDashboardsApi.PublicDashboardService),
+			hs.Index,
+		)
 	}
 
 	r.Get("/explore", authorize(func(c *models.ReqContext) {
@@ -481,6 +488,9 @@ func (hs *HTTPServer) registerRoutes() {
 				}
 			})
 
+			dashboardRoute.Post("/bulk", authorize(reqSignedIn, ac.EvalAny(ac.EvalPermission(dashboards.ActionDashboardsWrite), ac.EvalPermission(dashboards.ActionDashboardsDelete), ac.EvalPermission(dashboards.ActionDashboardsPermissionsWrite))), routing.Wrap(hs.BulkDashboards))
+			dashboardRoute.Get("/bulk/:jobUid", reqSignedIn, routing.Wrap(hs.GetBulkDashboardsJob))
+
 			dashboardRoute.Post("/calculate-diff", authorize(reqSignedIn, ac.EvalPermission(dashboards.ActionDashboardsWrite)), routing.Wrap(hs.CalculateDashboardDiff))
 			dashboardRoute.Post("/validate", authorize(reqSignedIn, ac.EvalPermission(dashboards.ActionDashboardsWrite)), routing.Wrap(hs.ValidateDashboard))
 			dashboardRoute.Post("/trim", routing.Wrap(hs.TrimDashboard))
@@ -693,4 +703,6 @@ func (hs *HTTPServer) registerRoutes() {
 	r.Get("/api/snapshots/:key", routing.Wrap(hs.GetDashboardSnapshot))
 	r.Get("/api/snapshots-delete/:deleteKey", reqSnapshotPublicModeOrSignedIn, routing.Wrap(hs.DeleteDashboardSnapshotByDeleteKey))
 	r.Delete("/api/snapshots/:key", reqSignedIn, routing.Wrap(hs.DeleteDashboardSnapshot))
+	r.Get("/api/snapshots/:key/export", reqSignedIn, routing.Wrap(hs.ExportDashboardSnapshot))
+	r.Post("/api/snapshots/import", reqSignedIn, routing.Wrap(hs.ImportDashboardSnapshot))
 }
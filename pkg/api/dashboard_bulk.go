@@ -0,0 +1,584 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/infra/kvstore"
+	"github.com/grafana/grafana/pkg/models"
+	"github.com/grafana/grafana/pkg/services/alerting"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/folder"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/services/search"
+	"github.com/grafana/grafana/pkg/services/user"
+	"github.com/grafana/grafana/pkg/util"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+const (
+	// maxBulkDashboards is the maximum number of dashboards a bulk operation can change
+	maxBulkDashboards = 1000
+	// maxBulkDashboardJobsPerUser is the maximum number of bulk operations a user can run at once in an organization
+	maxBulkDashboardJobsPerUser = 2
+	// bulkDashboardJobRetention is how long the progress of a finished bulk operation is kept
+	bulkDashboardJobRetention = time.Hour
+	// bulkDashboardJobSaveInterval is how often the progress of a running bulk operation is saved
+	bulkDashboardJobSaveInterval = time.Second
+	// bulkDashboardJobStaleAfter is how long after its last save a running bulk operation is considered interrupted,
+	// when the instance running it stopped
+	bulkDashboardJobStaleAfter = 5 * time.Minute
+	// bulkDashboardJobsNamespace is the namespace of the progress of the bulk operations in the kvstore
+	bulkDashboardJobsNamespace = "dashboard-bulk-jobs"
+)
+
+var (
+	errBulkDashboardAccessDenied = errors.New("access denied")
+	errBulkDashboardNotFound     = errors.New("dashboard not found")
+	errBulkDashboardIsFolder     = errors.New("not a dashboard")
+	errBulkDashboardOwnAdmin     = errors.New("cannot remove own admin permission")
+	errBulkDashboardTooManyJobs  = errors.New("too many bulk operations running")
+)
+
+// dashboardBulkJobs saves the progress of the bulk operations in the kvstore, so that every instance can report
+// it, and it's kept when an instance restarts.
+type dashboardBulkJobs struct {
+	kv kvstore.KVStore
+	// mu serializes the count of the running operations of a user with the save of a new one on this instance
+	mu sync.Mutex
+}
+
+func newDashboardBulkJobs(kv kvstore.KVStore) *dashboardBulkJobs {
+	return &dashboardBulkJobs{kv: kv}
+}
+
+// storedDashboardBulkJob is the progress of a bulk operation saved in the kvstore
+type storedDashboardBulkJob struct {
+	UserID  int64                 `json:"userId"`
+	Updated time.Time             `json:"updated"`
+	Status  dtos.BulkDashboardJob `json:"status"`
+}
+
+// add saves a new bulk operation, unless the user already runs too many. It drops the operations of the
+// organization that finished a while ago.
+func (j *dashboardBulkJobs) add(ctx context.Context, job *dashboardBulkJob) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	all, err := j.kv.GetAll(ctx, job.orgID, bulkDashboardJobsNamespace)
+	if err != nil {
+		return err
+	}
+	running := 0
+	for uid, value := range all[job.orgID] {
+		stored := storedDashboardBulkJob{}
+		if err := json.Unmarshal([]byte(value), &stored); err != nil || time.Since(stored.Updated) > bulkDashboardJobRetention {
+			if err := j.kv.Del(ctx, job.orgID, bulkDashboardJobsNamespace, uid); err != nil {
+				return err
+			}
+			continue
+		}
+		if stored.UserID == job.userID && stored.Status.State == dtos.BulkDashboardJobRunning && time.Since(stored.Updated) <= bulkDashboardJobStaleAfter {
+			running++
+		}
+	}
+	if running >= maxBulkDashboardJobsPerUser {
+		return errBulkDashboardTooManyJobs
+	}
+	return j.save(ctx, job)
+}
+
+// save saves the progress of a bulk operation
+func (j *dashboardBulkJobs) save(ctx context.Context, job *dashboardBulkJob) error {
+	value, err := json.Marshal(storedDashboardBulkJob{UserID: job.userID, Updated: time.Now(), Status: job.getStatus()})
+	if err != nil {
+		return err
+	}
+	return j.kv.Set(ctx, job.orgID, bulkDashboardJobsNamespace, job.status.UID, string(value))
+}
+
+// get returns the progress of a bulk operation of a user, or nil when it isn't found. A running operation that
+// wasn't saved in a while was interrupted with the instance running it.
+func (j *dashboardBulkJobs) get(ctx context.Context, orgID int64, userID int64, uid string) (*dtos.BulkDashboardJob, error) {
+	value, ok, err := j.kv.Get(ctx, orgID, bulkDashboardJobsNamespace, uid)
+	if err != nil || !ok {
+		return nil, err
+	}
+	stored := storedDashboardBulkJob{}
+	if err := json.Unmarshal([]byte(value), &stored); err != nil {
+		return nil, err
+	}
+	if stored.UserID != userID {
+		return nil, nil
+	}
+	if stored.Status.State == dtos.BulkDashboardJobRunning && time.Since(stored.Updated) > bulkDashboardJobStaleAfter {
+		stored.Status.State = dtos.BulkDashboardJobInterrupted
+	}
+	return &stored.Status, nil
+}
+
+type dashboardBulkJob struct {
+	orgID  int64
+	userID int64
+	cmd    dtos.BulkDashboardCommand
+	uids   []string
+	// folderID is the folder the dashboards are moved to
+	folderID int64
+
+	statusMu sync.Mutex
+	status   dtos.BulkDashboardJob
+}
+
+func (job *dashboardBulkJob) getStatus() dtos.BulkDashboardJob {
+	job.statusMu.Lock()
+	defer job.statusMu.Unlock()
+
+	status := job.status
+	status.Errors = append([]dtos.BulkDashboardItemError{}, job.status.Errors...)
+	return status
+}
+
+func (job *dashboardBulkJob) itemDone(uid string, title string, err string) {
+	job.statusMu.Lock()
+	defer job.statusMu.Unlock()
+
+	job.status.Processed++
+	if err == "" {
+		job.status.Succeeded++
+		return
+	}
+	job.status.Failed++
+	job.status.Errors = append(job.status.Errors, dtos.BulkDashboardItemError{UID: uid, Title: title, Error: err})
+}
+
+func (job *dashboardBulkJob) finish(state dtos.BulkDashboardJobState) {
+	job.statusMu.Lock()
+	defer job.statusMu.Unlock()
+
+	finished := time.Now()
+	job.status.State = state
+	job.status.Finished = &finished
+}
+
+// swagger:route POST /dashboards/bulk dashboards bulkDashboards
+//
+// Change many dashboards.
+//
+// Moves, tags, deletes or sets the permissions of the dashboards with the given UIDs, or matching a search, in the
+// background. Each dashboard is changed with the permissions of the user, and provisioned dashboards are protected
+// like when they are changed one by one. Use the returned job UID to follow the progress of the operation.
+//
+// Responses:
+// 202: bulkDashboardsResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 429: tooManyRequestsError
+// 500: internalServerError
+func (hs *HTTPServer) BulkDashboards(c *models.ReqContext) response.Response {
+	cmd := dtos.BulkDashboardCommand{}
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	ctx := c.Req.Context()
+
+	job := &dashboardBulkJob{
+		orgID:  c.OrgID,
+		userID: c.UserID,
+		cmd:    cmd,
+	}
+
+	switch cmd.Operation {
+	case dtos.BulkDashboardMove:
+		if cmd.FolderUID != "" && cmd.FolderUID != folder.GeneralFolderUID {
+			f, err := hs.folderService.Get(ctx, &folder.GetFolderQuery{OrgID: c.OrgID, UID: &cmd.FolderUID, SignedInUser: c.SignedInUser})
+			if err != nil {
+				if errors.Is(err, dashboards.ErrFolderNotFound) || errors.Is(err, dashboards.ErrFolderAccessDenied) {
+					return response.Error(http.StatusBadRequest, "Folder not found", err)
+				}
+				return response.Error(http.StatusInternalServerError, "Error while checking folder ID", err)
+			}
+			job.folderID = f.ID
+		}
+	case dtos.BulkDashboardAddTags, dtos.BulkDashboardRemoveTags:
+		if len(cmd.Tags) == 0 {
+			return response.Error(http.StatusBadRequest, "No tags to add or remove", nil)
+		}
+	case dtos.BulkDashboardDelete:
+	case dtos.BulkDashboardSetPermissions:
+		if err := validatePermissionsUpdate(dtos.UpdateDashboardACLCommand{Items: cmd.Permissions}); err != nil {
+			return response.Error(http.StatusBadRequest, err.Error(), err)
+		}
+	default:
+		return response.Error(http.StatusBadRequest, fmt.Sprintf("Unknown operation %q", cmd.Operation), nil)
+	}
+
+	if (len(cmd.UIDs) > 0) == (cmd.Search != nil) {
+		return response.Error(http.StatusBadRequest, "Either dashboard UIDs or a search are required", nil)
+	}
+	if len(cmd.UIDs) > 0 {
+		job.uids = cmd.UIDs
+	} else {
+		uids, rsp := hs.searchBulkDashboards(ctx, c.OrgID, c.SignedInUser, cmd.Search)
+		if rsp != nil {
+			return rsp
+		}
+		job.uids = uids
+	}
+	if len(job.uids) > maxBulkDashboards {
+		return response.Error(http.StatusBadRequest, fmt.Sprintf("Cannot change more than %d dashboards at once", maxBulkDashboards), nil)
+	}
+
+	job.status = dtos.BulkDashboardJob{
+		UID:       util.GenerateShortUID(),
+		Operation: cmd.Operation,
+		State:     dtos.BulkDashboardJobRunning,
+		Total:     len(job.uids),
+		Started:   time.Now(),
+		Errors:    []dtos.BulkDashboardItemError{},
+	}
+	if err := hs.dashboardBulkJobs.add(ctx, job); err != nil {
+		if errors.Is(err, errBulkDashboardTooManyJobs) {
+			return response.Error(http.StatusTooManyRequests, fmt.Sprintf("Cannot run more than %d bulk operations at once", maxBulkDashboardJobsPerUser), err)
+		}
+		return response.Error(http.StatusInternalServerError, "Failed to save the bulk operation", err)
+	}
+
+	// the job outlives the request, and is interrupted when the server shuts down
+	go hs.runBulkDashboardJob(hs.context, c.SignedInUser, job)
+
+	return response.JSON(http.StatusAccepted, job.getStatus())
+}
+
+// swagger:route GET /dashboards/bulk/{jobUid} dashboards getBulkDashboardsJob
+//
+// Get the progress of a bulk operation on dashboards.
+//
+// Only the user who started the operation can follow it.
+//
+// Responses:
+// 200: bulkDashboardsResponse
+// 401: unauthorisedError
+// 404: notFoundError
+// 500: internalServerError
+func (hs *HTTPServer) GetBulkDashboardsJob(c *models.ReqContext) response.Response {
+	status, err := hs.dashboardBulkJobs.get(c.Req.Context(), c.OrgID, c.UserID, web.Params(c.Req)[":jobUid"])
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to get the bulk operation", err)
+	}
+	if status == nil {
+		return response.Error(http.StatusNotFound, "Bulk operation not found", nil)
+	}
+
+	return response.JSON(http.StatusOK, status)
+}
+
+// searchBulkDashboards returns the UIDs of the dashboards matching the search of a bulk operation.
+// It returns one more dashboard than the limit of a bulk operation when there are too many.
+func (hs *HTTPServer) searchBulkDashboards(ctx context.Context, orgID int64, signedInUser *user.SignedInUser, s *dtos.BulkDashboardSearch) ([]string, response.Response) {
+	if s.Query == "" && len(s.Tags) == 0 && len(s.FolderUIDs) == 0 && !s.Starred {
+		return nil, response.Error(http.StatusBadRequest, "The search must have at least one parameter", nil)
+	}
+
+	searchQuery := search.Query{
+		Title:        s.Query,
+		Tags:         s.Tags,
+		IsStarred:    s.Starred,
+		SignedInUser: signedInUser,
+		OrgId:        orgID,
+		Type:         string(models.DashHitDB),
+		Limit:        maxBulkDashboards + 1,
+		Permission:   models.PERMISSION_EDIT,
+	}
+	for i := range s.FolderUIDs {
+		if s.FolderUIDs[i] == folder.GeneralFolderUID {
+			searchQuery.FolderIds = append(searchQuery.FolderIds, 0)
+			continue
+		}
+		f, err := hs.folderService.Get(ctx, &folder.GetFolderQuery{OrgID: orgID, UID: &s.FolderUIDs[i], SignedInUser: signedInUser})
+		if err != nil {
+			if errors.Is(err, dashboards.ErrFolderNotFound) || errors.Is(err, dashboards.ErrFolderAccessDenied) {
+				return nil, response.Error(http.StatusBadRequest, "Folder not found", err)
+			}
+			return nil, response.Error(http.StatusInternalServerError, "Error while checking folder ID", err)
+		}
+		searchQuery.FolderIds = append(searchQuery.FolderIds, f.ID)
+	}
+
+	if err := hs.SearchService.SearchHandler(ctx, &searchQuery); err != nil {
+		return nil, response.Error(http.StatusInternalServerError, "Search failed", err)
+	}
+
+	uids := make([]string, 0, len(searchQuery.Result))
+	for _, hit := range searchQuery.Result {
+		uids = append(uids, hit.UID)
+	}
+	return uids, nil
+}
+
+// runBulkDashboardJob runs a bulk operation until it's done or its context is canceled, and saves its progress
+// regularly.
+func (hs *HTTPServer) runBulkDashboardJob(ctx context.Context, signedInUser *user.SignedInUser, job *dashboardBulkJob) {
+	state := dtos.BulkDashboardJobDone
+	defer func() {
+		if err := recover(); err != nil {
+			hs.log.Error("Bulk dashboard operation panic", "job", job.status.UID, "error", err, "trace", string(debug.Stack()))
+		}
+		job.finish(state)
+		// the context may be canceled, the final progress is saved anyway
+		saveCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := hs.dashboardBulkJobs.save(saveCtx, job); err != nil {
+			hs.log.Error("Failed to save the progress of a bulk dashboard operation", "job", job.status.UID, "error", err)
+		}
+	}()
+
+	saved := time.Now()
+	for _, uid := range job.uids {
+		if ctx.Err() != nil {
+			hs.log.Warn("Bulk dashboard operation interrupted", "job", job.status.UID, "error", ctx.Err())
+			state = dtos.BulkDashboardJobInterrupted
+			return
+		}
+		title, err := hs.bulkDashboardItem(ctx, signedInUser, job, uid)
+		job.itemDone(uid, title, hs.bulkDashboardError(job, uid, err))
+
+		if time.Since(saved) >= bulkDashboardJobSaveInterval {
+			if err := hs.dashboardBulkJobs.save(ctx, job); err != nil {
+				hs.log.Warn("Failed to save the progress of a bulk dashboard operation", "job", job.status.UID, "error", err)
+			}
+			saved = time.Now()
+		}
+	}
+}
+
+// bulkDashboardItem runs the operation of a bulk job on one dashboard, and returns its title
+func (hs *HTTPServer) bulkDashboardItem(ctx context.Context, signedInUser *user.SignedInUser, job *dashboardBulkJob, uid string) (string, error) {
+	query := models.GetDashboardQuery{Uid: uid, OrgId: job.orgID}
+	if err := hs.DashboardService.GetDashboard(ctx, &query); err != nil {
+		if errors.Is(err, dashboards.ErrDashboardNotFound) {
+			return "", errBulkDashboardNotFound
+		}
+		return "", err
+	}
+	dash := query.Result
+
+	// the title is only reported to users who can view the dashboard
+	g := guardian.New(ctx, dash.Id, dash.OrgId, signedInUser)
+	if canView, err := g.CanView(); err != nil || !canView {
+		return "", bulkDashboardGuardianError(err)
+	}
+	if dash.IsFolder {
+		return dash.Title, errBulkDashboardIsFolder
+	}
+
+	switch job.cmd.Operation {
+	case dtos.BulkDashboardMove:
+		if dash.FolderId == job.folderID {
+			return dash.Title, nil
+		}
+		dash.FolderId = job.folderID
+		return dash.Title, hs.saveBulkDashboard(ctx, signedInUser, dash, "Moved with a bulk operation")
+	case dtos.BulkDashboardAddTags, dtos.BulkDashboardRemoveTags:
+		tags := bulkDashboardTags(dash.GetTags(), job.cmd.Tags, job.cmd.Operation == dtos.BulkDashboardAddTags)
+		if len(tags) == len(dash.GetTags()) {
+			return dash.Title, nil
+		}
+		tagsJSON := make([]interface{}, 0, len(tags))
+		for _, tag := range tags {
+			tagsJSON = append(tagsJSON, tag)
+		}
+		dash.Data.Set("tags", tagsJSON)
+		return dash.Title, hs.saveBulkDashboard(ctx, signedInUser, dash, "Tags changed with a bulk operation")
+	case dtos.BulkDashboardDelete:
+		return dash.Title, hs.deleteBulkDashboard(ctx, signedInUser, dash)
+	case dtos.BulkDashboardSetPermissions:
+		return dash.Title, hs.setBulkDashboardPermissions(ctx, signedInUser, dash, job.cmd.Permissions)
+	}
+	return dash.Title, nil
+}
+
+// bulkDashboardTags adds or removes tags, keeping the order of the existing ones
+func bulkDashboardTags(existing []string, tags []string, add bool) []string {
+	changed := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		changed[tag] = true
+	}
+
+	result := make([]string, 0, len(existing)+len(tags))
+	for _, tag := range existing {
+		if add {
+			delete(changed, tag)
+		} else if changed[tag] {
+			continue
+		}
+		result = append(result, tag)
+	}
+	if add {
+		for _, tag := range tags {
+			if changed[tag] {
+				result = append(result, tag)
+				delete(changed, tag)
+			}
+		}
+	}
+	return result
+}
+
+// saveBulkDashboard saves a dashboard like POST /api/dashboards/db, so provisioned dashboards can only be
+// changed when their provisioner allows UI updates
+func (hs *HTTPServer) saveBulkDashboard(ctx context.Context, signedInUser *user.SignedInUser, dash *models.Dashboard, message string) error {
+	provisioningData, err := hs.dashboardProvisioningService.GetProvisionedDashboardDataByDashboardID(ctx, dash.Id)
+	if err != nil {
+		return err
+	}
+	allowUiUpdate := true
+	if provisioningData != nil {
+		allowUiUpdate = hs.ProvisioningService.GetAllowUIUpdatesFromConfig(provisioningData.Name)
+	}
+
+	dashboard, err := hs.DashboardService.SaveDashboard(alerting.WithUAEnabled(ctx, hs.Cfg.UnifiedAlerting.IsEnabled()), &dashboards.SaveDashboardDTO{
+		Dashboard: dash,
+		Message:   message,
+		OrgId:     dash.OrgId,
+		User:      signedInUser,
+	}, allowUiUpdate)
+	if err != nil {
+		return err
+	}
+
+	if hs.Live != nil {
+		if err := hs.Live.GrafanaScope.Dashboards.DashboardSaved(dash.OrgId, signedInUser.ToUserDisplayDTO(), message, dashboard, nil); err != nil {
+			hs.log.Warn("unable to broadcast save event", "uid", dashboard.Uid, "error", err)
+		}
+	}
+	return nil
+}
+
+// deleteBulkDashboard deletes a dashboard like DELETE /api/dashboards/uid/:uid
+func (hs *HTTPServer) deleteBulkDashboard(ctx context.Context, signedInUser *user.SignedInUser, dash *models.Dashboard) error {
+	g := guardian.New(ctx, dash.Id, dash.OrgId, signedInUser)
+	if canDelete, err := g.CanDelete(); err != nil || !canDelete {
+		return bulkDashboardGuardianError(err)
+	}
+
+	if err := hs.LibraryElementService.DisconnectElementsFromDashboard(ctx, dash.Id); err != nil {
+		hs.log.Error("Failed to disconnect library elements", "dashboard", dash.Id, "user", signedInUser.UserID, "error", err)
+	}
+
+	if err := hs.DashboardService.DeleteDashboard(ctx, dash.Id, dash.OrgId); err != nil {
+		return err
+	}
+
+	if hs.Live != nil {
+		if err := hs.Live.GrafanaScope.Dashboards.DashboardDeleted(dash.OrgId, signedInUser.ToUserDisplayDTO(), dash.Uid); err != nil {
+			hs.log.Error("Failed to broadcast delete info", "dashboard", dash.Uid, "error", err)
+		}
+	}
+	return nil
+}
+
+// setBulkDashboardPermissions replaces the permissions of a dashboard like POST /api/dashboards/uid/:uid/permissions
+func (hs *HTTPServer) setBulkDashboardPermissions(ctx context.Context, signedInUser *user.SignedInUser, dash *models.Dashboard, permissions []dtos.DashboardACLUpdateItem) error {
+	g := guardian.New(ctx, dash.Id, dash.OrgId, signedInUser)
+	if canAdmin, err := g.CanAdmin(); err != nil || !canAdmin {
+		return bulkDashboardGuardianError(err)
+	}
+
+	items := make([]*models.DashboardACL, 0, len(permissions))
+	for _, item := range permissions {
+		items = append(items, &models.DashboardACL{
+			OrgID:       dash.OrgId,
+			DashboardID: dash.Id,
+			UserID:      item.UserID,
+			TeamID:      item.TeamID,
+			Role:        item.Role,
+			Permission:  item.Permission,
+			Created:     time.Now(),
+			Updated:     time.Now(),
+		})
+	}
+
+	hiddenACL, err := g.GetHiddenACL(hs.Cfg)
+	if err != nil {
+		return err
+	}
+	items = append(items, hiddenACL...)
+
+	if okToUpdate, err := g.CheckPermissionBeforeUpdate(models.PERMISSION_ADMIN, items); err != nil || !okToUpdate {
+		if err != nil {
+			return err
+		}
+		return errBulkDashboardOwnAdmin
+	}
+
+	if !hs.AccessControl.IsDisabled() {
+		old, err := g.GetACL()
+		if err != nil {
+			return err
+		}
+		return hs.updateDashboardAccessControl(ctx, dash.OrgId, dash.Uid, false, items, old)
+	}
+
+	return hs.DashboardService.UpdateDashboardACL(ctx, dash.Id, items)
+}
+
+func bulkDashboardGuardianError(err error) error {
+	if err != nil {
+		return err
+	}
+	return errBulkDashboardAccessDenied
+}
+
+// bulkDashboardError returns the message of the error of a bulk operation on a dashboard, without the details of
+// internal errors
+func (hs *HTTPServer) bulkDashboardError(job *dashboardBulkJob, uid string, err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var dashboardErr dashboards.DashboardErr
+	var pluginErr dashboards.UpdatePluginDashboardError
+	switch {
+	case errors.As(err, &dashboardErr):
+		return dashboardErr.Error()
+	case errors.As(err, &pluginErr):
+		return fmt.Sprintf("The dashboard belongs to plugin %s", pluginErr.PluginId)
+	case errors.Is(err, errBulkDashboardAccessDenied), errors.Is(err, errBulkDashboardNotFound),
+		errors.Is(err, errBulkDashboardIsFolder), errors.Is(err, errBulkDashboardOwnAdmin),
+		errors.Is(err, guardian.ErrGuardianPermissionExists), errors.Is(err, guardian.ErrGuardianOverride),
+		errors.Is(err, dashboards.ErrFolderNotFound), errors.Is(err, dashboards.ErrFolderAccessDenied):
+		return err.Error()
+	}
+
+	hs.log.Error("Bulk dashboard operation failed", "job", job.status.UID, "dashboard", uid, "error", err)
+	return "Internal error"
+}
+
+// swagger:parameters bulkDashboards
+type BulkDashboardsParams struct {
+	// in:body
+	// required:true
+	Body dtos.BulkDashboardCommand
+}
+
+// swagger:parameters getBulkDashboardsJob
+type GetBulkDashboardsJobParams struct {
+	// in:path
+	// required:true
+	JobUID string `json:"jobUid"`
+}
+
+// swagger:response bulkDashboardsResponse
+type BulkDashboardsResponse struct {
+	// in: body
+	Body dtos.BulkDashboardJob `json:"body"`
+}
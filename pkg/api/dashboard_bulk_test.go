@@ -0,0 +1,296 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/infra/kvstore"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/models"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/services/provisioning"
+	"github.com/grafana/grafana/pkg/services/user"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+// bulkProvisioningService reports the dashboards with the given IDs as provisioned
+type bulkProvisioningService struct {
+	dashboards.DashboardProvisioningService
+	provisioned map[int64]string
+}
+
+func (s bulkProvisioningService) GetProvisionedDashboardDataByDashboardID(_ context.Context, dashboardID int64) (*models.DashboardProvisioning, error) {
+	if name, ok := s.provisioned[dashboardID]; ok {
+		return &models.DashboardProvisioning{DashboardId: dashboardID, Name: name}, nil
+	}
+	return nil, nil
+}
+
+func bulkRequest(t *testing.T, cmd dtos.BulkDashboardCommand) *models.ReqContext {
+	t.Helper()
+
+	body, err := json.Marshal(cmd)
+	require.NoError(t, err)
+	httpReq, err := http.NewRequest(http.MethodPost, "/api/dashboards/bulk", bytes.NewReader(body))
+	require.NoError(t, err)
+	httpReq.Header.Add("Content-Type", "application/json")
+
+	signedInUser := &user.SignedInUser{UserID: 1, OrgID: 1}
+	return &models.ReqContext{SignedInUser: signedInUser, Context: &web.Context{Req: httpReq}}
+}
+
+func TestBulkDashboardsValidation(t *testing.T) {
+	hs := &HTTPServer{Cfg: setting.NewCfg(), log: log.New("test"), dashboardBulkJobs: newDashboardBulkJobs(nil)}
+
+	tests := []struct {
+		name string
+		cmd  dtos.BulkDashboardCommand
+	}{
+		{name: "unknown operation", cmd: dtos.BulkDashboardCommand{UIDs: []string{"a"}, Operation: "rename"}},
+		{name: "no dashboards", cmd: dtos.BulkDashboardCommand{Operation: dtos.BulkDashboardDelete}},
+		{name: "both UIDs and a search", cmd: dtos.BulkDashboardCommand{UIDs: []string{"a"}, Search: &dtos.BulkDashboardSearch{Query: "cpu"}, Operation: dtos.BulkDashboardDelete}},
+		{name: "empty search", cmd: dtos.BulkDashboardCommand{Search: &dtos.BulkDashboardSearch{}, Operation: dtos.BulkDashboardDelete}},
+		{name: "no tags", cmd: dtos.BulkDashboardCommand{UIDs: []string{"a"}, Operation: dtos.BulkDashboardAddTags}},
+		{name: "invalid permissions", cmd: dtos.BulkDashboardCommand{UIDs: []string{"a"}, Operation: dtos.BulkDashboardSetPermissions, Permissions: []dtos.DashboardACLUpdateItem{{UserID: 1, TeamID: 2, Permission: models.PERMISSION_VIEW}}}},
+		{name: "too many dashboards", cmd: dtos.BulkDashboardCommand{UIDs: make([]string, maxBulkDashboards+1), Operation: dtos.BulkDashboardDelete}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := hs.BulkDashboards(bulkRequest(t, tt.cmd))
+			assert.Equal(t, http.StatusBadRequest, resp.Status())
+		})
+	}
+}
+
+func TestBulkDashboardsJob(t *testing.T) {
+	signedInUser := &user.SignedInUser{UserID: 1, OrgID: 1}
+	dashboardsByUID := map[string]*models.Dashboard{
+		"one":         {Id: 1, Uid: "one", OrgId: 1, Title: "One", Data: simplejson.NewFromAny(map[string]interface{}{"tags": []interface{}{"a"}})},
+		"two":         {Id: 2, Uid: "two", OrgId: 1, Title: "Two", Data: simplejson.NewFromAny(map[string]interface{}{"tags": []interface{}{"a", "b"}})},
+		"provisioned": {Id: 3, Uid: "provisioned", OrgId: 1, Title: "Provisioned", Data: simplejson.New()},
+		"folder":      {Id: 4, Uid: "folder", OrgId: 1, Title: "Folder", IsFolder: true, Data: simplejson.New()},
+	}
+
+	setup := func(t *testing.T) (*HTTPServer, *dashboards.FakeDashboardService) {
+		dashboardService := dashboards.NewFakeDashboardService(t)
+		dashboardService.On("GetDashboard", mock.Anything, mock.AnythingOfType("*models.GetDashboardQuery")).Run(func(args mock.Arguments) {
+			q := args.Get(1).(*models.GetDashboardQuery)
+			if dash, ok := dashboardsByUID[q.Uid]; ok {
+				q.Result = &models.Dashboard{Id: dash.Id, Uid: dash.Uid, OrgId: dash.OrgId, Title: dash.Title, IsFolder: dash.IsFolder, Data: simplejson.NewFromAny(dash.Data.MustMap())}
+			}
+		}).Return(func(_ context.Context, q *models.GetDashboardQuery) error {
+			if q.Result == nil {
+				return dashboards.ErrDashboardNotFound
+			}
+			return nil
+		})
+
+		provisioningService := provisioning.NewProvisioningServiceMock(context.Background())
+		provisioningService.GetAllowUIUpdatesFromConfigFunc = func(name string) bool { return false }
+
+		hs := &HTTPServer{
+			Cfg:                          setting.NewCfg(),
+			log:                          log.New("test"),
+			DashboardService:             dashboardService,
+			ProvisioningService:          provisioningService,
+			dashboardProvisioningService: bulkProvisioningService{provisioned: map[int64]string{3: "default"}},
+			LibraryElementService:        &mockLibraryElementService{},
+			dashboardBulkJobs:            newDashboardBulkJobs(kvstore.ProvideService(db.InitTestDB(t))),
+		}
+		return hs, dashboardService
+	}
+
+	runWithContext := func(t *testing.T, ctx context.Context, hs *HTTPServer, cmd dtos.BulkDashboardCommand) dtos.BulkDashboardJob {
+		job := &dashboardBulkJob{orgID: 1, userID: 1, cmd: cmd, uids: cmd.UIDs}
+		job.status = dtos.BulkDashboardJob{UID: "job", Operation: cmd.Operation, Total: len(cmd.UIDs), Errors: []dtos.BulkDashboardItemError{}}
+		hs.runBulkDashboardJob(ctx, signedInUser, job)
+
+		// the final progress is saved
+		saved, err := hs.dashboardBulkJobs.get(context.Background(), 1, 1, "job")
+		require.NoError(t, err)
+		require.Equal(t, job.getStatus().State, saved.State)
+		require.Equal(t, job.getStatus().Processed, saved.Processed)
+		return job.getStatus()
+	}
+	run := func(hs *HTTPServer, cmd dtos.BulkDashboardCommand) dtos.BulkDashboardJob {
+		return runWithContext(t, context.Background(), hs, cmd)
+	}
+
+	t.Run("adds tags and protects provisioned dashboards", func(t *testing.T) {
+		origNewGuardian := guardian.New
+		t.Cleanup(func() { guardian.New = origNewGuardian })
+		guardian.MockDashboardGuardian(&guardian.FakeDashboardGuardian{CanViewValue: true})
+
+		hs, dashboardService := setup(t)
+		var saved []*models.Dashboard
+		dashboardService.On("SaveDashboard", mock.Anything, mock.AnythingOfType("*dashboards.SaveDashboardDTO"), true).Run(func(args mock.Arguments) {
+			saved = append(saved, args.Get(1).(*dashboards.SaveDashboardDTO).Dashboard)
+		}).Return(&models.Dashboard{}, nil)
+		dashboardService.On("SaveDashboard", mock.Anything, mock.AnythingOfType("*dashboards.SaveDashboardDTO"), false).
+			Return(nil, dashboards.ErrDashboardCannotSaveProvisionedDashboard)
+
+		status := run(hs, dtos.BulkDashboardCommand{
+			UIDs:      []string{"one", "two", "provisioned", "folder", "missing"},
+			Operation: dtos.BulkDashboardAddTags,
+			Tags:      []string{"b"},
+		})
+
+		require.Equal(t, dtos.BulkDashboardJobDone, status.State)
+		require.NotNil(t, status.Finished)
+		assert.Equal(t, 5, status.Processed)
+		assert.Equal(t, 2, status.Succeeded)
+		assert.Equal(t, 3, status.Failed)
+		assert.Equal(t, []dtos.BulkDashboardItemError{
+			{UID: "provisioned", Title: "Provisioned", Error: dashboards.ErrDashboardCannotSaveProvisionedDashboard.Error()},
+			{UID: "folder", Title: "Folder", Error: errBulkDashboardIsFolder.Error()},
+			{UID: "missing", Error: errBulkDashboardNotFound.Error()},
+		}, status.Errors)
+
+		// "two" already has the tag, so only "one" is saved
+		require.Len(t, saved, 1)
+		assert.Equal(t, []string{"a", "b"}, saved[0].GetTags())
+	})
+
+	t.Run("deletes the dashboards the user can delete", func(t *testing.T) {
+		origNewGuardian := guardian.New
+		t.Cleanup(func() { guardian.New = origNewGuardian })
+		guardian.MockDashboardGuardian(&guardian.FakeDashboardGuardian{CanViewValue: true, CanSaveValue: true})
+
+		hs, dashboardService := setup(t)
+		dashboardService.On("DeleteDashboard", mock.Anything, int64(1), int64(1)).Return(nil)
+		dashboardService.On("DeleteDashboard", mock.Anything, int64(3), int64(1)).Return(dashboards.ErrDashboardCannotDeleteProvisionedDashboard)
+
+		status := run(hs, dtos.BulkDashboardCommand{UIDs: []string{"one", "provisioned"}, Operation: dtos.BulkDashboardDelete})
+
+		assert.Equal(t, 1, status.Succeeded)
+		assert.Equal(t, []dtos.BulkDashboardItemError{
+			{UID: "provisioned", Title: "Provisioned", Error: dashboards.ErrDashboardCannotDeleteProvisionedDashboard.Error()},
+		}, status.Errors)
+	})
+
+	t.Run("does not delete without permission", func(t *testing.T) {
+		origNewGuardian := guardian.New
+		t.Cleanup(func() { guardian.New = origNewGuardian })
+		guardian.MockDashboardGuardian(&guardian.FakeDashboardGuardian{CanViewValue: true, CanSaveValue: false})
+
+		hs, dashboardService := setup(t)
+
+		status := run(hs, dtos.BulkDashboardCommand{UIDs: []string{"one"}, Operation: dtos.BulkDashboardDelete})
+
+		assert.Equal(t, []dtos.BulkDashboardItemError{{UID: "one", Title: "One", Error: errBulkDashboardAccessDenied.Error()}}, status.Errors)
+		dashboardService.AssertNotCalled(t, "DeleteDashboard", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("does not report the titles of dashboards the user can't view", func(t *testing.T) {
+		origNewGuardian := guardian.New
+		t.Cleanup(func() { guardian.New = origNewGuardian })
+		guardian.MockDashboardGuardian(&guardian.FakeDashboardGuardian{CanViewValue: false, CanSaveValue: true})
+
+		hs, dashboardService := setup(t)
+
+		status := run(hs, dtos.BulkDashboardCommand{UIDs: []string{"one", "folder"}, Operation: dtos.BulkDashboardAddTags, Tags: []string{"b"}})
+
+		assert.Equal(t, []dtos.BulkDashboardItemError{
+			{UID: "one", Error: errBulkDashboardAccessDenied.Error()},
+			{UID: "folder", Error: errBulkDashboardAccessDenied.Error()},
+		}, status.Errors)
+		dashboardService.AssertNotCalled(t, "SaveDashboard", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("stops when the server shuts down", func(t *testing.T) {
+		// no dashboard is changed, the dashboard service fails on any call
+		hs := &HTTPServer{
+			log:               log.New("test"),
+			DashboardService:  dashboards.NewFakeDashboardService(t),
+			dashboardBulkJobs: newDashboardBulkJobs(kvstore.ProvideService(db.InitTestDB(t))),
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		status := runWithContext(t, ctx, hs, dtos.BulkDashboardCommand{UIDs: []string{"one"}, Operation: dtos.BulkDashboardDelete})
+
+		assert.Equal(t, dtos.BulkDashboardJobInterrupted, status.State)
+		assert.Zero(t, status.Processed)
+	})
+}
+
+func TestGetBulkDashboardsJob(t *testing.T) {
+	kv := kvstore.ProvideService(db.InitTestDB(t))
+	hs := &HTTPServer{dashboardBulkJobs: newDashboardBulkJobs(kv)}
+	ctx := context.Background()
+	require.NoError(t, hs.dashboardBulkJobs.add(ctx, &dashboardBulkJob{orgID: 1, userID: 2, status: dtos.BulkDashboardJob{UID: "job", State: dtos.BulkDashboardJobRunning}}))
+
+	get := func(userID int64, uid string) (int, dtos.BulkDashboardJob) {
+		httpReq, err := http.NewRequest(http.MethodGet, "/api/dashboards/bulk/"+uid, nil)
+		require.NoError(t, err)
+		httpReq = web.SetURLParams(httpReq, map[string]string{":jobUid": uid})
+		c := &models.ReqContext{SignedInUser: &user.SignedInUser{UserID: userID, OrgID: 1}, Context: &web.Context{Req: httpReq}}
+		resp := hs.GetBulkDashboardsJob(c).(*response.NormalResponse)
+		status := dtos.BulkDashboardJob{}
+		if resp.Status() == http.StatusOK {
+			require.NoError(t, json.Unmarshal(resp.Body(), &status))
+		}
+		return resp.Status(), status
+	}
+
+	t.Run("only the user who started the operation gets it", func(t *testing.T) {
+		code, status := get(2, "job")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, dtos.BulkDashboardJobRunning, status.State)
+		code, _ = get(3, "job")
+		assert.Equal(t, http.StatusNotFound, code)
+		code, _ = get(2, "other")
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+
+	t.Run("any instance gets the operation", func(t *testing.T) {
+		other := &HTTPServer{dashboardBulkJobs: newDashboardBulkJobs(kv)}
+		httpReq, err := http.NewRequest(http.MethodGet, "/api/dashboards/bulk/job", nil)
+		require.NoError(t, err)
+		httpReq = web.SetURLParams(httpReq, map[string]string{":jobUid": "job"})
+		c := &models.ReqContext{SignedInUser: &user.SignedInUser{UserID: 2, OrgID: 1}, Context: &web.Context{Req: httpReq}}
+		assert.Equal(t, http.StatusOK, other.GetBulkDashboardsJob(c).Status())
+	})
+
+	t.Run("running operations that are no longer saved are interrupted", func(t *testing.T) {
+		value, err := json.Marshal(storedDashboardBulkJob{UserID: 2, Updated: time.Now().Add(-bulkDashboardJobStaleAfter - time.Minute), Status: dtos.BulkDashboardJob{UID: "stale", State: dtos.BulkDashboardJobRunning}})
+		require.NoError(t, err)
+		require.NoError(t, kv.Set(ctx, 1, bulkDashboardJobsNamespace, "stale", string(value)))
+
+		code, status := get(2, "stale")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, dtos.BulkDashboardJobInterrupted, status.State)
+	})
+
+	t.Run("users can only run a few operations at once", func(t *testing.T) {
+		require.NoError(t, hs.dashboardBulkJobs.add(ctx, &dashboardBulkJob{orgID: 1, userID: 2, status: dtos.BulkDashboardJob{UID: "second", State: dtos.BulkDashboardJobRunning}}))
+		err := hs.dashboardBulkJobs.add(ctx, &dashboardBulkJob{orgID: 1, userID: 2, status: dtos.BulkDashboardJob{UID: "third", State: dtos.BulkDashboardJobRunning}})
+		require.ErrorIs(t, err, errBulkDashboardTooManyJobs)
+
+		// other users and finished operations aren't counted
+		require.NoError(t, hs.dashboardBulkJobs.add(ctx, &dashboardBulkJob{orgID: 1, userID: 3, status: dtos.BulkDashboardJob{UID: "fourth", State: dtos.BulkDashboardJobRunning}}))
+		finished := &dashboardBulkJob{orgID: 1, userID: 2, status: dtos.BulkDashboardJob{UID: "second", State: dtos.BulkDashboardJobRunning}}
+		finished.finish(dtos.BulkDashboardJobDone)
+		require.NoError(t, hs.dashboardBulkJobs.save(ctx, finished))
+		require.NoError(t, hs.dashboardBulkJobs.add(ctx, &dashboardBulkJob{orgID: 1, userID: 2, status: dtos.BulkDashboardJob{UID: "third", State: dtos.BulkDashboardJobRunning}}))
+	})
+}
+
+func TestBulkDashboardTags(t *testing.T) {
+	assert.Equal(t, []string{"a", "b", "c"}, bulkDashboardTags([]string{"a", "b"}, []string{"b", "c", "c"}, true))
+	assert.Equal(t, []string{"a"}, bulkDashboardTags([]string{"a", "b"}, []string{"b", "c"}, false))
+}
@@ -351,6 +351,130 @@ func (hs *HTTPServer) DeleteDashboardSnapshot(c *models.ReqContext) response.Res
 	})
 }
 
+// swagger:route GET /snapshots/{key}/export snapshots exportDashboardSnapshot
+//
+// Export Snapshot as an archive.
+//
+// Downloads the snapshot, including its dashboard and the data of its panels, as a signed archive that can be imported
+// by the Grafana instances that use the same archive key.
+//
+// Produces:
+// - application/gzip
+//
+// Responses:
+// 200: exportDashboardSnapshotResponse
+// 400: badRequestError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (hs *HTTPServer) ExportDashboardSnapshot(c *models.ReqContext) response.Response {
+	if hs.Cfg.SnapshotArchiveKey == "" {
+		return response.Err(dashboardsnapshots.ErrBaseArchivesDisabled.Errorf("snapshot archive key is not set"))
+	}
+
+	key := web.Params(c.Req)[":key"]
+	query := &dashboardsnapshots.GetDashboardSnapshotQuery{Key: key}
+	if err := hs.dashboardsnapshotsService.GetDashboardSnapshot(c.Req.Context(), query); err != nil {
+		return response.Err(err)
+	}
+	snapshot := query.Result
+	if snapshot == nil || snapshot.OrgId != c.OrgID || snapshot.Expires.Before(time.Now()) {
+		return response.Error(http.StatusNotFound, "Dashboard snapshot not found", nil)
+	}
+	if snapshot.External {
+		return response.Error(http.StatusBadRequest, "External snapshots can't be exported", nil)
+	}
+
+	dashboardID := snapshot.Dashboard.Get("id").MustInt64()
+	if dashboardID != 0 && snapshot.UserId != c.SignedInUser.UserID {
+		guardian := guardian.New(c.Req.Context(), dashboardID, c.OrgID, c.SignedInUser)
+		canView, err := guardian.CanView()
+		if err != nil && !errors.Is(err, dashboards.ErrDashboardNotFound) {
+			return response.Error(http.StatusInternalServerError, "Error while checking permissions for snapshot", err)
+		}
+		if !canView && !errors.Is(err, dashboards.ErrDashboardNotFound) {
+			return response.Error(http.StatusForbidden, "Access denied to this snapshot", nil)
+		}
+	}
+
+	var archive bytes.Buffer
+	if err := dashboardsnapshots.WriteArchive(&archive, snapshot, hs.Cfg.SnapshotArchiveKey); err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to export snapshot", err)
+	}
+
+	header := http.Header{}
+	header.Set("Content-Type", "application/gzip")
+	header.Set("Content-Disposition", fmt.Sprintf(`attachment; filename="snapshot-%s.tar.gz"`, snapshot.Key))
+	return response.CreateNormalResponse(header, archive.Bytes(), http.StatusOK)
+}
+
+// swagger:route POST /snapshots/import snapshots importDashboardSnapshot
+//
+// Import Snapshot from an archive.
+//
+// Creates a snapshot from an archive exported by a Grafana instance that uses the same archive key. The body of the
+// request is the archive. The snapshot gets new keys and keeps the expiration of the exported snapshot.
+//
+// Consumes:
+// - application/gzip
+//
+// Responses:
+// 200: createDashboardSnapshotResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 500: internalServerError
+func (hs *HTTPServer) ImportDashboardSnapshot(c *models.ReqContext) response.Response {
+	if hs.Cfg.SnapshotArchiveKey == "" {
+		return response.Err(dashboardsnapshots.ErrBaseArchivesDisabled.Errorf("snapshot archive key is not set"))
+	}
+
+	body := http.MaxBytesReader(c.Resp, c.Req.Body, dashboardsnapshots.MaxArchiveSize)
+	archive, err := dashboardsnapshots.ReadArchive(body, hs.Cfg.SnapshotArchiveKey)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to import snapshot", err)
+	}
+
+	// The dashboard of the snapshot belongs to the exporting instance, so its id and uid would point to another
+	// dashboard here and grant the permissions of that dashboard on the snapshot.
+	archive.Dashboard.Del("id")
+	archive.Dashboard.Del("uid")
+
+	cmd := dashboardsnapshots.CreateDashboardSnapshotCommand{
+		Dashboard: archive.Dashboard,
+		Name:      archive.Name,
+		OrgId:     c.OrgID,
+		UserId:    c.UserID,
+	}
+	// The snapshots that never expire are stored with an expiration in 50 years.
+	if remaining := time.Until(archive.Expires); remaining < 24*365*40*time.Hour {
+		if remaining <= 0 {
+			return response.Error(http.StatusBadRequest, "The snapshot of the archive has expired", nil)
+		}
+		cmd.Expires = int64(remaining.Seconds())
+	}
+	if cmd.Key, err = util.GetRandomString(32); err != nil {
+		return response.Error(http.StatusInternalServerError, "Could not generate random string", err)
+	}
+	if cmd.DeleteKey, err = util.GetRandomString(32); err != nil {
+		return response.Error(http.StatusInternalServerError, "Could not generate random string", err)
+	}
+
+	if err := hs.dashboardsnapshotsService.CreateDashboardSnapshot(c.Req.Context(), &cmd); err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to create snapshot", err)
+	}
+
+	metrics.MApiDashboardSnapshotCreate.Inc()
+
+	return response.JSON(http.StatusOK, util.DynMap{
+		"key":       cmd.Key,
+		"deleteKey": cmd.DeleteKey,
+		"url":       setting.ToAbsUrl("dashboard/snapshot/" + cmd.Key),
+		"deleteUrl": setting.ToAbsUrl("api/snapshots-delete/" + cmd.DeleteKey),
+		"id":        cmd.Result.Id,
+	})
+}
+
 // swagger:route GET /dashboard/snapshots snapshots searchDashboardSnapshots
 //
 // List snapshots.
@@ -427,6 +551,20 @@ type DeleteDashboardSnapshotParams struct {
 	Key string `json:"key"`
 }
 
+// swagger:parameters exportDashboardSnapshot
+type ExportDashboardSnapshotParams struct {
+	// in:path
+	Key string `json:"key"`
+}
+
+// swagger:parameters importDashboardSnapshot
+type ImportDashboardSnapshotParams struct {
+	// The snapshot archive.
+	// in:body
+	// required:true
+	Body []byte `json:"body"`
+}
+
 // swagger:parameters deleteDashboardSnapshotByDeleteKey
 type DeleteSnapshotByDeleteKeyParams struct {
 	// in:path
@@ -457,6 +595,13 @@ type SearchDashboardSnapshotsResponse struct {
 // swagger:response getDashboardSnapshotResponse
 type GetDashboardSnapshotResponse DashboardResponse
 
+// swagger:response exportDashboardSnapshotResponse
+type ExportDashboardSnapshotResponse struct {
+	// The snapshot archive.
+	// in:body
+	Body []byte `json:"body"`
+}
+
 // swagger:response getSharingOptionsResponse
 type GetSharingOptionsResponse struct {
 	// in:body
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"net/http"
@@ -21,6 +22,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/org"
 	"github.com/grafana/grafana/pkg/services/sqlstore/mockstore"
 	"github.com/grafana/grafana/pkg/services/team/teamtest"
+	"github.com/grafana/grafana/pkg/setting"
 )
 
 func TestDashboardSnapshotAPIEndpoint_singleSnapshot(t *testing.T) {
@@ -328,3 +330,88 @@ func TestGetDashboardSnapshotFailure(t *testing.T) {
 			assert.Equal(t, http.StatusInternalServerError, sc.resp.Code)
 		}, sqlmock)
 }
+
+func TestDashboardSnapshotArchive(t *testing.T) {
+	sqlmock := mockstore.NewSQLStoreMock()
+	dashboard, err := simplejson.NewJson([]byte(`{"id":100,"uid":"incident","title":"Incident"}`))
+	require.NoError(t, err)
+	expires := time.Now().Add(time.Hour)
+	cfg := setting.NewCfg()
+	cfg.SnapshotArchiveKey = "secret"
+
+	setUpSnapshotTest := func(t *testing.T) *dashboardsnapshots.MockService {
+		t.Helper()
+
+		dashSnapSvc := dashboardsnapshots.NewMockService(t)
+		dashSnapSvc.On("GetDashboardSnapshot", mock.Anything, mock.AnythingOfType("*dashboardsnapshots.GetDashboardSnapshotQuery")).Run(func(args mock.Arguments) {
+			q := args.Get(1).(*dashboardsnapshots.GetDashboardSnapshotQuery)
+			q.Result = &dashboardsnapshots.DashboardSnapshot{
+				Id:        1,
+				Name:      "Incident 42",
+				Key:       "12345",
+				OrgId:     testOrgID,
+				UserId:    testUserID,
+				Dashboard: dashboard,
+				Expires:   expires,
+			}
+		}).Return(nil).Maybe()
+		return dashSnapSvc
+	}
+
+	var archive []byte
+	loggedInUserScenarioWithRole(t, "Should export a snapshot when calling GET on", "GET",
+		"/api/snapshots/12345/export", "/api/snapshots/:key/export", org.RoleViewer, func(sc *scenarioContext) {
+			hs := &HTTPServer{Cfg: cfg, dashboardsnapshotsService: setUpSnapshotTest(t)}
+			sc.handlerFunc = hs.ExportDashboardSnapshot
+			sc.fakeReqWithParams("GET", sc.url, map[string]string{}).exec()
+
+			require.Equal(t, http.StatusOK, sc.resp.Code)
+			assert.Equal(t, "application/gzip", sc.resp.Header().Get("Content-Type"))
+			archive = sc.resp.Body.Bytes()
+		}, sqlmock)
+
+	loggedInUserScenarioWithRole(t, "Should import a snapshot when calling POST on", "POST",
+		"/api/snapshots/import", "/api/snapshots/import", org.RoleViewer, func(sc *scenarioContext) {
+			dashSnapSvc := dashboardsnapshots.NewMockService(t)
+			var created *dashboardsnapshots.CreateDashboardSnapshotCommand
+			dashSnapSvc.On("CreateDashboardSnapshot", mock.Anything, mock.AnythingOfType("*dashboardsnapshots.CreateDashboardSnapshotCommand")).Run(func(args mock.Arguments) {
+				created = args.Get(1).(*dashboardsnapshots.CreateDashboardSnapshotCommand)
+				created.Result = &dashboardsnapshots.DashboardSnapshot{Id: 2}
+			}).Return(nil)
+			hs := &HTTPServer{Cfg: cfg, dashboardsnapshotsService: dashSnapSvc}
+			sc.handlerFunc = hs.ImportDashboardSnapshot
+			sc.resp = httptest.NewRecorder()
+			sc.req = httptest.NewRequest("POST", sc.url, bytes.NewReader(archive))
+			sc.exec()
+
+			require.Equal(t, http.StatusOK, sc.resp.Code)
+			assert.Equal(t, "Incident 42", created.Name)
+			assert.Equal(t, "Incident", created.Dashboard.Get("title").MustString())
+			assert.Equal(t, map[string]interface{}{"title": "Incident"}, created.Dashboard.MustMap(), "the id and uid of the dashboard are cleared")
+			assert.InDelta(t, time.Hour.Seconds(), created.Expires, 60)
+			assert.NotEqual(t, "12345", created.Key)
+			assert.Equal(t, int64(testUserID), created.UserId)
+		}, sqlmock)
+
+	loggedInUserScenarioWithRole(t, "Should reject an archive signed with another key when calling POST on", "POST",
+		"/api/snapshots/import", "/api/snapshots/import", org.RoleViewer, func(sc *scenarioContext) {
+			otherCfg := setting.NewCfg()
+			otherCfg.SnapshotArchiveKey = "other secret"
+			hs := &HTTPServer{Cfg: otherCfg, dashboardsnapshotsService: dashboardsnapshots.NewMockService(t)}
+			sc.handlerFunc = hs.ImportDashboardSnapshot
+			sc.resp = httptest.NewRecorder()
+			sc.req = httptest.NewRequest("POST", sc.url, bytes.NewReader(archive))
+			sc.exec()
+
+			assert.Equal(t, http.StatusBadRequest, sc.resp.Code)
+		}, sqlmock)
+
+	loggedInUserScenarioWithRole(t, "Should not export a snapshot without an archive key when calling GET on", "GET",
+		"/api/snapshots/12345/export", "/api/snapshots/:key/export", org.RoleViewer, func(sc *scenarioContext) {
+			hs := &HTTPServer{Cfg: setting.NewCfg(), dashboardsnapshotsService: setUpSnapshotTest(t)}
+			sc.handlerFunc = hs.ExportDashboardSnapshot
+			sc.fakeReqWithParams("GET", sc.url, map[string]string{}).exec()
+
+			assert.Equal(t, http.StatusForbidden, sc.resp.Code)
+		}, sqlmock)
+}
@@ -1286,3 +1286,8 @@ func (l *mockLibraryElementService) DisconnectElementsFromDashboard(c context.Co
 func (l *mockLibraryElementService) DeleteLibraryElementsInFolder(c context.Context, signedInUser *user.SignedInUser, folderUID string) error {
 	return nil
 }
+
+// DeleteExpiredVersions deletes the versions of all elements that exceed the number of versions to keep.
+func (l *mockLibraryElementService) DeleteExpiredVersions(c context.Context) (int64, error) {
+	return 0, nil
+}
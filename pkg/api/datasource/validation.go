@@ -30,6 +30,7 @@ var requiredURL = map[string]bool{
 	datasources.DS_MYSQL:        true,
 	datasources.DS_POSTGRES:     true,
 	datasources.DS_MSSQL:        true,
+	datasources.DS_JSONAPI:      true,
 }
 
 // URLValidationError represents an error from validating a data source URL.
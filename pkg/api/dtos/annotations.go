@@ -12,22 +12,34 @@ type PostAnnotationsCmd struct {
 	Text string           `json:"text"`
 	Tags []string         `json:"tags"`
 	Data *simplejson.Json `json:"data"`
+	// Severity of the incident: critical, high, medium, low or info.
+	Severity string `json:"severity,omitempty"`
+	// Links of the incident, at most 10 http or https URLs.
+	Links []string `json:"links,omitempty"`
+	// OwnerID is the ID of the user who owns the incident.
+	OwnerID int64 `json:"ownerId,omitempty"`
 }
 
 type UpdateAnnotationsCmd struct {
-	Id      int64    `json:"id"`
-	Time    int64    `json:"time"`
-	TimeEnd int64    `json:"timeEnd,omitempty"` // Optional
-	Text    string   `json:"text"`
-	Tags    []string `json:"tags"`
+	Id       int64    `json:"id"`
+	Time     int64    `json:"time"`
+	TimeEnd  int64    `json:"timeEnd,omitempty"` // Optional
+	Text     string   `json:"text"`
+	Tags     []string `json:"tags"`
+	Severity string   `json:"severity,omitempty"`
+	Links    []string `json:"links,omitempty"`
+	OwnerID  int64    `json:"ownerId,omitempty"`
 }
 
 type PatchAnnotationsCmd struct {
-	Id      int64    `json:"id"`
-	Time    int64    `json:"time"`
-	TimeEnd int64    `json:"timeEnd,omitempty"` // Optional
-	Text    string   `json:"text"`
-	Tags    []string `json:"tags"`
+	Id       int64    `json:"id"`
+	Time     int64    `json:"time"`
+	TimeEnd  int64    `json:"timeEnd,omitempty"` // Optional
+	Text     string   `json:"text"`
+	Tags     []string `json:"tags"`
+	Severity string   `json:"severity,omitempty"`
+	Links    []string `json:"links,omitempty"`
+	OwnerID  int64    `json:"ownerId,omitempty"`
 }
 
 type MassDeleteAnnotationsCmd struct {
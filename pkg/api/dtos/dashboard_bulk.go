@@ -0,0 +1,67 @@
+package dtos
+
+import "time"
+
+type BulkDashboardOperation string
+
+const (
+	BulkDashboardMove           BulkDashboardOperation = "move"
+	BulkDashboardAddTags        BulkDashboardOperation = "addTags"
+	BulkDashboardRemoveTags     BulkDashboardOperation = "removeTags"
+	BulkDashboardDelete         BulkDashboardOperation = "delete"
+	BulkDashboardSetPermissions BulkDashboardOperation = "setPermissions"
+)
+
+type BulkDashboardJobState string
+
+const (
+	BulkDashboardJobRunning     BulkDashboardJobState = "running"
+	BulkDashboardJobDone        BulkDashboardJobState = "done"
+	BulkDashboardJobInterrupted BulkDashboardJobState = "interrupted"
+)
+
+// BulkDashboardSearch selects the dashboards of a bulk operation with the parameters of the dashboard search
+type BulkDashboardSearch struct {
+	Query      string   `json:"query"`
+	Tags       []string `json:"tag"`
+	FolderUIDs []string `json:"folderUIDs"`
+	Starred    bool     `json:"starred"`
+}
+
+// swagger:model
+type BulkDashboardCommand struct {
+	// The dashboards to change, either by UID or with a search
+	UIDs   []string             `json:"uids"`
+	Search *BulkDashboardSearch `json:"search"`
+
+	// The operation to run on each dashboard: move, addTags, removeTags, delete or setPermissions
+	Operation BulkDashboardOperation `json:"operation" binding:"Required"`
+
+	// The folder to move the dashboards to, for the move operation. Empty for the General folder.
+	FolderUID string `json:"folderUid"`
+	// The tags to add or remove, for the addTags and removeTags operations
+	Tags []string `json:"tags"`
+	// The permissions replacing the existing ones, for the setPermissions operation
+	Permissions []DashboardACLUpdateItem `json:"permissions"`
+}
+
+// BulkDashboardItemError is the error of a bulk operation on a dashboard
+type BulkDashboardItemError struct {
+	UID   string `json:"uid"`
+	Title string `json:"title,omitempty"`
+	Error string `json:"error"`
+}
+
+// BulkDashboardJob is the progress of a bulk operation running in the background
+type BulkDashboardJob struct {
+	UID       string                   `json:"uid"`
+	Operation BulkDashboardOperation   `json:"operation"`
+	State     BulkDashboardJobState    `json:"state"`
+	Total     int                      `json:"total"`
+	Processed int                      `json:"processed"`
+	Succeeded int                      `json:"succeeded"`
+	Failed    int                      `json:"failed"`
+	Started   time.Time                `json:"started"`
+	Finished  *time.Time               `json:"finished,omitempty"`
+	Errors    []BulkDashboardItemError `json:"errors"`
+}
@@ -2,11 +2,18 @@ package dtos
 
 type PlaylistDashboard struct {
 	Id    int64  `json:"id"`
+	Uid   string `json:"uid"`
 	Slug  string `json:"slug"`
 	Title string `json:"title"`
 	Uri   string `json:"uri"`
 	Url   string `json:"url"`
 	Order int    `json:"order"`
+
+	// Settings overridden by the playlist item the dashboard was resolved from
+	TimeFrom string `json:"timeFrom,omitempty"`
+	TimeTo   string `json:"timeTo,omitempty"`
+	Refresh  string `json:"refresh,omitempty"`
+	Kiosk    string `json:"kiosk,omitempty"`
 }
 
 type PlaylistDashboardsSlice []PlaylistDashboard
@@ -209,6 +209,7 @@ type HTTPServer struct {
 	tagService             tag.Service
 	oauthTokenService      oauthtoken.OAuthTokenService
 	statsService           stats.Service
+	dashboardBulkJobs      *dashboardBulkJobs
 }
 
 type ServerOptions struct {
@@ -357,6 +358,7 @@ func ProvideHTTPServer(opts ServerOptions, cfg *setting.Cfg, routeRegister routi
 		QueryLibraryService:          queryLibraryService,
 		oauthTokenService:            oauthTokenService,
 		statsService:                 statsService,
+		dashboardBulkJobs:            newDashboardBulkJobs(kvStore),
 	}
 	if hs.Listener != nil {
 		hs.log.Debug("Using provided listener")
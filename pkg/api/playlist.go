@@ -163,6 +163,10 @@ func (hs *HTTPServer) CreatePlaylist(c *models.ReqContext) response.Response {
 	}
 	cmd.OrgId = c.OrgID
 
+	if err := playlist.ValidateItems(cmd.Items); err != nil {
+		return response.Error(http.StatusBadRequest, err.Error(), err)
+	}
+
 	p, err := hs.playlistService.Create(c.Req.Context(), &cmd)
 	if err != nil {
 		return response.Error(500, "Failed to create playlist", err)
@@ -189,6 +193,10 @@ func (hs *HTTPServer) UpdatePlaylist(c *models.ReqContext) response.Response {
 	cmd.OrgId = c.OrgID
 	cmd.UID = web.Params(c.Req)[":uid"]
 
+	if err := playlist.ValidateItems(cmd.Items); err != nil {
+		return response.Error(http.StatusBadRequest, err.Error(), err)
+	}
+
 	_, err := hs.playlistService.Update(c.Req.Context(), &cmd)
 	if err != nil {
 		return response.Error(500, "Failed to save playlist", err)
@@ -3,18 +3,25 @@ package api
 import (
 	"context"
 	"errors"
-	"sort"
 	"strconv"
 
 	"github.com/grafana/grafana/pkg/api/dtos"
 	_ "github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/models"
+	ac "github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/folder"
+	"github.com/grafana/grafana/pkg/services/org"
 	"github.com/grafana/grafana/pkg/services/playlist"
 	"github.com/grafana/grafana/pkg/services/search"
+	"github.com/grafana/grafana/pkg/services/star"
 	"github.com/grafana/grafana/pkg/services/user"
 )
 
-func (hs *HTTPServer) populateDashboardsByID(ctx context.Context, dashboardByIDs []int64, dashboardIDOrder map[int64]int) (dtos.PlaylistDashboardsSlice, error) {
+// playlistSearchLimit is the maximum number of dashboards a playlist item resolves into
+const playlistSearchLimit = 100
+
+func (hs *HTTPServer) populateDashboardsByID(ctx context.Context, dashboardByIDs []int64) (dtos.PlaylistDashboardsSlice, error) {
 	result := make(dtos.PlaylistDashboardsSlice, 0)
 
 	if len(dashboardByIDs) > 0 {
@@ -26,11 +33,11 @@ func (hs *HTTPServer) populateDashboardsByID(ctx context.Context, dashboardByIDs
 		for _, item := range dashboardQuery.Result {
 			result = append(result, dtos.PlaylistDashboard{
 				Id:    item.Id,
+				Uid:   item.Uid,
 				Slug:  item.Slug,
 				Title: item.Title,
 				Uri:   "db/" + item.Slug,
 				Url:   models.GetDashboardUrl(item.Uid, item.Slug),
-				Order: dashboardIDOrder[item.Id],
 			})
 		}
 	}
@@ -38,37 +45,130 @@ func (hs *HTTPServer) populateDashboardsByID(ctx context.Context, dashboardByIDs
 	return result, nil
 }
 
-func (hs *HTTPServer) populateDashboardsByTag(ctx context.Context, orgID int64, signedInUser *user.SignedInUser, dashboardByTag []string, dashboardTagOrder map[string]int) dtos.PlaylistDashboardsSlice {
+func (hs *HTTPServer) populateDashboardsBySearch(ctx context.Context, searchQuery *search.Query) (dtos.PlaylistDashboardsSlice, error) {
 	result := make(dtos.PlaylistDashboardsSlice, 0)
 
-	for _, tag := range dashboardByTag {
-		searchQuery := search.Query{
-			Title:        "",
-			Tags:         []string{tag},
-			SignedInUser: signedInUser,
-			Limit:        100,
-			IsStarred:    false,
-			OrgId:        orgID,
-		}
+	searchQuery.Type = string(models.DashHitDB)
+	searchQuery.Limit = playlistSearchLimit
+	if err := hs.SearchService.SearchHandler(ctx, searchQuery); err != nil {
+		return result, err
+	}
+
+	for _, item := range searchQuery.Result {
+		result = append(result, dtos.PlaylistDashboard{
+			Id:    item.ID,
+			Uid:   item.UID,
+			Slug:  item.Slug,
+			Title: item.Title,
+			Uri:   item.URI,
+			Url:   item.URL,
+		})
+	}
+
+	return result, nil
+}
 
-		if err := hs.SearchService.SearchHandler(ctx, &searchQuery); err == nil {
-			for _, item := range searchQuery.Result {
-				result = append(result, dtos.PlaylistDashboard{
-					Id:    item.ID,
-					Slug:  item.Slug,
-					Title: item.Title,
-					Uri:   item.URI,
-					Url:   item.URL,
-					Order: dashboardTagOrder[tag],
-				})
+// playlistItemSearchQuery builds the dashboard search of a dashboard_by_search playlist item. It returns nil
+// when no dashboard can match, because none of its folders can be read or its team can't be read or
+// starred no dashboard.
+func (hs *HTTPServer) playlistItemSearchQuery(ctx context.Context, orgID int64, signedInUser *user.SignedInUser, value string) (*search.Query, error) {
+	q, err := playlist.ParseSearchItemQuery(value)
+	if err != nil {
+		return nil, err
+	}
+
+	searchQuery := &search.Query{
+		Title:        q.Query,
+		Tags:         q.Tags,
+		SignedInUser: signedInUser,
+		OrgId:        orgID,
+	}
+
+	for i := range q.FolderUIDs {
+		if q.FolderUIDs[i] == folder.GeneralFolderUID {
+			searchQuery.FolderIds = append(searchQuery.FolderIds, 0)
+			continue
+		}
+		f, err := hs.folderService.Get(ctx, &folder.GetFolderQuery{OrgID: orgID, UID: &q.FolderUIDs[i], SignedInUser: signedInUser})
+		if err != nil {
+			if errors.Is(err, dashboards.ErrFolderNotFound) || errors.Is(err, dashboards.ErrFolderAccessDenied) {
+				continue
 			}
+			return nil, err
+		}
+		searchQuery.FolderIds = append(searchQuery.FolderIds, f.ID)
+	}
+	if len(q.FolderUIDs) > 0 && len(searchQuery.FolderIds) == 0 {
+		return nil, nil
+	}
+
+	if q.StarredByTeamID > 0 {
+		canRead, err := hs.canReadPlaylistTeam(ctx, orgID, signedInUser, q.StarredByTeamID)
+		if err != nil {
+			return nil, err
+		}
+		if !canRead {
+			return nil, nil
+		}
+		stars, err := hs.starService.GetByTeam(ctx, &star.GetTeamStarsQuery{TeamID: q.StarredByTeamID, OrgID: orgID})
+		if err != nil {
+			return nil, err
+		}
+		for dashboardID := range stars.UserStars {
+			searchQuery.DashboardIds = append(searchQuery.DashboardIds, dashboardID)
+		}
+		if len(searchQuery.DashboardIds) == 0 {
+			return nil, nil
 		}
 	}
 
-	return result
+	return searchQuery, nil
 }
 
-// Deprecated -- the frontend can do this better
+// canReadPlaylistTeam reports whether the user can read the team whose stars a playlist item resolves
+func (hs *HTTPServer) canReadPlaylistTeam(ctx context.Context, orgID int64, signedInUser *user.SignedInUser, teamID int64) (bool, error) {
+	if !hs.AccessControl.IsDisabled() {
+		return hs.AccessControl.Evaluate(ctx, signedInUser, ac.EvalPermission(ac.ActionTeamsRead, ac.Scope("teams", "id", strconv.FormatInt(teamID, 10))))
+	}
+	if signedInUser.OrgRole == org.RoleAdmin {
+		return true, nil
+	}
+	return hs.teamService.IsTeamMember(orgID, teamID, signedInUser.UserID)
+}
+
+func (hs *HTTPServer) loadPlaylistItemDashboards(ctx context.Context, orgID int64, signedInUser *user.SignedInUser, item playlist.PlaylistItemDTO) (dtos.PlaylistDashboardsSlice, error) {
+	switch item.Type {
+	case "dashboard_by_id":
+		dashboardID, err := strconv.ParseInt(item.Value, 10, 64)
+		if err != nil {
+			return nil, nil
+		}
+		return hs.populateDashboardsByID(ctx, []int64{dashboardID})
+	case "dashboard_by_uid":
+		return hs.populateDashboardsBySearch(ctx, &search.Query{
+			DashboardUIDs: []string{item.Value},
+			SignedInUser:  signedInUser,
+			OrgId:         orgID,
+		})
+	case "dashboard_by_tag":
+		return hs.populateDashboardsBySearch(ctx, &search.Query{
+			Tags:         []string{item.Value},
+			SignedInUser: signedInUser,
+			OrgId:        orgID,
+		})
+	case "dashboard_by_search":
+		searchQuery, err := hs.playlistItemSearchQuery(ctx, orgID, signedInUser, item.Value)
+		if err != nil || searchQuery == nil {
+			return nil, err
+		}
+		return hs.populateDashboardsBySearch(ctx, searchQuery)
+	default:
+		return nil, nil
+	}
+}
+
+// LoadPlaylistDashboards resolves the items of a playlist into the ordered list of dashboards it shows, with
+// the time range, refresh interval and kiosk mode each item overrides.
 func (hs *HTTPServer) LoadPlaylistDashboards(ctx context.Context, orgID int64, signedInUser *user.SignedInUser, playlistUID string) (dtos.PlaylistDashboardsSlice, error) {
 	result := make(dtos.PlaylistDashboardsSlice, 0)
 	dto, err := hs.playlistService.Get(ctx,
@@ -77,32 +177,29 @@ func (hs *HTTPServer) LoadPlaylistDashboards(ctx context.Context, orgID int64, s
 		return result, err
 	}
 
-	playlistItems := *dto.Items
-
-	dashboardByIDs := make([]int64, 0)
-	dashboardByTag := make([]string, 0)
-	dashboardIDOrder := make(map[int64]int)
-	dashboardTagOrder := make(map[string]int)
-
-	for i, item := range playlistItems {
-		switch item.Type {
-		case "dashboard_by_id":
-			dashboardID, _ := strconv.ParseInt(item.Value, 10, 64)
-			dashboardByIDs = append(dashboardByIDs, dashboardID)
-			dashboardIDOrder[dashboardID] = i
-		case "dashboard_by_tag":
-			dashboardByTag = append(dashboardByTag, item.Value)
-			dashboardTagOrder[item.Value] = i
-		case "dashboard_by_uid":
-			return nil, errors.New("dashboard_by_uid not supported by this deprecated API")
-		default:
+	for i, item := range *dto.Items {
+		dashboards, err := hs.loadPlaylistItemDashboards(ctx, orgID, signedInUser, item)
+		if err != nil {
+			return nil, err
 		}
-	}
 
-	k, _ := hs.populateDashboardsByID(ctx, dashboardByIDs, dashboardIDOrder)
-	result = append(result, k...)
-	result = append(result, hs.populateDashboardsByTag(ctx, orgID, signedInUser, dashboardByTag, dashboardTagOrder)...)
+		for _, dash := range dashboards {
+			dash.Order = i
+			if item.TimeFrom != nil {
+				dash.TimeFrom = *item.TimeFrom
+			}
+			if item.TimeTo != nil {
+				dash.TimeTo = *item.TimeTo
+			}
+			if item.Refresh != nil {
+				dash.Refresh = *item.Refresh
+			}
+			if item.Kiosk != nil {
+				dash.Kiosk = string(*item.Kiosk)
+			}
+			result = append(result, dash)
+		}
+	}
 
-	sort.Sort(result)
 	return result, nil
 }
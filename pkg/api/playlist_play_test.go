@@ -0,0 +1,169 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/models"
+	ac "github.com/grafana/grafana/pkg/services/accesscontrol"
+	accesscontrolmock "github.com/grafana/grafana/pkg/services/accesscontrol/mock"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/folder"
+	"github.com/grafana/grafana/pkg/services/folder/foldertest"
+	"github.com/grafana/grafana/pkg/services/org"
+	"github.com/grafana/grafana/pkg/services/playlist"
+	"github.com/grafana/grafana/pkg/services/playlist/playlisttest"
+	"github.com/grafana/grafana/pkg/services/search"
+	"github.com/grafana/grafana/pkg/services/star"
+	"github.com/grafana/grafana/pkg/services/star/startest"
+	"github.com/grafana/grafana/pkg/services/team/teamtest"
+	"github.com/grafana/grafana/pkg/services/user"
+)
+
+// playlistSearchService returns a dashboard per query, titled after the query
+type playlistSearchService struct {
+	queries []*search.Query
+}
+
+func (s *playlistSearchService) SearchHandler(_ context.Context, q *search.Query) error {
+	s.queries = append(s.queries, q)
+	uid := "search"
+	switch {
+	case len(q.DashboardUIDs) > 0:
+		uid = q.DashboardUIDs[0]
+	case len(q.Tags) > 0:
+		uid = q.Tags[0]
+	}
+	q.Result = models.HitList{{ID: int64(len(s.queries)), UID: uid, Title: uid, URL: "/d/" + uid, Type: models.DashHitDB}}
+	return nil
+}
+
+func (s *playlistSearchService) SortOptions() []models.SortOption { return nil }
+
+func TestLoadPlaylistDashboards(t *testing.T) {
+	ctx := context.Background()
+	signedInUser := &user.SignedInUser{UserID: 1, OrgID: 1}
+	timeFrom, timeTo, refresh, kiosk := "now-1h", "now", "1m", playlist.PlaylistItemKiosk("tv")
+
+	setup := func(items []playlist.PlaylistItemDTO) (*HTTPServer, *playlistSearchService, *startest.FakeStarService) {
+		playlistService := playlisttest.NewPlaylistServiveFake()
+		playlistService.ExpectedPlaylistDTO = &playlist.PlaylistDTO{Uid: "abc", Items: &items}
+		searchService := &playlistSearchService{}
+		starService := startest.NewStarServiceFake()
+		starService.ExpectedTeamStars = &star.GetUserStarsResult{UserStars: map[int64]bool{}}
+		folderService := foldertest.NewFakeService()
+		folderService.ExpectedFolder = &folder.Folder{ID: 7, UID: "folder"}
+
+		hs := &HTTPServer{
+			playlistService: playlistService,
+			SearchService:   searchService,
+			starService:     starService,
+			folderService:   folderService,
+			teamService:     teamtest.NewFakeService(),
+			AccessControl:   accesscontrolmock.New().WithPermissions([]ac.Permission{{Action: ac.ActionTeamsRead, Scope: "teams:id:3"}}),
+		}
+		return hs, searchService, starService
+	}
+
+	t.Run("resolves the items in order with their settings", func(t *testing.T) {
+		hs, searchService, starService := setup([]playlist.PlaylistItemDTO{
+			{Type: "dashboard_by_uid", Value: "first"},
+			{Type: "dashboard_by_search", Value: "query=cpu&tag=prod&folderUid=folder&starredByTeamId=3", TimeFrom: &timeFrom, TimeTo: &timeTo, Refresh: &refresh, Kiosk: &kiosk},
+			{Type: "dashboard_by_tag", Value: "last"},
+		})
+		starService.ExpectedTeamStars = &star.GetUserStarsResult{UserStars: map[int64]bool{42: true}}
+
+		result, err := hs.LoadPlaylistDashboards(ctx, 1, signedInUser, "abc")
+		require.NoError(t, err)
+		require.Equal(t, dtos.PlaylistDashboardsSlice{
+			{Id: 1, Uid: "first", Title: "first", Url: "/d/first", Order: 0},
+			{Id: 2, Uid: "prod", Title: "prod", Url: "/d/prod", Order: 1, TimeFrom: "now-1h", TimeTo: "now", Refresh: "1m", Kiosk: "tv"},
+			{Id: 3, Uid: "last", Title: "last", Url: "/d/last", Order: 2},
+		}, result)
+
+		require.Len(t, searchService.queries, 3)
+		q := searchService.queries[1]
+		require.Equal(t, "cpu", q.Title)
+		require.Equal(t, []string{"prod"}, q.Tags)
+		require.Equal(t, []int64{7}, q.FolderIds)
+		require.Equal(t, []int64{42}, q.DashboardIds)
+		require.Equal(t, string(models.DashHitDB), q.Type)
+		require.Equal(t, int64(1), q.OrgId)
+		require.Equal(t, signedInUser, q.SignedInUser)
+	})
+
+	t.Run("search item matches nothing when the team starred no dashboard", func(t *testing.T) {
+		hs, searchService, _ := setup([]playlist.PlaylistItemDTO{
+			{Type: "dashboard_by_search", Value: "starredByTeamId=3"},
+		})
+
+		result, err := hs.LoadPlaylistDashboards(ctx, 1, signedInUser, "abc")
+		require.NoError(t, err)
+		require.Empty(t, result)
+		require.Empty(t, searchService.queries)
+	})
+
+	t.Run("search item matches nothing when the team can't be read", func(t *testing.T) {
+		hs, searchService, starService := setup([]playlist.PlaylistItemDTO{
+			{Type: "dashboard_by_search", Value: "starredByTeamId=4"},
+		})
+		starService.ExpectedTeamStars = &star.GetUserStarsResult{UserStars: map[int64]bool{42: true}}
+
+		result, err := hs.LoadPlaylistDashboards(ctx, 1, signedInUser, "abc")
+		require.NoError(t, err)
+		require.Empty(t, result)
+		require.Empty(t, searchService.queries)
+	})
+
+	t.Run("without access control, only team members and org admins resolve the team stars", func(t *testing.T) {
+		hs, searchService, starService := setup([]playlist.PlaylistItemDTO{
+			{Type: "dashboard_by_search", Value: "starredByTeamId=3"},
+		})
+		hs.AccessControl = accesscontrolmock.New().WithDisabled()
+		starService.ExpectedTeamStars = &star.GetUserStarsResult{UserStars: map[int64]bool{42: true}}
+
+		result, err := hs.LoadPlaylistDashboards(ctx, 1, signedInUser, "abc")
+		require.NoError(t, err)
+		require.Empty(t, result)
+		require.Empty(t, searchService.queries)
+
+		hs.teamService.(*teamtest.FakeService).ExpectedIsMember = true
+		result, err = hs.LoadPlaylistDashboards(ctx, 1, signedInUser, "abc")
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		require.Equal(t, []int64{42}, searchService.queries[0].DashboardIds)
+
+		hs.teamService.(*teamtest.FakeService).ExpectedIsMember = false
+		admin := &user.SignedInUser{UserID: 2, OrgID: 1, OrgRole: org.RoleAdmin}
+		result, err = hs.LoadPlaylistDashboards(ctx, 1, admin, "abc")
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+	})
+
+	t.Run("search item matches nothing when its folders can't be read", func(t *testing.T) {
+		hs, searchService, _ := setup([]playlist.PlaylistItemDTO{
+			{Type: "dashboard_by_search", Value: "folderUid=folder"},
+		})
+		hs.folderService.(*foldertest.FakeService).ExpectedError = dashboards.ErrFolderAccessDenied
+		hs.folderService.(*foldertest.FakeService).ExpectedFolder = nil
+
+		result, err := hs.LoadPlaylistDashboards(ctx, 1, signedInUser, "abc")
+		require.NoError(t, err)
+		require.Empty(t, result)
+		require.Empty(t, searchService.queries)
+	})
+
+	t.Run("search item in the general folder", func(t *testing.T) {
+		hs, searchService, _ := setup([]playlist.PlaylistItemDTO{
+			{Type: "dashboard_by_search", Value: "folderUid=general"},
+		})
+
+		result, err := hs.LoadPlaylistDashboards(ctx, 1, signedInUser, "abc")
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		require.Equal(t, []int64{0}, searchService.queries[0].FolderIds)
+	})
+}
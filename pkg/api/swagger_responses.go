@@ -67,6 +67,11 @@ type PreconditionFailedError GenericError
 // swagger:response unprocessableEntityError
 type UnprocessableEntityError GenericError
 
+// TooManyRequestsError is returned when the user runs too many operations at once.
+//
+// swagger:response tooManyRequestsError
+type TooManyRequestsError GenericError
+
 // InternalServerError is a general error indicating something went wrong internally.
 //
 // swagger:response internalServerError
@@ -77,6 +77,15 @@ func parseRedisConnStr(connStr string) (*redis.Options, error) {
 	return options, nil
 }
 
+// RedisOptions returns the connection options of the Redis remote cache, so that other
+// services can connect to the same Redis server.
+func RedisOptions(opts *setting.RemoteCacheOptions) (*redis.Options, error) {
+	if opts == nil || opts.Name != redisCacheType {
+		return nil, fmt.Errorf("the remote cache is not configured to use redis")
+	}
+	return parseRedisConnStr(opts.ConnStr)
+}
+
 func newRedisStorage(opts *setting.RemoteCacheOptions, codec codec) (*redisStorage, error) {
 	opt, err := parseRedisConnStr(opts.ConnStr)
 	if err != nil {
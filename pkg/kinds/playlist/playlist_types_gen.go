@@ -10,10 +10,19 @@
 
 package playlist
 
+// Defines values for PlaylistItemKiosk.
+const (
+	PlaylistItemKioskFull PlaylistItemKiosk = "full"
+
+	PlaylistItemKioskTv PlaylistItemKiosk = "tv"
+)
+
 // Defines values for PlaylistItemType.
 const (
 	PlaylistItemTypeDashboardById PlaylistItemType = "dashboard_by_id"
 
+	PlaylistItemTypeDashboardBySearch PlaylistItemType = "dashboard_by_search"
+
 	PlaylistItemTypeDashboardByTag PlaylistItemType = "dashboard_by_tag"
 
 	PlaylistItemTypeDashboardByUid PlaylistItemType = "dashboard_by_uid"
@@ -39,6 +48,20 @@ type Playlist struct {
 
 // PlaylistItem defines model for playlist.Item.
 type PlaylistItem struct {
+	// Kiosk mode the dashboards of the item are shown in. Defaults to the mode
+	// the playlist was started in.
+	Kiosk *PlaylistItemKiosk `json:"kiosk,omitempty"`
+
+	// Refresh interval of the dashboards of the item, e.g. 1m.
+	Refresh *string `json:"refresh,omitempty"`
+
+	// Time range start shown for the dashboards of the item, e.g. now-6h. Defaults to the
+	// time range saved with the dashboard.
+	TimeFrom *string `json:"timeFrom,omitempty"`
+
+	// Time range end shown for the dashboards of the item, e.g. now.
+	TimeTo *string `json:"timeTo,omitempty"`
+
 	// Title is an unused property -- it will be removed in the future
 	Title *string `json:"title,omitempty"`
 
@@ -53,8 +76,16 @@ type PlaylistItem struct {
 	//  - dashboard_by_tag: The value is a tag which is set on any number of dashboards. All
 	//  dashboards behind the tag will be added to the playlist.
 	//  - dashboard_by_uid: The value is the dashboard UID
+	//  - dashboard_by_search: The value is a URL encoded search query. All dashboards
+	//  matching every parameter will be added to the playlist. The parameters are
+	//  query (title), tag, folderUid and starredByTeamId (dashboards starred by any
+	//  member of the team), and tag and folderUid can be repeated.
 	Value string `json:"value"`
 }
 
+// Kiosk mode the dashboards of the item are shown in. Defaults to the mode
+// the playlist was started in.
+type PlaylistItemKiosk string
+
 // Type of the item.
 type PlaylistItemType string
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"crypto/tls"
 	"errors"
 
 	"github.com/grafana/grafana/pkg/services/user"
@@ -42,6 +43,8 @@ type SendWebhookSync struct {
 	HttpHeader  map[string]string
 	ContentType string
 	Validation  func(body []byte, statusCode int) error
+	// TLSConfig replaces the default TLS configuration of the client, e.g. to present a client certificate.
+	TLSConfig *tls.Config
 }
 
 type SendResetPasswordEmailCommand struct {
@@ -11,6 +11,10 @@ import (
 var (
 	ErrTimerangeMissing     = errors.New("missing timerange")
 	ErrBaseTagLimitExceeded = errutil.NewBase(errutil.StatusBadRequest, "annotations.tag-limit-exceeded", errutil.WithPublicMessage("Tags length exceeds the maximum allowed."))
+	ErrBaseInvalidSeverity  = errutil.NewBase(errutil.StatusBadRequest, "annotations.invalid-severity", errutil.WithPublicMessage("Invalid severity, the supported severities are: critical, high, medium, low, info."))
+	ErrBaseInvalidTagRegex  = errutil.NewBase(errutil.StatusBadRequest, "annotations.invalid-tag-regex", errutil.WithPublicMessage("Invalid tag regular expression."))
+	ErrBaseInvalidInterval  = errutil.NewBase(errutil.StatusBadRequest, "annotations.invalid-interval", errutil.WithPublicMessage("The interval of the aggregation must be positive."))
+	ErrBaseInvalidLinks     = errutil.NewBase(errutil.StatusBadRequest, "annotations.invalid-links", errutil.WithPublicMessage("Invalid links, they must be at most 10 absolute http or https URLs."))
 )
 
 //go:generate mockery --name Repository --structname FakeAnnotationsRepo --inpackage --filename annotations_repository_mock.go
@@ -21,6 +25,9 @@ type Repository interface {
 	Find(ctx context.Context, query *ItemQuery) ([]*ItemDTO, error)
 	Delete(ctx context.Context, params *DeleteParams) error
 	FindTags(ctx context.Context, query *TagsQuery) (FindTagsResult, error)
+	// Aggregate returns the number of annotations that match the query per time bucket and tag, ordered by time
+	// and tag. The limit of the query is ignored.
+	Aggregate(ctx context.Context, query *AggregateQuery) ([]*AggregateBucket, error)
 }
 
 // Cleaner is responsible for cleaning up old annotations
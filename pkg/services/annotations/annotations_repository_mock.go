@@ -14,6 +14,29 @@ type FakeAnnotationsRepo struct {
 	mock.Mock
 }
 
+// Aggregate provides a mock function with given fields: ctx, query
+func (_m *FakeAnnotationsRepo) Aggregate(ctx context.Context, query *AggregateQuery) ([]*AggregateBucket, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 []*AggregateBucket
+	if rf, ok := ret.Get(0).(func(context.Context, *AggregateQuery) []*AggregateBucket); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*AggregateBucket)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *AggregateQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Delete provides a mock function with given fields: ctx, params
 func (_m *FakeAnnotationsRepo) Delete(ctx context.Context, params *DeleteParams) error {
 	ret := _m.Called(ctx, params)
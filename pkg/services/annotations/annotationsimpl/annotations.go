@@ -2,6 +2,9 @@ package annotationsimpl
 
 import (
 	"context"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/grafana/grafana/pkg/infra/db"
 	"github.com/grafana/grafana/pkg/infra/log"
@@ -11,19 +14,48 @@ import (
 )
 
 type RepositoryImpl struct {
-	store store
-}
-
-func ProvideService(db db.DB, cfg *setting.Cfg, tagService tag.Service) *RepositoryImpl {
-	return &RepositoryImpl{
-		store: &xormRepositoryImpl{
-			cfg:               cfg,
-			db:                db,
-			log:               log.New("annotations"),
-			tagService:        tagService,
-			maximumTagsLength: cfg.AnnotationMaximumTagsLength,
-		},
+	store      store
+	lokiStores []*lokiStore
+}
+
+func ProvideService(db db.DB, cfg *setting.Cfg, tagService tag.Service) (*RepositoryImpl, error) {
+	logger := log.New("annotations")
+	sqlStore := &xormRepositoryImpl{
+		cfg:               cfg,
+		db:                db,
+		log:               logger,
+		tagService:        tagService,
+		maximumTagsLength: cfg.AnnotationMaximumTagsLength,
+	}
+	if len(cfg.AnnotationExternalStores) == 0 {
+		return &RepositoryImpl{store: sqlStore}, nil
 	}
+
+	externals := make([]routedStore, 0, len(cfg.AnnotationExternalStores))
+	lokiStores := make([]*lokiStore, 0, len(cfg.AnnotationExternalStores))
+	for _, storeCfg := range cfg.AnnotationExternalStores {
+		external, err := newLokiStore(storeCfg, logger.New("store", storeCfg.Name), prometheus.DefaultRegisterer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the annotation store %s: %w", storeCfg.Name, err)
+		}
+		externals = append(externals, newRoutedStore(storeCfg, external))
+		lokiStores = append(lokiStores, external)
+	}
+	return &RepositoryImpl{store: newCompositeStore(sqlStore, sqlStore, externals, logger), lokiStores: lokiStores}, nil
+}
+
+// IsDisabled returns true when there are no external stores to stop on shutdown.
+func (r *RepositoryImpl) IsDisabled() bool {
+	return len(r.lokiStores) == 0
+}
+
+// Run waits for the server to shut down, then pushes the annotations queued for the external stores.
+func (r *RepositoryImpl) Run(ctx context.Context) error {
+	<-ctx.Done()
+	for _, s := range r.lokiStores {
+		s.stop()
+	}
+	return nil
 }
 
 func (r *RepositoryImpl) Save(ctx context.Context, item *annotations.Item) error {
@@ -51,3 +83,7 @@ func (r *RepositoryImpl) Delete(ctx context.Context, params *annotations.DeleteP
 func (r *RepositoryImpl) FindTags(ctx context.Context, query *annotations.TagsQuery) (annotations.FindTagsResult, error) {
 	return r.store.GetTags(ctx, query)
 }
+
+func (r *RepositoryImpl) Aggregate(ctx context.Context, query *annotations.AggregateQuery) ([]*annotations.AggregateBucket, error) {
+	return r.store.Aggregate(ctx, query)
+}
@@ -0,0 +1,290 @@
+package annotationsimpl
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/annotations"
+	"github.com/grafana/grafana/pkg/services/tag"
+	"github.com/grafana/grafana/pkg/services/user"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+// maxExternalAggregateItems is the maximum number of annotations of an external store that are aggregated.
+const maxExternalAggregateItems = 5000
+
+// externalStore is a store of annotations other than the SQL database. The annotations of an external store don't
+// have an ID, they can't be updated nor deleted.
+type externalStore interface {
+	Add(ctx context.Context, item *annotations.Item) error
+	// Get returns the annotations that match the query, the most recent first. The access control filter of the
+	// query is not applied.
+	Get(ctx context.Context, query *annotations.ItemQuery) ([]*annotations.ItemDTO, error)
+}
+
+// readableFilter filters out the annotations that a user can't read.
+type readableFilter interface {
+	FilterReadable(ctx context.Context, user *user.SignedInUser, items []*annotations.ItemDTO) ([]*annotations.ItemDTO, error)
+}
+
+// routedStore is an external store with the routes of the annotations that are written to it.
+type routedStore struct {
+	externalStore
+	name  string
+	tags  []*tag.Tag
+	types []string
+}
+
+func newRoutedStore(cfg setting.AnnotationStoreSettings, s externalStore) routedStore {
+	return routedStore{
+		externalStore: s,
+		name:          cfg.Name,
+		tags:          tag.ParseTagPairs(cfg.RouteTags),
+		types:         cfg.RouteTypes,
+	}
+}
+
+// matches returns true if the annotation is written to the store. A store without routes doesn't receive any
+// annotation, its annotations are only read.
+func (s routedStore) matches(item *annotations.Item) bool {
+	if len(s.tags) == 0 && len(s.types) == 0 {
+		return false
+	}
+	if len(s.types) > 0 {
+		itemType := setting.AnnotationRouteTypeAnnotation
+		if item.AlertId != 0 {
+			itemType = setting.AnnotationRouteTypeAlert
+		}
+		found := false
+		for _, t := range s.types {
+			if t == itemType {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(s.tags) == 0 {
+		return true
+	}
+	for _, itemTag := range tag.ParseTagPairs(item.Tags) {
+		for _, routeTag := range s.tags {
+			if routeTag.Key == itemTag.Key && (routeTag.Value == "" || routeTag.Value == itemTag.Value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// compositeStore is a store that writes the annotations to the first external store whose routes match them, or
+// to the SQL store otherwise. The queries are run against all the stores, and their results are merged. Updates,
+// deletes, tags and clean ups only apply to the SQL store.
+type compositeStore struct {
+	store
+	access    readableFilter
+	externals []routedStore
+	log       log.Logger
+}
+
+func newCompositeStore(sqlStore store, access readableFilter, externals []routedStore, logger log.Logger) *compositeStore {
+	return &compositeStore{
+		store:     sqlStore,
+		access:    access,
+		externals: externals,
+		log:       logger,
+	}
+}
+
+func (s *compositeStore) route(item *annotations.Item) (routedStore, bool) {
+	for _, external := range s.externals {
+		if external.matches(item) {
+			return external, true
+		}
+	}
+	return routedStore{}, false
+}
+
+func (s *compositeStore) Add(ctx context.Context, item *annotations.Item) error {
+	external, ok := s.route(item)
+	if !ok {
+		return s.store.Add(ctx, item)
+	}
+	if err := prepareExternalItem(item); err != nil {
+		return err
+	}
+	return external.Add(ctx, item)
+}
+
+func (s *compositeStore) AddMany(ctx context.Context, items []annotations.Item) error {
+	sqlItems := make([]annotations.Item, 0, len(items))
+	for i := range items {
+		external, ok := s.route(&items[i])
+		if !ok {
+			sqlItems = append(sqlItems, items[i])
+			continue
+		}
+		if err := prepareExternalItem(&items[i]); err != nil {
+			return err
+		}
+		if err := external.Add(ctx, &items[i]); err != nil {
+			return err
+		}
+	}
+	if len(sqlItems) == 0 {
+		return nil
+	}
+	return s.store.AddMany(ctx, sqlItems)
+}
+
+// Get returns the annotations of all the stores that match the query. The results of an external store that fails
+// are left out, so that the annotations of the other stores are still returned.
+func (s *compositeStore) Get(ctx context.Context, query *annotations.ItemQuery) ([]*annotations.ItemDTO, error) {
+	// The annotations of the external stores don't have an ID.
+	if query.AnnotationId != 0 || len(s.externals) == 0 {
+		return s.store.Get(ctx, query)
+	}
+	if query.Limit == 0 {
+		query.Limit = 100
+	}
+
+	results := make([][]*annotations.ItemDTO, len(s.externals)+1)
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		items, err := s.store.Get(gctx, query)
+		results[0] = items
+		return err
+	})
+	for i, external := range s.externals {
+		i, external := i, external
+		g.Go(func() error {
+			items, err := external.Get(gctx, query)
+			if err != nil {
+				s.log.Warn("Failed to get annotations from external store", "store", external.name, "error", err)
+				return nil
+			}
+			items, err = s.access.FilterReadable(gctx, query.SignedInUser, items)
+			results[i+1] = items
+			return err
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	items := make([]*annotations.ItemDTO, 0, query.Limit)
+	for _, result := range results {
+		items = append(items, result...)
+	}
+	// Same order as the SQL store.
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].TimeEnd != items[j].TimeEnd {
+			return items[i].TimeEnd > items[j].TimeEnd
+		}
+		return items[i].Time > items[j].Time
+	})
+	if int64(len(items)) > query.Limit {
+		items = items[:query.Limit]
+	}
+	return items, nil
+}
+
+// Aggregate returns the number of annotations of all the stores that match the query per time bucket and tag. The
+// annotations of the external stores are aggregated from at most maxExternalAggregateItems annotations per store.
+func (s *compositeStore) Aggregate(ctx context.Context, query *annotations.AggregateQuery) ([]*annotations.AggregateBucket, error) {
+	if query.AnnotationId != 0 || len(s.externals) == 0 {
+		return s.store.Aggregate(ctx, query)
+	}
+
+	results := make([][]*annotations.AggregateBucket, len(s.externals)+1)
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		buckets, err := s.store.Aggregate(gctx, query)
+		results[0] = buckets
+		return err
+	})
+	for i, external := range s.externals {
+		i, external := i, external
+		g.Go(func() error {
+			itemQuery := query.ItemQuery
+			itemQuery.Limit = maxExternalAggregateItems
+			items, err := external.Get(gctx, &itemQuery)
+			if err != nil {
+				s.log.Warn("Failed to get annotations from external store", "store", external.name, "error", err)
+				return nil
+			}
+			items, err = s.access.FilterReadable(gctx, query.SignedInUser, items)
+			results[i+1] = aggregateItems(items, query.Interval)
+			return err
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return mergeBuckets(results...), nil
+}
+
+// aggregateItems counts annotations per time bucket and tag like the SQL store.
+func aggregateItems(items []*annotations.ItemDTO, interval int64) []*annotations.AggregateBucket {
+	buckets := make([]*annotations.AggregateBucket, 0)
+	for _, item := range items {
+		bucketTime := item.Time - item.Time%interval
+		tags := tag.ParseTagPairs(item.Tags)
+		if len(tags) == 0 {
+			buckets = append(buckets, &annotations.AggregateBucket{Time: bucketTime, Count: 1})
+		}
+		for _, t := range tags {
+			buckets = append(buckets, &annotations.AggregateBucket{Time: bucketTime, Tag: tagString(t.Key, t.Value), Count: 1})
+		}
+	}
+	return mergeBuckets(buckets)
+}
+
+// mergeBuckets sums the counts of the buckets with the same time and tag, ordered by time and tag.
+func mergeBuckets(results ...[]*annotations.AggregateBucket) []*annotations.AggregateBucket {
+	type key struct {
+		time int64
+		tag  string
+	}
+	merged := make(map[key]*annotations.AggregateBucket)
+	buckets := make([]*annotations.AggregateBucket, 0)
+	for _, result := range results {
+		for _, bucket := range result {
+			k := key{bucket.Time, bucket.Tag}
+			if existing, ok := merged[k]; ok {
+				existing.Count += bucket.Count
+				continue
+			}
+			b := *bucket
+			merged[k] = &b
+			buckets = append(buckets, &b)
+		}
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		if buckets[i].Time != buckets[j].Time {
+			return buckets[i].Time < buckets[j].Time
+		}
+		return buckets[i].Tag < buckets[j].Tag
+	})
+	return buckets
+}
+
+// prepareExternalItem sets the fields of an annotation that the SQL store sets when it's added.
+func prepareExternalItem(item *annotations.Item) error {
+	item.Tags = tag.JoinTagPairs(tag.ParseTagPairs(item.Tags))
+	item.Created = timeNow().UnixNano() / int64(time.Millisecond)
+	item.Updated = item.Created
+	if item.Epoch == 0 {
+		item.Epoch = item.Created
+	}
+	if err := validateTimeRange(item); err != nil {
+		return err
+	}
+	return validateIncident(item)
+}
@@ -0,0 +1,222 @@
+package annotationsimpl
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/annotations"
+	"github.com/grafana/grafana/pkg/services/user"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+type fakeStore struct {
+	store
+	added   []annotations.Item
+	results []*annotations.ItemDTO
+	buckets []*annotations.AggregateBucket
+	queries []annotations.ItemQuery
+	err     error
+}
+
+func (s *fakeStore) Add(_ context.Context, item *annotations.Item) error {
+	s.added = append(s.added, *item)
+	return s.err
+}
+
+func (s *fakeStore) AddMany(_ context.Context, items []annotations.Item) error {
+	s.added = append(s.added, items...)
+	return s.err
+}
+
+func (s *fakeStore) Get(_ context.Context, query *annotations.ItemQuery) ([]*annotations.ItemDTO, error) {
+	s.queries = append(s.queries, *query)
+	return s.results, s.err
+}
+
+func (s *fakeStore) Aggregate(_ context.Context, query *annotations.AggregateQuery) ([]*annotations.AggregateBucket, error) {
+	s.queries = append(s.queries, query.ItemQuery)
+	return s.buckets, s.err
+}
+
+type fakeReadableFilter struct {
+	unreadableDashboards map[int64]bool
+}
+
+func (f fakeReadableFilter) FilterReadable(_ context.Context, _ *user.SignedInUser, items []*annotations.ItemDTO) ([]*annotations.ItemDTO, error) {
+	readable := make([]*annotations.ItemDTO, 0, len(items))
+	for _, item := range items {
+		if !f.unreadableDashboards[item.DashboardId] {
+			readable = append(readable, item)
+		}
+	}
+	return readable, nil
+}
+
+func TestRoutedStore(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		cfg     setting.AnnotationStoreSettings
+		item    annotations.Item
+		matches bool
+	}{
+		{
+			desc:    "a store without routes doesn't match",
+			cfg:     setting.AnnotationStoreSettings{},
+			item:    annotations.Item{Tags: []string{"deploy"}},
+			matches: false,
+		},
+		{
+			desc:    "a tag without value matches the tags with the same key",
+			cfg:     setting.AnnotationStoreSettings{RouteTags: []string{"deploy"}},
+			item:    annotations.Item{Tags: []string{"team:a", "deploy:api"}},
+			matches: true,
+		},
+		{
+			desc:    "a tag with a value only matches the same value",
+			cfg:     setting.AnnotationStoreSettings{RouteTags: []string{"deploy:web"}},
+			item:    annotations.Item{Tags: []string{"deploy:api"}},
+			matches: false,
+		},
+		{
+			desc:    "a type matches the annotations of the type",
+			cfg:     setting.AnnotationStoreSettings{RouteTypes: []string{setting.AnnotationRouteTypeAlert}},
+			item:    annotations.Item{AlertId: 1},
+			matches: true,
+		},
+		{
+			desc:    "a type doesn't match the annotations of other types",
+			cfg:     setting.AnnotationStoreSettings{RouteTypes: []string{setting.AnnotationRouteTypeAlert}},
+			item:    annotations.Item{Tags: []string{"deploy"}},
+			matches: false,
+		},
+		{
+			desc:    "the annotations must match both the types and the tags",
+			cfg:     setting.AnnotationStoreSettings{RouteTypes: []string{setting.AnnotationRouteTypeAnnotation}, RouteTags: []string{"deploy"}},
+			item:    annotations.Item{AlertId: 1, Tags: []string{"deploy"}},
+			matches: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			s := newRoutedStore(tc.cfg, &fakeStore{})
+			require.Equal(t, tc.matches, s.matches(&tc.item))
+		})
+	}
+}
+
+func TestCompositeStore(t *testing.T) {
+	newStore := func(sqlStore *fakeStore, externals ...*fakeStore) *compositeStore {
+		routed := []routedStore{
+			newRoutedStore(setting.AnnotationStoreSettings{Name: "deploys", RouteTags: []string{"deploy"}}, externals[0]),
+		}
+		for _, external := range externals[1:] {
+			routed = append(routed, newRoutedStore(setting.AnnotationStoreSettings{Name: "read-only"}, external))
+		}
+		return newCompositeStore(sqlStore, fakeReadableFilter{unreadableDashboards: map[int64]bool{2: true}}, routed, log.NewNopLogger())
+	}
+
+	t.Run("routes the annotations to the first store that matches", func(t *testing.T) {
+		sqlStore, deploys, readOnly := &fakeStore{}, &fakeStore{}, &fakeStore{}
+		s := newStore(sqlStore, deploys, readOnly)
+
+		require.NoError(t, s.Add(context.Background(), &annotations.Item{Text: "deploy", Tags: []string{"deploy:api"}, Epoch: 10}))
+		require.NoError(t, s.Add(context.Background(), &annotations.Item{Text: "outage", Tags: []string{"outage"}, Epoch: 10}))
+		require.NoError(t, s.AddMany(context.Background(), []annotations.Item{
+			{Text: "second deploy", Tags: []string{"deploy"}, Epoch: 10},
+			{Text: "comment", Epoch: 10},
+		}))
+
+		require.Len(t, deploys.added, 2)
+		require.Equal(t, "deploy", deploys.added[0].Text)
+		require.Equal(t, int64(10), deploys.added[0].EpochEnd)
+		require.NotZero(t, deploys.added[0].Created)
+		require.Equal(t, "second deploy", deploys.added[1].Text)
+		require.Len(t, sqlStore.added, 2)
+		require.Equal(t, "outage", sqlStore.added[0].Text)
+		require.Equal(t, "comment", sqlStore.added[1].Text)
+		require.Empty(t, readOnly.added)
+	})
+
+	t.Run("validates the time range of the annotations of external stores", func(t *testing.T) {
+		s := newStore(&fakeStore{}, &fakeStore{})
+		timeNow = func() time.Time { return time.Unix(0, 0) }
+		t.Cleanup(func() { timeNow = time.Now })
+
+		err := s.Add(context.Background(), &annotations.Item{Tags: []string{"deploy"}})
+		require.ErrorIs(t, err, annotations.ErrTimerangeMissing)
+	})
+
+	t.Run("merges the annotations of all the stores", func(t *testing.T) {
+		sqlStore := &fakeStore{results: []*annotations.ItemDTO{{Id: 1, Time: 30, TimeEnd: 30}, {Id: 2, Time: 10, TimeEnd: 10}}}
+		deploys := &fakeStore{results: []*annotations.ItemDTO{{Text: "a", Time: 20, TimeEnd: 20}, {Text: "b", Time: 5, TimeEnd: 5}}}
+		readOnly := &fakeStore{results: []*annotations.ItemDTO{{Text: "c", Time: 25, TimeEnd: 25, DashboardId: 2}, {Text: "d", Time: 15, TimeEnd: 40}}}
+		s := newStore(sqlStore, deploys, readOnly)
+
+		items, err := s.Get(context.Background(), &annotations.ItemQuery{OrgId: 1, Limit: 4})
+		require.NoError(t, err)
+
+		require.Len(t, items, 4)
+		require.Equal(t, "d", items[0].Text)
+		require.Equal(t, int64(1), items[1].Id)
+		require.Equal(t, "a", items[2].Text)
+		require.Equal(t, int64(2), items[3].Id)
+		for _, store := range []*fakeStore{sqlStore, deploys, readOnly} {
+			require.Len(t, store.queries, 1)
+			require.Equal(t, int64(4), store.queries[0].Limit)
+		}
+	})
+
+	t.Run("leaves out the annotations of an external store that fails", func(t *testing.T) {
+		sqlStore := &fakeStore{results: []*annotations.ItemDTO{{Id: 1}}}
+		deploys := &fakeStore{err: errors.New("loki is down")}
+		s := newStore(sqlStore, deploys)
+
+		items, err := s.Get(context.Background(), &annotations.ItemQuery{OrgId: 1})
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+	})
+
+	t.Run("fails if the SQL store fails", func(t *testing.T) {
+		s := newStore(&fakeStore{err: errors.New("database is down")}, &fakeStore{})
+
+		_, err := s.Get(context.Background(), &annotations.ItemQuery{OrgId: 1})
+		require.Error(t, err)
+	})
+
+	t.Run("only queries the SQL store for an annotation ID", func(t *testing.T) {
+		sqlStore, deploys := &fakeStore{results: []*annotations.ItemDTO{{Id: 1}}}, &fakeStore{}
+		s := newStore(sqlStore, deploys)
+
+		items, err := s.Get(context.Background(), &annotations.ItemQuery{OrgId: 1, AnnotationId: 1})
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		require.Empty(t, deploys.queries)
+	})
+
+	t.Run("aggregates the annotations of all the stores", func(t *testing.T) {
+		sqlStore := &fakeStore{buckets: []*annotations.AggregateBucket{{Time: 0, Tag: "outage", Count: 2}, {Time: 100, Tag: "deploy", Count: 1}}}
+		deploys := &fakeStore{results: []*annotations.ItemDTO{
+			{Time: 150, Tags: []string{"deploy", "service:api"}},
+			{Time: 120, Tags: []string{"deploy"}},
+			{Time: 50},
+			{Time: 10, Tags: []string{"deploy"}, DashboardId: 2},
+		}}
+		s := newStore(sqlStore, deploys)
+
+		buckets, err := s.Aggregate(context.Background(), &annotations.AggregateQuery{ItemQuery: annotations.ItemQuery{OrgId: 1}, Interval: 100})
+		require.NoError(t, err)
+		require.Equal(t, []*annotations.AggregateBucket{
+			{Time: 0, Tag: "", Count: 1},
+			{Time: 0, Tag: "outage", Count: 2},
+			{Time: 100, Tag: "deploy", Count: 3},
+			{Time: 100, Tag: "service:api", Count: 1},
+		}, buckets)
+		require.Equal(t, int64(maxExternalAggregateItems), deploys.queries[0].Limit)
+	})
+}
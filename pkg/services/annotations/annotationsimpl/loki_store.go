@@ -0,0 +1,450 @@
+package annotationsimpl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grafana/dskit/backoff"
+	"github.com/grafana/dskit/flagext"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/config"
+	"github.com/prometheus/common/model"
+
+	"github.com/grafana/grafana/pkg/components/loki/logproto"
+	"github.com/grafana/grafana/pkg/components/loki/lokihttp"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/annotations"
+	"github.com/grafana/grafana/pkg/services/tag"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+const (
+	lokiPushPath  = "/loki/api/v1/push"
+	lokiQueryPath = "/loki/api/v1/query_range"
+
+	// lokiDefaultQueryRange is the time range of the queries without a time range.
+	lokiDefaultQueryRange = 7 * 24 * time.Hour
+	// lokiMaxQueryPages is the maximum number of queries sent to Loki to find the annotations that match the
+	// filters applied after the query.
+	lokiMaxQueryPages = 10
+)
+
+// lokiAnnotation is the log line of an annotation in Loki. The ID fields are always written so that the queries
+// can filter on them.
+type lokiAnnotation struct {
+	Type        string           `json:"type"`
+	DashboardID int64            `json:"dashboard_id"`
+	PanelID     int64            `json:"panel_id"`
+	UserID      int64            `json:"user_id"`
+	AlertID     int64            `json:"alert_id"`
+	Text        string           `json:"text,omitempty"`
+	Tags        []string         `json:"tags,omitempty"`
+	PrevState   string           `json:"prev_state,omitempty"`
+	NewState    string           `json:"new_state,omitempty"`
+	TimeEnd     int64            `json:"time_end"`
+	Created     int64            `json:"created"`
+	Data        *simplejson.Json `json:"data,omitempty"`
+	Severity    string           `json:"severity,omitempty"`
+	Links       []string         `json:"links,omitempty"`
+	OwnerID     int64            `json:"owner_id"`
+}
+
+var (
+	errLokiDashboardUID = errors.New("the annotations can't be filtered by dashboard UID without the dashboard ID")
+	errLokiStopped      = errors.New("the annotation store is stopped")
+)
+
+type lokiQueryResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		Result []struct {
+			Stream map[string]string `json:"stream"`
+			Values [][2]string       `json:"values"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// lokiStore stores the annotations in a Loki stream per organization. The annotations are pushed in batches, so
+// an annotation can be queried a few seconds after it's added.
+type lokiStore struct {
+	queryURL *url.URL
+	client   lokihttp.Client
+	http     *http.Client
+	log      log.Logger
+
+	// stopMtx keeps the annotations from being queued while the client is stopped.
+	stopMtx sync.RWMutex
+	stopped bool
+}
+
+func newLokiStore(cfg setting.AnnotationStoreSettings, logger log.Logger, reg prometheus.Registerer) (*lokiStore, error) {
+	httpCfg := config.HTTPClientConfig{}
+	if cfg.BasicAuthUser != "" {
+		httpCfg.BasicAuth = &config.BasicAuth{
+			Username: cfg.BasicAuthUser,
+			Password: config.Secret(cfg.BasicAuthPassword),
+		}
+	}
+	withTenant := func(next http.RoundTripper) http.RoundTripper {
+		return tenantRoundTripper{tenantID: cfg.TenantID, next: next}
+	}
+
+	client, err := lokihttp.NewWithTripperware(reg, lokihttp.Config{
+		URL:       flagext.URLValue{URL: cfg.URL.JoinPath(lokiPushPath)},
+		BatchWait: time.Second,
+		BatchSize: 1024 * 1024,
+		Client:    httpCfg,
+		BackoffConfig: backoff.Config{
+			MinBackoff: 500 * time.Millisecond,
+			MaxBackoff: time.Minute,
+			MaxRetries: 10,
+		},
+		Timeout: cfg.Timeout,
+	}, logger, withTenant)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient, err := config.NewClientFromConfig(httpCfg, "annotations")
+	if err != nil {
+		return nil, err
+	}
+	httpClient.Transport = withTenant(httpClient.Transport)
+	httpClient.Timeout = cfg.Timeout
+
+	return &lokiStore{
+		queryURL: cfg.URL.JoinPath(lokiQueryPath),
+		client:   client,
+		http:     httpClient,
+		log:      logger,
+	}, nil
+}
+
+func lokiStreamLabels(orgID int64) model.LabelSet {
+	return model.LabelSet{
+		"from":   "grafana",
+		"source": "annotations",
+		"org_id": model.LabelValue(strconv.FormatInt(orgID, 10)),
+	}
+}
+
+// Add queues an annotation to be pushed to Loki. The annotations of Loki have no ID, so the ID of the item stays 0.
+// The annotations that fail to be pushed are logged by the client and counted by the
+// promtail_dropped_entries_total metric.
+func (s *lokiStore) Add(ctx context.Context, item *annotations.Item) error {
+	line := lokiAnnotation{
+		Type:        setting.AnnotationRouteTypeAnnotation,
+		DashboardID: item.DashboardId,
+		PanelID:     item.PanelId,
+		UserID:      item.UserId,
+		AlertID:     item.AlertId,
+		Text:        item.Text,
+		Tags:        item.Tags,
+		PrevState:   item.PrevState,
+		NewState:    item.NewState,
+		TimeEnd:     item.EpochEnd,
+		Created:     item.Created,
+		Data:        item.Data,
+		Severity:    item.Severity,
+		Links:       item.Links,
+		OwnerID:     item.OwnerId,
+	}
+	if item.AlertId != 0 {
+		line.Type = setting.AnnotationRouteTypeAlert
+	}
+	b, err := json.Marshal(line)
+	if err != nil {
+		return err
+	}
+
+	entry := lokihttp.Entry{
+		Labels: lokiStreamLabels(item.OrgId),
+		Entry: logproto.Entry{
+			Timestamp: time.UnixMilli(item.Epoch),
+			Line:      string(b),
+		},
+	}
+	s.stopMtx.RLock()
+	defer s.stopMtx.RUnlock()
+	if s.stopped {
+		return errLokiStopped
+	}
+	// The client blocks while it pushes a batch, so the request may be canceled first.
+	select {
+	case s.client.Chan() <- entry:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// stop pushes the queued annotations and stops the client.
+func (s *lokiStore) stop() {
+	s.stopMtx.Lock()
+	s.stopped = true
+	s.stopMtx.Unlock()
+	s.client.Stop()
+}
+
+// Get queries the annotations whose start is in the time range of the query. The filters of the query are applied
+// by Loki, except for the tags whose filter in Loki is a superset of the query's and the tag regex. These are
+// applied to the results, so Loki is queried again for older annotations until there are enough of them. The
+// annotations only have the ID of their dashboard, so a dashboard UID must be resolved to its ID first.
+func (s *lokiStore) Get(ctx context.Context, query *annotations.ItemQuery) ([]*annotations.ItemDTO, error) {
+	if query.DashboardUid != "" && query.DashboardId == 0 {
+		return nil, errLokiDashboardUID
+	}
+	end := time.Now()
+	if query.To > 0 {
+		end = time.UnixMilli(query.To)
+	}
+	start := end.Add(-lokiDefaultQueryRange)
+	if query.From > 0 {
+		start = time.UnixMilli(query.From)
+	}
+	limit := query.Limit
+	if limit == 0 {
+		limit = 100
+	}
+	var tagRegex *regexp.Regexp
+	if query.TagRegex != "" {
+		var err error
+		if tagRegex, err = regexp.Compile(query.TagRegex); err != nil {
+			return nil, annotations.ErrBaseInvalidTagRegex.Errorf("failed to compile tag regex: %w", err)
+		}
+	}
+
+	selector := lokiSelector(query)
+	filtered := len(query.Tags) > 0 || tagRegex != nil
+	items := make([]*annotations.ItemDTO, 0)
+	for page := 0; page < lokiMaxQueryPages; page++ {
+		result, err := s.query(ctx, selector, start, end, limit)
+		if err != nil {
+			return nil, err
+		}
+
+		count := 0
+		oldest := end
+		for _, stream := range result.Data.Result {
+			for _, value := range stream.Values {
+				count++
+				item, err := lokiItem(value)
+				if err != nil {
+					s.log.Warn("Skipping an invalid annotation", "error", err)
+					continue
+				}
+				if ts, err := strconv.ParseInt(value[0], 10, 64); err == nil && time.Unix(0, ts).Before(oldest) {
+					oldest = time.Unix(0, ts)
+				}
+				if matchesTags(item.Tags, query.Tags, query.MatchAny) && matchesTagRegex(item.Tags, tagRegex) {
+					items = append(items, item)
+				}
+			}
+		}
+		// The end of the range is exclusive, so the next page starts before the oldest annotation.
+		if !filtered || int64(len(items)) >= limit || int64(count) < limit || !oldest.Before(end) {
+			break
+		}
+		end = oldest.Add(-time.Nanosecond)
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Time > items[j].Time
+	})
+	if int64(len(items)) > limit {
+		items = items[:limit]
+	}
+	return items, nil
+}
+
+// query returns the annotations of a LogQL selector in a time range, the most recent first.
+func (s *lokiStore) query(ctx context.Context, selector string, start, end time.Time, limit int64) (*lokiQueryResponse, error) {
+	params := url.Values{}
+	params.Set("query", selector)
+	params.Set("start", strconv.FormatInt(start.UnixNano(), 10))
+	// The end of the range is exclusive.
+	params.Set("end", strconv.FormatInt(end.UnixNano()+1, 10))
+	params.Set("limit", strconv.FormatInt(limit, 10))
+	params.Set("direction", "backward")
+	u := *s.queryURL
+	u.RawQuery = params.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", lokihttp.UserAgent)
+	resp, err := s.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			s.log.Warn("Failed to close the response body", "error", err)
+		}
+	}()
+	if resp.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("loki returned HTTP status %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var result lokiQueryResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode the loki response: %w", err)
+	}
+	return &result, nil
+}
+
+func lokiItem(value [2]string) (*annotations.ItemDTO, error) {
+	ts, err := strconv.ParseInt(value[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timestamp %q: %w", value[0], err)
+	}
+	var line lokiAnnotation
+	if err := json.Unmarshal([]byte(value[1]), &line); err != nil {
+		return nil, err
+	}
+	epoch := time.Unix(0, ts).UnixMilli()
+	timeEnd := line.TimeEnd
+	if timeEnd == 0 {
+		timeEnd = epoch
+	}
+	return &annotations.ItemDTO{
+		AlertId:     line.AlertID,
+		DashboardId: line.DashboardID,
+		PanelId:     line.PanelID,
+		UserId:      line.UserID,
+		NewState:    line.NewState,
+		PrevState:   line.PrevState,
+		Created:     line.Created,
+		Updated:     line.Created,
+		Time:        epoch,
+		TimeEnd:     timeEnd,
+		Text:        line.Text,
+		Tags:        line.Tags,
+		Data:        line.Data,
+		Severity:    line.Severity,
+		Links:       line.Links,
+		OwnerId:     line.OwnerID,
+	}, nil
+}
+
+// lokiSelector returns the LogQL query of the annotations that match the query.
+func lokiSelector(query *annotations.ItemQuery) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, `{from="grafana",org_id="%d",source="annotations"}`, query.OrgId)
+
+	// The tags are filtered on the line because the json parser doesn't extract arrays. A tag without a value
+	// matches the tags with the same key and any value.
+	if len(query.Tags) > 0 {
+		patterns := make([]string, 0, len(query.Tags))
+		for _, t := range tag.ParseTagPairs(query.Tags) {
+			patterns = append(patterns, regexp.QuoteMeta(`"`+t.Key))
+		}
+		if query.MatchAny {
+			fmt.Fprintf(&b, " |~ %q", strings.Join(patterns, "|"))
+		} else {
+			for _, p := range patterns {
+				fmt.Fprintf(&b, " |~ %q", p)
+			}
+		}
+	}
+
+	// The links are filtered on the line for the same reason, they are encoded as in the line.
+	if query.Link != "" {
+		link, _ := json.Marshal(query.Link)
+		fmt.Fprintf(&b, " |= %q", link)
+	}
+
+	b.WriteString(" | json")
+	for _, filter := range []struct {
+		name  string
+		value int64
+	}{
+		{"dashboard_id", query.DashboardId},
+		{"panel_id", query.PanelId},
+		{"user_id", query.UserId},
+		{"alert_id", query.AlertId},
+		{"owner_id", query.OwnerId},
+	} {
+		if filter.value != 0 {
+			fmt.Fprintf(&b, ` | %s="%d"`, filter.name, filter.value)
+		}
+	}
+	if query.Type == setting.AnnotationRouteTypeAlert || query.Type == setting.AnnotationRouteTypeAnnotation {
+		fmt.Fprintf(&b, ` | type=%q`, query.Type)
+	}
+	if len(query.Severities) > 0 {
+		severities := make([]string, 0, len(query.Severities))
+		for _, severity := range query.Severities {
+			severities = append(severities, regexp.QuoteMeta(severity))
+		}
+		fmt.Fprintf(&b, ` | severity=~%q`, strings.Join(severities, "|"))
+	}
+	for _, term := range strings.Fields(query.Text) {
+		fmt.Fprintf(&b, ` | text=~%q`, "(?is).*"+regexp.QuoteMeta(term)+".*")
+	}
+	return b.String()
+}
+
+// matchesTags returns true if the tags of an annotation match the tags of a query, with the same rules as the
+// SQL store.
+func matchesTags(itemTags []string, queryTags []string, matchAny bool) bool {
+	if len(queryTags) == 0 {
+		return true
+	}
+	parsed := tag.ParseTagPairs(itemTags)
+	wanted := tag.ParseTagPairs(queryTags)
+	matched := 0
+	for _, queryTag := range wanted {
+		for _, itemTag := range parsed {
+			if queryTag.Key == itemTag.Key && (queryTag.Value == "" || queryTag.Value == itemTag.Value) {
+				matched++
+				break
+			}
+		}
+	}
+	if matchAny {
+		return matched > 0
+	}
+	return matched == len(wanted)
+}
+
+// matchesTagRegex returns true if one of the tags of an annotation matches a tag regex in the key:value format, or
+// if there is no regex.
+func matchesTagRegex(itemTags []string, tagRegex *regexp.Regexp) bool {
+	if tagRegex == nil {
+		return true
+	}
+	for _, t := range tag.ParseTagPairs(itemTags) {
+		if tagRegex.MatchString(tagString(t.Key, t.Value)) {
+			return true
+		}
+	}
+	return false
+}
+
+// tenantRoundTripper sets the tenant of the requests to Loki.
+type tenantRoundTripper struct {
+	tenantID string
+	next     http.RoundTripper
+}
+
+func (t tenantRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.tenantID != "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("X-Scope-OrgID", t.tenantID)
+	}
+	return t.next.RoundTrip(req)
+}
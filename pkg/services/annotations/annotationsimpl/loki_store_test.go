@@ -0,0 +1,231 @@
+package annotationsimpl
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/components/loki/lokihttp"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/annotations"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+func TestLokiStore_Add(t *testing.T) {
+	client := lokihttp.NewFake()
+	s := &lokiStore{client: client, log: log.NewNopLogger()}
+
+	err := s.Add(context.Background(), &annotations.Item{
+		OrgId:    2,
+		AlertId:  3,
+		Text:     "alert",
+		Tags:     []string{"deploy:api"},
+		Epoch:    1000,
+		EpochEnd: 2000,
+		Created:  3000,
+	})
+	require.NoError(t, err)
+	client.Stop()
+
+	require.Equal(t, model.LabelSet{"from": "grafana", "source": "annotations", "org_id": "2"}, client.Labels)
+	var line lokiAnnotation
+	require.NoError(t, json.Unmarshal([]byte(client.Entry), &line))
+	require.Equal(t, lokiAnnotation{
+		Type:    setting.AnnotationRouteTypeAlert,
+		AlertID: 3,
+		Text:    "alert",
+		Tags:    []string{"deploy:api"},
+		TimeEnd: 2000,
+		Created: 3000,
+	}, line)
+}
+
+// blockedLokiClient never accepts entries, like a client that is pushing a batch to an unavailable Loki.
+type blockedLokiClient struct {
+	lokihttp.Client
+}
+
+func (blockedLokiClient) Chan() chan<- lokihttp.Entry { return make(chan lokihttp.Entry) }
+
+func TestLokiStore_AddCanceled(t *testing.T) {
+	s := &lokiStore{client: blockedLokiClient{}, log: log.NewNopLogger()}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := s.Add(ctx, &annotations.Item{OrgId: 1, Text: "deploy"})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestLokiStore_Stop(t *testing.T) {
+	client := lokihttp.NewFake()
+	s := &lokiStore{client: client, log: log.NewNopLogger()}
+
+	require.NoError(t, s.Add(context.Background(), &annotations.Item{OrgId: 1, Text: "deploy"}))
+	s.stop()
+	require.NotEmpty(t, client.Entry)
+
+	err := s.Add(context.Background(), &annotations.Item{OrgId: 1, Text: "deploy"})
+	require.ErrorIs(t, err, errLokiStopped)
+}
+
+func TestLokiStore_GetPages(t *testing.T) {
+	var ends []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ends = append(ends, r.URL.Query().Get("end"))
+		// The first page only has annotations with other tags, the second one the annotation of the query.
+		if len(ends) == 1 {
+			_, _ = w.Write([]byte(`{"status":"success","data":{"result":[{"stream":{},"values":[
+				["3000000000","{\"type\":\"annotation\",\"tags\":[\"deploy:web\"]}"],
+				["2000000000","{\"type\":\"annotation\",\"tags\":[\"deploy:web\"]}"]
+			]}]}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"success","data":{"result":[{"stream":{},"values":[
+			["1000000000","{\"type\":\"annotation\",\"tags\":[\"deploy:api\"]}"]
+		]}]}}`))
+	}))
+	t.Cleanup(server.Close)
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	s, err := newLokiStore(setting.AnnotationStoreSettings{URL: u, Timeout: time.Second}, log.NewNopLogger(), nil)
+	require.NoError(t, err)
+
+	items, err := s.Get(context.Background(), &annotations.ItemQuery{OrgId: 1, Tags: []string{"deploy:api"}, To: 4000, Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	require.Equal(t, int64(1000), items[0].Time)
+	require.Equal(t, []string{"4000000001", "2000000000"}, ends)
+}
+
+func TestLokiStore_Get(t *testing.T) {
+	var received *http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"streams","result":[{
+			"stream":{"org_id":"1"},
+			"values":[
+				["1000000000","{\"type\":\"annotation\",\"dashboard_id\":1,\"text\":\"old\",\"tags\":[\"deploy:api\"],\"time_end\":1500,\"created\":1000}"],
+				["3000000000","{\"type\":\"annotation\",\"dashboard_id\":1,\"text\":\"new\",\"tags\":[\"deploy:api\"],\"time_end\":3000,\"created\":3000}"],
+				["2000000000","{\"type\":\"annotation\",\"dashboard_id\":1,\"text\":\"other tag\",\"tags\":[\"deploy:web\"],\"created\":2000}"],
+				["2500000000","not json"]
+			]}]}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	s, err := newLokiStore(setting.AnnotationStoreSettings{URL: u, TenantID: "tenant", Timeout: time.Second}, log.NewNopLogger(), nil)
+	require.NoError(t, err)
+	t.Cleanup(s.client.StopNow)
+
+	items, err := s.Get(context.Background(), &annotations.ItemQuery{
+		OrgId:       1,
+		DashboardId: 1,
+		Tags:        []string{"deploy:api"},
+		From:        1000,
+		To:          4000,
+		Limit:       10,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, lokiQueryPath, received.URL.Path)
+	require.Equal(t, "tenant", received.Header.Get("X-Scope-OrgID"))
+	params := received.URL.Query()
+	require.Equal(t, `{from="grafana",org_id="1",source="annotations"} |~ "\"deploy" | json | dashboard_id="1"`, params.Get("query"))
+	require.Equal(t, "1000000000", params.Get("start"))
+	require.Equal(t, "4000000001", params.Get("end"))
+	require.Equal(t, "10", params.Get("limit"))
+	require.Equal(t, "backward", params.Get("direction"))
+
+	require.Len(t, items, 2)
+	require.Equal(t, "new", items[0].Text)
+	require.Equal(t, int64(3000), items[0].Time)
+	require.Equal(t, int64(3000), items[0].TimeEnd)
+	require.Equal(t, int64(1), items[0].DashboardId)
+	require.Equal(t, "old", items[1].Text)
+	require.Equal(t, int64(1000), items[1].Time)
+	require.Equal(t, int64(1500), items[1].TimeEnd)
+}
+
+func TestLokiStore_GetFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "too many outstanding requests", http.StatusTooManyRequests)
+	}))
+	t.Cleanup(server.Close)
+
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	s, err := newLokiStore(setting.AnnotationStoreSettings{URL: u, Timeout: time.Second}, log.NewNopLogger(), nil)
+	require.NoError(t, err)
+	t.Cleanup(s.client.StopNow)
+
+	_, err = s.Get(context.Background(), &annotations.ItemQuery{OrgId: 1})
+	require.ErrorContains(t, err, "too many outstanding requests")
+}
+
+func TestLokiStore_GetByDashboardUID(t *testing.T) {
+	s := &lokiStore{log: log.NewNopLogger()}
+
+	_, err := s.Get(context.Background(), &annotations.ItemQuery{OrgId: 1, DashboardUid: "dash"})
+	require.ErrorIs(t, err, errLokiDashboardUID)
+}
+
+func TestLokiSelector(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		query    annotations.ItemQuery
+		expected string
+	}{
+		{
+			desc:     "without filters",
+			query:    annotations.ItemQuery{OrgId: 1},
+			expected: `{from="grafana",org_id="1",source="annotations"} | json`,
+		},
+		{
+			desc:     "with all the tags",
+			query:    annotations.ItemQuery{OrgId: 1, Tags: []string{"deploy:api", "team.a"}},
+			expected: `{from="grafana",org_id="1",source="annotations"} |~ "\"deploy" |~ "\"team\\.a" | json`,
+		},
+		{
+			desc:     "with any of the tags",
+			query:    annotations.ItemQuery{OrgId: 1, Tags: []string{"deploy", "outage"}, MatchAny: true},
+			expected: `{from="grafana",org_id="1",source="annotations"} |~ "\"deploy|\"outage" | json`,
+		},
+		{
+			desc:     "with the ids and the type",
+			query:    annotations.ItemQuery{OrgId: 1, DashboardId: 2, PanelId: 3, UserId: 4, AlertId: 5, Type: "alert"},
+			expected: `{from="grafana",org_id="1",source="annotations"} | json | dashboard_id="2" | panel_id="3" | user_id="4" | alert_id="5" | type="alert"`,
+		},
+		{
+			desc:     "with the incident fields",
+			query:    annotations.ItemQuery{OrgId: 1, Severities: []string{"critical", "high"}, OwnerId: 2, Link: "https://example.com/?a=b&c=d"},
+			expected: `{from="grafana",org_id="1",source="annotations"} |= "\"https://example.com/?a=b\\u0026c=d\"" | json | owner_id="2" | severity=~"critical|high"`,
+		},
+		{
+			desc:     "with the text",
+			query:    annotations.ItemQuery{OrgId: 1, Text: "api v1.2"},
+			expected: `{from="grafana",org_id="1",source="annotations"} | json | text=~"(?is).*api.*" | text=~"(?is).*v1\\.2.*"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			require.Equal(t, tc.expected, lokiSelector(&tc.query))
+		})
+	}
+}
+
+func TestMatchesTags(t *testing.T) {
+	require.True(t, matchesTags([]string{"deploy:api"}, nil, false))
+	require.True(t, matchesTags([]string{"deploy:api", "team:a"}, []string{"deploy", "team:a"}, false))
+	require.False(t, matchesTags([]string{"deploy:api"}, []string{"deploy", "team:a"}, false))
+	require.True(t, matchesTags([]string{"deploy:api"}, []string{"deploy", "team:a"}, true))
+	require.False(t, matchesTags([]string{"deploy:api"}, []string{"deploy:web"}, true))
+}
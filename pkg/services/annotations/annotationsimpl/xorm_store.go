@@ -3,8 +3,11 @@ package annotationsimpl
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
+	"regexp"
 	"strings"
 	"time"
 
@@ -23,6 +26,14 @@ import (
 
 var timeNow = time.Now
 
+// maxTagRegexMatches is the maximum number of tags a tag regex of a query can match.
+const maxTagRegexMatches = 1000
+
+// tagIDsBatchSize is the number of tag IDs looked up per query, below the limit of parameters of SQLite.
+const tagIDsBatchSize = 500
+
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
 // Update the item so that EpochEnd >= Epoch
 func validateTimeRange(item *annotations.Item) error {
 	if item.EpochEnd == 0 {
@@ -40,6 +51,23 @@ func validateTimeRange(item *annotations.Item) error {
 	return nil
 }
 
+// validateIncident validates the severity and the links of an annotation.
+func validateIncident(item *annotations.Item) error {
+	if !annotations.IsValidSeverity(item.Severity) {
+		return annotations.ErrBaseInvalidSeverity.Errorf("invalid severity %q", item.Severity)
+	}
+	if len(item.Links) > annotations.MaxLinks {
+		return annotations.ErrBaseInvalidLinks.Errorf("the annotation has %d links, the maximum is %d", len(item.Links), annotations.MaxLinks)
+	}
+	for _, link := range item.Links {
+		u, err := url.Parse(link)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return annotations.ErrBaseInvalidLinks.Errorf("invalid link %q", link)
+		}
+	}
+	return nil
+}
+
 type xormRepositoryImpl struct {
 	cfg               *setting.Cfg
 	db                db.DB
@@ -154,6 +182,9 @@ func (r *xormRepositoryImpl) Update(ctx context.Context, item *annotations.Item)
 
 		existing.Updated = timeNow().UnixNano() / int64(time.Millisecond)
 		existing.Text = item.Text
+		existing.Severity = item.Severity
+		existing.Links = item.Links
+		existing.OwnerId = item.OwnerId
 
 		if item.Epoch != 0 {
 			existing.Epoch = item.Epoch
@@ -183,7 +214,7 @@ func (r *xormRepositoryImpl) Update(ctx context.Context, item *annotations.Item)
 			return err
 		}
 
-		_, err = sess.Table("annotation").ID(existing.Id).Cols("epoch", "text", "epoch_end", "updated", "tags").Update(existing)
+		_, err = sess.Table("annotation").ID(existing.Id).Cols("epoch", "text", "epoch_end", "updated", "tags", "severity", "links", "owner_id").Update(existing)
 		return err
 	})
 }
@@ -208,112 +239,282 @@ func (r *xormRepositoryImpl) Get(ctx context.Context, query *annotations.ItemQue
 				annotation.data,
 				annotation.created,
 				annotation.updated,
+				annotation.severity,
+				annotation.links,
+				annotation.owner_id,
 				usr.email,
 				usr.login,
+				owner_usr.login as owner_login,
 				alert.name as alert_name
 			FROM annotation
 			LEFT OUTER JOIN ` + r.db.GetDialect().Quote("user") + ` as usr on usr.id = annotation.user_id
+			LEFT OUTER JOIN ` + r.db.GetDialect().Quote("user") + ` as owner_usr on owner_usr.id = annotation.owner_id
 			LEFT OUTER JOIN alert on alert.id = annotation.alert_id
 			INNER JOIN (
 				SELECT a.id from annotation a
 			`)
 
-		sql.WriteString(`WHERE a.org_id = ?`)
-		params = append(params, query.OrgId)
-
-		if query.AnnotationId != 0 {
-			// fmt.Print("annotation query")
-			sql.WriteString(` AND a.id = ?`)
-			params = append(params, query.AnnotationId)
+		filter, filterParams, err := r.queryFilter(sess, query)
+		if err != nil {
+			return err
 		}
+		sql.WriteString(filter)
+		params = append(params, filterParams...)
 
-		if query.AlertId != 0 {
-			sql.WriteString(` AND a.alert_id = ?`)
-			params = append(params, query.AlertId)
+		if query.Limit == 0 {
+			query.Limit = 100
 		}
 
-		if query.DashboardId != 0 {
-			sql.WriteString(` AND a.dashboard_id = ?`)
-			params = append(params, query.DashboardId)
+		// order of ORDER BY arguments match the order of a sql index for performance
+		sql.WriteString(" ORDER BY a.org_id, a.epoch_end DESC, a.epoch DESC" + r.db.GetDialect().Limit(query.Limit) + " ) dt on dt.id = annotation.id")
+		if err := sess.SQL(sql.String(), params...).Find(&items); err != nil {
+			items = nil
+			return err
 		}
+		return nil
+	},
+	)
 
-		if query.PanelId != 0 {
-			sql.WriteString(` AND a.panel_id = ?`)
-			params = append(params, query.PanelId)
-		}
+	return items, err
+}
 
-		if query.UserId != 0 {
-			sql.WriteString(` AND a.user_id = ?`)
-			params = append(params, query.UserId)
+func (r *xormRepositoryImpl) Aggregate(ctx context.Context, query *annotations.AggregateQuery) ([]*annotations.AggregateBucket, error) {
+	if query.Interval <= 0 {
+		return nil, annotations.ErrBaseInvalidInterval.Errorf("invalid interval %d", query.Interval)
+	}
+
+	type aggregateRow struct {
+		Bucket   int64
+		TagKey   string
+		TagValue string
+		Total    int64
+	}
+	rows := make([]*aggregateRow, 0)
+	err := r.db.WithDbSession(ctx, func(sess *db.Session) error {
+		filter, params, err := r.queryFilter(sess, &query.ItemQuery)
+		if err != nil {
+			return err
 		}
 
-		if query.From > 0 && query.To > 0 {
-			sql.WriteString(` AND a.epoch <= ? AND a.epoch_end >= ?`)
-			params = append(params, query.To, query.From)
+		// The interval is inlined so that the bucket expressions of SELECT and GROUP BY are identical.
+		bucket := fmt.Sprintf("(a.epoch - a.epoch %% %d)", query.Interval)
+		tagKey := `COALESCE(agg_tag.` + r.db.GetDialect().Quote("key") + `, '')`
+		tagValue := `COALESCE(agg_tag.` + r.db.GetDialect().Quote("value") + `, '')`
+		sql := `
+			SELECT
+				` + bucket + ` AS bucket,
+				` + tagKey + ` AS tag_key,
+				` + tagValue + ` AS tag_value,
+				COUNT(*) AS total
+			FROM annotation a
+			LEFT OUTER JOIN annotation_tag agg_at ON agg_at.annotation_id = a.id
+			LEFT OUTER JOIN tag agg_tag ON agg_tag.id = agg_at.tag_id
+			` + filter + `
+			GROUP BY ` + bucket + `, ` + tagKey + `, ` + tagValue + `
+			ORDER BY bucket, tag_key, tag_value`
+		return sess.SQL(sql, params...).Find(&rows)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	buckets := make([]*annotations.AggregateBucket, 0, len(rows))
+	for _, row := range rows {
+		buckets = append(buckets, &annotations.AggregateBucket{
+			Time:  row.Bucket,
+			Tag:   tagString(row.TagKey, row.TagValue),
+			Count: row.Total,
+		})
+	}
+	return buckets, nil
+}
+
+// queryFilter returns the WHERE clause of the annotations that match a query, the annotation table is aliased a.
+func (r *xormRepositoryImpl) queryFilter(sess *db.Session, query *annotations.ItemQuery) (string, []interface{}, error) {
+	var sql bytes.Buffer
+	params := make([]interface{}, 0)
+
+	sql.WriteString(`WHERE a.org_id = ?`)
+	params = append(params, query.OrgId)
+
+	if query.AnnotationId != 0 {
+		// fmt.Print("annotation query")
+		sql.WriteString(` AND a.id = ?`)
+		params = append(params, query.AnnotationId)
+	}
+
+	if query.AlertId != 0 {
+		sql.WriteString(` AND a.alert_id = ?`)
+		params = append(params, query.AlertId)
+	}
+
+	if query.DashboardId != 0 {
+		sql.WriteString(` AND a.dashboard_id = ?`)
+		params = append(params, query.DashboardId)
+	}
+
+	if query.PanelId != 0 {
+		sql.WriteString(` AND a.panel_id = ?`)
+		params = append(params, query.PanelId)
+	}
+
+	if query.UserId != 0 {
+		sql.WriteString(` AND a.user_id = ?`)
+		params = append(params, query.UserId)
+	}
+
+	if query.From > 0 && query.To > 0 {
+		sql.WriteString(` AND a.epoch <= ? AND a.epoch_end >= ?`)
+		params = append(params, query.To, query.From)
+	}
+
+	if len(query.Severities) > 0 {
+		sql.WriteString(` AND a.severity IN (?` + strings.Repeat(",?", len(query.Severities)-1) + `)`)
+		for _, severity := range query.Severities {
+			params = append(params, severity)
 		}
+	}
+
+	if query.OwnerId != 0 {
+		sql.WriteString(` AND a.owner_id = ?`)
+		params = append(params, query.OwnerId)
+	}
 
-		if query.Type == "alert" {
-			sql.WriteString(` AND a.alert_id > 0`)
-		} else if query.Type == "annotation" {
-			sql.WriteString(` AND a.alert_id = 0`)
+	if query.Link != "" {
+		// The links are stored as a JSON array.
+		link, err := json.Marshal(query.Link)
+		if err != nil {
+			return "", nil, err
 		}
+		sql.WriteString(` AND a.links ` + r.db.GetDialect().LikeStr() + ` ? ESCAPE '!'`)
+		params = append(params, "%"+escapeLike(string(link))+"%")
+	}
 
-		if len(query.Tags) > 0 {
-			keyValueFilters := []string{}
+	for _, term := range strings.Fields(query.Text) {
+		sql.WriteString(` AND a.text ` + r.db.GetDialect().LikeStr() + ` ? ESCAPE '!'`)
+		params = append(params, "%"+escapeLike(term)+"%")
+	}
 
-			tags := tag.ParseTagPairs(query.Tags)
-			for _, tag := range tags {
-				if tag.Value == "" {
-					keyValueFilters = append(keyValueFilters, "(tag."+r.db.GetDialect().Quote("key")+" = ?)")
-					params = append(params, tag.Key)
-				} else {
-					keyValueFilters = append(keyValueFilters, "(tag."+r.db.GetDialect().Quote("key")+" = ? AND tag."+r.db.GetDialect().Quote("value")+" = ?)")
-					params = append(params, tag.Key, tag.Value)
-				}
+	if query.TagRegex != "" {
+		tagIDs, err := r.matchingTagIDs(sess, query.OrgId, query.TagRegex)
+		if err != nil {
+			return "", nil, err
+		}
+		if len(tagIDs) == 0 {
+			sql.WriteString(` AND 1 = 0`)
+		} else {
+			sql.WriteString(` AND EXISTS (SELECT 1 FROM annotation_tag at WHERE at.annotation_id = a.id AND at.tag_id IN (?` + strings.Repeat(",?", len(tagIDs)-1) + `))`)
+			for _, id := range tagIDs {
+				params = append(params, id)
 			}
+		}
+	}
 
-			if len(tags) > 0 {
-				tagsSubQuery := fmt.Sprintf(`
-			SELECT SUM(1) FROM annotation_tag at
-			INNER JOIN tag on tag.id = at.tag_id
-			WHERE at.annotation_id = a.id
-				AND (
-				%s
-				)
-		`, strings.Join(keyValueFilters, " OR "))
-
-				if query.MatchAny {
-					sql.WriteString(fmt.Sprintf(" AND (%s) > 0 ", tagsSubQuery))
-				} else {
-					sql.WriteString(fmt.Sprintf(" AND (%s) = %d ", tagsSubQuery, len(tags)))
-				}
+	if query.Type == "alert" {
+		sql.WriteString(` AND a.alert_id > 0`)
+	} else if query.Type == "annotation" {
+		sql.WriteString(` AND a.alert_id = 0`)
+	}
+
+	if len(query.Tags) > 0 {
+		keyValueFilters := []string{}
+
+		tags := tag.ParseTagPairs(query.Tags)
+		for _, tag := range tags {
+			if tag.Value == "" {
+				keyValueFilters = append(keyValueFilters, "(tag."+r.db.GetDialect().Quote("key")+" = ?)")
+				params = append(params, tag.Key)
+			} else {
+				keyValueFilters = append(keyValueFilters, "(tag."+r.db.GetDialect().Quote("key")+" = ? AND tag."+r.db.GetDialect().Quote("value")+" = ?)")
+				params = append(params, tag.Key, tag.Value)
 			}
 		}
 
-		if !ac.IsDisabled(r.cfg) {
-			acFilter, acArgs, err := getAccessControlFilter(query.SignedInUser)
-			if err != nil {
-				return err
+		if len(tags) > 0 {
+			tagsSubQuery := fmt.Sprintf(`
+		SELECT SUM(1) FROM annotation_tag at
+		INNER JOIN tag on tag.id = at.tag_id
+		WHERE at.annotation_id = a.id
+			AND (
+			%s
+			)
+	`, strings.Join(keyValueFilters, " OR "))
+
+			if query.MatchAny {
+				sql.WriteString(fmt.Sprintf(" AND (%s) > 0 ", tagsSubQuery))
+			} else {
+				sql.WriteString(fmt.Sprintf(" AND (%s) = %d ", tagsSubQuery, len(tags)))
 			}
-			sql.WriteString(fmt.Sprintf(" AND (%s)", acFilter))
-			params = append(params, acArgs...)
 		}
+	}
 
-		if query.Limit == 0 {
-			query.Limit = 100
+	if !ac.IsDisabled(r.cfg) {
+		acFilter, acArgs, err := getAccessControlFilter(query.SignedInUser)
+		if err != nil {
+			return "", nil, err
 		}
+		sql.WriteString(fmt.Sprintf(" AND (%s)", acFilter))
+		params = append(params, acArgs...)
+	}
 
-		// order of ORDER BY arguments match the order of a sql index for performance
-		sql.WriteString(" ORDER BY a.org_id, a.epoch_end DESC, a.epoch DESC" + r.db.GetDialect().Limit(query.Limit) + " ) dt on dt.id = annotation.id")
-		if err := sess.SQL(sql.String(), params...).Find(&items); err != nil {
-			items = nil
-			return err
+	return sql.String(), params, nil
+}
+
+// matchingTagIDs returns the IDs of the annotation tags of an organization that match a regular expression in the
+// key:value format. The regular expression can't be evaluated by every database, so the tags are read from the tag
+// table and matched here, and only the matching tags are looked up in the annotations of the organization.
+func (r *xormRepositoryImpl) matchingTagIDs(sess *db.Session, orgID int64, tagRegex string) ([]int64, error) {
+	re, err := regexp.Compile(tagRegex)
+	if err != nil {
+		return nil, annotations.ErrBaseInvalidTagRegex.Errorf("failed to compile tag regex: %w", err)
+	}
+
+	tags := make([]*tag.Tag, 0)
+	if err := sess.Table("tag").Cols("id", "key", "value").Find(&tags); err != nil {
+		return nil, err
+	}
+	matching := make([]interface{}, 0)
+	for _, t := range tags {
+		if re.MatchString(tagString(t.Key, t.Value)) {
+			matching = append(matching, t.Id)
 		}
-		return nil
-	},
-	)
+	}
 
-	return items, err
+	ids := make([]int64, 0)
+	for start := 0; start < len(matching); start += tagIDsBatchSize {
+		end := start + tagIDsBatchSize
+		if end > len(matching) {
+			end = len(matching)
+		}
+		batch := make([]int64, 0)
+		err := sess.SQL(`
+			SELECT DISTINCT annotation_tag.tag_id
+			FROM annotation_tag
+			INNER JOIN annotation ON annotation.id = annotation_tag.annotation_id
+			WHERE annotation.org_id = ? AND annotation_tag.tag_id IN (?`+strings.Repeat(",?", end-start-1)+`)`,
+			append([]interface{}{orgID}, matching[start:end]...)...).Find(&batch)
+		if err != nil {
+			return nil, err
+		}
+		if len(ids)+len(batch) > maxTagRegexMatches {
+			return nil, annotations.ErrBaseInvalidTagRegex.Errorf("tag regex matches more than %d tags", maxTagRegexMatches)
+		}
+		ids = append(ids, batch...)
+	}
+	return ids, nil
+}
+
+// tagString returns a tag in the key:value format, or the key if the tag has no value.
+func tagString(key, value string) string {
+	if value == "" {
+		return key
+	}
+	return key + ":" + value
+}
+
+// escapeLike escapes the wildcards of a LIKE pattern, the pattern must use '!' as the escape character.
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
 }
 
 func getAccessControlFilter(user *user.SignedInUser) (string, []interface{}, error) {
@@ -347,6 +548,62 @@ func getAccessControlFilter(user *user.SignedInUser) (string, []interface{}, err
 	return strings.Join(filters, " OR "), params, nil
 }
 
+// FilterReadable returns the annotations that the user can read, with the same rules as the access control filter
+// of the queries. It filters the annotations that are not stored in the database.
+func (r *xormRepositoryImpl) FilterReadable(ctx context.Context, user *user.SignedInUser, items []*annotations.ItemDTO) ([]*annotations.ItemDTO, error) {
+	if ac.IsDisabled(r.cfg) || len(items) == 0 {
+		return items, nil
+	}
+	if user == nil || user.Permissions[user.OrgID] == nil {
+		return nil, errors.New("missing permissions")
+	}
+	scopes, has := user.Permissions[user.OrgID][ac.ActionAnnotationsRead]
+	if !has {
+		return nil, errors.New("missing permissions")
+	}
+	types, hasWildcardScope := ac.ParseScopes(ac.ScopeAnnotationsProvider.GetResourceScopeType(""), scopes)
+	if hasWildcardScope {
+		types = map[interface{}]struct{}{annotations.Dashboard.String(): {}, annotations.Organization.String(): {}}
+	}
+	_, canReadOrganization := types[annotations.Organization.String()]
+	_, canReadDashboards := types[annotations.Dashboard.String()]
+
+	readableDashboards := make(map[int64]bool)
+	if canReadDashboards {
+		params := make([]interface{}, 0, len(items)+1)
+		params = append(params, user.OrgID)
+		seen := make(map[int64]bool)
+		for _, item := range items {
+			if item.DashboardId != 0 && !seen[item.DashboardId] {
+				seen[item.DashboardId] = true
+				params = append(params, item.DashboardId)
+			}
+		}
+		if len(params) > 1 {
+			dashboardFilter, dashboardParams := permissions.NewAccessControlDashboardPermissionFilter(user, models.PERMISSION_VIEW, searchstore.TypeDashboard).Where()
+			sql := "SELECT id FROM dashboard WHERE org_id = ? AND id IN (?" + strings.Repeat(",?", len(params)-2) + ") AND " + dashboardFilter
+			var ids []int64
+			err := r.db.WithDbSession(ctx, func(sess *db.Session) error {
+				return sess.SQL(sql, append(params, dashboardParams...)...).Find(&ids)
+			})
+			if err != nil {
+				return nil, err
+			}
+			for _, id := range ids {
+				readableDashboards[id] = true
+			}
+		}
+	}
+
+	readable := make([]*annotations.ItemDTO, 0, len(items))
+	for _, item := range items {
+		if (item.DashboardId == 0 && canReadOrganization) || readableDashboards[item.DashboardId] {
+			readable = append(readable, item)
+		}
+	}
+	return readable, nil
+}
+
 func (r *xormRepositoryImpl) Delete(ctx context.Context, params *annotations.DeleteParams) error {
 	return r.db.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
 		var (
@@ -422,12 +679,8 @@ func (r *xormRepositoryImpl) GetTags(ctx context.Context, query *annotations.Tag
 	}
 	tags := make([]*annotations.TagsDTO, 0)
 	for _, item := range items {
-		tag := item.Key
-		if len(item.Value) > 0 {
-			tag = item.Key + ":" + item.Value
-		}
 		tags = append(tags, &annotations.TagsDTO{
-			Tag:   tag,
+			Tag:   tagString(item.Key, item.Value),
 			Count: item.Count,
 		})
 	}
@@ -443,6 +696,10 @@ func (r *xormRepositoryImpl) validateItem(item *annotations.Item) error {
 	if err := r.validateTagsLength(item); err != nil {
 		return err
 	}
+
+	if err := validateIncident(item); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -448,6 +448,214 @@ func TestIntegrationAnnotations(t *testing.T) {
 	})
 }
 
+func TestIntegrationIncidentAnnotations(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+	sql := db.InitTestDB(t)
+	repo := xormRepositoryImpl{db: sql, cfg: setting.NewCfg(), log: log.New("annotation.test"), tagService: tagimpl.ProvideService(sql, sql.Cfg), maximumTagsLength: 500}
+
+	testUser := &user.SignedInUser{
+		OrgID: 1,
+		Permissions: map[int64]map[string][]string{
+			1: {
+				accesscontrol.ActionAnnotationsRead: []string{accesscontrol.ScopeAnnotationsAll},
+				dashboards.ActionDashboardsRead:     []string{dashboards.ScopeDashboardsAll},
+			},
+		},
+	}
+
+	owner := &user.User{Login: "owner", Email: "owner@example.com", OrgID: 1, Created: time.Now(), Updated: time.Now()}
+	err := sql.WithDbSession(context.Background(), func(sess *db.Session) error {
+		_, err := sess.Insert(owner)
+		return err
+	})
+	require.NoError(t, err)
+
+	incident := &annotations.Item{
+		OrgId:    1,
+		Text:     "outage",
+		Epoch:    10,
+		Severity: annotations.SeverityCritical,
+		Links:    []string{"https://status.example.com/incidents/1?a=b&c=d", "https://runbooks.example.com/outage"},
+		OwnerId:  owner.ID,
+	}
+	require.NoError(t, repo.Add(context.Background(), incident))
+	require.NoError(t, repo.AddMany(context.Background(), []annotations.Item{
+		{OrgId: 1, Text: "degraded", Epoch: 20, Severity: annotations.SeverityLow},
+		{OrgId: 1, Text: "deploy", Epoch: 30},
+	}))
+
+	get := func(t *testing.T, query annotations.ItemQuery) []string {
+		t.Helper()
+
+		query.OrgId = 1
+		query.SignedInUser = testUser
+		items, err := repo.Get(context.Background(), &query)
+		require.NoError(t, err)
+		texts := make([]string, 0, len(items))
+		for _, item := range items {
+			texts = append(texts, item.Text)
+		}
+		return texts
+	}
+
+	t.Run("Should return the incident fields", func(t *testing.T) {
+		items, err := repo.Get(context.Background(), &annotations.ItemQuery{OrgId: 1, AnnotationId: incident.Id, SignedInUser: testUser})
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		assert.Equal(t, annotations.SeverityCritical, items[0].Severity)
+		assert.Equal(t, incident.Links, items[0].Links)
+		assert.Equal(t, owner.ID, items[0].OwnerId)
+		assert.Equal(t, "owner", items[0].OwnerLogin)
+	})
+
+	t.Run("Should filter by severity", func(t *testing.T) {
+		assert.Equal(t, []string{"outage"}, get(t, annotations.ItemQuery{Severities: []string{annotations.SeverityCritical}}))
+		assert.ElementsMatch(t, []string{"degraded", "outage"}, get(t, annotations.ItemQuery{Severities: []string{annotations.SeverityCritical, annotations.SeverityLow}}))
+	})
+
+	t.Run("Should filter by owner", func(t *testing.T) {
+		assert.Equal(t, []string{"outage"}, get(t, annotations.ItemQuery{OwnerId: owner.ID}))
+	})
+
+	t.Run("Should filter by link", func(t *testing.T) {
+		assert.Equal(t, []string{"outage"}, get(t, annotations.ItemQuery{Link: "https://status.example.com/incidents/1?a=b&c=d"}))
+		assert.Empty(t, get(t, annotations.ItemQuery{Link: "https://status.example.com/incidents/2"}))
+		// the wildcards of LIKE are matched literally
+		assert.Empty(t, get(t, annotations.ItemQuery{Link: "https://status.example.com/incidents/1?a=b&c=_"}))
+		assert.Empty(t, get(t, annotations.ItemQuery{Link: "https://status.example.com/incidents/%"}))
+	})
+
+	t.Run("Should update the incident fields", func(t *testing.T) {
+		err := repo.Update(context.Background(), &annotations.Item{
+			Id:       incident.Id,
+			OrgId:    1,
+			Text:     "outage",
+			Severity: annotations.SeverityHigh,
+			Links:    []string{"https://status.example.com/incidents/2"},
+		})
+		require.NoError(t, err)
+
+		items, err := repo.Get(context.Background(), &annotations.ItemQuery{OrgId: 1, AnnotationId: incident.Id, SignedInUser: testUser})
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		assert.Equal(t, annotations.SeverityHigh, items[0].Severity)
+		assert.Equal(t, []string{"https://status.example.com/incidents/2"}, items[0].Links)
+		assert.Zero(t, items[0].OwnerId)
+	})
+
+	t.Run("Should reject invalid incident fields", func(t *testing.T) {
+		err := repo.Add(context.Background(), &annotations.Item{OrgId: 1, Epoch: 10, Severity: "urgent"})
+		require.ErrorIs(t, err, annotations.ErrBaseInvalidSeverity)
+
+		err = repo.Add(context.Background(), &annotations.Item{OrgId: 1, Epoch: 10, Links: []string{"javascript:alert(1)"}})
+		require.ErrorIs(t, err, annotations.ErrBaseInvalidLinks)
+
+		links := make([]string, annotations.MaxLinks+1)
+		for i := range links {
+			links[i] = fmt.Sprintf("https://example.com/%d", i)
+		}
+		err = repo.Add(context.Background(), &annotations.Item{OrgId: 1, Epoch: 10, Links: links})
+		require.ErrorIs(t, err, annotations.ErrBaseInvalidLinks)
+	})
+}
+
+func TestIntegrationAnnotationSearch(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+	sql := db.InitTestDB(t)
+	repo := xormRepositoryImpl{db: sql, cfg: setting.NewCfg(), log: log.New("annotation.test"), tagService: tagimpl.ProvideService(sql, sql.Cfg), maximumTagsLength: 500}
+
+	testUser := &user.SignedInUser{
+		OrgID: 1,
+		Permissions: map[int64]map[string][]string{
+			1: {
+				accesscontrol.ActionAnnotationsRead: []string{accesscontrol.ScopeAnnotationsAll},
+				dashboards.ActionDashboardsRead:     []string{dashboards.ScopeDashboardsAll},
+			},
+		},
+	}
+
+	for _, item := range []annotations.Item{
+		{OrgId: 1, Text: "Deployed API v1.2", Epoch: 1000, Tags: []string{"deploy", "service:api"}},
+		{OrgId: 1, Text: "Deployed web 100% rollout", Epoch: 1500, Tags: []string{"deploy", "service:web"}},
+		{OrgId: 1, Text: "Rolled back API", Epoch: 2500, Tags: []string{"rollback", "service:api"}},
+		{OrgId: 1, Text: "Maintenance window", Epoch: 2600},
+		{OrgId: 2, Text: "Deployed API in other org", Epoch: 1000, Tags: []string{"deploy", "outage"}},
+	} {
+		item := item
+		require.NoError(t, repo.Add(context.Background(), &item))
+	}
+
+	get := func(t *testing.T, query annotations.ItemQuery) []string {
+		t.Helper()
+
+		query.OrgId = 1
+		query.SignedInUser = testUser
+		items, err := repo.Get(context.Background(), &query)
+		require.NoError(t, err)
+		texts := make([]string, 0, len(items))
+		for _, item := range items {
+			texts = append(texts, item.Text)
+		}
+		return texts
+	}
+
+	t.Run("Should search the text", func(t *testing.T) {
+		assert.ElementsMatch(t, []string{"Deployed API v1.2", "Deployed web 100% rollout"}, get(t, annotations.ItemQuery{Text: "deployed"}))
+		assert.Equal(t, []string{"Deployed API v1.2"}, get(t, annotations.ItemQuery{Text: "api deployed"}))
+		assert.Equal(t, []string{"Deployed web 100% rollout"}, get(t, annotations.ItemQuery{Text: "100%"}))
+		assert.Empty(t, get(t, annotations.ItemQuery{Text: "1_2"}))
+	})
+
+	t.Run("Should match the tags with a regex", func(t *testing.T) {
+		assert.ElementsMatch(t, []string{"Deployed API v1.2", "Rolled back API"}, get(t, annotations.ItemQuery{TagRegex: "^service:api$"}))
+		assert.ElementsMatch(t, []string{"Deployed API v1.2", "Deployed web 100% rollout", "Rolled back API"}, get(t, annotations.ItemQuery{TagRegex: "^(deploy|rollback)$"}))
+		assert.Empty(t, get(t, annotations.ItemQuery{TagRegex: "^outage$"}), "tags of other organizations must not match")
+	})
+
+	t.Run("Should reject an invalid tag regex", func(t *testing.T) {
+		_, err := repo.Get(context.Background(), &annotations.ItemQuery{OrgId: 1, TagRegex: "(", SignedInUser: testUser})
+		require.ErrorIs(t, err, annotations.ErrBaseInvalidTagRegex)
+	})
+
+	t.Run("Should aggregate per time bucket and tag", func(t *testing.T) {
+		buckets, err := repo.Aggregate(context.Background(), &annotations.AggregateQuery{
+			ItemQuery: annotations.ItemQuery{OrgId: 1, SignedInUser: testUser},
+			Interval:  1000,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []*annotations.AggregateBucket{
+			{Time: 1000, Tag: "deploy", Count: 2},
+			{Time: 1000, Tag: "service:api", Count: 1},
+			{Time: 1000, Tag: "service:web", Count: 1},
+			{Time: 2000, Tag: "", Count: 1},
+			{Time: 2000, Tag: "rollback", Count: 1},
+			{Time: 2000, Tag: "service:api", Count: 1},
+		}, buckets)
+	})
+
+	t.Run("Should aggregate the filtered annotations", func(t *testing.T) {
+		buckets, err := repo.Aggregate(context.Background(), &annotations.AggregateQuery{
+			ItemQuery: annotations.ItemQuery{OrgId: 1, Text: "api", TagRegex: "^service:", SignedInUser: testUser},
+			Interval:  5000,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []*annotations.AggregateBucket{
+			{Time: 0, Tag: "deploy", Count: 1},
+			{Time: 0, Tag: "rollback", Count: 1},
+			{Time: 0, Tag: "service:api", Count: 2},
+		}, buckets)
+	})
+
+	t.Run("Should reject an invalid interval", func(t *testing.T) {
+		_, err := repo.Aggregate(context.Background(), &annotations.AggregateQuery{ItemQuery: annotations.ItemQuery{OrgId: 1, SignedInUser: testUser}})
+		require.ErrorIs(t, err, annotations.ErrBaseInvalidInterval)
+	})
+}
+
 func TestIntegrationAnnotationListingWithRBAC(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test")
@@ -587,6 +795,26 @@ func TestIntegrationAnnotationListingWithRBAC(t *testing.T) {
 				assert.Contains(t, tc.expectedAnnotationIds, r.Id)
 			}
 		})
+
+		t.Run(tc.description+" in the annotations of external stores", func(t *testing.T) {
+			user.Permissions = map[int64]map[string][]string{1: tc.permissions}
+			setupRBACPermission(t, repo, role, user)
+
+			results, err := repo.FilterReadable(context.Background(), user, []*annotations.ItemDTO{
+				{Id: dash1Annotation.Id, DashboardId: dash1Annotation.DashboardId},
+				{Id: dash2Annotation.Id, DashboardId: dash2Annotation.DashboardId},
+				{Id: organizationAnnotation.Id},
+			})
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, results, len(tc.expectedAnnotationIds))
+			for _, r := range results {
+				assert.Contains(t, tc.expectedAnnotationIds, r.Id)
+			}
+		})
 	}
 }
 
@@ -70,7 +70,7 @@ func (repo *fakeAnnotationsRepo) Find(_ context.Context, query *annotations.Item
 	defer repo.mtx.Unlock()
 
 	if annotation, has := repo.annotations[query.AnnotationId]; has {
-		return []*annotations.ItemDTO{{Id: annotation.Id, DashboardId: annotation.DashboardId}}, nil
+		return []*annotations.ItemDTO{{Id: annotation.Id, DashboardId: annotation.DashboardId, Time: annotation.Epoch, TimeEnd: annotation.EpochEnd, Tags: annotation.Tags, Text: annotation.Text}}, nil
 	}
 	annotations := []*annotations.ItemDTO{{Id: 1, DashboardId: 0}}
 	return annotations, nil
@@ -83,6 +83,10 @@ func (repo *fakeAnnotationsRepo) FindTags(_ context.Context, query *annotations.
 	return result, nil
 }
 
+func (repo *fakeAnnotationsRepo) Aggregate(_ context.Context, query *annotations.AggregateQuery) ([]*annotations.AggregateBucket, error) {
+	return []*annotations.AggregateBucket{}, nil
+}
+
 func (repo *fakeAnnotationsRepo) Len() int {
 	repo.mtx.Lock()
 	defer repo.mtx.Unlock()
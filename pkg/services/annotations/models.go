@@ -18,11 +18,37 @@ type ItemQuery struct {
 	Tags         []string `json:"tags"`
 	Type         string   `json:"type"`
 	MatchAny     bool     `json:"matchAny"`
+	// Severities filters the annotations with any of the severities.
+	Severities []string `json:"severities"`
+	// Link filters the annotations with the link.
+	Link    string `json:"link"`
+	OwnerId int64  `json:"ownerId"`
+	// Text filters the annotations whose text contains all the words, ignoring case.
+	Text string `json:"text"`
+	// TagRegex filters the annotations with a tag that matches the regular expression. The tags are matched in
+	// the key:value format.
+	TagRegex     string `json:"tagRegex"`
 	SignedInUser *user.SignedInUser
 
 	Limit int64 `json:"limit"`
 }
 
+// AggregateQuery is the query for the number of annotations per time bucket and tag.
+type AggregateQuery struct {
+	ItemQuery
+	// Interval is the duration of the time buckets in milliseconds.
+	Interval int64 `json:"interval"`
+}
+
+// AggregateBucket is the number of annotations with a tag whose start is in a time bucket. An annotation is counted
+// once per tag, the annotations without tags are counted with an empty tag.
+type AggregateBucket struct {
+	// Time is the start of the bucket in milliseconds.
+	Time  int64  `json:"time"`
+	Tag   string `json:"tag"`
+	Count int64  `json:"count"`
+}
+
 // TagsQuery is the query for a tags search.
 type TagsQuery struct {
 	OrgID int64  `json:"orgId"`
@@ -77,6 +103,9 @@ type Item struct {
 	Updated     int64            `json:"updated"`
 	Tags        []string         `json:"tags"`
 	Data        *simplejson.Json `json:"data"`
+	Severity    string           `json:"severity"`
+	Links       []string         `json:"links"`
+	OwnerId     int64            `json:"ownerId"`
 
 	// needed until we remove it from db
 	Type  string
@@ -107,6 +136,36 @@ type ItemDTO struct {
 	Email        string           `json:"email"`
 	AvatarUrl    string           `json:"avatarUrl"`
 	Data         *simplejson.Json `json:"data"`
+	Severity     string           `json:"severity,omitempty"`
+	Links        []string         `json:"links,omitempty"`
+	OwnerId      int64            `json:"ownerId,omitempty"`
+	OwnerLogin   string           `json:"ownerLogin,omitempty"`
+	// CommentCount is the number of comments of the annotation, set if the annotation comments are enabled.
+	CommentCount int64 `json:"commentCount"`
+	// LastActivity is the time of the last comment of the annotation in milliseconds, or 0 if it has no comments.
+	LastActivity int64 `json:"lastActivity,omitempty"`
+}
+
+// Severities of the incident annotations.
+const (
+	SeverityCritical = "critical"
+	SeverityHigh     = "high"
+	SeverityMedium   = "medium"
+	SeverityLow      = "low"
+	SeverityInfo     = "info"
+)
+
+// MaxLinks is the maximum number of links of an annotation.
+const MaxLinks = 10
+
+// IsValidSeverity returns true if the severity is empty or one of the supported severities.
+func IsValidSeverity(severity string) bool {
+	switch severity {
+	case "", SeverityCritical, SeverityHigh, SeverityMedium, SeverityLow, SeverityInfo:
+		return true
+	default:
+		return false
+	}
 }
 
 type annotationType int
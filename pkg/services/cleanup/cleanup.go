@@ -20,7 +20,9 @@ import (
 	"github.com/grafana/grafana/pkg/services/annotations"
 	"github.com/grafana/grafana/pkg/services/dashboardsnapshots"
 	dashver "github.com/grafana/grafana/pkg/services/dashboardversion"
+	"github.com/grafana/grafana/pkg/services/libraryelements"
 	"github.com/grafana/grafana/pkg/services/ngalert/image"
+	"github.com/grafana/grafana/pkg/services/publicdashboards"
 	"github.com/grafana/grafana/pkg/services/queryhistory"
 	"github.com/grafana/grafana/pkg/services/shorturls"
 	tempuser "github.com/grafana/grafana/pkg/services/temp_user"
@@ -30,7 +32,8 @@ import (
 func ProvideService(cfg *setting.Cfg, serverLockService *serverlock.ServerLockService,
 	shortURLService shorturls.Service, sqlstore db.DB, queryHistoryService queryhistory.Service,
 	dashboardVersionService dashver.Service, dashSnapSvc dashboardsnapshots.Service, deleteExpiredImageService *image.DeleteExpiredService,
-	tempUserService tempuser.Service, tracer tracing.Tracer, annotationCleaner annotations.Cleaner) *CleanUpService {
+	tempUserService tempuser.Service, tracer tracing.Tracer, annotationCleaner annotations.Cleaner,
+	libraryElementService libraryelements.Service, publicDashboardService publicdashboards.Service) *CleanUpService {
 	s := &CleanUpService{
 		Cfg:                       cfg,
 		ServerLockService:         serverLockService,
@@ -44,6 +47,8 @@ func ProvideService(cfg *setting.Cfg, serverLockService *serverlock.ServerLockSe
 		tempUserService:           tempUserService,
 		tracer:                    tracer,
 		annotationCleaner:         annotationCleaner,
+		libraryElementService:     libraryElementService,
+		publicDashboardService:    publicDashboardService,
 	}
 	return s
 }
@@ -61,6 +66,8 @@ type CleanUpService struct {
 	deleteExpiredImageService *image.DeleteExpiredService
 	tempUserService           tempuser.Service
 	annotationCleaner         annotations.Cleaner
+	libraryElementService     libraryelements.Service
+	publicDashboardService    publicdashboards.Service
 }
 
 type cleanUpJob struct {
@@ -98,11 +105,13 @@ func (srv *CleanUpService) clean(ctx context.Context) {
 		{"clean up temporary files", srv.cleanUpTmpFiles},
 		{"delete expired snapshots", srv.deleteExpiredSnapshots},
 		{"delete expired dashboard versions", srv.deleteExpiredDashboardVersions},
+		{"delete expired library element versions", srv.deleteExpiredLibraryElementVersions},
 		{"delete expired images", srv.deleteExpiredImages},
 		{"cleanup old annotations", srv.cleanUpOldAnnotations},
 		{"expire old user invites", srv.expireOldUserInvites},
 		{"delete stale short URLs", srv.deleteStaleShortURLs},
 		{"delete stale query history", srv.deleteStaleQueryHistory},
+		{"delete expired public dashboard data", srv.deleteExpiredPublicDashboardData},
 	}
 
 	logger := srv.log.FromContext(ctx)
@@ -211,6 +220,15 @@ func (srv *CleanUpService) deleteExpiredDashboardVersions(ctx context.Context) {
 	}
 }
 
+func (srv *CleanUpService) deleteExpiredLibraryElementVersions(ctx context.Context) {
+	logger := srv.log.FromContext(ctx)
+	if rowsAffected, err := srv.libraryElementService.DeleteExpiredVersions(ctx); err != nil {
+		logger.Error("Failed to delete expired library element versions", "error", err.Error())
+	} else {
+		logger.Debug("Deleted old/expired library element versions", "rows affected", rowsAffected)
+	}
+}
+
 func (srv *CleanUpService) deleteExpiredImages(ctx context.Context) {
 	logger := srv.log.FromContext(ctx)
 	if !srv.Cfg.UnifiedAlerting.IsEnabled() {
@@ -280,3 +298,13 @@ func (srv *CleanUpService) deleteStaleQueryHistory(ctx context.Context) {
 		logger.Debug("Enforced row limit for query_history_star", "rows affected", rowsCount)
 	}
 }
+
+func (srv *CleanUpService) deleteExpiredPublicDashboardData(ctx context.Context) {
+	logger := srv.log.FromContext(ctx)
+	rowsCount, err := srv.publicDashboardService.DeleteExpired(ctx)
+	if err != nil {
+		logger.Error("Problem deleting expired public dashboard data", "error", err.Error())
+	} else {
+		logger.Debug("Deleted expired public dashboard magic links, sessions and audit entries", "rows affected", rowsCount)
+	}
+}
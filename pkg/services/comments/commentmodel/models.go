@@ -90,6 +90,14 @@ type CommentDto struct {
 	User    *CommentUser `json:"user,omitempty"`
 }
 
+// Stats are the statistics of the comments of an object.
+type Stats struct {
+	ObjectId string
+	Count    int64
+	// LastActivity is the creation time of the last comment in seconds.
+	LastActivity int64
+}
+
 func (i Comment) ToDTO(user *CommentUser) *CommentDto {
 	return &CommentDto{
 		Id:      i.Id,
@@ -26,7 +26,7 @@ func NewPermissionChecker(sqlStore db.DB, features featuremgmt.FeatureToggles,
 	accessControl accesscontrol.AccessControl, dashboardService dashboards.DashboardService,
 	annotationsRepo annotations.Repository,
 ) *PermissionChecker {
-	return &PermissionChecker{sqlStore: sqlStore, features: features, accessControl: accessControl, annotationsRepo: annotationsRepo}
+	return &PermissionChecker{sqlStore: sqlStore, features: features, accessControl: accessControl, dashboardService: dashboardService, annotationsRepo: annotationsRepo}
 }
 
 func (c *PermissionChecker) getDashboardByUid(ctx context.Context, orgID int64, uid string) (*models.Dashboard, error) {
@@ -117,6 +117,7 @@ func (s *Service) Create(ctx context.Context, orgID int64, signedInUser *user.Si
 	}
 	eventJSON, _ := json.Marshal(e)
 	_ = s.live.Publish(orgID, fmt.Sprintf("grafana/comment/%s/%s", cmd.ObjectType, cmd.ObjectID), eventJSON)
+	s.notifyMentions(ctx, orgID, signedInUser, cmd.ObjectType, cmd.ObjectID, cmd.Content)
 	return mDto, nil
 }
 
@@ -169,3 +170,9 @@ func (s *Service) Get(ctx context.Context, orgID int64, signedInUser *user.Signe
 	})
 	return result, nil
 }
+
+// GetStats returns the statistics of the comments of objects, by object ID. The objects without comments are left
+// out. The permissions are not checked, the caller must only pass the objects that the user can read.
+func (s *Service) GetStats(ctx context.Context, orgID int64, objectType string, objectIDs []string) (map[string]commentmodel.Stats, error) {
+	return s.storage.GetStats(ctx, orgID, objectType, objectIDs)
+}
@@ -0,0 +1,188 @@
+package comments
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana/pkg/models"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/annotations"
+	"github.com/grafana/grafana/pkg/services/comments/commentmodel"
+	"github.com/grafana/grafana/pkg/services/user"
+)
+
+const (
+	mentionEmailTemplate = "comment_mention"
+
+	// maxMentions is the maximum number of users notified by a comment.
+	maxMentions = 10
+
+	// annotationLinkMargin is the time before and after an annotation in the link to it.
+	annotationLinkMargin = 15 * time.Minute
+)
+
+// mentionRegexp matches the @mentions of users by login, logins can be emails.
+var mentionRegexp = regexp.MustCompile(`(?:^|[^\w@])@(\w[\w.+\-]*(?:@[\w\-]+(?:\.[\w\-]+)+)?)`)
+
+// parseMentions returns the logins mentioned in the content of a comment, without duplicates.
+func parseMentions(content string) []string {
+	logins := make([]string, 0)
+	seen := make(map[string]struct{})
+	for _, match := range mentionRegexp.FindAllStringSubmatch(content, -1) {
+		login := strings.TrimRight(match[1], ".-")
+		if _, ok := seen[login]; ok || login == "" {
+			continue
+		}
+		seen[login] = struct{}{}
+		logins = append(logins, login)
+		if len(logins) == maxMentions {
+			break
+		}
+	}
+	return logins
+}
+
+// notifyMentions emails the users mentioned in a comment who can read it. The failures are logged, they don't fail
+// the creation of the comment.
+func (s *Service) notifyMentions(ctx context.Context, orgID int64, author *user.SignedInUser, objectType string, objectID string, content string) {
+	logins := parseMentions(content)
+	if len(logins) == 0 || !s.cfg.Smtp.Enabled {
+		return
+	}
+	logger := s.log.FromContext(ctx).New("objectType", objectType, "objectID", objectID)
+
+	title, link, err := s.objectTitleAndLink(ctx, orgID, author, objectType, objectID)
+	if err != nil {
+		logger.Warn("Failed to get the object of a comment with mentions", "error", err)
+		return
+	}
+
+	for _, login := range logins {
+		mentioned, err := s.mentionedUser(ctx, orgID, login)
+		if err != nil {
+			logger.Warn("Failed to get a mentioned user", "login", login, "error", err)
+			continue
+		}
+		if mentioned == nil || mentioned.UserID == author.UserID || mentioned.Email == "" {
+			continue
+		}
+		ok, err := s.permissions.CheckReadPermissions(ctx, orgID, mentioned, objectType, objectID)
+		if err != nil || !ok {
+			logger.Debug("Skipping a mentioned user who can't read the comment", "login", login, "error", err)
+			continue
+		}
+
+		err = s.emailSender.SendEmailCommandHandler(ctx, &models.SendEmailCommand{
+			To:       []string{mentioned.Email},
+			Template: mentionEmailTemplate,
+			Data: map[string]interface{}{
+				"MentionedBy": author.NameOrFallback(),
+				"Title":       title,
+				"Content":     content,
+				"Link":        link,
+			},
+		})
+		if err != nil {
+			logger.Warn("Failed to email a mentioned user", "login", login, "error", err)
+		}
+	}
+}
+
+// mentionedUser returns the mentioned user with their permissions in the organization, or nil if they aren't a
+// member of the organization.
+func (s *Service) mentionedUser(ctx context.Context, orgID int64, login string) (*user.SignedInUser, error) {
+	usr, err := s.userService.GetByLogin(ctx, &user.GetUserByLoginQuery{LoginOrEmail: login})
+	if err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	signedInUser, err := s.userService.GetSignedInUserWithCacheCtx(ctx, &user.GetSignedInUserQuery{UserID: usr.ID, OrgID: orgID})
+	if err != nil {
+		return nil, err
+	}
+	if signedInUser.OrgID != orgID {
+		return nil, nil
+	}
+	if !s.accessControl.IsDisabled() {
+		permissions, err := s.accessControlService.GetUserPermissions(ctx, signedInUser, accesscontrol.Options{})
+		if err != nil {
+			return nil, err
+		}
+		signedInUser.Permissions = map[int64]map[string][]string{orgID: accesscontrol.GroupScopesByAction(permissions)}
+	}
+	return signedInUser, nil
+}
+
+// objectTitleAndLink returns the title of the dashboard of a commented object and the link to it. Annotations of the
+// organization have no dashboard, they are linked to Explore, which lists the annotations with their tags.
+func (s *Service) objectTitleAndLink(ctx context.Context, orgID int64, signedInUser *user.SignedInUser, objectType string, objectID string) (string, string, error) {
+	switch objectType {
+	case commentmodel.ObjectTypeDashboard:
+		query := models.GetDashboardQuery{Uid: objectID, OrgId: orgID}
+		if err := s.dashboardService.GetDashboard(ctx, &query); err != nil {
+			return "", "", err
+		}
+		return query.Result.Title, models.GetFullDashboardUrl(query.Result.Uid, query.Result.Slug), nil
+	case commentmodel.ObjectTypeAnnotation:
+		annotationID, err := strconv.ParseInt(objectID, 10, 64)
+		if err != nil {
+			return "", "", err
+		}
+		items, err := s.annotationsRepo.Find(ctx, &annotations.ItemQuery{AnnotationId: annotationID, OrgId: orgID, SignedInUser: signedInUser})
+		if err != nil {
+			return "", "", err
+		}
+		if len(items) != 1 {
+			return "", "", fmt.Errorf("annotation %d not found", annotationID)
+		}
+		from := items[0].Time - annotationLinkMargin.Milliseconds()
+		to := items[0].TimeEnd + annotationLinkMargin.Milliseconds()
+		if items[0].DashboardId == 0 {
+			link, err := s.organizationAnnotationLink(orgID, items[0].Tags, from, to)
+			if err != nil {
+				return "", "", err
+			}
+			return "an annotation of the organization", link, nil
+		}
+		query := models.GetDashboardQuery{Id: items[0].DashboardId, OrgId: orgID}
+		if err := s.dashboardService.GetDashboard(ctx, &query); err != nil {
+			return "", "", err
+		}
+		link := fmt.Sprintf("%s?from=%d&to=%d", models.GetFullDashboardUrl(query.Result.Uid, query.Result.Slug), from, to)
+		return fmt.Sprintf("an annotation of %s", query.Result.Title), link, nil
+	default:
+		return "", "", errUnknownObjectType
+	}
+}
+
+// organizationAnnotationLink returns the link to Explore querying the annotations of the organization that have the
+// tags of an annotation, around the annotation. Annotations without tags can't be queried by the Grafana datasource,
+// they are linked to the home page.
+func (s *Service) organizationAnnotationLink(orgID int64, tags []string, from int64, to int64) (string, error) {
+	if len(tags) == 0 {
+		return fmt.Sprintf("%s?orgId=%d", s.cfg.AppURL, orgID), nil
+	}
+	left, err := json.Marshal([]interface{}{
+		strconv.FormatInt(from, 10),
+		strconv.FormatInt(to, 10),
+		"-- Grafana --",
+		map[string]interface{}{
+			"refId":     "A",
+			"queryType": "annotations",
+			"target":    map[string]interface{}{"type": "tags", "tags": tags, "matchAny": false, "limit": 100},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%sexplore?orgId=%d&left=%s", s.cfg.AppURL, orgID, url.QueryEscape(string(left))), nil
+}
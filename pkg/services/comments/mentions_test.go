@@ -0,0 +1,62 @@
+package comments
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/annotations"
+	"github.com/grafana/grafana/pkg/services/annotations/annotationstest"
+	"github.com/grafana/grafana/pkg/services/comments/commentmodel"
+	"github.com/grafana/grafana/pkg/services/user"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+func TestParseMentions(t *testing.T) {
+	testCases := []struct {
+		content  string
+		expected []string
+	}{
+		{content: "no mentions", expected: []string{}},
+		{content: "@admin look at this", expected: []string{"admin"}},
+		{content: "cc @jane.doe, @john-smith and @jane.doe.", expected: []string{"jane.doe", "john-smith"}},
+		{content: "ping @jane@example.com please", expected: []string{"jane@example.com"}},
+		{content: "send it to jane@example.com", expected: []string{}},
+		{content: "(@admin)", expected: []string{"admin"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.content, func(t *testing.T) {
+			require.Equal(t, tc.expected, parseMentions(tc.content))
+		})
+	}
+}
+
+func TestObjectTitleAndLink(t *testing.T) {
+	t.Run("links the organization annotations to Explore", func(t *testing.T) {
+		repo := annotationstest.NewFakeAnnotationsRepo()
+		require.NoError(t, repo.Save(context.Background(), &annotations.Item{Id: 3, OrgId: 1, Epoch: 1800000, EpochEnd: 1800000, Tags: []string{"deploy"}}))
+		s := &Service{cfg: &setting.Cfg{AppURL: "http://localhost:3000/"}, annotationsRepo: repo}
+
+		title, link, err := s.objectTitleAndLink(context.Background(), 1, &user.SignedInUser{OrgID: 1}, commentmodel.ObjectTypeAnnotation, "3")
+		require.NoError(t, err)
+		require.Equal(t, "an annotation of the organization", title)
+		u, err := url.Parse(link)
+		require.NoError(t, err)
+		require.Equal(t, "/explore", u.Path)
+		require.Equal(t, "1", u.Query().Get("orgId"))
+		require.JSONEq(t, `["900000","2700000","-- Grafana --",{"refId":"A","queryType":"annotations","target":{"type":"tags","tags":["deploy"],"matchAny":false,"limit":100}}]`, u.Query().Get("left"))
+	})
+
+	t.Run("links the organization annotations without tags to the home page", func(t *testing.T) {
+		repo := annotationstest.NewFakeAnnotationsRepo()
+		require.NoError(t, repo.Save(context.Background(), &annotations.Item{Id: 3, OrgId: 1}))
+		s := &Service{cfg: &setting.Cfg{AppURL: "http://localhost:3000/"}, annotationsRepo: repo}
+
+		_, link, err := s.objectTitleAndLink(context.Background(), 1, &user.SignedInUser{OrgID: 1}, commentmodel.ObjectTypeAnnotation, "3")
+		require.NoError(t, err)
+		require.Equal(t, "http://localhost:3000/?orgId=1", link)
+	})
+}
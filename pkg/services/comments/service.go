@@ -4,37 +4,52 @@ import (
 	"context"
 
 	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
 	"github.com/grafana/grafana/pkg/services/annotations"
 	"github.com/grafana/grafana/pkg/services/comments/commentmodel"
 	"github.com/grafana/grafana/pkg/services/dashboards"
 	"github.com/grafana/grafana/pkg/services/featuremgmt"
 	"github.com/grafana/grafana/pkg/services/live"
+	"github.com/grafana/grafana/pkg/services/notifications"
 	"github.com/grafana/grafana/pkg/services/user"
 	"github.com/grafana/grafana/pkg/setting"
 )
 
 type Service struct {
-	cfg         *setting.Cfg
-	live        *live.GrafanaLive
-	sqlStore    db.DB
-	storage     Storage
-	permissions *commentmodel.PermissionChecker
-	userService user.Service
+	cfg                  *setting.Cfg
+	log                  log.Logger
+	live                 *live.GrafanaLive
+	sqlStore             db.DB
+	storage              Storage
+	permissions          *commentmodel.PermissionChecker
+	userService          user.Service
+	dashboardService     dashboards.DashboardService
+	annotationsRepo      annotations.Repository
+	accessControl        accesscontrol.AccessControl
+	accessControlService accesscontrol.Service
+	emailSender          notifications.EmailSender
 }
 
 func ProvideService(cfg *setting.Cfg, store db.DB, live *live.GrafanaLive,
 	features featuremgmt.FeatureToggles, accessControl accesscontrol.AccessControl,
-	dashboardService dashboards.DashboardService, userService user.Service, annotationsRepo annotations.Repository) *Service {
+	dashboardService dashboards.DashboardService, userService user.Service, annotationsRepo annotations.Repository,
+	accessControlService accesscontrol.Service, emailSender notifications.EmailSender) *Service {
 	s := &Service{
 		cfg:      cfg,
+		log:      log.New("comments"),
 		live:     live,
 		sqlStore: store,
 		storage: &sqlStorage{
 			sql: store,
 		},
-		permissions: commentmodel.NewPermissionChecker(store, features, accessControl, dashboardService, annotationsRepo),
-		userService: userService,
+		permissions:          commentmodel.NewPermissionChecker(store, features, accessControl, dashboardService, annotationsRepo),
+		userService:          userService,
+		dashboardService:     dashboardService,
+		annotationsRepo:      annotationsRepo,
+		accessControl:        accessControl,
+		accessControlService: accessControlService,
+		emailSender:          emailSender,
 	}
 	return s
 }
@@ -2,6 +2,7 @@ package comments
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/grafana/grafana/pkg/infra/db"
@@ -115,3 +116,36 @@ func (s *sqlStorage) Get(ctx context.Context, orgID int64, objectType string, ob
 		return clause.OrderBy("id desc").Limit(limit).Find(&result)
 	})
 }
+
+func (s *sqlStorage) GetStats(ctx context.Context, orgID int64, objectType string, objectIDs []string) (map[string]commentmodel.Stats, error) {
+	if !checkObjectType(objectType) {
+		return nil, errUnknownObjectType
+	}
+
+	result := make(map[string]commentmodel.Stats, len(objectIDs))
+	if len(objectIDs) == 0 {
+		return result, nil
+	}
+
+	return result, s.sql.WithDbSession(ctx, func(dbSession *db.Session) error {
+		params := []interface{}{orgID, objectType}
+		for _, id := range objectIDs {
+			params = append(params, id)
+		}
+
+		var stats []commentmodel.Stats
+		err := dbSession.SQL(`SELECT comment_group.object_id, COUNT(comment.id) AS count, MAX(comment.created) AS last_activity
+			FROM comment_group
+			INNER JOIN comment ON comment.group_id = comment_group.id
+			WHERE comment_group.org_id = ? AND comment_group.object_type = ?
+			AND comment_group.object_id IN (?`+strings.Repeat(",?", len(objectIDs)-1)+`)
+			GROUP BY comment_group.object_id`, params...).Find(&stats)
+		if err != nil {
+			return err
+		}
+		for _, s := range stats {
+			result[s.ObjectId] = s
+		}
+		return nil
+	})
+}
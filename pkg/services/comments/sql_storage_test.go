@@ -74,3 +74,34 @@ func TestSqlStorage(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, items, 0)
 }
+
+func TestSqlStorage_GetStats(t *testing.T) {
+	s := createSqlStorage(t)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		_, err := s.Create(ctx, 1, commentmodel.ObjectTypeAnnotation, "1", 1, "test"+strconv.Itoa(i))
+		require.NoError(t, err)
+	}
+	_, err := s.Create(ctx, 1, commentmodel.ObjectTypeAnnotation, "2", 1, "test")
+	require.NoError(t, err)
+	// Other organization and object type.
+	_, err = s.Create(ctx, 2, commentmodel.ObjectTypeAnnotation, "3", 1, "test")
+	require.NoError(t, err)
+	_, err = s.Create(ctx, 1, commentmodel.ObjectTypeDashboard, "3", 1, "test")
+	require.NoError(t, err)
+
+	stats, err := s.GetStats(ctx, 1, commentmodel.ObjectTypeAnnotation, []string{"1", "2", "3", "4"})
+	require.NoError(t, err)
+	require.Len(t, stats, 2)
+	require.Equal(t, int64(3), stats["1"].Count)
+	require.NotZero(t, stats["1"].LastActivity)
+	require.Equal(t, int64(1), stats["2"].Count)
+
+	stats, err = s.GetStats(ctx, 1, commentmodel.ObjectTypeAnnotation, nil)
+	require.NoError(t, err)
+	require.Empty(t, stats)
+
+	_, err = s.GetStats(ctx, 1, "unknown", []string{"1"})
+	require.ErrorIs(t, err, errUnknownObjectType)
+}
@@ -21,4 +21,5 @@ var (
 type Storage interface {
 	Get(ctx context.Context, orgID int64, objectType string, objectID string, filter GetFilter) ([]*commentmodel.Comment, error)
 	Create(ctx context.Context, orgID int64, objectType string, objectID string, userID int64, content string) (*commentmodel.Comment, error)
+	GetStats(ctx context.Context, orgID int64, objectType string, objectIDs []string) (map[string]commentmodel.Stats, error)
 }
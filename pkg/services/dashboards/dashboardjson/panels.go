@@ -0,0 +1,20 @@
+// Package dashboardjson reads the panels and template variables of the JSON model of dashboards on the backend, for
+// the features that run the queries of a dashboard without the frontend, such as public dashboards and scheduled
+// snapshots.
+package dashboardjson
+
+import "github.com/grafana/grafana/pkg/components/simplejson"
+
+// Panels returns the panels of a dashboard, including the panels of collapsed rows. The panels share their data
+// with the dashboard.
+func Panels(dashboard *simplejson.Json) []*simplejson.Json {
+	panels := make([]*simplejson.Json, 0)
+	for _, panelObj := range dashboard.Get("panels").MustArray() {
+		panel := simplejson.NewFromAny(panelObj)
+		panels = append(panels, panel)
+		for _, rowPanelObj := range panel.Get("panels").MustArray() {
+			panels = append(panels, simplejson.NewFromAny(rowPanelObj))
+		}
+	}
+	return panels
+}
@@ -0,0 +1,249 @@
+package dashboardjson
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+)
+
+// allValue is the value of a template variable when all its options are selected.
+const allValue = "$__all"
+
+// variableRegex matches the syntaxes of template variables in queries: $var, [[var]], [[var:format]], ${var},
+// ${var.fieldPath} and ${var:format}. It's the same as the regex of the template service of the frontend.
+var variableRegex = regexp.MustCompile(`\$(\w+)|\[\[(\w+?)(?::(\w+))?\]\]|\$\{(\w+)(?:\.([^:^\}]+))?(?::([^\}]+))?\}`)
+
+// notInterpolatedQueryKeys lists the keys of a query that aren't interpolated, the datasource must stay the one
+// that was checked before running the query.
+var notInterpolatedQueryKeys = map[string]bool{
+	"datasource": true,
+	"refId":      true,
+}
+
+// The formats of template variables, as named by the template service of the frontend.
+const (
+	formatRaw         = "raw"
+	formatCSV         = "csv"
+	formatPipe        = "pipe"
+	formatRegex       = "regex"
+	formatGlob        = "glob"
+	formatJSON        = "json"
+	formatSingleQuote = "singlequote"
+	formatDoubleQuote = "doublequote"
+	formatSQLString   = "sqlstring"
+)
+
+// defaultFormatsByDatasourceType are the formats the datasources of the frontend apply to the template variables
+// with several values when a query doesn't set one. The other datasources get the glob format, like in the frontend.
+var defaultFormatsByDatasourceType = map[string]string{
+	"prometheus": formatRegex,
+	"loki":       formatRegex,
+	"mysql":      formatSQLString,
+	"postgres":   formatSQLString,
+	"mssql":      formatSQLString,
+}
+
+// regexSpecialChars matches the characters escaped by the regex format of the frontend.
+var regexSpecialChars = regexp.MustCompile(`[\\^$*+?.()|[\]{}/]`)
+
+// VariableValue is the value of a template variable interpolated in queries. It has several values when multiple
+// values, or all values, of the variable are selected. A raw value, such as the custom all value of a variable, is
+// interpolated as is, whatever the format of the variable in the query.
+type VariableValue struct {
+	Values []string
+	Raw    bool
+}
+
+// String returns the values joined with commas.
+func (v VariableValue) String() string {
+	return strings.Join(v.Values, ",")
+}
+
+// Variables returns the template variables of a dashboard.
+func Variables(dashboard *simplejson.Json) []map[string]interface{} {
+	var variables []map[string]interface{}
+	for _, v := range dashboard.GetPath("templating", "list").MustArray() {
+		if variable, ok := v.(map[string]interface{}); ok {
+			variables = append(variables, variable)
+		}
+	}
+	return variables
+}
+
+// SavedValues returns the values saved with a dashboard by template variable name. Ad hoc filters aren't
+// interpolated in the queries, so they are left out.
+func SavedValues(dashboard *simplejson.Json) map[string]VariableValue {
+	values := make(map[string]VariableValue)
+	for _, variable := range Variables(dashboard) {
+		name, _ := variable["name"].(string)
+		if name == "" || variable["type"] == "adhoc" {
+			continue
+		}
+		values[name] = SavedValue(variable)
+	}
+	return values
+}
+
+// SavedValue returns the value of a template variable saved with a dashboard. When all values are selected, it's
+// the custom all value of the variable, or else all its options.
+func SavedValue(variable map[string]interface{}) VariableValue {
+	current, _ := variable["current"].(map[string]interface{})
+
+	var values []string
+	switch value := current["value"].(type) {
+	case string:
+		values = []string{value}
+	case []interface{}:
+		for _, v := range value {
+			if s, ok := v.(string); ok {
+				values = append(values, s)
+			}
+		}
+	}
+
+	if len(values) == 0 {
+		// constant and text box variables may only have a query
+		query, _ := variable["query"].(string)
+		return VariableValue{Values: []string{query}}
+	}
+
+	if len(values) == 1 && values[0] == allValue {
+		if all, _ := variable["allValue"].(string); all != "" {
+			return VariableValue{Values: []string{all}, Raw: true}
+		}
+
+		values = nil
+		options, _ := variable["options"].([]interface{})
+		for _, o := range options {
+			option, _ := o.(map[string]interface{})
+			if value, ok := option["value"].(string); ok && value != allValue {
+				values = append(values, value)
+			}
+		}
+	}
+
+	return VariableValue{Values: values}
+}
+
+// InterpolateVariables returns a copy of a query with the template variables replaced in its values, except its
+// datasource and refId. Variables that aren't in the values, such as the global variables, are left for the
+// datasource to interpolate.
+func InterpolateVariables(query *simplejson.Json, values map[string]VariableValue) *simplejson.Json {
+	if len(values) == 0 {
+		return query
+	}
+
+	model, ok := query.Interface().(map[string]interface{})
+	if !ok {
+		return query
+	}
+
+	defaultFormat := formatGlob
+	if format, ok := defaultFormatsByDatasourceType[query.GetPath("datasource", "type").MustString()]; ok {
+		defaultFormat = format
+	}
+
+	// the query belongs to the dashboard, so the interpolated query is a copy
+	interpolated := make(map[string]interface{}, len(model))
+	for key, value := range model {
+		if notInterpolatedQueryKeys[key] {
+			interpolated[key] = value
+		} else {
+			interpolated[key] = interpolateValue(value, values, defaultFormat)
+		}
+	}
+
+	return simplejson.NewFromAny(interpolated)
+}
+
+func interpolateValue(value interface{}, values map[string]VariableValue, defaultFormat string) interface{} {
+	switch v := value.(type) {
+	case string:
+		return variableRegex.ReplaceAllStringFunc(v, func(match string) string {
+			groups := variableRegex.FindStringSubmatch(match)
+			name, format := groups[1], ""
+			if name == "" {
+				name, format = groups[2], groups[3]
+			}
+			if name == "" {
+				name, format = groups[4], groups[6]
+			}
+			replacement, ok := values[name]
+			if !ok {
+				return match
+			}
+			if format == "" {
+				format = defaultFormat
+			}
+			return formatValue(replacement, format)
+		})
+	case map[string]interface{}:
+		interpolated := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			interpolated[key] = interpolateValue(item, values, defaultFormat)
+		}
+		return interpolated
+	case []interface{}:
+		interpolated := make([]interface{}, len(v))
+		for i, item := range v {
+			interpolated[i] = interpolateValue(item, values, defaultFormat)
+		}
+		return interpolated
+	default:
+		return value
+	}
+}
+
+// formatValue formats the value of a template variable like the template service of the frontend. A single value
+// is interpolated as is when the query doesn't set a format, and unknown formats fall back to glob.
+func formatValue(value VariableValue, format string) string {
+	if value.Raw || len(value.Values) == 0 {
+		return value.String()
+	}
+
+	single := len(value.Values) == 1
+	switch format {
+	case formatRaw, formatCSV:
+		return value.String()
+	case formatPipe:
+		return strings.Join(value.Values, "|")
+	case formatRegex:
+		escaped := make([]string, 0, len(value.Values))
+		for _, v := range value.Values {
+			escaped = append(escaped, regexSpecialChars.ReplaceAllString(v, `\$0`))
+		}
+		if single {
+			return escaped[0]
+		}
+		return "(" + strings.Join(escaped, "|") + ")"
+	case formatJSON:
+		var data []byte
+		if single {
+			data, _ = json.Marshal(value.Values[0])
+		} else {
+			data, _ = json.Marshal(value.Values)
+		}
+		return string(data)
+	case formatSingleQuote:
+		return quoteValues(value.Values, "'", `\'`)
+	case formatDoubleQuote:
+		return quoteValues(value.Values, `"`, `\"`)
+	case formatSQLString:
+		return quoteValues(value.Values, "'", "''")
+	default:
+		if single {
+			return value.Values[0]
+		}
+		return "{" + value.String() + "}"
+	}
+}
+
+func quoteValues(values []string, quote string, escapedQuote string) string {
+	quoted := make([]string, 0, len(values))
+	for _, v := range values {
+		quoted = append(quoted, quote+strings.ReplaceAll(v, quote, escapedQuote)+quote)
+	}
+	return strings.Join(quoted, ",")
+}
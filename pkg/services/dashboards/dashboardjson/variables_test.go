@@ -0,0 +1,64 @@
+package dashboardjson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+)
+
+func TestInterpolateVariables(t *testing.T) {
+	values := map[string]VariableValue{
+		"env":  {Values: []string{"prod"}},
+		"host": {Values: []string{"a.1", "b"}},
+		"all":  {Values: []string{".*"}, Raw: true},
+	}
+
+	testCases := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{name: "dollar syntax", query: `up{env="$env"}`, expected: `up{env="prod"}`},
+		{name: "braces syntax", query: `up{env="${env}"}`, expected: `up{env="prod"}`},
+		{name: "braces syntax with format", query: `up{env=~"${host:regex}"}`, expected: `up{env=~"(a\.1|b)"}`},
+		{name: "brackets syntax", query: `SELECT * FROM [[env]]`, expected: `SELECT * FROM prod`},
+		{name: "brackets syntax with format", query: `[[host:pipe]]`, expected: `a.1|b`},
+		{name: "single value with format", query: `${env:regex} ${env:sqlstring}`, expected: `prod 'prod'`},
+		{name: "csv format", query: `${host:csv}`, expected: `a.1,b`},
+		{name: "json format", query: `${host:json}`, expected: `["a.1","b"]`},
+		{name: "quote formats", query: `${host:singlequote} ${host:doublequote}`, expected: `'a.1','b' "a.1","b"`},
+		{name: "glob by default", query: `$host ${host:unknown}`, expected: `{a.1,b} {a.1,b}`},
+		{name: "custom all value isn't formatted", query: `${all:regex} $all`, expected: `.* .*`},
+		{name: "unknown variables", query: `$__timeFilter(time) AND $unknown`, expected: `$__timeFilter(time) AND $unknown`},
+		{name: "no variables", query: `up`, expected: `up`},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			query := simplejson.NewFromAny(map[string]interface{}{"refId": "A", "expr": tt.query})
+			assert.Equal(t, tt.expected, InterpolateVariables(query, values).Get("expr").MustString())
+		})
+	}
+
+	t.Run("uses the default format of the datasource", func(t *testing.T) {
+		for datasourceType, expected := range map[string]string{"prometheus": `(a\.1|b)`, "postgres": `'a.1','b'`, "graphite": `{a.1,b}`} {
+			query := simplejson.NewFromAny(map[string]interface{}{"datasource": map[string]interface{}{"type": datasourceType}, "expr": "$host"})
+			assert.Equal(t, expected, InterpolateVariables(query, values).Get("expr").MustString(), datasourceType)
+		}
+	})
+
+	t.Run("interpolates nested values but not the datasource", func(t *testing.T) {
+		query := simplejson.NewFromAny(map[string]interface{}{
+			"refId":      "$env",
+			"datasource": map[string]interface{}{"uid": "$env"},
+			"filters":    []interface{}{map[string]interface{}{"value": "$env"}},
+		})
+
+		interpolated := InterpolateVariables(query, values)
+		assert.Equal(t, "$env", interpolated.Get("refId").MustString())
+		assert.Equal(t, "$env", interpolated.GetPath("datasource", "uid").MustString())
+		assert.Equal(t, "prod", interpolated.Get("filters").GetIndex(0).Get("value").MustString())
+	})
+}
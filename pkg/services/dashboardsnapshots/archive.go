@@ -0,0 +1,160 @@
+package dashboardsnapshots
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+const (
+	// ArchiveVersion is the version of the format of the snapshot archives.
+	ArchiveVersion = 1
+
+	// MaxArchiveSize is the maximum size of a snapshot archive, compressed or not.
+	MaxArchiveSize = 100 << 20
+
+	archiveManifestFile  = "manifest.json"
+	archiveDashboardFile = "dashboard.json.enc"
+	archiveSignatureFile = "signature"
+)
+
+// ArchiveManifest is the metadata of a snapshot archive.
+type ArchiveManifest struct {
+	Version int       `json:"version"`
+	Name    string    `json:"name"`
+	Created time.Time `json:"created"`
+	Expires time.Time `json:"expires"`
+}
+
+// Archive is a snapshot read from an archive.
+type Archive struct {
+	ArchiveManifest
+	Dashboard *simplejson.Json
+}
+
+// WriteArchive writes a snapshot as a gzipped tar archive. The dashboard of the snapshot, which includes the data of
+// its panels, is encrypted with the key, and the archive is signed with an HMAC-SHA256 of its files.
+func WriteArchive(w io.Writer, snapshot *DashboardSnapshot, key string) error {
+	manifest, err := json.Marshal(ArchiveManifest{
+		Version: ArchiveVersion,
+		Name:    snapshot.Name,
+		Created: snapshot.Created,
+		Expires: snapshot.Expires,
+	})
+	if err != nil {
+		return err
+	}
+	dashboard, err := snapshot.Dashboard.Encode()
+	if err != nil {
+		return err
+	}
+	encrypted, err := util.Encrypt(dashboard, key)
+	if err != nil {
+		return err
+	}
+	signature := []byte(hex.EncodeToString(archiveSignature(key, manifest, encrypted)))
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	for _, file := range []struct {
+		name    string
+		content []byte
+	}{
+		{archiveManifestFile, manifest},
+		{archiveDashboardFile, encrypted},
+		{archiveSignatureFile, signature},
+	} {
+		if err := tw.WriteHeader(&tar.Header{
+			Name:    file.name,
+			Mode:    0600,
+			Size:    int64(len(file.content)),
+			ModTime: snapshot.Created,
+		}); err != nil {
+			return err
+		}
+		if _, err := tw.Write(file.content); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// ReadArchive reads a snapshot archive written by WriteArchive with the same key.
+func ReadArchive(r io.Reader, key string) (*Archive, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, ErrBaseInvalidArchive.Errorf("failed to decompress archive: %w", err)
+	}
+	files := make(map[string][]byte)
+	tr := tar.NewReader(io.LimitReader(gz, MaxArchiveSize))
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, ErrBaseInvalidArchive.Errorf("failed to read archive: %w", err)
+		}
+		switch header.Name {
+		case archiveManifestFile, archiveDashboardFile, archiveSignatureFile:
+		default:
+			return nil, ErrBaseInvalidArchive.Errorf("unexpected file %q in archive", header.Name)
+		}
+		var buf bytes.Buffer
+		if _, err := io.Copy(&buf, tr); err != nil {
+			return nil, ErrBaseInvalidArchive.Errorf("failed to read %s: %w", header.Name, err)
+		}
+		files[header.Name] = buf.Bytes()
+	}
+	for _, name := range []string{archiveManifestFile, archiveDashboardFile, archiveSignatureFile} {
+		if _, ok := files[name]; !ok {
+			return nil, ErrBaseInvalidArchive.Errorf("missing %s in archive", name)
+		}
+	}
+
+	signature, err := hex.DecodeString(string(files[archiveSignatureFile]))
+	if err != nil || !hmac.Equal(signature, archiveSignature(key, files[archiveManifestFile], files[archiveDashboardFile])) {
+		return nil, ErrBaseInvalidArchiveSignature.Errorf("invalid archive signature")
+	}
+
+	archive := &Archive{}
+	if err := json.Unmarshal(files[archiveManifestFile], &archive.ArchiveManifest); err != nil {
+		return nil, ErrBaseInvalidArchive.Errorf("invalid manifest: %w", err)
+	}
+	if archive.Version != ArchiveVersion {
+		return nil, ErrBaseInvalidArchive.Errorf("unsupported archive version %d", archive.Version)
+	}
+	dashboard, err := util.Decrypt(files[archiveDashboardFile], key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt dashboard: %w", err)
+	}
+	if archive.Dashboard, err = simplejson.NewJson(dashboard); err != nil {
+		return nil, ErrBaseInvalidArchive.Errorf("invalid dashboard: %w", err)
+	}
+	return archive, nil
+}
+
+// archiveSignature returns the HMAC-SHA256 of the manifest and the encrypted dashboard of an archive. The files are
+// hashed separately so that their boundary is part of the signature.
+func archiveSignature(key string, manifest []byte, dashboard []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(key))
+	for _, content := range [][]byte{manifest, dashboard} {
+		sum := sha256.Sum256(content)
+		mac.Write(sum[:])
+	}
+	return mac.Sum(nil)
+}
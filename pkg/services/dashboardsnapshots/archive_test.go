@@ -0,0 +1,113 @@
+package dashboardsnapshots
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+)
+
+func TestArchive(t *testing.T) {
+	dashboard, err := simplejson.NewJson([]byte(`{"id":1,"title":"Incident","panels":[{"id":2,"snapshotData":[{"fields":[{"name":"value","values":[1,2,3]}]}]}]}`))
+	require.NoError(t, err)
+	snapshot := &DashboardSnapshot{
+		Name:      "Incident 42",
+		Key:       "key",
+		Dashboard: dashboard,
+		Created:   time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC),
+		Expires:   time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	write := func(t *testing.T) []byte {
+		t.Helper()
+
+		var buf bytes.Buffer
+		require.NoError(t, WriteArchive(&buf, snapshot, "secret"))
+		return buf.Bytes()
+	}
+
+	t.Run("reads the snapshot of an archive", func(t *testing.T) {
+		archive, err := ReadArchive(bytes.NewReader(write(t)), "secret")
+		require.NoError(t, err)
+		require.Equal(t, "Incident 42", archive.Name)
+		require.True(t, snapshot.Created.Equal(archive.Created))
+		require.True(t, snapshot.Expires.Equal(archive.Expires))
+		expected, err := dashboard.Encode()
+		require.NoError(t, err)
+		actual, err := archive.Dashboard.Encode()
+		require.NoError(t, err)
+		require.JSONEq(t, string(expected), string(actual))
+	})
+
+	t.Run("encrypts the dashboard", func(t *testing.T) {
+		files := readTar(t, write(t))
+		require.NotContains(t, string(files[archiveDashboardFile]), "Incident")
+	})
+
+	t.Run("rejects an archive signed with another key", func(t *testing.T) {
+		_, err := ReadArchive(bytes.NewReader(write(t)), "other secret")
+		require.ErrorIs(t, err, ErrBaseInvalidArchiveSignature)
+	})
+
+	t.Run("rejects a modified archive", func(t *testing.T) {
+		files := readTar(t, write(t))
+		files[archiveManifestFile] = bytes.Replace(files[archiveManifestFile], []byte("2022-11-01"), []byte("2099-11-01"), 1)
+
+		_, err := ReadArchive(bytes.NewReader(writeTar(t, files)), "secret")
+		require.ErrorIs(t, err, ErrBaseInvalidArchiveSignature)
+	})
+
+	t.Run("rejects an archive with missing files", func(t *testing.T) {
+		files := readTar(t, write(t))
+		delete(files, archiveSignatureFile)
+
+		_, err := ReadArchive(bytes.NewReader(writeTar(t, files)), "secret")
+		require.ErrorIs(t, err, ErrBaseInvalidArchive)
+	})
+
+	t.Run("rejects a file that isn't an archive", func(t *testing.T) {
+		_, err := ReadArchive(bytes.NewReader([]byte(`{"dashboard":{}}`)), "secret")
+		require.ErrorIs(t, err, ErrBaseInvalidArchive)
+	})
+}
+
+func readTar(t *testing.T, archive []byte) map[string][]byte {
+	t.Helper()
+
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	require.NoError(t, err)
+	tr := tar.NewReader(gz)
+	files := make(map[string][]byte)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return files
+		}
+		require.NoError(t, err)
+		content, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		files[header.Name] = content
+	}
+}
+
+func writeTar(t *testing.T, files map[string][]byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(content))}))
+		_, err := tw.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
@@ -61,6 +61,8 @@ func (d *DashboardSnapshotStore) CreateDashboardSnapshot(ctx context.Context, cm
 			External:           cmd.External,
 			ExternalUrl:        cmd.ExternalUrl,
 			ExternalDeleteUrl:  cmd.ExternalDeleteUrl,
+			ScheduleId:         cmd.ScheduleId,
+			DashboardUid:       cmd.DashboardUid,
 			Dashboard:          simplejson.New(),
 			DashboardEncrypted: cmd.DashboardEncrypted,
 			Expires:            expires,
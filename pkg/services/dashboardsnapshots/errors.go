@@ -4,4 +4,9 @@ import (
 	"github.com/grafana/grafana/pkg/util/errutil"
 )
 
-var ErrBaseNotFound = errutil.NewBase(errutil.StatusNotFound, "dashboardsnapshots.not-found", errutil.WithPublicMessage("Snapshot not found"))
+var (
+	ErrBaseNotFound                = errutil.NewBase(errutil.StatusNotFound, "dashboardsnapshots.not-found", errutil.WithPublicMessage("Snapshot not found"))
+	ErrBaseArchivesDisabled        = errutil.NewBase(errutil.StatusForbidden, "dashboardsnapshots.archives-disabled", errutil.WithPublicMessage("Snapshot archives are disabled, set archive_key in the snapshots section to enable them"))
+	ErrBaseInvalidArchive          = errutil.NewBase(errutil.StatusBadRequest, "dashboardsnapshots.invalid-archive", errutil.WithPublicMessage("Invalid snapshot archive"))
+	ErrBaseInvalidArchiveSignature = errutil.NewBase(errutil.StatusBadRequest, "dashboardsnapshots.invalid-archive-signature", errutil.WithPublicMessage("The signature of the snapshot archive is invalid, it was modified or signed with another key"))
+)
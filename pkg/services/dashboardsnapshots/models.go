@@ -18,6 +18,10 @@ type DashboardSnapshot struct {
 	External          bool
 	ExternalUrl       string
 	ExternalDeleteUrl string
+	// ScheduleId is the ID of the schedule that created the snapshot, if any.
+	ScheduleId int64
+	// DashboardUid is the UID of the dashboard of the schedule that created the snapshot, if any.
+	DashboardUid string
 
 	Expires time.Time
 	Created time.Time
@@ -73,8 +77,10 @@ type CreateDashboardSnapshotCommand struct {
 	// required:false
 	DeleteKey string `json:"deleteKey"`
 
-	OrgId  int64 `json:"-"`
-	UserId int64 `json:"-"`
+	OrgId        int64  `json:"-"`
+	UserId       int64  `json:"-"`
+	ScheduleId   int64  `json:"-"`
+	DashboardUid string `json:"-"`
 
 	DashboardEncrypted []byte `json:"-"`
 
@@ -0,0 +1,254 @@
+package schedule
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/api/routing"
+	"github.com/grafana/grafana/pkg/middleware"
+	"github.com/grafana/grafana/pkg/models"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+const (
+	defaultSnapshotsLimit = 100
+	maxSnapshotsLimit     = 1000
+)
+
+func (s *Service) registerAPIEndpoints() {
+	s.routeRegister.Group("/api/dashboards/uid/:uid", func(dashboardRoute routing.RouteRegister) {
+		dashboardRoute.Get("/scheduled-snapshots", routing.Wrap(s.listSnapshotsHandler))
+
+		dashboardRoute.Group("/snapshot-schedules", func(schedulesRoute routing.RouteRegister) {
+			schedulesRoute.Get("/", routing.Wrap(s.listHandler))
+			schedulesRoute.Post("/", routing.Wrap(s.createHandler))
+			schedulesRoute.Get("/:scheduleUid", routing.Wrap(s.getHandler))
+			schedulesRoute.Put("/:scheduleUid", routing.Wrap(s.updateHandler))
+			schedulesRoute.Delete("/:scheduleUid", routing.Wrap(s.deleteHandler))
+		})
+	}, middleware.ReqSignedIn)
+}
+
+// checkDashboardAccess returns an error response if the signed in user can't view the dashboard of the request, or
+// can't edit it when edit is true.
+func (s *Service) checkDashboardAccess(c *models.ReqContext, edit bool) response.Response {
+	query := models.GetDashboardQuery{Uid: web.Params(c.Req)[":uid"], OrgId: c.OrgID}
+	if err := s.dashboardService.GetDashboard(c.Req.Context(), &query); err != nil {
+		return response.Error(http.StatusNotFound, "Dashboard not found", err)
+	}
+
+	g := guardian.New(c.Req.Context(), query.Result.Id, c.OrgID, c.SignedInUser)
+	can, err := g.CanView()
+	if err == nil && can && edit {
+		can, err = g.CanEdit()
+	}
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to check the dashboard permissions", err)
+	}
+	if !can {
+		return response.Err(ErrDashboardAccess.Errorf("access denied to dashboard %s", query.Result.Uid))
+	}
+	return nil
+}
+
+// swagger:route GET /dashboards/uid/{uid}/snapshot-schedules snapshots listSnapshotSchedules
+//
+// Get the snapshot schedules of a dashboard.
+//
+// Responses:
+// 200: listSnapshotSchedulesResponse
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (s *Service) listHandler(c *models.ReqContext) response.Response {
+	if resp := s.checkDashboardAccess(c, false); resp != nil {
+		return resp
+	}
+
+	schedules, err := s.List(c.Req.Context(), c.OrgID, web.Params(c.Req)[":uid"])
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to get snapshot schedules", err)
+	}
+	return response.JSON(http.StatusOK, schedules)
+}
+
+// swagger:route POST /dashboards/uid/{uid}/snapshot-schedules snapshots createSnapshotSchedule
+//
+// Create a snapshot schedule of a dashboard.
+//
+// The queries of the dashboard are run with the permissions of the user who creates the schedule.
+//
+// Responses:
+// 200: snapshotScheduleResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (s *Service) createHandler(c *models.ReqContext) response.Response {
+	cmd := SaveScheduleCommand{}
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	if resp := s.checkDashboardAccess(c, true); resp != nil {
+		return resp
+	}
+
+	schedule, err := s.Create(c.Req.Context(), c.SignedInUser, web.Params(c.Req)[":uid"], cmd)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to create snapshot schedule", err)
+	}
+	return response.JSON(http.StatusOK, schedule)
+}
+
+// swagger:route GET /dashboards/uid/{uid}/snapshot-schedules/{scheduleUid} snapshots getSnapshotSchedule
+//
+// Get a snapshot schedule of a dashboard.
+//
+// Responses:
+// 200: snapshotScheduleResponse
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (s *Service) getHandler(c *models.ReqContext) response.Response {
+	if resp := s.checkDashboardAccess(c, false); resp != nil {
+		return resp
+	}
+
+	schedule, err := s.Get(c.Req.Context(), c.OrgID, web.Params(c.Req)[":uid"], web.Params(c.Req)[":scheduleUid"])
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to get snapshot schedule", err)
+	}
+	return response.JSON(http.StatusOK, schedule)
+}
+
+// swagger:route PUT /dashboards/uid/{uid}/snapshot-schedules/{scheduleUid} snapshots updateSnapshotSchedule
+//
+// Update a snapshot schedule of a dashboard.
+//
+// Responses:
+// 200: snapshotScheduleResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (s *Service) updateHandler(c *models.ReqContext) response.Response {
+	cmd := SaveScheduleCommand{}
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	if resp := s.checkDashboardAccess(c, true); resp != nil {
+		return resp
+	}
+
+	schedule, err := s.Update(c.Req.Context(), c.OrgID, web.Params(c.Req)[":uid"], web.Params(c.Req)[":scheduleUid"], cmd)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to update snapshot schedule", err)
+	}
+	return response.JSON(http.StatusOK, schedule)
+}
+
+// swagger:route DELETE /dashboards/uid/{uid}/snapshot-schedules/{scheduleUid} snapshots deleteSnapshotSchedule
+//
+// Delete a snapshot schedule of a dashboard.
+//
+// The snapshots taken by the schedule are kept until they expire.
+//
+// Responses:
+// 200: okResponse
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (s *Service) deleteHandler(c *models.ReqContext) response.Response {
+	if resp := s.checkDashboardAccess(c, true); resp != nil {
+		return resp
+	}
+
+	if err := s.Delete(c.Req.Context(), c.OrgID, web.Params(c.Req)[":uid"], web.Params(c.Req)[":scheduleUid"]); err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to delete snapshot schedule", err)
+	}
+	return response.Success("Snapshot schedule deleted")
+}
+
+// swagger:route GET /dashboards/uid/{uid}/scheduled-snapshots snapshots listScheduledSnapshots
+//
+// Get the snapshots taken by the schedules of a dashboard, the most recent first.
+//
+// Responses:
+// 200: listScheduledSnapshotsResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (s *Service) listSnapshotsHandler(c *models.ReqContext) response.Response {
+	limit := defaultSnapshotsLimit
+	if value := c.Query("limit"); value != "" {
+		var err error
+		if limit, err = strconv.Atoi(value); err != nil || limit <= 0 || limit > maxSnapshotsLimit {
+			return response.Error(http.StatusBadRequest, "limit must be between 1 and 1000", err)
+		}
+	}
+	if resp := s.checkDashboardAccess(c, false); resp != nil {
+		return resp
+	}
+
+	snapshots, err := s.ListSnapshots(c.Req.Context(), c.OrgID, web.Params(c.Req)[":uid"], limit)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to get scheduled snapshots", err)
+	}
+	return response.JSON(http.StatusOK, snapshots)
+}
+
+// swagger:parameters listSnapshotSchedules getSnapshotSchedule createSnapshotSchedule updateSnapshotSchedule deleteSnapshotSchedule listScheduledSnapshots
+type DashboardUIDParam struct {
+	// in:path
+	// required:true
+	UID string `json:"uid"`
+}
+
+// swagger:parameters getSnapshotSchedule updateSnapshotSchedule deleteSnapshotSchedule
+type SnapshotScheduleUIDParam struct {
+	// in:path
+	// required:true
+	ScheduleUID string `json:"scheduleUid"`
+}
+
+// swagger:parameters createSnapshotSchedule updateSnapshotSchedule
+type SaveSnapshotScheduleParams struct {
+	// in:body
+	// required:true
+	Body SaveScheduleCommand `json:"body"`
+}
+
+// swagger:parameters listScheduledSnapshots
+type ListScheduledSnapshotsParams struct {
+	// in:query
+	// required:false
+	// default:100
+	Limit int64 `json:"limit"`
+}
+
+// swagger:response listSnapshotSchedulesResponse
+type ListSnapshotSchedulesResponse struct {
+	// in:body
+	Body []*Schedule `json:"body"`
+}
+
+// swagger:response snapshotScheduleResponse
+type SnapshotScheduleResponse struct {
+	// in:body
+	Body *Schedule `json:"body"`
+}
+
+// swagger:response listScheduledSnapshotsResponse
+type ListScheduledSnapshotsResponse struct {
+	// in:body
+	Body []*ScheduledSnapshot `json:"body"`
+}
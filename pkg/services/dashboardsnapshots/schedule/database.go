@@ -0,0 +1,110 @@
+package schedule
+
+import (
+	"context"
+	"time"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+)
+
+type store struct {
+	db db.DB
+}
+
+func (s *store) insert(ctx context.Context, schedule *Schedule) error {
+	return s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		_, err := sess.Insert(schedule)
+		return err
+	})
+}
+
+func (s *store) update(ctx context.Context, schedule *Schedule) error {
+	return s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		_, err := sess.ID(schedule.ID).
+			Cols("name", "cron", "timezone", "time_from", "time_to", "expires", "enabled", "next_run", "updated").
+			Update(schedule)
+		return err
+	})
+}
+
+func (s *store) get(ctx context.Context, orgID int64, dashboardUID string, uid string) (*Schedule, error) {
+	schedule := &Schedule{}
+	err := s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		has, err := sess.Where("org_id = ? AND dashboard_uid = ? AND uid = ?", orgID, dashboardUID, uid).Get(schedule)
+		if err != nil {
+			return err
+		}
+		if !has {
+			return ErrScheduleNotFound.Errorf("snapshot schedule %s not found", uid)
+		}
+		return nil
+	})
+	return schedule, err
+}
+
+func (s *store) listByDashboard(ctx context.Context, orgID int64, dashboardUID string) ([]*Schedule, error) {
+	schedules := make([]*Schedule, 0)
+	err := s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		return sess.Where("org_id = ? AND dashboard_uid = ?", orgID, dashboardUID).Asc("name", "id").Find(&schedules)
+	})
+	return schedules, err
+}
+
+func (s *store) delete(ctx context.Context, orgID int64, dashboardUID string, uid string) error {
+	return s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		affected, err := sess.Where("org_id = ? AND dashboard_uid = ? AND uid = ?", orgID, dashboardUID, uid).Delete(&Schedule{})
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return ErrScheduleNotFound.Errorf("snapshot schedule %s not found", uid)
+		}
+		return nil
+	})
+}
+
+// listDue returns the enabled schedules whose next run is before now, the most overdue first.
+func (s *store) listDue(ctx context.Context, now time.Time, limit int) ([]*Schedule, error) {
+	schedules := make([]*Schedule, 0)
+	err := s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		return sess.Where("enabled = ? AND next_run <= ?", true, now).Asc("next_run").Limit(limit).Find(&schedules)
+	})
+	return schedules, err
+}
+
+// claim sets the next run of a schedule that is still due, and whether it's enabled. It returns false if the
+// schedule is no longer due, because another instance claimed it.
+func (s *store) claim(ctx context.Context, schedule *Schedule, now time.Time) (bool, error) {
+	var affected int64
+	err := s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		var err error
+		affected, err = sess.ID(schedule.ID).Where("enabled = ? AND next_run <= ?", true, now).
+			Cols("enabled", "next_run").Update(schedule)
+		return err
+	})
+	return affected > 0, err
+}
+
+// updateRun records a run of a schedule.
+func (s *store) updateRun(ctx context.Context, schedule *Schedule) error {
+	return s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		_, err := sess.ID(schedule.ID).Cols("enabled", "next_run", "last_run", "last_error").Update(schedule)
+		return err
+	})
+}
+
+// listSnapshots returns the snapshots created by the schedules of a dashboard, the most recent first. The
+// expired snapshots are left out. The snapshots of deleted schedules are listed without a schedule UID.
+func (s *store) listSnapshots(ctx context.Context, orgID int64, dashboardUID string, now time.Time, limit int) ([]*ScheduledSnapshot, error) {
+	snapshots := make([]*ScheduledSnapshot, 0)
+	err := s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		return sess.SQL(`
+			SELECT s.`+s.db.GetDialect().Quote("key")+` AS `+s.db.GetDialect().Quote("key")+`, s.name, COALESCE(sch.uid, '') AS schedule_uid, s.created, s.expires
+			FROM dashboard_snapshot s
+			LEFT JOIN dashboard_snapshot_schedule sch ON sch.id = s.schedule_id AND sch.org_id = s.org_id
+			WHERE s.org_id = ? AND s.dashboard_uid = ? AND s.schedule_id > 0 AND s.expires > ?
+			ORDER BY s.created DESC`+s.db.GetDialect().Limit(int64(limit)),
+			orgID, dashboardUID, now).Find(&snapshots)
+	})
+	return snapshots, err
+}
@@ -0,0 +1,80 @@
+package schedule
+
+import (
+	"time"
+
+	"github.com/grafana/grafana/pkg/util/errutil"
+)
+
+const (
+	defaultName     = "Scheduled snapshot"
+	defaultTimezone = "UTC"
+	defaultFrom     = "now-24h"
+	defaultTo       = "now"
+)
+
+var (
+	ErrScheduleNotFound = errutil.NewBase(errutil.StatusNotFound, "snapshotschedules.not-found", errutil.WithPublicMessage("Snapshot schedule not found"))
+	ErrInvalidSchedule  = errutil.NewBase(errutil.StatusBadRequest, "snapshotschedules.invalid", errutil.WithPublicMessage("Invalid snapshot schedule"))
+	ErrDashboardAccess  = errutil.NewBase(errutil.StatusForbidden, "snapshotschedules.access-denied", errutil.WithPublicMessage("Access denied to the dashboard"))
+)
+
+// Schedule is a schedule of snapshots of a dashboard. The queries of the dashboard are run with the permissions of
+// the user who created the schedule.
+// swagger:model SnapshotSchedule
+type Schedule struct {
+	ID           int64  `xorm:"pk autoincr 'id'" json:"-"`
+	UID          string `xorm:"uid" json:"uid"`
+	OrgID        int64  `xorm:"org_id" json:"-"`
+	DashboardUID string `xorm:"dashboard_uid" json:"dashboardUid"`
+	UserID       int64  `xorm:"user_id" json:"userId"`
+	Name         string `xorm:"name" json:"name"`
+	// Cron is the schedule of the snapshots in the standard cron format, e.g. `59 23 * * *` for every night at 23:59.
+	Cron string `xorm:"cron" json:"cron"`
+	// Timezone is the IANA time zone of the cron schedule and of the time range.
+	Timezone string `xorm:"timezone" json:"timezone"`
+	// From is the start of the time range of the snapshots, e.g. `now-24h` or `now/d`.
+	From string `xorm:"time_from" json:"from"`
+	// To is the end of the time range of the snapshots.
+	To string `xorm:"time_to" json:"to"`
+	// Expires is the number of seconds after which the snapshots expire, 0 means never.
+	Expires   int64      `xorm:"expires" json:"expires"`
+	Enabled   bool       `xorm:"enabled" json:"enabled"`
+	NextRun   time.Time  `xorm:"next_run" json:"nextRun"`
+	LastRun   *time.Time `xorm:"last_run" json:"lastRun,omitempty"`
+	LastError string     `xorm:"last_error" json:"lastError,omitempty"`
+	Created   time.Time  `xorm:"created" json:"created"`
+	Updated   time.Time  `xorm:"updated" json:"updated"`
+}
+
+func (Schedule) TableName() string {
+	return "dashboard_snapshot_schedule"
+}
+
+// SaveScheduleCommand is the command to create or update a schedule.
+// swagger:model SaveSnapshotScheduleCommand
+type SaveScheduleCommand struct {
+	Name string `json:"name"`
+	// required:true
+	// example: 59 23 * * *
+	Cron string `json:"cron"`
+	// default: UTC
+	Timezone string `json:"timezone"`
+	// default: now-24h
+	From string `json:"from"`
+	// default: now
+	To      string `json:"to"`
+	Expires int64  `json:"expires"`
+	Enabled bool   `json:"enabled"`
+}
+
+// ScheduledSnapshot is a snapshot created by a schedule.
+// swagger:model
+type ScheduledSnapshot struct {
+	Key         string    `xorm:"key" json:"key"`
+	Name        string    `xorm:"name" json:"name"`
+	ScheduleUID string    `xorm:"schedule_uid" json:"scheduleUid"`
+	URL         string    `xorm:"-" json:"url"`
+	Created     time.Time `xorm:"created" json:"created"`
+	Expires     time.Time `xorm:"expires" json:"expires"`
+}
@@ -0,0 +1,295 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/robfig/cron/v3"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/api/routing"
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/infra/serverlock"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/dashboardsnapshots"
+	"github.com/grafana/grafana/pkg/services/libraryelements"
+	"github.com/grafana/grafana/pkg/services/query"
+	"github.com/grafana/grafana/pkg/services/user"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+const (
+	// runInterval is how often the due schedules are looked up.
+	runInterval = time.Minute
+	// runTimeout bounds the snapshots of a run. The lock is held for a bit less than the interval so that the next
+	// run isn't skipped, and the snapshots are taken while the lock is held.
+	runTimeout = runInterval - 10*time.Second
+	// maxRunsPerInterval is the maximum number of snapshots taken in an interval, the other due schedules run in
+	// the next intervals.
+	maxRunsPerInterval = 100
+)
+
+// queryDataService runs the queries of the panels.
+type queryDataService interface {
+	QueryData(ctx context.Context, user *user.SignedInUser, skipCache bool, reqDTO dtos.MetricRequest) (*backend.QueryDataResponse, error)
+}
+
+// Service takes the snapshots of the dashboards on a schedule. A single instance takes the snapshots of a run when
+// Grafana is highly available.
+type Service struct {
+	cfg                   *setting.Cfg
+	store                 *store
+	routeRegister         routing.RouteRegister
+	serverLock            *serverlock.ServerLockService
+	dashboardService      dashboards.DashboardService
+	libraryElementService libraryelements.Service
+	snapshotService       dashboardsnapshots.Service
+	queryService          queryDataService
+	userService           user.Service
+	accessControl         accesscontrol.AccessControl
+	accessControlService  accesscontrol.Service
+	log                   log.Logger
+	now                   func() time.Time
+}
+
+func ProvideService(
+	cfg *setting.Cfg,
+	sqlStore db.DB,
+	routeRegister routing.RouteRegister,
+	serverLock *serverlock.ServerLockService,
+	dashboardService dashboards.DashboardService,
+	libraryElementService libraryelements.Service,
+	snapshotService dashboardsnapshots.Service,
+	queryService *query.Service,
+	userService user.Service,
+	ac accesscontrol.AccessControl,
+	acService accesscontrol.Service,
+) *Service {
+	s := &Service{
+		cfg:                   cfg,
+		store:                 &store{db: sqlStore},
+		routeRegister:         routeRegister,
+		serverLock:            serverLock,
+		dashboardService:      dashboardService,
+		libraryElementService: libraryElementService,
+		snapshotService:       snapshotService,
+		queryService:          queryService,
+		userService:           userService,
+		accessControl:         ac,
+		accessControlService:  acService,
+		log:                   log.New("snapshot.schedule"),
+		now:                   time.Now,
+	}
+
+	s.registerAPIEndpoints()
+
+	return s
+}
+
+func (s *Service) Run(ctx context.Context) error {
+	ticker := time.NewTicker(runInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			err := s.serverLock.LockAndExecute(ctx, "dashboard snapshot schedules", runTimeout, s.runDue)
+			if err != nil {
+				s.log.Error("Failed to run the snapshot schedules", "error", err)
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Create creates a schedule of the snapshots of a dashboard owned by a user.
+func (s *Service) Create(ctx context.Context, owner *user.SignedInUser, dashboardUID string, cmd SaveScheduleCommand) (*Schedule, error) {
+	now := s.now()
+	schedule := &Schedule{
+		UID:          util.GenerateShortUID(),
+		OrgID:        owner.OrgID,
+		DashboardUID: dashboardUID,
+		UserID:       owner.UserID,
+		Created:      now,
+	}
+	if err := s.apply(schedule, cmd, now); err != nil {
+		return nil, err
+	}
+	if err := s.store.insert(ctx, schedule); err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+// Update replaces the settings of a schedule. The schedule keeps its owner.
+func (s *Service) Update(ctx context.Context, orgID int64, dashboardUID string, uid string, cmd SaveScheduleCommand) (*Schedule, error) {
+	schedule, err := s.store.get(ctx, orgID, dashboardUID, uid)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.apply(schedule, cmd, s.now()); err != nil {
+		return nil, err
+	}
+	if err := s.store.update(ctx, schedule); err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+func (s *Service) Get(ctx context.Context, orgID int64, dashboardUID string, uid string) (*Schedule, error) {
+	return s.store.get(ctx, orgID, dashboardUID, uid)
+}
+
+func (s *Service) List(ctx context.Context, orgID int64, dashboardUID string) ([]*Schedule, error) {
+	return s.store.listByDashboard(ctx, orgID, dashboardUID)
+}
+
+// Delete deletes a schedule. The snapshots it created are kept until they expire.
+func (s *Service) Delete(ctx context.Context, orgID int64, dashboardUID string, uid string) error {
+	return s.store.delete(ctx, orgID, dashboardUID, uid)
+}
+
+// ListSnapshots returns the snapshots of a dashboard that haven't expired, the most recent first.
+func (s *Service) ListSnapshots(ctx context.Context, orgID int64, dashboardUID string, limit int) ([]*ScheduledSnapshot, error) {
+	snapshots, err := s.store.listSnapshots(ctx, orgID, dashboardUID, s.now(), limit)
+	if err != nil {
+		return nil, err
+	}
+	for _, snapshot := range snapshots {
+		snapshot.URL = setting.ToAbsUrl("dashboard/snapshot/" + snapshot.Key)
+	}
+	return snapshots, nil
+}
+
+// apply validates a command and sets it to a schedule.
+func (s *Service) apply(schedule *Schedule, cmd SaveScheduleCommand, now time.Time) error {
+	if cmd.Name == "" {
+		cmd.Name = defaultName
+	}
+	if cmd.Timezone == "" {
+		cmd.Timezone = defaultTimezone
+	}
+	if cmd.From == "" {
+		cmd.From = defaultFrom
+	}
+	if cmd.To == "" {
+		cmd.To = defaultTo
+	}
+	if cmd.Expires < 0 {
+		return ErrInvalidSchedule.Errorf("expires must not be negative")
+	}
+	if _, err := cron.ParseStandard(cmd.Cron); err != nil {
+		return ErrInvalidSchedule.Errorf("invalid cron %q: %w", cmd.Cron, err)
+	}
+	if _, err := time.LoadLocation(cmd.Timezone); err != nil {
+		return ErrInvalidSchedule.Errorf("invalid timezone %q: %w", cmd.Timezone, err)
+	}
+
+	schedule.Name = cmd.Name
+	schedule.Cron = cmd.Cron
+	schedule.Timezone = cmd.Timezone
+	schedule.From = cmd.From
+	schedule.To = cmd.To
+	schedule.Expires = cmd.Expires
+	schedule.Enabled = cmd.Enabled
+	schedule.Updated = now
+	if _, _, err := timeRange(schedule, now); err != nil {
+		return ErrInvalidSchedule.Errorf("invalid time range: %w", err)
+	}
+	next, err := nextRun(schedule, now)
+	if err != nil {
+		return ErrInvalidSchedule.Errorf("invalid schedule: %w", err)
+	}
+	schedule.NextRun = next
+	return nil
+}
+
+// nextRun returns the first time after now when a schedule runs.
+func nextRun(schedule *Schedule, now time.Time) (time.Time, error) {
+	loc, err := time.LoadLocation(schedule.Timezone)
+	if err != nil {
+		return time.Time{}, err
+	}
+	spec, err := cron.ParseStandard(schedule.Cron)
+	if err != nil {
+		return time.Time{}, err
+	}
+	next := spec.Next(now.In(loc))
+	if next.IsZero() {
+		return time.Time{}, ErrInvalidSchedule.Errorf("the cron %q never runs", schedule.Cron)
+	}
+	return next.UTC(), nil
+}
+
+// runDue takes the snapshots of the due schedules. The runs missed while Grafana was down are skipped, a schedule
+// takes a single snapshot when it's overdue. The schedules left when the run times out are due in the next run.
+func (s *Service) runDue(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, runTimeout)
+	defer cancel()
+
+	now := s.now()
+	schedules, err := s.store.listDue(ctx, now, maxRunsPerInterval)
+	if err != nil {
+		s.log.Error("Failed to list the due snapshot schedules", "error", err)
+		return
+	}
+
+	for _, schedule := range schedules {
+		if ctx.Err() != nil {
+			return
+		}
+		s.run(ctx, schedule, now)
+	}
+}
+
+// run takes the snapshot of a schedule and records the run. The schedule is claimed first by advancing its next run,
+// so that a schedule is captured once even if another instance runs it after the lock expired.
+func (s *Service) run(ctx context.Context, schedule *Schedule, now time.Time) {
+	logger := s.log.New("orgID", schedule.OrgID, "dashboardUID", schedule.DashboardUID, "scheduleUID", schedule.UID)
+
+	next, nextErr := nextRun(schedule, now)
+	if nextErr != nil {
+		schedule.Enabled = false
+	} else {
+		schedule.NextRun = next
+	}
+	claimed, err := s.store.claim(ctx, schedule, now)
+	if err != nil {
+		logger.Error("Failed to claim a snapshot schedule", "error", err)
+		return
+	}
+	if !claimed {
+		logger.Debug("Snapshot schedule already claimed")
+		return
+	}
+
+	schedule.LastRun = &now
+	schedule.LastError = ""
+	cmd, err := s.capture(ctx, schedule, now)
+	if cmd != nil {
+		if createErr := s.snapshotService.CreateDashboardSnapshot(ctx, cmd); createErr != nil {
+			err = createErr
+		}
+	}
+	if err != nil {
+		logger.Warn("Failed to take a scheduled snapshot", "error", err)
+		schedule.LastError = err.Error()
+	}
+	// A schedule whose dashboard is gone can't run anymore.
+	if errors.Is(err, dashboards.ErrDashboardNotFound) {
+		schedule.Enabled = false
+	}
+
+	if nextErr != nil {
+		schedule.LastError = nextErr.Error()
+	}
+	if err := s.store.updateRun(ctx, schedule); err != nil {
+		logger.Error("Failed to record the run of a snapshot schedule", "error", err)
+	}
+}
@@ -0,0 +1,253 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/models"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/actest"
+	acmock "github.com/grafana/grafana/pkg/services/accesscontrol/mock"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/dashboardsnapshots"
+	dashsnapdb "github.com/grafana/grafana/pkg/services/dashboardsnapshots/database"
+	dashsnapsvc "github.com/grafana/grafana/pkg/services/dashboardsnapshots/service"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/services/libraryelements"
+	"github.com/grafana/grafana/pkg/services/secrets/fakes"
+	"github.com/grafana/grafana/pkg/services/user"
+	"github.com/grafana/grafana/pkg/services/user/usertest"
+)
+
+type fakeQueryService struct {
+	requests []dtos.MetricRequest
+}
+
+func (f *fakeQueryService) QueryData(_ context.Context, _ *user.SignedInUser, _ bool, reqDTO dtos.MetricRequest) (*backend.QueryDataResponse, error) {
+	f.requests = append(f.requests, reqDTO)
+	resp := backend.NewQueryDataResponse()
+	for _, query := range reqDTO.Queries {
+		refID := query.Get("refId").MustString()
+		if refID == "B" {
+			resp.Responses[refID] = backend.DataResponse{Error: errors.New("bad query")}
+			continue
+		}
+		resp.Responses[refID] = backend.DataResponse{Frames: data.Frames{data.NewFrame("cpu",
+			data.NewField("time", nil, []time.Time{time.UnixMilli(1000)}),
+			data.NewField("value", data.Labels{"host": "a"}, []*float64{nil}),
+		)}}
+	}
+	return resp, nil
+}
+
+// fakeLibraryElementService returns the library panels of every dashboard.
+type fakeLibraryElementService struct {
+	libraryelements.Service
+	elements map[string]libraryelements.LibraryElementDTO
+}
+
+func (f *fakeLibraryElementService) GetElementsForDashboard(_ context.Context, _ int64) (map[string]libraryelements.LibraryElementDTO, error) {
+	return f.elements, nil
+}
+
+func setupTestService(t *testing.T, now time.Time) (*Service, *fakeQueryService, dashboardsnapshots.Service) {
+	t.Helper()
+
+	sqlStore := db.InitTestDB(t)
+	snapshotService := dashsnapsvc.ProvideService(dashsnapdb.ProvideStore(sqlStore), fakes.NewFakeSecretsService())
+
+	dashboardService := dashboards.NewFakeDashboardService(t)
+	dashboardService.On("GetDashboard", mock.Anything, mock.AnythingOfType("*models.GetDashboardQuery")).Run(func(args mock.Arguments) {
+		q := args.Get(1).(*models.GetDashboardQuery)
+		q.Result = &models.Dashboard{Id: 1, Uid: q.Uid, Slug: "test", OrgId: q.OrgId, Data: simplejson.NewFromAny(map[string]interface{}{
+			"title": "test",
+			"panels": []interface{}{
+				map[string]interface{}{
+					"id":         1,
+					"datasource": map[string]interface{}{"uid": "ds"},
+					"targets": []interface{}{
+						map[string]interface{}{"refId": "A", "expr": `up{host="$host", job=~"${job:regex}"} [[host]]`},
+						map[string]interface{}{"refId": "C", "hide": true},
+					},
+				},
+				map[string]interface{}{
+					"id":   2,
+					"type": "row",
+					"panels": []interface{}{
+						map[string]interface{}{"id": 3, "targets": []interface{}{map[string]interface{}{"refId": "B"}}},
+					},
+				},
+				map[string]interface{}{
+					"id":           4,
+					"gridPos":      map[string]interface{}{"x": 0, "y": 10},
+					"libraryPanel": map[string]interface{}{"uid": "lib", "name": "library panel"},
+				},
+			},
+			"templating": map[string]interface{}{"list": []interface{}{
+				map[string]interface{}{"name": "host", "query": "label_values(host)", "current": map[string]interface{}{"value": "a"}},
+				map[string]interface{}{"name": "job", "query": "label_values(job)", "current": map[string]interface{}{"value": []interface{}{"$__all"}},
+					"options": []interface{}{
+						map[string]interface{}{"value": "$__all"}, map[string]interface{}{"value": "api"}, map[string]interface{}{"value": "web"},
+					}},
+			}},
+		})}
+	}).Return(nil).Maybe()
+	origNewGuardian := guardian.New
+	t.Cleanup(func() { guardian.New = origNewGuardian })
+	guardian.MockDashboardGuardian(&guardian.FakeDashboardGuardian{CanViewValue: true})
+
+	queryService := &fakeQueryService{}
+	s := &Service{
+		store:            &store{db: sqlStore},
+		dashboardService: dashboardService,
+		libraryElementService: &fakeLibraryElementService{elements: map[string]libraryelements.LibraryElementDTO{
+			"lib": {UID: "lib", Model: []byte(`{"id": 10, "type": "graph", "libraryPanel": {"uid": "lib"}, "targets": [{"refId": "D", "expr": "$host"}]}`)},
+		}},
+		snapshotService:      snapshotService,
+		queryService:         queryService,
+		userService:          &usertest.FakeUserService{ExpectedSignedInUser: &user.SignedInUser{UserID: 1, OrgID: 1}},
+		accessControl:        acmock.New(),
+		accessControlService: actest.FakeService{},
+		log:                  log.NewNopLogger(),
+		now:                  func() time.Time { return now },
+	}
+	return s, queryService, snapshotService
+}
+
+func TestIntegrationSnapshotSchedules(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+	now := time.Date(2022, 11, 1, 10, 0, 0, 0, time.UTC)
+	s, queryService, snapshotService := setupTestService(t, now)
+	ctx := context.Background()
+	owner := &user.SignedInUser{UserID: 1, OrgID: 1}
+
+	t.Run("invalid schedules are rejected", func(t *testing.T) {
+		for _, cmd := range []SaveScheduleCommand{
+			{Cron: "every night"},
+			{Cron: "59 23 * * *", Timezone: "Mars/Olympus"},
+			{Cron: "59 23 * * *", From: "yesterday"},
+			{Cron: "59 23 * * *", From: "now", To: "now-1h"},
+			{Cron: "59 23 * * *", Expires: -1},
+		} {
+			_, err := s.Create(ctx, owner, "dash", cmd)
+			require.ErrorIs(t, err, ErrInvalidSchedule)
+		}
+	})
+
+	schedule, err := s.Create(ctx, owner, "dash", SaveScheduleCommand{Cron: "59 23 * * *", Timezone: "Europe/Paris", Expires: 3600, Enabled: true})
+	require.NoError(t, err)
+	require.Equal(t, defaultName, schedule.Name)
+	require.Equal(t, defaultFrom, schedule.From)
+	require.Equal(t, time.Date(2022, 11, 1, 22, 59, 0, 0, time.UTC), schedule.NextRun)
+
+	t.Run("schedules are listed per dashboard", func(t *testing.T) {
+		schedules, err := s.List(ctx, 1, "dash")
+		require.NoError(t, err)
+		require.Len(t, schedules, 1)
+		require.Equal(t, schedule.UID, schedules[0].UID)
+
+		schedules, err = s.List(ctx, 1, "other")
+		require.NoError(t, err)
+		require.Empty(t, schedules)
+
+		_, err = s.Get(ctx, 2, "dash", schedule.UID)
+		require.ErrorIs(t, err, ErrScheduleNotFound)
+	})
+
+	t.Run("due schedules take a snapshot", func(t *testing.T) {
+		s.now = func() time.Time { return now.Add(13 * time.Hour) }
+		s.runDue(ctx)
+
+		require.Len(t, queryService.requests, 3)
+		require.Equal(t, "1667343600000", queryService.requests[0].To)
+		require.Len(t, queryService.requests[0].Queries, 1)
+		require.Equal(t, "ds", queryService.requests[0].Queries[0].GetPath("datasource", "uid").MustString())
+		require.Equal(t, `up{host="a", job=~"(api|web)"} a`, queryService.requests[0].Queries[0].Get("expr").MustString())
+		require.Equal(t, "a", queryService.requests[2].Queries[0].Get("expr").MustString(), "library panels are resolved")
+
+		got, err := s.Get(ctx, 1, "dash", schedule.UID)
+		require.NoError(t, err)
+		require.Equal(t, time.Date(2022, 11, 2, 22, 59, 0, 0, time.UTC), got.NextRun.UTC())
+		require.NotNil(t, got.LastRun)
+		require.Contains(t, got.LastError, "panel 3: query B: bad query")
+
+		snapshots, err := s.ListSnapshots(ctx, 1, "dash", 10)
+		require.NoError(t, err)
+		require.Len(t, snapshots, 1)
+		require.Equal(t, schedule.UID, snapshots[0].ScheduleUID)
+		require.Contains(t, snapshots[0].URL, "dashboard/snapshot/"+snapshots[0].Key)
+
+		query := &dashboardsnapshots.GetDashboardSnapshotQuery{Key: snapshots[0].Key}
+		require.NoError(t, snapshotService.GetDashboardSnapshot(ctx, query))
+		dashboard := query.Result.Dashboard
+		require.Equal(t, "2022-10-31T23:00:00Z", dashboard.GetPath("time", "from").MustString())
+		panel := dashboard.Get("panels").GetIndex(0)
+		require.Empty(t, panel.Get("targets").MustArray())
+		frame := panel.Get("snapshotData").GetIndex(0)
+		require.Equal(t, "A", frame.Get("refId").MustString())
+		require.Equal(t, "time", frame.Get("fields").GetIndex(0).Get("type").MustString())
+		require.Equal(t, int64(1000), frame.Get("fields").GetIndex(0).Get("values").GetIndex(0).MustInt64())
+		require.Equal(t, "number", frame.Get("fields").GetIndex(1).Get("type").MustString())
+		require.Equal(t, "a", frame.Get("fields").GetIndex(1).GetPath("labels", "host").MustString())
+		variable := dashboard.GetPath("templating", "list").GetIndex(0)
+		require.Equal(t, "", variable.Get("query").MustString())
+		require.Len(t, variable.Get("options").MustArray(), 1)
+		libraryPanel := dashboard.Get("panels").GetIndex(2)
+		require.Equal(t, int64(4), libraryPanel.Get("id").MustInt64())
+		require.Equal(t, "graph", libraryPanel.Get("type").MustString())
+		require.Equal(t, "D", libraryPanel.Get("snapshotData").GetIndex(0).Get("refId").MustString())
+
+		s.runDue(ctx)
+		require.Len(t, queryService.requests, 3, "a schedule must not run before its next run")
+
+		// an instance that listed the schedule before it was claimed doesn't run it again
+		stale := *schedule
+		s.run(ctx, &stale, now.Add(13*time.Hour))
+		require.Len(t, queryService.requests, 3, "a schedule must run once")
+	})
+
+	t.Run("expired snapshots aren't listed", func(t *testing.T) {
+		s.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+		snapshots, err := s.ListSnapshots(ctx, 1, "dash", 10)
+		require.NoError(t, err)
+		require.Empty(t, snapshots)
+	})
+
+	t.Run("disabled schedules don't run", func(t *testing.T) {
+		s.now = func() time.Time { return now }
+		_, err := s.Update(ctx, 1, "dash", schedule.UID, SaveScheduleCommand{Name: "nightly", Cron: "* * * * *"})
+		require.NoError(t, err)
+		s.now = func() time.Time { return now.Add(time.Hour) }
+		s.runDue(ctx)
+		require.Len(t, queryService.requests, 3)
+	})
+
+	t.Run("deleted schedules are not found", func(t *testing.T) {
+		require.NoError(t, s.Delete(ctx, 1, "dash", schedule.UID))
+		require.ErrorIs(t, s.Delete(ctx, 1, "dash", schedule.UID), ErrScheduleNotFound)
+	})
+
+	t.Run("snapshots of deleted schedules are listed", func(t *testing.T) {
+		s.now = time.Now
+		snapshots, err := s.ListSnapshots(ctx, 1, "dash", 10)
+		require.NoError(t, err)
+		require.Len(t, snapshots, 1)
+		require.Empty(t, snapshots[0].ScheduleUID)
+
+		snapshots, err = s.ListSnapshots(ctx, 1, "other", 10)
+		require.NoError(t, err)
+		require.Empty(t, snapshots)
+	})
+}
@@ -0,0 +1,308 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/models"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/dashboards/dashboardjson"
+	"github.com/grafana/grafana/pkg/services/dashboardsnapshots"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/services/libraryelements"
+	"github.com/grafana/grafana/pkg/services/user"
+	"github.com/grafana/grafana/pkg/tsdb/legacydata"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+// defaultMaxDataPoints is the resolution of the queries of the panels without max data points.
+const defaultMaxDataPoints = 1000
+
+// capture takes a snapshot of the dashboard of a schedule at a time. The snapshot is created even if some queries
+// fail, the errors of the queries are returned with it.
+func (s *Service) capture(ctx context.Context, schedule *Schedule, now time.Time) (*dashboardsnapshots.CreateDashboardSnapshotCommand, error) {
+	owner, err := s.owner(ctx, schedule)
+	if err != nil {
+		return nil, err
+	}
+
+	query := models.GetDashboardQuery{Uid: schedule.DashboardUID, OrgId: schedule.OrgID}
+	if err := s.dashboardService.GetDashboard(ctx, &query); err != nil {
+		return nil, err
+	}
+	canView, err := guardian.New(ctx, query.Result.Id, schedule.OrgID, owner).CanView()
+	if err != nil {
+		return nil, err
+	}
+	if !canView {
+		return nil, ErrDashboardAccess.Errorf("the owner of the schedule can't view the dashboard")
+	}
+
+	from, to, err := timeRange(schedule, now)
+	if err != nil {
+		return nil, err
+	}
+
+	dashboard := simplejson.NewFromAny(query.Result.Data.Interface())
+	if err := libraryelements.ResolveLibraryPanels(ctx, s.libraryElementService, query.Result.Id, dashboard); err != nil {
+		return nil, err
+	}
+	variables := dashboardjson.SavedValues(dashboard)
+	var queryErrs []string
+	for _, panel := range dashboardjson.Panels(dashboard) {
+		frames, err := s.queryPanel(ctx, owner, panel, variables, from, to)
+		if err != nil {
+			queryErrs = append(queryErrs, fmt.Sprintf("panel %d: %s", panel.Get("id").MustInt64(), err))
+		}
+		scrubPanel(panel, frames)
+	}
+	scrubDashboard(dashboard, from, to, now, models.GetFullDashboardUrl(query.Result.Uid, query.Result.Slug))
+
+	cmd := &dashboardsnapshots.CreateDashboardSnapshotCommand{
+		Dashboard:    dashboard,
+		Name:         fmt.Sprintf("%s %s", schedule.Name, now.In(from.Location()).Format("2006-01-02 15:04")),
+		Expires:      schedule.Expires,
+		OrgId:        schedule.OrgID,
+		UserId:       schedule.UserID,
+		ScheduleId:   schedule.ID,
+		DashboardUid: schedule.DashboardUID,
+	}
+	if cmd.Key, err = util.GetRandomString(32); err != nil {
+		return nil, err
+	}
+	if cmd.DeleteKey, err = util.GetRandomString(32); err != nil {
+		return nil, err
+	}
+	if len(queryErrs) > 0 {
+		return cmd, errors.New(strings.Join(queryErrs, "; "))
+	}
+	return cmd, nil
+}
+
+// owner returns the user who created a schedule with their permissions in the organization of the schedule.
+func (s *Service) owner(ctx context.Context, schedule *Schedule) (*user.SignedInUser, error) {
+	owner, err := s.userService.GetSignedInUserWithCacheCtx(ctx, &user.GetSignedInUserQuery{UserID: schedule.UserID, OrgID: schedule.OrgID})
+	if err != nil {
+		return nil, err
+	}
+	if owner.OrgID != schedule.OrgID {
+		return nil, ErrDashboardAccess.Errorf("the owner of the schedule isn't a member of the organization")
+	}
+	if !s.accessControl.IsDisabled() {
+		permissions, err := s.accessControlService.GetUserPermissions(ctx, owner, accesscontrol.Options{})
+		if err != nil {
+			return nil, err
+		}
+		owner.Permissions = map[int64]map[string][]string{schedule.OrgID: accesscontrol.GroupScopesByAction(permissions)}
+	}
+	return owner, nil
+}
+
+// timeRange returns the time range of a snapshot taken at a time.
+func timeRange(schedule *Schedule, now time.Time) (time.Time, time.Time, error) {
+	loc, err := time.LoadLocation(schedule.Timezone)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	tr := legacydata.DataTimeRange{From: schedule.From, To: schedule.To, Now: now}
+	from, err := tr.ParseFrom(legacydata.WithLocation(loc))
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	to, err := tr.ParseTo(legacydata.WithLocation(loc))
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if !from.Before(to) {
+		return time.Time{}, time.Time{}, fmt.Errorf("the start of the time range must be before its end")
+	}
+	return from.In(loc), to.In(loc), nil
+}
+
+// queryPanel runs the visible queries of a panel with the saved values of the template variables and returns their
+// frames in the order of the queries.
+func (s *Service) queryPanel(ctx context.Context, owner *user.SignedInUser, panel *simplejson.Json, variables map[string]dashboardjson.VariableValue, from time.Time, to time.Time) ([]*data.Frame, error) {
+	maxDataPoints := panel.Get("maxDataPoints").MustInt64(defaultMaxDataPoints)
+	if maxDataPoints <= 0 {
+		maxDataPoints = defaultMaxDataPoints
+	}
+	intervalMs := to.Sub(from).Milliseconds() / maxDataPoints
+	if intervalMs < 1 {
+		intervalMs = 1
+	}
+
+	queries := make([]*simplejson.Json, 0)
+	for i := range panel.Get("targets").MustArray() {
+		query := panel.Get("targets").GetIndex(i)
+		if query.Get("hide").MustBool() {
+			continue
+		}
+		if _, ok := query.CheckGet("datasource"); !ok {
+			query.Set("datasource", panel.Get("datasource").Interface())
+		}
+		query = dashboardjson.InterpolateVariables(query, variables)
+		query.Set("maxDataPoints", maxDataPoints)
+		query.Set("intervalMs", intervalMs)
+		queries = append(queries, query)
+	}
+	if len(queries) == 0 {
+		return nil, nil
+	}
+
+	resp, err := s.queryService.QueryData(ctx, owner, true, dtos.MetricRequest{
+		From:    strconv.FormatInt(from.UnixMilli(), 10),
+		To:      strconv.FormatInt(to.UnixMilli(), 10),
+		Queries: queries,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return responseFrames(resp, queries)
+}
+
+// responseFrames returns the frames of a response in the order of the queries, with the error of the first query
+// that failed.
+func responseFrames(resp *backend.QueryDataResponse, queries []*simplejson.Json) ([]*data.Frame, error) {
+	var err error
+	frames := make([]*data.Frame, 0)
+	for _, query := range queries {
+		refID := query.Get("refId").MustString("A")
+		res, ok := resp.Responses[refID]
+		if !ok {
+			continue
+		}
+		if res.Error != nil && err == nil {
+			err = fmt.Errorf("query %s: %w", refID, res.Error)
+		}
+		for _, frame := range res.Frames {
+			frame.RefID = refID
+			frames = append(frames, frame)
+		}
+	}
+	return frames, err
+}
+
+// scrubPanel replaces the queries of a panel with their data, like the snapshots shared from the UI.
+func scrubPanel(panel *simplejson.Json, frames []*data.Frame) {
+	if _, ok := panel.CheckGet("targets"); !ok {
+		return
+	}
+	snapshotData := make([]interface{}, 0, len(frames))
+	for _, frame := range frames {
+		snapshotData = append(snapshotData, frameToSnapshotData(frame))
+	}
+	panel.Set("snapshotData", snapshotData)
+	panel.Set("targets", []interface{}{})
+	panel.Set("links", []interface{}{})
+	panel.Set("datasource", nil)
+}
+
+// scrubDashboard sets the time range of a snapshot and removes the queries of the annotations and variables of its
+// dashboard, like the snapshots shared from the UI.
+func scrubDashboard(dashboard *simplejson.Json, from time.Time, to time.Time, now time.Time, originalURL string) {
+	dashboard.Set("time", map[string]interface{}{
+		"from": from.UTC().Format(time.RFC3339Nano),
+		"to":   to.UTC().Format(time.RFC3339Nano),
+	})
+	dashboard.Set("refresh", "")
+	dashboard.Set("snapshot", map[string]interface{}{
+		"timestamp":   now.UTC().Format(time.RFC3339Nano),
+		"originalUrl": originalURL,
+	})
+	dashboard.SetPath([]string{"annotations", "list"}, []interface{}{})
+
+	for i := range dashboard.GetPath("templating", "list").MustArray() {
+		variable := dashboard.GetPath("templating", "list").GetIndex(i)
+		variable.Set("query", "")
+		variable.Set("refresh", 0)
+		if current, ok := variable.CheckGet("current"); ok {
+			variable.Set("options", []interface{}{current.Interface()})
+		} else {
+			variable.Set("options", []interface{}{})
+		}
+	}
+}
+
+// frameToSnapshotData converts a frame to the format of the data of the panels of snapshots.
+func frameToSnapshotData(frame *data.Frame) map[string]interface{} {
+	fields := make([]interface{}, 0, len(frame.Fields))
+	for _, field := range frame.Fields {
+		values := make([]interface{}, 0, field.Len())
+		for i := 0; i < field.Len(); i++ {
+			value, ok := field.ConcreteAt(i)
+			if !ok {
+				values = append(values, nil)
+				continue
+			}
+			if t, isTime := value.(time.Time); isTime {
+				value = t.UnixMilli()
+			}
+			values = append(values, value)
+		}
+		f := map[string]interface{}{
+			"name":   field.Name,
+			"type":   snapshotFieldType(field.Type()),
+			"values": values,
+		}
+		if field.Config != nil {
+			f["config"] = field.Config
+		} else {
+			f["config"] = map[string]interface{}{}
+		}
+		if len(field.Labels) > 0 {
+			f["labels"] = sortedLabels(field.Labels)
+		}
+		fields = append(fields, f)
+	}
+
+	result := map[string]interface{}{
+		"refId":  frame.RefID,
+		"fields": fields,
+	}
+	if frame.Name != "" {
+		result["name"] = frame.Name
+	}
+	if frame.Meta != nil {
+		result["meta"] = frame.Meta
+	}
+	return result
+}
+
+// snapshotFieldType returns the type of a field as the frontend names it.
+func snapshotFieldType(t data.FieldType) string {
+	switch {
+	case t.Time():
+		return "time"
+	case t.Numeric():
+		return "number"
+	case t == data.FieldTypeString || t == data.FieldTypeNullableString:
+		return "string"
+	case t == data.FieldTypeBool || t == data.FieldTypeNullableBool:
+		return "boolean"
+	default:
+		return "other"
+	}
+}
+
+func sortedLabels(labels data.Labels) map[string]string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	result := make(map[string]string, len(labels))
+	for _, k := range keys {
+		result[k] = labels[k]
+	}
+	return result
+}
@@ -23,6 +23,8 @@ const (
 	DS_MYSQL          = "mysql"
 	DS_POSTGRES       = "postgres"
 	DS_MSSQL          = "mssql"
+	DS_JSONAPI        = "jsonapi"
+	DS_FILEDATA       = "filedata"
 	DS_ACCESS_DIRECT  = "direct"
 	DS_ACCESS_PROXY   = "proxy"
 	DS_ES_OPEN_DISTRO = "grafana-es-open-distro-datasource"
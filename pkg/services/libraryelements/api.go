@@ -3,9 +3,11 @@ package libraryelements
 import (
 	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/grafana/grafana/pkg/api/response"
 	"github.com/grafana/grafana/pkg/api/routing"
+	"github.com/grafana/grafana/pkg/components/dashdiffs"
 	"github.com/grafana/grafana/pkg/middleware"
 	"github.com/grafana/grafana/pkg/models"
 	"github.com/grafana/grafana/pkg/services/dashboards"
@@ -22,6 +24,10 @@ func (l *LibraryElementService) registerAPIEndpoints() {
 		entities.Get("/:uid/connections/", middleware.ReqSignedIn, routing.Wrap(l.getConnectionsHandler))
 		entities.Get("/name/:name", middleware.ReqSignedIn, routing.Wrap(l.getByNameHandler))
 		entities.Patch("/:uid", middleware.ReqSignedIn, routing.Wrap(l.patchHandler))
+		entities.Get("/:uid/versions", middleware.ReqSignedIn, routing.Wrap(l.getVersionsHandler))
+		entities.Get("/:uid/versions/:version", middleware.ReqSignedIn, routing.Wrap(l.getVersionHandler))
+		entities.Get("/:uid/versions/:version/diff", middleware.ReqSignedIn, routing.Wrap(l.diffVersionHandler))
+		entities.Post("/:uid/versions/:version/restore", middleware.ReqSignedIn, routing.Wrap(l.restoreVersionHandler))
 	})
 }
 
@@ -242,6 +248,127 @@ func (l *LibraryElementService) getByNameHandler(c *models.ReqContext) response.
 	return response.JSON(http.StatusOK, LibraryElementArrayResponse{Result: elements})
 }
 
+// swagger:route GET /library-elements/{library_element_uid}/versions library_elements getLibraryElementVersions
+//
+// Get library element versions.
+//
+// Returns the versions of a library element, the most recent first. The model of the versions is not returned.
+// Use the `limit` query parameter to control the maximum number of versions returned; the default limit is `100`.
+//
+// Responses:
+// 200: getLibraryElementVersionsResponse
+// 401: unauthorisedError
+// 404: notFoundError
+// 500: internalServerError
+func (l *LibraryElementService) getVersionsHandler(c *models.ReqContext) response.Response {
+	query := listLibraryElementVersionsQuery{
+		limit: c.QueryInt("limit"),
+		start: c.QueryInt("start"),
+	}
+	versions, err := l.getLibraryElementVersions(c.Req.Context(), c.SignedInUser, web.Params(c.Req)[":uid"], query)
+	if err != nil {
+		return toLibraryElementError(err, "Failed to get library element versions")
+	}
+
+	return response.JSON(http.StatusOK, LibraryElementVersionsResponse{Result: versions})
+}
+
+// swagger:route GET /library-elements/{library_element_uid}/versions/{library_element_version} library_elements getLibraryElementVersion
+//
+// Get library element version.
+//
+// Returns a version of a library element with its model.
+//
+// Responses:
+// 200: getLibraryElementVersionResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 404: notFoundError
+// 500: internalServerError
+func (l *LibraryElementService) getVersionHandler(c *models.ReqContext) response.Response {
+	version, err := strconv.ParseInt(web.Params(c.Req)[":version"], 10, 64)
+	if err != nil {
+		return response.Error(http.StatusBadRequest, "version is invalid", err)
+	}
+
+	elementVersion, err := l.getLibraryElementVersion(c.Req.Context(), c.SignedInUser, web.Params(c.Req)[":uid"], version)
+	if err != nil {
+		return toLibraryElementError(err, "Failed to get library element version")
+	}
+
+	return response.JSON(http.StatusOK, LibraryElementVersionResponse{Result: elementVersion})
+}
+
+// swagger:route GET /library-elements/{library_element_uid}/versions/{library_element_version}/diff library_elements getLibraryElementVersionDiff
+//
+// Compare library element versions.
+//
+// Compares the model of a version of a library element with the current version, or with the version given in the
+// `compareTo` query parameter.
+//
+// Responses:
+// 200: calculateDashboardDiffResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 404: notFoundError
+// 500: internalServerError
+func (l *LibraryElementService) diffVersionHandler(c *models.ReqContext) response.Response {
+	version, err := strconv.ParseInt(web.Params(c.Req)[":version"], 10, 64)
+	if err != nil {
+		return response.Error(http.StatusBadRequest, "version is invalid", err)
+	}
+	diffType := dashdiffs.ParseDiffType(c.Query("diffType"))
+
+	result, err := l.compareLibraryElementVersions(c.Req.Context(), c.SignedInUser, web.Params(c.Req)[":uid"], version, c.QueryInt64("compareTo"), diffType)
+	if err != nil {
+		return toLibraryElementError(err, "Failed to compare library element versions")
+	}
+
+	if diffType == dashdiffs.DiffDelta {
+		return response.Respond(http.StatusOK, result.Delta).SetHeader("Content-Type", "application/json")
+	}
+
+	return response.Respond(http.StatusOK, result.Delta).SetHeader("Content-Type", "text/html")
+}
+
+// swagger:route POST /library-elements/{library_element_uid}/versions/{library_element_version}/restore library_elements restoreLibraryElementVersion
+//
+// Restore library element version.
+//
+// Restores the name and the model of a version of a library element. The restored version is saved as a new version,
+// and the dashboards connected to the library element use it.
+//
+// Responses:
+// 200: getLibraryElementResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (l *LibraryElementService) restoreVersionHandler(c *models.ReqContext) response.Response {
+	version, err := strconv.ParseInt(web.Params(c.Req)[":version"], 10, 64)
+	if err != nil {
+		return response.Error(http.StatusBadRequest, "version is invalid", err)
+	}
+
+	element, err := l.restoreLibraryElementVersion(c.Req.Context(), c.SignedInUser, web.Params(c.Req)[":uid"], version)
+	if err != nil {
+		return toLibraryElementError(err, "Failed to restore library element version")
+	}
+
+	if element.FolderID != 0 {
+		folder, err := l.folderService.Get(c.Req.Context(), &folder.GetFolderQuery{OrgID: c.OrgID, ID: &element.FolderID, SignedInUser: c.SignedInUser})
+		if err != nil {
+			return response.Error(http.StatusInternalServerError, "failed to get folder", err)
+		}
+		element.FolderUID = folder.UID
+		element.Meta.FolderUID = folder.UID
+		element.Meta.FolderName = folder.Title
+	}
+
+	return response.JSON(http.StatusOK, LibraryElementResponse{Result: element})
+}
+
 func toLibraryElementError(err error, message string) response.Response {
 	if errors.Is(err, errLibraryElementAlreadyExists) {
 		return response.Error(400, errLibraryElementAlreadyExists.Error(), err)
@@ -270,6 +397,12 @@ func toLibraryElementError(err error, message string) response.Response {
 	if errors.Is(err, errLibraryElementUIDTooLong) {
 		return response.Error(400, errLibraryElementUIDTooLong.Error(), err)
 	}
+	if errors.Is(err, errLibraryElementVersionNotFound) {
+		return response.Error(404, errLibraryElementVersionNotFound.Error(), err)
+	}
+	if errors.Is(err, errLibraryElementVersionsIdentical) {
+		return response.Error(400, errLibraryElementVersionsIdentical.Error(), err)
+	}
 	return response.Error(500, message, err)
 }
 
@@ -388,3 +521,62 @@ type GetLibraryElementConnectionsResponse struct {
 	// in: body
 	Body LibraryElementConnectionsResponse `json:"body"`
 }
+
+// swagger:parameters getLibraryElementVersions
+type GetLibraryElementVersionsParams struct {
+	// in:path
+	// required:true
+	UID string `json:"library_element_uid"`
+	// Maximum number of results to return
+	// in:query
+	// required:false
+	// default:100
+	Limit int `json:"limit"`
+	// Version to start from when returning queries
+	// in:query
+	// required:false
+	// default:0
+	Start int `json:"start"`
+}
+
+// swagger:parameters getLibraryElementVersion restoreLibraryElementVersion
+type LibraryElementVersionParams struct {
+	// in:path
+	// required:true
+	UID string `json:"library_element_uid"`
+	// in:path
+	// required:true
+	Version int64 `json:"library_element_version"`
+}
+
+// swagger:parameters getLibraryElementVersionDiff
+type GetLibraryElementVersionDiffParams struct {
+	// in:path
+	// required:true
+	UID string `json:"library_element_uid"`
+	// in:path
+	// required:true
+	Version int64 `json:"library_element_version"`
+	// Version to compare with. Defaults to the current version.
+	// in:query
+	// required:false
+	CompareTo int64 `json:"compareTo"`
+	// Format of the diff.
+	// in:query
+	// required:false
+	// default:basic
+	// Enum: basic,json,delta
+	DiffType string `json:"diffType"`
+}
+
+// swagger:response getLibraryElementVersionsResponse
+type GetLibraryElementVersionsResponse struct {
+	// in: body
+	Body LibraryElementVersionsResponse `json:"body"`
+}
+
+// swagger:response getLibraryElementVersionResponse
+type GetLibraryElementVersionResponse struct {
+	// in: body
+	Body LibraryElementVersionResponse `json:"body"`
+}
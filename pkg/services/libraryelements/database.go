@@ -9,6 +9,8 @@ import (
 	"time"
 
 	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/components/dashdiffs"
+	"github.com/grafana/grafana/pkg/components/simplejson"
 	"github.com/grafana/grafana/pkg/infra/db"
 	"github.com/grafana/grafana/pkg/models"
 	"github.com/grafana/grafana/pkg/services/dashboards"
@@ -148,7 +150,7 @@ func (l *LibraryElementService) createLibraryElement(c context.Context, signedIn
 			}
 			return err
 		}
-		return nil
+		return insertLibraryElementVersion(session, element, 0)
 	})
 
 	dto := LibraryElementDTO{
@@ -209,6 +211,9 @@ func (l *LibraryElementService) deleteLibraryElement(c context.Context, signedIn
 			return errLibraryElementHasConnections
 		}
 
+		if _, err := session.Exec("DELETE FROM library_element_version WHERE element_id=?", element.ID); err != nil {
+			return err
+		}
 		result, err := session.Exec("DELETE FROM library_element WHERE id=?", element.ID)
 		if err != nil {
 			return err
@@ -525,40 +530,48 @@ func (l *LibraryElementService) patchLibraryElement(c context.Context, signedInU
 		} else if rowsAffected != 1 {
 			return ErrLibraryElementNotFound
 		}
-
-		dto = LibraryElementDTO{
-			ID:          libraryElement.ID,
-			OrgID:       libraryElement.OrgID,
-			FolderID:    libraryElement.FolderID,
-			UID:         libraryElement.UID,
-			Name:        libraryElement.Name,
-			Kind:        libraryElement.Kind,
-			Type:        libraryElement.Type,
-			Description: libraryElement.Description,
-			Model:       libraryElement.Model,
-			Version:     libraryElement.Version,
-			Meta: LibraryElementDTOMeta{
-				ConnectedDashboards: elementInDB.ConnectedDashboards,
-				Created:             libraryElement.Created,
-				Updated:             libraryElement.Updated,
-				CreatedBy: LibraryElementDTOMetaUser{
-					ID:        elementInDB.CreatedBy,
-					Name:      elementInDB.CreatedByName,
-					AvatarURL: dtos.GetGravatarUrl(elementInDB.CreatedByEmail),
-				},
-				UpdatedBy: LibraryElementDTOMetaUser{
-					ID:        libraryElement.UpdatedBy,
-					Name:      signedInUser.Login,
-					AvatarURL: dtos.GetGravatarUrl(signedInUser.Email),
-				},
-			},
+		if err := insertLibraryElementVersion(session, libraryElement, 0); err != nil {
+			return err
 		}
+
+		dto = updatedLibraryElementDTO(signedInUser, elementInDB, libraryElement)
 		return nil
 	})
 
 	return dto, err
 }
 
+// updatedLibraryElementDTO returns the DTO of a Library Element that has been updated by the user.
+func updatedLibraryElementDTO(signedInUser *user.SignedInUser, elementInDB LibraryElementWithMeta, libraryElement LibraryElement) LibraryElementDTO {
+	return LibraryElementDTO{
+		ID:          libraryElement.ID,
+		OrgID:       libraryElement.OrgID,
+		FolderID:    libraryElement.FolderID,
+		UID:         libraryElement.UID,
+		Name:        libraryElement.Name,
+		Kind:        libraryElement.Kind,
+		Type:        libraryElement.Type,
+		Description: libraryElement.Description,
+		Model:       libraryElement.Model,
+		Version:     libraryElement.Version,
+		Meta: LibraryElementDTOMeta{
+			ConnectedDashboards: elementInDB.ConnectedDashboards,
+			Created:             libraryElement.Created,
+			Updated:             libraryElement.Updated,
+			CreatedBy: LibraryElementDTOMetaUser{
+				ID:        elementInDB.CreatedBy,
+				Name:      elementInDB.CreatedByName,
+				AvatarURL: dtos.GetGravatarUrl(elementInDB.CreatedByEmail),
+			},
+			UpdatedBy: LibraryElementDTOMetaUser{
+				ID:        libraryElement.UpdatedBy,
+				Name:      signedInUser.Login,
+				AvatarURL: dtos.GetGravatarUrl(signedInUser.Email),
+			},
+		},
+	}
+}
+
 // getConnections gets all connections for a Library Element.
 func (l *LibraryElementService) getConnections(c context.Context, signedInUser *user.SignedInUser, uid string) ([]LibraryElementConnectionDTO, error) {
 	connections := make([]LibraryElementConnectionDTO, 0)
@@ -755,6 +768,9 @@ func (l *LibraryElementService) deleteLibraryElementsInFolderUID(c context.Conte
 			if err != nil {
 				return err
 			}
+			if _, err := session.Exec("DELETE FROM library_element_version WHERE element_id=?", elementID.ID); err != nil {
+				return err
+			}
 		}
 		if _, err := session.Exec("DELETE FROM library_element WHERE folder_id=? AND org_id=?", folderID, signedInUser.OrgID); err != nil {
 			return err
@@ -763,3 +779,259 @@ func (l *LibraryElementService) deleteLibraryElementsInFolderUID(c context.Conte
 		return nil
 	})
 }
+
+const (
+	maxVersionsToDeletePerBatch = 100
+	maxVersionDeletionBatches   = 50
+)
+
+func getLibraryElementVersionsSQL(dialect migrator.Dialect, withModel bool) string {
+	sql := "SELECT lev.id, lev.element_id, lev.version, lev.restored_from, lev.name, lev.created, lev.created_by"
+	if withModel {
+		sql += ", lev.model"
+	}
+	return sql +
+		", u.login AS created_by_name" +
+		", u.email AS created_by_email" +
+		" FROM library_element_version AS lev" +
+		" LEFT JOIN " + dialect.Quote("user") + " AS u ON lev.created_by = u.id"
+}
+
+func toLibraryElementVersionDTO(version libraryElementVersionWithMeta) LibraryElementVersionDTO {
+	return LibraryElementVersionDTO{
+		ID:           version.ID,
+		ElementID:    version.ElementID,
+		Version:      version.Version,
+		RestoredFrom: version.RestoredFrom,
+		Name:         version.Name,
+		Model:        version.Model,
+		Created:      version.Created,
+		CreatedBy: LibraryElementDTOMetaUser{
+			ID:        version.CreatedBy,
+			Name:      version.CreatedByName,
+			AvatarURL: dtos.GetGravatarUrl(version.CreatedByEmail),
+		},
+	}
+}
+
+// insertLibraryElementVersion adds the current version of a Library Element to its history.
+func insertLibraryElementVersion(session *db.Session, element LibraryElement, restoredFrom int64) error {
+	version := libraryElementVersion{
+		ElementID:    element.ID,
+		Version:      element.Version,
+		RestoredFrom: restoredFrom,
+		Name:         element.Name,
+		Model:        element.Model,
+		Created:      element.Updated,
+		CreatedBy:    element.UpdatedBy,
+	}
+	_, err := session.Insert(&version)
+	return err
+}
+
+func getLibraryElementVersion(dialect migrator.Dialect, session *db.Session, elementID int64, version int64) (libraryElementVersionWithMeta, error) {
+	versions := make([]libraryElementVersionWithMeta, 0)
+	sql := getLibraryElementVersionsSQL(dialect, true) + " WHERE lev.element_id=? AND lev.version=?"
+	if err := session.SQL(sql, elementID, version).Find(&versions); err != nil {
+		return libraryElementVersionWithMeta{}, err
+	}
+	if len(versions) == 0 {
+		return libraryElementVersionWithMeta{}, errLibraryElementVersionNotFound
+	}
+	return versions[0], nil
+}
+
+// getLibraryElementVersions gets the versions of a Library Element, the most recent first. The model of the
+// versions is not returned.
+func (l *LibraryElementService) getLibraryElementVersions(c context.Context, signedInUser *user.SignedInUser, uid string, query listLibraryElementVersionsQuery) ([]LibraryElementVersionDTO, error) {
+	element, err := l.getLibraryElementByUid(c, signedInUser, uid)
+	if err != nil {
+		return nil, err
+	}
+	if query.limit <= 0 {
+		query.limit = 100
+	}
+	if query.start < 0 {
+		query.start = 0
+	}
+
+	versions := make([]libraryElementVersionWithMeta, 0)
+	err = l.SQLStore.WithDbSession(c, func(session *db.Session) error {
+		dialect := l.SQLStore.GetDialect()
+		sql := getLibraryElementVersionsSQL(dialect, false) +
+			" WHERE lev.element_id=?" +
+			" ORDER BY lev.version DESC" +
+			dialect.LimitOffset(int64(query.limit), int64(query.start))
+		return session.SQL(sql, element.ID).Find(&versions)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]LibraryElementVersionDTO, 0, len(versions))
+	for _, version := range versions {
+		result = append(result, toLibraryElementVersionDTO(version))
+	}
+	return result, nil
+}
+
+// getLibraryElementVersion gets a version of a Library Element.
+func (l *LibraryElementService) getLibraryElementVersion(c context.Context, signedInUser *user.SignedInUser, uid string, version int64) (LibraryElementVersionDTO, error) {
+	element, err := l.getLibraryElementByUid(c, signedInUser, uid)
+	if err != nil {
+		return LibraryElementVersionDTO{}, err
+	}
+
+	var dto LibraryElementVersionDTO
+	err = l.SQLStore.WithDbSession(c, func(session *db.Session) error {
+		elementVersion, err := getLibraryElementVersion(l.SQLStore.GetDialect(), session, element.ID, version)
+		if err != nil {
+			return err
+		}
+		dto = toLibraryElementVersionDTO(elementVersion)
+		return nil
+	})
+	return dto, err
+}
+
+// compareLibraryElementVersions computes the diff of the models of two versions of a Library Element. The base
+// version is compared with the current version when newVersion is 0.
+func (l *LibraryElementService) compareLibraryElementVersions(c context.Context, signedInUser *user.SignedInUser, uid string, baseVersion int64, newVersion int64, diffType dashdiffs.DiffType) (*dashdiffs.Result, error) {
+	element, err := l.getLibraryElementByUid(c, signedInUser, uid)
+	if err != nil {
+		return nil, err
+	}
+	if newVersion == 0 {
+		newVersion = element.Version
+	}
+
+	var baseElementVersion, newElementVersion libraryElementVersionWithMeta
+	err = l.SQLStore.WithDbSession(c, func(session *db.Session) error {
+		if baseElementVersion, err = getLibraryElementVersion(l.SQLStore.GetDialect(), session, element.ID, baseVersion); err != nil {
+			return err
+		}
+		newElementVersion, err = getLibraryElementVersion(l.SQLStore.GetDialect(), session, element.ID, newVersion)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	baseData, err := simplejson.NewJson(baseElementVersion.Model)
+	if err != nil {
+		return nil, err
+	}
+	newData, err := simplejson.NewJson(newElementVersion.Model)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := dashdiffs.CalculateDiff(c, &dashdiffs.Options{OrgId: signedInUser.OrgID, DiffType: diffType}, baseData, newData)
+	if errors.Is(err, dashdiffs.ErrNilDiff) {
+		return nil, errLibraryElementVersionsIdentical
+	}
+	return result, err
+}
+
+// restoreLibraryElementVersion restores the name and the model of a version of a Library Element. The restored
+// version is saved as a new version.
+func (l *LibraryElementService) restoreLibraryElementVersion(c context.Context, signedInUser *user.SignedInUser, uid string, version int64) (LibraryElementDTO, error) {
+	var dto LibraryElementDTO
+	err := l.SQLStore.WithTransactionalDbSession(c, func(session *db.Session) error {
+		elementInDB, err := getLibraryElement(l.SQLStore.GetDialect(), session, uid, signedInUser.OrgID)
+		if err != nil {
+			return err
+		}
+		if err := l.requireEditPermissionsOnFolder(c, signedInUser, elementInDB.FolderID); err != nil {
+			return err
+		}
+		elementVersion, err := getLibraryElementVersion(l.SQLStore.GetDialect(), session, elementInDB.ID, version)
+		if err != nil {
+			return err
+		}
+
+		var libraryElement = LibraryElement{
+			ID:          elementInDB.ID,
+			OrgID:       elementInDB.OrgID,
+			FolderID:    elementInDB.FolderID,
+			UID:         elementInDB.UID,
+			Name:        elementVersion.Name,
+			Kind:        elementInDB.Kind,
+			Type:        elementInDB.Type,
+			Description: elementInDB.Description,
+			Model:       elementVersion.Model,
+			Version:     elementInDB.Version + 1,
+			Created:     elementInDB.Created,
+			CreatedBy:   elementInDB.CreatedBy,
+			Updated:     time.Now(),
+			UpdatedBy:   signedInUser.UserID,
+		}
+		if err := syncFieldsWithModel(&libraryElement); err != nil {
+			return err
+		}
+		if rowsAffected, err := session.ID(elementInDB.ID).Update(&libraryElement); err != nil {
+			if l.SQLStore.GetDialect().IsUniqueConstraintViolation(err) {
+				return errLibraryElementAlreadyExists
+			}
+			return err
+		} else if rowsAffected != 1 {
+			return ErrLibraryElementNotFound
+		}
+		if err := insertLibraryElementVersion(session, libraryElement, version); err != nil {
+			return err
+		}
+
+		dto = updatedLibraryElementDTO(signedInUser, elementInDB, libraryElement)
+		return nil
+	})
+
+	return dto, err
+}
+
+// deleteExpiredLibraryElementVersions deletes the versions of the Library Elements that exceed the number of
+// versions to keep, the oldest first.
+func (l *LibraryElementService) deleteExpiredLibraryElementVersions(c context.Context) (int64, error) {
+	versionsToKeep := l.Cfg.LibraryPanelVersionsToKeep
+	if versionsToKeep < 1 {
+		versionsToKeep = 1
+	}
+
+	var deletedRows int64
+	for batch := 0; batch < maxVersionDeletionBatches; batch++ {
+		var deleted int64
+		err := l.SQLStore.WithTransactionalDbSession(c, func(session *db.Session) error {
+			var versionIDs []interface{}
+			versionIDsToDeleteQuery := `SELECT id
+				FROM library_element_version, (
+					SELECT element_id AS latest_element_id, max(version) AS latest
+					FROM library_element_version
+					GROUP BY element_id
+				) AS latest_versions
+				WHERE library_element_version.element_id=latest_versions.latest_element_id
+				AND version <= latest_versions.latest - ?
+				LIMIT ?`
+			if err := session.SQL(versionIDsToDeleteQuery, versionsToKeep, maxVersionsToDeletePerBatch).Find(&versionIDs); err != nil {
+				return err
+			}
+			if len(versionIDs) == 0 {
+				return nil
+			}
+
+			deleteExpiredSQL := `DELETE FROM library_element_version WHERE id IN (?` + strings.Repeat(",?", len(versionIDs)-1) + `)`
+			result, err := session.Exec(append([]interface{}{deleteExpiredSQL}, versionIDs...)...)
+			if err != nil {
+				return err
+			}
+			deleted, err = result.RowsAffected()
+			return err
+		})
+		if err != nil {
+			return deletedRows, err
+		}
+
+		deletedRows += deleted
+		if deleted < maxVersionsToDeletePerBatch {
+			break
+		}
+	}
+	return deletedRows, nil
+}
@@ -31,6 +31,7 @@ type Service interface {
 	ConnectElementsToDashboard(c context.Context, signedInUser *user.SignedInUser, elementUIDs []string, dashboardID int64) error
 	DisconnectElementsFromDashboard(c context.Context, dashboardID int64) error
 	DeleteLibraryElementsInFolder(c context.Context, signedInUser *user.SignedInUser, folderUID string) error
+	DeleteExpiredVersions(c context.Context) (int64, error)
 }
 
 // LibraryElementService is the service for the Library Element feature.
@@ -71,3 +72,8 @@ func (l *LibraryElementService) DisconnectElementsFromDashboard(c context.Contex
 func (l *LibraryElementService) DeleteLibraryElementsInFolder(c context.Context, signedInUser *user.SignedInUser, folderUID string) error {
 	return l.deleteLibraryElementsInFolderUID(c, signedInUser, folderUID)
 }
+
+// DeleteExpiredVersions deletes the versions of all elements that exceed the number of versions to keep.
+func (l *LibraryElementService) DeleteExpiredVersions(c context.Context) (int64, error) {
+	return l.deleteExpiredLibraryElementVersions(c)
+}
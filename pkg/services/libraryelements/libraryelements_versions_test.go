@@ -0,0 +1,204 @@
+package libraryelements
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/models"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+func TestLibraryElementVersions(t *testing.T) {
+	scenarioWithPanel(t, "When an admin gets the versions of a library panel, it should return them the most recent first",
+		func(t *testing.T, sc scenarioContext) {
+			patchPanelTitle(t, sc, 1, "Second title")
+			patchPanelTitle(t, sc, 2, "Third title")
+
+			sc.ctx.Req = web.SetURLParams(sc.ctx.Req, map[string]string{":uid": sc.initialResult.Result.UID})
+			resp := sc.service.getVersionsHandler(sc.reqContext)
+			result := validateAndUnMarshalVersionsResponse(t, resp)
+
+			require.Len(t, result.Result, 3)
+			for i, version := range result.Result {
+				require.Equal(t, int64(3-i), version.Version)
+				require.Equal(t, sc.initialResult.Result.ID, version.ElementID)
+				require.Equal(t, "Text - Library Panel", version.Name)
+				require.Equal(t, int64(1), version.CreatedBy.ID)
+				require.Equal(t, userInDbName, version.CreatedBy.Name)
+				require.Empty(t, version.Model)
+			}
+		})
+
+	scenarioWithPanel(t, "When an admin gets the versions of a library panel with a limit, it should return the most recent ones",
+		func(t *testing.T, sc scenarioContext) {
+			patchPanelTitle(t, sc, 1, "Second title")
+			patchPanelTitle(t, sc, 2, "Third title")
+
+			sc.ctx.Req = web.SetURLParams(sc.ctx.Req, map[string]string{":uid": sc.initialResult.Result.UID})
+			sc.ctx.Req.URL = &url.URL{RawQuery: "limit=2"}
+			resp := sc.service.getVersionsHandler(sc.reqContext)
+			result := validateAndUnMarshalVersionsResponse(t, resp)
+
+			require.Len(t, result.Result, 2)
+			require.Equal(t, int64(3), result.Result[0].Version)
+			require.Equal(t, int64(2), result.Result[1].Version)
+		})
+
+	scenarioWithPanel(t, "When an admin gets the versions of a library panel that does not exist, it should fail",
+		func(t *testing.T, sc scenarioContext) {
+			sc.ctx.Req = web.SetURLParams(sc.ctx.Req, map[string]string{":uid": "unknown"})
+			resp := sc.service.getVersionsHandler(sc.reqContext)
+			require.Equal(t, 404, resp.Status())
+		})
+
+	scenarioWithPanel(t, "When an admin gets a version of a library panel, it should return its model",
+		func(t *testing.T, sc scenarioContext) {
+			patchPanelTitle(t, sc, 1, "Second title")
+
+			sc.ctx.Req = web.SetURLParams(sc.ctx.Req, map[string]string{":uid": sc.initialResult.Result.UID, ":version": "1"})
+			resp := sc.service.getVersionHandler(sc.reqContext)
+			require.Equal(t, 200, resp.Status())
+			var result LibraryElementVersionResponse
+			require.NoError(t, json.Unmarshal(resp.Body(), &result))
+
+			require.Equal(t, int64(1), result.Result.Version)
+			var model map[string]interface{}
+			require.NoError(t, json.Unmarshal(result.Result.Model, &model))
+			require.Equal(t, "Text - Library Panel", model["title"])
+		})
+
+	scenarioWithPanel(t, "When an admin gets a version of a library panel that does not exist, it should fail",
+		func(t *testing.T, sc scenarioContext) {
+			sc.ctx.Req = web.SetURLParams(sc.ctx.Req, map[string]string{":uid": sc.initialResult.Result.UID, ":version": "2"})
+			resp := sc.service.getVersionHandler(sc.reqContext)
+			require.Equal(t, 404, resp.Status())
+
+			sc.ctx.Req = web.SetURLParams(sc.ctx.Req, map[string]string{":uid": sc.initialResult.Result.UID, ":version": "latest"})
+			resp = sc.service.getVersionHandler(sc.reqContext)
+			require.Equal(t, 400, resp.Status())
+		})
+
+	scenarioWithPanel(t, "When an admin compares a version of a library panel with the current version, it should return the diff",
+		func(t *testing.T, sc scenarioContext) {
+			patchPanelTitle(t, sc, 1, "Second title")
+
+			sc.ctx.Req = web.SetURLParams(sc.ctx.Req, map[string]string{":uid": sc.initialResult.Result.UID, ":version": "1"})
+			sc.ctx.Req.URL = &url.URL{RawQuery: "diffType=delta"}
+			resp := sc.service.diffVersionHandler(sc.reqContext)
+			require.Equal(t, 200, resp.Status())
+			var delta map[string]interface{}
+			require.NoError(t, json.Unmarshal(resp.Body(), &delta))
+			require.Equal(t, []interface{}{"Text - Library Panel", "Second title"}, delta["title"])
+		})
+
+	scenarioWithPanel(t, "When an admin compares identical versions of a library panel, it should fail",
+		func(t *testing.T, sc scenarioContext) {
+			patchPanelTitle(t, sc, 1, "Second title")
+
+			sc.ctx.Req = web.SetURLParams(sc.ctx.Req, map[string]string{":uid": sc.initialResult.Result.UID, ":version": "1"})
+			sc.ctx.Req.URL = &url.URL{RawQuery: "compareTo=1"}
+			resp := sc.service.diffVersionHandler(sc.reqContext)
+			require.Equal(t, 400, resp.Status())
+		})
+
+	scenarioWithPanel(t, "When an admin restores a version of a library panel, it should be saved as a new version",
+		func(t *testing.T, sc scenarioContext) {
+			patchPanelTitle(t, sc, 1, "Second title")
+
+			sc.ctx.Req = web.SetURLParams(sc.ctx.Req, map[string]string{":uid": sc.initialResult.Result.UID, ":version": "1"})
+			resp := sc.service.restoreVersionHandler(sc.reqContext)
+			result := validateAndUnMarshalResponse(t, resp)
+			require.Equal(t, int64(3), result.Result.Version)
+			require.Equal(t, "Text - Library Panel", result.Result.Model["title"])
+			require.Equal(t, sc.folder.UID, result.Result.Meta.FolderUID)
+
+			sc.ctx.Req = web.SetURLParams(sc.ctx.Req, map[string]string{":uid": sc.initialResult.Result.UID, ":version": "3"})
+			resp = sc.service.getVersionHandler(sc.reqContext)
+			require.Equal(t, 200, resp.Status())
+			var version LibraryElementVersionResponse
+			require.NoError(t, json.Unmarshal(resp.Body(), &version))
+			require.Equal(t, int64(1), version.Result.RestoredFrom)
+		})
+
+	scenarioWithPanel(t, "When an admin restores a version of a library panel that does not exist, it should fail",
+		func(t *testing.T, sc scenarioContext) {
+			sc.ctx.Req = web.SetURLParams(sc.ctx.Req, map[string]string{":uid": sc.initialResult.Result.UID, ":version": "5"})
+			resp := sc.service.restoreVersionHandler(sc.reqContext)
+			require.Equal(t, 404, resp.Status())
+		})
+
+	scenarioWithPanel(t, "When an admin deletes a library panel, it should delete its versions",
+		func(t *testing.T, sc scenarioContext) {
+			patchPanelTitle(t, sc, 1, "Second title")
+
+			sc.ctx.Req = web.SetURLParams(sc.ctx.Req, map[string]string{":uid": sc.initialResult.Result.UID})
+			resp := sc.service.deleteHandler(sc.reqContext)
+			require.Equal(t, 200, resp.Status())
+			require.Zero(t, countLibraryElementVersions(t, sc, sc.initialResult.Result.ID))
+		})
+
+	scenarioWithPanel(t, "When the expired versions are deleted, it should keep the most recent versions of each library panel",
+		func(t *testing.T, sc scenarioContext) {
+			for version := int64(1); version < 5; version++ {
+				patchPanelTitle(t, sc, version, fmt.Sprintf("Title %d", version))
+			}
+			command := getCreatePanelCommand(sc.folder.ID, "Other - Library Panel")
+			sc.reqContext.Req.Body = mockRequestBody(command)
+			other := validateAndUnMarshalResponse(t, sc.service.createHandler(sc.reqContext))
+
+			sc.service.Cfg.LibraryPanelVersionsToKeep = 2
+			deleted, err := sc.service.DeleteExpiredVersions(sc.reqContext.Req.Context())
+			require.NoError(t, err)
+			require.Equal(t, int64(3), deleted)
+
+			sc.ctx.Req = web.SetURLParams(sc.ctx.Req, map[string]string{":uid": sc.initialResult.Result.UID})
+			result := validateAndUnMarshalVersionsResponse(t, sc.service.getVersionsHandler(sc.reqContext))
+			require.Len(t, result.Result, 2)
+			require.Equal(t, int64(5), result.Result[0].Version)
+			require.Equal(t, int64(4), result.Result[1].Version)
+			require.Equal(t, int64(1), countLibraryElementVersions(t, sc, other.Result.ID))
+		})
+}
+
+func patchPanelTitle(t *testing.T, sc scenarioContext, version int64, title string) {
+	t.Helper()
+
+	cmd := PatchLibraryElementCommand{
+		FolderID: -1,
+		Model:    []byte(fmt.Sprintf(`{"title": %q, "type": "text"}`, title)),
+		Kind:     int64(models.PanelElement),
+		Version:  version,
+	}
+	sc.ctx.Req = web.SetURLParams(sc.ctx.Req, map[string]string{":uid": sc.initialResult.Result.UID})
+	sc.reqContext.Req.Body = mockRequestBody(cmd)
+	resp := sc.service.patchHandler(sc.reqContext)
+	require.Equal(t, 200, resp.Status())
+}
+
+func countLibraryElementVersions(t *testing.T, sc scenarioContext, elementID int64) int64 {
+	t.Helper()
+
+	var count int64
+	err := sc.sqlStore.WithDbSession(sc.reqContext.Req.Context(), func(session *db.Session) error {
+		var err error
+		count, err = session.Table("library_element_version").Where("element_id=?", elementID).Count()
+		return err
+	})
+	require.NoError(t, err)
+	return count
+}
+
+func validateAndUnMarshalVersionsResponse(t *testing.T, resp response.Response) LibraryElementVersionsResponse {
+	t.Helper()
+
+	require.Equal(t, 200, resp.Status())
+	var result LibraryElementVersionsResponse
+	require.NoError(t, json.Unmarshal(resp.Body(), &result))
+	return result
+}
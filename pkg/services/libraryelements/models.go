@@ -104,6 +104,44 @@ type LibraryElementDTOMetaUser struct {
 	AvatarURL string `json:"avatarUrl"`
 }
 
+// libraryElementVersion is the model for the versions of library elements.
+type libraryElementVersion struct {
+	ID           int64 `xorm:"pk autoincr 'id'"`
+	ElementID    int64 `xorm:"element_id"`
+	Version      int64
+	RestoredFrom int64
+	Name         string
+	Model        json.RawMessage
+	Created      time.Time
+	CreatedBy    int64
+}
+
+// libraryElementVersionWithMeta is the model used to retrieve versions with additional meta information.
+type libraryElementVersionWithMeta struct {
+	ID             int64 `xorm:"pk autoincr 'id'"`
+	ElementID      int64 `xorm:"element_id"`
+	Version        int64
+	RestoredFrom   int64
+	Name           string
+	Model          json.RawMessage
+	Created        time.Time
+	CreatedBy      int64
+	CreatedByName  string
+	CreatedByEmail string
+}
+
+// LibraryElementVersionDTO is the frontend DTO for the versions of library elements.
+type LibraryElementVersionDTO struct {
+	ID           int64                     `json:"id"`
+	ElementID    int64                     `json:"elementId"`
+	Version      int64                     `json:"version"`
+	RestoredFrom int64                     `json:"restoredFrom"`
+	Name         string                    `json:"name"`
+	Model        json.RawMessage           `json:"model,omitempty"`
+	Created      time.Time                 `json:"created"`
+	CreatedBy    LibraryElementDTOMetaUser `json:"createdBy"`
+}
+
 // libraryElementConnection is the model for library element connections.
 type libraryElementConnection struct {
 	ID           int64 `xorm:"pk autoincr 'id'"`
@@ -157,6 +195,10 @@ var (
 	errLibraryElementInvalidUID = errors.New("uid contains illegal characters")
 	// errLibraryElementUIDTooLong is an error for when the uid of a library element is invalid
 	errLibraryElementUIDTooLong = errors.New("uid too long, max 40 characters")
+	// errLibraryElementVersionNotFound is an error for when a version of a library element can't be found.
+	errLibraryElementVersionNotFound = errors.New("library element version could not be found")
+	// errLibraryElementVersionsIdentical is an error for when two versions of a library element that are compared are identical.
+	errLibraryElementVersionsIdentical = errors.New("the library element versions are identical")
 )
 
 // Commands
@@ -205,6 +247,12 @@ type PatchLibraryElementCommand struct {
 	UID string `json:"uid"`
 }
 
+// listLibraryElementVersionsQuery is the query used for listing the versions of an Element
+type listLibraryElementVersionsQuery struct {
+	limit int
+	start int
+}
+
 // searchLibraryElementsQuery is the query used for searching for Elements
 type searchLibraryElementsQuery struct {
 	perPage          int
@@ -238,6 +286,16 @@ type LibraryElementConnectionsResponse struct {
 	Result []LibraryElementConnectionDTO `json:"result"`
 }
 
+// LibraryElementVersionsResponse is a response struct for an array of LibraryElementVersionDTO.
+type LibraryElementVersionsResponse struct {
+	Result []LibraryElementVersionDTO `json:"result"`
+}
+
+// LibraryElementVersionResponse is a response struct for LibraryElementVersionDTO.
+type LibraryElementVersionResponse struct {
+	Result LibraryElementVersionDTO `json:"result"`
+}
+
 // DeleteLibraryElementResponse is the response struct for deleting a library element.
 type DeleteLibraryElementResponse struct {
 	ID      int64  `json:"id"`
@@ -0,0 +1,92 @@
+package libraryelements
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+)
+
+// ResolveLibraryPanels replaces the library panels of a dashboard with the models of their library elements,
+// keeping the id and position of the panels on the dashboard. Panels of collapsed rows are resolved as well. The
+// library elements are only loaded when the dashboard has library panels.
+func ResolveLibraryPanels(ctx context.Context, service Service, dashboardID int64, dashboard *simplejson.Json) error {
+	panels := dashboard.Get("panels").MustArray()
+	if !hasLibraryPanels(panels) {
+		return nil
+	}
+
+	elements, err := service.GetElementsForDashboard(ctx, dashboardID)
+	if err != nil {
+		return err
+	}
+
+	resolved, err := resolveLibraryPanels(panels, elements)
+	if err != nil {
+		return err
+	}
+	dashboard.Set("panels", resolved)
+	return nil
+}
+
+func libraryPanelUID(panel map[string]interface{}) string {
+	libraryPanel, ok := panel["libraryPanel"].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	uid, _ := libraryPanel["uid"].(string)
+	return uid
+}
+
+func hasLibraryPanels(panels []interface{}) bool {
+	for _, p := range panels {
+		panel, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if libraryPanelUID(panel) != "" {
+			return true
+		}
+		if rowPanels, ok := panel["panels"].([]interface{}); ok && hasLibraryPanels(rowPanels) {
+			return true
+		}
+	}
+	return false
+}
+
+func resolveLibraryPanels(panels []interface{}, elements map[string]LibraryElementDTO) ([]interface{}, error) {
+	resolved := make([]interface{}, 0, len(panels))
+	for _, p := range panels {
+		panel, ok := p.(map[string]interface{})
+		if !ok {
+			resolved = append(resolved, p)
+			continue
+		}
+
+		if rowPanels, ok := panel["panels"].([]interface{}); ok {
+			rowPanels, err := resolveLibraryPanels(rowPanels, elements)
+			if err != nil {
+				return nil, err
+			}
+			panel["panels"] = rowPanels
+		}
+
+		uid := libraryPanelUID(panel)
+		element, ok := elements[uid]
+		if !ok {
+			resolved = append(resolved, panel)
+			continue
+		}
+
+		var model map[string]interface{}
+		if err := json.Unmarshal(element.Model, &model); err != nil {
+			return nil, fmt.Errorf("invalid model of library panel %s: %w", uid, err)
+		}
+		delete(model, "libraryPanel")
+		model["id"] = panel["id"]
+		model["gridPos"] = panel["gridPos"]
+		resolved = append(resolved, model)
+	}
+	return resolved, nil
+}
@@ -44,6 +44,12 @@ type Alertmanager interface {
 	GetSilence(silenceID string) (apimodels.GettableSilence, error)
 	ListSilences(filter []string) (apimodels.GettableSilences, error)
 
+	// Silence templates
+	GetSilenceTemplates(ctx context.Context) (apimodels.SilenceTemplates, error)
+	SaveSilenceTemplate(ctx context.Context, uid string, t apimodels.PostableSilenceTemplate) (apimodels.SilenceTemplate, error)
+	DeleteSilenceTemplate(ctx context.Context, uid string) error
+	ApplySilenceTemplate(ctx context.Context, uid string, apply apimodels.ApplySilenceTemplate, createdBy string) (string, error)
+
 	// Alerts
 	GetAlerts(active, silenced, inhibited bool, filter []string, receiver string) (apimodels.GettableAlerts, error)
 	GetAlertGroups(active, silenced, inhibited bool, filter []string, receiver string) (apimodels.AlertGroups, error)
@@ -51,6 +57,10 @@ type Alertmanager interface {
 	// Receivers
 	GetReceivers(ctx context.Context) []apimodels.Receiver
 	TestReceivers(ctx context.Context, c apimodels.TestReceiversConfigBodyParams) (*notifier.TestReceiversResult, error)
+	GetNotificationDeliveries(ctx context.Context, query *models.GetNotificationDeliveriesQuery) (apimodels.NotificationDeliveries, error)
+
+	// Routes
+	TestRoutes(ctx context.Context, c apimodels.TestRoutesConfigBodyParams) (apimodels.TestRoutesResult, error)
 }
 
 type AlertingStore interface {
@@ -78,6 +88,7 @@ type API struct {
 	ContactPointService  *provisioning.ContactPointService
 	Templates            *provisioning.TemplateService
 	MuteTimings          *provisioning.MuteTimingService
+	InhibitionRules      *provisioning.InhibitionRuleService
 	AlertRules           *provisioning.AlertRuleService
 	AlertsRouter         *sender.AlertsRouter
 	EvaluatorFactory     eval.EvaluatorFactory
@@ -117,6 +128,7 @@ func (api *API) RegisterAPIEndpoints(m *metrics.API) {
 			log:                logger,
 			cfg:                &api.Cfg.UnifiedAlerting,
 			ac:                 api.AccessControl,
+			amConfigStore:      api.AlertingStore,
 		},
 	), m)
 	api.RegisterTestingApiEndpoints(NewTestingApi(
@@ -142,6 +154,7 @@ func (api *API) RegisterAPIEndpoints(m *metrics.API) {
 		contactPointService: api.ContactPointService,
 		templates:           api.Templates,
 		muteTimings:         api.MuteTimings,
+		inhibitionRules:     api.InhibitionRules,
 		alertRules:          api.AlertRules,
 	}), m)
 }
@@ -16,6 +16,7 @@ import (
 	"github.com/grafana/grafana/pkg/models"
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
 	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
 	"github.com/grafana/grafana/pkg/services/ngalert/notifier"
 	"github.com/grafana/grafana/pkg/services/ngalert/store"
 	"github.com/grafana/grafana/pkg/util"
@@ -120,6 +121,77 @@ func (srv AlertmanagerSrv) RouteDeleteSilence(c *models.ReqContext, silenceID st
 	return response.JSON(http.StatusOK, util.DynMap{"message": "silence deleted"})
 }
 
+func (srv AlertmanagerSrv) RouteGetSilenceTemplates(c *models.ReqContext) response.Response {
+	am, errResp := srv.AlertmanagerFor(c.OrgID)
+	if errResp != nil {
+		return errResp
+	}
+
+	templates, err := am.GetSilenceTemplates(c.Req.Context())
+	if err != nil {
+		return ErrResp(http.StatusInternalServerError, err, "failed to get silence templates")
+	}
+	return response.JSON(http.StatusOK, templates)
+}
+
+func (srv AlertmanagerSrv) RoutePostSilenceTemplate(c *models.ReqContext, body apimodels.PostableSilenceTemplate) response.Response {
+	return srv.saveSilenceTemplate(c, "", body, http.StatusCreated)
+}
+
+func (srv AlertmanagerSrv) RoutePutSilenceTemplate(c *models.ReqContext, body apimodels.PostableSilenceTemplate, uid string) response.Response {
+	return srv.saveSilenceTemplate(c, uid, body, http.StatusOK)
+}
+
+func (srv AlertmanagerSrv) saveSilenceTemplate(c *models.ReqContext, uid string, body apimodels.PostableSilenceTemplate, status int) response.Response {
+	am, errResp := srv.AlertmanagerFor(c.OrgID)
+	if errResp != nil {
+		return errResp
+	}
+
+	template, err := am.SaveSilenceTemplate(c.Req.Context(), uid, body)
+	if err != nil {
+		return silenceTemplateErrorResp(err)
+	}
+	return response.JSON(status, template)
+}
+
+func (srv AlertmanagerSrv) RouteDeleteSilenceTemplate(c *models.ReqContext, uid string) response.Response {
+	am, errResp := srv.AlertmanagerFor(c.OrgID)
+	if errResp != nil {
+		return errResp
+	}
+
+	if err := am.DeleteSilenceTemplate(c.Req.Context(), uid); err != nil {
+		return silenceTemplateErrorResp(err)
+	}
+	return response.JSON(http.StatusOK, util.DynMap{"message": "silence template deleted"})
+}
+
+func (srv AlertmanagerSrv) RoutePostApplySilenceTemplate(c *models.ReqContext, body apimodels.ApplySilenceTemplate, uid string) response.Response {
+	am, errResp := srv.AlertmanagerFor(c.OrgID)
+	if errResp != nil {
+		return errResp
+	}
+
+	silenceID, err := am.ApplySilenceTemplate(c.Req.Context(), uid, body, c.SignedInUser.Login)
+	if err != nil {
+		return silenceTemplateErrorResp(err)
+	}
+	return response.JSON(http.StatusAccepted, apimodels.PostSilencesOKBody{
+		SilenceID: silenceID,
+	})
+}
+
+func silenceTemplateErrorResp(err error) response.Response {
+	switch {
+	case errors.Is(err, notifier.ErrSilenceTemplateNotFound):
+		return ErrResp(http.StatusNotFound, err, "")
+	case errors.Is(err, notifier.ErrSilenceTemplateBadPayload), errors.Is(err, notifier.ErrCreateSilenceBadPayload):
+		return ErrResp(http.StatusBadRequest, err, "")
+	}
+	return ErrResp(http.StatusInternalServerError, err, "")
+}
+
 func (srv AlertmanagerSrv) RouteGetAlertingConfig(c *models.ReqContext) response.Response {
 	config, err := srv.mam.GetAlertmanagerConfiguration(c.Req.Context(), c.OrgID)
 	if err != nil {
@@ -131,6 +203,94 @@ func (srv AlertmanagerSrv) RouteGetAlertingConfig(c *models.ReqContext) response
 	return response.JSON(http.StatusOK, config)
 }
 
+func (srv AlertmanagerSrv) RouteGetAlertingConfigHistory(c *models.ReqContext) response.Response {
+	limit := c.QueryInt("limit")
+	if limit < 0 {
+		return ErrResp(http.StatusBadRequest, errors.New("limit must not be negative"), "")
+	}
+
+	history, err := srv.mam.GetAlertmanagerConfigurationHistory(c.Req.Context(), c.OrgID, limit)
+	if err != nil {
+		return ErrResp(http.StatusInternalServerError, err, "")
+	}
+	return response.JSON(http.StatusOK, history)
+}
+
+func (srv AlertmanagerSrv) RouteGetAlertingConfigHistoryEntry(c *models.ReqContext, id string) response.Response {
+	configID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return ErrResp(http.StatusBadRequest, err, "invalid configuration ID")
+	}
+
+	config, err := srv.mam.GetHistoricalAlertmanagerConfiguration(c.Req.Context(), c.OrgID, configID)
+	if err != nil {
+		return configHistoryErrorResp(err)
+	}
+	return response.JSON(http.StatusOK, config)
+}
+
+func (srv AlertmanagerSrv) RouteGetAlertingConfigHistoryDiff(c *models.ReqContext, id string) response.Response {
+	configID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return ErrResp(http.StatusBadRequest, err, "invalid configuration ID")
+	}
+	var compareTo int64
+	if v := c.Query("compareTo"); v != "" {
+		compareTo, err = strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return ErrResp(http.StatusBadRequest, err, "invalid compareTo parameter")
+		}
+	}
+
+	diff, err := srv.mam.DiffAlertmanagerConfigurations(c.Req.Context(), c.OrgID, configID, compareTo)
+	if err != nil {
+		return configHistoryErrorResp(err)
+	}
+	return response.JSON(http.StatusOK, diff)
+}
+
+func (srv AlertmanagerSrv) RoutePostAlertingConfigHistoryActivate(c *models.ReqContext, id string) response.Response {
+	configID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return ErrResp(http.StatusBadRequest, err, "invalid configuration ID")
+	}
+
+	historicConfig, err := srv.mam.GetHistoricalAlertmanagerConfiguration(c.Req.Context(), c.OrgID, configID)
+	if err != nil {
+		return configHistoryErrorResp(err)
+	}
+	currentConfig, err := srv.mam.GetAlertmanagerConfiguration(c.Req.Context(), c.OrgID)
+	// As for a posted configuration, the guard is bypassed if there is no valid current configuration.
+	if err == nil {
+		if err := srv.historicProvenanceGuard(currentConfig, historicConfig); err != nil {
+			return ErrResp(http.StatusBadRequest, err, "")
+		}
+	}
+
+	err = srv.mam.ActivateHistoricalConfiguration(c.Req.Context(), c.OrgID, configID)
+	if err == nil {
+		return response.JSON(http.StatusAccepted, util.DynMap{"message": "configuration activated"})
+	}
+	var configRejectedError notifier.AlertmanagerConfigRejectedError
+	if errors.As(err, &configRejectedError) {
+		return ErrResp(http.StatusBadRequest, configRejectedError, "")
+	}
+	if errors.Is(err, notifier.ErrNoAlertmanagerForOrg) {
+		return response.Error(http.StatusNotFound, err.Error(), err)
+	}
+	if errors.Is(err, notifier.ErrAlertmanagerNotReady) {
+		return response.Error(http.StatusConflict, err.Error(), err)
+	}
+	return configHistoryErrorResp(err)
+}
+
+func configHistoryErrorResp(err error) response.Response {
+	if errors.Is(err, store.ErrNoAlertmanagerConfiguration) {
+		return ErrResp(http.StatusNotFound, err, "")
+	}
+	return ErrResp(http.StatusInternalServerError, err, "")
+}
+
 func (srv AlertmanagerSrv) RouteGetAMAlertGroups(c *models.ReqContext) response.Response {
 	am, errResp := srv.AlertmanagerFor(c.OrgID)
 	if errResp != nil {
@@ -257,6 +417,62 @@ func (srv AlertmanagerSrv) RouteGetReceivers(c *models.ReqContext) response.Resp
 	return response.JSON(http.StatusOK, rcvs)
 }
 
+func (srv AlertmanagerSrv) RouteGetNotificationDeliveries(c *models.ReqContext) response.Response {
+	query := &ngmodels.GetNotificationDeliveriesQuery{
+		Receiver:    c.Query("receiver"),
+		Integration: c.Query("integration"),
+		Status:      ngmodels.NotificationDeliveryStatus(c.Query("status")),
+		Limit:       c.QueryInt("limit"),
+	}
+	switch query.Status {
+	case "", ngmodels.NotificationDeliverySuccess, ngmodels.NotificationDeliveryFailed:
+	default:
+		return ErrResp(http.StatusBadRequest, fmt.Errorf("unknown status %q", query.Status), "")
+	}
+	if query.Limit < 0 {
+		return ErrResp(http.StatusBadRequest, errors.New("limit must not be negative"), "")
+	}
+	for param, t := range map[string]*time.Time{"from": &query.From, "to": &query.To} {
+		if v := c.Query(param); v != "" {
+			parsed, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return ErrResp(http.StatusBadRequest, err, "invalid %s parameter", param)
+			}
+			*t = parsed.UTC()
+		}
+	}
+
+	am, errResp := srv.AlertmanagerFor(c.OrgID)
+	if errResp != nil {
+		return errResp
+	}
+
+	deliveries, err := am.GetNotificationDeliveries(c.Req.Context(), query)
+	if err != nil {
+		return ErrResp(http.StatusInternalServerError, err, "failed to get notification deliveries")
+	}
+	return response.JSON(http.StatusOK, deliveries)
+}
+
+func (srv AlertmanagerSrv) RoutePostTestRoutes(c *models.ReqContext, body apimodels.TestRoutesConfigBodyParams) response.Response {
+	am, errResp := srv.AlertmanagerFor(c.OrgID)
+	if errResp != nil {
+		return errResp
+	}
+
+	result, err := am.TestRoutes(c.Req.Context(), body)
+	if err != nil {
+		switch {
+		case errors.Is(err, notifier.ErrTestRoutesBadPayload):
+			return ErrResp(http.StatusBadRequest, err, "")
+		case errors.Is(err, notifier.ErrAlertmanagerNotReady):
+			return response.Error(http.StatusConflict, err.Error(), err)
+		}
+		return ErrResp(http.StatusInternalServerError, err, "failed to test routes")
+	}
+	return response.JSON(http.StatusOK, result)
+}
+
 func (srv AlertmanagerSrv) RoutePostTestReceivers(c *models.ReqContext, body apimodels.TestReceiversConfigBodyParams) response.Response {
 	if err := srv.crypto.LoadSecureSettings(c.Req.Context(), c.OrgID, body.Receivers); err != nil {
 		var unknownReceiverError UnknownReceiverError
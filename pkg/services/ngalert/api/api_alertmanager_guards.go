@@ -25,9 +25,43 @@ func (srv AlertmanagerSrv) provenanceGuard(currentConfig apimodels.GettableUserC
 	if err := checkMuteTimes(currentConfig, newConfig); err != nil {
 		return err
 	}
+	if err := checkInhibitRules(currentConfig, newConfig); err != nil {
+		return err
+	}
 	return nil
 }
 
+// historicProvenanceGuard checks that activating a stored version of the configuration does not change provisioned
+// resources. The secure settings of the stored version are encrypted, so they cannot be compared and are ignored.
+func (srv AlertmanagerSrv) historicProvenanceGuard(currentConfig apimodels.GettableUserConfig, historicConfig apimodels.GettableHistoricUserConfig) error {
+	newConfig := apimodels.PostableUserConfig{
+		TemplateFiles: historicConfig.TemplateFiles,
+		AlertmanagerConfig: apimodels.PostableApiAlertingConfig{
+			Config: historicConfig.AlertmanagerConfig.Config,
+		},
+	}
+	// The stored routes have no provenance, so the provenance of the current route is not compared.
+	if newConfig.AlertmanagerConfig.Route != nil && currentConfig.AlertmanagerConfig.Route != nil {
+		route := *newConfig.AlertmanagerConfig.Route
+		route.Provenance = currentConfig.AlertmanagerConfig.Route.Provenance
+		newConfig.AlertmanagerConfig.Route = &route
+	}
+	for _, receiver := range historicConfig.AlertmanagerConfig.Receivers {
+		postable := &apimodels.PostableApiReceiver{Receiver: receiver.Receiver}
+		for _, contactPoint := range receiver.GrafanaManagedReceivers {
+			postable.GrafanaManagedReceivers = append(postable.GrafanaManagedReceivers, &apimodels.PostableGrafanaReceiver{
+				UID:                   contactPoint.UID,
+				Name:                  contactPoint.Name,
+				Type:                  contactPoint.Type,
+				DisableResolveMessage: contactPoint.DisableResolveMessage,
+				Settings:              contactPoint.Settings,
+			})
+		}
+		newConfig.AlertmanagerConfig.Receivers = append(newConfig.AlertmanagerConfig.Receivers, postable)
+	}
+	return srv.provenanceGuard(currentConfig, newConfig)
+}
+
 func checkRoutes(currentConfig apimodels.GettableUserConfig, newConfig apimodels.PostableUserConfig) error {
 	reporter := cmputil.DiffReporter{}
 	options := []cmp.Option{cmp.Reporter(&reporter), cmpopts.EquateEmpty(), cmpopts.IgnoreUnexported(labels.Matcher{})}
@@ -144,3 +178,26 @@ func checkMuteTimes(currentConfig apimodels.GettableUserConfig, newConfig apimod
 	}
 	return nil
 }
+
+// checkInhibitRules checks that the provisioned inhibition rules are neither changed nor deleted. The UID of an
+// inhibition rule is computed from its content, so a changed rule has a different UID.
+func checkInhibitRules(currentConfig apimodels.GettableUserConfig, newConfig apimodels.PostableUserConfig) error {
+	newRules := make(map[string]struct{})
+	for _, rule := range newConfig.AlertmanagerConfig.InhibitRules {
+		newRules[apimodels.InhibitionRuleUID(*rule)] = struct{}{}
+	}
+	for _, rule := range currentConfig.AlertmanagerConfig.InhibitRules {
+		uid := apimodels.InhibitionRuleUID(*rule)
+		provenance := ngmodels.ProvenanceNone
+		if prov, present := currentConfig.AlertmanagerConfig.InhibitRuleProvenances[uid]; present {
+			provenance = prov
+		}
+		if provenance == ngmodels.ProvenanceNone {
+			continue // we are only interested in non none
+		}
+		if _, present := newRules[uid]; !present {
+			return fmt.Errorf("cannot change or delete provisioned inhibition rule '%s'", uid)
+		}
+	}
+	return nil
+}
@@ -587,3 +587,83 @@ func defaultInterval(t *testing.T) []timeinterval.TimeInterval {
 		},
 	}
 }
+
+func TestCheckInhibitRules(t *testing.T) {
+	rule := func(t *testing.T, source string) *amConfig.InhibitRule {
+		t.Helper()
+		sourceMatcher, err := labels.NewMatcher(labels.MatchEqual, "severity", source)
+		require.NoError(t, err)
+		targetMatcher, err := labels.NewMatcher(labels.MatchEqual, "severity", "warning")
+		require.NoError(t, err)
+		return &amConfig.InhibitRule{
+			SourceMatchers: amConfig.Matchers{sourceMatcher},
+			TargetMatchers: amConfig.Matchers{targetMatcher},
+			Equal:          model.LabelNames{"alertname"},
+		}
+	}
+	gettable := func(rule *amConfig.InhibitRule, provenance models.Provenance) definitions.GettableUserConfig {
+		return definitions.GettableUserConfig{
+			AlertmanagerConfig: definitions.GettableApiAlertingConfig{
+				InhibitRuleProvenances: map[string]models.Provenance{definitions.InhibitionRuleUID(*rule): provenance},
+				Config:                 definitions.Config{InhibitRules: []*amConfig.InhibitRule{rule}},
+			},
+		}
+	}
+	postable := func(rules ...*amConfig.InhibitRule) definitions.PostableUserConfig {
+		return definitions.PostableUserConfig{
+			AlertmanagerConfig: definitions.PostableApiAlertingConfig{
+				Config: definitions.Config{InhibitRules: rules},
+			},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		shouldErr     bool
+		currentConfig definitions.GettableUserConfig
+		newConfig     definitions.PostableUserConfig
+	}{
+		{
+			name:          "equal configs should not error",
+			currentConfig: gettable(rule(t, "critical"), models.ProvenanceAPI),
+			newConfig:     postable(rule(t, "critical")),
+		},
+		{
+			name:          "adding an inhibition rule should not fail",
+			currentConfig: gettable(rule(t, "critical"), models.ProvenanceAPI),
+			newConfig:     postable(rule(t, "critical"), rule(t, "error")),
+		},
+		{
+			name:          "editing a non provisioned object should not fail",
+			currentConfig: gettable(rule(t, "critical"), models.ProvenanceNone),
+			newConfig:     postable(rule(t, "error")),
+		},
+		{
+			name:          "deleting a non provisioned object should not fail",
+			currentConfig: gettable(rule(t, "critical"), models.ProvenanceNone),
+			newConfig:     postable(),
+		},
+		{
+			name:          "editing a provisioned object should fail",
+			shouldErr:     true,
+			currentConfig: gettable(rule(t, "critical"), models.ProvenanceFile),
+			newConfig:     postable(rule(t, "error")),
+		},
+		{
+			name:          "deleting a provisioned object should fail",
+			shouldErr:     true,
+			currentConfig: gettable(rule(t, "critical"), models.ProvenanceAPI),
+			newConfig:     postable(),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := checkInhibitRules(test.currentConfig, test.newConfig)
+			if test.shouldErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -5,12 +5,15 @@ import (
 	"encoding/json"
 	"math/rand"
 	"net/http"
+	"net/http/httptest"
+	"strconv"
 	"testing"
 	"time"
 
 	"github.com/go-openapi/strfmt"
 	amv2 "github.com/prometheus/alertmanager/api/v2/models"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/require"
 
 	"github.com/grafana/grafana/pkg/api/response"
@@ -482,6 +485,89 @@ func TestRouteCreateSilence(t *testing.T) {
 	}
 }
 
+func TestRouteGetNotificationDeliveries(t *testing.T) {
+	sut := createSut(t, nil)
+
+	request := func(query string) response.Response {
+		rc := createRequestCtxInOrg(1)
+		rc.Req = httptest.NewRequest(http.MethodGet, "/api/alertmanager/grafana/config/api/v1/receivers/deliveries?"+query, nil)
+		return sut.RouteGetNotificationDeliveries(rc)
+	}
+
+	t.Run("returns the deliveries of the organization", func(t *testing.T) {
+		resp := request("receiver=grafana-default-email&status=failed&from=2022-11-01T00:00:00Z&limit=10")
+		require.Equal(t, http.StatusOK, resp.Status())
+		require.JSONEq(t, "[]", string(resp.Body()))
+	})
+
+	t.Run("rejects invalid parameters", func(t *testing.T) {
+		for _, query := range []string{"status=unknown", "from=yesterday", "to=1667260800", "limit=-1"} {
+			require.Equal(t, http.StatusBadRequest, request(query).Status(), query)
+		}
+	})
+}
+
+func TestRouteAlertingConfigHistory(t *testing.T) {
+	setup := func(t *testing.T) (AlertmanagerSrv, *models.ReqContext, string, string) {
+		t.Helper()
+		sut := createSut(t, nil)
+		rc := createRequestCtxInOrg(1)
+		rc.Req = httptest.NewRequest(http.MethodGet, "/api/alertmanager/grafana/config/history", nil)
+
+		require.Equal(t, http.StatusAccepted, sut.RoutePostAlertingConfig(rc, createAmConfigRequest(t)).Status())
+		request := createAmConfigRequest(t)
+		request.AlertmanagerConfig.Route.GroupByStr = []string{"alertname"}
+		require.Equal(t, http.StatusAccepted, sut.RoutePostAlertingConfig(rc, request).Status())
+
+		resp := sut.RouteGetAlertingConfigHistory(rc)
+		require.Equal(t, http.StatusOK, resp.Status())
+		var history apimodels.GettableHistoricUserConfigs
+		require.NoError(t, json.Unmarshal(resp.Body(), &history))
+		require.Len(t, history, 2)
+		return sut, rc, strconv.FormatInt(history[1].ID, 10), strconv.FormatInt(history[0].ID, 10)
+	}
+
+	t.Run("returns a stored version or 404", func(t *testing.T) {
+		sut, rc, first, _ := setup(t)
+
+		resp := sut.RouteGetAlertingConfigHistoryEntry(rc, first)
+		require.Equal(t, http.StatusOK, resp.Status())
+		require.Equal(t, http.StatusNotFound, sut.RouteGetAlertingConfigHistoryEntry(rc, "1000").Status())
+		require.Equal(t, http.StatusBadRequest, sut.RouteGetAlertingConfigHistoryEntry(rc, "first").Status())
+	})
+
+	t.Run("returns the diff with the latest version", func(t *testing.T) {
+		sut, rc, first, last := setup(t)
+
+		resp := sut.RouteGetAlertingConfigHistoryDiff(rc, first)
+		require.Equal(t, http.StatusOK, resp.Status())
+		var diff apimodels.AlertingConfigDiff
+		require.NoError(t, json.Unmarshal(resp.Body(), &diff))
+		require.Equal(t, last, strconv.FormatInt(diff.To, 10))
+		require.Contains(t, diff.Diff, "alertname")
+
+		rc.Req = httptest.NewRequest(http.MethodGet, "/api/alertmanager/grafana/config/history/"+first+"/diff?compareTo=abc", nil)
+		require.Equal(t, http.StatusBadRequest, sut.RouteGetAlertingConfigHistoryDiff(rc, first).Status())
+	})
+
+	t.Run("activates a stored version", func(t *testing.T) {
+		sut, rc, first, _ := setup(t)
+
+		require.Equal(t, http.StatusAccepted, sut.RoutePostAlertingConfigHistoryActivate(rc, first).Status())
+		body := asGettableUserConfig(t, sut.RouteGetAlertingConfig(rc))
+		require.Empty(t, body.AlertmanagerConfig.Route.GroupByStr)
+		require.Equal(t, http.StatusNotFound, sut.RoutePostAlertingConfigHistoryActivate(rc, "1000").Status())
+	})
+
+	t.Run("rejects activating a version that changes provisioned objects", func(t *testing.T) {
+		sut, rc, first, last := setup(t)
+		setRouteProvenance(t, 1, sut.mam.ProvStore)
+
+		require.Equal(t, http.StatusBadRequest, sut.RoutePostAlertingConfigHistoryActivate(rc, first).Status())
+		require.Equal(t, http.StatusAccepted, sut.RoutePostAlertingConfigHistoryActivate(rc, last).Status())
+	})
+}
+
 func createSut(t *testing.T, accessControl accesscontrol.AccessControl) AlertmanagerSrv {
 	t.Helper()
 
@@ -498,6 +584,32 @@ func createSut(t *testing.T, accessControl accesscontrol.AccessControl) Alertman
 	}
 }
 
+func TestRoutePostTestRoutes(t *testing.T) {
+	sut := createSut(t, nil)
+	rc := createRequestCtxInOrg(1)
+	rc.Req = httptest.NewRequest(http.MethodPost, "/api/alertmanager/grafana/config/api/v1/routes/test", nil)
+	require.Equal(t, http.StatusAccepted, sut.RoutePostAlertingConfig(rc, createAmConfigRequest(t)).Status())
+
+	t.Run("returns the routes of the alert", func(t *testing.T) {
+		resp := sut.RoutePostTestRoutes(rc, apimodels.TestRoutesConfigBodyParams{Labels: model.LabelSet{"alertname": "test"}})
+		require.Equal(t, http.StatusOK, resp.Status())
+		var result apimodels.TestRoutesResult
+		require.NoError(t, json.Unmarshal(resp.Body(), &result))
+		require.Len(t, result.Routes, 1)
+		require.Equal(t, "grafana-default-email", result.Routes[0].Receiver)
+	})
+
+	t.Run("returns 400 without labels", func(t *testing.T) {
+		resp := sut.RoutePostTestRoutes(rc, apimodels.TestRoutesConfigBodyParams{})
+		require.Equal(t, http.StatusBadRequest, resp.Status())
+	})
+
+	t.Run("returns 404 for an unknown organization", func(t *testing.T) {
+		resp := sut.RoutePostTestRoutes(createRequestCtxInOrg(100), apimodels.TestRoutesConfigBodyParams{Labels: model.LabelSet{"alertname": "test"}})
+		require.Equal(t, http.StatusNotFound, resp.Status())
+	})
+}
+
 func createAmConfigRequest(t *testing.T) apimodels.PostableUserConfig {
 	t.Helper()
 
@@ -23,6 +23,7 @@ type ProvisioningSrv struct {
 	contactPointService ContactPointService
 	templates           TemplateService
 	muteTimings         MuteTimingService
+	inhibitionRules     InhibitionRuleService
 	alertRules          AlertRuleService
 }
 
@@ -52,6 +53,14 @@ type MuteTimingService interface {
 	DeleteMuteTiming(ctx context.Context, name string, orgID int64) error
 }
 
+type InhibitionRuleService interface {
+	GetInhibitionRules(ctx context.Context, orgID int64) ([]definitions.InhibitionRule, error)
+	GetInhibitionRule(ctx context.Context, uid string, orgID int64) (definitions.InhibitionRule, error)
+	CreateInhibitionRule(ctx context.Context, rule definitions.InhibitionRule, orgID int64) (*definitions.InhibitionRule, error)
+	UpdateInhibitionRule(ctx context.Context, uid string, rule definitions.InhibitionRule, orgID int64) (*definitions.InhibitionRule, error)
+	DeleteInhibitionRule(ctx context.Context, uid string, orgID int64) error
+}
+
 type AlertRuleService interface {
 	GetAlertRule(ctx context.Context, orgID int64, ruleUID string) (alerting_models.AlertRule, alerting_models.Provenance, error)
 	CreateAlertRule(ctx context.Context, rule alerting_models.AlertRule, provenance alerting_models.Provenance, userID int64) (alerting_models.AlertRule, error)
@@ -247,6 +256,60 @@ func (srv *ProvisioningSrv) RouteDeleteMuteTiming(c *models.ReqContext, name str
 	return response.JSON(http.StatusNoContent, nil)
 }
 
+func (srv *ProvisioningSrv) RouteGetInhibitionRules(c *models.ReqContext) response.Response {
+	rules, err := srv.inhibitionRules.GetInhibitionRules(c.Req.Context(), c.OrgID)
+	if err != nil {
+		return ErrResp(http.StatusInternalServerError, err, "")
+	}
+	return response.JSON(http.StatusOK, rules)
+}
+
+func (srv *ProvisioningSrv) RouteGetInhibitionRule(c *models.ReqContext, uid string) response.Response {
+	rule, err := srv.inhibitionRules.GetInhibitionRule(c.Req.Context(), uid, c.OrgID)
+	if err != nil {
+		if errors.Is(err, provisioning.ErrNotFound) {
+			return response.Empty(http.StatusNotFound)
+		}
+		return ErrResp(http.StatusInternalServerError, err, "")
+	}
+	return response.JSON(http.StatusOK, rule)
+}
+
+func (srv *ProvisioningSrv) RoutePostInhibitionRule(c *models.ReqContext, rule definitions.InhibitionRule) response.Response {
+	rule.Provenance = alerting_models.ProvenanceAPI
+	created, err := srv.inhibitionRules.CreateInhibitionRule(c.Req.Context(), rule, c.OrgID)
+	if err != nil {
+		if errors.Is(err, provisioning.ErrValidation) {
+			return ErrResp(http.StatusBadRequest, err, "")
+		}
+		return ErrResp(http.StatusInternalServerError, err, "")
+	}
+	return response.JSON(http.StatusCreated, created)
+}
+
+func (srv *ProvisioningSrv) RoutePutInhibitionRule(c *models.ReqContext, rule definitions.InhibitionRule, uid string) response.Response {
+	rule.Provenance = alerting_models.ProvenanceAPI
+	updated, err := srv.inhibitionRules.UpdateInhibitionRule(c.Req.Context(), uid, rule, c.OrgID)
+	if err != nil {
+		if errors.Is(err, provisioning.ErrValidation) {
+			return ErrResp(http.StatusBadRequest, err, "")
+		}
+		return ErrResp(http.StatusInternalServerError, err, "")
+	}
+	if updated == nil {
+		return response.Empty(http.StatusNotFound)
+	}
+	return response.JSON(http.StatusAccepted, updated)
+}
+
+func (srv *ProvisioningSrv) RouteDeleteInhibitionRule(c *models.ReqContext, uid string) response.Response {
+	err := srv.inhibitionRules.DeleteInhibitionRule(c.Req.Context(), uid, c.OrgID)
+	if err != nil {
+		return ErrResp(http.StatusInternalServerError, err, "")
+	}
+	return response.JSON(http.StatusNoContent, nil)
+}
+
 func (srv *ProvisioningSrv) RouteRouteGetAlertRule(c *models.ReqContext, UID string) response.Response {
 	rule, provenace, err := srv.alertRules.GetAlertRule(c.Req.Context(), c.OrgID, UID)
 	if err != nil {
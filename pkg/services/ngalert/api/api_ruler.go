@@ -11,6 +11,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
 	"github.com/grafana/grafana/pkg/services/dashboards"
 	"github.com/grafana/grafana/pkg/services/ngalert/eval"
+	"github.com/grafana/grafana/pkg/services/ngalert/notifier"
 	"github.com/grafana/grafana/pkg/services/ngalert/provisioning"
 	"github.com/grafana/grafana/pkg/services/ngalert/store"
 	"github.com/grafana/grafana/pkg/services/quota"
@@ -43,6 +44,7 @@ type RulerSrv struct {
 	cfg                *setting.UnifiedAlertingSettings
 	ac                 accesscontrol.AccessControl
 	conditionValidator ConditionValidator
+	amConfigStore      AlertingStore
 }
 
 var (
@@ -314,6 +316,13 @@ func (srv RulerSrv) RoutePostNameRulesConfig(c *models.ReqContext, ruleGroupConf
 		return ErrResp(http.StatusBadRequest, err, "")
 	}
 
+	if err := srv.validateNotificationSettings(c.Req.Context(), c.SignedInUser.OrgID, rules); err != nil {
+		if errors.Is(err, ngmodels.ErrAlertRuleFailedValidation) {
+			return ErrResp(http.StatusBadRequest, err, "")
+		}
+		return ErrResp(http.StatusInternalServerError, err, "failed to validate the notification settings")
+	}
+
 	groupKey := ngmodels.AlertRuleGroupKey{
 		OrgID:        c.SignedInUser.OrgID,
 		NamespaceUID: namespace.UID,
@@ -323,6 +332,39 @@ func (srv RulerSrv) RoutePostNameRulesConfig(c *models.ReqContext, ruleGroupConf
 	return srv.updateAlertRulesInGroup(c, groupKey, rules)
 }
 
+// validateNotificationSettings checks that the contact points and mute timings the rules send their alerts to
+// exist in the current Alertmanager configuration of the organization.
+func (srv RulerSrv) validateNotificationSettings(ctx context.Context, orgID int64, rules []*ngmodels.AlertRule) error {
+	var settings []ngmodels.NotificationSettings
+	for _, rule := range rules {
+		if ns := rule.GetNotificationSettings(); ns != nil {
+			settings = append(settings, *ns)
+		}
+	}
+	if len(settings) == 0 {
+		return nil
+	}
+
+	rawConfig := srv.cfg.DefaultConfiguration
+	q := ngmodels.GetLatestAlertmanagerConfigurationQuery{OrgID: orgID}
+	if err := srv.amConfigStore.GetLatestAlertmanagerConfiguration(ctx, &q); err == nil {
+		rawConfig = q.Result.AlertmanagerConfiguration
+	} else if !errors.Is(err, store.ErrNoAlertmanagerConfiguration) {
+		return fmt.Errorf("failed to get the Alertmanager configuration: %w", err)
+	}
+	cfg, err := notifier.Load([]byte(rawConfig))
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal the Alertmanager configuration: %w", err)
+	}
+
+	for _, s := range settings {
+		if err := cfg.AlertmanagerConfig.ValidateNotificationSettings(s); err != nil {
+			return fmt.Errorf("%w: invalid notification settings: %s", ngmodels.ErrAlertRuleFailedValidation, err)
+		}
+	}
+	return nil
+}
+
 // updateAlertRulesInGroup calculates changes (rules to add,update,delete), verifies that the user is authorized to do the calculated changes and updates database.
 // All operations are performed in a single transaction
 func (srv RulerSrv) updateAlertRulesInGroup(c *models.ReqContext, groupKey ngmodels.AlertRuleGroupKey, rules []*ngmodels.AlertRule) response.Response {
@@ -468,21 +510,22 @@ func toGettableExtendedRuleNode(r ngmodels.AlertRule, namespaceID int64, provena
 	}
 	gettableExtendedRuleNode := apimodels.GettableExtendedRuleNode{
 		GrafanaManagedAlert: &apimodels.GettableGrafanaRule{
-			ID:              r.ID,
-			OrgID:           r.OrgID,
-			Title:           r.Title,
-			Condition:       r.Condition,
-			Data:            r.Data,
-			Updated:         r.Updated,
-			IntervalSeconds: r.IntervalSeconds,
-			Version:         r.Version,
-			UID:             r.UID,
-			NamespaceUID:    r.NamespaceUID,
-			NamespaceID:     namespaceID,
-			RuleGroup:       r.RuleGroup,
-			NoDataState:     apimodels.NoDataState(r.NoDataState),
-			ExecErrState:    apimodels.ExecutionErrorState(r.ExecErrState),
-			Provenance:      provenance,
+			ID:                   r.ID,
+			OrgID:                r.OrgID,
+			Title:                r.Title,
+			Condition:            r.Condition,
+			Data:                 r.Data,
+			Updated:              r.Updated,
+			IntervalSeconds:      r.IntervalSeconds,
+			Version:              r.Version,
+			UID:                  r.UID,
+			NamespaceUID:         r.NamespaceUID,
+			NamespaceID:          namespaceID,
+			RuleGroup:            r.RuleGroup,
+			NoDataState:          apimodels.NoDataState(r.NoDataState),
+			ExecErrState:         apimodels.ExecutionErrorState(r.ExecErrState),
+			Provenance:           provenance,
+			NotificationSettings: r.GetNotificationSettings(),
 		},
 	}
 	forDuration := model.Duration(r.For)
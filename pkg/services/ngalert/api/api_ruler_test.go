@@ -21,12 +21,14 @@ import (
 	"github.com/grafana/grafana/pkg/services/folder"
 	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
 	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/notifier"
 	"github.com/grafana/grafana/pkg/services/ngalert/provisioning"
 	"github.com/grafana/grafana/pkg/services/ngalert/schedule"
 	"github.com/grafana/grafana/pkg/services/ngalert/store"
 	"github.com/grafana/grafana/pkg/services/ngalert/tests/fakes"
 	"github.com/grafana/grafana/pkg/services/org"
 	"github.com/grafana/grafana/pkg/services/user"
+	"github.com/grafana/grafana/pkg/setting"
 	"github.com/grafana/grafana/pkg/util"
 	"github.com/grafana/grafana/pkg/web"
 )
@@ -638,6 +640,54 @@ func TestVerifyProvisionedRulesNotAffected(t *testing.T) {
 	})
 }
 
+func TestValidateNotificationSettings(t *testing.T) {
+	const orgConfig = `{
+		"alertmanager_config": {
+			"route": {"receiver": "team-a"},
+			"mute_time_intervals": [{"name": "weekends", "time_intervals": [{"weekdays": ["saturday", "sunday"]}]}],
+			"receivers": [{"name": "team-a", "grafana_managed_receiver_configs": [{"uid": "a", "name": "team-a", "type": "email", "settings": {"addresses": "a@example.com"}}]}]
+		}
+	}`
+	srv := createService(acMock.New(), fakes.NewRuleStore(t), nil)
+	srv.cfg = &setting.UnifiedAlertingSettings{DefaultConfiguration: setting.GetAlertmanagerDefaultConfiguration()}
+	configStore := notifier.NewFakeConfigStore(t, map[int64]*models.AlertConfiguration{
+		2: {OrgID: 2, AlertmanagerConfiguration: orgConfig},
+	})
+	srv.amConfigStore = &configStore
+
+	withSettings := func(settings models.NotificationSettings) []*models.AlertRule {
+		return []*models.AlertRule{
+			models.AlertRuleGen()(),
+			models.AlertRuleGen(func(rule *models.AlertRule) {
+				rule.NotificationSettings = []models.NotificationSettings{settings}
+			})(),
+		}
+	}
+
+	testCases := []struct {
+		name     string
+		orgID    int64
+		settings models.NotificationSettings
+		err      string
+	}{
+		{name: "receiver of the configuration", orgID: 2, settings: models.NotificationSettings{Receiver: "team-a", MuteTimeIntervals: []string{"weekends"}}},
+		{name: "receiver of the default configuration", orgID: 1, settings: models.NotificationSettings{Receiver: "grafana-default-email"}},
+		{name: "unknown receiver", orgID: 2, settings: models.NotificationSettings{Receiver: "grafana-default-email"}, err: "receiver 'grafana-default-email' does not exist"},
+		{name: "unknown mute timing", orgID: 2, settings: models.NotificationSettings{Receiver: "team-a", MuteTimeIntervals: []string{"holidays"}}, err: "mute time interval 'holidays' does not exist"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := srv.validateNotificationSettings(context.Background(), tc.orgID, withSettings(tc.settings))
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, models.ErrAlertRuleFailedValidation)
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
 func createServiceWithProvenanceStore(ac *acMock.Mock, store *fakes.RuleStore, scheduler schedule.ScheduleService, provenanceStore provisioning.ProvisioningStore) *RulerSrv {
 	svc := createService(ac, store, scheduler)
 	svc.provenanceStore = provenanceStore
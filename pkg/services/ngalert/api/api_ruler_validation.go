@@ -104,6 +104,13 @@ func validateRuleNode(
 		return nil, err
 	}
 
+	if ns := ruleNode.GrafanaManagedAlert.NotificationSettings; ns != nil {
+		if err := ns.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: invalid notification settings: %s", ngmodels.ErrAlertRuleFailedValidation, err)
+		}
+		newAlertRule.NotificationSettings = []ngmodels.NotificationSettings{*ns}
+	}
+
 	if ruleNode.ApiRuleNode != nil {
 		newAlertRule.Annotations = ruleNode.ApiRuleNode.Annotations
 		newAlertRule.Labels = ruleNode.ApiRuleNode.Labels
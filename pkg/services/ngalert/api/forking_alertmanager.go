@@ -167,6 +167,42 @@ func (f *AlertmanagerApiHandler) handleRouteGetGrafanaSilences(ctx *models.ReqCo
 	return f.GrafanaSvc.RouteGetSilences(ctx)
 }
 
+func (f *AlertmanagerApiHandler) handleRouteGetGrafanaAlertingConfigHistory(ctx *models.ReqContext) response.Response {
+	return f.GrafanaSvc.RouteGetAlertingConfigHistory(ctx)
+}
+
+func (f *AlertmanagerApiHandler) handleRouteGetGrafanaAlertingConfigHistoryEntry(ctx *models.ReqContext, id string) response.Response {
+	return f.GrafanaSvc.RouteGetAlertingConfigHistoryEntry(ctx, id)
+}
+
+func (f *AlertmanagerApiHandler) handleRouteGetGrafanaAlertingConfigHistoryDiff(ctx *models.ReqContext, id string) response.Response {
+	return f.GrafanaSvc.RouteGetAlertingConfigHistoryDiff(ctx, id)
+}
+
+func (f *AlertmanagerApiHandler) handleRoutePostGrafanaAlertingConfigHistoryActivate(ctx *models.ReqContext, id string) response.Response {
+	return f.GrafanaSvc.RoutePostAlertingConfigHistoryActivate(ctx, id)
+}
+
+func (f *AlertmanagerApiHandler) handleRouteGetGrafanaSilenceTemplates(ctx *models.ReqContext) response.Response {
+	return f.GrafanaSvc.RouteGetSilenceTemplates(ctx)
+}
+
+func (f *AlertmanagerApiHandler) handleRoutePostGrafanaSilenceTemplate(ctx *models.ReqContext, body apimodels.PostableSilenceTemplate) response.Response {
+	return f.GrafanaSvc.RoutePostSilenceTemplate(ctx, body)
+}
+
+func (f *AlertmanagerApiHandler) handleRoutePutGrafanaSilenceTemplate(ctx *models.ReqContext, body apimodels.PostableSilenceTemplate, uid string) response.Response {
+	return f.GrafanaSvc.RoutePutSilenceTemplate(ctx, body, uid)
+}
+
+func (f *AlertmanagerApiHandler) handleRouteDeleteGrafanaSilenceTemplate(ctx *models.ReqContext, uid string) response.Response {
+	return f.GrafanaSvc.RouteDeleteSilenceTemplate(ctx, uid)
+}
+
+func (f *AlertmanagerApiHandler) handleRoutePostApplyGrafanaSilenceTemplate(ctx *models.ReqContext, body apimodels.ApplySilenceTemplate, uid string) response.Response {
+	return f.GrafanaSvc.RoutePostApplySilenceTemplate(ctx, body, uid)
+}
+
 func (f *AlertmanagerApiHandler) handleRoutePostGrafanaAlertingConfig(ctx *models.ReqContext, conf apimodels.PostableUserConfig) response.Response {
 	if !conf.AlertmanagerConfig.ReceiverType().Can(apimodels.GrafanaReceiverType) {
 		return errorToResponse(backendTypeDoesNotMatchPayloadTypeError(apimodels.GrafanaBackend, conf.AlertmanagerConfig.ReceiverType().String()))
@@ -178,6 +214,17 @@ func (f *AlertmanagerApiHandler) handleRouteGetGrafanaReceivers(ctx *models.ReqC
 	return f.GrafanaSvc.RouteGetReceivers(ctx)
 }
 
+func (f *AlertmanagerApiHandler) handleRouteGetGrafanaNotificationDeliveries(ctx *models.ReqContext) response.Response {
+	return f.GrafanaSvc.RouteGetNotificationDeliveries(ctx)
+}
+
 func (f *AlertmanagerApiHandler) handleRoutePostTestGrafanaReceivers(ctx *models.ReqContext, conf apimodels.TestReceiversConfigBodyParams) response.Response {
 	return f.GrafanaSvc.RoutePostTestReceivers(ctx, conf)
 }
+
+func (f *AlertmanagerApiHandler) handleRoutePostTestGrafanaRoutes(ctx *models.ReqContext, conf apimodels.TestRoutesConfigBodyParams) response.Response {
+	if conf.AlertmanagerConfig != nil && !conf.AlertmanagerConfig.ReceiverType().Can(apimodels.GrafanaReceiverType) {
+		return errorToResponse(backendTypeDoesNotMatchPayloadTypeError(apimodels.GrafanaBackend, conf.AlertmanagerConfig.ReceiverType().String()))
+	}
+	return f.GrafanaSvc.RoutePostTestRoutes(ctx, conf)
+}
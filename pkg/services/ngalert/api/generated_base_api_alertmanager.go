@@ -24,6 +24,7 @@ type AlertmanagerApi interface {
 	RouteDeleteAlertingConfig(*models.ReqContext) response.Response
 	RouteDeleteGrafanaAlertingConfig(*models.ReqContext) response.Response
 	RouteDeleteGrafanaSilence(*models.ReqContext) response.Response
+	RouteDeleteGrafanaSilenceTemplate(*models.ReqContext) response.Response
 	RouteDeleteSilence(*models.ReqContext) response.Response
 	RouteGetAMAlertGroups(*models.ReqContext) response.Response
 	RouteGetAMAlerts(*models.ReqContext) response.Response
@@ -33,15 +34,25 @@ type AlertmanagerApi interface {
 	RouteGetGrafanaAMAlerts(*models.ReqContext) response.Response
 	RouteGetGrafanaAMStatus(*models.ReqContext) response.Response
 	RouteGetGrafanaAlertingConfig(*models.ReqContext) response.Response
+	RouteGetGrafanaAlertingConfigHistory(*models.ReqContext) response.Response
+	RouteGetGrafanaAlertingConfigHistoryDiff(*models.ReqContext) response.Response
+	RouteGetGrafanaAlertingConfigHistoryEntry(*models.ReqContext) response.Response
+	RouteGetGrafanaNotificationDeliveries(*models.ReqContext) response.Response
 	RouteGetGrafanaReceivers(*models.ReqContext) response.Response
 	RouteGetGrafanaSilence(*models.ReqContext) response.Response
+	RouteGetGrafanaSilenceTemplates(*models.ReqContext) response.Response
 	RouteGetGrafanaSilences(*models.ReqContext) response.Response
 	RouteGetSilence(*models.ReqContext) response.Response
 	RouteGetSilences(*models.ReqContext) response.Response
 	RoutePostAMAlerts(*models.ReqContext) response.Response
 	RoutePostAlertingConfig(*models.ReqContext) response.Response
+	RoutePostApplyGrafanaSilenceTemplate(*models.ReqContext) response.Response
 	RoutePostGrafanaAlertingConfig(*models.ReqContext) response.Response
+	RoutePostGrafanaAlertingConfigHistoryActivate(*models.ReqContext) response.Response
+	RoutePostGrafanaSilenceTemplate(*models.ReqContext) response.Response
 	RoutePostTestGrafanaReceivers(*models.ReqContext) response.Response
+	RoutePostTestGrafanaRoutes(*models.ReqContext) response.Response
+	RoutePutGrafanaSilenceTemplate(*models.ReqContext) response.Response
 }
 
 func (f *AlertmanagerApiHandler) RouteCreateGrafanaSilence(ctx *models.ReqContext) response.Response {
@@ -75,6 +86,11 @@ func (f *AlertmanagerApiHandler) RouteDeleteGrafanaSilence(ctx *models.ReqContex
 	silenceIdParam := web.Params(ctx.Req)[":SilenceId"]
 	return f.handleRouteDeleteGrafanaSilence(ctx, silenceIdParam)
 }
+func (f *AlertmanagerApiHandler) RouteDeleteGrafanaSilenceTemplate(ctx *models.ReqContext) response.Response {
+	// Parse Path Parameters
+	templateUIDParam := web.Params(ctx.Req)[":TemplateUID"]
+	return f.handleRouteDeleteGrafanaSilenceTemplate(ctx, templateUIDParam)
+}
 func (f *AlertmanagerApiHandler) RouteDeleteSilence(ctx *models.ReqContext) response.Response {
 	// Parse Path Parameters
 	silenceIdParam := web.Params(ctx.Req)[":SilenceId"]
@@ -113,6 +129,22 @@ func (f *AlertmanagerApiHandler) RouteGetGrafanaAMStatus(ctx *models.ReqContext)
 func (f *AlertmanagerApiHandler) RouteGetGrafanaAlertingConfig(ctx *models.ReqContext) response.Response {
 	return f.handleRouteGetGrafanaAlertingConfig(ctx)
 }
+func (f *AlertmanagerApiHandler) RouteGetGrafanaAlertingConfigHistory(ctx *models.ReqContext) response.Response {
+	return f.handleRouteGetGrafanaAlertingConfigHistory(ctx)
+}
+func (f *AlertmanagerApiHandler) RouteGetGrafanaAlertingConfigHistoryDiff(ctx *models.ReqContext) response.Response {
+	// Parse Path Parameters
+	idParam := web.Params(ctx.Req)[":id"]
+	return f.handleRouteGetGrafanaAlertingConfigHistoryDiff(ctx, idParam)
+}
+func (f *AlertmanagerApiHandler) RouteGetGrafanaAlertingConfigHistoryEntry(ctx *models.ReqContext) response.Response {
+	// Parse Path Parameters
+	idParam := web.Params(ctx.Req)[":id"]
+	return f.handleRouteGetGrafanaAlertingConfigHistoryEntry(ctx, idParam)
+}
+func (f *AlertmanagerApiHandler) RouteGetGrafanaNotificationDeliveries(ctx *models.ReqContext) response.Response {
+	return f.handleRouteGetGrafanaNotificationDeliveries(ctx)
+}
 func (f *AlertmanagerApiHandler) RouteGetGrafanaReceivers(ctx *models.ReqContext) response.Response {
 	return f.handleRouteGetGrafanaReceivers(ctx)
 }
@@ -121,6 +153,9 @@ func (f *AlertmanagerApiHandler) RouteGetGrafanaSilence(ctx *models.ReqContext)
 	silenceIdParam := web.Params(ctx.Req)[":SilenceId"]
 	return f.handleRouteGetGrafanaSilence(ctx, silenceIdParam)
 }
+func (f *AlertmanagerApiHandler) RouteGetGrafanaSilenceTemplates(ctx *models.ReqContext) response.Response {
+	return f.handleRouteGetGrafanaSilenceTemplates(ctx)
+}
 func (f *AlertmanagerApiHandler) RouteGetGrafanaSilences(ctx *models.ReqContext) response.Response {
 	return f.handleRouteGetGrafanaSilences(ctx)
 }
@@ -155,6 +190,16 @@ func (f *AlertmanagerApiHandler) RoutePostAlertingConfig(ctx *models.ReqContext)
 	}
 	return f.handleRoutePostAlertingConfig(ctx, conf, datasourceUIDParam)
 }
+func (f *AlertmanagerApiHandler) RoutePostApplyGrafanaSilenceTemplate(ctx *models.ReqContext) response.Response {
+	// Parse Path Parameters
+	templateUIDParam := web.Params(ctx.Req)[":TemplateUID"]
+	// Parse Request Body
+	conf := apimodels.ApplySilenceTemplate{}
+	if err := web.Bind(ctx.Req, &conf); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	return f.handleRoutePostApplyGrafanaSilenceTemplate(ctx, conf, templateUIDParam)
+}
 func (f *AlertmanagerApiHandler) RoutePostGrafanaAlertingConfig(ctx *models.ReqContext) response.Response {
 	// Parse Request Body
 	conf := apimodels.PostableUserConfig{}
@@ -163,6 +208,19 @@ func (f *AlertmanagerApiHandler) RoutePostGrafanaAlertingConfig(ctx *models.ReqC
 	}
 	return f.handleRoutePostGrafanaAlertingConfig(ctx, conf)
 }
+func (f *AlertmanagerApiHandler) RoutePostGrafanaAlertingConfigHistoryActivate(ctx *models.ReqContext) response.Response {
+	// Parse Path Parameters
+	idParam := web.Params(ctx.Req)[":id"]
+	return f.handleRoutePostGrafanaAlertingConfigHistoryActivate(ctx, idParam)
+}
+func (f *AlertmanagerApiHandler) RoutePostGrafanaSilenceTemplate(ctx *models.ReqContext) response.Response {
+	// Parse Request Body
+	conf := apimodels.PostableSilenceTemplate{}
+	if err := web.Bind(ctx.Req, &conf); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	return f.handleRoutePostGrafanaSilenceTemplate(ctx, conf)
+}
 func (f *AlertmanagerApiHandler) RoutePostTestGrafanaReceivers(ctx *models.ReqContext) response.Response {
 	// Parse Request Body
 	conf := apimodels.TestReceiversConfigBodyParams{}
@@ -171,6 +229,24 @@ func (f *AlertmanagerApiHandler) RoutePostTestGrafanaReceivers(ctx *models.ReqCo
 	}
 	return f.handleRoutePostTestGrafanaReceivers(ctx, conf)
 }
+func (f *AlertmanagerApiHandler) RoutePostTestGrafanaRoutes(ctx *models.ReqContext) response.Response {
+	// Parse Request Body
+	conf := apimodels.TestRoutesConfigBodyParams{}
+	if err := web.Bind(ctx.Req, &conf); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	return f.handleRoutePostTestGrafanaRoutes(ctx, conf)
+}
+func (f *AlertmanagerApiHandler) RoutePutGrafanaSilenceTemplate(ctx *models.ReqContext) response.Response {
+	// Parse Path Parameters
+	templateUIDParam := web.Params(ctx.Req)[":TemplateUID"]
+	// Parse Request Body
+	conf := apimodels.PostableSilenceTemplate{}
+	if err := web.Bind(ctx.Req, &conf); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	return f.handleRoutePutGrafanaSilenceTemplate(ctx, conf, templateUIDParam)
+}
 
 func (api *API) RegisterAlertmanagerApiEndpoints(srv AlertmanagerApi, m *metrics.API) {
 	api.RouteRegister.Group("", func(group routing.RouteRegister) {
@@ -224,6 +300,16 @@ func (api *API) RegisterAlertmanagerApiEndpoints(srv AlertmanagerApi, m *metrics
 				m,
 			),
 		)
+		group.Delete(
+			toMacaronPath("/api/alertmanager/grafana/config/api/v1/silence-templates/{TemplateUID}"),
+			api.authorize(http.MethodDelete, "/api/alertmanager/grafana/config/api/v1/silence-templates/{TemplateUID}"),
+			metrics.Instrument(
+				http.MethodDelete,
+				"/api/alertmanager/grafana/config/api/v1/silence-templates/{TemplateUID}",
+				srv.RouteDeleteGrafanaSilenceTemplate,
+				m,
+			),
+		)
 		group.Delete(
 			toMacaronPath("/api/alertmanager/{DatasourceUID}/api/v2/silence/{SilenceId}"),
 			api.authorize(http.MethodDelete, "/api/alertmanager/{DatasourceUID}/api/v2/silence/{SilenceId}"),
@@ -314,6 +400,46 @@ func (api *API) RegisterAlertmanagerApiEndpoints(srv AlertmanagerApi, m *metrics
 				m,
 			),
 		)
+		group.Get(
+			toMacaronPath("/api/alertmanager/grafana/config/history"),
+			api.authorize(http.MethodGet, "/api/alertmanager/grafana/config/history"),
+			metrics.Instrument(
+				http.MethodGet,
+				"/api/alertmanager/grafana/config/history",
+				srv.RouteGetGrafanaAlertingConfigHistory,
+				m,
+			),
+		)
+		group.Get(
+			toMacaronPath("/api/alertmanager/grafana/config/history/{id}/diff"),
+			api.authorize(http.MethodGet, "/api/alertmanager/grafana/config/history/{id}/diff"),
+			metrics.Instrument(
+				http.MethodGet,
+				"/api/alertmanager/grafana/config/history/{id}/diff",
+				srv.RouteGetGrafanaAlertingConfigHistoryDiff,
+				m,
+			),
+		)
+		group.Get(
+			toMacaronPath("/api/alertmanager/grafana/config/history/{id}"),
+			api.authorize(http.MethodGet, "/api/alertmanager/grafana/config/history/{id}"),
+			metrics.Instrument(
+				http.MethodGet,
+				"/api/alertmanager/grafana/config/history/{id}",
+				srv.RouteGetGrafanaAlertingConfigHistoryEntry,
+				m,
+			),
+		)
+		group.Get(
+			toMacaronPath("/api/alertmanager/grafana/config/api/v1/receivers/deliveries"),
+			api.authorize(http.MethodGet, "/api/alertmanager/grafana/config/api/v1/receivers/deliveries"),
+			metrics.Instrument(
+				http.MethodGet,
+				"/api/alertmanager/grafana/config/api/v1/receivers/deliveries",
+				srv.RouteGetGrafanaNotificationDeliveries,
+				m,
+			),
+		)
 		group.Get(
 			toMacaronPath("/api/alertmanager/grafana/config/api/v1/receivers"),
 			api.authorize(http.MethodGet, "/api/alertmanager/grafana/config/api/v1/receivers"),
@@ -334,6 +460,16 @@ func (api *API) RegisterAlertmanagerApiEndpoints(srv AlertmanagerApi, m *metrics
 				m,
 			),
 		)
+		group.Get(
+			toMacaronPath("/api/alertmanager/grafana/config/api/v1/silence-templates"),
+			api.authorize(http.MethodGet, "/api/alertmanager/grafana/config/api/v1/silence-templates"),
+			metrics.Instrument(
+				http.MethodGet,
+				"/api/alertmanager/grafana/config/api/v1/silence-templates",
+				srv.RouteGetGrafanaSilenceTemplates,
+				m,
+			),
+		)
 		group.Get(
 			toMacaronPath("/api/alertmanager/grafana/api/v2/silences"),
 			api.authorize(http.MethodGet, "/api/alertmanager/grafana/api/v2/silences"),
@@ -384,6 +520,16 @@ func (api *API) RegisterAlertmanagerApiEndpoints(srv AlertmanagerApi, m *metrics
 				m,
 			),
 		)
+		group.Post(
+			toMacaronPath("/api/alertmanager/grafana/config/api/v1/silence-templates/{TemplateUID}/apply"),
+			api.authorize(http.MethodPost, "/api/alertmanager/grafana/config/api/v1/silence-templates/{TemplateUID}/apply"),
+			metrics.Instrument(
+				http.MethodPost,
+				"/api/alertmanager/grafana/config/api/v1/silence-templates/{TemplateUID}/apply",
+				srv.RoutePostApplyGrafanaSilenceTemplate,
+				m,
+			),
+		)
 		group.Post(
 			toMacaronPath("/api/alertmanager/grafana/config/api/v1/alerts"),
 			api.authorize(http.MethodPost, "/api/alertmanager/grafana/config/api/v1/alerts"),
@@ -394,6 +540,26 @@ func (api *API) RegisterAlertmanagerApiEndpoints(srv AlertmanagerApi, m *metrics
 				m,
 			),
 		)
+		group.Post(
+			toMacaronPath("/api/alertmanager/grafana/config/history/{id}/_activate"),
+			api.authorize(http.MethodPost, "/api/alertmanager/grafana/config/history/{id}/_activate"),
+			metrics.Instrument(
+				http.MethodPost,
+				"/api/alertmanager/grafana/config/history/{id}/_activate",
+				srv.RoutePostGrafanaAlertingConfigHistoryActivate,
+				m,
+			),
+		)
+		group.Post(
+			toMacaronPath("/api/alertmanager/grafana/config/api/v1/silence-templates"),
+			api.authorize(http.MethodPost, "/api/alertmanager/grafana/config/api/v1/silence-templates"),
+			metrics.Instrument(
+				http.MethodPost,
+				"/api/alertmanager/grafana/config/api/v1/silence-templates",
+				srv.RoutePostGrafanaSilenceTemplate,
+				m,
+			),
+		)
 		group.Post(
 			toMacaronPath("/api/alertmanager/grafana/config/api/v1/receivers/test"),
 			api.authorize(http.MethodPost, "/api/alertmanager/grafana/config/api/v1/receivers/test"),
@@ -404,5 +570,25 @@ func (api *API) RegisterAlertmanagerApiEndpoints(srv AlertmanagerApi, m *metrics
 				m,
 			),
 		)
+		group.Post(
+			toMacaronPath("/api/alertmanager/grafana/config/api/v1/routes/test"),
+			api.authorize(http.MethodPost, "/api/alertmanager/grafana/config/api/v1/routes/test"),
+			metrics.Instrument(
+				http.MethodPost,
+				"/api/alertmanager/grafana/config/api/v1/routes/test",
+				srv.RoutePostTestGrafanaRoutes,
+				m,
+			),
+		)
+		group.Put(
+			toMacaronPath("/api/alertmanager/grafana/config/api/v1/silence-templates/{TemplateUID}"),
+			api.authorize(http.MethodPut, "/api/alertmanager/grafana/config/api/v1/silence-templates/{TemplateUID}"),
+			metrics.Instrument(
+				http.MethodPut,
+				"/api/alertmanager/grafana/config/api/v1/silence-templates/{TemplateUID}",
+				srv.RoutePutGrafanaSilenceTemplate,
+				m,
+			),
+		)
 	}, middleware.ReqSignedIn)
 }
@@ -21,11 +21,14 @@ import (
 type ProvisioningApi interface {
 	RouteDeleteAlertRule(*models.ReqContext) response.Response
 	RouteDeleteContactpoints(*models.ReqContext) response.Response
+	RouteDeleteInhibitionRule(*models.ReqContext) response.Response
 	RouteDeleteMuteTiming(*models.ReqContext) response.Response
 	RouteDeleteTemplate(*models.ReqContext) response.Response
 	RouteGetAlertRule(*models.ReqContext) response.Response
 	RouteGetAlertRuleGroup(*models.ReqContext) response.Response
 	RouteGetContactpoints(*models.ReqContext) response.Response
+	RouteGetInhibitionRule(*models.ReqContext) response.Response
+	RouteGetInhibitionRules(*models.ReqContext) response.Response
 	RouteGetMuteTiming(*models.ReqContext) response.Response
 	RouteGetMuteTimings(*models.ReqContext) response.Response
 	RouteGetPolicyTree(*models.ReqContext) response.Response
@@ -33,10 +36,12 @@ type ProvisioningApi interface {
 	RouteGetTemplates(*models.ReqContext) response.Response
 	RoutePostAlertRule(*models.ReqContext) response.Response
 	RoutePostContactpoints(*models.ReqContext) response.Response
+	RoutePostInhibitionRule(*models.ReqContext) response.Response
 	RoutePostMuteTiming(*models.ReqContext) response.Response
 	RoutePutAlertRule(*models.ReqContext) response.Response
 	RoutePutAlertRuleGroup(*models.ReqContext) response.Response
 	RoutePutContactpoint(*models.ReqContext) response.Response
+	RoutePutInhibitionRule(*models.ReqContext) response.Response
 	RoutePutMuteTiming(*models.ReqContext) response.Response
 	RoutePutPolicyTree(*models.ReqContext) response.Response
 	RoutePutTemplate(*models.ReqContext) response.Response
@@ -53,6 +58,11 @@ func (f *ProvisioningApiHandler) RouteDeleteContactpoints(ctx *models.ReqContext
 	uIDParam := web.Params(ctx.Req)[":UID"]
 	return f.handleRouteDeleteContactpoints(ctx, uIDParam)
 }
+func (f *ProvisioningApiHandler) RouteDeleteInhibitionRule(ctx *models.ReqContext) response.Response {
+	// Parse Path Parameters
+	uIDParam := web.Params(ctx.Req)[":UID"]
+	return f.handleRouteDeleteInhibitionRule(ctx, uIDParam)
+}
 func (f *ProvisioningApiHandler) RouteDeleteMuteTiming(ctx *models.ReqContext) response.Response {
 	// Parse Path Parameters
 	nameParam := web.Params(ctx.Req)[":name"]
@@ -77,6 +87,14 @@ func (f *ProvisioningApiHandler) RouteGetAlertRuleGroup(ctx *models.ReqContext)
 func (f *ProvisioningApiHandler) RouteGetContactpoints(ctx *models.ReqContext) response.Response {
 	return f.handleRouteGetContactpoints(ctx)
 }
+func (f *ProvisioningApiHandler) RouteGetInhibitionRule(ctx *models.ReqContext) response.Response {
+	// Parse Path Parameters
+	uIDParam := web.Params(ctx.Req)[":UID"]
+	return f.handleRouteGetInhibitionRule(ctx, uIDParam)
+}
+func (f *ProvisioningApiHandler) RouteGetInhibitionRules(ctx *models.ReqContext) response.Response {
+	return f.handleRouteGetInhibitionRules(ctx)
+}
 func (f *ProvisioningApiHandler) RouteGetMuteTiming(ctx *models.ReqContext) response.Response {
 	// Parse Path Parameters
 	nameParam := web.Params(ctx.Req)[":name"]
@@ -112,6 +130,14 @@ func (f *ProvisioningApiHandler) RoutePostContactpoints(ctx *models.ReqContext)
 	}
 	return f.handleRoutePostContactpoints(ctx, conf)
 }
+func (f *ProvisioningApiHandler) RoutePostInhibitionRule(ctx *models.ReqContext) response.Response {
+	// Parse Request Body
+	conf := apimodels.InhibitionRule{}
+	if err := web.Bind(ctx.Req, &conf); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	return f.handleRoutePostInhibitionRule(ctx, conf)
+}
 func (f *ProvisioningApiHandler) RoutePostMuteTiming(ctx *models.ReqContext) response.Response {
 	// Parse Request Body
 	conf := apimodels.MuteTimeInterval{}
@@ -151,6 +177,16 @@ func (f *ProvisioningApiHandler) RoutePutContactpoint(ctx *models.ReqContext) re
 	}
 	return f.handleRoutePutContactpoint(ctx, conf, uIDParam)
 }
+func (f *ProvisioningApiHandler) RoutePutInhibitionRule(ctx *models.ReqContext) response.Response {
+	// Parse Path Parameters
+	uIDParam := web.Params(ctx.Req)[":UID"]
+	// Parse Request Body
+	conf := apimodels.InhibitionRule{}
+	if err := web.Bind(ctx.Req, &conf); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	return f.handleRoutePutInhibitionRule(ctx, conf, uIDParam)
+}
 func (f *ProvisioningApiHandler) RoutePutMuteTiming(ctx *models.ReqContext) response.Response {
 	// Parse Path Parameters
 	nameParam := web.Params(ctx.Req)[":name"]
@@ -205,6 +241,16 @@ func (api *API) RegisterProvisioningApiEndpoints(srv ProvisioningApi, m *metrics
 				m,
 			),
 		)
+		group.Delete(
+			toMacaronPath("/api/v1/provisioning/inhibition-rules/{UID}"),
+			api.authorize(http.MethodDelete, "/api/v1/provisioning/inhibition-rules/{UID}"),
+			metrics.Instrument(
+				http.MethodDelete,
+				"/api/v1/provisioning/inhibition-rules/{UID}",
+				srv.RouteDeleteInhibitionRule,
+				m,
+			),
+		)
 		group.Delete(
 			toMacaronPath("/api/v1/provisioning/mute-timings/{name}"),
 			api.authorize(http.MethodDelete, "/api/v1/provisioning/mute-timings/{name}"),
@@ -255,6 +301,26 @@ func (api *API) RegisterProvisioningApiEndpoints(srv ProvisioningApi, m *metrics
 				m,
 			),
 		)
+		group.Get(
+			toMacaronPath("/api/v1/provisioning/inhibition-rules/{UID}"),
+			api.authorize(http.MethodGet, "/api/v1/provisioning/inhibition-rules/{UID}"),
+			metrics.Instrument(
+				http.MethodGet,
+				"/api/v1/provisioning/inhibition-rules/{UID}",
+				srv.RouteGetInhibitionRule,
+				m,
+			),
+		)
+		group.Get(
+			toMacaronPath("/api/v1/provisioning/inhibition-rules"),
+			api.authorize(http.MethodGet, "/api/v1/provisioning/inhibition-rules"),
+			metrics.Instrument(
+				http.MethodGet,
+				"/api/v1/provisioning/inhibition-rules",
+				srv.RouteGetInhibitionRules,
+				m,
+			),
+		)
 		group.Get(
 			toMacaronPath("/api/v1/provisioning/mute-timings/{name}"),
 			api.authorize(http.MethodGet, "/api/v1/provisioning/mute-timings/{name}"),
@@ -325,6 +391,16 @@ func (api *API) RegisterProvisioningApiEndpoints(srv ProvisioningApi, m *metrics
 				m,
 			),
 		)
+		group.Post(
+			toMacaronPath("/api/v1/provisioning/inhibition-rules"),
+			api.authorize(http.MethodPost, "/api/v1/provisioning/inhibition-rules"),
+			metrics.Instrument(
+				http.MethodPost,
+				"/api/v1/provisioning/inhibition-rules",
+				srv.RoutePostInhibitionRule,
+				m,
+			),
+		)
 		group.Post(
 			toMacaronPath("/api/v1/provisioning/mute-timings"),
 			api.authorize(http.MethodPost, "/api/v1/provisioning/mute-timings"),
@@ -365,6 +441,16 @@ func (api *API) RegisterProvisioningApiEndpoints(srv ProvisioningApi, m *metrics
 				m,
 			),
 		)
+		group.Put(
+			toMacaronPath("/api/v1/provisioning/inhibition-rules/{UID}"),
+			api.authorize(http.MethodPut, "/api/v1/provisioning/inhibition-rules/{UID}"),
+			metrics.Instrument(
+				http.MethodPut,
+				"/api/v1/provisioning/inhibition-rules/{UID}",
+				srv.RoutePutInhibitionRule,
+				m,
+			),
+		)
 		group.Put(
 			toMacaronPath("/api/v1/provisioning/mute-timings/{name}"),
 			api.authorize(http.MethodPut, "/api/v1/provisioning/mute-timings/{name}"),
@@ -76,6 +76,26 @@ func (f *ProvisioningApiHandler) handleRouteDeleteMuteTiming(ctx *models.ReqCont
 	return f.svc.RouteDeleteMuteTiming(ctx, name)
 }
 
+func (f *ProvisioningApiHandler) handleRouteGetInhibitionRules(ctx *models.ReqContext) response.Response {
+	return f.svc.RouteGetInhibitionRules(ctx)
+}
+
+func (f *ProvisioningApiHandler) handleRouteGetInhibitionRule(ctx *models.ReqContext, uid string) response.Response {
+	return f.svc.RouteGetInhibitionRule(ctx, uid)
+}
+
+func (f *ProvisioningApiHandler) handleRoutePostInhibitionRule(ctx *models.ReqContext, rule apimodels.InhibitionRule) response.Response {
+	return f.svc.RoutePostInhibitionRule(ctx, rule)
+}
+
+func (f *ProvisioningApiHandler) handleRoutePutInhibitionRule(ctx *models.ReqContext, rule apimodels.InhibitionRule, uid string) response.Response {
+	return f.svc.RoutePutInhibitionRule(ctx, rule, uid)
+}
+
+func (f *ProvisioningApiHandler) handleRouteDeleteInhibitionRule(ctx *models.ReqContext, uid string) response.Response {
+	return f.svc.RouteDeleteInhibitionRule(ctx, uid)
+}
+
 func (f *ProvisioningApiHandler) handleRouteGetAlertRule(ctx *models.ReqContext, UID string) response.Response {
 	return f.svc.RouteRouteGetAlertRule(ctx, UID)
 }
@@ -57,6 +57,42 @@ import (
 //       400: ValidationError
 //       404: NotFound
 
+// swagger:route GET /api/alertmanager/grafana/config/history alertmanager RouteGetGrafanaAlertingConfigHistory
+//
+// gets the stored versions of the Alerting config, the most recent first
+//
+//     Responses:
+//       200: GettableHistoricUserConfigs
+//       400: ValidationError
+
+// swagger:route GET /api/alertmanager/grafana/config/history/{id} alertmanager RouteGetGrafanaAlertingConfigHistoryEntry
+//
+// gets a stored version of the Alerting config
+//
+//     Responses:
+//       200: GettableHistoricUserConfig
+//       400: ValidationError
+//       404: NotFound
+
+// swagger:route GET /api/alertmanager/grafana/config/history/{id}/diff alertmanager RouteGetGrafanaAlertingConfigHistoryDiff
+//
+// compares a stored version of the Alerting config with another version, by default the latest one
+//
+//     Responses:
+//       200: AlertingConfigDiff
+//       400: ValidationError
+//       404: NotFound
+
+// swagger:route POST /api/alertmanager/grafana/config/history/{id}/_activate alertmanager RoutePostGrafanaAlertingConfigHistoryActivate
+//
+// restores a stored version of the Alerting config by saving it as the latest version
+//
+//     Responses:
+//       202: Ack
+//       400: ValidationError
+//       404: NotFound
+//       409: AlertManagerNotReady
+
 // swagger:route DELETE /api/alertmanager/grafana/config/api/v1/alerts alertmanager RouteDeleteGrafanaAlertingConfig
 //
 // deletes the Alerting config for a tenant
@@ -155,6 +191,65 @@ import (
 //       408: Failure
 //       409: AlertManagerNotReady
 
+// swagger:route POST /api/alertmanager/grafana/config/api/v1/routes/test alertmanager RoutePostTestGrafanaRoutes
+//
+// Test to which notification policies and contact points an alert is routed, without sending notifications.
+//
+//     Responses:
+//       200: TestRoutesResult
+//       400: ValidationError
+//       404: NotFound
+//       409: AlertManagerNotReady
+
+// swagger:route GET /api/alertmanager/grafana/config/api/v1/receivers/deliveries alertmanager RouteGetGrafanaNotificationDeliveries
+//
+// Get the delivery history of the notifications sent by Grafana managed receivers, the most recent first.
+//
+//     Responses:
+//       200: NotificationDeliveries
+//       400: ValidationError
+
+// swagger:route GET /api/alertmanager/grafana/config/api/v1/silence-templates alertmanager RouteGetGrafanaSilenceTemplates
+//
+// Get the silence templates.
+//
+//     Responses:
+//       200: SilenceTemplates
+
+// swagger:route POST /api/alertmanager/grafana/config/api/v1/silence-templates alertmanager RoutePostGrafanaSilenceTemplate
+//
+// Create a silence template.
+//
+//     Responses:
+//       201: SilenceTemplate
+//       400: ValidationError
+
+// swagger:route PUT /api/alertmanager/grafana/config/api/v1/silence-templates/{TemplateUID} alertmanager RoutePutGrafanaSilenceTemplate
+//
+// Update a silence template.
+//
+//     Responses:
+//       200: SilenceTemplate
+//       400: ValidationError
+//       404: NotFound
+
+// swagger:route DELETE /api/alertmanager/grafana/config/api/v1/silence-templates/{TemplateUID} alertmanager RouteDeleteGrafanaSilenceTemplate
+//
+// Delete a silence template.
+//
+//     Responses:
+//       200: Ack
+//       404: NotFound
+
+// swagger:route POST /api/alertmanager/grafana/config/api/v1/silence-templates/{TemplateUID}/apply alertmanager RoutePostApplyGrafanaSilenceTemplate
+//
+// Create a silence from a silence template.
+//
+//     Responses:
+//       202: postSilencesOKBody
+//       400: ValidationError
+//       404: NotFound
+
 // swagger:route GET /api/alertmanager/grafana/api/v2/silences alertmanager RouteGetGrafanaSilences
 //
 // get silences
@@ -252,6 +347,63 @@ type TestReceiversConfigAlertParams struct {
 	Labels      model.LabelSet `yaml:"labels,omitempty" json:"labels,omitempty"`
 }
 
+// swagger:parameters RoutePostTestGrafanaRoutes
+type TestRoutesConfigParams struct {
+	// in:body
+	Body TestRoutesConfigBodyParams
+}
+
+type TestRoutesConfigBodyParams struct {
+	// Labels of the alert to route. Either labels or an alert is required.
+	Labels model.LabelSet `yaml:"labels,omitempty" json:"labels,omitempty"`
+	// Alert to route. Its labels are added to the labels.
+	Alert *TestReceiversConfigAlertParams `yaml:"alert,omitempty" json:"alert,omitempty"`
+	// Time at which the mute timings and the silences are evaluated. Defaults to the current time.
+	Time *time.Time `yaml:"time,omitempty" json:"time,omitempty"`
+	// Proposed configuration to test instead of the current configuration. Its route, mute timings and
+	// inhibition rules are used.
+	AlertmanagerConfig *PostableApiAlertingConfig `yaml:"alertmanager_config,omitempty" json:"alertmanager_config,omitempty"`
+}
+
+// swagger:model
+type TestRoutesResult struct {
+	Labels model.LabelSet `json:"labels"`
+	Time   time.Time      `json:"time"`
+	// Routes that match the alert, in the order in which they are evaluated.
+	Routes []TestRouteResult `json:"routes"`
+	// Silences that match the alert at the time.
+	Silences GettableSilences `json:"silences"`
+	// Inhibition rules whose target matchers match the alert, with the firing alerts that inhibit it.
+	Inhibitions []TestInhibitionResult `json:"inhibitions"`
+	Silenced    bool                   `json:"silenced"`
+	Inhibited   bool                   `json:"inhibited"`
+}
+
+// swagger:model
+type TestRouteResult struct {
+	// Matchers of the route and its parents, from the root of the tree.
+	Path           string         `json:"path"`
+	Receiver       string         `json:"receiver"`
+	GroupBy        []string       `json:"group_by"`
+	GroupWait      model.Duration `json:"group_wait"`
+	GroupInterval  model.Duration `json:"group_interval"`
+	RepeatInterval model.Duration `json:"repeat_interval"`
+	Continue       bool           `json:"continue"`
+	// Mute timings of the route.
+	MuteTimeIntervals []string `json:"mute_time_intervals,omitempty"`
+	// Mute timings of the route that contain the time.
+	ActiveMuteTimeIntervals []string `json:"active_mute_time_intervals,omitempty"`
+	// Whether the notifications of the route are muted at the time.
+	Muted bool `json:"muted"`
+}
+
+// swagger:model
+type TestInhibitionResult struct {
+	Rule config.InhibitRule `json:"rule"`
+	// Labels of the firing alerts that inhibit the alert.
+	SourceAlerts []model.LabelSet `json:"source_alerts"`
+}
+
 // swagger:model
 type TestReceiversResult struct {
 	Alert      TestReceiversConfigAlertParams `json:"alert"`
@@ -291,6 +443,143 @@ type GetSilencesParams struct {
 	Filter []string `json:"filter"`
 }
 
+// swagger:parameters RouteGetGrafanaAlertingConfigHistory
+type AlertingConfigHistoryParams struct {
+	// Maximum number of versions to return.
+	// in:query
+	Limit int `json:"limit"`
+}
+
+// swagger:parameters RouteGetGrafanaAlertingConfigHistoryEntry RouteGetGrafanaAlertingConfigHistoryDiff RoutePostGrafanaAlertingConfigHistoryActivate
+type AlertingConfigHistoryIDParam struct {
+	// ID of the stored version of the configuration.
+	// in:path
+	ID int64 `json:"id"`
+}
+
+// swagger:parameters RouteGetGrafanaAlertingConfigHistoryDiff
+type AlertingConfigHistoryDiffParams struct {
+	// ID of the stored version to compare with. Defaults to the latest version.
+	// in:query
+	CompareTo int64 `json:"compareTo"`
+}
+
+// swagger:model
+type GettableHistoricUserConfigs []GettableHistoricUserConfig
+
+// GettableHistoricUserConfig is a stored version of the configuration. The secure settings of the receivers
+// are not returned, only whether they are set.
+//
+// swagger:model
+type GettableHistoricUserConfig struct {
+	ID                 int64                     `yaml:"id" json:"id"`
+	CreatedAt          time.Time                 `yaml:"created_at" json:"created_at"`
+	Default            bool                      `yaml:"default" json:"default"`
+	TemplateFiles      map[string]string         `yaml:"template_files" json:"template_files"`
+	AlertmanagerConfig GettableApiAlertingConfig `yaml:"alertmanager_config" json:"alertmanager_config"`
+}
+
+// swagger:model
+type AlertingConfigDiff struct {
+	From int64 `json:"from"`
+	To   int64 `json:"to"`
+	// Line-oriented diff between the two versions, empty if they are identical.
+	Diff string `json:"diff"`
+}
+
+// swagger:parameters RouteGetGrafanaNotificationDeliveries
+type NotificationDeliveriesParams struct {
+	// Only return the deliveries of this receiver.
+	// in:query
+	Receiver string `json:"receiver"`
+	// Only return the deliveries of this type of integration, such as slack or webhook.
+	// in:query
+	Integration string `json:"integration"`
+	// Only return the deliveries with this status.
+	// in:query
+	// enum: success,failed
+	Status string `json:"status"`
+	// Only return the deliveries since this time, in RFC3339 format.
+	// in:query
+	From string `json:"from"`
+	// Only return the deliveries until this time, in RFC3339 format.
+	// in:query
+	To string `json:"to"`
+	// The maximum number of deliveries to return.
+	// in:query
+	// default: 100
+	Limit int `json:"limit"`
+}
+
+// swagger:model
+type NotificationDeliveries []NotificationDelivery
+
+// swagger:model
+type NotificationDelivery struct {
+	Receiver         string    `json:"receiver"`
+	Integration      string    `json:"integration"`
+	IntegrationIndex int       `json:"integrationIndex"`
+	IntegrationUID   string    `json:"integrationUid,omitempty"`
+	GroupKey         string    `json:"groupKey"`
+	Fingerprints     []string  `json:"fingerprints"`
+	Status           string    `json:"status"`
+	StatusCode       int       `json:"statusCode,omitempty"`
+	DurationMs       int64     `json:"durationMs"`
+	Error            string    `json:"error,omitempty"`
+	Retry            bool      `json:"retry"`
+	Timestamp        time.Time `json:"timestamp"`
+}
+
+// swagger:parameters RoutePutGrafanaSilenceTemplate RouteDeleteGrafanaSilenceTemplate RoutePostApplyGrafanaSilenceTemplate
+type SilenceTemplateUIDParams struct {
+	// in:path
+	TemplateUID string
+}
+
+// swagger:parameters RoutePostGrafanaSilenceTemplate RoutePutGrafanaSilenceTemplate
+type PostableSilenceTemplateParams struct {
+	// in:body
+	Body PostableSilenceTemplate
+}
+
+// swagger:parameters RoutePostApplyGrafanaSilenceTemplate
+type ApplySilenceTemplateParams struct {
+	// in:body
+	Body ApplySilenceTemplate
+}
+
+// swagger:model
+type SilenceTemplates []SilenceTemplate
+
+// swagger:model
+type SilenceTemplate struct {
+	UID      string        `json:"uid"`
+	Name     string        `json:"name"`
+	Comment  string        `json:"comment"`
+	Matchers amv2.Matchers `json:"matchers"`
+	Updated  time.Time     `json:"updated"`
+}
+
+// swagger:model
+type PostableSilenceTemplate struct {
+	// required: true
+	Name    string `json:"name"`
+	Comment string `json:"comment"`
+	// required: true
+	Matchers amv2.Matchers `json:"matchers"`
+}
+
+// swagger:model
+type ApplySilenceTemplate struct {
+	// How long the silence lasts, for example 2h.
+	// required: true
+	Duration model.Duration `json:"duration"`
+	// When the silence starts, now if it is not set.
+	StartsAt *strfmt.DateTime `json:"startsAt,omitempty"`
+	// The comment of the silence, the comment of the template if it is not set.
+	Comment string `json:"comment,omitempty"`
+}
+
 // swagger:model
 type GettableStatus struct {
 	// cluster
@@ -637,6 +926,8 @@ func (c *GettableUserConfig) GetGrafanaReceiverMap() map[string]*GettableGrafana
 type GettableApiAlertingConfig struct {
 	Config              `yaml:",inline"`
 	MuteTimeProvenances map[string]models.Provenance `yaml:"muteTimeProvenances,omitempty" json:"muteTimeProvenances,omitempty"`
+	// InhibitRuleProvenances are the provenances of the inhibition rules by InhibitionRuleUID.
+	InhibitRuleProvenances map[string]models.Provenance `yaml:"inhibitRuleProvenances,omitempty" json:"inhibitRuleProvenances,omitempty"`
 	// Override with our superset receiver type
 	Receivers []*GettableApiReceiver `yaml:"receivers,omitempty" json:"receivers,omitempty"`
 }
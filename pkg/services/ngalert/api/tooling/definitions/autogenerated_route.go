@@ -0,0 +1,197 @@
+package definitions
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/prometheus/alertmanager/pkg/labels"
+	"github.com/prometheus/common/model"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+// ValidateNotificationSettings checks that the contact point and the mute timings of the notification settings
+// exist in the configuration.
+func (c *PostableApiAlertingConfig) ValidateNotificationSettings(s models.NotificationSettings) error {
+	if !c.hasReceiver(s.Receiver) {
+		return fmt.Errorf("receiver '%s' does not exist", s.Receiver)
+	}
+	for _, name := range s.MuteTimeIntervals {
+		if !c.hasMuteTimeInterval(name) {
+			return fmt.Errorf("mute time interval '%s' does not exist", name)
+		}
+	}
+	return nil
+}
+
+// AddAutogeneratedRoute replaces the route generated for the notification settings of the alert rules.
+// The generated route is the first child of the root route and matches the alerts labeled by
+// models.NotificationSettings.ToLabels. It has a child per contact point, which has a child per set of
+// grouping and timing options. Settings that do not pass ValidateNotificationSettings are ignored.
+func (c *PostableApiAlertingConfig) AddAutogeneratedRoute(settings []models.NotificationSettings) {
+	if c.Route == nil {
+		return
+	}
+	c.Route.Routes = withoutAutogeneratedRoute(c.Route.Routes)
+
+	byReceiver := make(map[string]map[string]models.NotificationSettings)
+	for _, s := range settings {
+		if c.ValidateNotificationSettings(s) != nil {
+			continue
+		}
+		if byReceiver[s.Receiver] == nil {
+			byReceiver[s.Receiver] = make(map[string]models.NotificationSettings)
+		}
+		byReceiver[s.Receiver][s.Fingerprint()] = s
+	}
+	if len(byReceiver) == 0 {
+		return
+	}
+
+	autogenerated := &Route{
+		Receiver:       c.Route.Receiver,
+		ObjectMatchers: ObjectMatchers{equalMatcher(models.AutogeneratedRouteLabel, "true")},
+	}
+	for _, receiver := range sortedKeys(byReceiver) {
+		receiverRoute := &Route{
+			Receiver:       receiver,
+			ObjectMatchers: ObjectMatchers{equalMatcher(models.AutogeneratedRouteReceiverNameLabel, receiver)},
+		}
+		for _, fingerprint := range sortedKeys(byReceiver[receiver]) {
+			s := byReceiver[receiver][fingerprint]
+			receiverRoute.Routes = append(receiverRoute.Routes, settingsRoute(s, fingerprint))
+		}
+		autogenerated.Routes = append(autogenerated.Routes, receiverRoute)
+	}
+	c.Route.Routes = append([]*Route{autogenerated}, c.Route.Routes...)
+}
+
+// IsAutogenerated returns true if the route is the route generated for the notification settings of the alert rules.
+func (r *Route) IsAutogenerated() bool {
+	for _, m := range r.ObjectMatchers {
+		if m.Name == models.AutogeneratedRouteLabel && m.Type == labels.MatchEqual && m.Value == "true" {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateNoAutogeneratedLabels checks that the route tree does not match on the labels reserved for the
+// generated route, except in the generated route itself.
+func (r *Route) ValidateNoAutogeneratedLabels() error {
+	for _, child := range withoutAutogeneratedRoute(r.Routes) {
+		if err := child.validateNoAutogeneratedLabels(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Route) validateNoAutogeneratedLabels() error {
+	reserved := func(name string) error {
+		switch name {
+		case models.AutogeneratedRouteLabel, models.AutogeneratedRouteReceiverNameLabel, models.AutogeneratedRouteSettingsHashLabel:
+			return fmt.Errorf("label '%s' is reserved for the routes generated for the notification settings of alert rules", name)
+		}
+		return nil
+	}
+	for _, m := range r.ObjectMatchers {
+		if err := reserved(m.Name); err != nil {
+			return err
+		}
+	}
+	for _, m := range r.Matchers {
+		if err := reserved(m.Name); err != nil {
+			return err
+		}
+	}
+	for name := range r.Match {
+		if err := reserved(name); err != nil {
+			return err
+		}
+	}
+	for name := range r.MatchRE {
+		if err := reserved(name); err != nil {
+			return err
+		}
+	}
+	for _, child := range r.Routes {
+		if err := child.validateNoAutogeneratedLabels(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WithoutAutogeneratedRoute returns a copy of the route without the generated route among its children.
+func (r Route) WithoutAutogeneratedRoute() Route {
+	r.Routes = withoutAutogeneratedRoute(r.Routes)
+	return r
+}
+
+func withoutAutogeneratedRoute(routes []*Route) []*Route {
+	result := make([]*Route, 0, len(routes))
+	for _, route := range routes {
+		if route.IsAutogenerated() {
+			continue
+		}
+		result = append(result, route)
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+func settingsRoute(s models.NotificationSettings, fingerprint string) *Route {
+	route := &Route{
+		Receiver:          s.Receiver,
+		ObjectMatchers:    ObjectMatchers{equalMatcher(models.AutogeneratedRouteSettingsHashLabel, fingerprint)},
+		GroupWait:         s.GroupWait,
+		GroupInterval:     s.GroupInterval,
+		RepeatInterval:    s.RepeatInterval,
+		MuteTimeIntervals: s.MuteTimeIntervals,
+	}
+	if len(s.GroupBy) > 0 {
+		route.GroupByStr = s.GroupBy
+		if s.GroupByAll() {
+			route.GroupByAll = true
+		} else {
+			for _, l := range s.GroupBy {
+				route.GroupBy = append(route.GroupBy, model.LabelName(l))
+			}
+		}
+	}
+	return route
+}
+
+func (c *PostableApiAlertingConfig) hasReceiver(name string) bool {
+	for _, r := range c.Receivers {
+		if r.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *PostableApiAlertingConfig) hasMuteTimeInterval(name string) bool {
+	for _, mt := range c.MuteTimeIntervals {
+		if mt.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func equalMatcher(name, value string) *labels.Matcher {
+	return &labels.Matcher{Type: labels.MatchEqual, Name: name, Value: value}
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,141 @@
+package definitions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/pkg/labels"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+func TestAddAutogeneratedRoute(t *testing.T) {
+	minute := model.Duration(time.Minute)
+
+	newConfig := func() *PostableApiAlertingConfig {
+		return &PostableApiAlertingConfig{
+			Config: Config{
+				Route: &Route{
+					Receiver: "default",
+					Routes:   []*Route{{Receiver: "team-a", ObjectMatchers: ObjectMatchers{equalMatcher("team", "a")}}},
+				},
+				MuteTimeIntervals: []config.MuteTimeInterval{{Name: "weekends"}},
+			},
+			Receivers: []*PostableApiReceiver{
+				{Receiver: config.Receiver{Name: "default"}},
+				{Receiver: config.Receiver{Name: "team-a"}},
+				{Receiver: config.Receiver{Name: "team-b"}},
+			},
+		}
+	}
+
+	t.Run("generated route is the first child of the root route", func(t *testing.T) {
+		cfg := newConfig()
+		teamB := models.NotificationSettings{Receiver: "team-b", GroupBy: []string{"..."}}
+		teamA := models.NotificationSettings{Receiver: "team-a", GroupBy: []string{"alertname"}, GroupWait: &minute, MuteTimeIntervals: []string{"weekends"}}
+		cfg.AddAutogeneratedRoute([]models.NotificationSettings{teamB, teamA, teamB})
+
+		require.Len(t, cfg.Route.Routes, 2)
+		autogenerated := cfg.Route.Routes[0]
+		require.True(t, autogenerated.IsAutogenerated())
+		require.Equal(t, "default", autogenerated.Receiver)
+		require.False(t, cfg.Route.Routes[1].IsAutogenerated())
+
+		require.Len(t, autogenerated.Routes, 2)
+		receiverA := autogenerated.Routes[0]
+		require.Equal(t, "team-a", receiverA.Receiver)
+		require.Equal(t, ObjectMatchers{equalMatcher(models.AutogeneratedRouteReceiverNameLabel, "team-a")}, receiverA.ObjectMatchers)
+		require.Equal(t, []*Route{{
+			Receiver:          "team-a",
+			ObjectMatchers:    ObjectMatchers{equalMatcher(models.AutogeneratedRouteSettingsHashLabel, teamA.Fingerprint())},
+			GroupByStr:        []string{"alertname"},
+			GroupBy:           []model.LabelName{"alertname"},
+			GroupWait:         &minute,
+			MuteTimeIntervals: []string{"weekends"},
+		}}, receiverA.Routes)
+
+		receiverB := autogenerated.Routes[1]
+		require.Equal(t, "team-b", receiverB.Receiver)
+		require.Len(t, receiverB.Routes, 1)
+		require.True(t, receiverB.Routes[0].GroupByAll)
+	})
+
+	t.Run("generated route matches the labels of the rule", func(t *testing.T) {
+		cfg := newConfig()
+		settings := models.NotificationSettings{Receiver: "team-b"}
+		cfg.AddAutogeneratedRoute([]models.NotificationSettings{settings})
+
+		lbls := model.LabelSet{"team": "a"}
+		for k, v := range settings.ToLabels() {
+			lbls[model.LabelName(k)] = model.LabelValue(v)
+		}
+		route := cfg.Route.Routes[0]
+		for route != nil {
+			var next *Route
+			for _, child := range route.Routes {
+				if matchesAll(child.ObjectMatchers, lbls) {
+					next = child
+					break
+				}
+			}
+			if next == nil {
+				break
+			}
+			route = next
+		}
+		require.Equal(t, models.AutogeneratedRouteSettingsHashLabel, route.ObjectMatchers[0].Name)
+		require.Equal(t, "team-b", route.Receiver)
+	})
+
+	t.Run("settings with unknown receiver or mute timing are ignored", func(t *testing.T) {
+		cfg := newConfig()
+		cfg.AddAutogeneratedRoute([]models.NotificationSettings{
+			{Receiver: "unknown"},
+			{Receiver: "team-a", MuteTimeIntervals: []string{"unknown"}},
+		})
+		require.Len(t, cfg.Route.Routes, 1)
+		require.False(t, cfg.Route.Routes[0].IsAutogenerated())
+	})
+
+	t.Run("existing generated route is replaced", func(t *testing.T) {
+		cfg := newConfig()
+		cfg.AddAutogeneratedRoute([]models.NotificationSettings{{Receiver: "team-a"}})
+		cfg.AddAutogeneratedRoute([]models.NotificationSettings{{Receiver: "team-b"}})
+		require.Len(t, cfg.Route.Routes, 2)
+		require.Len(t, cfg.Route.Routes[0].Routes, 1)
+		require.Equal(t, "team-b", cfg.Route.Routes[0].Routes[0].Receiver)
+
+		cfg.AddAutogeneratedRoute(nil)
+		require.Len(t, cfg.Route.Routes, 1)
+		require.False(t, cfg.Route.Routes[0].IsAutogenerated())
+	})
+}
+
+func TestValidateNoAutogeneratedLabels(t *testing.T) {
+	cfg := &PostableApiAlertingConfig{
+		Config:    Config{Route: &Route{Receiver: "default"}},
+		Receivers: []*PostableApiReceiver{{Receiver: config.Receiver{Name: "default"}}},
+	}
+	cfg.AddAutogeneratedRoute([]models.NotificationSettings{{Receiver: "default"}})
+	require.NoError(t, cfg.Route.ValidateNoAutogeneratedLabels())
+
+	cfg.Route.Routes = append(cfg.Route.Routes, &Route{
+		Receiver: "default",
+		Routes: []*Route{{
+			Receiver:       "default",
+			ObjectMatchers: ObjectMatchers{equalMatcher(models.AutogeneratedRouteReceiverNameLabel, "default")},
+		}},
+	})
+	require.EqualError(t, cfg.Route.ValidateNoAutogeneratedLabels(), "label '__grafana_receiver__' is reserved for the routes generated for the notification settings of alert rules")
+
+	withoutAutogenerated := cfg.Route.WithoutAutogeneratedRoute()
+	require.Len(t, withoutAutogenerated.Routes, 1)
+	require.Len(t, cfg.Route.Routes, 2)
+}
+
+func matchesAll(matchers ObjectMatchers, lbls model.LabelSet) bool {
+	return labels.Matchers(matchers).Matches(lbls)
+}
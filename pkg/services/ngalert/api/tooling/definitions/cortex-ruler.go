@@ -374,6 +374,8 @@ type PostableGrafanaRule struct {
 	UID          string              `json:"uid" yaml:"uid"`
 	NoDataState  NoDataState         `json:"no_data_state" yaml:"no_data_state"`
 	ExecErrState ExecutionErrorState `json:"exec_err_state" yaml:"exec_err_state"`
+	// NotificationSettings sends the alerts of the rule to a contact point without going through the notification policies.
+	NotificationSettings *models.NotificationSettings `json:"notification_settings,omitempty" yaml:"notification_settings,omitempty"`
 }
 
 // swagger:model
@@ -393,4 +395,6 @@ type GettableGrafanaRule struct {
 	NoDataState     NoDataState         `json:"no_data_state" yaml:"no_data_state"`
 	ExecErrState    ExecutionErrorState `json:"exec_err_state" yaml:"exec_err_state"`
 	Provenance      models.Provenance   `json:"provenance,omitempty" yaml:"provenance,omitempty"`
+	// NotificationSettings sends the alerts of the rule to a contact point without going through the notification policies.
+	NotificationSettings *models.NotificationSettings `json:"notification_settings,omitempty" yaml:"notification_settings,omitempty"`
 }
@@ -0,0 +1,144 @@
+package definitions
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/common/model"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+// swagger:route GET /api/v1/provisioning/inhibition-rules provisioning stable RouteGetInhibitionRules
+//
+// Get all the inhibition rules.
+//
+//     Responses:
+//       200: InhibitionRules
+
+// swagger:route GET /api/v1/provisioning/inhibition-rules/{UID} provisioning stable RouteGetInhibitionRule
+//
+// Get an inhibition rule.
+//
+//     Responses:
+//       200: InhibitionRule
+//       404: description: Not found.
+
+// swagger:route POST /api/v1/provisioning/inhibition-rules provisioning stable RoutePostInhibitionRule
+//
+// Create a new inhibition rule.
+//
+//     Consumes:
+//     - application/json
+//
+//     Responses:
+//       201: InhibitionRule
+//       400: ValidationError
+
+// swagger:route PUT /api/v1/provisioning/inhibition-rules/{UID} provisioning stable RoutePutInhibitionRule
+//
+// Replace an existing inhibition rule. The UID of the rule changes with its content.
+//
+//     Consumes:
+//     - application/json
+//
+//     Responses:
+//       202: InhibitionRule
+//       400: ValidationError
+//       404: description: Not found.
+
+// swagger:route DELETE /api/v1/provisioning/inhibition-rules/{UID} provisioning stable RouteDeleteInhibitionRule
+//
+// Delete an inhibition rule.
+//
+//     Responses:
+//       204: description: The inhibition rule was deleted successfully.
+
+// swagger:model
+type InhibitionRules []InhibitionRule
+
+// swagger:parameters RouteGetInhibitionRule RoutePutInhibitionRule RouteDeleteInhibitionRule
+type InhibitionRuleUIDParam struct {
+	// Inhibition rule UID
+	// in:path
+	UID string
+}
+
+// swagger:parameters RoutePostInhibitionRule RoutePutInhibitionRule
+type InhibitionRulePayload struct {
+	// in:body
+	Body InhibitionRule
+}
+
+// swagger:model
+type InhibitionRule struct {
+	// UID is computed from the content of the rule. It is ignored when the rule is created or updated.
+	UID                string `json:"uid,omitempty"`
+	config.InhibitRule `json:",inline" yaml:",inline"`
+	Provenance         models.Provenance `json:"provenance,omitempty"`
+}
+
+// NewInhibitionRule returns the inhibition rule with its UID.
+func NewInhibitionRule(rule config.InhibitRule, provenance models.Provenance) InhibitionRule {
+	return InhibitionRule{
+		UID:         InhibitionRuleUID(rule),
+		InhibitRule: rule,
+		Provenance:  provenance,
+	}
+}
+
+func (r *InhibitionRule) ResourceType() string {
+	return "inhibitionRule"
+}
+
+func (r *InhibitionRule) ResourceID() string {
+	return r.UID
+}
+
+// Validate checks that the rule has valid matchers for both the source and the target alerts, and valid equal labels.
+func (r *InhibitionRule) Validate() error {
+	if err := r.InhibitRule.UnmarshalYAML(func(interface{}) error { return nil }); err != nil {
+		return err
+	}
+	for k := range r.SourceMatchRE {
+		if !model.LabelName(k).IsValid() {
+			return fmt.Errorf("invalid label name %q", k)
+		}
+	}
+	for k := range r.TargetMatchRE {
+		if !model.LabelName(k).IsValid() {
+			return fmt.Errorf("invalid label name %q", k)
+		}
+	}
+	for _, m := range append(append(config.Matchers{}, r.SourceMatchers...), r.TargetMatchers...) {
+		if m == nil || !model.LabelName(m.Name).IsValid() {
+			return errors.New("invalid matcher")
+		}
+	}
+	if len(r.SourceMatch)+len(r.SourceMatchRE)+len(r.SourceMatchers) == 0 {
+		return errors.New("at least one source matcher is required")
+	}
+	if len(r.TargetMatch)+len(r.TargetMatchRE)+len(r.TargetMatchers) == 0 {
+		return errors.New("at least one target matcher is required")
+	}
+	for _, l := range r.Equal {
+		if !l.IsValid() {
+			return fmt.Errorf("invalid equal label name %q", l)
+		}
+	}
+	return nil
+}
+
+// InhibitionRuleUID returns an identifier of the inhibition rule, which is computed from its content as the
+// inhibition rules of the Alertmanager configuration have no name.
+func InhibitionRuleUID(rule config.InhibitRule) string {
+	h := fnv.New64a()
+	// The maps are encoded with sorted keys, so the encoding does not depend on the order of their elements.
+	b, _ := json.Marshal(rule)
+	_, _ = h.Write(b)
+	return strconv.FormatUint(h.Sum64(), 16)
+}
@@ -124,8 +124,11 @@ const (
 var (
 	// InternalLabelNameSet are labels that grafana automatically include as part of the labelset.
 	InternalLabelNameSet = map[string]struct{}{
-		RuleUIDLabel:      {},
-		NamespaceUIDLabel: {},
+		RuleUIDLabel:                        {},
+		NamespaceUIDLabel:                   {},
+		AutogeneratedRouteLabel:             {},
+		AutogeneratedRouteReceiverNameLabel: {},
+		AutogeneratedRouteSettingsHashLabel: {},
 	}
 	InternalAnnotationNameSet = map[string]struct{}{
 		DashboardUIDAnnotation: {},
@@ -166,6 +169,17 @@ type AlertRule struct {
 	For         time.Duration
 	Annotations map[string]string
 	Labels      map[string]string
+	// NotificationSettings route the alerts of the rule to a contact point without using the notification policy tree.
+	// There is at most one element.
+	NotificationSettings []NotificationSettings `xorm:"notification_settings"`
+}
+
+// GetNotificationSettings returns the notification settings of the rule or nil if the rule has none.
+func (alertRule *AlertRule) GetNotificationSettings() *NotificationSettings {
+	if len(alertRule.NotificationSettings) == 0 {
+		return nil
+	}
+	return &alertRule.NotificationSettings[0]
 }
 
 // GetDashboardUID returns the DashboardUID or "".
@@ -343,9 +357,10 @@ type AlertRuleVersion struct {
 	ExecErrState    ExecutionErrorState
 	// ideally this field should have been apimodels.ApiDuration
 	// but this is currently not possible because of circular dependencies
-	For         time.Duration
-	Annotations map[string]string
-	Labels      map[string]string
+	For                  time.Duration
+	Annotations          map[string]string
+	Labels               map[string]string
+	NotificationSettings []NotificationSettings `xorm:"notification_settings"`
 }
 
 // GetAlertRuleByUIDQuery is the query for retrieving/deleting an alert rule by UID and organisation ID.
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"time"
+)
+
+// NotificationDeliveryStatus is the outcome of an attempt to deliver a notification.
+type NotificationDeliveryStatus string
+
+const (
+	// NotificationDeliverySuccess is the status of a notification that was delivered.
+	NotificationDeliverySuccess NotificationDeliveryStatus = "success"
+	// NotificationDeliveryFailed is the status of a notification that could not be delivered.
+	NotificationDeliveryFailed NotificationDeliveryStatus = "failed"
+)
+
+// NotificationDelivery is an attempt of an integration of a contact point to deliver
+// a notification for a group of alerts.
+type NotificationDelivery struct {
+	ID               int64                      `xorm:"pk autoincr 'id'"`
+	OrgID            int64                      `xorm:"org_id"`
+	Receiver         string                     `xorm:"receiver"`
+	Integration      string                     `xorm:"integration"`
+	IntegrationIndex int                        `xorm:"integration_index"`
+	IntegrationUID   string                     `xorm:"integration_uid"`
+	GroupKey         string                     `xorm:"group_key"`
+	Fingerprints     []string                   `xorm:"fingerprints"`
+	Status           NotificationDeliveryStatus `xorm:"status"`
+	StatusCode       int                        `xorm:"status_code"`
+	Duration         time.Duration              `xorm:"duration"`
+	Error            string                     `xorm:"error"`
+	Retry            bool                       `xorm:"retry"`
+	CreatedAt        time.Time                  `xorm:"created_at"`
+}
+
+// A XORM interface that defines the used table for this struct.
+func (d *NotificationDelivery) TableName() string {
+	return "alert_notification_delivery"
+}
+
+// GetNotificationDeliveriesQuery is the query for the delivery history of an organization.
+// The deliveries are returned with the most recent first.
+type GetNotificationDeliveriesQuery struct {
+	OrgID       int64
+	Receiver    string
+	Integration string
+	Status      NotificationDeliveryStatus
+	From        time.Time
+	To          time.Time
+	Limit       int
+}
+
+// NotificationRetry is a notification that an integration failed to deliver and
+// that is sent again after NextAttemptAt.
+type NotificationRetry struct {
+	ID               int64     `xorm:"pk autoincr 'id'"`
+	OrgID            int64     `xorm:"org_id"`
+	Receiver         string    `xorm:"receiver"`
+	Integration      string    `xorm:"integration"`
+	IntegrationIndex int       `xorm:"integration_index"`
+	GroupKey         string    `xorm:"group_key"`
+	GroupLabels      string    `xorm:"group_labels"`
+	Alerts           string    `xorm:"alerts"`
+	Attempts         int       `xorm:"attempts"`
+	LastError        string    `xorm:"last_error"`
+	NextAttemptAt    time.Time `xorm:"next_attempt_at"`
+	CreatedAt        time.Time `xorm:"created_at"`
+}
+
+// A XORM interface that defines the used table for this struct.
+func (r *NotificationRetry) TableName() string {
+	return "alert_notification_retry"
+}
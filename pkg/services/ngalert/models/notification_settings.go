@@ -0,0 +1,111 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+
+	"github.com/prometheus/common/model"
+)
+
+const (
+	// AutogeneratedRouteLabel is the label of the alerts of the rules that have notification settings.
+	// The route generated for these rules matches this label.
+	AutogeneratedRouteLabel = "__grafana_autogenerated__"
+	// AutogeneratedRouteReceiverNameLabel is the label that contains the name of the contact point of the rule.
+	AutogeneratedRouteReceiverNameLabel = "__grafana_receiver__"
+	// AutogeneratedRouteSettingsHashLabel is the label that contains the fingerprint of the grouping and timing
+	// options of the rule, so that the rules with the same options share a route.
+	AutogeneratedRouteSettingsHashLabel = "__grafana_route_settings_hash__"
+
+	// groupByAll is the special value of group_by that disables the aggregation of the alerts.
+	groupByAll = "..."
+)
+
+// NotificationSettings are the options of a rule that sends its alerts directly to a contact point,
+// without going through the notification policy tree. A route is generated for them when the
+// configuration of the Alertmanager is applied.
+type NotificationSettings struct {
+	Receiver          string          `json:"receiver"`
+	GroupBy           []string        `json:"group_by,omitempty"`
+	GroupWait         *model.Duration `json:"group_wait,omitempty"`
+	GroupInterval     *model.Duration `json:"group_interval,omitempty"`
+	RepeatInterval    *model.Duration `json:"repeat_interval,omitempty"`
+	MuteTimeIntervals []string        `json:"mute_time_intervals,omitempty"`
+}
+
+// Validate checks that the settings have a contact point and valid grouping and timing options.
+func (s NotificationSettings) Validate() error {
+	if s.Receiver == "" {
+		return errors.New("receiver must be specified")
+	}
+	seen := make(map[string]struct{}, len(s.GroupBy))
+	for _, label := range s.GroupBy {
+		if label == groupByAll && len(s.GroupBy) > 1 {
+			return fmt.Errorf("group_by: '%s' must be the only label", groupByAll)
+		}
+		if _, ok := seen[label]; ok {
+			return fmt.Errorf("group_by: duplicated label '%s'", label)
+		}
+		seen[label] = struct{}{}
+	}
+	if s.GroupWait != nil && *s.GroupWait < 0 {
+		return errors.New("group_wait cannot be negative")
+	}
+	if s.GroupInterval != nil && *s.GroupInterval <= 0 {
+		return errors.New("group_interval must be positive")
+	}
+	if s.RepeatInterval != nil && *s.RepeatInterval <= 0 {
+		return errors.New("repeat_interval must be positive")
+	}
+	return nil
+}
+
+// GroupByAll returns true if the alerts must not be aggregated.
+func (s NotificationSettings) GroupByAll() bool {
+	return len(s.GroupBy) == 1 && s.GroupBy[0] == groupByAll
+}
+
+// Fingerprint returns a hash of the grouping and timing options. It does not depend on the contact point
+// or the order of the labels and mute timings.
+func (s NotificationSettings) Fingerprint() string {
+	h := fnv.New64a()
+	writeString := func(v string) {
+		_, _ = h.Write([]byte(v))
+		// separate the values so that ["ab", "c"] and ["a", "bc"] have different hashes
+		_, _ = h.Write([]byte{255})
+	}
+	writeStrings := func(values []string) {
+		sorted := append([]string(nil), values...)
+		sort.Strings(sorted)
+		for _, v := range sorted {
+			writeString(v)
+		}
+		writeString("")
+	}
+	writeDuration := func(d *model.Duration) {
+		if d == nil {
+			writeString("")
+			return
+		}
+		writeString(d.String())
+	}
+
+	writeStrings(s.GroupBy)
+	writeDuration(s.GroupWait)
+	writeDuration(s.GroupInterval)
+	writeDuration(s.RepeatInterval)
+	writeStrings(s.MuteTimeIntervals)
+	return strconv.FormatUint(h.Sum64(), 16)
+}
+
+// ToLabels returns the labels to add to the alerts of the rule so that they match the generated route.
+func (s NotificationSettings) ToLabels() map[string]string {
+	return map[string]string{
+		AutogeneratedRouteLabel:             "true",
+		AutogeneratedRouteReceiverNameLabel: s.Receiver,
+		AutogeneratedRouteSettingsHashLabel: s.Fingerprint(),
+	}
+}
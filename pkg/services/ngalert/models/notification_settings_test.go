@@ -0,0 +1,109 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationSettings_Validate(t *testing.T) {
+	zero := model.Duration(0)
+	minute := model.Duration(time.Minute)
+
+	testCases := []struct {
+		name        string
+		settings    NotificationSettings
+		expectedErr string
+	}{
+		{
+			name:     "valid settings",
+			settings: NotificationSettings{Receiver: "team-a", GroupBy: []string{"alertname"}, GroupWait: &zero, GroupInterval: &minute, RepeatInterval: &minute},
+		},
+		{
+			name:     "group by all",
+			settings: NotificationSettings{Receiver: "team-a", GroupBy: []string{"..."}},
+		},
+		{
+			name:        "missing receiver",
+			settings:    NotificationSettings{},
+			expectedErr: "receiver must be specified",
+		},
+		{
+			name:        "group by all with other labels",
+			settings:    NotificationSettings{Receiver: "team-a", GroupBy: []string{"alertname", "..."}},
+			expectedErr: "group_by: '...' must be the only label",
+		},
+		{
+			name:        "duplicated group by label",
+			settings:    NotificationSettings{Receiver: "team-a", GroupBy: []string{"alertname", "alertname"}},
+			expectedErr: "group_by: duplicated label 'alertname'",
+		},
+		{
+			name:        "zero group interval",
+			settings:    NotificationSettings{Receiver: "team-a", GroupInterval: &zero},
+			expectedErr: "group_interval must be positive",
+		},
+		{
+			name:        "zero repeat interval",
+			settings:    NotificationSettings{Receiver: "team-a", RepeatInterval: &zero},
+			expectedErr: "repeat_interval must be positive",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.settings.Validate()
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestNotificationSettings_Fingerprint(t *testing.T) {
+	minute := model.Duration(time.Minute)
+	hour := model.Duration(time.Hour)
+
+	settings := NotificationSettings{
+		Receiver:          "team-a",
+		GroupBy:           []string{"alertname", "cluster"},
+		GroupWait:         &minute,
+		MuteTimeIntervals: []string{"weekends", "nights"},
+	}
+
+	t.Run("does not depend on the receiver and the order of the values", func(t *testing.T) {
+		other := NotificationSettings{
+			Receiver:          "team-b",
+			GroupBy:           []string{"cluster", "alertname"},
+			GroupWait:         &minute,
+			MuteTimeIntervals: []string{"nights", "weekends"},
+		}
+		require.Equal(t, settings.Fingerprint(), other.Fingerprint())
+	})
+
+	t.Run("depends on the grouping and timing options", func(t *testing.T) {
+		fingerprints := map[string]struct{}{settings.Fingerprint(): {}}
+		for _, other := range []NotificationSettings{
+			{Receiver: "team-a", GroupBy: []string{"alertname"}, GroupWait: &minute, MuteTimeIntervals: settings.MuteTimeIntervals},
+			{Receiver: "team-a", GroupBy: settings.GroupBy, GroupWait: &hour, MuteTimeIntervals: settings.MuteTimeIntervals},
+			{Receiver: "team-a", GroupBy: settings.GroupBy, GroupInterval: &minute, MuteTimeIntervals: settings.MuteTimeIntervals},
+			{Receiver: "team-a", GroupBy: settings.GroupBy, GroupWait: &minute},
+			{Receiver: "team-a"},
+		} {
+			fingerprints[other.Fingerprint()] = struct{}{}
+		}
+		require.Len(t, fingerprints, 6)
+	})
+
+	t.Run("labels match the generated route", func(t *testing.T) {
+		require.Equal(t, map[string]string{
+			AutogeneratedRouteLabel:             "true",
+			AutogeneratedRouteReceiverNameLabel: "team-a",
+			AutogeneratedRouteSettingsHashLabel: settings.Fingerprint(),
+		}, settings.ToLabels())
+	})
+}
@@ -0,0 +1,15 @@
+package models
+
+// SchedulerMember is a Grafana instance that takes part in the evaluation of the alert rules when they are sharded
+// between the instances.
+type SchedulerMember struct {
+	ID         int64  `xorm:"pk autoincr 'id'"`
+	InstanceID string `xorm:"instance_id"`
+	// LastHeartbeat is the Unix time of the last heartbeat of the instance.
+	LastHeartbeat int64 `xorm:"last_heartbeat"`
+}
+
+// A XORM interface that defines the used table for this struct.
+func (m *SchedulerMember) TableName() string {
+	return "alert_scheduler_member"
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrSilenceTemplateNotFound      = errors.New("silence template not found")
+	ErrSilenceTemplateNameDuplicate = errors.New("a silence template with this name already exists")
+)
+
+// SilenceMatcher is a matcher of a silence template.
+type SilenceMatcher struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	IsRegex bool   `json:"isRegex"`
+	IsEqual bool   `json:"isEqual"`
+}
+
+// SilenceTemplate is a saved silence that can be applied with a duration that is chosen when it is applied.
+type SilenceTemplate struct {
+	ID       int64            `xorm:"pk autoincr 'id'"`
+	OrgID    int64            `xorm:"org_id"`
+	UID      string           `xorm:"uid"`
+	Name     string           `xorm:"name"`
+	Comment  string           `xorm:"comment"`
+	Matchers []SilenceMatcher `xorm:"matchers"`
+	Updated  time.Time        `xorm:"updated"`
+}
+
+// A XORM interface that defines the used table for this struct.
+func (t *SilenceTemplate) TableName() string {
+	return "alert_silence_template"
+}
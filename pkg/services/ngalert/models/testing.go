@@ -293,6 +293,13 @@ func CopyRule(r *AlertRule) *AlertRule {
 		}
 	}
 
+	for _, ns := range r.NotificationSettings {
+		c := ns
+		c.GroupBy = append([]string(nil), ns.GroupBy...)
+		c.MuteTimeIntervals = append([]string(nil), ns.MuteTimeIntervals...)
+		result.NotificationSettings = append(result.NotificationSettings, c)
+	}
+
 	return &result
 }
 
@@ -10,6 +10,7 @@ import (
 	"net/url"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strconv"
 	"sync"
 	"time"
@@ -56,6 +57,8 @@ const (
 	defaultResolveTimeout = 5 * time.Minute
 	// memoryAlertsGCInterval is the interval at which we'll remove resolved alerts from memory.
 	memoryAlertsGCInterval = 30 * time.Minute
+	// componentStopRetryInterval is how often a dispatcher or an inhibitor is stopped again until it returns.
+	componentStopRetryInterval = 10 * time.Millisecond
 )
 
 // How long should we keep silences and notification entries on-disk after they've served their purpose.
@@ -89,6 +92,9 @@ type ClusterPeer interface {
 type AlertingStore interface {
 	store.AlertingStore
 	store.ImageStore
+	store.NotificationDeliveryStore
+	store.NotificationSettingsStore
+	store.SilenceTemplateStore
 }
 
 type Alertmanager struct {
@@ -109,14 +115,21 @@ type Alertmanager struct {
 
 	dispatcher *dispatch.Dispatcher
 	inhibitor  *inhibit.Inhibitor
+	// dispatcherRun and inhibitorRun stop the dispatcher and the inhibitor of the current configuration.
+	dispatcherRun *componentRun
+	inhibitorRun  *componentRun
 	// wg is for dispatcher, inhibitor, silences and notifications
 	// Across configuration changes dispatcher and inhibitor are completely replaced, however, silences, notification log and alerts remain the same.
 	// stopc is used to let silences and notifications know we are done.
 	wg    sync.WaitGroup
 	stopc chan struct{}
+	// retryQueueOnce starts the retries of the failed notifications once the first configuration is applied.
+	retryQueueOnce sync.Once
 
 	silencer *silence.Silencer
 	silences *silence.Silences
+	// provisionedSilencesMtx serializes the updates of the IDs of the provisioned silences.
+	provisionedSilencesMtx sync.Mutex
 
 	receivers []*notify.Receiver
 
@@ -231,13 +244,10 @@ func (am *Alertmanager) ready() bool {
 }
 
 func (am *Alertmanager) StopAndWait() {
-	if am.dispatcher != nil {
-		am.dispatcher.Stop()
-	}
-
-	if am.inhibitor != nil {
-		am.inhibitor.Stop()
-	}
+	am.reloadConfigMtx.Lock()
+	am.dispatcherRun.stop()
+	am.inhibitorRun.stop()
+	am.reloadConfigMtx.Unlock()
 
 	am.alerts.Close()
 
@@ -263,9 +273,12 @@ func (am *Alertmanager) SaveAndApplyDefaultConfig(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	if err := am.addAutogeneratedRoute(ctx, cfg); err != nil {
+		return err
+	}
 
 	err = am.Store.SaveAlertmanagerConfigurationWithCallback(ctx, cmd, func() error {
-		if err := am.applyConfig(cfg, []byte(am.Settings.UnifiedAlerting.DefaultConfiguration)); err != nil {
+		if err := am.applyConfig(cfg, nil); err != nil {
 			return err
 		}
 		return nil
@@ -294,8 +307,13 @@ func (am *Alertmanager) SaveAndApplyConfig(ctx context.Context, cfg *apimodels.P
 		OrgID:                     am.orgID,
 	}
 
+	// The generated route is not saved, so it is added after the configuration is serialized.
+	if err := am.addAutogeneratedRoute(ctx, cfg); err != nil {
+		return err
+	}
+
 	err = am.Store.SaveAlertmanagerConfigurationWithCallback(ctx, cmd, func() error {
-		if err := am.applyConfig(cfg, rawConfig); err != nil {
+		if err := am.applyConfig(cfg, nil); err != nil {
 			return err
 		}
 		return nil
@@ -315,6 +333,10 @@ func (am *Alertmanager) ApplyConfig(dbCfg *ngmodels.AlertConfiguration) error {
 		return fmt.Errorf("failed to parse Alertmanager config: %w", err)
 	}
 
+	if err := am.addAutogeneratedRoute(context.Background(), cfg); err != nil {
+		return err
+	}
+
 	am.reloadConfigMtx.Lock()
 	defer am.reloadConfigMtx.Unlock()
 
@@ -324,6 +346,34 @@ func (am *Alertmanager) ApplyConfig(dbCfg *ngmodels.AlertConfiguration) error {
 	return nil
 }
 
+// addAutogeneratedRoute adds the route generated for the notification settings of the alert rules to the configuration.
+// The settings that reference a contact point or a mute timing that does not exist are logged and ignored.
+func (am *Alertmanager) addAutogeneratedRoute(ctx context.Context, cfg *apimodels.PostableUserConfig) error {
+	settings, err := am.Store.ListNotificationSettings(ctx, am.orgID)
+	if err != nil {
+		return fmt.Errorf("failed to get the notification settings of the alert rules: %w", err)
+	}
+
+	keys := make([]ngmodels.AlertRuleKey, 0, len(settings))
+	for key := range settings {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].UID < keys[j].UID })
+
+	var all []ngmodels.NotificationSettings
+	for _, key := range keys {
+		for _, s := range settings[key] {
+			if err := cfg.AlertmanagerConfig.ValidateNotificationSettings(s); err != nil {
+				am.logger.Warn("ignoring the notification settings of the alert rule", "rule_uid", key.UID, "error", err)
+				continue
+			}
+			all = append(all, s)
+		}
+	}
+	cfg.AlertmanagerConfig.AddAutogeneratedRoute(all)
+	return nil
+}
+
 func (am *Alertmanager) getTemplate() (*template.Template, error) {
 	am.reloadConfigMtx.RLock()
 	defer am.reloadConfigMtx.RUnlock()
@@ -408,12 +458,8 @@ func (am *Alertmanager) applyConfig(cfg *apimodels.PostableUserConfig, rawConfig
 	// Now, let's put together our notification pipeline
 	routingStage := make(notify.RoutingStage, len(integrationsMap))
 
-	if am.inhibitor != nil {
-		am.inhibitor.Stop()
-	}
-	if am.dispatcher != nil {
-		am.dispatcher.Stop()
-	}
+	am.inhibitorRun.stop()
+	am.dispatcherRun.stop()
 
 	am.inhibitor = inhibit.NewInhibitor(am.alerts, cfg.AlertmanagerConfig.InhibitRules, am.marker, am.logger)
 	am.muteTimes = am.buildMuteTimesMap(cfg.AlertmanagerConfig.MuteTimeIntervals)
@@ -439,17 +485,17 @@ func (am *Alertmanager) applyConfig(cfg *apimodels.PostableUserConfig, rawConfig
 	}
 	am.receivers = receivers
 
-	am.wg.Add(1)
-	go func() {
-		defer am.wg.Done()
-		am.dispatcher.Run()
-	}()
+	am.dispatcherRun = am.runComponent(am.dispatcher)
+	am.inhibitorRun = am.runComponent(am.inhibitor)
 
-	am.wg.Add(1)
-	go func() {
-		defer am.wg.Done()
-		am.inhibitor.Run()
-	}()
+	// Retry the notifications that failed, until the Alertmanager stops
+	am.retryQueueOnce.Do(func() {
+		am.wg.Add(1)
+		go func() {
+			defer am.wg.Done()
+			am.runRetryQueue(context.Background())
+		}()
+	})
 
 	am.config = cfg
 	am.configHash = md5.Sum(rawConfig)
@@ -483,7 +529,8 @@ func (am *Alertmanager) buildReceiverIntegrations(receiver *apimodels.PostableAp
 		if err != nil {
 			return nil, err
 		}
-		integrations = append(integrations, notify.NewIntegration(n, n, r.Type, i))
+		dn := &deliveryNotifier{NotificationChannel: n, am: am, receiver: receiver.Name, config: r, index: i}
+		integrations = append(integrations, notify.NewIntegration(dn, dn, r.Type, i))
 	}
 	return integrations, nil
 }
@@ -514,7 +561,11 @@ func (am *Alertmanager) buildReceiverIntegration(r *apimodels.PostableGrafanaRec
 			SecureSettings:        secureSettings,
 		}
 	)
-	factoryConfig, err := channels.NewFactoryConfig(cfg, am.NotificationService, am.decryptFn, tmpl, am.Store)
+	var ns notifications.Service
+	if am.NotificationService != nil {
+		ns = deliveryNotificationService{Service: am.NotificationService}
+	}
+	factoryConfig, err := channels.NewFactoryConfig(cfg, ns, am.decryptFn, tmpl, am.Store)
 	if err != nil {
 		return nil, InvalidReceiverError{
 			Receiver: r,
@@ -688,7 +739,12 @@ func (am *Alertmanager) createReceiverStage(name string, integrations []*notify.
 		var s notify.MultiStage
 		s = append(s, notify.NewWaitStage(wait))
 		s = append(s, notify.NewDedupStage(integration, notificationLog, recv))
-		s = append(s, notify.NewRetryStage(integration, name, am.stageMetrics))
+		s = append(s, &deliveryStage{
+			am:          am,
+			stage:       notify.NewRetryStage(integration, name, am.stageMetrics),
+			receiver:    name,
+			integration: integration,
+		})
 		s = append(s, notify.NewSetNotifiesStage(notificationLog, recv))
 
 		fs = append(fs, s)
@@ -723,3 +779,44 @@ func (am *Alertmanager) timeoutFunc(d time.Duration) time.Duration {
 type nilLimits struct{}
 
 func (n nilLimits) MaxNumberOfAggregationGroups() int { return 0 }
+
+// component is the dispatcher or the inhibitor, whose Stop does nothing when it is called before Run.
+type component interface {
+	Run()
+	Stop()
+}
+
+// componentRun is a component running in the background.
+type componentRun struct {
+	component component
+	done      chan struct{}
+}
+
+// runComponent runs the component in a goroutine. The goroutine gets the component rather than reading
+// the field of the Alertmanager, which is replaced by the next configuration.
+func (am *Alertmanager) runComponent(c component) *componentRun {
+	r := &componentRun{component: c, done: make(chan struct{})}
+	am.wg.Add(1)
+	go func() {
+		defer am.wg.Done()
+		defer close(r.done)
+		c.Run()
+	}()
+	return r
+}
+
+// stop stops the component and waits until it returns. It stops the component again until then,
+// in case the goroutine had not started running it yet.
+func (r *componentRun) stop() {
+	if r == nil {
+		return
+	}
+	for {
+		r.component.Stop()
+		select {
+		case <-r.done:
+			return
+		case <-time.After(componentStopRetryInterval):
+		}
+	}
+}
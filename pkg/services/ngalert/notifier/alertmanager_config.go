@@ -36,7 +36,23 @@ func (moa *MultiOrgAlertmanager) GetAlertmanagerConfiguration(ctx context.Contex
 	if err != nil {
 		return definitions.GettableUserConfig{}, fmt.Errorf("failed to get latest configuration: %w", err)
 	}
-	cfg, err := Load([]byte(query.Result.AlertmanagerConfiguration))
+	result, err := moa.gettableUserConfigFromStored(query.Result)
+	if err != nil {
+		return definitions.GettableUserConfig{}, err
+	}
+
+	result, err = moa.mergeProvenance(ctx, result, org)
+	if err != nil {
+		return definitions.GettableUserConfig{}, err
+	}
+
+	return result, nil
+}
+
+// gettableUserConfigFromStored returns the stored configuration without the values of the secure settings of the
+// receivers, which are replaced by whether they are set.
+func (moa *MultiOrgAlertmanager) gettableUserConfigFromStored(stored *models.AlertConfiguration) (definitions.GettableUserConfig, error) {
+	cfg, err := Load([]byte(stored.AlertmanagerConfiguration))
 	if err != nil {
 		return definitions.GettableUserConfig{}, fmt.Errorf("failed to unmarshal alertmanager configuration: %w", err)
 	}
@@ -81,11 +97,6 @@ func (moa *MultiOrgAlertmanager) GetAlertmanagerConfiguration(ctx context.Contex
 		result.AlertmanagerConfig.Receivers = append(result.AlertmanagerConfig.Receivers, &gettableApiReceiver)
 	}
 
-	result, err = moa.mergeProvenance(ctx, result, org)
-	if err != nil {
-		return definitions.GettableUserConfig{}, err
-	}
-
 	return result, nil
 }
 
@@ -99,6 +110,15 @@ func (moa *MultiOrgAlertmanager) ApplyAlertmanagerConfiguration(ctx context.Cont
 		}
 	}
 
+	if route := config.AlertmanagerConfig.Route; route != nil {
+		// The route generated for the notification settings of the alert rules is managed by Grafana.
+		withoutAutogenerated := route.WithoutAutogeneratedRoute()
+		if err := withoutAutogenerated.ValidateNoAutogeneratedLabels(); err != nil {
+			return AlertmanagerConfigRejectedError{err}
+		}
+		config.AlertmanagerConfig.Route = &withoutAutogenerated
+	}
+
 	if err := moa.Crypto.LoadSecureSettings(ctx, org, config.AlertmanagerConfig.Receivers); err != nil {
 		return err
 	}
@@ -159,5 +179,12 @@ func (moa *MultiOrgAlertmanager) mergeProvenance(ctx context.Context, config def
 	}
 	config.AlertmanagerConfig.MuteTimeProvenances = mtProvs
 
+	ir := definitions.InhibitionRule{}
+	irProvs, err := moa.ProvStore.GetProvenances(ctx, org, ir.ResourceType())
+	if err != nil {
+		return definitions.GettableUserConfig{}, err
+	}
+	config.AlertmanagerConfig.InhibitRuleProvenances = irProvs
+
 	return config, nil
 }
@@ -0,0 +1,145 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	diff "github.com/yudai/gojsondiff"
+	"github.com/yudai/gojsondiff/formatter"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+// GetAlertmanagerConfigurationHistory returns the stored versions of the configuration of the organization, the most
+// recent first. At most limit versions are returned if limit is greater than 0.
+func (moa *MultiOrgAlertmanager) GetAlertmanagerConfigurationHistory(ctx context.Context, org int64, limit int) (definitions.GettableHistoricUserConfigs, error) {
+	history, err := moa.configStore.GetAlertmanagerConfigurationHistory(ctx, org, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get configuration history: %w", err)
+	}
+
+	result := make(definitions.GettableHistoricUserConfigs, 0, len(history))
+	for _, stored := range history {
+		cfg, err := moa.historicUserConfigFromStored(stored)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, cfg)
+	}
+	return result, nil
+}
+
+// GetHistoricalAlertmanagerConfiguration returns the stored version of the configuration with the ID.
+func (moa *MultiOrgAlertmanager) GetHistoricalAlertmanagerConfiguration(ctx context.Context, org int64, id int64) (definitions.GettableHistoricUserConfig, error) {
+	stored, err := moa.configStore.GetAlertmanagerConfigurationByID(ctx, org, id)
+	if err != nil {
+		return definitions.GettableHistoricUserConfig{}, fmt.Errorf("failed to get configuration %d: %w", id, err)
+	}
+	return moa.historicUserConfigFromStored(stored)
+}
+
+// DiffAlertmanagerConfigurations compares two stored versions of the configuration. If to is 0, the version with the
+// ID from is compared with the latest version. The secure settings are not part of the diff.
+func (moa *MultiOrgAlertmanager) DiffAlertmanagerConfigurations(ctx context.Context, org int64, from, to int64) (definitions.AlertingConfigDiff, error) {
+	if to == 0 {
+		query := models.GetLatestAlertmanagerConfigurationQuery{OrgID: org}
+		if err := moa.configStore.GetLatestAlertmanagerConfiguration(ctx, &query); err != nil {
+			return definitions.AlertingConfigDiff{}, fmt.Errorf("failed to get latest configuration: %w", err)
+		}
+		to = query.Result.ID
+	}
+
+	left, err := moa.GetHistoricalAlertmanagerConfiguration(ctx, org, from)
+	if err != nil {
+		return definitions.AlertingConfigDiff{}, err
+	}
+	right, err := moa.GetHistoricalAlertmanagerConfiguration(ctx, org, to)
+	if err != nil {
+		return definitions.AlertingConfigDiff{}, err
+	}
+
+	result := definitions.AlertingConfigDiff{From: from, To: to}
+	result.Diff, err = diffHistoricUserConfigs(left, right)
+	if err != nil {
+		return definitions.AlertingConfigDiff{}, fmt.Errorf("failed to compare configurations: %w", err)
+	}
+	return result, nil
+}
+
+// ActivateHistoricalConfiguration saves the stored version of the configuration with the ID as the latest version
+// and applies it. The secure settings of the stored version are already encrypted, so they are saved as they are.
+func (moa *MultiOrgAlertmanager) ActivateHistoricalConfiguration(ctx context.Context, org int64, id int64) error {
+	stored, err := moa.configStore.GetAlertmanagerConfigurationByID(ctx, org, id)
+	if err != nil {
+		return fmt.Errorf("failed to get configuration %d: %w", id, err)
+	}
+	cfg, err := Load([]byte(stored.AlertmanagerConfiguration))
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal alertmanager configuration: %w", err)
+	}
+
+	am, err := moa.AlertmanagerFor(org)
+	if err != nil {
+		// It's okay if the alertmanager isn't ready yet, we're changing its config anyway.
+		if !errors.Is(err, ErrAlertmanagerNotReady) {
+			return err
+		}
+	}
+
+	if err := am.SaveAndApplyConfig(ctx, cfg); err != nil {
+		moa.logger.Error("unable to save and apply historical alertmanager configuration", "id", id, "error", err)
+		return AlertmanagerConfigRejectedError{err}
+	}
+	moa.logger.Info("historical alertmanager configuration activated", "org", org, "id", id)
+	return nil
+}
+
+func (moa *MultiOrgAlertmanager) historicUserConfigFromStored(stored *models.AlertConfiguration) (definitions.GettableHistoricUserConfig, error) {
+	cfg, err := moa.gettableUserConfigFromStored(stored)
+	if err != nil {
+		return definitions.GettableHistoricUserConfig{}, err
+	}
+	return definitions.GettableHistoricUserConfig{
+		ID:                 stored.ID,
+		CreatedAt:          time.Unix(stored.CreatedAt, 0).UTC(),
+		Default:            stored.Default,
+		TemplateFiles:      cfg.TemplateFiles,
+		AlertmanagerConfig: cfg.AlertmanagerConfig,
+	}, nil
+}
+
+// diffHistoricUserConfigs returns a line-oriented diff of the JSON representation of the configurations, or an empty
+// string if they are identical.
+func diffHistoricUserConfigs(left, right definitions.GettableHistoricUserConfig) (string, error) {
+	encode := func(c definitions.GettableHistoricUserConfig) ([]byte, error) {
+		return json.Marshal(struct {
+			TemplateFiles      map[string]string                     `json:"template_files"`
+			AlertmanagerConfig definitions.GettableApiAlertingConfig `json:"alertmanager_config"`
+		}{c.TemplateFiles, c.AlertmanagerConfig})
+	}
+	leftBytes, err := encode(left)
+	if err != nil {
+		return "", err
+	}
+	rightBytes, err := encode(right)
+	if err != nil {
+		return "", err
+	}
+
+	d, err := diff.New().Compare(leftBytes, rightBytes)
+	if err != nil {
+		return "", err
+	}
+	if !d.Modified() {
+		return "", nil
+	}
+	var leftObject map[string]interface{}
+	if err := json.Unmarshal(leftBytes, &leftObject); err != nil {
+		return "", err
+	}
+	return formatter.NewAsciiFormatter(leftObject, formatter.AsciiFormatterConfig{}).Format(d)
+}
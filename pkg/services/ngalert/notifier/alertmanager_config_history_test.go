@@ -0,0 +1,115 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/provisioning"
+	"github.com/grafana/grafana/pkg/services/ngalert/store"
+	"github.com/grafana/grafana/pkg/services/secrets/fakes"
+	secretsManager "github.com/grafana/grafana/pkg/services/secrets/manager"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+func TestMultiOrgAlertmanager_ConfigurationHistory(t *testing.T) {
+	ctx := context.Background()
+	configStore := &FakeConfigStore{
+		configs: map[int64]*models.AlertConfiguration{},
+	}
+	orgStore := &FakeOrgStore{
+		orgs: []int64{1},
+	}
+	secretsService := secretsManager.SetupTestService(t, fakes.NewFakeSecretsStore())
+	m := metrics.NewNGAlert(prometheus.NewPedanticRegistry())
+	cfg := &setting.Cfg{
+		DataPath: t.TempDir(),
+		UnifiedAlerting: setting.UnifiedAlertingSettings{
+			AlertmanagerConfigPollInterval: 3 * time.Minute,
+			DefaultConfiguration:           setting.GetAlertmanagerDefaultConfiguration(),
+		},
+	}
+	mam, err := NewMultiOrgAlertmanager(cfg, configStore, orgStore, NewFakeKVStore(t), provisioning.NewFakeProvisioningStore(),
+		secretsService.GetDecryptedValue, m.GetMultiOrgAlertmanagerMetrics(), nil, log.New("testlogger"), secretsService)
+	require.NoError(t, err)
+	require.NoError(t, mam.LoadAndSyncAlertmanagersForOrgs(ctx))
+
+	var slackConfig definitions.PostableUserConfig
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"alertmanager_config": {
+			"route": {"receiver": "slack"},
+			"receivers": [{
+				"name": "slack",
+				"grafana_managed_receiver_configs": [{
+					"name": "slack",
+					"type": "slack",
+					"settings": {"recipient": "#alerts"},
+					"secureSettings": {"url": "https://hooks.slack.com/services/secret"}
+				}]
+			}]
+		}
+	}`), &slackConfig))
+	require.NoError(t, mam.ApplyAlertmanagerConfiguration(ctx, 1, slackConfig))
+
+	history, err := mam.GetAlertmanagerConfigurationHistory(ctx, 1, 0)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	defaultID, slackID := history[1].ID, history[0].ID
+	require.True(t, history[1].Default)
+	slackReceiver := history[0].AlertmanagerConfig.Receivers[0].GrafanaManagedReceivers[0]
+	require.Equal(t, map[string]bool{"url": true}, slackReceiver.SecureFields)
+
+	t.Run("history should be limited", func(t *testing.T) {
+		limited, err := mam.GetAlertmanagerConfigurationHistory(ctx, 1, 1)
+		require.NoError(t, err)
+		require.Len(t, limited, 1)
+		require.Equal(t, slackID, limited[0].ID)
+	})
+
+	t.Run("unknown versions should not be found", func(t *testing.T) {
+		_, err := mam.GetHistoricalAlertmanagerConfiguration(ctx, 1, slackID+100)
+		require.ErrorIs(t, err, store.ErrNoAlertmanagerConfiguration)
+		_, err = mam.GetHistoricalAlertmanagerConfiguration(ctx, 2, slackID)
+		require.ErrorIs(t, err, store.ErrNoAlertmanagerConfiguration)
+	})
+
+	t.Run("diff should compare with the latest version by default", func(t *testing.T) {
+		d, err := mam.DiffAlertmanagerConfigurations(ctx, 1, defaultID, 0)
+		require.NoError(t, err)
+		require.Equal(t, slackID, d.To)
+		require.Contains(t, d.Diff, "slack")
+		require.NotContains(t, d.Diff, "hooks.slack.com")
+
+		d, err = mam.DiffAlertmanagerConfigurations(ctx, 1, slackID, slackID)
+		require.NoError(t, err)
+		require.Empty(t, d.Diff)
+	})
+
+	t.Run("activating a version should save it as the latest version", func(t *testing.T) {
+		require.NoError(t, mam.ActivateHistoricalConfiguration(ctx, 1, defaultID))
+		d, err := mam.DiffAlertmanagerConfigurations(ctx, 1, defaultID, 0)
+		require.NoError(t, err)
+		require.NotEqual(t, defaultID, d.To)
+		require.Empty(t, d.Diff)
+
+		// The secure settings must not be encrypted again.
+		require.NoError(t, mam.ActivateHistoricalConfiguration(ctx, 1, slackID))
+		latest, err := Load([]byte(configStore.configs[1].AlertmanagerConfiguration))
+		require.NoError(t, err)
+		url, err := mam.Crypto.getDecryptedSecret(latest.AlertmanagerConfig.Receivers[0].GrafanaManagedReceivers[0], "url")
+		require.NoError(t, err)
+		require.Equal(t, "https://hooks.slack.com/services/secret", url)
+
+		history, err := mam.GetAlertmanagerConfigurationHistory(ctx, 1, 0)
+		require.NoError(t, err)
+		require.Len(t, history, 4)
+	})
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/grafana/grafana/pkg/infra/log"
 	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
 	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
 	"github.com/grafana/grafana/pkg/services/ngalert/store"
 	secretsManager "github.com/grafana/grafana/pkg/services/secrets/manager"
 	"github.com/grafana/grafana/pkg/setting"
@@ -416,3 +417,48 @@ func TestSilenceCleanup(t *testing.T) {
 		return len(found) == 2
 	}, 6*time.Second, 150*time.Millisecond)
 }
+
+func TestApplyConfigWithNotificationSettings(t *testing.T) {
+	const config = `{
+		"alertmanager_config": {
+			"route": {"receiver": "default"},
+			"receivers": [
+				{"name": "default", "grafana_managed_receivers": [{"uid": "default", "name": "default", "type": "email", "settings": {"addresses": "<example@email.com>"}}]},
+				{"name": "team-a", "grafana_managed_receivers": [{"uid": "team-a", "name": "team-a", "type": "email", "settings": {"addresses": "<example@email.com>"}}]}
+			]
+		}
+	}`
+
+	configStore := &FakeConfigStore{configs: map[int64]*ngmodels.AlertConfiguration{}}
+	cfg := &setting.Cfg{DataPath: t.TempDir(), AppURL: "http://localhost:3000"}
+	decryptFn := func(_ context.Context, _ map[string][]byte, _ string, fallback string) string { return fallback }
+	am, err := newAlertmanager(context.Background(), 1, cfg, configStore, NewFakeKVStore(t), &NilPeer{}, decryptFn, nil,
+		metrics.NewAlertmanagerMetrics(prometheus.NewRegistry()))
+	require.NoError(t, err)
+
+	dbCfg := &ngmodels.AlertConfiguration{AlertmanagerConfiguration: config}
+	require.NoError(t, am.ApplyConfig(dbCfg))
+	require.Empty(t, am.config.AlertmanagerConfig.Route.Routes)
+
+	// Changes of the notification settings of the rules are applied even if the configuration does not change.
+	configStore.notificationSettings = map[int64]map[ngmodels.AlertRuleKey][]ngmodels.NotificationSettings{
+		1: {
+			{OrgID: 1, UID: "rule-1"}: {{Receiver: "team-a"}},
+			{OrgID: 1, UID: "rule-2"}: {{Receiver: "unknown"}},
+		},
+	}
+	require.NoError(t, am.ApplyConfig(dbCfg))
+	routes := am.config.AlertmanagerConfig.Route.Routes
+	require.Len(t, routes, 1)
+	require.True(t, routes[0].IsAutogenerated())
+	require.Len(t, routes[0].Routes, 1)
+	require.Equal(t, "team-a", routes[0].Routes[0].Receiver)
+
+	alert := &types.Alert{Alert: model.Alert{Labels: model.LabelSet{"alertname": "test"}}}
+	for k, v := range (ngmodels.NotificationSettings{Receiver: "team-a"}).ToLabels() {
+		alert.Labels[model.LabelName(k)] = model.LabelValue(v)
+	}
+	matched := am.route.Match(alert.Labels)
+	require.Len(t, matched, 1)
+	require.Equal(t, "team-a", matched[0].RouteOpts.Receiver)
+}
@@ -55,6 +55,7 @@ var receiverFactories = map[string]func(FactoryConfig) (NotificationChannel, err
 	"googlechat":              GoogleChatFactory,
 	"kafka":                   KafkaFactory,
 	"line":                    LineFactory,
+	"mqtt":                    MqttFactory,
 	"opsgenie":                OpsgenieFactory,
 	"pagerduty":               PagerdutyFactory,
 	"pushover":                PushoverFactory,
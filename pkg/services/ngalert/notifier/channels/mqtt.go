@@ -0,0 +1,306 @@
+package channels
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/common/model"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/models"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+const (
+	// MQTTMessageFormatJSON sends the alert data and the templated message as a JSON object.
+	MQTTMessageFormatJSON = "json"
+	// MQTTMessageFormatText sends only the templated message.
+	MQTTMessageFormatText = "text"
+
+	// mqttTimeout is the maximum time to connect to the broker and to publish a message.
+	mqttTimeout = 30 * time.Second
+	// mqttDisconnectQuiesce is the time in milliseconds given to the client to complete
+	// the in-flight work before disconnecting.
+	mqttDisconnectQuiesce = 250
+)
+
+var mqttSchemes = map[string]struct{}{
+	"tcp": {}, "mqtt": {}, "ssl": {}, "tls": {}, "mqtts": {}, "ws": {}, "wss": {},
+}
+
+// MqttNotifier is responsible for publishing
+// alert notifications to a MQTT broker.
+type MqttNotifier struct {
+	*Base
+	log      log.Logger
+	images   ImageStore
+	tmpl     *template.Template
+	orgID    int64
+	settings mqttSettings
+}
+
+type mqttSettings struct {
+	BrokerURL     string
+	ClientID      string
+	Topic         string
+	MessageFormat string
+	Title         string
+	Message       string
+	Username      string
+	Password      string
+	QoS           byte
+	Retain        bool
+	TLSConfig     *tls.Config
+}
+
+func buildMqttSettings(fc FactoryConfig) (mqttSettings, error) {
+	settings := mqttSettings{}
+	rawSettings := struct {
+		BrokerURL          string      `json:"brokerUrl,omitempty" yaml:"brokerUrl,omitempty"`
+		ClientID           string      `json:"clientId,omitempty" yaml:"clientId,omitempty"`
+		Topic              string      `json:"topic,omitempty" yaml:"topic,omitempty"`
+		MessageFormat      string      `json:"messageFormat,omitempty" yaml:"messageFormat,omitempty"`
+		Title              string      `json:"title,omitempty" yaml:"title,omitempty"`
+		Message            string      `json:"message,omitempty" yaml:"message,omitempty"`
+		Username           string      `json:"username,omitempty" yaml:"username,omitempty"`
+		Password           string      `json:"password,omitempty" yaml:"password,omitempty"`
+		QoS                json.Number `json:"qos,omitempty" yaml:"qos,omitempty"`
+		Retain             bool        `json:"retain,omitempty" yaml:"retain,omitempty"`
+		InsecureSkipVerify bool        `json:"insecureSkipVerify,omitempty" yaml:"insecureSkipVerify,omitempty"`
+		TLSCACert          string      `json:"tlsCACert,omitempty" yaml:"tlsCACert,omitempty"`
+		TLSClientCert      string      `json:"tlsClientCert,omitempty" yaml:"tlsClientCert,omitempty"`
+		TLSClientKey       string      `json:"tlsClientKey,omitempty" yaml:"tlsClientKey,omitempty"`
+	}{}
+
+	err := fc.Config.unmarshalSettings(&rawSettings)
+	if err != nil {
+		return settings, fmt.Errorf("failed to unmarshal settings: %w", err)
+	}
+
+	if rawSettings.BrokerURL == "" {
+		return settings, errors.New("required field 'brokerUrl' is not specified")
+	}
+	u, err := url.Parse(rawSettings.BrokerURL)
+	if err != nil {
+		return settings, fmt.Errorf("invalid broker URL: %w", err)
+	}
+	if _, ok := mqttSchemes[u.Scheme]; !ok {
+		return settings, fmt.Errorf("unsupported broker URL scheme '%s'", u.Scheme)
+	}
+	settings.BrokerURL = rawSettings.BrokerURL
+
+	if rawSettings.Topic == "" {
+		return settings, errors.New("required field 'topic' is not specified")
+	}
+	settings.Topic = rawSettings.Topic
+	settings.ClientID = rawSettings.ClientID
+
+	switch rawSettings.MessageFormat {
+	case "":
+		settings.MessageFormat = MQTTMessageFormatJSON
+	case MQTTMessageFormatJSON, MQTTMessageFormatText:
+		settings.MessageFormat = rawSettings.MessageFormat
+	default:
+		return settings, fmt.Errorf("invalid message format '%s', expected '%s' or '%s'", rawSettings.MessageFormat, MQTTMessageFormatJSON, MQTTMessageFormatText)
+	}
+
+	settings.Title = rawSettings.Title
+	if settings.Title == "" {
+		settings.Title = DefaultMessageTitleEmbed
+	}
+	settings.Message = rawSettings.Message
+	if settings.Message == "" {
+		settings.Message = DefaultMessageEmbed
+	}
+
+	if rawSettings.QoS != "" {
+		qos, err := strconv.Atoi(rawSettings.QoS.String())
+		if err != nil || qos < 0 || qos > 2 {
+			return settings, fmt.Errorf("invalid QoS '%s', expected 0, 1 or 2", rawSettings.QoS)
+		}
+		settings.QoS = byte(qos)
+	}
+	settings.Retain = rawSettings.Retain
+
+	settings.Username = rawSettings.Username
+	settings.Password = fc.DecryptFunc(context.Background(), fc.Config.SecureSettings, "password", rawSettings.Password)
+
+	caCert := fc.DecryptFunc(context.Background(), fc.Config.SecureSettings, "tlsCACert", rawSettings.TLSCACert)
+	clientCert := fc.DecryptFunc(context.Background(), fc.Config.SecureSettings, "tlsClientCert", rawSettings.TLSClientCert)
+	clientKey := fc.DecryptFunc(context.Background(), fc.Config.SecureSettings, "tlsClientKey", rawSettings.TLSClientKey)
+	if rawSettings.InsecureSkipVerify || caCert != "" || clientCert != "" || clientKey != "" {
+		settings.TLSConfig, err = buildTLSConfig(rawSettings.InsecureSkipVerify, caCert, clientCert, clientKey)
+		if err != nil {
+			return settings, err
+		}
+	}
+
+	return settings, nil
+}
+
+func MqttFactory(fc FactoryConfig) (NotificationChannel, error) {
+	notifier, err := newMqttNotifier(fc)
+	if err != nil {
+		return nil, receiverInitError{
+			Reason: err.Error(),
+			Cfg:    *fc.Config,
+		}
+	}
+	return notifier, nil
+}
+
+// newMqttNotifier is the constructor for the MQTT notifier.
+func newMqttNotifier(fc FactoryConfig) (*MqttNotifier, error) {
+	settings, err := buildMqttSettings(fc)
+	if err != nil {
+		return nil, err
+	}
+	return &MqttNotifier{
+		Base: NewBase(&models.AlertNotification{
+			Uid:                   fc.Config.UID,
+			Name:                  fc.Config.Name,
+			Type:                  fc.Config.Type,
+			DisableResolveMessage: fc.Config.DisableResolveMessage,
+			Settings:              fc.Config.Settings,
+		}),
+		orgID:    fc.Config.OrgID,
+		log:      log.New("alerting.notifier.mqtt"),
+		images:   fc.ImageStore,
+		tmpl:     fc.Template,
+		settings: settings,
+	}, nil
+}
+
+// MqttMessage defines the JSON object published to the MQTT broker.
+type MqttMessage struct {
+	*ExtendedData
+
+	GroupKey string `json:"groupKey"`
+	OrgID    int64  `json:"orgId"`
+	Title    string `json:"title"`
+	State    string `json:"state"`
+	Message  string `json:"message"`
+}
+
+// Notify publishes the alerts to the MQTT broker.
+func (n *MqttNotifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	groupKey, err := notify.ExtractGroupKey(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	var tmplErr error
+	tmpl, data := TmplText(ctx, n.tmpl, as, n.log, &tmplErr)
+
+	topic := tmpl(n.settings.Topic)
+	if tmplErr != nil {
+		return false, fmt.Errorf("failed to template MQTT topic: %w", tmplErr)
+	}
+	if topic == "" {
+		return false, errors.New("the templated MQTT topic is empty")
+	}
+
+	var payload []byte
+	switch n.settings.MessageFormat {
+	case MQTTMessageFormatText:
+		payload = []byte(tmpl(n.settings.Message))
+	default:
+		_ = withStoredImages(ctx, n.log, n.images,
+			func(index int, image ngmodels.Image) error {
+				if len(image.URL) != 0 {
+					data.Alerts[index].ImageURL = image.URL
+				}
+				return nil
+			},
+			as...)
+
+		msg := &MqttMessage{
+			ExtendedData: data,
+			GroupKey:     groupKey.String(),
+			OrgID:        n.orgID,
+			Title:        tmpl(n.settings.Title),
+			Message:      tmpl(n.settings.Message),
+		}
+		if types.Alerts(as...).Status() == model.AlertFiring {
+			msg.State = string(models.AlertStateAlerting)
+		} else {
+			msg.State = string(models.AlertStateOK)
+		}
+		payload, err = json.Marshal(msg)
+		if err != nil {
+			return false, err
+		}
+	}
+	if tmplErr != nil {
+		n.log.Warn("failed to template MQTT message", "error", tmplErr.Error())
+	}
+
+	if err := n.publish(ctx, topic, payload); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// publish connects to the broker, publishes the payload and disconnects.
+func (n *MqttNotifier) publish(ctx context.Context, topic string, payload []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, mqttTimeout)
+	defer cancel()
+	deadline, _ := ctx.Deadline()
+	timeout := time.Until(deadline)
+
+	clientID := n.settings.ClientID
+	if clientID == "" {
+		clientID = "grafana-" + util.GenerateShortUID()
+	}
+	opts := mqtt.NewClientOptions().
+		AddBroker(n.settings.BrokerURL).
+		SetClientID(clientID).
+		SetCleanSession(true).
+		SetAutoReconnect(false).
+		SetConnectRetry(false).
+		SetConnectTimeout(timeout).
+		SetWriteTimeout(timeout)
+	if n.settings.Username != "" {
+		opts.SetUsername(n.settings.Username)
+		opts.SetPassword(n.settings.Password)
+	}
+	if n.settings.TLSConfig != nil {
+		opts.SetTLSConfig(n.settings.TLSConfig)
+	}
+
+	client := mqtt.NewClient(opts)
+	if err := waitMqttToken(ctx, client.Connect()); err != nil {
+		return fmt.Errorf("failed to connect to MQTT broker: %w", err)
+	}
+	defer client.Disconnect(mqttDisconnectQuiesce)
+
+	if err := waitMqttToken(ctx, client.Publish(topic, n.settings.QoS, n.settings.Retain, payload)); err != nil {
+		return fmt.Errorf("failed to publish MQTT message: %w", err)
+	}
+	n.log.Debug("published MQTT message", "topic", topic, "qos", n.settings.QoS, "retain", n.settings.Retain)
+	return nil
+}
+
+func waitMqttToken(ctx context.Context, token mqtt.Token) error {
+	select {
+	case <-token.Done():
+		return token.Error()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (n *MqttNotifier) SendResolved() bool {
+	return !n.GetDisableResolveMessage()
+}
@@ -0,0 +1,334 @@
+package channels
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/eclipse/paho.mqtt.golang/packets"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+)
+
+func TestMqttNotifier(t *testing.T) {
+	tmpl := templateForTests(t)
+
+	externalURL, err := url.Parse("http://localhost")
+	require.NoError(t, err)
+	tmpl.ExternalURL = externalURL
+
+	broker := newTestMqttBroker(t)
+
+	firingAlert := &types.Alert{
+		Alert: model.Alert{
+			Labels:      model.LabelSet{"alertname": "alert1", "lbl1": "val1"},
+			Annotations: model.LabelSet{"ann1": "annv1", "__alertImageToken__": "test-image-1"},
+		},
+	}
+
+	cases := []struct {
+		name         string
+		settings     string
+		alerts       []*types.Alert
+		expMessage   testMqttMessage
+		expJSON      string
+		expInitError string
+	}{
+		{
+			name:     "JSON message with default settings",
+			settings: `{"brokerUrl": "tcp://` + broker.addr + `", "topic": "grafana/alerts"}`,
+			alerts:   []*types.Alert{firingAlert},
+			expMessage: testMqttMessage{
+				Topic: "grafana/alerts",
+			},
+			expJSON: `{
+				"receiver": "",
+				"status": "firing",
+				"alerts": [
+					{
+						"status": "firing",
+						"labels": {"alertname": "alert1", "lbl1": "val1"},
+						"annotations": {"ann1": "annv1"},
+						"startsAt": "0001-01-01T00:00:00Z",
+						"endsAt": "0001-01-01T00:00:00Z",
+						"generatorURL": "",
+						"fingerprint": "fac0861a85de433a",
+						"silenceURL": "http://localhost/alerting/silence/new?alertmanager=grafana&matcher=alertname%3Dalert1&matcher=lbl1%3Dval1",
+						"imageURL": "https://www.example.com/test-image-1.jpg",
+						"dashboardURL": "",
+						"panelURL": "",
+						"values": null,
+						"valueString": ""
+					}
+				],
+				"groupLabels": {"alertname": ""},
+				"commonLabels": {"alertname": "alert1", "lbl1": "val1"},
+				"commonAnnotations": {"ann1": "annv1"},
+				"externalURL": "http://localhost",
+				"groupKey": "alertname",
+				"orgId": 1,
+				"title": "[FIRING:1]  (val1)",
+				"state": "alerting",
+				"message": "**Firing**\n\nValue: [no value]\nLabels:\n - alertname = alert1\n - lbl1 = val1\nAnnotations:\n - ann1 = annv1\nSilence: http://localhost/alerting/silence/new?alertmanager=grafana&matcher=alertname%3Dalert1&matcher=lbl1%3Dval1\n"
+			}`,
+		},
+		{
+			name: "Text message with templated topic, QoS and retain",
+			settings: `{
+				"brokerUrl": "mqtt://` + broker.addr + `",
+				"clientId": "grafana-test",
+				"topic": "grafana/{{ .CommonLabels.alertname }}",
+				"messageFormat": "text",
+				"message": "{{ len .Alerts.Firing }} firing",
+				"username": "user",
+				"password": "pass",
+				"qos": 2,
+				"retain": true
+			}`,
+			alerts: []*types.Alert{firingAlert},
+			expMessage: testMqttMessage{
+				ClientID: "grafana-test",
+				Username: "user",
+				Password: "pass",
+				Topic:    "grafana/alert1",
+				Payload:  "1 firing",
+				QoS:      2,
+				Retain:   true,
+			},
+		},
+		{
+			name: "QoS 1",
+			settings: `{
+				"brokerUrl": "tcp://` + broker.addr + `",
+				"topic": "grafana/alerts",
+				"messageFormat": "text",
+				"message": "hello",
+				"qos": "1"
+			}`,
+			alerts: []*types.Alert{firingAlert},
+			expMessage: testMqttMessage{
+				Topic:   "grafana/alerts",
+				Payload: "hello",
+				QoS:     1,
+			},
+		},
+		{
+			name:         "Broker URL missing",
+			settings:     `{"topic": "grafana/alerts"}`,
+			expInitError: `required field 'brokerUrl' is not specified`,
+		},
+		{
+			name:         "Unsupported broker URL scheme",
+			settings:     `{"brokerUrl": "http://localhost:1883", "topic": "grafana/alerts"}`,
+			expInitError: `unsupported broker URL scheme 'http'`,
+		},
+		{
+			name:         "Topic missing",
+			settings:     `{"brokerUrl": "tcp://localhost:1883"}`,
+			expInitError: `required field 'topic' is not specified`,
+		},
+		{
+			name:         "Invalid message format",
+			settings:     `{"brokerUrl": "tcp://localhost:1883", "topic": "grafana/alerts", "messageFormat": "xml"}`,
+			expInitError: `invalid message format 'xml', expected 'json' or 'text'`,
+		},
+		{
+			name:         "Invalid QoS",
+			settings:     `{"brokerUrl": "tcp://localhost:1883", "topic": "grafana/alerts", "qos": 3}`,
+			expInitError: `invalid QoS '3', expected 0, 1 or 2`,
+		},
+		{
+			name:         "Invalid CA certificate",
+			settings:     `{"brokerUrl": "ssl://localhost:8883", "topic": "grafana/alerts", "tlsCACert": "not a certificate"}`,
+			expInitError: `failed to parse the CA certificate`,
+		},
+		{
+			name:         "Client certificate without key",
+			settings:     `{"brokerUrl": "ssl://localhost:8883", "topic": "grafana/alerts", "tlsClientCert": "not a certificate"}`,
+			expInitError: `failed to load the client certificate: tls: failed to find any PEM data in certificate input`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			settingsJSON, err := simplejson.NewJson([]byte(c.settings))
+			require.NoError(t, err)
+
+			fc := FactoryConfig{
+				Config: &NotificationChannelConfig{
+					OrgID:          1,
+					Name:           "mqtt_testing",
+					Type:           "mqtt",
+					Settings:       settingsJSON,
+					SecureSettings: map[string][]byte{},
+				},
+				ImageStore:  newFakeImageStore(1),
+				DecryptFunc: func(_ context.Context, _ map[string][]byte, _ string, fallback string) string { return fallback },
+				Template:    tmpl,
+			}
+
+			n, err := newMqttNotifier(fc)
+			if c.expInitError != "" {
+				require.Error(t, err)
+				require.Equal(t, c.expInitError, err.Error())
+				return
+			}
+			require.NoError(t, err)
+
+			ctx := notify.WithGroupKey(context.Background(), "alertname")
+			ctx = notify.WithGroupLabels(ctx, model.LabelSet{"alertname": ""})
+
+			ok, err := n.Notify(ctx, c.alerts...)
+			require.NoError(t, err)
+			require.True(t, ok)
+
+			var msg testMqttMessage
+			select {
+			case msg = <-broker.messages:
+			case <-time.After(5 * time.Second):
+				t.Fatal("the broker did not receive the message")
+			}
+
+			if c.expJSON != "" {
+				require.JSONEq(t, c.expJSON, msg.Payload)
+				msg.Payload = ""
+			}
+			if c.expMessage.ClientID == "" {
+				require.Regexp(t, "^grafana-", msg.ClientID)
+				msg.ClientID = ""
+			}
+			require.Equal(t, c.expMessage, msg)
+		})
+	}
+}
+
+func TestMqttNotifier_BrokerUnavailable(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+	require.NoError(t, l.Close())
+
+	tmpl := templateForTests(t)
+	externalURL, err := url.Parse("http://localhost")
+	require.NoError(t, err)
+	tmpl.ExternalURL = externalURL
+
+	settingsJSON, err := simplejson.NewJson([]byte(`{"brokerUrl": "tcp://` + addr + `", "topic": "grafana/alerts"}`))
+	require.NoError(t, err)
+	n, err := newMqttNotifier(FactoryConfig{
+		Config: &NotificationChannelConfig{
+			Name:           "mqtt_testing",
+			Type:           "mqtt",
+			Settings:       settingsJSON,
+			SecureSettings: map[string][]byte{},
+		},
+		ImageStore:  &UnavailableImageStore{},
+		DecryptFunc: func(_ context.Context, _ map[string][]byte, _ string, fallback string) string { return fallback },
+		Template:    tmpl,
+	})
+	require.NoError(t, err)
+
+	ctx := notify.WithGroupKey(context.Background(), "alertname")
+	ok, err := n.Notify(ctx, &types.Alert{Alert: model.Alert{Labels: model.LabelSet{"alertname": "alert1"}}})
+	require.False(t, ok)
+	require.ErrorContains(t, err, "failed to connect to MQTT broker")
+}
+
+// testMqttMessage is a message received by the test broker.
+type testMqttMessage struct {
+	ClientID string
+	Username string
+	Password string
+	Topic    string
+	Payload  string
+	QoS      byte
+	Retain   bool
+}
+
+// testMqttBroker is a minimal MQTT 3.1.1 broker that accepts every connection
+// and records the published messages.
+type testMqttBroker struct {
+	addr     string
+	messages chan testMqttMessage
+}
+
+func newTestMqttBroker(t *testing.T) *testMqttBroker {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = l.Close() })
+
+	b := &testMqttBroker{
+		addr:     l.Addr().String(),
+		messages: make(chan testMqttMessage, 10),
+	}
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go b.serve(conn)
+		}
+	}()
+	return b
+}
+
+func (b *testMqttBroker) serve(conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+
+	var connect *packets.ConnectPacket
+	for {
+		p, err := packets.ReadPacket(conn)
+		if err != nil {
+			return
+		}
+
+		var reply packets.ControlPacket
+		switch p := p.(type) {
+		case *packets.ConnectPacket:
+			connect = p
+			reply = packets.NewControlPacket(packets.Connack)
+		case *packets.PublishPacket:
+			b.messages <- testMqttMessage{
+				ClientID: connect.ClientIdentifier,
+				Username: connect.Username,
+				Password: string(connect.Password),
+				Topic:    p.TopicName,
+				Payload:  string(p.Payload),
+				QoS:      p.Qos,
+				Retain:   p.Retain,
+			}
+			switch p.Qos {
+			case 1:
+				ack := packets.NewControlPacket(packets.Puback).(*packets.PubackPacket)
+				ack.MessageID = p.MessageID
+				reply = ack
+			case 2:
+				rec := packets.NewControlPacket(packets.Pubrec).(*packets.PubrecPacket)
+				rec.MessageID = p.MessageID
+				reply = rec
+			}
+		case *packets.PubrelPacket:
+			comp := packets.NewControlPacket(packets.Pubcomp).(*packets.PubcompPacket)
+			comp.MessageID = p.MessageID
+			reply = comp
+		case *packets.PingreqPacket:
+			reply = packets.NewControlPacket(packets.Pingresp)
+		case *packets.DisconnectPacket:
+			return
+		}
+
+		if reply != nil {
+			if err := reply.Write(conn); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -187,6 +188,29 @@ func (c NotificationChannelConfig) unmarshalSettings(v interface{}) error {
 	return nil
 }
 
+// buildTLSConfig returns the TLS configuration of a client that trusts the PEM encoded CA certificate
+// and presents the PEM encoded client certificate, when they are not empty.
+func buildTLSConfig(insecureSkipVerify bool, caCert, clientCert, clientKey string) (*tls.Config, error) {
+	cfg := &tls.Config{
+		InsecureSkipVerify: insecureSkipVerify, //nolint:gosec
+	}
+	if caCert != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(caCert)) {
+			return nil, errors.New("failed to parse the CA certificate")
+		}
+		cfg.RootCAs = pool
+	}
+	if clientCert != "" || clientKey != "" {
+		cert, err := tls.X509KeyPair([]byte(clientCert), []byte(clientKey))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return cfg, nil
+}
+
 type httpCfg struct {
 	body     []byte
 	user     string
@@ -2,11 +2,16 @@ package channels
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/prometheus/alertmanager/notify"
 	"github.com/prometheus/alertmanager/template"
@@ -19,6 +24,18 @@ import (
 	"github.com/grafana/grafana/pkg/services/notifications"
 )
 
+const (
+	// defaultWebhookHMACHeader is the header of the HMAC signature when the settings do not have one.
+	defaultWebhookHMACHeader = "X-Grafana-Alerting-Signature"
+)
+
+// webhookMethods are the HTTP methods supported by the webhook notifier.
+var webhookMethods = map[string]struct{}{
+	http.MethodPost:  {},
+	http.MethodPut:   {},
+	http.MethodPatch: {},
+}
+
 // WebhookNotifier is responsible for sending
 // alert notifications as webhooks.
 type WebhookNotifier struct {
@@ -44,6 +61,19 @@ type webhookSettings struct {
 
 	Title   string
 	Message string
+
+	// Custom request. The values of the headers and the body are templates. When the body
+	// is empty, the notifier sends the default JSON payload.
+	HTTPHeaders map[string]string
+	Body        string
+	ContentType string
+
+	// HMAC-SHA256 signature of the body.
+	HMACSecret          string
+	HMACHeader          string
+	HMACTimestampHeader string
+
+	TLSConfig *tls.Config
 }
 
 func buildWebhookSettings(factoryConfig FactoryConfig) (webhookSettings, error) {
@@ -58,6 +88,17 @@ func buildWebhookSettings(factoryConfig FactoryConfig) (webhookSettings, error)
 		Password                 string      `json:"password,omitempty" yaml:"password,omitempty"`
 		Title                    string      `json:"title,omitempty" yaml:"title,omitempty"`
 		Message                  string      `json:"message,omitempty" yaml:"message,omitempty"`
+
+		HTTPHeaders         map[string]string `json:"httpHeaders,omitempty" yaml:"httpHeaders,omitempty"`
+		Body                string            `json:"body,omitempty" yaml:"body,omitempty"`
+		ContentType         string            `json:"contentType,omitempty" yaml:"contentType,omitempty"`
+		HMACSecret          string            `json:"hmacSecret,omitempty" yaml:"hmacSecret,omitempty"`
+		HMACHeader          string            `json:"hmacHeader,omitempty" yaml:"hmacHeader,omitempty"`
+		HMACTimestampHeader string            `json:"hmacTimestampHeader,omitempty" yaml:"hmacTimestampHeader,omitempty"`
+		InsecureSkipVerify  bool              `json:"insecureSkipVerify,omitempty" yaml:"insecureSkipVerify,omitempty"`
+		TLSCACert           string            `json:"tlsCACert,omitempty" yaml:"tlsCACert,omitempty"`
+		TLSClientCert       string            `json:"tlsClientCert,omitempty" yaml:"tlsClientCert,omitempty"`
+		TLSClientKey        string            `json:"tlsClientKey,omitempty" yaml:"tlsClientKey,omitempty"`
 	}{}
 
 	err := factoryConfig.Config.unmarshalSettings(&rawSettings)
@@ -73,6 +114,13 @@ func buildWebhookSettings(factoryConfig FactoryConfig) (webhookSettings, error)
 		rawSettings.HTTPMethod = http.MethodPost
 	}
 	settings.HTTPMethod = rawSettings.HTTPMethod
+	// The method can be a template, in which case it is validated when the notification is sent.
+	if !strings.Contains(settings.HTTPMethod, "{{") {
+		settings.HTTPMethod = strings.ToUpper(settings.HTTPMethod)
+		if _, ok := webhookMethods[settings.HTTPMethod]; !ok {
+			return settings, fmt.Errorf("unsupported HTTP method '%s', expected POST, PUT or PATCH", rawSettings.HTTPMethod)
+		}
+	}
 
 	if rawSettings.MaxAlerts != "" {
 		settings.MaxAlerts, _ = strconv.Atoi(rawSettings.MaxAlerts.String())
@@ -96,6 +144,29 @@ func buildWebhookSettings(factoryConfig FactoryConfig) (webhookSettings, error)
 	if settings.Message == "" {
 		settings.Message = DefaultMessageEmbed
 	}
+
+	settings.HTTPHeaders = rawSettings.HTTPHeaders
+	settings.Body = rawSettings.Body
+	settings.ContentType = rawSettings.ContentType
+
+	settings.HMACSecret = factoryConfig.DecryptFunc(context.Background(), factoryConfig.Config.SecureSettings, "hmacSecret", rawSettings.HMACSecret)
+	if settings.HMACSecret != "" {
+		settings.HMACHeader = rawSettings.HMACHeader
+		if settings.HMACHeader == "" {
+			settings.HMACHeader = defaultWebhookHMACHeader
+		}
+		settings.HMACTimestampHeader = rawSettings.HMACTimestampHeader
+	}
+
+	caCert := factoryConfig.DecryptFunc(context.Background(), factoryConfig.Config.SecureSettings, "tlsCACert", rawSettings.TLSCACert)
+	clientCert := factoryConfig.DecryptFunc(context.Background(), factoryConfig.Config.SecureSettings, "tlsClientCert", rawSettings.TLSClientCert)
+	clientKey := factoryConfig.DecryptFunc(context.Background(), factoryConfig.Config.SecureSettings, "tlsClientKey", rawSettings.TLSClientKey)
+	if rawSettings.InsecureSkipVerify || caCert != "" || clientCert != "" || clientKey != "" {
+		settings.TLSConfig, err = buildTLSConfig(rawSettings.InsecureSkipVerify, caCert, clientCert, clientKey)
+		if err != nil {
+			return settings, err
+		}
+	}
 	return settings, err
 }
 
@@ -189,28 +260,61 @@ func (wn *WebhookNotifier) Notify(ctx context.Context, as ...*types.Alert) (bool
 		tmplErr = nil
 	}
 
-	body, err := json.Marshal(msg)
-	if err != nil {
-		return false, err
+	var body []byte
+	if wn.settings.Body != "" {
+		body = []byte(tmpl(wn.settings.Body))
+		if tmplErr != nil {
+			return false, fmt.Errorf("failed to template webhook body: %w", tmplErr)
+		}
+	} else {
+		body, err = json.Marshal(msg)
+		if err != nil {
+			return false, err
+		}
 	}
 
 	headers := make(map[string]string)
 	if wn.settings.AuthorizationScheme != "" && wn.settings.AuthorizationCredentials != "" {
 		headers["Authorization"] = fmt.Sprintf("%s %s", wn.settings.AuthorizationScheme, wn.settings.AuthorizationCredentials)
 	}
+	for k, v := range wn.settings.HTTPHeaders {
+		headers[k] = tmpl(v)
+	}
+	if tmplErr != nil {
+		return false, fmt.Errorf("failed to template webhook headers: %w", tmplErr)
+	}
+
+	if wn.settings.HMACSecret != "" {
+		timestamp := ""
+		if wn.settings.HMACTimestampHeader != "" {
+			timestamp = strconv.FormatInt(timeNow().Unix(), 10)
+			headers[wn.settings.HMACTimestampHeader] = timestamp
+		}
+		headers[wn.settings.HMACHeader] = signWebhookPayload(wn.settings.HMACSecret, timestamp, body)
+	}
 
 	parsedURL := tmpl(wn.settings.URL)
 	if tmplErr != nil {
 		return false, tmplErr
 	}
 
+	method := strings.ToUpper(strings.TrimSpace(tmpl(wn.settings.HTTPMethod)))
+	if tmplErr != nil {
+		return false, fmt.Errorf("failed to template webhook HTTP method: %w", tmplErr)
+	}
+	if _, ok := webhookMethods[method]; !ok {
+		return false, fmt.Errorf("unsupported HTTP method '%s', expected POST, PUT or PATCH", method)
+	}
+
 	cmd := &models.SendWebhookSync{
-		Url:        parsedURL,
-		User:       wn.settings.User,
-		Password:   wn.settings.Password,
-		Body:       string(body),
-		HttpMethod: wn.settings.HTTPMethod,
-		HttpHeader: headers,
+		Url:         parsedURL,
+		User:        wn.settings.User,
+		Password:    wn.settings.Password,
+		Body:        string(body),
+		HttpMethod:  method,
+		HttpHeader:  headers,
+		ContentType: wn.settings.ContentType,
+		TLSConfig:   wn.settings.TLSConfig,
 	}
 
 	if err := wn.ns.SendWebhookSync(ctx, cmd); err != nil {
@@ -220,6 +324,18 @@ func (wn *WebhookNotifier) Notify(ctx context.Context, as ...*types.Alert) (bool
 	return true, nil
 }
 
+// signWebhookPayload returns the hex encoded HMAC-SHA256 of the body. When the timestamp is not
+// empty, the signed message is the timestamp and the body separated by a colon, so receivers can
+// reject replayed requests.
+func signWebhookPayload(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	if timestamp != "" {
+		_, _ = mac.Write([]byte(timestamp + ":"))
+	}
+	_, _ = mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
 func truncateAlerts(maxAlerts int, alerts []*types.Alert) ([]*types.Alert, int) {
 	if maxAlerts > 0 && len(alerts) > maxAlerts {
 		return alerts[:maxAlerts], len(alerts) - maxAlerts
@@ -2,10 +2,16 @@ package channels
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/url"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/grafana/grafana/pkg/components/simplejson"
 	"github.com/grafana/grafana/pkg/services/secrets/fakes"
@@ -393,3 +399,174 @@ func TestWebhookNotifier(t *testing.T) {
 		})
 	}
 }
+
+func TestWebhookNotifier_CustomRequest(t *testing.T) {
+	tmpl := templateForTests(t)
+
+	externalURL, err := url.Parse("http://localhost")
+	require.NoError(t, err)
+	tmpl.ExternalURL = externalURL
+
+	defer mockTimeNow(time.Unix(1700000000, 0))()
+
+	alerts := []*types.Alert{
+		{
+			Alert: model.Alert{
+				Labels:      model.LabelSet{"alertname": "alert1", "team": "ops"},
+				Annotations: model.LabelSet{"summary": "CPU is high"},
+			},
+		},
+	}
+	body := `{"project": "{{ .CommonLabels.team }}", "summary": "{{ (index .Alerts 0).Annotations.summary }}", "count": {{ len .Alerts.Firing }}}`
+	expBody := `{"project": "ops", "summary": "CPU is high", "count": 1}`
+
+	cases := []struct {
+		name         string
+		settings     string
+		expInitError string
+		expMsgError  string
+		expMethod    string
+		expURL       string
+		expHeaders   map[string]string
+		expBody      string
+		expTLS       bool
+	}{
+		{
+			name: "Templated method, URL, headers and body",
+			settings: `{
+				"url": "http://localhost/tickets/{{ .CommonLabels.team }}",
+				"httpMethod": "{{ if eq .Status \"firing\" }}put{{ else }}patch{{ end }}",
+				"httpHeaders": {"X-Team": "{{ .CommonLabels.team }}", "X-Static": "static"},
+				"contentType": "application/vnd.ticket+json",
+				"body": ` + strconv.Quote(body) + `
+			}`,
+			expMethod:  http.MethodPut,
+			expURL:     "http://localhost/tickets/ops",
+			expHeaders: map[string]string{"X-Team": "ops", "X-Static": "static"},
+			expBody:    expBody,
+		},
+		{
+			name: "HMAC signature of the body",
+			settings: `{
+				"url": "http://localhost/hook",
+				"hmacSecret": "secret",
+				"body": ` + strconv.Quote(body) + `
+			}`,
+			expMethod:  http.MethodPost,
+			expURL:     "http://localhost/hook",
+			expHeaders: map[string]string{defaultWebhookHMACHeader: hmacForTest("secret", "", expBody)},
+			expBody:    expBody,
+		},
+		{
+			name: "HMAC signature with timestamp in custom headers",
+			settings: `{
+				"url": "http://localhost/hook",
+				"httpMethod": "PATCH",
+				"hmacSecret": "secret",
+				"hmacHeader": "X-Signature",
+				"hmacTimestampHeader": "X-Timestamp",
+				"body": ` + strconv.Quote(body) + `
+			}`,
+			expMethod: http.MethodPatch,
+			expURL:    "http://localhost/hook",
+			expHeaders: map[string]string{
+				"X-Signature": hmacForTest("secret", "1700000000", expBody),
+				"X-Timestamp": "1700000000",
+			},
+			expBody: expBody,
+		},
+		{
+			name: "TLS configuration",
+			settings: `{
+				"url": "https://localhost/hook",
+				"insecureSkipVerify": true,
+				"body": ` + strconv.Quote(body) + `
+			}`,
+			expMethod:  http.MethodPost,
+			expURL:     "https://localhost/hook",
+			expHeaders: map[string]string{},
+			expBody:    expBody,
+			expTLS:     true,
+		},
+		{
+			name:         "Unsupported HTTP method",
+			settings:     `{"url": "http://localhost/hook", "httpMethod": "DELETE"}`,
+			expInitError: `unsupported HTTP method 'DELETE', expected POST, PUT or PATCH`,
+		},
+		{
+			name:        "Unsupported templated HTTP method",
+			settings:    `{"url": "http://localhost/hook", "httpMethod": "{{ .CommonLabels.team }}"}`,
+			expMsgError: `unsupported HTTP method 'OPS', expected POST, PUT or PATCH`,
+		},
+		{
+			name:        "Invalid body template",
+			settings:    `{"url": "http://localhost/hook", "body": "{{ .Missing }}"}`,
+			expMsgError: `failed to template webhook body: template: :1:3: executing "" at <.Missing>: can't evaluate field Missing in type *channels.ExtendedData`,
+		},
+		{
+			name:         "Invalid CA certificate",
+			settings:     `{"url": "https://localhost/hook", "tlsCACert": "not a certificate"}`,
+			expInitError: `failed to parse the CA certificate`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			settingsJSON, err := simplejson.NewJson([]byte(c.settings))
+			require.NoError(t, err)
+
+			webhookSender := mockNotificationService()
+			fc := FactoryConfig{
+				Config: &NotificationChannelConfig{
+					OrgID:          1,
+					Name:           "webhook_testing",
+					Type:           "webhook",
+					Settings:       settingsJSON,
+					SecureSettings: map[string][]byte{},
+				},
+				NotificationService: webhookSender,
+				DecryptFunc:         func(_ context.Context, _ map[string][]byte, _ string, fallback string) string { return fallback },
+				ImageStore:          &UnavailableImageStore{},
+				Template:            tmpl,
+			}
+
+			pn, err := buildWebhookNotifier(fc)
+			if c.expInitError != "" {
+				require.EqualError(t, err, c.expInitError)
+				return
+			}
+			require.NoError(t, err)
+
+			ctx := notify.WithGroupKey(context.Background(), "alertname")
+			ctx = notify.WithGroupLabels(ctx, model.LabelSet{"alertname": ""})
+			ok, err := pn.Notify(ctx, alerts...)
+			if c.expMsgError != "" {
+				require.False(t, ok)
+				require.EqualError(t, err, c.expMsgError)
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, ok)
+
+			require.Equal(t, c.expMethod, webhookSender.Webhook.HttpMethod)
+			require.Equal(t, c.expURL, webhookSender.Webhook.Url)
+			require.Equal(t, c.expHeaders, webhookSender.Webhook.HttpHeader)
+			require.JSONEq(t, c.expBody, webhookSender.Webhook.Body)
+			if c.expTLS {
+				require.NotNil(t, webhookSender.Webhook.TLSConfig)
+				require.True(t, webhookSender.Webhook.TLSConfig.InsecureSkipVerify)
+			} else {
+				require.Nil(t, webhookSender.Webhook.TLSConfig)
+			}
+		})
+	}
+}
+
+func hmacForTest(secret, timestamp, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	if timestamp != "" {
+		mac.Write([]byte(timestamp + ":"))
+	}
+	mac.Write([]byte(body))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -687,7 +687,7 @@ func GetAvailableNotifiers() []*NotifierPlugin {
 		{
 			Type:        "webhook",
 			Name:        "Webhook",
-			Description: "Sends HTTP POST request to a URL",
+			Description: "Sends HTTP request to a URL",
 			Heading:     "Webhook settings",
 			Options: []NotifierOption{
 				{
@@ -709,6 +709,10 @@ func GetAvailableNotifiers() []*NotifierPlugin {
 							Value: "PUT",
 							Label: "PUT",
 						},
+						{
+							Value: "PATCH",
+							Label: "PATCH",
+						},
 					},
 					PropertyName: "httpMethod",
 				},
@@ -763,6 +767,76 @@ func GetAvailableNotifiers() []*NotifierPlugin {
 					PropertyName: "message",
 					Placeholder:  channels.DefaultMessageEmbed,
 				},
+				{
+					Label:        "HTTP Headers",
+					Description:  "Custom headers of the request. The values can use template variables.",
+					Element:      ElementTypeKeyValueMap,
+					PropertyName: "httpHeaders",
+				},
+				{
+					Label:        "Body",
+					Description:  "Custom body of the request. You can use template variables. Grafana's JSON payload is sent if empty.",
+					Element:      ElementTypeTextArea,
+					PropertyName: "body",
+				},
+				{
+					Label:        "Content Type",
+					Description:  "Content type of the request. Default is application/json.",
+					Element:      ElementTypeInput,
+					InputType:    InputTypeText,
+					PropertyName: "contentType",
+					Placeholder:  "application/json",
+				},
+				{
+					Label:        "HMAC Signature - Secret",
+					Description:  "Signs the body of the request with HMAC-SHA256 using this secret.",
+					Element:      ElementTypeInput,
+					InputType:    InputTypePassword,
+					PropertyName: "hmacSecret",
+					Secure:       true,
+				},
+				{
+					Label:        "HMAC Signature - Header",
+					Description:  "Header of the hex encoded signature.",
+					Element:      ElementTypeInput,
+					InputType:    InputTypeText,
+					PropertyName: "hmacHeader",
+					Placeholder:  "X-Grafana-Alerting-Signature",
+				},
+				{
+					Label:        "HMAC Signature - Timestamp Header",
+					Description:  "When set, the Unix timestamp of the request is sent in this header and the signed message is the timestamp and the body separated by a colon.",
+					Element:      ElementTypeInput,
+					InputType:    InputTypeText,
+					PropertyName: "hmacTimestampHeader",
+				},
+				{
+					Label:        "Disable certificate verification",
+					Element:      ElementTypeCheckbox,
+					Description:  "Do not verify the certificate of the server. This is insecure.",
+					PropertyName: "insecureSkipVerify",
+				},
+				{
+					Label:        "TLS CA certificate",
+					Element:      ElementTypeTextArea,
+					Description:  "The PEM encoded certificate of the CA that signed the certificate of the server.",
+					PropertyName: "tlsCACert",
+					Secure:       true,
+				},
+				{
+					Label:        "TLS client certificate",
+					Element:      ElementTypeTextArea,
+					Description:  "The PEM encoded client certificate.",
+					PropertyName: "tlsClientCert",
+					Secure:       true,
+				},
+				{
+					Label:        "TLS client key",
+					Element:      ElementTypeTextArea,
+					Description:  "The PEM encoded key of the client certificate.",
+					PropertyName: "tlsClientKey",
+					Secure:       true,
+				},
 			},
 		},
 		{
@@ -1114,6 +1188,128 @@ func GetAvailableNotifiers() []*NotifierPlugin {
 				},
 			},
 		},
+		{
+			Type:        "mqtt",
+			Name:        "MQTT",
+			Description: "Sends notifications to an MQTT broker",
+			Heading:     "MQTT settings",
+			Options: []NotifierOption{
+				{
+					Label:        "Broker URL",
+					Element:      ElementTypeInput,
+					InputType:    InputTypeText,
+					Description:  "The URL of the MQTT broker. Supported schemes are tcp, ssl, tls, mqtt, mqtts, ws and wss.",
+					Placeholder:  "tcp://localhost:1883",
+					PropertyName: "brokerUrl",
+					Required:     true,
+				},
+				{
+					Label:        "Topic",
+					Element:      ElementTypeInput,
+					InputType:    InputTypeText,
+					Description:  "The topic to publish the message to. You can use template variables.",
+					Placeholder:  "grafana/alerts",
+					PropertyName: "topic",
+					Required:     true,
+				},
+				{
+					Label:        "Client ID",
+					Element:      ElementTypeInput,
+					InputType:    InputTypeText,
+					Description:  "The client ID to use when connecting to the broker. A random ID is used if empty.",
+					PropertyName: "clientId",
+				},
+				{
+					Label:        "Message format",
+					Element:      ElementTypeSelect,
+					Description:  "The format of the message. JSON sends the alert data with the message, text sends only the message.",
+					PropertyName: "messageFormat",
+					SelectOptions: []SelectOption{
+						{
+							Value: channels.MQTTMessageFormatJSON,
+							Label: "JSON",
+						},
+						{
+							Value: channels.MQTTMessageFormatText,
+							Label: "Text",
+						},
+					},
+				},
+				{
+					Label:        "Message",
+					Element:      ElementTypeTextArea,
+					Description:  "Custom message. You can use template variables.",
+					Placeholder:  channels.DefaultMessageEmbed,
+					PropertyName: "message",
+				},
+				{
+					Label:        "QoS",
+					Element:      ElementTypeSelect,
+					Description:  "The quality of service level of the published message.",
+					PropertyName: "qos",
+					SelectOptions: []SelectOption{
+						{
+							Value: "0",
+							Label: "At most once (0)",
+						},
+						{
+							Value: "1",
+							Label: "At least once (1)",
+						},
+						{
+							Value: "2",
+							Label: "Exactly once (2)",
+						},
+					},
+				},
+				{
+					Label:        "Retain",
+					Element:      ElementTypeCheckbox,
+					Description:  "Ask the broker to retain the last message of the topic.",
+					PropertyName: "retain",
+				},
+				{
+					Label:        "Username",
+					Element:      ElementTypeInput,
+					InputType:    InputTypeText,
+					PropertyName: "username",
+				},
+				{
+					Label:        "Password",
+					Element:      ElementTypeInput,
+					InputType:    InputTypePassword,
+					PropertyName: "password",
+					Secure:       true,
+				},
+				{
+					Label:        "Disable certificate verification",
+					Element:      ElementTypeCheckbox,
+					Description:  "Do not verify the certificate of the broker. This is insecure.",
+					PropertyName: "insecureSkipVerify",
+				},
+				{
+					Label:        "TLS CA certificate",
+					Element:      ElementTypeTextArea,
+					Description:  "The PEM encoded certificate of the CA that signed the certificate of the broker.",
+					PropertyName: "tlsCACert",
+					Secure:       true,
+				},
+				{
+					Label:        "TLS client certificate",
+					Element:      ElementTypeTextArea,
+					Description:  "The PEM encoded client certificate.",
+					PropertyName: "tlsClientCert",
+					Secure:       true,
+				},
+				{
+					Label:        "TLS client key",
+					Element:      ElementTypeTextArea,
+					Description:  "The PEM encoded key of the client certificate.",
+					PropertyName: "tlsClientKey",
+					Secure:       true,
+				},
+			},
+		},
 		{
 			Type:        "webex",
 			Name:        "Cisco Webex Teams",
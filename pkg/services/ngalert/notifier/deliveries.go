@@ -0,0 +1,408 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	gokitlog "github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/common/model"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/models"
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/notifier/channels"
+	"github.com/grafana/grafana/pkg/services/notifications"
+)
+
+const (
+	// retryPollInterval is how often the retry queue is checked for notifications that are due.
+	retryPollInterval = 30 * time.Second
+	// retryBatchSize is the maximum number of notifications retried per poll.
+	retryBatchSize = 100
+	// maxRetryAttempts is the number of times a notification is retried before it is dropped.
+	maxRetryAttempts = 10
+	// retryInitialBackoff is the time before the first retry, it doubles with every attempt up to retryMaxBackoff.
+	retryInitialBackoff = 30 * time.Second
+	retryMaxBackoff     = time.Hour
+	// retryTimeout is the timeout of a retried notification.
+	retryTimeout = time.Minute
+	// deliveryStoreTimeout is the timeout to save deliveries and retries, which happens
+	// after the context of the notification might have expired.
+	deliveryStoreTimeout = 5 * time.Second
+)
+
+type statusCodeRecorderKey struct{}
+
+type notificationRetryKey struct{}
+
+type retryableRecorderKey struct{}
+
+// statusCodeRecorder holds the status code of the last HTTP request sent by an integration.
+type statusCodeRecorder struct {
+	statusCode int
+}
+
+func withStatusCodeRecorder(ctx context.Context) (context.Context, *statusCodeRecorder) {
+	rec := &statusCodeRecorder{}
+	return context.WithValue(ctx, statusCodeRecorderKey{}, rec), rec
+}
+
+// retryableRecorder holds whether the last failure of an integration is worth retrying later.
+type retryableRecorder struct {
+	retryable bool
+}
+
+func withRetryableRecorder(ctx context.Context) (context.Context, *retryableRecorder) {
+	// failures without any attempt, such as a timeout before the first one, are retried
+	rec := &retryableRecorder{retryable: true}
+	return context.WithValue(ctx, retryableRecorderKey{}, rec), rec
+}
+
+// isRetryable returns whether a failed notification can succeed later. The Grafana integrations don't
+// report failures as retryable, so the status code of the response tells: server errors, timeouts and
+// rate limits are retried, other responses are final. Failures without a response are network errors.
+func isRetryable(retry bool, statusCode int, err error) bool {
+	switch {
+	case retry:
+		return true
+	case statusCode >= http.StatusInternalServerError, statusCode == http.StatusRequestTimeout, statusCode == http.StatusTooManyRequests:
+		return true
+	case statusCode != 0:
+		return false
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr) || errors.Is(err, context.DeadlineExceeded)
+}
+
+func withNotificationRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, notificationRetryKey{}, true)
+}
+
+func isNotificationRetry(ctx context.Context) bool {
+	retry, _ := ctx.Value(notificationRetryKey{}).(bool)
+	return retry
+}
+
+// deliveryNotificationService records the status code of the webhooks sent by the integrations,
+// as they do not return it.
+type deliveryNotificationService struct {
+	notifications.Service
+}
+
+func (s deliveryNotificationService) SendWebhookSync(ctx context.Context, cmd *models.SendWebhookSync) error {
+	if rec, ok := ctx.Value(statusCodeRecorderKey{}).(*statusCodeRecorder); ok {
+		validation := cmd.Validation
+		cmd.Validation = func(body []byte, statusCode int) error {
+			rec.statusCode = statusCode
+			if validation != nil {
+				return validation(body, statusCode)
+			}
+			return nil
+		}
+	}
+	return s.Service.SendWebhookSync(ctx, cmd)
+}
+
+// deliveryNotifier records every attempt of an integration to send a notification in the delivery history.
+type deliveryNotifier struct {
+	channels.NotificationChannel
+	am       *Alertmanager
+	receiver string
+	config   *apimodels.PostableGrafanaReceiver
+	index    int
+}
+
+func (n *deliveryNotifier) Notify(ctx context.Context, alerts ...*types.Alert) (bool, error) {
+	ctx, rec := withStatusCodeRecorder(ctx)
+	start := time.Now()
+	retry, err := n.NotificationChannel.Notify(ctx, alerts...)
+
+	groupKey, _ := notify.GroupKey(ctx)
+	delivery := &ngmodels.NotificationDelivery{
+		OrgID:            n.am.orgID,
+		Receiver:         n.receiver,
+		Integration:      n.config.Type,
+		IntegrationIndex: n.index,
+		IntegrationUID:   n.config.UID,
+		GroupKey:         groupKey,
+		Fingerprints:     make([]string, 0, len(alerts)),
+		Status:           ngmodels.NotificationDeliverySuccess,
+		StatusCode:       rec.statusCode,
+		Duration:         time.Since(start),
+		Retry:            isNotificationRetry(ctx),
+		CreatedAt:        start.UTC(),
+	}
+	for _, alert := range alerts {
+		delivery.Fingerprints = append(delivery.Fingerprints, alert.Fingerprint().String())
+	}
+	if err != nil {
+		delivery.Status = ngmodels.NotificationDeliveryFailed
+		delivery.Error = err.Error()
+		if rr, ok := ctx.Value(retryableRecorderKey{}).(*retryableRecorder); ok {
+			rr.retryable = isRetryable(retry, rec.statusCode, err)
+		}
+	}
+
+	storeCtx, cancel := context.WithTimeout(context.Background(), deliveryStoreTimeout)
+	defer cancel()
+	if err := n.am.Store.SaveNotificationDelivery(storeCtx, delivery); err != nil {
+		n.am.logger.Warn("failed to save notification delivery", "receiver", n.receiver, "integration", n.config.Type, "error", err)
+	}
+
+	return retry, err
+}
+
+// deliveryStage hands the notifications that an integration fails to deliver over to the retry
+// queue, so that they are retried with backoff even across restarts. The notifications that are
+// queued are reported as sent to the rest of the pipeline to not send them twice. Failures that
+// can't be retried fail the pipeline as usual.
+type deliveryStage struct {
+	am          *Alertmanager
+	stage       notify.Stage
+	receiver    string
+	integration *notify.Integration
+}
+
+func (s *deliveryStage) Exec(ctx context.Context, l gokitlog.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+	execCtx, rec := withRetryableRecorder(ctx)
+	resCtx, sent, err := s.stage.Exec(execCtx, l, alerts...)
+	if err == nil {
+		return resCtx, sent, nil
+	}
+	if !rec.retryable {
+		return resCtx, sent, err
+	}
+
+	if qerr := s.am.enqueueRetry(ctx, s.receiver, s.integration, alerts, err); qerr != nil {
+		level.Error(l).Log("msg", "failed to queue notification for retry", "integration", s.integration.String(), "err", qerr)
+		return resCtx, sent, err
+	}
+	level.Warn(l).Log("msg", "notification failed, queued for retry", "integration", s.integration.String(), "err", err)
+	return ctx, alerts, nil
+}
+
+func (am *Alertmanager) enqueueRetry(ctx context.Context, receiver string, integration *notify.Integration, alerts []*types.Alert, cause error) error {
+	encodedAlerts, err := json.Marshal(alerts)
+	if err != nil {
+		return fmt.Errorf("failed to encode alerts: %w", err)
+	}
+	groupKey, _ := notify.GroupKey(ctx)
+	groupLabels, _ := notify.GroupLabels(ctx)
+	encodedLabels, err := json.Marshal(groupLabels)
+	if err != nil {
+		return fmt.Errorf("failed to encode group labels: %w", err)
+	}
+
+	now := time.Now().UTC()
+	storeCtx, cancel := context.WithTimeout(context.Background(), deliveryStoreTimeout)
+	defer cancel()
+	return am.Store.SaveNotificationRetry(storeCtx, &ngmodels.NotificationRetry{
+		OrgID:            am.orgID,
+		Receiver:         receiver,
+		Integration:      integration.Name(),
+		IntegrationIndex: integration.Index(),
+		GroupKey:         groupKey,
+		GroupLabels:      string(encodedLabels),
+		Alerts:           string(encodedAlerts),
+		LastError:        cause.Error(),
+		NextAttemptAt:    now.Add(retryBackoff(0)),
+		CreatedAt:        now,
+	})
+}
+
+// retryBackoff returns the time to wait before the next retry of a notification that was retried attempts times.
+func retryBackoff(attempts int) time.Duration {
+	backoff := retryInitialBackoff
+	for i := 0; i < attempts && backoff < retryMaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > retryMaxBackoff {
+		backoff = retryMaxBackoff
+	}
+	return backoff
+}
+
+// runRetryQueue retries the queued notifications that are due and deletes the deliveries
+// that are older than the retention, until the Alertmanager is stopped.
+func (am *Alertmanager) runRetryQueue(ctx context.Context) {
+	ticker := time.NewTicker(retryPollInterval)
+	defer ticker.Stop()
+
+	lastCleanup := time.Time{}
+	for {
+		select {
+		case <-am.stopc:
+			return
+		case <-ticker.C:
+			am.processRetries(ctx)
+			if time.Since(lastCleanup) >= maintenanceNotificationAndSilences {
+				am.deleteExpiredDeliveries(ctx)
+				lastCleanup = time.Now()
+			}
+		}
+	}
+}
+
+// processRetries sends the queued notifications that are due. In high availability mode, only the
+// first peer retries notifications, the same way only the first peer sends notifications without delay.
+func (am *Alertmanager) processRetries(ctx context.Context) {
+	if !am.Ready() || am.peer.Position() != 0 {
+		return
+	}
+
+	retries, err := am.Store.GetDueNotificationRetries(ctx, am.orgID, time.Now().UTC(), retryBatchSize)
+	if err != nil {
+		am.logger.Error("failed to get notifications to retry", "error", err)
+		return
+	}
+	for _, retry := range retries {
+		am.retryNotification(ctx, retry)
+	}
+}
+
+func (am *Alertmanager) retryNotification(ctx context.Context, retry *ngmodels.NotificationRetry) {
+	logger := am.logger.New("receiver", retry.Receiver, "integration", retry.Integration, "index", retry.IntegrationIndex, "attempts", retry.Attempts)
+
+	integration := am.integrationFor(retry.Receiver, retry.Integration, retry.IntegrationIndex)
+	if integration == nil {
+		logger.Warn("dropping notification retry as the integration no longer exists")
+		am.deleteRetry(ctx, logger, retry)
+		return
+	}
+
+	var alerts []*types.Alert
+	if err := json.Unmarshal([]byte(retry.Alerts), &alerts); err != nil {
+		logger.Error("dropping notification retry with invalid alerts", "error", err)
+		am.deleteRetry(ctx, logger, retry)
+		return
+	}
+	alerts = am.currentAlerts(alerts, integration.SendResolved())
+	if len(alerts) == 0 {
+		am.deleteRetry(ctx, logger, retry)
+		return
+	}
+
+	var groupLabels model.LabelSet
+	if err := json.Unmarshal([]byte(retry.GroupLabels), &groupLabels); err != nil {
+		logger.Warn("failed to decode group labels of notification retry", "error", err)
+	}
+
+	nctx := notify.WithGroupKey(ctx, retry.GroupKey)
+	nctx = notify.WithGroupLabels(nctx, groupLabels)
+	nctx = notify.WithReceiverName(nctx, retry.Receiver)
+	nctx = withNotificationRetry(nctx)
+	nctx, cancel := context.WithTimeout(nctx, retryTimeout)
+	defer cancel()
+
+	nctx, rec := withRetryableRecorder(nctx)
+	_, err := integration.Notify(nctx, alerts...)
+	if err == nil {
+		logger.Debug("notification retry succeeded")
+		am.deleteRetry(ctx, logger, retry)
+		return
+	}
+
+	retry.LastError = err.Error()
+	retry.Attempts++
+	if !rec.retryable {
+		logger.Error("dropping notification retry after a failure that can't be retried", "error", retry.LastError)
+		am.deleteRetry(ctx, logger, retry)
+		return
+	}
+	if retry.Attempts >= maxRetryAttempts {
+		logger.Error("dropping notification retry after the maximum number of attempts", "error", retry.LastError)
+		am.deleteRetry(ctx, logger, retry)
+		return
+	}
+	retry.NextAttemptAt = time.Now().UTC().Add(retryBackoff(retry.Attempts))
+	if err := am.Store.SaveNotificationRetry(ctx, retry); err != nil {
+		logger.Error("failed to update notification retry", "error", err)
+	}
+}
+
+func (am *Alertmanager) deleteRetry(ctx context.Context, logger log.Logger, retry *ngmodels.NotificationRetry) {
+	if err := am.Store.DeleteNotificationRetry(ctx, am.orgID, retry.ID); err != nil {
+		logger.Error("failed to delete notification retry", "error", err)
+	}
+}
+
+// integrationFor returns the integration of the current configuration with the same receiver,
+// type and index, which is how the notification log identifies integrations too.
+func (am *Alertmanager) integrationFor(receiver, integrationType string, index int) *notify.Integration {
+	am.reloadConfigMtx.RLock()
+	defer am.reloadConfigMtx.RUnlock()
+
+	for _, rcv := range am.receivers {
+		if rcv.Name() != receiver {
+			continue
+		}
+		for _, integration := range rcv.Integrations() {
+			if integration.Index() == index && integration.Name() == integrationType {
+				return integration
+			}
+		}
+	}
+	return nil
+}
+
+// currentAlerts replaces the queued alerts with their current version, if it is newer,
+// so that alerts that resolved since the notification failed are not retried as firing.
+func (am *Alertmanager) currentAlerts(alerts []*types.Alert, sendResolved bool) []*types.Alert {
+	result := make([]*types.Alert, 0, len(alerts))
+	for _, alert := range alerts {
+		if current, err := am.alerts.Get(alert.Fingerprint()); err == nil && current.UpdatedAt.After(alert.UpdatedAt) {
+			alert = current
+		}
+		if !sendResolved && alert.Resolved() {
+			continue
+		}
+		result = append(result, alert)
+	}
+	return result
+}
+
+func (am *Alertmanager) deleteExpiredDeliveries(ctx context.Context) {
+	deleted, err := am.Store.DeleteNotificationDeliveries(ctx, am.orgID, time.Now().UTC().Add(-retentionNotificationsAndSilences))
+	if err != nil {
+		am.logger.Error("failed to delete expired notification deliveries", "error", err)
+		return
+	}
+	am.logger.Debug("deleted expired notification deliveries", "count", deleted)
+}
+
+// GetNotificationDeliveries returns the delivery history of the notifications that match the query.
+func (am *Alertmanager) GetNotificationDeliveries(ctx context.Context, query *ngmodels.GetNotificationDeliveriesQuery) (apimodels.NotificationDeliveries, error) {
+	query.OrgID = am.orgID
+	deliveries, err := am.Store.GetNotificationDeliveries(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(apimodels.NotificationDeliveries, 0, len(deliveries))
+	for _, d := range deliveries {
+		result = append(result, apimodels.NotificationDelivery{
+			Receiver:         d.Receiver,
+			Integration:      d.Integration,
+			IntegrationIndex: d.IntegrationIndex,
+			IntegrationUID:   d.IntegrationUID,
+			GroupKey:         d.GroupKey,
+			Fingerprints:     d.Fingerprints,
+			Status:           string(d.Status),
+			StatusCode:       d.StatusCode,
+			DurationMs:       d.Duration.Milliseconds(),
+			Error:            d.Error,
+			Retry:            d.Retry,
+			Timestamp:        d.CreatedAt,
+		})
+	}
+	return result, nil
+}
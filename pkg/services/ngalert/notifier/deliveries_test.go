@@ -0,0 +1,179 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	gokitlog "github.com/go-kit/log"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/notifications"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+const deliveriesTestConfig = `{
+	"alertmanager_config": {
+		"route": {
+			"receiver": "webhook"
+		},
+		"receivers": [{
+			"name": "webhook",
+			"grafana_managed_receiver_configs": [{
+				"uid": "webhook-uid",
+				"name": "webhook",
+				"type": "webhook",
+				"settings": {
+					"url": "http://localhost/hook"
+				}
+			}]
+		}]
+	}
+}`
+
+type failingStage struct{}
+
+func (failingStage) Exec(ctx context.Context, _ gokitlog.Logger, _ ...*types.Alert) (context.Context, []*types.Alert, error) {
+	return ctx, nil, errors.New("context deadline exceeded")
+}
+
+func TestNotificationDeliveries(t *testing.T) {
+	statusCode := http.StatusServiceUnavailable
+	ns := notifications.MockNotificationService()
+	ns.WebhookHandler = func(_ context.Context, cmd *models.SendWebhookSync) error {
+		if err := cmd.Validation(nil, statusCode); err != nil {
+			return err
+		}
+		if statusCode/100 != 2 {
+			return errors.New("webhook response status " + http.StatusText(statusCode))
+		}
+		return nil
+	}
+
+	configStore := &FakeConfigStore{configs: map[int64]*ngmodels.AlertConfiguration{}}
+	cfg := &setting.Cfg{DataPath: t.TempDir(), AppURL: "http://localhost:3000"}
+	decryptFn := func(_ context.Context, _ map[string][]byte, _ string, fallback string) string { return fallback }
+	am, err := newAlertmanager(context.Background(), 1, cfg, configStore, NewFakeKVStore(t), &NilPeer{}, decryptFn, ns,
+		metrics.NewAlertmanagerMetrics(prometheus.NewRegistry()))
+	require.NoError(t, err)
+	require.NoError(t, am.ApplyConfig(&ngmodels.AlertConfiguration{AlertmanagerConfiguration: deliveriesTestConfig}))
+
+	integration := am.integrationFor("webhook", "webhook", 0)
+	require.NotNil(t, integration)
+
+	alert := &types.Alert{
+		Alert: model.Alert{
+			Labels:   model.LabelSet{"alertname": "test"},
+			StartsAt: time.Now().Add(-time.Minute),
+		},
+		UpdatedAt: time.Now(),
+	}
+	ctx := notify.WithGroupKey(context.Background(), "{}:{alertname=\"test\"}")
+	ctx = notify.WithGroupLabels(ctx, model.LabelSet{"alertname": "test"})
+
+	t.Run("failed deliveries are recorded with the status code", func(t *testing.T) {
+		_, err := integration.Notify(ctx, alert)
+		require.Error(t, err)
+
+		deliveries, err := am.GetNotificationDeliveries(context.Background(), &ngmodels.GetNotificationDeliveriesQuery{})
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		require.Equal(t, "webhook", deliveries[0].Receiver)
+		require.Equal(t, "webhook", deliveries[0].Integration)
+		require.Equal(t, "webhook-uid", deliveries[0].IntegrationUID)
+		require.Equal(t, []string{alert.Fingerprint().String()}, deliveries[0].Fingerprints)
+		require.Equal(t, string(ngmodels.NotificationDeliveryFailed), deliveries[0].Status)
+		require.Equal(t, http.StatusServiceUnavailable, deliveries[0].StatusCode)
+		require.NotEmpty(t, deliveries[0].Error)
+		require.False(t, deliveries[0].Retry)
+	})
+
+	t.Run("failed notifications are queued instead of failing the pipeline", func(t *testing.T) {
+		stage := &deliveryStage{am: am, stage: failingStage{}, receiver: "webhook", integration: integration}
+		_, sent, err := stage.Exec(ctx, gokitlog.NewNopLogger(), alert)
+		require.NoError(t, err)
+		require.Len(t, sent, 1)
+
+		require.Len(t, configStore.retries, 1)
+		retry := configStore.retries[0]
+		require.Equal(t, "webhook", retry.Receiver)
+		require.Equal(t, "{}:{alertname=\"test\"}", retry.GroupKey)
+		require.Equal(t, "context deadline exceeded", retry.LastError)
+		require.True(t, retry.NextAttemptAt.After(time.Now()))
+	})
+
+	t.Run("retries that fail again are rescheduled with backoff", func(t *testing.T) {
+		configStore.retries[0].NextAttemptAt = time.Now().Add(-time.Second)
+		am.processRetries(context.Background())
+
+		require.Len(t, configStore.retries, 1)
+		require.Equal(t, 1, configStore.retries[0].Attempts)
+		require.WithinDuration(t, time.Now().Add(retryBackoff(1)), configStore.retries[0].NextAttemptAt, 5*time.Second)
+
+		deliveries, err := am.GetNotificationDeliveries(context.Background(), &ngmodels.GetNotificationDeliveriesQuery{})
+		require.NoError(t, err)
+		require.Len(t, deliveries, 2)
+		require.True(t, deliveries[0].Retry)
+	})
+
+	t.Run("retries that succeed are removed from the queue", func(t *testing.T) {
+		statusCode = http.StatusOK
+		configStore.retries[0].NextAttemptAt = time.Now().Add(-time.Second)
+		am.processRetries(context.Background())
+
+		require.Empty(t, configStore.retries)
+		deliveries, err := am.GetNotificationDeliveries(context.Background(), &ngmodels.GetNotificationDeliveriesQuery{
+			Status: ngmodels.NotificationDeliverySuccess,
+		})
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		require.Equal(t, http.StatusOK, deliveries[0].StatusCode)
+		require.True(t, deliveries[0].Retry)
+	})
+
+	t.Run("retries of integrations that no longer exist are dropped", func(t *testing.T) {
+		require.NoError(t, configStore.SaveNotificationRetry(context.Background(), &ngmodels.NotificationRetry{
+			OrgID:         1,
+			Receiver:      "deleted",
+			Integration:   "webhook",
+			Alerts:        "[]",
+			NextAttemptAt: time.Now().Add(-time.Second),
+		}))
+		am.processRetries(context.Background())
+		require.Empty(t, configStore.retries)
+	})
+
+	t.Run("failures that can't be retried fail the pipeline", func(t *testing.T) {
+		statusCode = http.StatusBadRequest
+		stage := &deliveryStage{am: am, stage: notify.NewRetryStage(integration, "webhook", am.stageMetrics), receiver: "webhook", integration: integration}
+		_, _, err := stage.Exec(ctx, gokitlog.NewNopLogger(), alert)
+		require.Error(t, err)
+		require.Empty(t, configStore.retries)
+	})
+}
+
+func TestIsRetryable(t *testing.T) {
+	require.True(t, isRetryable(true, http.StatusBadRequest, errors.New("failed")))
+	require.True(t, isRetryable(false, http.StatusServiceUnavailable, errors.New("failed")))
+	require.True(t, isRetryable(false, http.StatusTooManyRequests, errors.New("failed")))
+	require.False(t, isRetryable(false, http.StatusBadRequest, errors.New("failed")))
+	require.True(t, isRetryable(false, 0, &url.Error{Op: "Post", URL: "http://localhost/hook", Err: errors.New("connection refused")}))
+	require.False(t, isRetryable(false, 0, errors.New("failed to template webhook body")))
+}
+
+func TestRetryBackoff(t *testing.T) {
+	require.Equal(t, 30*time.Second, retryBackoff(0))
+	require.Equal(t, time.Minute, retryBackoff(1))
+	require.Equal(t, 8*time.Minute, retryBackoff(4))
+	require.Equal(t, time.Hour, retryBackoff(9))
+}
@@ -19,6 +19,7 @@ import (
 
 	"github.com/grafana/grafana/pkg/infra/kvstore"
 	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/infra/remotecache"
 	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
 	"github.com/grafana/grafana/pkg/services/ngalert/models"
 	"github.com/grafana/grafana/pkg/services/ngalert/store"
@@ -37,6 +38,9 @@ type MultiOrgAlertmanager struct {
 	alertmanagersMtx sync.RWMutex
 	alertmanagers    map[int64]*Alertmanager
 
+	pendingSilencesMtx sync.Mutex
+	pendingSilences    map[int64][]pendingProvisionedSilence
+
 	settings *setting.Cfg
 	logger   log.Logger
 
@@ -62,20 +66,35 @@ func NewMultiOrgAlertmanager(cfg *setting.Cfg, configStore AlertingStore, orgSto
 		Crypto:    NewCrypto(s, configStore, l),
 		ProvStore: provStore,
 
-		logger:        l,
-		settings:      cfg,
-		alertmanagers: map[int64]*Alertmanager{},
-		configStore:   configStore,
-		orgStore:      orgStore,
-		kvStore:       kvStore,
-		decryptFn:     decryptFn,
-		metrics:       m,
-		ns:            ns,
+		logger:          l,
+		settings:        cfg,
+		alertmanagers:   map[int64]*Alertmanager{},
+		pendingSilences: map[int64][]pendingProvisionedSilence{},
+		configStore:     configStore,
+		orgStore:        orgStore,
+		kvStore:         kvStore,
+		decryptFn:       decryptFn,
+		metrics:         m,
+		ns:              ns,
 	}
 
 	clusterLogger := l.New("component", "cluster")
 	moa.peer = &NilPeer{}
-	if len(cfg.UnifiedAlerting.HAPeers) > 0 {
+	if cfg.UnifiedAlerting.HARedisEnabled {
+		opts, err := remotecache.RedisOptions(cfg.RemoteCacheOptions)
+		if err != nil {
+			return nil, fmt.Errorf("unable to initialize redis for high availability mode: %w", err)
+		}
+		peer, err := newRedisPeer(redisPeerConfig{
+			Options:          opts,
+			Prefix:           cfg.UnifiedAlerting.HARedisPrefix,
+			PushPullInterval: cfg.UnifiedAlerting.HAPushPullInterval,
+		}, clusterLogger, m.Registerer)
+		if err != nil {
+			return nil, fmt.Errorf("unable to initialize redis for high availability mode: %w", err)
+		}
+		moa.peer = peer
+	} else if len(cfg.UnifiedAlerting.HAPeers) > 0 {
 		peer, err := cluster.Create(
 			clusterLogger,
 			m.Registerer,
@@ -224,6 +243,8 @@ func (moa *MultiOrgAlertmanager) SyncAlertmanagersForOrgs(ctx context.Context, o
 	moa.metrics.ActiveConfigurations.Set(float64(len(moa.alertmanagers)))
 	moa.alertmanagersMtx.Unlock()
 
+	moa.applyPendingSilences(ctx, orgsFound)
+
 	// Now, we can stop the Alertmanagers without having to hold a lock.
 	for orgID, am := range amsToStop {
 		moa.logger.Info("stopping Alertmanager", "org", orgID)
@@ -272,7 +293,7 @@ func (moa *MultiOrgAlertmanager) cleanupOrphanLocalOrgState(ctx context.Context,
 	// Remove all orphaned items from kvstore by listing all existing items
 	// in our used namespace and comparing them to the currently active
 	// organizations.
-	storedFiles := []string{notificationLogFilename, silencesFilename}
+	storedFiles := []string{notificationLogFilename, silencesFilename, provisionedSilencesKey}
 	for _, fileName := range storedFiles {
 		keys, err := moa.kvStore.Keys(ctx, kvstore.AllOrganizations, KVNamespace, fileName)
 		if err != nil {
@@ -300,12 +321,14 @@ func (moa *MultiOrgAlertmanager) StopAndWait() {
 		am.StopAndWait()
 	}
 
-	p, ok := moa.peer.(*cluster.Peer)
-	if ok {
+	switch p := moa.peer.(type) {
+	case *cluster.Peer:
 		moa.settleCancel()
 		if err := p.Leave(10 * time.Second); err != nil {
 			moa.logger.Warn("unable to leave the gossip mesh", "error", err)
 		}
+	case *redisPeer:
+		p.Shutdown()
 	}
 }
 
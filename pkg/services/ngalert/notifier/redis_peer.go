@@ -0,0 +1,437 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/prometheus/alertmanager/cluster"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+)
+
+const (
+	// redisPeerHeartbeatInterval is how often a peer renews its membership key and reads the other members.
+	redisPeerHeartbeatInterval = 5 * time.Second
+	// redisPeerHeartbeatTimeout is the expiry of the membership key. A peer that stops renewing
+	// its key is no longer a member once the key expires.
+	redisPeerHeartbeatTimeout = 30 * time.Second
+	// redisPeerOpTimeout bounds every single Redis operation.
+	redisPeerOpTimeout = 5 * time.Second
+	// redisPeerQueueSize is the number of broadcasts that can wait to be published.
+	redisPeerQueueSize = 1024
+
+	// fullStateRequestPrefix starts the messages on the full state channel that ask the other
+	// peers to publish the full state of a key. It is followed by the name of the requesting peer
+	// and the key, separated by a colon.
+	fullStateRequestPrefix = "request:"
+)
+
+var errRedisPeerStopped = errors.New("redis peer is stopped")
+
+type redisPeerConfig struct {
+	Options *redis.Options
+	// Prefix is prepended to all keys and channels.
+	Prefix string
+	// PushPullInterval is how often the full state is published to the other peers.
+	PushPullInterval time.Duration
+}
+
+// redisMessage is the payload of the state channels.
+type redisMessage struct {
+	Origin string `json:"origin"`
+	Data   []byte `json:"data"`
+}
+
+type redisPublish struct {
+	channel string
+	payload []byte
+}
+
+// redisPeer is a ClusterPeer that uses Redis instead of the gossip mesh. Each peer renews a
+// key with a short expiry, and the position of a peer is its index among the sorted names of
+// all keys. Broadcasts and full state syncs are published to a channel per state.
+type redisPeer struct {
+	name   string
+	prefix string
+	client *redis.Client
+	logger log.Logger
+
+	pushPullInterval time.Duration
+
+	statesMtx sync.RWMutex
+	states    map[string]cluster.State
+
+	membersMtx sync.RWMutex
+	members    []string
+	position   int
+
+	readyOnce sync.Once
+	readyc    chan struct{}
+
+	queue    chan redisPublish
+	pubSub   *redis.PubSub
+	stopc    chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+
+	messagesPublished prometheus.Counter
+	messagesReceived  prometheus.Counter
+	publishFailures   prometheus.Counter
+	membersGauge      prometheus.Gauge
+}
+
+func newRedisPeer(cfg redisPeerConfig, logger log.Logger, reg prometheus.Registerer) (*redisPeer, error) {
+	client := redis.NewClient(cfg.Options)
+
+	ctx, cancel := context.WithTimeout(context.Background(), redisPeerOpTimeout)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		_ = client.Close()
+		return nil, fmt.Errorf("failed to connect to redis: %w", err)
+	}
+
+	p := &redisPeer{
+		name:             uuid.NewString(),
+		prefix:           cfg.Prefix,
+		client:           client,
+		logger:           logger,
+		pushPullInterval: cfg.PushPullInterval,
+		states:           map[string]cluster.State{},
+		readyc:           make(chan struct{}),
+		queue:            make(chan redisPublish, redisPeerQueueSize),
+		stopc:            make(chan struct{}),
+		messagesPublished: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "alertmanager_redis_peer_messages_published_total",
+			Help: "Total number of state messages published to Redis.",
+		}),
+		messagesReceived: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "alertmanager_redis_peer_messages_received_total",
+			Help: "Total number of state messages received from other peers through Redis.",
+		}),
+		publishFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "alertmanager_redis_peer_publish_failures_total",
+			Help: "Total number of state messages that could not be published to Redis.",
+		}),
+		membersGauge: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "alertmanager_redis_peer_members",
+			Help: "Number of peers that are members of the cluster.",
+		}),
+	}
+	if reg != nil {
+		reg.MustRegister(p.messagesPublished, p.messagesReceived, p.publishFailures, p.membersGauge)
+	}
+
+	// Subscribe before announcing ourselves, so that the answers to the full state request are not missed.
+	p.pubSub = client.PSubscribe(ctx, p.stateChannel("*"))
+	if err := p.pubSub.Subscribe(ctx, p.fullStateChannel()); err != nil {
+		_ = p.pubSub.Close()
+		_ = client.Close()
+		return nil, fmt.Errorf("failed to subscribe to redis channels: %w", err)
+	}
+	// The subscription is confirmed once the first message arrives.
+	if _, err := p.pubSub.Receive(ctx); err != nil {
+		_ = p.pubSub.Close()
+		_ = client.Close()
+		return nil, fmt.Errorf("failed to subscribe to redis channels: %w", err)
+	}
+
+	p.wg.Add(3)
+	go p.heartbeatLoop()
+	go p.receiveLoop()
+	go p.publishLoop()
+
+	return p, nil
+}
+
+func (p *redisPeer) key(parts ...string) string {
+	return p.prefix + ":" + strings.Join(parts, ":")
+}
+
+func (p *redisPeer) memberKey(name string) string {
+	return p.key("peers", name)
+}
+
+func (p *redisPeer) stateChannel(key string) string {
+	return p.key("state", key)
+}
+
+func (p *redisPeer) fullStateChannel() string {
+	return p.key("fullstate")
+}
+
+// AddState registers a state, like the silences or the notification log of an organization,
+// and returns the channel used to broadcast its changes. The other peers are asked for their
+// full state of the key, so a new Alertmanager does not have to wait for the next push.
+func (p *redisPeer) AddState(key string, state cluster.State, _ prometheus.Registerer) cluster.ClusterChannel {
+	p.statesMtx.Lock()
+	p.states[key] = state
+	p.statesMtx.Unlock()
+
+	p.enqueue(p.fullStateChannel(), []byte(fullStateRequestPrefix+p.name+":"+key))
+
+	return &redisChannel{peer: p, key: key}
+}
+
+// Position returns the index of the peer among all members, ordered by name.
+func (p *redisPeer) Position() int {
+	p.membersMtx.RLock()
+	defer p.membersMtx.RUnlock()
+	return p.position
+}
+
+// WaitReady waits until the peer has registered itself and read the other members.
+func (p *redisPeer) WaitReady(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-p.stopc:
+		return errRedisPeerStopped
+	case <-p.readyc:
+		// A peer that is stopped is not ready, even if it was before.
+		select {
+		case <-p.stopc:
+			return errRedisPeerStopped
+		default:
+			return nil
+		}
+	}
+}
+
+// Members returns the names of all members, ordered by name.
+func (p *redisPeer) Members() []string {
+	p.membersMtx.RLock()
+	defer p.membersMtx.RUnlock()
+	return append([]string{}, p.members...)
+}
+
+// Shutdown stops the peer and removes its membership key, so that the other peers
+// move up without waiting for the key to expire. It can be called more than once.
+func (p *redisPeer) Shutdown() {
+	p.stopOnce.Do(p.shutdown)
+}
+
+func (p *redisPeer) shutdown() {
+	close(p.stopc)
+	if err := p.pubSub.Close(); err != nil {
+		p.logger.Warn("failed to close the redis subscription", "error", err)
+	}
+	p.wg.Wait()
+
+	ctx, cancel := context.WithTimeout(context.Background(), redisPeerOpTimeout)
+	defer cancel()
+	if err := p.client.Del(ctx, p.memberKey(p.name)).Err(); err != nil {
+		p.logger.Warn("failed to remove the peer from redis", "error", err)
+	}
+	if err := p.client.Close(); err != nil {
+		p.logger.Warn("failed to close the redis client", "error", err)
+	}
+}
+
+func (p *redisPeer) heartbeatLoop() {
+	defer p.wg.Done()
+
+	heartbeat := time.NewTicker(redisPeerHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	var pushPull <-chan time.Time
+	if p.pushPullInterval > 0 {
+		ticker := time.NewTicker(p.pushPullInterval)
+		defer ticker.Stop()
+		pushPull = ticker.C
+	}
+
+	p.heartbeat()
+	for {
+		select {
+		case <-p.stopc:
+			return
+		case <-heartbeat.C:
+			p.heartbeat()
+		case <-pushPull:
+			p.publishFullState()
+		}
+	}
+}
+
+// heartbeat renews the membership key of the peer and refreshes the members and position.
+func (p *redisPeer) heartbeat() {
+	ctx, cancel := context.WithTimeout(context.Background(), redisPeerOpTimeout)
+	defer cancel()
+
+	if err := p.client.Set(ctx, p.memberKey(p.name), time.Now().Unix(), redisPeerHeartbeatTimeout).Err(); err != nil {
+		p.logger.Error("failed to renew the peer in redis", "error", err)
+		return
+	}
+
+	var members []string
+	iter := p.client.Scan(ctx, 0, p.memberKey("*"), 100).Iterator()
+	for iter.Next(ctx) {
+		members = append(members, strings.TrimPrefix(iter.Val(), p.memberKey("")))
+	}
+	if err := iter.Err(); err != nil {
+		p.logger.Error("failed to read the peers from redis", "error", err)
+		return
+	}
+	sort.Strings(members)
+
+	position := sort.SearchStrings(members, p.name)
+	if position == len(members) || members[position] != p.name {
+		// the key was just set, so this only happens if it expired in between
+		p.logger.Warn("peer is missing from the members in redis")
+		return
+	}
+
+	p.membersMtx.Lock()
+	if p.position != position || len(p.members) != len(members) {
+		p.logger.Debug("peer members changed", "members", len(members), "position", position)
+	}
+	p.members = members
+	p.position = position
+	p.membersMtx.Unlock()
+	p.membersGauge.Set(float64(len(members)))
+
+	p.readyOnce.Do(func() { close(p.readyc) })
+}
+
+func (p *redisPeer) publishFullState() {
+	p.statesMtx.RLock()
+	defer p.statesMtx.RUnlock()
+
+	for key, state := range p.states {
+		p.publishState(key, state)
+	}
+}
+
+// publishStateOf publishes the full state of a key, if it is registered on this peer.
+func (p *redisPeer) publishStateOf(key string) {
+	p.statesMtx.RLock()
+	defer p.statesMtx.RUnlock()
+
+	if state, ok := p.states[key]; ok {
+		p.publishState(key, state)
+	}
+}
+
+func (p *redisPeer) publishState(key string, state cluster.State) {
+	b, err := state.MarshalBinary()
+	if err != nil {
+		p.logger.Error("failed to encode the full state", "key", key, "error", err)
+		return
+	}
+	p.broadcast(key, b)
+}
+
+// parseFullStateRequest returns the name of the peer and the key of a full state request.
+func parseFullStateRequest(payload string) (origin string, key string, ok bool) {
+	request := strings.TrimPrefix(payload, fullStateRequestPrefix)
+	if request == payload {
+		return "", "", false
+	}
+	// the names of the peers are UUIDs, the keys can contain colons
+	return strings.Cut(request, ":")
+}
+
+func (p *redisPeer) broadcast(key string, data []byte) {
+	payload, err := json.Marshal(redisMessage{Origin: p.name, Data: data})
+	if err != nil {
+		p.logger.Error("failed to encode the state message", "key", key, "error", err)
+		return
+	}
+	p.enqueue(p.stateChannel(key), payload)
+}
+
+// enqueue does not block, as broadcasts happen while the silences and notification log hold their locks.
+func (p *redisPeer) enqueue(channel string, payload []byte) {
+	select {
+	case p.queue <- redisPublish{channel: channel, payload: payload}:
+	default:
+		p.publishFailures.Inc()
+		p.logger.Warn("dropping state message, the publish queue is full", "channel", channel)
+	}
+}
+
+func (p *redisPeer) publishLoop() {
+	defer p.wg.Done()
+	for {
+		select {
+		case <-p.stopc:
+			return
+		case msg := <-p.queue:
+			ctx, cancel := context.WithTimeout(context.Background(), redisPeerOpTimeout)
+			err := p.client.Publish(ctx, msg.channel, msg.payload).Err()
+			cancel()
+			if err != nil {
+				p.publishFailures.Inc()
+				p.logger.Error("failed to publish state message", "channel", msg.channel, "error", err)
+				continue
+			}
+			p.messagesPublished.Inc()
+		}
+	}
+}
+
+func (p *redisPeer) receiveLoop() {
+	defer p.wg.Done()
+
+	messages := p.pubSub.Channel()
+	for {
+		select {
+		case <-p.stopc:
+			return
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+			if msg.Channel == p.fullStateChannel() {
+				if origin, key, ok := parseFullStateRequest(msg.Payload); ok && origin != p.name {
+					p.publishStateOf(key)
+				}
+				continue
+			}
+			p.merge(strings.TrimPrefix(msg.Channel, p.stateChannel("")), msg.Payload)
+		}
+	}
+}
+
+func (p *redisPeer) merge(key string, payload string) {
+	var msg redisMessage
+	if err := json.Unmarshal([]byte(payload), &msg); err != nil {
+		p.logger.Warn("failed to decode state message", "key", key, "error", err)
+		return
+	}
+	if msg.Origin == p.name {
+		return
+	}
+
+	p.statesMtx.RLock()
+	state, ok := p.states[key]
+	p.statesMtx.RUnlock()
+	if !ok {
+		// the Alertmanager of the organization does not run on this instance yet
+		return
+	}
+
+	p.messagesReceived.Inc()
+	if err := state.Merge(msg.Data); err != nil {
+		p.logger.Warn("failed to merge state message", "key", key, "origin", msg.Origin, "error", err)
+	}
+}
+
+// redisChannel publishes the changes of one state.
+type redisChannel struct {
+	peer *redisPeer
+	key  string
+}
+
+func (c *redisChannel) Broadcast(b []byte) {
+	c.peer.broadcast(c.key, b)
+}
@@ -0,0 +1,171 @@
+package notifier
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/prometheus/alertmanager/silence"
+	pb "github.com/prometheus/alertmanager/silence/silencepb"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/provisioning"
+	"github.com/grafana/grafana/pkg/services/secrets/fakes"
+	secretsManager "github.com/grafana/grafana/pkg/services/secrets/manager"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+func TestRedisPeer(t *testing.T) {
+	mr := miniredis.RunT(t)
+	cfg := redisPeerConfig{
+		Options: &redis.Options{Addr: mr.Addr()},
+		Prefix:  "alertmanager",
+	}
+
+	newPeer := func(t *testing.T) *redisPeer {
+		t.Helper()
+		p, err := newRedisPeer(cfg, log.New("test"), prometheus.NewPedanticRegistry())
+		require.NoError(t, err)
+		return p
+	}
+
+	newSilences := func(t *testing.T, p *redisPeer) *silence.Silences {
+		t.Helper()
+		s, err := silence.New(silence.Options{Retention: time.Hour})
+		require.NoError(t, err)
+		c := p.AddState("silences:1", s, nil)
+		s.SetBroadcast(c.Broadcast)
+		return s
+	}
+
+	setSilence := func(t *testing.T, s *silence.Silences, comment string) string {
+		t.Helper()
+		id, err := s.Set(&pb.Silence{
+			Matchers:  []*pb.Matcher{{Type: pb.Matcher_EQUAL, Name: "alertname", Pattern: "test"}},
+			StartsAt:  time.Now(),
+			EndsAt:    time.Now().Add(time.Hour),
+			CreatedBy: "test",
+			Comment:   comment,
+		})
+		require.NoError(t, err)
+		return id
+	}
+
+	hasSilence := func(s *silence.Silences, id string) func() bool {
+		return func() bool {
+			silences, _, err := s.Query(silence.QIDs(id))
+			return err == nil && len(silences) == 1
+		}
+	}
+
+	first := newPeer(t)
+	second := newPeer(t)
+	t.Cleanup(second.Shutdown)
+
+	t.Run("peers are ready and get a position by name", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		require.NoError(t, first.WaitReady(ctx))
+		require.NoError(t, second.WaitReady(ctx))
+
+		first.heartbeat()
+		second.heartbeat()
+
+		require.Len(t, first.Members(), 2)
+		require.Equal(t, first.Members(), second.Members())
+		require.ElementsMatch(t, []int{0, 1}, []int{first.Position(), second.Position()})
+		if first.name < second.name {
+			require.Equal(t, 0, first.Position())
+		} else {
+			require.Equal(t, 0, second.Position())
+		}
+	})
+
+	firstSilences := newSilences(t, first)
+	existing := setSilence(t, firstSilences, "created before the second peer added the state")
+
+	secondSilences := newSilences(t, second)
+
+	t.Run("a new state receives the full state of the other peers", func(t *testing.T) {
+		require.Eventually(t, hasSilence(secondSilences, existing), 5*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("broadcasts are merged by the other peers", func(t *testing.T) {
+		id := setSilence(t, secondSilences, "broadcast")
+		require.Eventually(t, hasSilence(firstSilences, id), 5*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("a peer that shuts down is no longer a member", func(t *testing.T) {
+		first.Shutdown()
+		second.heartbeat()
+
+		require.Equal(t, []string{second.name}, second.Members())
+		require.Equal(t, 0, second.Position())
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		require.ErrorIs(t, first.WaitReady(ctx), errRedisPeerStopped)
+
+		require.NotPanics(t, first.Shutdown)
+	})
+}
+
+func TestParseFullStateRequest(t *testing.T) {
+	origin, key, ok := parseFullStateRequest("request:peer:silences:1")
+	require.True(t, ok)
+	require.Equal(t, "peer", origin)
+	require.Equal(t, "silences:1", key)
+
+	_, _, ok = parseFullStateRequest("peer:silences:1")
+	require.False(t, ok)
+	_, _, ok = parseFullStateRequest("request:peer")
+	require.False(t, ok)
+}
+
+func TestMultiOrgAlertmanager_RedisPeer(t *testing.T) {
+	mr := miniredis.RunT(t)
+
+	secretsService := secretsManager.SetupTestService(t, fakes.NewFakeSecretsStore())
+	newMultiOrgAlertmanager := func(remoteCache *setting.RemoteCacheOptions) (*MultiOrgAlertmanager, error) {
+		cfg := &setting.Cfg{
+			DataPath:           t.TempDir(),
+			RemoteCacheOptions: remoteCache,
+			UnifiedAlerting: setting.UnifiedAlertingSettings{
+				AlertmanagerConfigPollInterval: 3 * time.Minute,
+				DefaultConfiguration:           setting.GetAlertmanagerDefaultConfiguration(),
+				HARedisEnabled:                 true,
+				HARedisPrefix:                  "alertmanager",
+				HAPeers:                        []string{"ignored:9094"},
+			},
+		}
+		m := metrics.NewNGAlert(prometheus.NewPedanticRegistry())
+		return NewMultiOrgAlertmanager(cfg, &FakeConfigStore{configs: map[int64]*models.AlertConfiguration{}}, &FakeOrgStore{orgs: []int64{1}},
+			NewFakeKVStore(t), provisioning.NewFakeProvisioningStore(), secretsService.GetDecryptedValue,
+			m.GetMultiOrgAlertmanagerMetrics(), nil, log.New("testlogger"), secretsService)
+	}
+
+	t.Run("uses the redis server of the remote cache", func(t *testing.T) {
+		mam, err := newMultiOrgAlertmanager(&setting.RemoteCacheOptions{Name: "redis", ConnStr: "addr=" + mr.Addr()})
+		require.NoError(t, err)
+		require.IsType(t, &redisPeer{}, mam.peer)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		require.NoError(t, mam.peer.WaitReady(ctx))
+		require.Len(t, mr.Keys(), 1)
+
+		mam.StopAndWait()
+		require.Empty(t, mr.Keys())
+	})
+
+	t.Run("fails when the remote cache does not use redis", func(t *testing.T) {
+		_, err := newMultiOrgAlertmanager(&setting.RemoteCacheOptions{Name: "database"})
+		require.ErrorContains(t, err, "not configured to use redis")
+	})
+}
@@ -0,0 +1,205 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	v2 "github.com/prometheus/alertmanager/api/v2"
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/dispatch"
+	"github.com/prometheus/alertmanager/inhibit"
+	"github.com/prometheus/alertmanager/silence"
+	"github.com/prometheus/alertmanager/timeinterval"
+	"github.com/prometheus/common/model"
+
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+)
+
+var ErrTestRoutesBadPayload = errors.New("unable to test routes")
+
+// TestRoutes walks the route tree of the current configuration, or of the proposed configuration if there is one,
+// with the labels of an alert. It returns the matching routes with their options, and whether the notifications
+// of the alert would be muted by a mute timing, a silence or an inhibition rule. No notification is sent.
+func (am *Alertmanager) TestRoutes(ctx context.Context, c apimodels.TestRoutesConfigBodyParams) (apimodels.TestRoutesResult, error) {
+	lset := model.LabelSet{}
+	for k, v := range c.Labels {
+		lset[k] = v
+	}
+	if c.Alert != nil {
+		for k, v := range c.Alert.Labels {
+			lset[k] = v
+		}
+	}
+	if len(lset) == 0 {
+		return apimodels.TestRoutesResult{}, fmt.Errorf("%w: labels or an alert are required", ErrTestRoutesBadPayload)
+	}
+	if err := lset.Validate(); err != nil {
+		return apimodels.TestRoutesResult{}, fmt.Errorf("%w: %s", ErrTestRoutesBadPayload, err)
+	}
+	at := time.Now()
+	if c.Time != nil {
+		at = *c.Time
+	}
+
+	route, muteTimes, inhibitRules, err := am.routingConfig(ctx, c.AlertmanagerConfig)
+	if err != nil {
+		return apimodels.TestRoutesResult{}, err
+	}
+
+	result := apimodels.TestRoutesResult{
+		Labels:      lset,
+		Time:        at,
+		Routes:      []apimodels.TestRouteResult{},
+		Silences:    apimodels.GettableSilences{},
+		Inhibitions: []apimodels.TestInhibitionResult{},
+	}
+	for _, r := range route.Match(lset) {
+		result.Routes = append(result.Routes, testRouteResult(r, muteTimes, at))
+	}
+
+	result.Silences, err = am.silencesAt(lset, at)
+	if err != nil {
+		return apimodels.TestRoutesResult{}, err
+	}
+	result.Silenced = len(result.Silences) > 0
+
+	result.Inhibitions = am.inhibitionsOf(lset, inhibitRules)
+	for _, inhibition := range result.Inhibitions {
+		if len(inhibition.SourceAlerts) > 0 {
+			result.Inhibited = true
+		}
+	}
+	return result, nil
+}
+
+// routingConfig returns the route tree, the mute timings and the inhibition rules of the proposed configuration,
+// or of the current configuration if there is no proposed configuration.
+func (am *Alertmanager) routingConfig(ctx context.Context, proposed *apimodels.PostableApiAlertingConfig) (*dispatch.Route, map[string][]timeinterval.TimeInterval, []*config.InhibitRule, error) {
+	if proposed != nil {
+		if proposed.Route == nil {
+			return nil, nil, nil, fmt.Errorf("%w: the proposed configuration has no route", ErrTestRoutesBadPayload)
+		}
+		cfg := &apimodels.PostableUserConfig{AlertmanagerConfig: *proposed}
+		// The route generated for the notification settings of the alert rules is part of every applied configuration.
+		if err := am.addAutogeneratedRoute(ctx, cfg); err != nil {
+			return nil, nil, nil, err
+		}
+		return dispatch.NewRoute(cfg.AlertmanagerConfig.Route.AsAMRoute(), nil),
+			am.buildMuteTimesMap(cfg.AlertmanagerConfig.MuteTimeIntervals),
+			cfg.AlertmanagerConfig.InhibitRules,
+			nil
+	}
+
+	am.reloadConfigMtx.RLock()
+	defer am.reloadConfigMtx.RUnlock()
+	if am.route == nil || am.config == nil {
+		return nil, nil, nil, ErrAlertmanagerNotReady
+	}
+	return am.route, am.muteTimes, am.config.AlertmanagerConfig.InhibitRules, nil
+}
+
+func testRouteResult(r *dispatch.Route, muteTimes map[string][]timeinterval.TimeInterval, at time.Time) apimodels.TestRouteResult {
+	result := apimodels.TestRouteResult{
+		Path:              r.Key(),
+		Receiver:          r.RouteOpts.Receiver,
+		GroupWait:         model.Duration(r.RouteOpts.GroupWait),
+		GroupInterval:     model.Duration(r.RouteOpts.GroupInterval),
+		RepeatInterval:    model.Duration(r.RouteOpts.RepeatInterval),
+		Continue:          r.Continue,
+		MuteTimeIntervals: r.RouteOpts.MuteTimeIntervals,
+	}
+	if r.RouteOpts.GroupByAll {
+		result.GroupBy = []string{"..."}
+	} else {
+		result.GroupBy = make([]string, 0, len(r.RouteOpts.GroupBy))
+		for l := range r.RouteOpts.GroupBy {
+			result.GroupBy = append(result.GroupBy, string(l))
+		}
+		sort.Strings(result.GroupBy)
+	}
+
+	for _, name := range r.RouteOpts.MuteTimeIntervals {
+		if containsTime(muteTimes[name], at) {
+			result.ActiveMuteTimeIntervals = append(result.ActiveMuteTimeIntervals, name)
+		}
+	}
+	result.Muted = len(result.ActiveMuteTimeIntervals) > 0
+	return result
+}
+
+func containsTime(intervals []timeinterval.TimeInterval, at time.Time) bool {
+	for _, ti := range intervals {
+		if ti.ContainsTime(at.UTC()) {
+			return true
+		}
+	}
+	return false
+}
+
+// silencesAt returns the silences that match the labels and are active at the time.
+func (am *Alertmanager) silencesAt(lset model.LabelSet, at time.Time) (apimodels.GettableSilences, error) {
+	psils, _, err := am.silences.Query(silence.QMatches(lset))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrGetSilencesInternal.Error(), err)
+	}
+
+	sils := apimodels.GettableSilences{}
+	for _, ps := range psils {
+		if at.Before(ps.StartsAt) || !at.Before(ps.EndsAt) {
+			continue
+		}
+		s, err := v2.GettableSilenceFromProto(ps)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to convert internal silence to API silence: %w", ErrGetSilencesInternal.Error(), err)
+		}
+		sils = append(sils, &s)
+	}
+	v2.SortSilences(sils)
+	return sils, nil
+}
+
+// inhibitionsOf returns the inhibition rules whose target matchers match the labels, with the labels of the
+// firing alerts that match the source matchers and have the same values for the equal labels.
+func (am *Alertmanager) inhibitionsOf(lset model.LabelSet, rules []*config.InhibitRule) []apimodels.TestInhibitionResult {
+	result := []apimodels.TestInhibitionResult{}
+	fp := lset.Fingerprint()
+	for _, cr := range rules {
+		rule := inhibit.NewInhibitRule(cr)
+		if !rule.TargetMatchers.Matches(lset) {
+			continue
+		}
+		// As in the inhibitor, if the alert also matches the source matchers, the alerts that match both the
+		// source and the target matchers do not inhibit it.
+		excludeTwoSided := rule.SourceMatchers.Matches(lset)
+
+		inhibition := apimodels.TestInhibitionResult{Rule: *cr, SourceAlerts: []model.LabelSet{}}
+		it := am.alerts.GetPending()
+		for a := range it.Next() {
+			if a.Resolved() || a.Fingerprint() == fp || !rule.SourceMatchers.Matches(a.Labels) {
+				continue
+			}
+			if excludeTwoSided && rule.TargetMatchers.Matches(a.Labels) {
+				continue
+			}
+			if !hasEqualLabels(rule.Equal, lset, a.Labels) {
+				continue
+			}
+			inhibition.SourceAlerts = append(inhibition.SourceAlerts, a.Labels)
+		}
+		it.Close()
+		result = append(result, inhibition)
+	}
+	return result
+}
+
+func hasEqualLabels(equal map[model.LabelName]struct{}, a, b model.LabelSet) bool {
+	for l := range equal {
+		if a[l] != b[l] {
+			return false
+		}
+	}
+	return true
+}
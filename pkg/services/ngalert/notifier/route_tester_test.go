@@ -0,0 +1,157 @@
+package notifier
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	amv2 "github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+const routeTesterTestConfig = `{
+	"alertmanager_config": {
+		"route": {
+			"receiver": "default",
+			"group_by": ["alertname"],
+			"routes": [
+				{"receiver": "team-a", "object_matchers": [["team", "=", "a"]], "mute_time_intervals": ["weekend"], "continue": true},
+				{"receiver": "team-b", "object_matchers": [["team", "=~", "a|b"]], "group_by": ["..."]}
+			]
+		},
+		"mute_time_intervals": [{"name": "weekend", "time_intervals": [{"weekdays": ["saturday", "sunday"]}]}],
+		"inhibit_rules": [{"source_matchers": ["severity=critical"], "target_matchers": ["severity=warning"], "equal": ["cluster"]}],
+		"receivers": [
+			{"name": "default", "grafana_managed_receiver_configs": [{"uid": "default", "name": "default", "type": "email", "settings": {"addresses": "<example@email.com>"}}]},
+			{"name": "team-a", "grafana_managed_receiver_configs": [{"uid": "team-a", "name": "team-a", "type": "email", "settings": {"addresses": "<example@email.com>"}}]},
+			{"name": "team-b", "grafana_managed_receiver_configs": [{"uid": "team-b", "name": "team-b", "type": "email", "settings": {"addresses": "<example@email.com>"}}]}
+		]
+	}
+}`
+
+func TestTestRoutes(t *testing.T) {
+	ctx := context.Background()
+	configStore := &FakeConfigStore{configs: map[int64]*ngmodels.AlertConfiguration{}}
+	cfg := &setting.Cfg{DataPath: t.TempDir(), AppURL: "http://localhost:3000"}
+	decryptFn := func(_ context.Context, _ map[string][]byte, _ string, fallback string) string { return fallback }
+	am, err := newAlertmanager(ctx, 1, cfg, configStore, NewFakeKVStore(t), &NilPeer{}, decryptFn, nil,
+		metrics.NewAlertmanagerMetrics(prometheus.NewRegistry()))
+	require.NoError(t, err)
+
+	t.Run("the configuration must be applied", func(t *testing.T) {
+		_, err := am.TestRoutes(ctx, apimodels.TestRoutesConfigBodyParams{Labels: model.LabelSet{"alertname": "test"}})
+		require.ErrorIs(t, err, ErrAlertmanagerNotReady)
+	})
+
+	require.NoError(t, am.ApplyConfig(&ngmodels.AlertConfiguration{AlertmanagerConfiguration: routeTesterTestConfig}))
+
+	monday := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+	saturday := time.Date(2026, time.October, 24, 12, 0, 0, 0, time.UTC)
+
+	t.Run("labels are required", func(t *testing.T) {
+		_, err := am.TestRoutes(ctx, apimodels.TestRoutesConfigBodyParams{})
+		require.ErrorIs(t, err, ErrTestRoutesBadPayload)
+
+		_, err = am.TestRoutes(ctx, apimodels.TestRoutesConfigBodyParams{Labels: model.LabelSet{"invalid-label": "a"}})
+		require.ErrorIs(t, err, ErrTestRoutesBadPayload)
+	})
+
+	t.Run("returns the matching routes with their options", func(t *testing.T) {
+		result, err := am.TestRoutes(ctx, apimodels.TestRoutesConfigBodyParams{
+			Labels: model.LabelSet{"alertname": "test", "team": "a"},
+			Time:   &monday,
+		})
+		require.NoError(t, err)
+		require.Len(t, result.Routes, 2)
+		require.Equal(t, "team-a", result.Routes[0].Receiver)
+		require.Equal(t, []string{"alertname"}, result.Routes[0].GroupBy)
+		require.True(t, result.Routes[0].Continue)
+		require.Equal(t, []string{"weekend"}, result.Routes[0].MuteTimeIntervals)
+		require.False(t, result.Routes[0].Muted)
+		require.Equal(t, "team-b", result.Routes[1].Receiver)
+		require.Equal(t, []string{"..."}, result.Routes[1].GroupBy)
+		require.False(t, result.Silenced)
+		require.False(t, result.Inhibited)
+
+		result, err = am.TestRoutes(ctx, apimodels.TestRoutesConfigBodyParams{Labels: model.LabelSet{"alertname": "test"}})
+		require.NoError(t, err)
+		require.Len(t, result.Routes, 1)
+		require.Equal(t, "default", result.Routes[0].Receiver)
+	})
+
+	t.Run("reports the active mute timings", func(t *testing.T) {
+		result, err := am.TestRoutes(ctx, apimodels.TestRoutesConfigBodyParams{
+			Labels: model.LabelSet{"alertname": "test", "team": "a"},
+			Time:   &saturday,
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"weekend"}, result.Routes[0].ActiveMuteTimeIntervals)
+		require.True(t, result.Routes[0].Muted)
+		require.False(t, result.Routes[1].Muted)
+	})
+
+	t.Run("reports the silences active at the time", func(t *testing.T) {
+		startsAt, endsAt := strfmt.DateTime(time.Now()), strfmt.DateTime(time.Now().Add(time.Hour))
+		_, err := am.CreateSilence(&apimodels.PostableSilence{
+			Silence: amv2.Silence{
+				Comment:   strPtr("test"),
+				CreatedBy: strPtr("test"),
+				Matchers:  amv2.Matchers{newMatcher("team", "b")},
+				StartsAt:  &startsAt,
+				EndsAt:    &endsAt,
+			},
+		})
+		require.NoError(t, err)
+
+		result, err := am.TestRoutes(ctx, apimodels.TestRoutesConfigBodyParams{Labels: model.LabelSet{"alertname": "test", "team": "b"}})
+		require.NoError(t, err)
+		require.True(t, result.Silenced)
+		require.Len(t, result.Silences, 1)
+
+		later := time.Now().Add(2 * time.Hour)
+		result, err = am.TestRoutes(ctx, apimodels.TestRoutesConfigBodyParams{Labels: model.LabelSet{"alertname": "test", "team": "b"}, Time: &later})
+		require.NoError(t, err)
+		require.False(t, result.Silenced)
+		require.Empty(t, result.Silences)
+	})
+
+	t.Run("reports the firing alerts that inhibit the alert", func(t *testing.T) {
+		require.NoError(t, am.PutAlerts(apimodels.PostableAlerts{PostableAlerts: []amv2.PostableAlert{{
+			Alert: amv2.Alert{Labels: amv2.LabelSet{"alertname": "source", "severity": "critical", "cluster": "eu"}},
+		}}}))
+
+		result, err := am.TestRoutes(ctx, apimodels.TestRoutesConfigBodyParams{Labels: model.LabelSet{"alertname": "test", "severity": "warning", "cluster": "eu"}})
+		require.NoError(t, err)
+		require.True(t, result.Inhibited)
+		require.Len(t, result.Inhibitions, 1)
+		require.Equal(t, []model.LabelSet{{"alertname": "source", "severity": "critical", "cluster": "eu"}}, result.Inhibitions[0].SourceAlerts)
+
+		result, err = am.TestRoutes(ctx, apimodels.TestRoutesConfigBodyParams{Labels: model.LabelSet{"alertname": "test", "severity": "warning", "cluster": "us"}})
+		require.NoError(t, err)
+		require.False(t, result.Inhibited)
+		require.Len(t, result.Inhibitions, 1)
+		require.Empty(t, result.Inhibitions[0].SourceAlerts)
+	})
+
+	t.Run("uses the proposed configuration", func(t *testing.T) {
+		proposed, err := Load([]byte(routeTesterTestConfig))
+		require.NoError(t, err)
+		proposed.AlertmanagerConfig.Route.Routes = proposed.AlertmanagerConfig.Route.Routes[1:]
+
+		result, err := am.TestRoutes(ctx, apimodels.TestRoutesConfigBodyParams{
+			Labels:             model.LabelSet{"alertname": "test", "team": "a"},
+			AlertmanagerConfig: &proposed.AlertmanagerConfig,
+		})
+		require.NoError(t, err)
+		require.Len(t, result.Routes, 1)
+		require.Equal(t, "team-b", result.Routes[0].Receiver)
+	})
+}
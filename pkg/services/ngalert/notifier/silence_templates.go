@@ -0,0 +1,150 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	amv2 "github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/prometheus/alertmanager/pkg/labels"
+
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+var (
+	ErrSilenceTemplateBadPayload = errors.New("invalid silence template")
+	ErrSilenceTemplateNotFound   = models.ErrSilenceTemplateNotFound
+)
+
+// GetSilenceTemplates returns the silence templates of the organization sorted by name.
+func (am *Alertmanager) GetSilenceTemplates(ctx context.Context) (apimodels.SilenceTemplates, error) {
+	templates, err := am.Store.GetSilenceTemplates(ctx, am.orgID)
+	if err != nil {
+		return nil, err
+	}
+	result := make(apimodels.SilenceTemplates, 0, len(templates))
+	for _, t := range templates {
+		result = append(result, silenceTemplateToAPI(t))
+	}
+	return result, nil
+}
+
+// SaveSilenceTemplate creates a silence template, or updates the template with the UID if it is not empty.
+func (am *Alertmanager) SaveSilenceTemplate(ctx context.Context, uid string, t apimodels.PostableSilenceTemplate) (apimodels.SilenceTemplate, error) {
+	if t.Name == "" {
+		return apimodels.SilenceTemplate{}, fmt.Errorf("%w: name must not be empty", ErrSilenceTemplateBadPayload)
+	}
+	matchers, err := silenceTemplateMatchersFromAPI(t.Matchers)
+	if err != nil {
+		return apimodels.SilenceTemplate{}, fmt.Errorf("%w: %s", ErrSilenceTemplateBadPayload, err)
+	}
+
+	template := &models.SilenceTemplate{OrgID: am.orgID}
+	if uid != "" {
+		template, err = am.Store.GetSilenceTemplate(ctx, am.orgID, uid)
+		if err != nil {
+			return apimodels.SilenceTemplate{}, err
+		}
+	}
+	template.Name = t.Name
+	template.Comment = t.Comment
+	template.Matchers = matchers
+
+	if err := am.Store.SaveSilenceTemplate(ctx, template); err != nil {
+		if errors.Is(err, models.ErrSilenceTemplateNameDuplicate) {
+			return apimodels.SilenceTemplate{}, fmt.Errorf("%w: %s", ErrSilenceTemplateBadPayload, err)
+		}
+		return apimodels.SilenceTemplate{}, err
+	}
+	return silenceTemplateToAPI(template), nil
+}
+
+// DeleteSilenceTemplate deletes the silence template. It does not expire the silences created from it.
+func (am *Alertmanager) DeleteSilenceTemplate(ctx context.Context, uid string) error {
+	return am.Store.DeleteSilenceTemplate(ctx, am.orgID, uid)
+}
+
+// ApplySilenceTemplate creates a silence with the matchers of the template and returns its ID.
+func (am *Alertmanager) ApplySilenceTemplate(ctx context.Context, uid string, apply apimodels.ApplySilenceTemplate, createdBy string) (string, error) {
+	if apply.Duration <= 0 {
+		return "", fmt.Errorf("%w: duration must be positive", ErrCreateSilenceBadPayload)
+	}
+	template, err := am.Store.GetSilenceTemplate(ctx, am.orgID, uid)
+	if err != nil {
+		return "", err
+	}
+
+	startsAt := strfmt.DateTime(time.Now())
+	if apply.StartsAt != nil {
+		startsAt = *apply.StartsAt
+	}
+	endsAt := strfmt.DateTime(time.Time(startsAt).Add(time.Duration(apply.Duration)))
+	comment := apply.Comment
+	if comment == "" {
+		comment = template.Comment
+	}
+	if comment == "" {
+		comment = fmt.Sprintf("Created from the silence template %s", template.Name)
+	}
+
+	ps := &apimodels.PostableSilence{
+		Silence: amv2.Silence{
+			Comment:   &comment,
+			CreatedBy: &createdBy,
+			Matchers:  silenceTemplateMatchersToAPI(template.Matchers),
+			StartsAt:  &startsAt,
+			EndsAt:    &endsAt,
+		},
+	}
+	return am.CreateSilence(ps)
+}
+
+func silenceTemplateToAPI(t *models.SilenceTemplate) apimodels.SilenceTemplate {
+	return apimodels.SilenceTemplate{
+		UID:      t.UID,
+		Name:     t.Name,
+		Comment:  t.Comment,
+		Matchers: silenceTemplateMatchersToAPI(t.Matchers),
+		Updated:  t.Updated,
+	}
+}
+
+func silenceTemplateMatchersToAPI(matchers []models.SilenceMatcher) amv2.Matchers {
+	result := make(amv2.Matchers, 0, len(matchers))
+	for _, m := range matchers {
+		m := m
+		result = append(result, &amv2.Matcher{Name: &m.Name, Value: &m.Value, IsRegex: &m.IsRegex, IsEqual: &m.IsEqual})
+	}
+	return result
+}
+
+func silenceTemplateMatchersFromAPI(matchers amv2.Matchers) ([]models.SilenceMatcher, error) {
+	if len(matchers) == 0 {
+		return nil, errors.New("at least one matcher is required")
+	}
+	result := make([]models.SilenceMatcher, 0, len(matchers))
+	for _, m := range matchers {
+		if err := m.Validate(strfmt.Default); err != nil {
+			return nil, err
+		}
+		matcher := models.SilenceMatcher{Name: *m.Name, Value: *m.Value, IsRegex: *m.IsRegex, IsEqual: true}
+		if m.IsEqual != nil {
+			matcher.IsEqual = *m.IsEqual
+		}
+		if matcher.Name == "" {
+			return nil, errors.New("matcher name must not be empty")
+		}
+		matchType := labels.MatchEqual
+		if matcher.IsRegex {
+			matchType = labels.MatchRegexp
+		}
+		if _, err := labels.NewMatcher(matchType, matcher.Name, matcher.Value); err != nil {
+			return nil, err
+		}
+		result = append(result, matcher)
+	}
+	return result, nil
+}
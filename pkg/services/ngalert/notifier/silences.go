@@ -1,15 +1,26 @@
 package notifier
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
 
+	"github.com/go-openapi/strfmt"
+	"github.com/google/uuid"
 	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/matttproud/golang_protobuf_extensions/pbutil"
 	v2 "github.com/prometheus/alertmanager/api/v2"
 	"github.com/prometheus/alertmanager/silence"
+	pb "github.com/prometheus/alertmanager/silence/silencepb"
+	"github.com/prometheus/alertmanager/types"
 )
 
+// provisionedSilencesKey is the key of the IDs of the provisioned silences in the KV store.
+const provisionedSilencesKey = "provisioned_silences"
+
 var (
 	ErrGetSilencesInternal     = fmt.Errorf("unable to retrieve silence(s) due to an internal error")
 	ErrDeleteSilenceInternal   = fmt.Errorf("unable to delete silence due to an internal error")
@@ -117,3 +128,225 @@ func (am *Alertmanager) DeleteSilence(silenceID string) error {
 
 	return nil
 }
+
+// ProvisionSilence creates the silence identified by the external ID, or updates it if it was provisioned before.
+// The ID of the silence is derived from the organization, the external ID and the matchers, so that the instances
+// of a high availability setup provision the same silence instead of one each. Updating the matchers of a silence
+// replaces it by a new silence. A silence without start time starts now, or keeps the start time of the
+// provisioned silence. If the silence has already ended, the provisioned silence is expired instead. It returns
+// the ID of the silence, empty if it was expired.
+func (am *Alertmanager) ProvisionSilence(ctx context.Context, externalID string, ps apimodels.PostableSilence) (string, error) {
+	am.provisionedSilencesMtx.Lock()
+	defer am.provisionedSilencesMtx.Unlock()
+
+	ids, err := am.getProvisionedSilences(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if ps.EndsAt == nil {
+		return "", fmt.Errorf("end time is required: %w", ErrCreateSilenceBadPayload)
+	}
+	startsAt := ps.StartsAt
+	if startsAt == nil {
+		now := strfmt.DateTime(time.Now())
+		ps.StartsAt = &now
+	}
+	ps.ID = ""
+	sil, err := v2.PostableSilenceToProto(&ps)
+	if err != nil {
+		return "", fmt.Errorf("%s: failed to convert API silence to internal silence: %w", ErrCreateSilenceBadPayload.Error(), err)
+	}
+	sil.Id = provisionedSilenceID(am.orgID, externalID, sil.Matchers)
+
+	if previous, ok := ids[externalID]; ok && previous != sil.Id {
+		if err := am.DeleteSilence(previous); err != nil && !errors.Is(err, ErrSilenceNotFound) {
+			return "", err
+		}
+	}
+
+	existing, err := am.GetSilence(sil.Id)
+	switch {
+	case err == nil:
+		// The Alertmanager starts the silences now when they start in the past, so the silence would be
+		// replaced every time it is provisioned if we kept the start time of the provisioned silence.
+		if *existing.Status.State == string(types.SilenceStateActive) && (startsAt == nil || sil.StartsAt.Before(time.Time(*existing.StartsAt))) {
+			sil.StartsAt = time.Time(*existing.StartsAt)
+		}
+	case !errors.Is(err, ErrSilenceNotFound):
+		return "", err
+	}
+
+	now := time.Now()
+	if !sil.EndsAt.After(now) {
+		if err == nil {
+			if err := am.DeleteSilence(sil.Id); err != nil && !errors.Is(err, ErrSilenceNotFound) {
+				return "", err
+			}
+		}
+		delete(ids, externalID)
+		return "", am.setProvisionedSilences(ctx, ids)
+	}
+
+	if err := am.mergeSilence(sil, now); err != nil {
+		return "", err
+	}
+	ids[externalID] = sil.Id
+	return sil.Id, am.setProvisionedSilences(ctx, ids)
+}
+
+// provisionedSilenceID returns the ID of a provisioned silence. The Alertmanager can't update the matchers of a
+// silence, so they are part of the ID.
+func provisionedSilenceID(orgID int64, externalID string, matchers []*pb.Matcher) string {
+	name := fmt.Sprintf("%d/%s", orgID, externalID)
+	for _, m := range matchers {
+		name += fmt.Sprintf("/%s%s%q", m.Name, m.Type, m.Pattern)
+	}
+	return uuid.NewSHA1(uuid.NameSpaceOID, []byte(name)).String()
+}
+
+// mergeSilence creates or replaces a silence with its own ID, which Silences.Set does not allow. The silence is
+// merged like the silences received from the other instances, the most recently updated one wins, and broadcast.
+func (am *Alertmanager) mergeSilence(sil *pb.Silence, now time.Time) error {
+	if len(sil.Matchers) == 0 {
+		return fmt.Errorf("at least one matcher required: %w", ErrCreateSilenceBadPayload)
+	}
+	for i, m := range sil.Matchers {
+		if err := silence.ValidateMatcher(m); err != nil {
+			return fmt.Errorf("invalid label matcher %d: %s: %w", i, err.Error(), ErrCreateSilenceBadPayload)
+		}
+	}
+	if sil.StartsAt.IsZero() || sil.StartsAt.Before(now) {
+		sil.StartsAt = now
+	}
+	if !sil.EndsAt.After(sil.StartsAt) {
+		return fmt.Errorf("start time must be before end time: %w", ErrCreateSilenceBadPayload)
+	}
+	sil.UpdatedAt = now
+
+	var buf bytes.Buffer
+	if _, err := pbutil.WriteDelimited(&buf, &pb.MeshSilence{Silence: sil, ExpiresAt: sil.EndsAt.Add(retentionNotificationsAndSilences)}); err != nil {
+		return fmt.Errorf("unable to save silence: %w", err)
+	}
+	if err := am.silences.Merge(buf.Bytes()); err != nil {
+		return fmt.Errorf("unable to save silence: %w", err)
+	}
+	return nil
+}
+
+// DeleteProvisionedSilence expires the silence provisioned with the external ID, if any.
+func (am *Alertmanager) DeleteProvisionedSilence(ctx context.Context, externalID string) error {
+	am.provisionedSilencesMtx.Lock()
+	defer am.provisionedSilencesMtx.Unlock()
+
+	ids, err := am.getProvisionedSilences(ctx)
+	if err != nil {
+		return err
+	}
+	id, ok := ids[externalID]
+	if !ok {
+		return nil
+	}
+	if err := am.DeleteSilence(id); err != nil && !errors.Is(err, ErrSilenceNotFound) {
+		return err
+	}
+	delete(ids, externalID)
+	return am.setProvisionedSilences(ctx, ids)
+}
+
+// getProvisionedSilences returns the IDs of the provisioned silences by external ID.
+func (am *Alertmanager) getProvisionedSilences(ctx context.Context) (map[string]string, error) {
+	ids := map[string]string{}
+	content, exists, err := am.fileStore.kv.Get(ctx, provisionedSilencesKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the provisioned silences: %w", err)
+	}
+	if !exists {
+		return ids, nil
+	}
+	if err := json.Unmarshal([]byte(content), &ids); err != nil {
+		return nil, fmt.Errorf("failed to decode the provisioned silences: %w", err)
+	}
+	return ids, nil
+}
+
+func (am *Alertmanager) setProvisionedSilences(ctx context.Context, ids map[string]string) error {
+	content, err := json.Marshal(ids)
+	if err != nil {
+		return err
+	}
+	if err := am.fileStore.kv.Set(ctx, provisionedSilencesKey, string(content)); err != nil {
+		return fmt.Errorf("failed to save the provisioned silences: %w", err)
+	}
+	return nil
+}
+
+// pendingProvisionedSilence is a silence provisioned before the Alertmanager of the organization was created.
+// A nil silence means that the provisioned silence is deleted.
+type pendingProvisionedSilence struct {
+	externalID string
+	silence    *apimodels.PostableSilence
+}
+
+// ProvisionSilence provisions the silence in the Alertmanager of the organization, see Alertmanager.ProvisionSilence.
+// Silences are provisioned before the Alertmanagers are started, in which case they are kept until the
+// Alertmanager of the organization is created.
+func (moa *MultiOrgAlertmanager) ProvisionSilence(ctx context.Context, orgID int64, externalID string, ps apimodels.PostableSilence) error {
+	return moa.provisionSilence(ctx, orgID, pendingProvisionedSilence{externalID: externalID, silence: &ps})
+}
+
+// DeleteProvisionedSilence expires the silence provisioned in the Alertmanager of the organization, see
+// Alertmanager.DeleteProvisionedSilence.
+func (moa *MultiOrgAlertmanager) DeleteProvisionedSilence(ctx context.Context, orgID int64, externalID string) error {
+	return moa.provisionSilence(ctx, orgID, pendingProvisionedSilence{externalID: externalID})
+}
+
+func (moa *MultiOrgAlertmanager) provisionSilence(ctx context.Context, orgID int64, p pendingProvisionedSilence) error {
+	moa.pendingSilencesMtx.Lock()
+	am, err := moa.AlertmanagerFor(orgID)
+	if errors.Is(err, ErrNoAlertmanagerForOrg) {
+		moa.pendingSilences[orgID] = append(moa.pendingSilences[orgID], p)
+		moa.pendingSilencesMtx.Unlock()
+		return nil
+	}
+	moa.pendingSilencesMtx.Unlock()
+	// The silences can be updated before the configuration of the Alertmanager is applied.
+	if err != nil && !errors.Is(err, ErrAlertmanagerNotReady) {
+		return err
+	}
+	return p.apply(ctx, am)
+}
+
+// applyPendingSilences provisions the silences kept until the Alertmanagers of the organizations were created.
+// The silences of the organizations that don't exist, or have alerting disabled, are dropped as they would
+// never be applied.
+func (moa *MultiOrgAlertmanager) applyPendingSilences(ctx context.Context, orgs map[int64]struct{}) {
+	moa.pendingSilencesMtx.Lock()
+	defer moa.pendingSilencesMtx.Unlock()
+
+	for orgID, pending := range moa.pendingSilences {
+		if _, ok := orgs[orgID]; !ok {
+			moa.logger.Warn("dropping provisioned silences of an organization without Alertmanager", "org", orgID, "count", len(pending))
+			delete(moa.pendingSilences, orgID)
+			continue
+		}
+		am, err := moa.AlertmanagerFor(orgID)
+		if errors.Is(err, ErrNoAlertmanagerForOrg) {
+			continue
+		}
+		for _, p := range pending {
+			if err := p.apply(ctx, am); err != nil {
+				moa.logger.Error("failed to provision silence", "org", orgID, "id", p.externalID, "error", err)
+			}
+		}
+		delete(moa.pendingSilences, orgID)
+	}
+}
+
+func (p pendingProvisionedSilence) apply(ctx context.Context, am *Alertmanager) error {
+	if p.silence == nil {
+		return am.DeleteProvisionedSilence(ctx, p.externalID)
+	}
+	_, err := am.ProvisionSilence(ctx, p.externalID, *p.silence)
+	return err
+}
@@ -0,0 +1,231 @@
+package notifier
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	amv2 "github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/provisioning"
+	"github.com/grafana/grafana/pkg/services/secrets/fakes"
+	secretsManager "github.com/grafana/grafana/pkg/services/secrets/manager"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+func TestProvisionSilence(t *testing.T) {
+	ctx := context.Background()
+	am := setupAMTest(t)
+
+	newSilence := func(value string, endsAt time.Time) apimodels.PostableSilence {
+		end := strfmt.DateTime(endsAt)
+		return apimodels.PostableSilence{
+			Silence: amv2.Silence{
+				Comment:   strPtr("provisioned"),
+				CreatedBy: strPtr("provisioning"),
+				Matchers:  amv2.Matchers{newMatcher("alertname", value)},
+				EndsAt:    &end,
+			},
+		}
+	}
+
+	id, err := am.ProvisionSilence(ctx, "maintenance", newSilence("a", time.Now().Add(time.Hour)))
+	require.NoError(t, err)
+	require.NotEmpty(t, id)
+
+	t.Run("provisioning the silence again updates it", func(t *testing.T) {
+		updatedID, err := am.ProvisionSilence(ctx, "maintenance", newSilence("a", time.Now().Add(2*time.Hour)))
+		require.NoError(t, err)
+		require.Equal(t, id, updatedID)
+
+		silences, err := am.ListSilences(nil)
+		require.NoError(t, err)
+		require.Len(t, silences, 1)
+	})
+
+	t.Run("changing the matchers replaces the silence", func(t *testing.T) {
+		replacedID, err := am.ProvisionSilence(ctx, "maintenance", newSilence("b", time.Now().Add(2*time.Hour)))
+		require.NoError(t, err)
+		require.NotEqual(t, id, replacedID)
+
+		old, err := am.GetSilence(id)
+		require.NoError(t, err)
+		require.Equal(t, string(types.SilenceStateExpired), *old.Status.State)
+		id = replacedID
+	})
+
+	t.Run("a silence that has ended is expired", func(t *testing.T) {
+		expiredID, err := am.ProvisionSilence(ctx, "maintenance", newSilence("b", time.Now().Add(-time.Minute)))
+		require.NoError(t, err)
+		require.Empty(t, expiredID)
+
+		s, err := am.GetSilence(id)
+		require.NoError(t, err)
+		require.Equal(t, string(types.SilenceStateExpired), *s.Status.State)
+
+		ids, err := am.getProvisionedSilences(ctx)
+		require.NoError(t, err)
+		require.Empty(t, ids)
+	})
+
+	t.Run("deleting the provisioned silence expires it", func(t *testing.T) {
+		id, err := am.ProvisionSilence(ctx, "deploy", newSilence("c", time.Now().Add(time.Hour)))
+		require.NoError(t, err)
+
+		require.NoError(t, am.DeleteProvisionedSilence(ctx, "deploy"))
+		s, err := am.GetSilence(id)
+		require.NoError(t, err)
+		require.Equal(t, string(types.SilenceStateExpired), *s.Status.State)
+
+		require.NoError(t, am.DeleteProvisionedSilence(ctx, "unknown"))
+	})
+
+	t.Run("other instances provision the same silence", func(t *testing.T) {
+		silence := newSilence("d", time.Now().Add(time.Hour))
+		id, err := am.ProvisionSilence(ctx, "replicated", silence)
+		require.NoError(t, err)
+
+		replicaID, err := setupAMTest(t).ProvisionSilence(ctx, "replicated", silence)
+		require.NoError(t, err)
+		require.Equal(t, id, replicaID)
+	})
+}
+
+func TestMultiOrgAlertmanager_ProvisionSilence(t *testing.T) {
+	ctx := context.Background()
+	configStore := &FakeConfigStore{
+		configs: map[int64]*models.AlertConfiguration{},
+	}
+	orgStore := &FakeOrgStore{
+		orgs: []int64{1},
+	}
+	secretsService := secretsManager.SetupTestService(t, fakes.NewFakeSecretsStore())
+	m := metrics.NewNGAlert(prometheus.NewPedanticRegistry())
+	cfg := &setting.Cfg{
+		DataPath: t.TempDir(),
+		UnifiedAlerting: setting.UnifiedAlertingSettings{
+			AlertmanagerConfigPollInterval: 3 * time.Minute,
+			DefaultConfiguration:           setting.GetAlertmanagerDefaultConfiguration(),
+		},
+	}
+	mam, err := NewMultiOrgAlertmanager(cfg, configStore, orgStore, NewFakeKVStore(t), provisioning.NewFakeProvisioningStore(),
+		secretsService.GetDecryptedValue, m.GetMultiOrgAlertmanagerMetrics(), nil, log.New("testlogger"), secretsService)
+	require.NoError(t, err)
+
+	end := strfmt.DateTime(time.Now().Add(time.Hour))
+	ps := apimodels.PostableSilence{
+		Silence: amv2.Silence{
+			Comment:   strPtr("provisioned"),
+			CreatedBy: strPtr("provisioning"),
+			Matchers:  amv2.Matchers{newMatcher("alertname", "a")},
+			EndsAt:    &end,
+		},
+	}
+
+	// The silence is provisioned before the Alertmanager of the organization is created.
+	require.NoError(t, mam.ProvisionSilence(ctx, 1, "maintenance", ps))
+	require.Len(t, mam.pendingSilences[1], 1)
+
+	require.NoError(t, mam.LoadAndSyncAlertmanagersForOrgs(ctx))
+	require.Empty(t, mam.pendingSilences)
+
+	am, err := mam.AlertmanagerFor(1)
+	require.NoError(t, err)
+	silences, err := am.ListSilences(nil)
+	require.NoError(t, err)
+	require.Len(t, silences, 1)
+
+	require.NoError(t, mam.DeleteProvisionedSilence(ctx, 1, "maintenance"))
+	silences, err = am.ListSilences([]string{"alertname=a"})
+	require.NoError(t, err)
+	require.Equal(t, string(types.SilenceStateExpired), *silences[0].Status.State)
+
+	t.Run("the silences of organizations that don't exist are dropped", func(t *testing.T) {
+		require.NoError(t, mam.ProvisionSilence(ctx, 2, "maintenance", ps))
+		require.Len(t, mam.pendingSilences[2], 1)
+
+		require.NoError(t, mam.LoadAndSyncAlertmanagersForOrgs(ctx))
+		require.Empty(t, mam.pendingSilences)
+	})
+}
+
+func TestSilenceTemplates(t *testing.T) {
+	ctx := context.Background()
+	am := setupAMTest(t)
+
+	template, err := am.SaveSilenceTemplate(ctx, "", apimodels.PostableSilenceTemplate{
+		Name:     "maintenance",
+		Comment:  "Planned maintenance",
+		Matchers: amv2.Matchers{newMatcher("cluster", "eu-west")},
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, template.UID)
+
+	t.Run("templates with the same name are rejected", func(t *testing.T) {
+		_, err := am.SaveSilenceTemplate(ctx, "", apimodels.PostableSilenceTemplate{
+			Name:     "maintenance",
+			Matchers: amv2.Matchers{newMatcher("cluster", "us-east")},
+		})
+		require.ErrorIs(t, err, ErrSilenceTemplateBadPayload)
+	})
+
+	t.Run("templates without matchers are rejected", func(t *testing.T) {
+		_, err := am.SaveSilenceTemplate(ctx, "", apimodels.PostableSilenceTemplate{Name: "empty"})
+		require.ErrorIs(t, err, ErrSilenceTemplateBadPayload)
+	})
+
+	t.Run("applying the template creates a silence", func(t *testing.T) {
+		id, err := am.ApplySilenceTemplate(ctx, template.UID, apimodels.ApplySilenceTemplate{Duration: model.Duration(2 * time.Hour)}, "admin")
+		require.NoError(t, err)
+
+		s, err := am.GetSilence(id)
+		require.NoError(t, err)
+		require.Equal(t, "Planned maintenance", *s.Comment)
+		require.Equal(t, "admin", *s.CreatedBy)
+		require.Equal(t, "cluster", *s.Matchers[0].Name)
+		require.Equal(t, "eu-west", *s.Matchers[0].Value)
+		require.Equal(t, 2*time.Hour, time.Time(*s.EndsAt).Sub(time.Time(*s.StartsAt)).Round(time.Second))
+	})
+
+	t.Run("applying the template requires a positive duration", func(t *testing.T) {
+		_, err := am.ApplySilenceTemplate(ctx, template.UID, apimodels.ApplySilenceTemplate{}, "admin")
+		require.ErrorIs(t, err, ErrCreateSilenceBadPayload)
+	})
+
+	t.Run("updating and deleting the template", func(t *testing.T) {
+		updated, err := am.SaveSilenceTemplate(ctx, template.UID, apimodels.PostableSilenceTemplate{
+			Name:     "maintenance-eu",
+			Matchers: amv2.Matchers{newMatcher("cluster", "eu-west")},
+		})
+		require.NoError(t, err)
+		require.Equal(t, template.UID, updated.UID)
+
+		templates, err := am.GetSilenceTemplates(ctx)
+		require.NoError(t, err)
+		require.Len(t, templates, 1)
+		require.Equal(t, "maintenance-eu", templates[0].Name)
+
+		require.NoError(t, am.DeleteSilenceTemplate(ctx, template.UID))
+		_, err = am.ApplySilenceTemplate(ctx, template.UID, apimodels.ApplySilenceTemplate{Duration: model.Duration(time.Hour)}, "admin")
+		require.ErrorIs(t, err, ErrSilenceTemplateNotFound)
+	})
+}
+
+func newMatcher(name, value string) *amv2.Matcher {
+	isRegex := false
+	isEqual := true
+	return &amv2.Matcher{Name: &name, Value: &value, IsRegex: &isRegex, IsEqual: &isEqual}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
@@ -5,17 +5,95 @@ import (
 	"crypto/md5"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/grafana/grafana/pkg/infra/kvstore"
 	"github.com/grafana/grafana/pkg/services/ngalert/models"
 	"github.com/grafana/grafana/pkg/services/ngalert/store"
+	"github.com/grafana/grafana/pkg/util"
 )
 
 type FakeConfigStore struct {
 	configs map[int64]*models.AlertConfiguration
+
+	history      []*models.AlertConfiguration
+	lastConfigID int64
+
+	deliveryMtx sync.Mutex
+	deliveries  []*models.NotificationDelivery
+	retries     []*models.NotificationRetry
+	lastRetryID int64
+
+	notificationSettings map[int64]map[models.AlertRuleKey][]models.NotificationSettings
+
+	silenceTemplates      []*models.SilenceTemplate
+	lastSilenceTemplateID int64
+}
+
+func (f *FakeConfigStore) ListNotificationSettings(_ context.Context, orgID int64) (map[models.AlertRuleKey][]models.NotificationSettings, error) {
+	return f.notificationSettings[orgID], nil
+}
+
+func (f *FakeConfigStore) GetSilenceTemplates(_ context.Context, orgID int64) ([]*models.SilenceTemplate, error) {
+	result := make([]*models.SilenceTemplate, 0)
+	for _, t := range f.silenceTemplates {
+		if t.OrgID == orgID {
+			result = append(result, t)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, nil
+}
+
+func (f *FakeConfigStore) GetSilenceTemplate(_ context.Context, orgID int64, uid string) (*models.SilenceTemplate, error) {
+	for _, t := range f.silenceTemplates {
+		if t.OrgID == orgID && t.UID == uid {
+			c := *t
+			return &c, nil
+		}
+	}
+	return nil, models.ErrSilenceTemplateNotFound
+}
+
+func (f *FakeConfigStore) SaveSilenceTemplate(_ context.Context, template *models.SilenceTemplate) error {
+	for _, t := range f.silenceTemplates {
+		if t.OrgID == template.OrgID && t.Name == template.Name && t.ID != template.ID {
+			return models.ErrSilenceTemplateNameDuplicate
+		}
+	}
+	template.Updated = time.Now().UTC()
+	if template.ID == 0 {
+		f.lastSilenceTemplateID++
+		template.ID = f.lastSilenceTemplateID
+		if template.UID == "" {
+			template.UID = util.GenerateShortUID()
+		}
+		c := *template
+		f.silenceTemplates = append(f.silenceTemplates, &c)
+		return nil
+	}
+	for i, t := range f.silenceTemplates {
+		if t.ID == template.ID {
+			c := *template
+			f.silenceTemplates[i] = &c
+			return nil
+		}
+	}
+	return models.ErrSilenceTemplateNotFound
+}
+
+func (f *FakeConfigStore) DeleteSilenceTemplate(_ context.Context, orgID int64, uid string) error {
+	for i, t := range f.silenceTemplates {
+		if t.OrgID == orgID && t.UID == uid {
+			f.silenceTemplates = append(f.silenceTemplates[:i], f.silenceTemplates[i+1:]...)
+			return nil
+		}
+	}
+	return models.ErrSilenceTemplateNotFound
 }
 
 // Saves the image or returns an error.
@@ -57,24 +135,55 @@ func (f *FakeConfigStore) GetLatestAlertmanagerConfiguration(_ context.Context,
 	return nil
 }
 
+func (f *FakeConfigStore) GetAlertmanagerConfigurationHistory(_ context.Context, orgID int64, limit int) ([]*models.AlertConfiguration, error) {
+	var result []*models.AlertConfiguration
+	for i := len(f.history) - 1; i >= 0; i-- {
+		if f.history[i].OrgID != orgID {
+			continue
+		}
+		result = append(result, f.history[i])
+		if limit > 0 && len(result) == limit {
+			break
+		}
+	}
+	return result, nil
+}
+
+func (f *FakeConfigStore) GetAlertmanagerConfigurationByID(_ context.Context, orgID int64, id int64) (*models.AlertConfiguration, error) {
+	for _, config := range f.history {
+		if config.OrgID == orgID && config.ID == id {
+			return config, nil
+		}
+	}
+	return nil, store.ErrNoAlertmanagerConfiguration
+}
+
+// save stores the configuration as the latest configuration of its organization and adds it to the history.
+func (f *FakeConfigStore) save(config *models.AlertConfiguration) {
+	f.lastConfigID++
+	config.ID = f.lastConfigID
+	f.configs[config.OrgID] = config
+	f.history = append(f.history, config)
+}
+
 func (f *FakeConfigStore) SaveAlertmanagerConfiguration(_ context.Context, cmd *models.SaveAlertmanagerConfigurationCmd) error {
-	f.configs[cmd.OrgID] = &models.AlertConfiguration{
+	f.save(&models.AlertConfiguration{
 		AlertmanagerConfiguration: cmd.AlertmanagerConfiguration,
 		OrgID:                     cmd.OrgID,
 		ConfigurationVersion:      "v1",
 		Default:                   cmd.Default,
-	}
+	})
 
 	return nil
 }
 
 func (f *FakeConfigStore) SaveAlertmanagerConfigurationWithCallback(_ context.Context, cmd *models.SaveAlertmanagerConfigurationCmd, callback store.SaveCallback) error {
-	f.configs[cmd.OrgID] = &models.AlertConfiguration{
+	f.save(&models.AlertConfiguration{
 		AlertmanagerConfiguration: cmd.AlertmanagerConfiguration,
 		OrgID:                     cmd.OrgID,
 		ConfigurationVersion:      "v1",
 		Default:                   cmd.Default,
-	}
+	})
 
 	if err := callback(); err != nil {
 		return err
@@ -85,18 +194,97 @@ func (f *FakeConfigStore) SaveAlertmanagerConfigurationWithCallback(_ context.Co
 
 func (f *FakeConfigStore) UpdateAlertmanagerConfiguration(_ context.Context, cmd *models.SaveAlertmanagerConfigurationCmd) error {
 	if config, exists := f.configs[cmd.OrgID]; exists && config.ConfigurationHash == cmd.FetchedConfigurationHash {
-		f.configs[cmd.OrgID] = &models.AlertConfiguration{
+		f.save(&models.AlertConfiguration{
 			AlertmanagerConfiguration: cmd.AlertmanagerConfiguration,
 			OrgID:                     cmd.OrgID,
 			ConfigurationHash:         fmt.Sprintf("%x", md5.Sum([]byte(cmd.AlertmanagerConfiguration))),
 			ConfigurationVersion:      "v1",
 			Default:                   cmd.Default,
-		}
+		})
 		return nil
 	}
 	return errors.New("config not found or hash not valid")
 }
 
+func (f *FakeConfigStore) SaveNotificationDelivery(_ context.Context, delivery *models.NotificationDelivery) error {
+	f.deliveryMtx.Lock()
+	defer f.deliveryMtx.Unlock()
+	f.deliveries = append(f.deliveries, delivery)
+	return nil
+}
+
+func (f *FakeConfigStore) GetNotificationDeliveries(_ context.Context, query *models.GetNotificationDeliveriesQuery) ([]*models.NotificationDelivery, error) {
+	f.deliveryMtx.Lock()
+	defer f.deliveryMtx.Unlock()
+	var result []*models.NotificationDelivery
+	for i := len(f.deliveries) - 1; i >= 0; i-- {
+		d := f.deliveries[i]
+		if d.OrgID != query.OrgID ||
+			(query.Receiver != "" && d.Receiver != query.Receiver) ||
+			(query.Integration != "" && d.Integration != query.Integration) ||
+			(query.Status != "" && d.Status != query.Status) {
+			continue
+		}
+		result = append(result, d)
+	}
+	return result, nil
+}
+
+func (f *FakeConfigStore) DeleteNotificationDeliveries(_ context.Context, orgID int64, before time.Time) (int64, error) {
+	f.deliveryMtx.Lock()
+	defer f.deliveryMtx.Unlock()
+	var kept []*models.NotificationDelivery
+	for _, d := range f.deliveries {
+		if d.OrgID != orgID || !d.CreatedAt.Before(before) {
+			kept = append(kept, d)
+		}
+	}
+	deleted := int64(len(f.deliveries) - len(kept))
+	f.deliveries = kept
+	return deleted, nil
+}
+
+func (f *FakeConfigStore) SaveNotificationRetry(_ context.Context, retry *models.NotificationRetry) error {
+	f.deliveryMtx.Lock()
+	defer f.deliveryMtx.Unlock()
+	if retry.ID == 0 {
+		f.lastRetryID++
+		retry.ID = f.lastRetryID
+		f.retries = append(f.retries, retry)
+		return nil
+	}
+	for i, r := range f.retries {
+		if r.ID == retry.ID {
+			f.retries[i] = retry
+		}
+	}
+	return nil
+}
+
+func (f *FakeConfigStore) GetDueNotificationRetries(_ context.Context, orgID int64, now time.Time, limit int) ([]*models.NotificationRetry, error) {
+	f.deliveryMtx.Lock()
+	defer f.deliveryMtx.Unlock()
+	var result []*models.NotificationRetry
+	for _, r := range f.retries {
+		if r.OrgID == orgID && !r.NextAttemptAt.After(now) && len(result) < limit {
+			result = append(result, r)
+		}
+	}
+	return result, nil
+}
+
+func (f *FakeConfigStore) DeleteNotificationRetry(_ context.Context, orgID int64, id int64) error {
+	f.deliveryMtx.Lock()
+	defer f.deliveryMtx.Unlock()
+	for i, r := range f.retries {
+		if r.OrgID == orgID && r.ID == id {
+			f.retries = append(f.retries[:i], f.retries[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
 type FakeOrgStore struct {
 	orgs []int64
 }
@@ -57,6 +57,22 @@ func (service *AlertRuleService) GetAlertRule(ctx context.Context, orgID int64,
 	return *query.Result, provenance, nil
 }
 
+// withStoredNotificationSettings keeps the notification settings of the updated rules, which are managed through
+// the ruler API only, and drops the updates that do not change anything else.
+func withStoredNotificationSettings(delta *store.GroupDelta) *store.GroupDelta {
+	updates := make([]store.RuleDelta, 0, len(delta.Update))
+	for _, update := range delta.Update {
+		update.New.NotificationSettings = update.Existing.NotificationSettings
+		update.Diff = update.Existing.Diff(update.New, store.AlertRuleFieldsToIgnoreInDiff[:]...)
+		if len(update.Diff) == 0 {
+			continue
+		}
+		updates = append(updates, update)
+	}
+	delta.Update = updates
+	return delta
+}
+
 // CreateAlertRule creates a new alert rule. This function will ignore any
 // interval that is set in the rule struct and use the already existing group
 // interval or the default one.
@@ -198,6 +214,8 @@ func (service *AlertRuleService) ReplaceRuleGroup(ctx context.Context, orgID int
 		return fmt.Errorf("failed to calculate diff for alert rules: %w", err)
 	}
 
+	delta = withStoredNotificationSettings(delta)
+
 	// Refresh all calculated fields across all rules.
 	delta = store.UpdateCalculatedRuleFields(delta)
 
@@ -276,6 +294,8 @@ func (service *AlertRuleService) UpdateAlertRule(ctx context.Context, rule model
 	rule.Updated = time.Now()
 	rule.ID = storedRule.ID
 	rule.IntervalSeconds = storedRule.IntervalSeconds
+	// the notification settings are managed through the ruler API only
+	rule.NotificationSettings = storedRule.NotificationSettings
 	err = rule.SetDashboardAndPanel()
 	if err != nil {
 		return models.AlertRule{}, err
@@ -164,6 +164,38 @@ func TestAlertRuleService(t *testing.T) {
 		require.Equal(t, int64(2), readGroup.Rules[0].Version)
 	})
 
+	t.Run("updating a rule should keep its notification settings", func(t *testing.T) {
+		var orgID int64 = 1
+		group := createDummyGroup("group-test-6", orgID)
+		err := ruleService.ReplaceRuleGroup(context.Background(), orgID, group, 0, models.ProvenanceAPI)
+		require.NoError(t, err)
+		readGroup, err := ruleService.GetRuleGroup(context.Background(), orgID, "my-namespace", "group-test-6")
+		require.NoError(t, err)
+
+		settings := []models.NotificationSettings{{Receiver: "team-a"}}
+		stored := readGroup.Rules[0]
+		withSettings := models.CopyRule(&stored)
+		withSettings.NotificationSettings = settings
+		err = ruleService.ruleStore.UpdateAlertRules(context.Background(), []models.UpdateRule{{Existing: &stored, New: *withSettings}})
+		require.NoError(t, err)
+
+		rule := readGroup.Rules[0]
+		rule.Title = "updated-rule"
+		_, err = ruleService.UpdateAlertRule(context.Background(), rule, models.ProvenanceAPI)
+		require.NoError(t, err)
+		rule, _, err = ruleService.GetAlertRule(context.Background(), orgID, rule.UID)
+		require.NoError(t, err)
+		require.Equal(t, settings, rule.NotificationSettings)
+
+		readGroup.Rules[0].Title = "updated-group"
+		err = ruleService.ReplaceRuleGroup(context.Background(), orgID, readGroup, 0, models.ProvenanceAPI)
+		require.NoError(t, err)
+		rule, _, err = ruleService.GetAlertRule(context.Background(), orgID, rule.UID)
+		require.NoError(t, err)
+		require.Equal(t, "updated-group", rule.Title)
+		require.Equal(t, settings, rule.NotificationSettings)
+	})
+
 	t.Run("alert rule provenace should be correctly checked", func(t *testing.T) {
 		tests := []struct {
 			name   string
@@ -0,0 +1,174 @@
+package provisioning
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/prometheus/alertmanager/config"
+)
+
+type InhibitionRuleService struct {
+	config AMConfigStore
+	prov   ProvisioningStore
+	xact   TransactionManager
+	log    log.Logger
+}
+
+func NewInhibitionRuleService(config AMConfigStore, prov ProvisioningStore, xact TransactionManager, log log.Logger) *InhibitionRuleService {
+	return &InhibitionRuleService{
+		config: config,
+		prov:   prov,
+		xact:   xact,
+		log:    log,
+	}
+}
+
+// GetInhibitionRules returns a slice of all inhibition rules within the specified org.
+func (svc *InhibitionRuleService) GetInhibitionRules(ctx context.Context, orgID int64) ([]definitions.InhibitionRule, error) {
+	rev, err := getLastConfiguration(ctx, orgID, svc.config)
+	if err != nil {
+		return nil, err
+	}
+	provenances, err := svc.prov.GetProvenances(ctx, orgID, (&definitions.InhibitionRule{}).ResourceType())
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]definitions.InhibitionRule, 0, len(rev.cfg.AlertmanagerConfig.InhibitRules))
+	for _, rule := range rev.cfg.AlertmanagerConfig.InhibitRules {
+		r := definitions.NewInhibitionRule(*rule, models.ProvenanceNone)
+		if p, ok := provenances[r.UID]; ok {
+			r.Provenance = p
+		}
+		result = append(result, r)
+	}
+	return result, nil
+}
+
+// GetInhibitionRule returns the inhibition rule with the given UID in the given org.
+func (svc *InhibitionRuleService) GetInhibitionRule(ctx context.Context, uid string, orgID int64) (definitions.InhibitionRule, error) {
+	rules, err := svc.GetInhibitionRules(ctx, orgID)
+	if err != nil {
+		return definitions.InhibitionRule{}, err
+	}
+	for _, rule := range rules {
+		if rule.UID == uid {
+			return rule, nil
+		}
+	}
+	return definitions.InhibitionRule{}, fmt.Errorf("%w: inhibition rule with uid '%s' not found", ErrNotFound, uid)
+}
+
+// CreateInhibitionRule adds a new inhibition rule within the specified org. The created inhibition rule is returned.
+func (svc *InhibitionRuleService) CreateInhibitionRule(ctx context.Context, rule definitions.InhibitionRule, orgID int64) (*definitions.InhibitionRule, error) {
+	if err := rule.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrValidation, err.Error())
+	}
+	rule.UID = definitions.InhibitionRuleUID(rule.InhibitRule)
+
+	revision, err := getLastConfiguration(ctx, orgID, svc.config)
+	if err != nil {
+		return nil, err
+	}
+	if indexOfInhibitRule(revision.cfg.AlertmanagerConfig.InhibitRules, rule.UID) >= 0 {
+		return nil, fmt.Errorf("%w: %s", ErrValidation, "an identical inhibition rule already exists")
+	}
+	inhibitRule := rule.InhibitRule
+	revision.cfg.AlertmanagerConfig.InhibitRules = append(revision.cfg.AlertmanagerConfig.InhibitRules, &inhibitRule)
+
+	err = svc.persist(ctx, revision, orgID, func(ctx context.Context) error {
+		return svc.prov.SetProvenance(ctx, &rule, orgID, rule.Provenance)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// UpdateInhibitionRule replaces the inhibition rule with the given UID within the specified org. The replaced
+// inhibition rule, which has a new UID if its content changed, is returned. If the inhibition rule does not exist,
+// nil is returned and no action is taken.
+func (svc *InhibitionRuleService) UpdateInhibitionRule(ctx context.Context, uid string, rule definitions.InhibitionRule, orgID int64) (*definitions.InhibitionRule, error) {
+	if err := rule.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrValidation, err.Error())
+	}
+	rule.UID = definitions.InhibitionRuleUID(rule.InhibitRule)
+
+	revision, err := getLastConfiguration(ctx, orgID, svc.config)
+	if err != nil {
+		return nil, err
+	}
+	rules := revision.cfg.AlertmanagerConfig.InhibitRules
+	i := indexOfInhibitRule(rules, uid)
+	if i < 0 {
+		return nil, nil
+	}
+	if j := indexOfInhibitRule(rules, rule.UID); j >= 0 && j != i {
+		return nil, fmt.Errorf("%w: %s", ErrValidation, "an identical inhibition rule already exists")
+	}
+	inhibitRule := rule.InhibitRule
+	rules[i] = &inhibitRule
+
+	err = svc.persist(ctx, revision, orgID, func(ctx context.Context) error {
+		if err := svc.prov.DeleteProvenance(ctx, &definitions.InhibitionRule{UID: uid}, orgID); err != nil {
+			return err
+		}
+		return svc.prov.SetProvenance(ctx, &rule, orgID, rule.Provenance)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// DeleteInhibitionRule deletes the inhibition rule with the given UID in the given org. If the inhibition rule does
+// not exist, no error is returned.
+func (svc *InhibitionRuleService) DeleteInhibitionRule(ctx context.Context, uid string, orgID int64) error {
+	revision, err := getLastConfiguration(ctx, orgID, svc.config)
+	if err != nil {
+		return err
+	}
+	rules := revision.cfg.AlertmanagerConfig.InhibitRules
+	i := indexOfInhibitRule(rules, uid)
+	if i < 0 {
+		return nil
+	}
+	revision.cfg.AlertmanagerConfig.InhibitRules = append(rules[:i], rules[i+1:]...)
+
+	return svc.persist(ctx, revision, orgID, func(ctx context.Context) error {
+		return svc.prov.DeleteProvenance(ctx, &definitions.InhibitionRule{UID: uid}, orgID)
+	})
+}
+
+// persist saves the configuration revision and runs the provenance changes in the same transaction.
+func (svc *InhibitionRuleService) persist(ctx context.Context, revision *cfgRevision, orgID int64, work func(ctx context.Context) error) error {
+	serialized, err := serializeAlertmanagerConfig(*revision.cfg)
+	if err != nil {
+		return err
+	}
+	cmd := models.SaveAlertmanagerConfigurationCmd{
+		AlertmanagerConfiguration: string(serialized),
+		ConfigurationVersion:      revision.version,
+		FetchedConfigurationHash:  revision.concurrencyToken,
+		Default:                   false,
+		OrgID:                     orgID,
+	}
+	return svc.xact.InTransaction(ctx, func(ctx context.Context) error {
+		if err := PersistConfig(ctx, svc.config, &cmd); err != nil {
+			return err
+		}
+		return work(ctx)
+	})
+}
+
+func indexOfInhibitRule(rules []*config.InhibitRule, uid string) int {
+	for i, rule := range rules {
+		if definitions.InhibitionRuleUID(*rule) == uid {
+			return i
+		}
+	}
+	return -1
+}
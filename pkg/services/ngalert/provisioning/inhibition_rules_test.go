@@ -0,0 +1,212 @@
+package provisioning
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/pkg/labels"
+	"github.com/prometheus/common/model"
+	mock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+func TestInhibitionRuleService(t *testing.T) {
+	existing := createInhibitionRule("critical", "warning")
+	existingUID := definitions.InhibitionRuleUID(existing.InhibitRule)
+
+	t.Run("service returns rules from config file with their provenance", func(t *testing.T) {
+		sut := createInhibitionRuleSvcSut()
+		sut.config.(*MockAMConfigStore).EXPECT().
+			GetsConfig(models.AlertConfiguration{
+				AlertmanagerConfiguration: configWithInhibitionRules,
+			})
+		sut.prov.(*MockProvisioningStore).EXPECT().
+			GetProvenances(mock.Anything, mock.Anything, "inhibitionRule").
+			Return(map[string]models.Provenance{existingUID: models.ProvenanceFile}, nil)
+
+		result, err := sut.GetInhibitionRules(context.Background(), 1)
+
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		require.Equal(t, existingUID, result[0].UID)
+		require.Equal(t, models.ProvenanceFile, result[0].Provenance)
+		require.Equal(t, model.LabelNames{"cluster"}, result[0].Equal)
+
+		rule, err := sut.GetInhibitionRule(context.Background(), existingUID, 1)
+		require.NoError(t, err)
+		require.Equal(t, result[0], rule)
+
+		_, err = sut.GetInhibitionRule(context.Background(), "unknown", 1)
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("creating inhibition rules", func(t *testing.T) {
+		t.Run("rejects rules that fail validation", func(t *testing.T) {
+			sut := createInhibitionRuleSvcSut()
+			for _, rule := range []definitions.InhibitionRule{
+				{InhibitRule: config.InhibitRule{TargetMatchers: existing.TargetMatchers}},
+				{InhibitRule: config.InhibitRule{SourceMatchers: existing.SourceMatchers}},
+				{InhibitRule: config.InhibitRule{SourceMatch: map[string]string{"invalid-label": "a"}, TargetMatchers: existing.TargetMatchers}},
+				{InhibitRule: config.InhibitRule{SourceMatchers: existing.SourceMatchers, TargetMatchers: existing.TargetMatchers, Equal: model.LabelNames{"invalid-label"}}},
+			} {
+				_, err := sut.CreateInhibitionRule(context.Background(), rule, 1)
+				require.ErrorIs(t, err, ErrValidation)
+			}
+		})
+
+		t.Run("rejects identical rules", func(t *testing.T) {
+			sut := createInhibitionRuleSvcSut()
+			sut.config.(*MockAMConfigStore).EXPECT().
+				GetsConfig(models.AlertConfiguration{
+					AlertmanagerConfiguration: configWithInhibitionRules,
+				})
+
+			_, err := sut.CreateInhibitionRule(context.Background(), existing, 1)
+
+			require.ErrorIs(t, err, ErrValidation)
+		})
+
+		t.Run("saves the rule with a new configuration version", func(t *testing.T) {
+			sut := createInhibitionRuleSvcSut()
+			sut.config.(*MockAMConfigStore).EXPECT().
+				GetsConfig(models.AlertConfiguration{
+					AlertmanagerConfiguration: configWithInhibitionRules,
+					ConfigurationHash:         "hash",
+					ConfigurationVersion:      "v1",
+				})
+			var saved models.SaveAlertmanagerConfigurationCmd
+			sut.config.(*MockAMConfigStore).EXPECT().SaveSucceedsIntercept(&saved)
+			sut.prov.(*MockProvisioningStore).EXPECT().SaveSucceeds()
+
+			created, err := sut.CreateInhibitionRule(context.Background(), createInhibitionRule("critical", "info"), 1)
+
+			require.NoError(t, err)
+			require.NotEqual(t, existingUID, created.UID)
+			require.Equal(t, "hash", saved.FetchedConfigurationHash)
+			require.Equal(t, "v1", saved.ConfigurationVersion)
+			cfg, err := deserializeAlertmanagerConfig([]byte(saved.AlertmanagerConfiguration))
+			require.NoError(t, err)
+			require.Len(t, cfg.AlertmanagerConfig.InhibitRules, 2)
+		})
+	})
+
+	t.Run("updating inhibition rules", func(t *testing.T) {
+		t.Run("returns nil when the rule does not exist", func(t *testing.T) {
+			sut := createInhibitionRuleSvcSut()
+			sut.config.(*MockAMConfigStore).EXPECT().
+				GetsConfig(models.AlertConfiguration{
+					AlertmanagerConfiguration: configWithInhibitionRules,
+				})
+
+			updated, err := sut.UpdateInhibitionRule(context.Background(), "unknown", createInhibitionRule("critical", "info"), 1)
+
+			require.NoError(t, err)
+			require.Nil(t, updated)
+		})
+
+		t.Run("replaces the rule and its provenance", func(t *testing.T) {
+			sut := createInhibitionRuleSvcSut()
+			sut.config.(*MockAMConfigStore).EXPECT().
+				GetsConfig(models.AlertConfiguration{
+					AlertmanagerConfiguration: configWithInhibitionRules,
+				})
+			var saved models.SaveAlertmanagerConfigurationCmd
+			sut.config.(*MockAMConfigStore).EXPECT().SaveSucceedsIntercept(&saved)
+			sut.prov.(*MockProvisioningStore).EXPECT().SaveSucceeds()
+
+			rule := createInhibitionRule("critical", "info")
+			rule.Provenance = models.ProvenanceAPI
+			updated, err := sut.UpdateInhibitionRule(context.Background(), existingUID, rule, 1)
+
+			require.NoError(t, err)
+			require.Equal(t, definitions.InhibitionRuleUID(rule.InhibitRule), updated.UID)
+			cfg, err := deserializeAlertmanagerConfig([]byte(saved.AlertmanagerConfiguration))
+			require.NoError(t, err)
+			require.Len(t, cfg.AlertmanagerConfig.InhibitRules, 1)
+			require.Equal(t, updated.UID, definitions.InhibitionRuleUID(*cfg.AlertmanagerConfig.InhibitRules[0]))
+			sut.prov.(*MockProvisioningStore).AssertCalled(t, "DeleteProvenance", mock.Anything, &definitions.InhibitionRule{UID: existingUID}, int64(1))
+			sut.prov.(*MockProvisioningStore).AssertCalled(t, "SetProvenance", mock.Anything, updated, int64(1), models.ProvenanceAPI)
+		})
+	})
+
+	t.Run("deleting inhibition rules", func(t *testing.T) {
+		t.Run("does nothing when the rule does not exist", func(t *testing.T) {
+			sut := createInhibitionRuleSvcSut()
+			sut.config.(*MockAMConfigStore).EXPECT().
+				GetsConfig(models.AlertConfiguration{
+					AlertmanagerConfiguration: configWithInhibitionRules,
+				})
+
+			require.NoError(t, sut.DeleteInhibitionRule(context.Background(), "unknown", 1))
+			sut.config.(*MockAMConfigStore).AssertNotCalled(t, "UpdateAlertmanagerConfiguration", mock.Anything, mock.Anything)
+		})
+
+		t.Run("removes the rule", func(t *testing.T) {
+			sut := createInhibitionRuleSvcSut()
+			sut.config.(*MockAMConfigStore).EXPECT().
+				GetsConfig(models.AlertConfiguration{
+					AlertmanagerConfiguration: configWithInhibitionRules,
+				})
+			var saved models.SaveAlertmanagerConfigurationCmd
+			sut.config.(*MockAMConfigStore).EXPECT().SaveSucceedsIntercept(&saved)
+			sut.prov.(*MockProvisioningStore).EXPECT().SaveSucceeds()
+
+			require.NoError(t, sut.DeleteInhibitionRule(context.Background(), existingUID, 1))
+			cfg, err := deserializeAlertmanagerConfig([]byte(saved.AlertmanagerConfiguration))
+			require.NoError(t, err)
+			require.Empty(t, cfg.AlertmanagerConfig.InhibitRules)
+		})
+	})
+}
+
+func createInhibitionRuleSvcSut() *InhibitionRuleService {
+	return &InhibitionRuleService{
+		config: &MockAMConfigStore{},
+		prov:   &MockProvisioningStore{},
+		xact:   newNopTransactionManager(),
+		log:    log.NewNopLogger(),
+	}
+}
+
+func createInhibitionRule(source, target string) definitions.InhibitionRule {
+	return definitions.InhibitionRule{
+		InhibitRule: config.InhibitRule{
+			SourceMatchers: config.Matchers{{Type: labels.MatchEqual, Name: "severity", Value: source}},
+			TargetMatchers: config.Matchers{{Type: labels.MatchEqual, Name: "severity", Value: target}},
+			Equal:          model.LabelNames{"cluster"},
+		},
+	}
+}
+
+var configWithInhibitionRules = func() string {
+	rule := createInhibitionRule("critical", "warning")
+	rules, _ := json.Marshal([]config.InhibitRule{rule.InhibitRule})
+	return `
+{
+	"alertmanager_config": {
+		"route": {
+			"receiver": "grafana-default-email"
+		},
+		"inhibit_rules": ` + string(rules) + `,
+		"receivers": [{
+			"name": "grafana-default-email",
+			"grafana_managed_receiver_configs": [{
+				"uid": "",
+				"name": "email receiver",
+				"type": "email",
+				"isDefault": true,
+				"settings": {
+					"addresses": "<example@email.com>"
+				}
+			}]
+		}]
+	}
+}
+`
+}()
@@ -11,21 +11,23 @@ import (
 )
 
 type NotificationPolicyService struct {
-	amStore         AMConfigStore
-	provenanceStore ProvisioningStore
-	xact            TransactionManager
-	log             log.Logger
-	settings        setting.UnifiedAlertingSettings
+	amStore                   AMConfigStore
+	provenanceStore           ProvisioningStore
+	notificationSettingsStore NotificationSettingsStore
+	xact                      TransactionManager
+	log                       log.Logger
+	settings                  setting.UnifiedAlertingSettings
 }
 
-func NewNotificationPolicyService(am AMConfigStore, prov ProvisioningStore,
+func NewNotificationPolicyService(am AMConfigStore, prov ProvisioningStore, ns NotificationSettingsStore,
 	xact TransactionManager, settings setting.UnifiedAlertingSettings, log log.Logger) *NotificationPolicyService {
 	return &NotificationPolicyService{
-		amStore:         am,
-		provenanceStore: prov,
-		xact:            xact,
-		log:             log,
-		settings:        settings,
+		amStore:                   am,
+		provenanceStore:           prov,
+		notificationSettingsStore: ns,
+		xact:                      xact,
+		log:                       log,
+		settings:                  settings,
 	}
 }
 
@@ -56,6 +58,18 @@ func (nps *NotificationPolicyService) GetPolicyTree(ctx context.Context, orgID i
 		return definitions.Route{}, err
 	}
 
+	// The route generated for the notification settings of the alert rules is not saved,
+	// but it is part of the tree used by the Alertmanager.
+	settings, err := nps.notificationSettingsStore.ListNotificationSettings(ctx, orgID)
+	if err != nil {
+		return definitions.Route{}, err
+	}
+	var all []models.NotificationSettings
+	for _, s := range settings {
+		all = append(all, s...)
+	}
+	cfg.AlertmanagerConfig.AddAutogeneratedRoute(all)
+
 	result := *cfg.AlertmanagerConfig.Route
 	result.Provenance = provenance
 
@@ -63,10 +77,16 @@ func (nps *NotificationPolicyService) GetPolicyTree(ctx context.Context, orgID i
 }
 
 func (nps *NotificationPolicyService) UpdatePolicyTree(ctx context.Context, orgID int64, tree definitions.Route, p models.Provenance) error {
+	// The generated route can be part of the tree returned by GetPolicyTree, but it cannot be modified.
+	tree = tree.WithoutAutogeneratedRoute()
 	err := tree.Validate()
 	if err != nil {
 		return fmt.Errorf("%w: %s", ErrValidation, err.Error())
 	}
+	err = tree.ValidateNoAutogeneratedLabels()
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrValidation, err.Error())
+	}
 
 	revision, err := getLastConfiguration(ctx, orgID, nps.amStore)
 	if err != nil {
@@ -25,6 +25,58 @@ func TestNotificationPolicyService(t *testing.T) {
 		require.Equal(t, "grafana-default-email", tree.Receiver)
 	})
 
+	t.Run("service includes the route generated for the notification settings of the rules", func(t *testing.T) {
+		sut := createNotificationPolicyServiceSut()
+		settings := models.NotificationSettings{Receiver: "grafana-default-email"}
+		sut.notificationSettingsStore = &fakeNotificationSettingsStore{
+			settings: map[models.AlertRuleKey][]models.NotificationSettings{
+				{OrgID: 1, UID: "rule"}: {settings},
+			},
+		}
+
+		tree, err := sut.GetPolicyTree(context.Background(), 1)
+		require.NoError(t, err)
+
+		require.True(t, tree.Routes[0].IsAutogenerated())
+		require.Equal(t, "grafana-default-email", tree.Routes[0].Routes[0].Receiver)
+	})
+
+	t.Run("generated route is not saved", func(t *testing.T) {
+		sut := createNotificationPolicyServiceSut()
+		sut.notificationSettingsStore = &fakeNotificationSettingsStore{
+			settings: map[models.AlertRuleKey][]models.NotificationSettings{
+				{OrgID: 1, UID: "rule"}: {{Receiver: "grafana-default-email"}},
+			},
+		}
+		tree, err := sut.GetPolicyTree(context.Background(), 1)
+		require.NoError(t, err)
+		tree.Routes = append(tree.Routes, &definitions.Route{Receiver: "grafana-default-email"})
+
+		err = sut.UpdatePolicyTree(context.Background(), 1, tree, models.ProvenanceNone)
+		require.NoError(t, err)
+
+		cfg, err := deserializeAlertmanagerConfig([]byte(sut.amStore.(*fakeAMConfigStore).config.AlertmanagerConfiguration))
+		require.NoError(t, err)
+		require.Len(t, cfg.AlertmanagerConfig.Route.Routes, len(tree.Routes)-1)
+		for _, route := range cfg.AlertmanagerConfig.Route.Routes {
+			require.False(t, route.IsAutogenerated())
+		}
+	})
+
+	t.Run("error if route matches on the labels of the generated route", func(t *testing.T) {
+		sut := createNotificationPolicyServiceSut()
+		tree := definitions.Route{
+			Receiver: "grafana-default-email",
+			Routes: []*definitions.Route{{
+				Receiver: "grafana-default-email",
+				Match:    map[string]string{models.AutogeneratedRouteReceiverNameLabel: "grafana-default-email"},
+			}},
+		}
+
+		err := sut.UpdatePolicyTree(context.Background(), 1, tree, models.ProvenanceNone)
+		require.ErrorIs(t, err, ErrValidation)
+	})
+
 	t.Run("error if referenced mute time interval is not existing", func(t *testing.T) {
 		sut := createNotificationPolicyServiceSut()
 		sut.amStore = &MockAMConfigStore{}
@@ -242,10 +294,11 @@ func TestNotificationPolicyService(t *testing.T) {
 
 func createNotificationPolicyServiceSut() *NotificationPolicyService {
 	return &NotificationPolicyService{
-		amStore:         newFakeAMConfigStore(),
-		provenanceStore: NewFakeProvisioningStore(),
-		xact:            newNopTransactionManager(),
-		log:             log.NewNopLogger(),
+		amStore:                   newFakeAMConfigStore(),
+		provenanceStore:           NewFakeProvisioningStore(),
+		notificationSettingsStore: &fakeNotificationSettingsStore{},
+		xact:                      newNopTransactionManager(),
+		log:                       log.NewNopLogger(),
 		settings: setting.UnifiedAlertingSettings{
 			DefaultConfiguration: setting.GetAlertmanagerDefaultConfiguration(),
 		},
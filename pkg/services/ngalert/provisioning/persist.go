@@ -44,6 +44,11 @@ type RuleStore interface {
 	GetAlertRulesGroupByRuleUID(ctx context.Context, query *models.GetAlertRulesGroupByRuleUIDQuery) error
 }
 
+// NotificationSettingsStore represents the ability to query the notification settings of alert rules.
+type NotificationSettingsStore interface {
+	ListNotificationSettings(ctx context.Context, orgID int64) (map[models.AlertRuleKey][]models.NotificationSettings, error)
+}
+
 // QuotaChecker represents the ability to evaluate whether quotas are met.
 //
 //go:generate mockery --name QuotaChecker --structname MockQuotaChecker --inpackage --filename quota_checker_mock.go --with-expecter
@@ -136,6 +136,20 @@ func (f *fakeProvisioningStore) DeleteProvenance(ctx context.Context, o models.P
 	return nil
 }
 
+type fakeNotificationSettingsStore struct {
+	settings map[models.AlertRuleKey][]models.NotificationSettings
+}
+
+func (f *fakeNotificationSettingsStore) ListNotificationSettings(ctx context.Context, orgID int64) (map[models.AlertRuleKey][]models.NotificationSettings, error) {
+	result := make(map[models.AlertRuleKey][]models.NotificationSettings)
+	for key, s := range f.settings {
+		if key.OrgID == orgID {
+			result[key] = s
+		}
+	}
+	return result, nil
+}
+
 type NopTransactionManager struct{}
 
 func newNopTransactionManager() *NopTransactionManager {
@@ -11,7 +11,10 @@ import (
 	"github.com/grafana/grafana/pkg/util"
 )
 
-var errRuleDeleted = errors.New("rule deleted")
+var (
+	errRuleDeleted    = errors.New("rule deleted")
+	errRuleHandedOver = errors.New("rule handed over to another instance")
+)
 
 type alertRuleInfoRegistry struct {
 	mu            sync.Mutex
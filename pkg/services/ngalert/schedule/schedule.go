@@ -93,6 +93,12 @@ type schedule struct {
 	// current tick depends on its evaluation interval and when it was
 	// last evaluated.
 	schedulableAlertRules alertRulesRegistry
+
+	// sharder assigns the alert rules to the Grafana instances when the evaluation is sharded between them. It is
+	// nil when every instance evaluates all rules.
+	sharder *ruleSharder
+	// shardingStarted is true after the first tick in which the rules were assigned to the instances.
+	shardingStarted bool
 }
 
 // SchedulerCfg is the scheduler configuration.
@@ -107,6 +113,13 @@ type SchedulerCfg struct {
 	RuleStore            RulesStore
 	Metrics              *metrics.Scheduler
 	AlertSender          AlertsSender
+	// InstanceID identifies this instance among the members of the scheduler.
+	InstanceID string
+	// MemberStore tracks the members of the scheduler. If it is set, the evaluation of the alert rules is sharded
+	// between the members.
+	MemberStore SchedulerMemberStore
+	// MemberTimeout is the time after its last heartbeat after which an instance is no longer a member.
+	MemberTimeout time.Duration
 }
 
 // NewScheduler returns a new schedule.
@@ -128,6 +141,10 @@ func NewScheduler(cfg SchedulerCfg, stateManager *state.Manager) *schedule {
 		alertsSender:          cfg.AlertSender,
 	}
 
+	if cfg.MemberStore != nil {
+		sch.sharder = newRuleSharder(cfg.InstanceID, cfg.MemberStore, cfg.MemberTimeout, cfg.C, sch.log.New("instance", cfg.InstanceID))
+	}
+
 	return &sch
 }
 
@@ -138,6 +155,9 @@ func (sch *schedule) Run(ctx context.Context) error {
 	if err := sch.schedulePeriodic(ctx, t); err != nil {
 		sch.log.Error("Failure while running the rule evaluation loop", "error", err)
 	}
+	if sch.sharder != nil {
+		sch.sharder.leave(context.Background())
+	}
 	return nil
 }
 
@@ -174,6 +194,22 @@ func (sch *schedule) DeleteAlertRule(keys ...ngmodels.AlertRuleKey) {
 	sch.metrics.SchedulableAlertRulesHash.Set(float64(hashUIDs(alertRules)))
 }
 
+// ownsRule returns true if the rule is evaluated by this instance.
+func (sch *schedule) ownsRule(key ngmodels.AlertRuleKey) bool {
+	return sch.sharder == nil || sch.sharder.owns(key)
+}
+
+// handOverAlertRule stops the evaluation of a rule that is now evaluated by another instance. Its state is kept in
+// the database for the other instance to take it over.
+func (sch *schedule) handOverAlertRule(key ngmodels.AlertRuleKey) {
+	ruleInfo, ok := sch.registry.del(key)
+	if !ok {
+		return
+	}
+	sch.log.Info("Alert rule moved to another instance, stopping its evaluation", key.LogContext()...)
+	ruleInfo.stop(errRuleHandedOver)
+}
+
 func (sch *schedule) schedulePeriodic(ctx context.Context, t *ticker.T) error {
 	dispatcherGroup, ctx := errgroup.WithContext(ctx)
 	for {
@@ -210,6 +246,13 @@ func (sch *schedule) processTick(ctx context.Context, dispatcherGroup *errgroup.
 	}
 	alertRules, folderTitles := sch.schedulableAlertRules.all()
 
+	if sch.sharder != nil {
+		sch.sharder.refresh(ctx)
+	}
+	// takeOver is true if the rules that start being evaluated by this instance may have been evaluated by another
+	// instance since the state was warmed up.
+	takeOver := sch.sharder != nil && sch.shardingStarted
+
 	// registeredDefinitions is a map used for finding deleted alert rules
 	// initially it is assigned to all known alert rules from the previous cycle
 	// each alert rule found also in this cycle is removed
@@ -223,8 +266,19 @@ func (sch *schedule) processTick(ctx context.Context, dispatcherGroup *errgroup.
 
 	readyToRun := make([]readyToRunItem, 0)
 	missingFolder := make(map[string][]string)
+	handedOver := make(map[ngmodels.AlertRuleKey]struct{})
 	for _, item := range alertRules {
 		key := item.GetKey()
+		if !sch.ownsRule(key) {
+			// The rule is not removed from the registered alert rules, so its routine is stopped if it was started
+			// before the rule moved to another instance.
+			handedOver[key] = struct{}{}
+			if sch.sharder != nil && !sch.shardingStarted {
+				// The state of all rules was loaded when the state was warmed up.
+				sch.stateManager.ReleaseRule(key)
+			}
+			continue
+		}
 		ruleInfo, newRoutine := sch.registry.getOrCreateInfo(ctx, key)
 
 		// enforce minimum evaluation interval
@@ -236,7 +290,13 @@ func (sch *schedule) processTick(ctx context.Context, dispatcherGroup *errgroup.
 		invalidInterval := item.IntervalSeconds%int64(sch.baseInterval.Seconds()) != 0
 
 		if newRoutine && !invalidInterval {
+			rule := item
 			dispatcherGroup.Go(func() error {
+				if takeOver {
+					if err := sch.stateManager.TakeOverRule(ruleInfo.ctx, rule); err != nil {
+						sch.log.Error("Failed to take over the state of the rule", append(key.LogContext(), "error", err)...)
+					}
+				}
 				return sch.ruleRoutine(ruleInfo.ctx, key, ruleInfo.evalCh, ruleInfo.updateCh)
 			})
 		}
@@ -297,10 +357,17 @@ func (sch *schedule) processTick(ctx context.Context, dispatcherGroup *errgroup.
 		})
 	}
 
-	// unregister and stop routines of the deleted alert rules
+	// unregister and stop routines of the deleted alert rules, and of the rules that moved to another instance
 	for key := range registeredDefinitions {
+		if _, ok := handedOver[key]; ok {
+			sch.handOverAlertRule(key)
+			continue
+		}
 		sch.DeleteAlertRule(key)
 	}
+	if sch.sharder != nil {
+		sch.shardingStarted = true
+	}
 
 	return readyToRun, registeredDefinitions
 }
@@ -446,6 +513,10 @@ func (sch *schedule) ruleRoutine(grafanaCtx context.Context, key ngmodels.AlertR
 			if errors.Is(grafanaCtx.Err(), errRuleDeleted) {
 				clearState()
 			}
+			// the instance that now evaluates the rule takes over its state from the database
+			if errors.Is(grafanaCtx.Err(), errRuleHandedOver) {
+				sch.stateManager.ReleaseRule(key)
+			}
 			logger.Debug("Stopping alert rule routine")
 			return nil
 		}
@@ -480,5 +551,12 @@ func (sch *schedule) getRuleExtraLabels(evalCtx *evaluation) map[string]string {
 	if !sch.disableGrafanaFolder {
 		extraLabels[ngmodels.FolderTitleLabel] = evalCtx.folderTitle
 	}
+
+	// The alerts of the rules with notification settings are routed by the generated route that matches these labels.
+	if ns := evalCtx.rule.GetNotificationSettings(); ns != nil {
+		for k, v := range ns.ToLabels() {
+			extraLabels[k] = v
+		}
+	}
 	return extraLabels
 }
@@ -0,0 +1,120 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"github.com/benbjohnson/clock"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+// SchedulerMemberStore is a store that tracks the Grafana instances that share the evaluation of the alert rules.
+type SchedulerMemberStore interface {
+	HeartbeatSchedulerMember(ctx context.Context, instanceID string, at time.Time) error
+	GetSchedulerMembers(ctx context.Context, since time.Time) ([]string, error)
+	DeleteSchedulerMember(ctx context.Context, instanceID string) error
+}
+
+// ruleSharder assigns every alert rule to one of the Grafana instances that are members of the scheduler, so that
+// each rule is evaluated by one instance only. The members are tracked in the database: every instance records a
+// heartbeat at each tick of the scheduler, and the instances whose last heartbeat is older than the member timeout
+// are no longer members. A rule is owned by the member with the highest hash of its ID and the rule key (rendezvous
+// hashing), so when a member joins or leaves only the rules that it owns, or will own, move.
+//
+// The instances do not see a change of the members at exactly the same time. Until they do, a rule that moves can
+// be evaluated by both instances, or by none of them, for up to one tick.
+type ruleSharder struct {
+	instanceID    string
+	store         SchedulerMemberStore
+	memberTimeout time.Duration
+	clock         clock.Clock
+	log           log.Logger
+
+	members []string
+}
+
+func newRuleSharder(instanceID string, store SchedulerMemberStore, memberTimeout time.Duration, clock clock.Clock, logger log.Logger) *ruleSharder {
+	return &ruleSharder{
+		instanceID:    instanceID,
+		store:         store,
+		memberTimeout: memberTimeout,
+		clock:         clock,
+		log:           logger,
+		// Until the members are loaded, the instance evaluates all rules.
+		members: []string{instanceID},
+	}
+}
+
+// refresh records the heartbeat of the instance and reloads the members. The last known members are kept if the
+// members cannot be loaded. Returns true if the members changed.
+func (s *ruleSharder) refresh(ctx context.Context) bool {
+	now := s.clock.Now()
+	if err := s.store.HeartbeatSchedulerMember(ctx, s.instanceID, now); err != nil {
+		s.log.Error("Failed to record the heartbeat of the scheduler member", "instance", s.instanceID, "error", err)
+	}
+	members, err := s.store.GetSchedulerMembers(ctx, now.Add(-s.memberTimeout))
+	if err != nil {
+		s.log.Error("Failed to get the scheduler members, keeping the last known members", "error", err)
+		return false
+	}
+	if equalMembers(s.members, members) {
+		return false
+	}
+	s.log.Info("Scheduler members changed, rebalancing the alert rules", "instance", s.instanceID, "previous", s.members, "members", members)
+	s.members = members
+	return true
+}
+
+// owns returns true if the rule is evaluated by this instance.
+func (s *ruleSharder) owns(key ngmodels.AlertRuleKey) bool {
+	return ruleOwner(s.members, key) == s.instanceID
+}
+
+// leave removes the instance from the members, so that the other instances take over its rules without waiting
+// for the member timeout.
+func (s *ruleSharder) leave(ctx context.Context) {
+	if err := s.store.DeleteSchedulerMember(ctx, s.instanceID); err != nil {
+		s.log.Error("Failed to remove the scheduler member", "instance", s.instanceID, "error", err)
+	}
+}
+
+// ruleOwner returns the member that owns the rule, or an empty string if there are no members.
+func ruleOwner(members []string, key ngmodels.AlertRuleKey) string {
+	var owner string
+	var max uint64
+	for _, m := range members {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(fmt.Sprintf("%s/%d/%s", m, key.OrgID, key.UID)))
+		if sum := mix64(h.Sum64()); owner == "" || sum > max {
+			owner, max = m, sum
+		}
+	}
+	return owner
+}
+
+// mix64 is the finalizer of MurmurHash3. It spreads the differences of the members over all the bits of the hash,
+// which FNV alone does not do well for short keys.
+func mix64(h uint64) uint64 {
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
+
+func equalMembers(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,150 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/eval"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/state"
+)
+
+func TestRuleOwner(t *testing.T) {
+	keys := make([]models.AlertRuleKey, 0, 300)
+	for i := 0; i < 300; i++ {
+		keys = append(keys, models.AlertRuleKey{OrgID: 1, UID: fmt.Sprintf("rule-%d", i)})
+	}
+
+	owners := func(members ...string) map[models.AlertRuleKey]string {
+		result := make(map[models.AlertRuleKey]string, len(keys))
+		for _, key := range keys {
+			result[key] = ruleOwner(members, key)
+		}
+		return result
+	}
+
+	t.Run("every member owns rules", func(t *testing.T) {
+		counts := map[string]int{}
+		for _, owner := range owners("a", "b", "c") {
+			counts[owner]++
+		}
+		require.Len(t, counts, 3)
+		for member, count := range counts {
+			require.Greater(t, count, 50, "member %s owns too few rules", member)
+		}
+	})
+
+	t.Run("only the rules of a member that leaves move", func(t *testing.T) {
+		before, after := owners("a", "b", "c"), owners("a", "c")
+		for key, owner := range before {
+			if owner != "b" {
+				require.Equal(t, owner, after[key])
+			}
+		}
+	})
+
+	t.Run("there is no owner without members", func(t *testing.T) {
+		require.Empty(t, ruleOwner(nil, keys[0]))
+	})
+}
+
+func TestProcessTicksWithSharding(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	dispatcherGroup, ctx := errgroup.WithContext(ctx)
+
+	ruleStore := newFakeRulesStore()
+	rules := models.GenerateAlertRules(20, models.AlertRuleGen(models.WithOrgID(1), models.WithInterval(time.Second), withQueryForState(t, eval.Alerting)))
+	ruleStore.PutRule(ctx, rules...)
+	members := newFakeMemberStore()
+
+	newShardedScheduler := func(instanceID string, is *state.FakeInstanceStore) (*schedule, chan evalAppliedInfo, chan models.AlertRuleKey) {
+		sch := setupScheduler(t, ruleStore, is, nil, nil, nil)
+		sch.sharder = newRuleSharder(instanceID, members, time.Minute, sch.clock, log.NewNopLogger())
+		evalAppliedCh := make(chan evalAppliedInfo, len(rules))
+		stopAppliedCh := make(chan models.AlertRuleKey, len(rules))
+		sch.evalAppliedFunc = func(key models.AlertRuleKey, now time.Time) {
+			evalAppliedCh <- evalAppliedInfo{alertDefKey: key, now: now}
+		}
+		sch.stopAppliedFunc = func(key models.AlertRuleKey) {
+			stopAppliedCh <- key
+		}
+		return sch, evalAppliedCh, stopAppliedCh
+	}
+	keysOf := func(items []readyToRunItem) []models.AlertRuleKey {
+		keys := make([]models.AlertRuleKey, 0, len(items))
+		for _, item := range items {
+			keys = append(keys, item.rule.GetKey())
+		}
+		return keys
+	}
+	allKeys := make([]models.AlertRuleKey, 0, len(rules))
+	for _, rule := range rules {
+		allKeys = append(allKeys, rule.GetKey())
+	}
+
+	isA := &state.FakeInstanceStore{}
+	schA, evalA, stopA := newShardedScheduler("a", isA)
+	tick := time.Time{}
+
+	t.Run("a single member evaluates all rules", func(t *testing.T) {
+		tick = tick.Add(time.Second)
+		scheduled, stopped := schA.processTick(ctx, dispatcherGroup, tick)
+
+		require.ElementsMatch(t, allKeys, keysOf(scheduled))
+		require.Empty(t, stopped)
+		assertEvalRun(t, evalA, tick, allKeys...)
+	})
+
+	schB, evalB, _ := newShardedScheduler("b", &state.FakeInstanceStore{})
+	var keysA, keysB []models.AlertRuleKey
+
+	t.Run("the rules are split when a member joins", func(t *testing.T) {
+		tick = tick.Add(time.Second)
+		scheduledB, _ := schB.processTick(ctx, dispatcherGroup, tick)
+		scheduledA, stoppedA := schA.processTick(ctx, dispatcherGroup, tick)
+
+		keysA, keysB = keysOf(scheduledA), keysOf(scheduledB)
+		require.NotEmpty(t, keysA)
+		require.NotEmpty(t, keysB)
+		require.ElementsMatch(t, allKeys, append(append([]models.AlertRuleKey{}, keysA...), keysB...))
+		require.Len(t, stoppedA, len(keysB))
+		for _, key := range keysB {
+			require.Contains(t, stoppedA, key)
+		}
+		assertStopRun(t, stopA, keysB...)
+		assertEvalRun(t, evalA, tick, keysA...)
+		assertEvalRun(t, evalB, tick, keysB...)
+	})
+
+	t.Run("the member that hands over a rule releases its state", func(t *testing.T) {
+		for _, key := range keysB {
+			require.Empty(t, schA.stateManager.GetStatesForRuleUID(key.OrgID, key.UID))
+		}
+		for _, key := range keysA {
+			require.NotEmpty(t, schA.stateManager.GetStatesForRuleUID(key.OrgID, key.UID))
+		}
+	})
+
+	t.Run("the rules of a member that leaves are taken over with their state", func(t *testing.T) {
+		schB.sharder.leave(ctx)
+		tick = tick.Add(time.Second)
+		scheduledA, stoppedA := schA.processTick(ctx, dispatcherGroup, tick)
+
+		require.ElementsMatch(t, allKeys, keysOf(scheduledA))
+		require.Empty(t, stoppedA)
+		assertEvalRun(t, evalA, tick, allKeys...)
+		for _, key := range keysB {
+			require.Contains(t, isA.RecordedOps, models.ListAlertInstancesQuery{RuleOrgID: key.OrgID, RuleUID: key.UID})
+		}
+		for _, key := range keysA {
+			require.NotContains(t, isA.RecordedOps, models.ListAlertInstancesQuery{RuleOrgID: key.OrgID, RuleUID: key.UID})
+		}
+	})
+}
@@ -2,6 +2,8 @@ package schedule
 
 import (
 	"context"
+	"sort"
+	"sync"
 	"testing"
 	"time"
 
@@ -80,3 +82,39 @@ func (f *fakeRulesStore) DeleteRule(rules ...*models.AlertRule) {
 func (f *fakeRulesStore) getNamespaceTitle(uid string) string {
 	return "TEST-FOLDER-" + uid
 }
+
+type fakeMemberStore struct {
+	mtx        sync.Mutex
+	heartbeats map[string]time.Time
+}
+
+func newFakeMemberStore() *fakeMemberStore {
+	return &fakeMemberStore{heartbeats: map[string]time.Time{}}
+}
+
+func (f *fakeMemberStore) HeartbeatSchedulerMember(_ context.Context, instanceID string, at time.Time) error {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.heartbeats[instanceID] = at
+	return nil
+}
+
+func (f *fakeMemberStore) GetSchedulerMembers(_ context.Context, since time.Time) ([]string, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	members := make([]string, 0, len(f.heartbeats))
+	for id, at := range f.heartbeats {
+		if !at.Before(since) {
+			members = append(members, id)
+		}
+	}
+	sort.Strings(members)
+	return members, nil
+}
+
+func (f *fakeMemberStore) DeleteSchedulerMember(_ context.Context, instanceID string) error {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	delete(f.heartbeats, instanceID)
+	return nil
+}
@@ -183,6 +183,15 @@ func (c *cache) setAllStates(newStates map[int64]map[string]*ruleStates) {
 	c.states = newStates
 }
 
+func (c *cache) setRuleStates(orgID int64, uid string, rs *ruleStates) {
+	c.mtxStates.Lock()
+	defer c.mtxStates.Unlock()
+	if _, ok := c.states[orgID]; !ok {
+		c.states[orgID] = make(map[string]*ruleStates)
+	}
+	c.states[orgID][uid] = rs
+}
+
 func (c *cache) set(entry *State) {
 	c.mtxStates.Lock()
 	defer c.mtxStates.Unlock()
@@ -121,24 +121,8 @@ func (st *Manager) Warm(ctx context.Context, rulesReader RuleReader) {
 				orgStates[entry.RuleUID] = rulesStates
 			}
 
-			lbs := map[string]string(entry.Labels)
-			cacheID, err := entry.Labels.StringKey()
-			if err != nil {
-				st.log.Error("Error getting cacheId for entry", "error", err)
-			}
-			rulesStates.states[cacheID] = &State{
-				AlertRuleUID:         entry.RuleUID,
-				OrgID:                entry.RuleOrgID,
-				CacheID:              cacheID,
-				Labels:               lbs,
-				State:                translateInstanceState(entry.CurrentState),
-				StateReason:          entry.CurrentReason,
-				LastEvaluationString: "",
-				StartsAt:             entry.CurrentStateSince,
-				EndsAt:               entry.CurrentStateEnd,
-				LastEvaluationTime:   entry.LastEvalTime,
-				Annotations:          ruleForEntry.Annotations,
-			}
+			state := st.stateFromInstance(entry, ruleForEntry)
+			rulesStates.states[state.CacheID] = state
 			statesCount++
 		}
 	}
@@ -146,6 +130,57 @@ func (st *Manager) Warm(ctx context.Context, rulesReader RuleReader) {
 	st.log.Info("State cache has been initialized", "states", statesCount, "duration", time.Since(startTime))
 }
 
+// TakeOverRule replaces the states of the rule in the cache with the states saved in the database. It is used when
+// the evaluation of the rule moves to this instance from another one, which saved the states of its last evaluation.
+func (st *Manager) TakeOverRule(ctx context.Context, rule *ngModels.AlertRule) error {
+	if st.instanceStore == nil {
+		return nil
+	}
+	cmd := ngModels.ListAlertInstancesQuery{
+		RuleOrgID: rule.OrgID,
+		RuleUID:   rule.UID,
+	}
+	if err := st.instanceStore.ListAlertInstances(ctx, &cmd); err != nil {
+		return err
+	}
+	rs := &ruleStates{states: make(map[string]*State, len(cmd.Result))}
+	for _, entry := range cmd.Result {
+		state := st.stateFromInstance(entry, rule)
+		rs.states[state.CacheID] = state
+	}
+	st.cache.setRuleStates(rule.OrgID, rule.UID, rs)
+	st.log.Debug("Took over the state of the rule", append(rule.GetKey().LogContext(), "states", len(rs.states))...)
+	return nil
+}
+
+// ReleaseRule deletes the states of the rule from the cache when the rule is evaluated by another instance. Unlike
+// ResetStateByRuleUID, it keeps the states in the database so that the other instance can take them over.
+func (st *Manager) ReleaseRule(ruleKey ngModels.AlertRuleKey) []*State {
+	states := st.cache.removeByRuleUID(ruleKey.OrgID, ruleKey.UID)
+	st.log.Debug("Released the state of the rule", append(ruleKey.LogContext(), "states", len(states))...)
+	return states
+}
+
+func (st *Manager) stateFromInstance(entry *ngModels.AlertInstance, rule *ngModels.AlertRule) *State {
+	cacheID, err := entry.Labels.StringKey()
+	if err != nil {
+		st.log.Error("Error getting cacheId for entry", "error", err)
+	}
+	return &State{
+		AlertRuleUID:         entry.RuleUID,
+		OrgID:                entry.RuleOrgID,
+		CacheID:              cacheID,
+		Labels:               map[string]string(entry.Labels),
+		State:                translateInstanceState(entry.CurrentState),
+		StateReason:          entry.CurrentReason,
+		LastEvaluationString: "",
+		StartsAt:             entry.CurrentStateSince,
+		EndsAt:               entry.CurrentStateEnd,
+		LastEvaluationTime:   entry.LastEvalTime,
+		Annotations:          rule.Annotations,
+	}
+}
+
 func (st *Manager) Get(orgID int64, alertRuleUID, stateId string) *State {
 	return st.cache.get(orgID, alertRuleUID, stateId)
 }
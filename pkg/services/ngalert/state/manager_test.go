@@ -117,6 +117,46 @@ func TestWarmStateCache(t *testing.T) {
 	})
 }
 
+func TestTakeOverAndReleaseRule(t *testing.T) {
+	evaluationTime, err := time.Parse("2006-01-02", "2021-03-25")
+	require.NoError(t, err)
+	ctx := context.Background()
+	_, dbstore := tests.SetupTestEnv(t, 1)
+
+	rule := tests.CreateTestAlertRule(t, ctx, dbstore, 600, 1)
+	labels := models.InstanceLabels{"test1": "testValue1"}
+	_, hash, _ := labels.StringAndHash()
+	require.NoError(t, dbstore.SaveAlertInstances(ctx, models.AlertInstance{
+		AlertInstanceKey: models.AlertInstanceKey{
+			RuleOrgID:  rule.OrgID,
+			RuleUID:    rule.UID,
+			LabelsHash: hash,
+		},
+		CurrentState:      models.InstanceStateFiring,
+		LastEvalTime:      evaluationTime,
+		CurrentStateSince: evaluationTime.Add(-1 * time.Minute),
+		CurrentStateEnd:   evaluationTime.Add(1 * time.Minute),
+		Labels:            labels,
+	}))
+	st := state.NewManager(testMetrics.GetStateMetrics(), nil, dbstore, &state.NoopImageService{}, clock.NewMock(), &state.FakeHistorian{})
+
+	require.NoError(t, st.TakeOverRule(ctx, rule))
+	states := st.GetStatesForRuleUID(rule.OrgID, rule.UID)
+	require.Len(t, states, 1)
+	require.Equal(t, eval.Alerting, states[0].State)
+	require.Equal(t, evaluationTime.Add(-1*time.Minute), states[0].StartsAt)
+	require.Equal(t, rule.Annotations, states[0].Annotations)
+
+	released := st.ReleaseRule(rule.GetKey())
+	require.Len(t, released, 1)
+	require.Empty(t, st.GetStatesForRuleUID(rule.OrgID, rule.UID))
+
+	// The state is kept in the database for the instance that takes over the rule.
+	q := &models.ListAlertInstancesQuery{RuleOrgID: rule.OrgID, RuleUID: rule.UID}
+	require.NoError(t, dbstore.ListAlertInstances(ctx, q))
+	require.Len(t, q.Result, 1)
+}
+
 func TestDashboardAnnotations(t *testing.T) {
 	evaluationTime, err := time.Parse("2006-01-02", "2022-01-01")
 	require.NoError(t, err)
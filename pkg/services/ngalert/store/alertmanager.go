@@ -61,6 +61,43 @@ func (st *DBstore) GetAllLatestAlertmanagerConfiguration(ctx context.Context) ([
 	return result, nil
 }
 
+// GetAlertmanagerConfigurationHistory returns the stored versions of the alertmanager configuration of the organization,
+// the most recent first. At most limit versions are returned if limit is greater than 0.
+func (st *DBstore) GetAlertmanagerConfigurationHistory(ctx context.Context, orgID int64, limit int) ([]*models.AlertConfiguration, error) {
+	var result []*models.AlertConfiguration
+	err := st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		q := sess.Table("alert_configuration").Where("org_id = ?", orgID).Desc("id")
+		if limit > 0 {
+			q = q.Limit(limit)
+		}
+		return q.Find(&result)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetAlertmanagerConfigurationByID returns the stored version of the alertmanager configuration with the ID.
+// It returns ErrNoAlertmanagerConfiguration if the organization has no such version.
+func (st *DBstore) GetAlertmanagerConfigurationByID(ctx context.Context, orgID int64, id int64) (*models.AlertConfiguration, error) {
+	c := &models.AlertConfiguration{}
+	err := st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		ok, err := sess.Table("alert_configuration").Where("org_id = ? AND id = ?", orgID, id).Get(c)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrNoAlertmanagerConfiguration
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
 // SaveAlertmanagerConfiguration creates an alertmanager configuration.
 func (st DBstore) SaveAlertmanagerConfiguration(ctx context.Context, cmd *models.SaveAlertmanagerConfigurationCmd) error {
 	return st.SaveAlertmanagerConfigurationWithCallback(ctx, cmd, func() error { return nil })
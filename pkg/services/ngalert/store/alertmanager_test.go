@@ -76,6 +76,50 @@ func TestIntegrationAlertManagerHash(t *testing.T) {
 	})
 }
 
+func TestIntegrationAlertManagerConfigHistory(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+	sqlStore := db.InitTestDB(t)
+	store := &DBstore{
+		SQLStore: sqlStore,
+		Logger:   log.NewNopLogger(),
+	}
+	var orgID int64 = 4
+	for _, config := range []string{"first-record", "second-record", "third-record"} {
+		err := store.SaveAlertmanagerConfiguration(context.Background(), &models.SaveAlertmanagerConfigurationCmd{
+			AlertmanagerConfiguration: config,
+			ConfigurationVersion:      "v1",
+			OrgID:                     orgID,
+		})
+		require.NoError(t, err)
+	}
+
+	t.Run("history should return the most recent records first", func(t *testing.T) {
+		history, err := store.GetAlertmanagerConfigurationHistory(context.Background(), orgID, 0)
+		require.NoError(t, err)
+		require.Len(t, history, 3)
+		require.Equal(t, "third-record", history[0].AlertmanagerConfiguration)
+		require.Equal(t, "first-record", history[2].AlertmanagerConfiguration)
+
+		history, err = store.GetAlertmanagerConfigurationHistory(context.Background(), orgID, 2)
+		require.NoError(t, err)
+		require.Len(t, history, 2)
+		require.Equal(t, "second-record", history[1].AlertmanagerConfiguration)
+	})
+	t.Run("a record should be returned by its ID only in its organization", func(t *testing.T) {
+		history, err := store.GetAlertmanagerConfigurationHistory(context.Background(), orgID, 0)
+		require.NoError(t, err)
+
+		config, err := store.GetAlertmanagerConfigurationByID(context.Background(), orgID, history[1].ID)
+		require.NoError(t, err)
+		require.Equal(t, "second-record", config.AlertmanagerConfiguration)
+
+		_, err = store.GetAlertmanagerConfigurationByID(context.Background(), orgID+1, history[1].ID)
+		require.ErrorIs(t, err, ErrNoAlertmanagerConfiguration)
+	})
+}
+
 func TestIntegrationAlertManagerConfigCleanup(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test")
@@ -0,0 +1,119 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+// defaultNotificationDeliveriesLimit is the number of deliveries returned when the query has no limit.
+const defaultNotificationDeliveriesLimit = 100
+
+// NotificationDeliveryStore is the database interface for the delivery history and
+// the retry queue of the notifications sent by the Alertmanager.
+type NotificationDeliveryStore interface {
+	// SaveNotificationDelivery adds a delivery attempt to the history.
+	SaveNotificationDelivery(ctx context.Context, delivery *models.NotificationDelivery) error
+	// GetNotificationDeliveries returns the deliveries that match the query, the most recent first.
+	GetNotificationDeliveries(ctx context.Context, query *models.GetNotificationDeliveriesQuery) ([]*models.NotificationDelivery, error)
+	// DeleteNotificationDeliveries deletes the deliveries of an organization created before the time.
+	// It returns the number of deleted deliveries.
+	DeleteNotificationDeliveries(ctx context.Context, orgID int64, before time.Time) (int64, error)
+
+	// SaveNotificationRetry adds the retry to the queue or updates it when it has an ID.
+	SaveNotificationRetry(ctx context.Context, retry *models.NotificationRetry) error
+	// GetDueNotificationRetries returns up to limit retries of an organization that are due at the time.
+	GetDueNotificationRetries(ctx context.Context, orgID int64, now time.Time, limit int) ([]*models.NotificationRetry, error)
+	// DeleteNotificationRetry removes the retry from the queue.
+	DeleteNotificationRetry(ctx context.Context, orgID int64, id int64) error
+}
+
+func (st DBstore) SaveNotificationDelivery(ctx context.Context, delivery *models.NotificationDelivery) error {
+	return st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		if delivery.Fingerprints == nil {
+			delivery.Fingerprints = []string{}
+		}
+		if _, err := sess.Insert(delivery); err != nil {
+			return fmt.Errorf("failed to save notification delivery: %w", err)
+		}
+		return nil
+	})
+}
+
+func (st DBstore) GetNotificationDeliveries(ctx context.Context, query *models.GetNotificationDeliveriesQuery) ([]*models.NotificationDelivery, error) {
+	var deliveries []*models.NotificationDelivery
+	err := st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		q := sess.Where("org_id = ?", query.OrgID)
+		if query.Receiver != "" {
+			q = q.And("receiver = ?", query.Receiver)
+		}
+		if query.Integration != "" {
+			q = q.And("integration = ?", query.Integration)
+		}
+		if query.Status != "" {
+			q = q.And("status = ?", query.Status)
+		}
+		if !query.From.IsZero() {
+			q = q.And("created_at >= ?", query.From)
+		}
+		if !query.To.IsZero() {
+			q = q.And("created_at <= ?", query.To)
+		}
+
+		limit := query.Limit
+		if limit <= 0 {
+			limit = defaultNotificationDeliveriesLimit
+		}
+		return q.Desc("created_at", "id").Limit(limit).Find(&deliveries)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (st DBstore) DeleteNotificationDeliveries(ctx context.Context, orgID int64, before time.Time) (int64, error) {
+	var deleted int64
+	err := st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		n, err := sess.Where("org_id = ? AND created_at < ?", orgID, before).Delete(&models.NotificationDelivery{})
+		deleted = n
+		return err
+	})
+	return deleted, err
+}
+
+func (st DBstore) SaveNotificationRetry(ctx context.Context, retry *models.NotificationRetry) error {
+	return st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		if retry.ID == 0 {
+			if _, err := sess.Insert(retry); err != nil {
+				return fmt.Errorf("failed to save notification retry: %w", err)
+			}
+			return nil
+		}
+		if _, err := sess.ID(retry.ID).Where("org_id = ?", retry.OrgID).AllCols().Update(retry); err != nil {
+			return fmt.Errorf("failed to update notification retry: %w", err)
+		}
+		return nil
+	})
+}
+
+func (st DBstore) GetDueNotificationRetries(ctx context.Context, orgID int64, now time.Time, limit int) ([]*models.NotificationRetry, error) {
+	var retries []*models.NotificationRetry
+	err := st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		return sess.Where("org_id = ? AND next_attempt_at <= ?", orgID, now).Asc("next_attempt_at", "id").Limit(limit).Find(&retries)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return retries, nil
+}
+
+func (st DBstore) DeleteNotificationRetry(ctx context.Context, orgID int64, id int64) error {
+	return st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		_, err := sess.Where("org_id = ? AND id = ?", orgID, id).Delete(&models.NotificationRetry{})
+		return err
+	})
+}
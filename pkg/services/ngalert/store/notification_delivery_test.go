@@ -0,0 +1,120 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/tests"
+)
+
+func TestIntegrationNotificationDeliveries(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	ctx := context.Background()
+	_, dbstore := tests.SetupTestEnv(t, baseIntervalSeconds)
+
+	// our database schema uses second precision for timestamps
+	now := time.Now().UTC().Truncate(time.Second)
+	deliveries := []*models.NotificationDelivery{
+		{OrgID: 1, Receiver: "slack", Integration: "slack", Status: models.NotificationDeliverySuccess, CreatedAt: now.Add(-2 * time.Hour)},
+		{OrgID: 1, Receiver: "ops", Integration: "webhook", Status: models.NotificationDeliveryFailed, StatusCode: 503, Error: "webhook response status 503", Fingerprints: []string{"a", "b"}, Duration: time.Second, CreatedAt: now.Add(-time.Hour)},
+		{OrgID: 1, Receiver: "ops", Integration: "webhook", Status: models.NotificationDeliverySuccess, StatusCode: 200, Retry: true, CreatedAt: now},
+		{OrgID: 2, Receiver: "ops", Integration: "webhook", Status: models.NotificationDeliverySuccess, CreatedAt: now},
+	}
+	for _, d := range deliveries {
+		require.NoError(t, dbstore.SaveNotificationDelivery(ctx, d))
+	}
+
+	t.Run("deliveries are returned most recent first", func(t *testing.T) {
+		result, err := dbstore.GetNotificationDeliveries(ctx, &models.GetNotificationDeliveriesQuery{OrgID: 1})
+		require.NoError(t, err)
+		require.Len(t, result, 3)
+		require.Equal(t, deliveries[2].ID, result[0].ID)
+		require.Equal(t, deliveries[0].ID, result[2].ID)
+		require.Equal(t, []string{"a", "b"}, result[1].Fingerprints)
+		require.Equal(t, time.Second, result[1].Duration)
+		require.Equal(t, 503, result[1].StatusCode)
+		require.True(t, result[0].Retry)
+	})
+
+	t.Run("deliveries are filtered", func(t *testing.T) {
+		result, err := dbstore.GetNotificationDeliveries(ctx, &models.GetNotificationDeliveriesQuery{
+			OrgID:       1,
+			Integration: "webhook",
+			Status:      models.NotificationDeliveryFailed,
+		})
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		require.Equal(t, deliveries[1].ID, result[0].ID)
+
+		result, err = dbstore.GetNotificationDeliveries(ctx, &models.GetNotificationDeliveriesQuery{
+			OrgID: 1,
+			From:  now.Add(-90 * time.Minute),
+			To:    now.Add(-30 * time.Minute),
+		})
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		require.Equal(t, deliveries[1].ID, result[0].ID)
+
+		result, err = dbstore.GetNotificationDeliveries(ctx, &models.GetNotificationDeliveriesQuery{OrgID: 1, Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+	})
+
+	t.Run("expired deliveries are deleted", func(t *testing.T) {
+		deleted, err := dbstore.DeleteNotificationDeliveries(ctx, 1, now.Add(-30*time.Minute))
+		require.NoError(t, err)
+		require.Equal(t, int64(2), deleted)
+
+		result, err := dbstore.GetNotificationDeliveries(ctx, &models.GetNotificationDeliveriesQuery{OrgID: 1})
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+	})
+}
+
+func TestIntegrationNotificationRetries(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	ctx := context.Background()
+	_, dbstore := tests.SetupTestEnv(t, baseIntervalSeconds)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	due := &models.NotificationRetry{OrgID: 1, Receiver: "ops", Integration: "webhook", Alerts: "[]", NextAttemptAt: now.Add(-time.Minute), CreatedAt: now}
+	later := &models.NotificationRetry{OrgID: 1, Receiver: "ops", Integration: "webhook", Alerts: "[]", NextAttemptAt: now.Add(time.Hour), CreatedAt: now}
+	otherOrg := &models.NotificationRetry{OrgID: 2, Receiver: "ops", Integration: "webhook", Alerts: "[]", NextAttemptAt: now.Add(-time.Minute), CreatedAt: now}
+	for _, r := range []*models.NotificationRetry{due, later, otherOrg} {
+		require.NoError(t, dbstore.SaveNotificationRetry(ctx, r))
+		require.NotZero(t, r.ID)
+	}
+
+	result, err := dbstore.GetDueNotificationRetries(ctx, 1, now, 10)
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	require.Equal(t, due.ID, result[0].ID)
+
+	due.Attempts = 1
+	due.LastError = "timeout"
+	due.NextAttemptAt = now.Add(2 * time.Hour)
+	require.NoError(t, dbstore.SaveNotificationRetry(ctx, due))
+
+	result, err = dbstore.GetDueNotificationRetries(ctx, 1, now.Add(3*time.Hour), 10)
+	require.NoError(t, err)
+	require.Len(t, result, 2)
+	require.Equal(t, later.ID, result[0].ID)
+	require.Equal(t, 1, result[1].Attempts)
+	require.Equal(t, "timeout", result[1].LastError)
+
+	require.NoError(t, dbstore.DeleteNotificationRetry(ctx, 1, due.ID))
+	result, err = dbstore.GetDueNotificationRetries(ctx, 1, now.Add(3*time.Hour), 10)
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	require.Equal(t, later.ID, result[0].ID)
+}
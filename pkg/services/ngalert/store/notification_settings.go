@@ -0,0 +1,38 @@
+package store
+
+import (
+	"context"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+// NotificationSettingsStore is the database interface for the notification settings of the alert rules.
+type NotificationSettingsStore interface {
+	// ListNotificationSettings returns the notification settings of the rules of an organization that have them.
+	ListNotificationSettings(ctx context.Context, orgID int64) (map[models.AlertRuleKey][]models.NotificationSettings, error)
+}
+
+func (st DBstore) ListNotificationSettings(ctx context.Context, orgID int64) (map[models.AlertRuleKey][]models.NotificationSettings, error) {
+	var rules []models.AlertRule
+	err := st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		return sess.Table("alert_rule").
+			Cols("org_id", "uid", "notification_settings").
+			Where("org_id = ?", orgID).
+			// xorm writes an empty list of settings as the JSON "null"
+			And("notification_settings IS NOT NULL AND notification_settings <> 'null'").
+			Find(&rules)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[models.AlertRuleKey][]models.NotificationSettings)
+	for _, rule := range rules {
+		if len(rule.NotificationSettings) == 0 {
+			continue
+		}
+		result[rule.GetKey()] = rule.NotificationSettings
+	}
+	return result, nil
+}
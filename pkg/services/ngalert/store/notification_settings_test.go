@@ -0,0 +1,60 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/tests"
+)
+
+func TestIntegrationListNotificationSettings(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	ctx := context.Background()
+	_, dbstore := tests.SetupTestEnv(t, baseIntervalSeconds)
+
+	groupWait := model.Duration(time.Minute)
+	settings := models.NotificationSettings{
+		Receiver:  "team-a",
+		GroupBy:   []string{"alertname", "cluster"},
+		GroupWait: &groupWait,
+	}
+
+	withSettings := tests.CreateTestAlertRule(t, ctx, dbstore, 60, 1)
+	_ = tests.CreateTestAlertRule(t, ctx, dbstore, 60, 1)
+	otherOrg := tests.CreateTestAlertRule(t, ctx, dbstore, 60, 2)
+
+	for _, rule := range []*models.AlertRule{withSettings, otherOrg} {
+		updated := models.CopyRule(rule)
+		updated.NotificationSettings = []models.NotificationSettings{settings}
+		require.NoError(t, dbstore.UpdateAlertRules(ctx, []models.UpdateRule{{Existing: rule, New: *updated}}))
+	}
+
+	result, err := dbstore.ListNotificationSettings(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, map[models.AlertRuleKey][]models.NotificationSettings{
+		withSettings.GetKey(): {settings},
+	}, result)
+
+	t.Run("notification settings are returned with the rule", func(t *testing.T) {
+		q := &models.GetAlertRuleByUIDQuery{OrgID: 1, UID: withSettings.UID}
+		require.NoError(t, dbstore.GetAlertRuleByUID(ctx, q))
+		require.Equal(t, &settings, q.Result.GetNotificationSettings())
+	})
+
+	t.Run("rules cannot have more than one notification settings", func(t *testing.T) {
+		q := &models.GetAlertRuleByUIDQuery{OrgID: 1, UID: withSettings.UID}
+		require.NoError(t, dbstore.GetAlertRuleByUID(ctx, q))
+		updated := models.CopyRule(q.Result)
+		updated.NotificationSettings = []models.NotificationSettings{settings, settings}
+		err := dbstore.UpdateAlertRules(ctx, []models.UpdateRule{{Existing: q.Result, New: *updated}})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,54 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+// SchedulerMemberStore is the database interface for the members of the sharded scheduler.
+type SchedulerMemberStore interface {
+	// HeartbeatSchedulerMember records that the instance is alive at the time, and adds it to the members if needed.
+	HeartbeatSchedulerMember(ctx context.Context, instanceID string, at time.Time) error
+	// GetSchedulerMembers returns the IDs of the instances whose last heartbeat is not before the time, sorted.
+	GetSchedulerMembers(ctx context.Context, since time.Time) ([]string, error)
+	// DeleteSchedulerMember removes the instance from the members.
+	DeleteSchedulerMember(ctx context.Context, instanceID string) error
+}
+
+func (st DBstore) HeartbeatSchedulerMember(ctx context.Context, instanceID string, at time.Time) error {
+	return st.SQLStore.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		updated, err := sess.Where("instance_id = ?", instanceID).
+			Cols("last_heartbeat").
+			Update(&models.SchedulerMember{LastHeartbeat: at.Unix()})
+		if err != nil || updated > 0 {
+			return err
+		}
+		_, err = sess.Insert(&models.SchedulerMember{InstanceID: instanceID, LastHeartbeat: at.Unix()})
+		return err
+	})
+}
+
+func (st DBstore) GetSchedulerMembers(ctx context.Context, since time.Time) ([]string, error) {
+	members := make([]string, 0)
+	err := st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		return sess.Table(&models.SchedulerMember{}).
+			Where("last_heartbeat >= ?", since.Unix()).
+			Asc("instance_id").
+			Cols("instance_id").
+			Find(&members)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+func (st DBstore) DeleteSchedulerMember(ctx context.Context, instanceID string) error {
+	return st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		_, err := sess.Where("instance_id = ?", instanceID).Delete(&models.SchedulerMember{})
+		return err
+	})
+}
@@ -0,0 +1,43 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/tests"
+)
+
+func TestIntegrationSchedulerMembers(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	ctx := context.Background()
+	_, dbstore := tests.SetupTestEnv(t, baseIntervalSeconds)
+
+	now := time.Now()
+	require.NoError(t, dbstore.HeartbeatSchedulerMember(ctx, "b", now))
+	require.NoError(t, dbstore.HeartbeatSchedulerMember(ctx, "a", now.Add(-time.Minute)))
+	require.NoError(t, dbstore.HeartbeatSchedulerMember(ctx, "c", now.Add(-2*time.Minute)))
+
+	members, err := dbstore.GetSchedulerMembers(ctx, now.Add(-90*time.Second))
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b"}, members)
+
+	t.Run("a heartbeat updates the member", func(t *testing.T) {
+		require.NoError(t, dbstore.HeartbeatSchedulerMember(ctx, "c", now))
+		members, err := dbstore.GetSchedulerMembers(ctx, now.Add(-time.Second))
+		require.NoError(t, err)
+		require.Equal(t, []string{"b", "c"}, members)
+	})
+
+	t.Run("a deleted member is removed", func(t *testing.T) {
+		require.NoError(t, dbstore.DeleteSchedulerMember(ctx, "b"))
+		members, err := dbstore.GetSchedulerMembers(ctx, now.Add(-time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, []string{"a", "c"}, members)
+	})
+}
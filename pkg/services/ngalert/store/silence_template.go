@@ -0,0 +1,100 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+// SilenceTemplateStore is the database interface for the silence templates.
+type SilenceTemplateStore interface {
+	// GetSilenceTemplates returns the silence templates of an organization sorted by name.
+	GetSilenceTemplates(ctx context.Context, orgID int64) ([]*models.SilenceTemplate, error)
+	// GetSilenceTemplate returns models.ErrSilenceTemplateNotFound if the template does not exist.
+	GetSilenceTemplate(ctx context.Context, orgID int64, uid string) (*models.SilenceTemplate, error)
+	// SaveSilenceTemplate inserts the template, or updates it when it has an ID.
+	SaveSilenceTemplate(ctx context.Context, template *models.SilenceTemplate) error
+	// DeleteSilenceTemplate returns models.ErrSilenceTemplateNotFound if the template does not exist.
+	DeleteSilenceTemplate(ctx context.Context, orgID int64, uid string) error
+}
+
+func (st DBstore) GetSilenceTemplates(ctx context.Context, orgID int64) ([]*models.SilenceTemplate, error) {
+	templates := make([]*models.SilenceTemplate, 0)
+	err := st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		return sess.Where("org_id = ?", orgID).Asc("name").Find(&templates)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+func (st DBstore) GetSilenceTemplate(ctx context.Context, orgID int64, uid string) (*models.SilenceTemplate, error) {
+	template := &models.SilenceTemplate{}
+	err := st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		has, err := sess.Where("org_id = ? AND uid = ?", orgID, uid).Get(template)
+		if err != nil {
+			return err
+		}
+		if !has {
+			return models.ErrSilenceTemplateNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+func (st DBstore) SaveSilenceTemplate(ctx context.Context, template *models.SilenceTemplate) error {
+	return st.SQLStore.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		exists, err := sess.Table(&models.SilenceTemplate{}).
+			Where("org_id = ? AND name = ? AND id <> ?", template.OrgID, template.Name, template.ID).
+			Exist()
+		if err != nil {
+			return err
+		}
+		if exists {
+			return models.ErrSilenceTemplateNameDuplicate
+		}
+
+		if template.Matchers == nil {
+			template.Matchers = []models.SilenceMatcher{}
+		}
+		template.Updated = TimeNow().UTC()
+		if template.ID == 0 {
+			if template.UID == "" {
+				template.UID = util.GenerateShortUID()
+			}
+			if _, err := sess.Insert(template); err != nil {
+				return fmt.Errorf("failed to save silence template: %w", err)
+			}
+			return nil
+		}
+		affected, err := sess.ID(template.ID).Where("org_id = ?", template.OrgID).AllCols().Update(template)
+		if err != nil {
+			return fmt.Errorf("failed to update silence template: %w", err)
+		}
+		if affected == 0 {
+			return models.ErrSilenceTemplateNotFound
+		}
+		return nil
+	})
+}
+
+func (st DBstore) DeleteSilenceTemplate(ctx context.Context, orgID int64, uid string) error {
+	return st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		affected, err := sess.Where("org_id = ? AND uid = ?", orgID, uid).Delete(&models.SilenceTemplate{})
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return models.ErrSilenceTemplateNotFound
+		}
+		return nil
+	})
+}
@@ -0,0 +1,56 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/tests"
+)
+
+func TestIntegrationSilenceTemplates(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	ctx := context.Background()
+	_, dbstore := tests.SetupTestEnv(t, baseIntervalSeconds)
+
+	matchers := []models.SilenceMatcher{{Name: "cluster", Value: "eu-.*", IsRegex: true, IsEqual: true}}
+	maintenance := &models.SilenceTemplate{OrgID: 1, Name: "maintenance", Matchers: matchers}
+	require.NoError(t, dbstore.SaveSilenceTemplate(ctx, maintenance))
+	require.NotEmpty(t, maintenance.UID)
+	require.NoError(t, dbstore.SaveSilenceTemplate(ctx, &models.SilenceTemplate{OrgID: 1, Name: "deploy", Matchers: matchers}))
+	require.NoError(t, dbstore.SaveSilenceTemplate(ctx, &models.SilenceTemplate{OrgID: 2, Name: "maintenance", Matchers: matchers}))
+
+	templates, err := dbstore.GetSilenceTemplates(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, templates, 2)
+	require.Equal(t, "deploy", templates[0].Name)
+	require.Equal(t, "maintenance", templates[1].Name)
+	require.Equal(t, matchers, templates[1].Matchers)
+
+	t.Run("names are unique in an organization", func(t *testing.T) {
+		err := dbstore.SaveSilenceTemplate(ctx, &models.SilenceTemplate{OrgID: 1, Name: "deploy", Matchers: matchers})
+		require.ErrorIs(t, err, models.ErrSilenceTemplateNameDuplicate)
+	})
+
+	t.Run("updating the template keeps its UID", func(t *testing.T) {
+		maintenance.Comment = "Planned maintenance"
+		uid := maintenance.UID
+		require.NoError(t, dbstore.SaveSilenceTemplate(ctx, maintenance))
+
+		saved, err := dbstore.GetSilenceTemplate(ctx, 1, uid)
+		require.NoError(t, err)
+		require.Equal(t, "Planned maintenance", saved.Comment)
+	})
+
+	t.Run("deleting the template", func(t *testing.T) {
+		require.NoError(t, dbstore.DeleteSilenceTemplate(ctx, 1, maintenance.UID))
+		_, err := dbstore.GetSilenceTemplate(ctx, 1, maintenance.UID)
+		require.ErrorIs(t, err, models.ErrSilenceTemplateNotFound)
+		require.ErrorIs(t, dbstore.DeleteSilenceTemplate(ctx, 1, maintenance.UID), models.ErrSilenceTemplateNotFound)
+	})
+}
@@ -2,6 +2,8 @@ package notifications
 
 import (
 	"context"
+	"crypto/tls"
+	"net/http"
 	"regexp"
 	"testing"
 
@@ -301,3 +303,20 @@ func createSmtpConfig() *setting.Cfg {
 	cfg.Smtp.ContentTypes = []string{"text/html", "text/plain"}
 	return cfg
 }
+
+func TestClientWithTLSConfig(t *testing.T) {
+	tlsConfig := &tls.Config{ServerName: "a"}
+	client := clientWithTLSConfig(tlsConfig)
+	require.Same(t, client, clientWithTLSConfig(tlsConfig), "the client is built once per TLS configuration")
+	require.NotSame(t, client, clientWithTLSConfig(&tls.Config{ServerName: "b"}))
+
+	transport := client.(*http.Client).Transport.(*http.Transport)
+	require.Equal(t, "a", transport.TLSClientConfig.ServerName)
+	require.Equal(t, tls.RenegotiateFreelyAsClient, transport.TLSClientConfig.Renegotiation)
+	require.Equal(t, tls.RenegotiateNever, tlsConfig.Renegotiation, "the TLS configuration of the webhook is not changed")
+
+	for i := 0; i < maxTLSClients; i++ {
+		clientWithTLSConfig(&tls.Config{})
+	}
+	require.LessOrEqual(t, len(tlsClients.clients), maxTLSClients)
+}
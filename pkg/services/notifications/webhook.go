@@ -8,6 +8,7 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/grafana/grafana/pkg/util"
@@ -25,6 +26,9 @@ type Webhook struct {
 	// Validation is a function that will validate the response body and statusCode of the webhook. Any returned error will cause the webhook request to be considered failed.
 	// This can be useful when a webhook service communicates failures in creative ways, such as using the response body instead of the status code.
 	Validation func(body []byte, statusCode int) error
+
+	// TLSConfig replaces the default TLS configuration of the client when set.
+	TLSConfig *tls.Config
 }
 
 // WebhookClient exists to mock the client in tests.
@@ -54,8 +58,8 @@ func (ns *NotificationService) sendWebRequestSync(ctx context.Context, webhook *
 
 	ns.log.Debug("Sending webhook", "url", webhook.Url, "http method", webhook.HttpMethod)
 
-	if webhook.HttpMethod != http.MethodPost && webhook.HttpMethod != http.MethodPut {
-		return fmt.Errorf("webhook only supports HTTP methods PUT or POST")
+	if webhook.HttpMethod != http.MethodPost && webhook.HttpMethod != http.MethodPut && webhook.HttpMethod != http.MethodPatch {
+		return fmt.Errorf("webhook only supports HTTP methods PUT, POST or PATCH")
 	}
 
 	request, err := http.NewRequestWithContext(ctx, webhook.HttpMethod, webhook.Url, bytes.NewReader([]byte(webhook.Body)))
@@ -78,7 +82,12 @@ func (ns *NotificationService) sendWebRequestSync(ctx context.Context, webhook *
 		request.Header.Set(k, v)
 	}
 
-	resp, err := netClient.Do(request)
+	client := netClient
+	if webhook.TLSConfig != nil {
+		client = clientWithTLSConfig(webhook.TLSConfig)
+	}
+
+	resp, err := client.Do(request)
 	if err != nil {
 		return err
 	}
@@ -109,3 +118,42 @@ func (ns *NotificationService) sendWebRequestSync(ctx context.Context, webhook *
 	ns.log.Debug("Webhook failed", "url", webhook.Url, "statuscode", resp.Status, "body", string(body))
 	return fmt.Errorf("webhook response status %v", resp.Status)
 }
+
+// maxTLSClients bounds the clients cached by TLS configuration. The notifiers build their TLS configuration when
+// the alerting configuration is applied, so the cache is emptied when it's full rather than growing with every
+// configuration.
+const maxTLSClients = 100
+
+// tlsClients caches the clients by TLS configuration, so that the webhooks with the same configuration reuse the
+// connections of the same transport.
+var tlsClients = struct {
+	sync.Mutex
+	clients map[*tls.Config]*http.Client
+}{clients: make(map[*tls.Config]*http.Client)}
+
+// clientWithTLSConfig returns a client that uses the same transport settings as the default client
+// but the given TLS configuration. The client is built once per configuration.
+func clientWithTLSConfig(tlsConfig *tls.Config) WebhookClient {
+	tlsClients.Lock()
+	defer tlsClients.Unlock()
+
+	if client, ok := tlsClients.clients[tlsConfig]; ok {
+		return client
+	}
+	if len(tlsClients.clients) >= maxTLSClients {
+		for _, client := range tlsClients.clients {
+			client.CloseIdleConnections()
+		}
+		tlsClients.clients = make(map[*tls.Config]*http.Client)
+	}
+
+	transport := netTransport.Clone()
+	transport.TLSClientConfig = tlsConfig.Clone()
+	transport.TLSClientConfig.Renegotiation = tls.RenegotiateFreelyAsClient
+	client := &http.Client{
+		Timeout:   time.Second * 30,
+		Transport: transport,
+	}
+	tlsClients.clients[tlsConfig] = client
+	return client
+}
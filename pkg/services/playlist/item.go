@@ -0,0 +1,85 @@
+package playlist
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend/gtime"
+
+	"github.com/grafana/grafana/pkg/kinds/playlist"
+)
+
+var ErrInvalidPlaylistItem = errors.New("invalid playlist item")
+
+// SearchItemQuery is the search query a dashboard_by_search playlist item resolves into dashboards.
+type SearchItemQuery struct {
+	Query      string
+	Tags       []string
+	FolderUIDs []string
+	// StarredByTeamID limits the search to the dashboards starred by any member of the team
+	StarredByTeamID int64
+}
+
+// ParseSearchItemQuery parses the URL encoded value of a dashboard_by_search playlist item, e.g.
+// query=cpu&tag=prod&tag=wallboard&folderUid=abc&starredByTeamId=1
+func ParseSearchItemQuery(value string) (*SearchItemQuery, error) {
+	params, err := url.ParseQuery(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid search query: %v", ErrInvalidPlaylistItem, err)
+	}
+	if len(params) == 0 {
+		return nil, fmt.Errorf("%w: empty search query", ErrInvalidPlaylistItem)
+	}
+
+	q := &SearchItemQuery{}
+	for key, values := range params {
+		switch key {
+		case "query":
+			q.Query = values[0]
+		case "tag":
+			q.Tags = values
+		case "folderUid":
+			q.FolderUIDs = values
+		case "starredByTeamId":
+			q.StarredByTeamID, err = strconv.ParseInt(values[0], 10, 64)
+			if err != nil || q.StarredByTeamID <= 0 {
+				return nil, fmt.Errorf("%w: invalid team id %q", ErrInvalidPlaylistItem, values[0])
+			}
+		default:
+			return nil, fmt.Errorf("%w: unknown search parameter %q", ErrInvalidPlaylistItem, key)
+		}
+	}
+	return q, nil
+}
+
+// ValidateItems checks the type, value and overridden settings of playlist items.
+func ValidateItems(items []PlaylistItem) error {
+	for _, item := range items {
+		switch PlaylistItemType(item.Type) {
+		case playlist.PlaylistItemTypeDashboardById, playlist.PlaylistItemTypeDashboardByUid, playlist.PlaylistItemTypeDashboardByTag:
+		case playlist.PlaylistItemTypeDashboardBySearch:
+			if _, err := ParseSearchItemQuery(item.Value); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("%w: unknown type %q", ErrInvalidPlaylistItem, item.Type)
+		}
+
+		if item.TimeTo != "" && item.TimeFrom == "" {
+			return fmt.Errorf("%w: timeTo requires timeFrom", ErrInvalidPlaylistItem)
+		}
+		if item.Refresh != "" {
+			if _, err := gtime.ParseDuration(item.Refresh); err != nil {
+				return fmt.Errorf("%w: invalid refresh interval %q", ErrInvalidPlaylistItem, item.Refresh)
+			}
+		}
+		switch PlaylistItemKiosk(item.Kiosk) {
+		case "", playlist.PlaylistItemKioskTv, playlist.PlaylistItemKioskFull:
+		default:
+			return fmt.Errorf("%w: unknown kiosk mode %q", ErrInvalidPlaylistItem, item.Kiosk)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package playlist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSearchItemQuery(t *testing.T) {
+	q, err := ParseSearchItemQuery("query=cpu&tag=prod&tag=wallboard&folderUid=abc&starredByTeamId=3")
+	require.NoError(t, err)
+	require.Equal(t, &SearchItemQuery{
+		Query:           "cpu",
+		Tags:            []string{"prod", "wallboard"},
+		FolderUIDs:      []string{"abc"},
+		StarredByTeamID: 3,
+	}, q)
+
+	for _, value := range []string{"", "unknown=1", "starredByTeamId=team", "starredByTeamId=0", "tag=%zz"} {
+		_, err := ParseSearchItemQuery(value)
+		require.ErrorIs(t, err, ErrInvalidPlaylistItem, value)
+	}
+}
+
+func TestValidateItems(t *testing.T) {
+	testCases := []struct {
+		name  string
+		item  PlaylistItem
+		valid bool
+	}{
+		{name: "dashboard by uid", item: PlaylistItem{Type: "dashboard_by_uid", Value: "abc"}, valid: true},
+		{name: "dashboard by tag", item: PlaylistItem{Type: "dashboard_by_tag", Value: "prod"}, valid: true},
+		{name: "dashboard by search", item: PlaylistItem{Type: "dashboard_by_search", Value: "tag=prod"}, valid: true},
+		{name: "overridden settings", item: PlaylistItem{Type: "dashboard_by_uid", Value: "abc", TimeFrom: "now-1h", TimeTo: "now", Refresh: "30s", Kiosk: "full"}, valid: true},
+		{name: "unknown type", item: PlaylistItem{Type: "dashboard_by_title", Value: "abc"}},
+		{name: "invalid search", item: PlaylistItem{Type: "dashboard_by_search", Value: "title=abc"}},
+		{name: "time range end without start", item: PlaylistItem{Type: "dashboard_by_uid", Value: "abc", TimeTo: "now"}},
+		{name: "invalid refresh interval", item: PlaylistItem{Type: "dashboard_by_uid", Value: "abc", Refresh: "often"}},
+		{name: "unknown kiosk mode", item: PlaylistItem{Type: "dashboard_by_uid", Value: "abc", Kiosk: "partial"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateItems([]PlaylistItem{tc.item})
+			if tc.valid {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, ErrInvalidPlaylistItem)
+			}
+		})
+	}
+}
@@ -25,6 +25,7 @@ type Playlist struct {
 type PlaylistDTO = playlist.Playlist
 type PlaylistItemDTO = playlist.PlaylistItem
 type PlaylistItemType = playlist.PlaylistItemType
+type PlaylistItemKiosk = playlist.PlaylistItemKiosk
 
 type PlaylistItem struct {
 	Id         int64  `db:"id"`
@@ -33,6 +34,10 @@ type PlaylistItem struct {
 	Value      string `json:"value" db:"value"`
 	Order      int    `json:"order" db:"order"`
 	Title      string `json:"title" db:"title"`
+	TimeFrom   string `json:"timeFrom" db:"time_from"`
+	TimeTo     string `json:"timeTo" db:"time_to"`
+	Refresh    string `json:"refresh" db:"refresh"`
+	Kiosk      string `json:"kiosk" db:"kiosk"`
 }
 
 type Playlists []*Playlist
@@ -78,6 +78,20 @@ func (s *Service) Get(ctx context.Context, q *playlist.GetPlaylistByUidQuery) (*
 		if title != "" {
 			items[i].Title = &title
 		}
+
+		// Only set the settings the item overrides
+		if timeFrom := rawItems[i].TimeFrom; timeFrom != "" {
+			items[i].TimeFrom = &timeFrom
+		}
+		if timeTo := rawItems[i].TimeTo; timeTo != "" {
+			items[i].TimeTo = &timeTo
+		}
+		if refresh := rawItems[i].Refresh; refresh != "" {
+			items[i].Refresh = &refresh
+		}
+		if kiosk := playlist.PlaylistItemKiosk(rawItems[i].Kiosk); kiosk != "" {
+			items[i].Kiosk = &kiosk
+		}
 	}
 	return &playlist.PlaylistDTO{
 		Uid:      v.UID,
@@ -46,9 +46,13 @@ func (s *sqlxStore) Insert(ctx context.Context, cmd *playlist.CreatePlaylistComm
 					Value:      item.Value,
 					Order:      order + 1,
 					Title:      item.Title,
+					TimeFrom:   item.TimeFrom,
+					TimeTo:     item.TimeTo,
+					Refresh:    item.Refresh,
+					Kiosk:      item.Kiosk,
 				})
 			}
-			query := `INSERT INTO playlist_item (playlist_id, type, value, title, "order") VALUES (:playlist_id, :type, :value, :title, :order)`
+			query := `INSERT INTO playlist_item (playlist_id, type, value, title, "order", time_from, time_to, refresh, kiosk) VALUES (:playlist_id, :type, :value, :title, :order, :time_from, :time_to, :refresh, :kiosk)`
 			_, err = tx.NamedExec(ctx, query, playlistItems)
 			if err != nil {
 				return err
@@ -98,9 +102,13 @@ func (s *sqlxStore) Update(ctx context.Context, cmd *playlist.UpdatePlaylistComm
 				Value:      item.Value,
 				Order:      index + 1,
 				Title:      item.Title,
+				TimeFrom:   item.TimeFrom,
+				TimeTo:     item.TimeTo,
+				Refresh:    item.Refresh,
+				Kiosk:      item.Kiosk,
 			})
 		}
-		query = `INSERT INTO playlist_item (playlist_id, type, value, title, "order") VALUES (:playlist_id, :type, :value, :title, :order)`
+		query = `INSERT INTO playlist_item (playlist_id, type, value, title, "order", time_from, time_to, refresh, kiosk) VALUES (:playlist_id, :type, :value, :title, :order, :time_from, :time_to, :refresh, :kiosk)`
 		_, err = tx.NamedExec(ctx, query, playlistItems)
 		return err
 	})
@@ -22,6 +22,7 @@ func testIntegrationPlaylistDataAccess(t *testing.T, fn getStore) {
 		items := []playlist.PlaylistItem{
 			{Title: "graphite", Value: "graphite", Type: "dashboard_by_tag"},
 			{Title: "Backend response times", Value: "3", Type: "dashboard_by_id"},
+			{Value: "tag=prod&folderUid=abc", Type: "dashboard_by_search", TimeFrom: "now-1h", TimeTo: "now", Refresh: "1m", Kiosk: "tv"},
 		}
 		cmd := playlist.CreatePlaylistCommand{Name: "NYC office", Interval: "10m", OrgId: 1, Items: items}
 		p, err := playlistStore.Insert(context.Background(), &cmd)
@@ -40,6 +41,19 @@ func testIntegrationPlaylistDataAccess(t *testing.T, fn getStore) {
 			storedPlaylistItems, err := playlistStore.GetItems(context.Background(), get)
 			require.NoError(t, err)
 			require.Equal(t, len(items), len(storedPlaylistItems))
+
+			var search playlist.PlaylistItem
+			for _, item := range storedPlaylistItems {
+				if item.Type == "dashboard_by_search" {
+					search = item
+				}
+			}
+			require.Equal(t, "tag=prod&folderUid=abc", search.Value)
+			require.Equal(t, 3, search.Order)
+			require.Equal(t, "now-1h", search.TimeFrom)
+			require.Equal(t, "now", search.TimeTo)
+			require.Equal(t, "1m", search.Refresh)
+			require.Equal(t, "tv", search.Kiosk)
 		})
 
 		t.Run("Can update playlist", func(t *testing.T) {
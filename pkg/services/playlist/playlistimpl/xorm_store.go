@@ -41,6 +41,10 @@ func (s *sqlStore) Insert(ctx context.Context, cmd *playlist.CreatePlaylistComma
 				Value:      item.Value,
 				Order:      order + 1,
 				Title:      item.Title,
+				TimeFrom:   item.TimeFrom,
+				TimeTo:     item.TimeTo,
+				Refresh:    item.Refresh,
+				Kiosk:      item.Kiosk,
 			})
 		}
 
@@ -95,6 +99,10 @@ func (s *sqlStore) Update(ctx context.Context, cmd *playlist.UpdatePlaylistComma
 				Value:      item.Value,
 				Order:      index + 1,
 				Title:      item.Title,
+				TimeFrom:   item.TimeFrom,
+				TimeTo:     item.TimeTo,
+				Refresh:    item.Refresh,
+				Kiosk:      item.Kiosk,
 			})
 		}
 
@@ -14,6 +14,8 @@ type FakePlaylistService struct {
 	ExpectedError         error
 }
 
+var _ playlist.Service = &FakePlaylistService{}
+
 func NewPlaylistServiveFake() *FakePlaylistService {
 	return &FakePlaylistService{}
 }
@@ -26,10 +28,14 @@ func (f *FakePlaylistService) Update(context.Context, *playlist.UpdatePlaylistCo
 	return f.ExpectedPlaylistDTO, f.ExpectedError
 }
 
-func (f *FakePlaylistService) Get(context.Context, *playlist.GetPlaylistByUidQuery) (*playlist.Playlist, error) {
+func (f *FakePlaylistService) GetWithoutItems(context.Context, *playlist.GetPlaylistByUidQuery) (*playlist.Playlist, error) {
 	return f.ExpectedPlaylist, f.ExpectedError
 }
 
+func (f *FakePlaylistService) Get(context.Context, *playlist.GetPlaylistByUidQuery) (*playlist.PlaylistDTO, error) {
+	return f.ExpectedPlaylistDTO, f.ExpectedError
+}
+
 func (f *FakePlaylistService) GetItems(context.Context, *playlist.GetPlaylistItemsByUidQuery) ([]playlist.PlaylistItem, error) {
 	return f.ExpectedPlaylistItems, f.ExpectedError
 }
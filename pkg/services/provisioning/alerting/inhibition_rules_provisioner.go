@@ -0,0 +1,96 @@
+package alerting
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/provisioning"
+)
+
+type InhibitionRulesProvisioner interface {
+	Provision(ctx context.Context, files []*AlertingFile) error
+	Unprovision(ctx context.Context, files []*AlertingFile) error
+}
+
+type defaultInhibitionRulesProvisioner struct {
+	logger                log.Logger
+	inhibitionRuleService provisioning.InhibitionRuleService
+}
+
+func NewInhibitionRulesProvisioner(logger log.Logger,
+	inhibitionRuleService provisioning.InhibitionRuleService) InhibitionRulesProvisioner {
+	return &defaultInhibitionRulesProvisioner{
+		logger:                logger,
+		inhibitionRuleService: inhibitionRuleService,
+	}
+}
+
+// Provision makes the inhibition rules of the files the only inhibition rules provisioned from files in their
+// organizations. As inhibition rules have no name, a rule that is changed in a file replaces the previous rule.
+func (c *defaultInhibitionRulesProvisioner) Provision(ctx context.Context,
+	files []*AlertingFile) error {
+	desired := map[int64]map[string]definitions.InhibitionRule{}
+	for _, file := range files {
+		for _, rule := range file.InhibitionRules {
+			if _, exists := desired[rule.OrgID]; !exists {
+				desired[rule.OrgID] = map[string]definitions.InhibitionRule{}
+			}
+			desired[rule.OrgID][rule.Rule.UID] = rule.Rule
+		}
+	}
+	for orgID, rules := range desired {
+		existing, err := c.inhibitionRuleService.GetInhibitionRules(ctx, orgID)
+		if err != nil {
+			return err
+		}
+		for _, rule := range existing {
+			if _, exists := rules[rule.UID]; exists {
+				if rule.Provenance != models.ProvenanceFile {
+					rule.Provenance = models.ProvenanceFile
+					if _, err := c.inhibitionRuleService.UpdateInhibitionRule(ctx, rule.UID, rule, orgID); err != nil {
+						return err
+					}
+				}
+				delete(rules, rule.UID)
+				continue
+			}
+			if rule.Provenance == models.ProvenanceFile {
+				if err := c.inhibitionRuleService.DeleteInhibitionRule(ctx, rule.UID, orgID); err != nil {
+					return err
+				}
+			}
+		}
+		for _, rule := range rules {
+			rule.Provenance = models.ProvenanceFile
+			if _, err := c.inhibitionRuleService.CreateInhibitionRule(ctx, rule, orgID); err != nil {
+				return fmt.Errorf("org %d: %w", orgID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Unprovision deletes the inhibition rules provisioned from files in the organizations to reset.
+func (c *defaultInhibitionRulesProvisioner) Unprovision(ctx context.Context,
+	files []*AlertingFile) error {
+	for _, file := range files {
+		for _, orgID := range file.ResetInhibitionRules {
+			rules, err := c.inhibitionRuleService.GetInhibitionRules(ctx, int64(orgID))
+			if err != nil {
+				return fmt.Errorf("%s: %w", file.Filename, err)
+			}
+			for _, rule := range rules {
+				if rule.Provenance != models.ProvenanceFile {
+					continue
+				}
+				if err := c.inhibitionRuleService.DeleteInhibitionRule(ctx, rule.UID, int64(orgID)); err != nil {
+					return fmt.Errorf("%s: %w", file.Filename, err)
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package alerting
+
+import (
+	"fmt"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/pkg/labels"
+	"github.com/prometheus/common/model"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/provisioning/values"
+)
+
+type InhibitionRuleV1 struct {
+	OrgID          values.Int64Value    `json:"orgId" yaml:"orgId"`
+	SourceMatchers []values.StringValue `json:"source_matchers" yaml:"source_matchers"`
+	TargetMatchers []values.StringValue `json:"target_matchers" yaml:"target_matchers"`
+	Equal          []values.StringValue `json:"equal" yaml:"equal"`
+}
+
+func (v1 *InhibitionRuleV1) mapToModel() (InhibitionRule, error) {
+	orgID := v1.OrgID.Value()
+	if orgID < 1 {
+		orgID = 1
+	}
+	sourceMatchers, err := parseInhibitionRuleMatchers(v1.SourceMatchers)
+	if err != nil {
+		return InhibitionRule{}, fmt.Errorf("invalid source matcher: %w", err)
+	}
+	targetMatchers, err := parseInhibitionRuleMatchers(v1.TargetMatchers)
+	if err != nil {
+		return InhibitionRule{}, fmt.Errorf("invalid target matcher: %w", err)
+	}
+	var equal model.LabelNames
+	for _, l := range v1.Equal {
+		equal = append(equal, model.LabelName(l.Value()))
+	}
+	rule := definitions.InhibitionRule{
+		InhibitRule: config.InhibitRule{
+			SourceMatchers: sourceMatchers,
+			TargetMatchers: targetMatchers,
+			Equal:          equal,
+		},
+	}
+	if err := rule.Validate(); err != nil {
+		return InhibitionRule{}, err
+	}
+	rule.UID = definitions.InhibitionRuleUID(rule.InhibitRule)
+	return InhibitionRule{
+		OrgID: orgID,
+		Rule:  rule,
+	}, nil
+}
+
+func parseInhibitionRuleMatchers(v1 []values.StringValue) (config.Matchers, error) {
+	var matchers config.Matchers
+	for _, m := range v1 {
+		matcher, err := labels.ParseMatcher(m.Value())
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, matcher)
+	}
+	return matchers, nil
+}
+
+type InhibitionRule struct {
+	OrgID int64
+	Rule  definitions.InhibitionRule
+}
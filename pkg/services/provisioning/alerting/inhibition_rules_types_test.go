@@ -0,0 +1,58 @@
+package alerting
+
+import (
+	"testing"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+)
+
+func TestInhibitionRules(t *testing.T) {
+	parse := func(t *testing.T, data string) (InhibitionRule, error) {
+		t.Helper()
+		var model InhibitionRuleV1
+		require.NoError(t, yaml.Unmarshal([]byte(data), &model))
+		return model.mapToModel()
+	}
+
+	t.Run("valid inhibition rule should not error on mapping", func(t *testing.T) {
+		rule, err := parse(t, `orgId: 2
+source_matchers:
+  - severity="critical"
+target_matchers:
+  - severity=~"warning|info"
+equal:
+  - cluster
+`)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), rule.OrgID)
+		require.Equal(t, definitions.InhibitionRuleUID(rule.Rule.InhibitRule), rule.Rule.UID)
+		require.Equal(t, "severity", rule.Rule.SourceMatchers[0].Name)
+		require.Equal(t, "warning|info", rule.Rule.TargetMatchers[0].Value)
+		require.Equal(t, model.LabelNames{"cluster"}, rule.Rule.Equal)
+	})
+	t.Run("inhibition rule without org should default to org 1", func(t *testing.T) {
+		rule, err := parse(t, "source_matchers: [a=b]\ntarget_matchers: [c=d]\n")
+		require.NoError(t, err)
+		require.Equal(t, int64(1), rule.OrgID)
+	})
+	t.Run("missing source matchers should error on mapping", func(t *testing.T) {
+		_, err := parse(t, "target_matchers: [c=d]\n")
+		require.Error(t, err)
+	})
+	t.Run("missing target matchers should error on mapping", func(t *testing.T) {
+		_, err := parse(t, "source_matchers: [a=b]\n")
+		require.Error(t, err)
+	})
+	t.Run("invalid matcher should error on mapping", func(t *testing.T) {
+		_, err := parse(t, "source_matchers: ['a=~\"(\"']\ntarget_matchers: [c=d]\n")
+		require.Error(t, err)
+	})
+	t.Run("invalid equal label should error on mapping", func(t *testing.T) {
+		_, err := parse(t, "source_matchers: [a=b]\ntarget_matchers: [c=d]\nequal: [invalid-label]\n")
+		require.Error(t, err)
+	})
+}
@@ -18,6 +18,8 @@ type ProvisionerConfig struct {
 	NotificiationPolicyService provisioning.NotificationPolicyService
 	MuteTimingService          provisioning.MuteTimingService
 	TemplateService            provisioning.TemplateService
+	InhibitionRuleService      provisioning.InhibitionRuleService
+	SilenceService             SilenceService
 }
 
 func Provision(ctx context.Context, cfg ProvisionerConfig) error {
@@ -53,15 +55,33 @@ func Provision(ctx context.Context, cfg ProvisionerConfig) error {
 	if err != nil {
 		return fmt.Errorf("text templates: %w", err)
 	}
+	irProvisioner := NewInhibitionRulesProvisioner(logger, cfg.InhibitionRuleService)
+	err = irProvisioner.Provision(ctx, files)
+	if err != nil {
+		return fmt.Errorf("inhibition rules: %w", err)
+	}
 	npProvisioner := NewNotificationPolicyProvisoner(logger, cfg.NotificiationPolicyService)
 	err = npProvisioner.Provision(ctx, files)
 	if err != nil {
 		return fmt.Errorf("notification policies: %w", err)
 	}
+	silencesProvisioner := NewSilencesProvisioner(logger, cfg.SilenceService)
+	err = silencesProvisioner.Provision(ctx, files)
+	if err != nil {
+		return fmt.Errorf("silences: %w", err)
+	}
+	err = silencesProvisioner.Unprovision(ctx, files)
+	if err != nil {
+		return fmt.Errorf("silences: %w", err)
+	}
 	err = npProvisioner.Unprovision(ctx, files)
 	if err != nil {
 		return fmt.Errorf("notification policies: %w", err)
 	}
+	err = irProvisioner.Unprovision(ctx, files)
+	if err != nil {
+		return fmt.Errorf("inhibition rules: %w", err)
+	}
 	err = cpProvisioner.Unprovision(ctx, files)
 	if err != nil {
 		return fmt.Errorf("contact points: %w", err)
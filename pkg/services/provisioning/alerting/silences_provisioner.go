@@ -0,0 +1,62 @@
+package alerting
+
+import (
+	"context"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+)
+
+// SilenceService provisions the silences identified by an external ID.
+type SilenceService interface {
+	ProvisionSilence(ctx context.Context, orgID int64, externalID string, ps definitions.PostableSilence) error
+	DeleteProvisionedSilence(ctx context.Context, orgID int64, externalID string) error
+}
+
+type SilencesProvisioner interface {
+	Provision(ctx context.Context, files []*AlertingFile) error
+	Unprovision(ctx context.Context, files []*AlertingFile) error
+}
+
+type defaultSilencesProvisioner struct {
+	logger         log.Logger
+	silenceService SilenceService
+}
+
+func NewSilencesProvisioner(logger log.Logger, silenceService SilenceService) SilencesProvisioner {
+	return &defaultSilencesProvisioner{
+		logger:         logger,
+		silenceService: silenceService,
+	}
+}
+
+func (c *defaultSilencesProvisioner) Provision(ctx context.Context, files []*AlertingFile) error {
+	for _, file := range files {
+		if len(file.Silences) > 0 && c.silenceService == nil {
+			c.logger.Warn("silences are not provisioned because unified alerting is disabled", "file", file.Filename)
+			continue
+		}
+		for _, silence := range file.Silences {
+			err := c.silenceService.ProvisionSilence(ctx, silence.OrgID, silence.ID, silence.Silence)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (c *defaultSilencesProvisioner) Unprovision(ctx context.Context, files []*AlertingFile) error {
+	if c.silenceService == nil {
+		return nil
+	}
+	for _, file := range files {
+		for _, deleteSilence := range file.DeleteSilences {
+			err := c.silenceService.DeleteProvisionedSilence(ctx, deleteSilence.OrgID, deleteSilence.ID)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
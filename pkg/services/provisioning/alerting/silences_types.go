@@ -0,0 +1,123 @@
+package alerting
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	amv2 "github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/prometheus/alertmanager/pkg/labels"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/provisioning/values"
+)
+
+const defaultSilenceCreatedBy = "provisioning"
+
+type SilenceV1 struct {
+	OrgID     values.Int64Value    `json:"orgId" yaml:"orgId"`
+	ID        values.StringValue   `json:"id" yaml:"id"`
+	Matchers  []values.StringValue `json:"matchers" yaml:"matchers"`
+	StartsAt  values.StringValue   `json:"startsAt" yaml:"startsAt"`
+	EndsAt    values.StringValue   `json:"endsAt" yaml:"endsAt"`
+	CreatedBy values.StringValue   `json:"createdBy" yaml:"createdBy"`
+	Comment   values.StringValue   `json:"comment" yaml:"comment"`
+}
+
+func (v1 *SilenceV1) mapToModel() (Silence, error) {
+	id := strings.TrimSpace(v1.ID.Value())
+	if id == "" {
+		return Silence{}, errors.New("silence missing id")
+	}
+	if len(v1.Matchers) == 0 {
+		return Silence{}, fmt.Errorf("silence '%s' missing matchers", id)
+	}
+	matchers := make(amv2.Matchers, 0, len(v1.Matchers))
+	for _, m := range v1.Matchers {
+		matcher, err := labels.ParseMatcher(m.Value())
+		if err != nil {
+			return Silence{}, fmt.Errorf("silence '%s' has an invalid matcher: %w", id, err)
+		}
+		isEqual := matcher.Type == labels.MatchEqual || matcher.Type == labels.MatchRegexp
+		isRegex := matcher.Type == labels.MatchRegexp || matcher.Type == labels.MatchNotRegexp
+		matchers = append(matchers, &amv2.Matcher{Name: &matcher.Name, Value: &matcher.Value, IsEqual: &isEqual, IsRegex: &isRegex})
+	}
+
+	var startsAt *strfmt.DateTime
+	if v := strings.TrimSpace(v1.StartsAt.Value()); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return Silence{}, fmt.Errorf("silence '%s' has an invalid startsAt: %w", id, err)
+		}
+		dt := strfmt.DateTime(t)
+		startsAt = &dt
+	}
+	v := strings.TrimSpace(v1.EndsAt.Value())
+	if v == "" {
+		return Silence{}, fmt.Errorf("silence '%s' missing endsAt", id)
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return Silence{}, fmt.Errorf("silence '%s' has an invalid endsAt: %w", id, err)
+	}
+	endsAt := strfmt.DateTime(t)
+	if startsAt != nil && time.Time(endsAt).Before(time.Time(*startsAt)) {
+		return Silence{}, fmt.Errorf("silence '%s' ends before it starts", id)
+	}
+
+	createdBy := v1.CreatedBy.Value()
+	if createdBy == "" {
+		createdBy = defaultSilenceCreatedBy
+	}
+	comment := v1.Comment.Value()
+	orgID := v1.OrgID.Value()
+	if orgID < 1 {
+		orgID = 1
+	}
+	return Silence{
+		OrgID: orgID,
+		ID:    id,
+		Silence: definitions.PostableSilence{
+			Silence: amv2.Silence{
+				Matchers:  matchers,
+				StartsAt:  startsAt,
+				EndsAt:    &endsAt,
+				CreatedBy: &createdBy,
+				Comment:   &comment,
+			},
+		},
+	}, nil
+}
+
+type Silence struct {
+	OrgID   int64
+	ID      string
+	Silence definitions.PostableSilence
+}
+
+type DeleteSilenceV1 struct {
+	OrgID values.Int64Value  `json:"orgId" yaml:"orgId"`
+	ID    values.StringValue `json:"id" yaml:"id"`
+}
+
+func (v1 *DeleteSilenceV1) mapToModel() (DeleteSilence, error) {
+	id := strings.TrimSpace(v1.ID.Value())
+	if id == "" {
+		return DeleteSilence{}, errors.New("delete silence missing id")
+	}
+	orgID := v1.OrgID.Value()
+	if orgID < 1 {
+		orgID = 1
+	}
+	return DeleteSilence{
+		OrgID: orgID,
+		ID:    id,
+	}, nil
+}
+
+type DeleteSilence struct {
+	OrgID int64
+	ID    string
+}
@@ -0,0 +1,73 @@
+package alerting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSilences(t *testing.T) {
+	parse := func(t *testing.T, data string) (Silence, error) {
+		t.Helper()
+		var model SilenceV1
+		require.NoError(t, yaml.Unmarshal([]byte(data), &model))
+		return model.mapToModel()
+	}
+
+	t.Run("valid silence should not error on mapping", func(t *testing.T) {
+		silence, err := parse(t, `orgId: 2
+id: maintenance
+matchers:
+  - alertname="HighLatency"
+  - cluster=~"eu-.*"
+  - team!="a"
+startsAt: 2030-01-01T00:00:00Z
+endsAt: 2030-01-01T02:00:00Z
+comment: Planned maintenance
+`)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), silence.OrgID)
+		require.Equal(t, "maintenance", silence.ID)
+		require.Equal(t, "Planned maintenance", *silence.Silence.Comment)
+		require.Equal(t, defaultSilenceCreatedBy, *silence.Silence.CreatedBy)
+		require.Equal(t, time.Date(2030, 1, 1, 2, 0, 0, 0, time.UTC), time.Time(*silence.Silence.EndsAt).UTC())
+
+		require.Len(t, silence.Silence.Matchers, 3)
+		regex := silence.Silence.Matchers[1]
+		require.Equal(t, "cluster", *regex.Name)
+		require.Equal(t, "eu-.*", *regex.Value)
+		require.True(t, *regex.IsRegex)
+		require.True(t, *regex.IsEqual)
+		notEqual := silence.Silence.Matchers[2]
+		require.False(t, *notEqual.IsRegex)
+		require.False(t, *notEqual.IsEqual)
+	})
+	t.Run("silence without start time should start when it is provisioned", func(t *testing.T) {
+		silence, err := parse(t, "id: maintenance\nmatchers: [alertname=test]\nendsAt: 2030-01-01T00:00:00Z\n")
+		require.NoError(t, err)
+		require.Equal(t, int64(1), silence.OrgID)
+		require.Nil(t, silence.Silence.StartsAt)
+	})
+	t.Run("missing id should error on mapping", func(t *testing.T) {
+		_, err := parse(t, "matchers: [alertname=test]\nendsAt: 2030-01-01T00:00:00Z\n")
+		require.Error(t, err)
+	})
+	t.Run("missing matchers should error on mapping", func(t *testing.T) {
+		_, err := parse(t, "id: maintenance\nendsAt: 2030-01-01T00:00:00Z\n")
+		require.Error(t, err)
+	})
+	t.Run("invalid matcher should error on mapping", func(t *testing.T) {
+		_, err := parse(t, "id: maintenance\nmatchers: ['alertname=~\"(\"']\nendsAt: 2030-01-01T00:00:00Z\n")
+		require.Error(t, err)
+	})
+	t.Run("missing end time should error on mapping", func(t *testing.T) {
+		_, err := parse(t, "id: maintenance\nmatchers: [alertname=test]\n")
+		require.Error(t, err)
+	})
+	t.Run("end time before start time should error on mapping", func(t *testing.T) {
+		_, err := parse(t, "id: maintenance\nmatchers: [alertname=test]\nstartsAt: 2030-01-02T00:00:00Z\nendsAt: 2030-01-01T00:00:00Z\n")
+		require.Error(t, err)
+	})
+}
@@ -14,32 +14,40 @@ type OrgID int64
 
 type AlertingFile struct {
 	configVersion
-	Filename            string
-	Groups              []AlertRuleGroup
-	DeleteRules         []RuleDelete
-	ContactPoints       []ContactPoint
-	DeleteContactPoints []DeleteContactPoint
-	Policies            []NotificiationPolicy
-	ResetPolicies       []OrgID
-	MuteTimes           []MuteTime
-	DeleteMuteTimes     []DeleteMuteTime
-	Templates           []Template
-	DeleteTemplates     []DeleteTemplate
+	Filename             string
+	Groups               []AlertRuleGroup
+	DeleteRules          []RuleDelete
+	ContactPoints        []ContactPoint
+	DeleteContactPoints  []DeleteContactPoint
+	Policies             []NotificiationPolicy
+	ResetPolicies        []OrgID
+	MuteTimes            []MuteTime
+	DeleteMuteTimes      []DeleteMuteTime
+	Templates            []Template
+	DeleteTemplates      []DeleteTemplate
+	Silences             []Silence
+	DeleteSilences       []DeleteSilence
+	InhibitionRules      []InhibitionRule
+	ResetInhibitionRules []OrgID
 }
 
 type AlertingFileV1 struct {
 	configVersion
-	Filename            string
-	Groups              []AlertRuleGroupV1      `json:"groups" yaml:"groups"`
-	DeleteRules         []RuleDeleteV1          `json:"deleteRules" yaml:"deleteRules"`
-	ContactPoints       []ContactPointV1        `json:"contactPoints" yaml:"contactPoints"`
-	DeleteContactPoints []DeleteContactPointV1  `json:"deleteContactPoints" yaml:"deleteContactPoints"`
-	Policies            []NotificiationPolicyV1 `json:"policies" yaml:"policies"`
-	ResetPolicies       []values.Int64Value     `json:"resetPolicies" yaml:"resetPolicies"`
-	MuteTimes           []MuteTimeV1            `json:"muteTimes" yaml:"muteTimes"`
-	DeleteMuteTimes     []DeleteMuteTimeV1      `json:"deleteMuteTimes" yaml:"deleteMuteTimes"`
-	Templates           []TemplateV1            `json:"templates" yaml:"templates"`
-	DeleteTemplates     []DeleteTemplateV1      `json:"deleteTemplates" yaml:"deleteTemplates"`
+	Filename             string
+	Groups               []AlertRuleGroupV1      `json:"groups" yaml:"groups"`
+	DeleteRules          []RuleDeleteV1          `json:"deleteRules" yaml:"deleteRules"`
+	ContactPoints        []ContactPointV1        `json:"contactPoints" yaml:"contactPoints"`
+	DeleteContactPoints  []DeleteContactPointV1  `json:"deleteContactPoints" yaml:"deleteContactPoints"`
+	Policies             []NotificiationPolicyV1 `json:"policies" yaml:"policies"`
+	ResetPolicies        []values.Int64Value     `json:"resetPolicies" yaml:"resetPolicies"`
+	MuteTimes            []MuteTimeV1            `json:"muteTimes" yaml:"muteTimes"`
+	DeleteMuteTimes      []DeleteMuteTimeV1      `json:"deleteMuteTimes" yaml:"deleteMuteTimes"`
+	Templates            []TemplateV1            `json:"templates" yaml:"templates"`
+	DeleteTemplates      []DeleteTemplateV1      `json:"deleteTemplates" yaml:"deleteTemplates"`
+	Silences             []SilenceV1             `json:"silences" yaml:"silences"`
+	DeleteSilences       []DeleteSilenceV1       `json:"deleteSilences" yaml:"deleteSilences"`
+	InhibitionRules      []InhibitionRuleV1      `json:"inhibitionRules" yaml:"inhibitionRules"`
+	ResetInhibitionRules []values.Int64Value     `json:"resetInhibitionRules" yaml:"resetInhibitionRules"`
 }
 
 func (fileV1 *AlertingFileV1) MapToModel() (AlertingFile, error) {
@@ -60,9 +68,47 @@ func (fileV1 *AlertingFileV1) MapToModel() (AlertingFile, error) {
 	if err := fileV1.mapTemplates(&alertingFile); err != nil {
 		return AlertingFile{}, fmt.Errorf("failure parsing templates: %w", err)
 	}
+	if err := fileV1.mapSilences(&alertingFile); err != nil {
+		return AlertingFile{}, fmt.Errorf("failure parsing silences: %w", err)
+	}
+	if err := fileV1.mapInhibitionRules(&alertingFile); err != nil {
+		return AlertingFile{}, fmt.Errorf("failure parsing inhibition rules: %w", err)
+	}
 	return alertingFile, nil
 }
 
+func (fileV1 *AlertingFileV1) mapInhibitionRules(alertingFile *AlertingFile) error {
+	for _, ruleV1 := range fileV1.InhibitionRules {
+		rule, err := ruleV1.mapToModel()
+		if err != nil {
+			return err
+		}
+		alertingFile.InhibitionRules = append(alertingFile.InhibitionRules, rule)
+	}
+	for _, orgIDV1 := range fileV1.ResetInhibitionRules {
+		alertingFile.ResetInhibitionRules = append(alertingFile.ResetInhibitionRules, OrgID(orgIDV1.Value()))
+	}
+	return nil
+}
+
+func (fileV1 *AlertingFileV1) mapSilences(alertingFile *AlertingFile) error {
+	for _, silenceV1 := range fileV1.Silences {
+		silence, err := silenceV1.mapToModel()
+		if err != nil {
+			return err
+		}
+		alertingFile.Silences = append(alertingFile.Silences, silence)
+	}
+	for _, deleteV1 := range fileV1.DeleteSilences {
+		delReq, err := deleteV1.mapToModel()
+		if err != nil {
+			return err
+		}
+		alertingFile.DeleteSilences = append(alertingFile.DeleteSilences, delReq)
+	}
+	return nil
+}
+
 func (fileV1 *AlertingFileV1) mapTemplates(alertingFile *AlertingFile) error {
 	for _, ttV1 := range fileV1.Templates {
 		alertingFile.Templates = append(alertingFile.Templates, ttV1.mapToModel())
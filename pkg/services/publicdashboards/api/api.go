@@ -18,6 +18,11 @@ import (
 	"github.com/grafana/grafana/pkg/web"
 )
 
+const (
+	defaultAuditLimit = 100
+	maxAuditLimit     = 1000
+)
+
 type Api struct {
 	PublicDashboardService publicdashboards.Service
 	RouteRegister          routing.RouteRegister
@@ -58,6 +63,8 @@ func (api *Api) RegisterAPIEndpoints() {
 	api.RouteRegister.Get("/api/public/dashboards/:accessToken", routing.Wrap(api.ViewPublicDashboard))
 	api.RouteRegister.Post("/api/public/dashboards/:accessToken/panels/:panelId/query", routing.Wrap(api.QueryPublicDashboard))
 	api.RouteRegister.Get("/api/public/dashboards/:accessToken/annotations", routing.Wrap(api.GetAnnotations))
+	api.RouteRegister.Post("/api/public/dashboards/:accessToken/magic-link", routing.Wrap(api.SendMagicLink))
+	api.RouteRegister.Post("/api/public/dashboards/:accessToken/verify", routing.Wrap(api.VerifyMagicLink))
 
 	// Auth endpoints
 	auth := accesscontrol.Middleware(api.AccessControl)
@@ -85,6 +92,11 @@ func (api *Api) RegisterAPIEndpoints() {
 	api.RouteRegister.Delete("/api/dashboards/uid/:dashboardUid/public-dashboards/:uid",
 		auth(middleware.ReqOrgAdmin, accesscontrol.EvalPermission(dashboards.ActionDashboardsPublicWrite, uidScope)),
 		routing.Wrap(api.DeletePublicDashboard))
+
+	// Get the audit log of a public dashboard
+	api.RouteRegister.Get("/api/dashboards/uid/:dashboardUid/public-dashboards/:uid/audit",
+		auth(middleware.ReqOrgAdmin, accesscontrol.EvalPermission(dashboards.ActionDashboardsPublicWrite, uidScope)),
+		routing.Wrap(api.GetPublicDashboardAudit))
 }
 
 // ListPublicDashboards Gets list of public dashboards by orgId
@@ -204,6 +216,41 @@ func (api *Api) DeletePublicDashboard(c *models.ReqContext) response.Response {
 	return response.JSON(http.StatusOK, nil)
 }
 
+// GetPublicDashboardAudit Gets the audit log of a public dashboard, the most recent entries first
+// GET /api/dashboards/uid/:dashboardUid/public-dashboards/:uid/audit
+func (api *Api) GetPublicDashboardAudit(c *models.ReqContext) response.Response {
+	dashboardUid := web.Params(c.Req)[":dashboardUid"]
+	if !tokens.IsValidShortUID(dashboardUid) {
+		return response.Err(ErrInvalidUid.Errorf("GetPublicDashboardAudit: invalid dashboard Uid %s", dashboardUid))
+	}
+
+	uid := web.Params(c.Req)[":uid"]
+	if !tokens.IsValidShortUID(uid) {
+		return response.Err(ErrInvalidUid.Errorf("GetPublicDashboardAudit: invalid Uid %s", uid))
+	}
+
+	limit := c.QueryInt("limit")
+	if limit <= 0 || limit > maxAuditLimit {
+		limit = defaultAuditLimit
+	}
+
+	// the permissions are checked on the dashboard, so the public dashboard must belong to it
+	pd, err := api.PublicDashboardService.FindByDashboardUid(c.Req.Context(), c.OrgID, dashboardUid)
+	if err != nil {
+		return response.Err(err)
+	}
+	if pd.Uid != uid {
+		return response.Err(ErrPublicDashboardNotFound.Errorf("GetPublicDashboardAudit: public dashboard %s not found for dashboard %s", uid, dashboardUid))
+	}
+
+	entries, err := api.PublicDashboardService.FindAuditEntries(c.Req.Context(), c.OrgID, uid, limit)
+	if err != nil {
+		return response.Err(err)
+	}
+
+	return response.JSON(http.StatusOK, entries)
+}
+
 // Copied from pkg/api/metrics.go
 func toJsonStreamingResponse(features *featuremgmt.FeatureManager, qdr *backend.QueryDataResponse) response.Response {
 	statusWhenError := http.StatusBadRequest
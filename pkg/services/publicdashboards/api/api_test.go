@@ -641,3 +641,66 @@ func TestAPIUpdatePublicDashboard(t *testing.T) {
 		})
 	}
 }
+
+func TestAPIGetPublicDashboardAudit(t *testing.T) {
+	pubdash := &PublicDashboard{Uid: "pubdash1", DashboardUid: "dash1", OrgId: 1}
+	entries := []AuditEntry{{Id: 1, PublicDashboardUid: "pubdash1", Action: AuditActionView, Email: "viewer@example.com"}}
+
+	testCases := []struct {
+		Name                 string
+		Path                 string
+		User                 *user.SignedInUser
+		AccessControlEnabled bool
+		ShouldCallService    bool
+		ExpectedHttpResponse int
+	}{
+		{
+			Name:                 "returns the audit log",
+			Path:                 "/api/dashboards/uid/dash1/public-dashboards/pubdash1/audit",
+			User:                 userAdminRBAC,
+			AccessControlEnabled: true,
+			ShouldCallService:    true,
+			ExpectedHttpResponse: http.StatusOK,
+		},
+		{
+			Name:                 "returns 404 when the public dashboard belongs to another dashboard",
+			Path:                 "/api/dashboards/uid/dash1/public-dashboards/pubdash2/audit",
+			User:                 userAdmin,
+			ShouldCallService:    false,
+			ExpectedHttpResponse: http.StatusNotFound,
+		},
+		{
+			Name:                 "returns 403 for viewers",
+			Path:                 "/api/dashboards/uid/dash1/public-dashboards/pubdash1/audit",
+			User:                 userViewerRBAC,
+			AccessControlEnabled: true,
+			ExpectedHttpResponse: http.StatusForbidden,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.Name, func(t *testing.T) {
+			service := publicdashboards.NewFakePublicDashboardService(t)
+			if test.ExpectedHttpResponse != http.StatusForbidden {
+				service.On("FindByDashboardUid", mock.Anything, int64(1), "dash1").Return(pubdash, nil)
+			}
+			if test.ShouldCallService {
+				service.On("FindAuditEntries", mock.Anything, int64(1), "pubdash1", 100).Return(entries, nil)
+			}
+
+			cfg := setting.NewCfg()
+			cfg.RBACEnabled = test.AccessControlEnabled
+
+			testServer := setupTestServer(t, cfg, featuremgmt.WithFeatures(featuremgmt.FlagPublicDashboards), service, nil, test.User)
+
+			response := callAPI(testServer, http.MethodGet, test.Path, nil, t)
+			assert.Equal(t, test.ExpectedHttpResponse, response.Code)
+
+			if response.Code == http.StatusOK {
+				var resp []AuditEntry
+				require.NoError(t, json.Unmarshal(response.Body.Bytes(), &resp))
+				assert.Equal(t, entries, resp)
+			}
+		})
+	}
+}
@@ -3,12 +3,15 @@ package api
 import (
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/grafana/grafana/pkg/api/dtos"
 	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/middleware/cookies"
 	"github.com/grafana/grafana/pkg/models"
 	"github.com/grafana/grafana/pkg/services/publicdashboards/internal/tokens"
 	. "github.com/grafana/grafana/pkg/services/publicdashboards/models"
+	"github.com/grafana/grafana/pkg/setting"
 	"github.com/grafana/grafana/pkg/web"
 )
 
@@ -20,14 +23,15 @@ func (api *Api) ViewPublicDashboard(c *models.ReqContext) response.Response {
 		return response.Err(ErrInvalidAccessToken.Errorf("ViewPublicDashboard: invalid access token"))
 	}
 
-	pubdash, dash, err := api.PublicDashboardService.FindPublicDashboardAndDashboardByAccessToken(
-		c.Req.Context(),
-		accessToken,
-	)
+	pubdash, dash, email, err := api.findViewablePublicDashboard(c, accessToken)
 	if err != nil {
 		return response.Err(err)
 	}
 
+	if err := api.PublicDashboardService.RecordView(c.Req.Context(), pubdash, email); err != nil {
+		api.Log.Warn("Failed to record a view of a public dashboard", "publicDashboardUid", pubdash.Uid, "error", err)
+	}
+
 	meta := dtos.DashboardMeta{
 		Slug:                       dash.Slug,
 		Type:                       models.DashTypeDB,
@@ -45,6 +49,9 @@ func (api *Api) ViewPublicDashboard(c *models.ReqContext) response.Response {
 		PublicDashboardUID:         pubdash.Uid,
 	}
 
+	// viewers only see the template variable values they can select
+	dash.Data.SetPath([]string{"templating", "list"}, pubdash.BuildTemplateVariables(dash))
+
 	dto := dtos.DashboardFullWithMeta{Meta: meta, Dashboard: dash.Data}
 
 	return response.JSON(http.StatusOK, dto)
@@ -68,6 +75,10 @@ func (api *Api) QueryPublicDashboard(c *models.ReqContext) response.Response {
 		return response.Err(ErrBadRequest.Errorf("QueryPublicDashboard: error parsing request: %v", err))
 	}
 
+	if _, _, _, err := api.findViewablePublicDashboard(c, accessToken); err != nil {
+		return response.Err(err)
+	}
+
 	resp, err := api.PublicDashboardService.GetQueryDataResponse(c.Req.Context(), c.SkipCache, reqDTO, panelId, accessToken)
 	if err != nil {
 		return response.Err(err)
@@ -84,6 +95,10 @@ func (api *Api) GetAnnotations(c *models.ReqContext) response.Response {
 		return response.Err(ErrInvalidAccessToken.Errorf("GetAnnotations: invalid access token"))
 	}
 
+	if _, _, _, err := api.findViewablePublicDashboard(c, accessToken); err != nil {
+		return response.Err(err)
+	}
+
 	reqDTO := AnnotationsQueryDTO{
 		From: c.QueryInt64("from"),
 		To:   c.QueryInt64("to"),
@@ -96,3 +111,77 @@ func (api *Api) GetAnnotations(c *models.ReqContext) response.Response {
 
 	return response.JSON(http.StatusOK, annotations)
 }
+
+// SendMagicLink emails a magic link to a recipient of a public dashboard shared with emails. The response is the same
+// whether the email address is a recipient or not.
+// POST /api/public/dashboards/:accessToken/magic-link
+func (api *Api) SendMagicLink(c *models.ReqContext) response.Response {
+	accessToken := web.Params(c.Req)[":accessToken"]
+	if !tokens.IsValidAccessToken(accessToken) {
+		return response.Err(ErrInvalidAccessToken.Errorf("SendMagicLink: invalid access token"))
+	}
+
+	reqDTO := SendMagicLinkDTO{}
+	if err := web.Bind(c.Req, &reqDTO); err != nil {
+		return response.Err(ErrBadRequest.Errorf("SendMagicLink: error parsing request: %v", err))
+	}
+
+	if err := api.PublicDashboardService.SendMagicLink(c.Req.Context(), accessToken, reqDTO.Email); err != nil {
+		return response.Err(err)
+	}
+
+	return response.Success("If the email address can view the dashboard, a link was sent to it")
+}
+
+// VerifyMagicLink verifies the email address of a recipient of a public dashboard with the code of a magic link and
+// starts their session. The magic link opens a page that posts the code, so that the code isn't used by the clients
+// that open the links of emails before the recipient does.
+// POST /api/public/dashboards/:accessToken/verify
+func (api *Api) VerifyMagicLink(c *models.ReqContext) response.Response {
+	accessToken := web.Params(c.Req)[":accessToken"]
+	if !tokens.IsValidAccessToken(accessToken) {
+		return response.Err(ErrInvalidAccessToken.Errorf("VerifyMagicLink: invalid access token"))
+	}
+
+	reqDTO := VerifyMagicLinkDTO{}
+	if err := web.Bind(c.Req, &reqDTO); err != nil {
+		return response.Err(ErrBadRequest.Errorf("VerifyMagicLink: error parsing request: %v", err))
+	}
+	if reqDTO.Code == "" {
+		return response.Err(ErrInvalidMagicLink.Errorf("VerifyMagicLink: no code"))
+	}
+
+	token, expiresAt, err := api.PublicDashboardService.VerifyMagicLink(c.Req.Context(), accessToken, reqDTO.Code)
+	if err != nil {
+		return response.Err(err)
+	}
+
+	cookies.WriteCookie(c.Resp, EmailSessionCookieName, token, int(time.Until(expiresAt).Seconds()), emailSessionCookieOptions(accessToken))
+
+	return response.Success("Email address verified")
+}
+
+// findViewablePublicDashboard returns the public dashboard of an access token with its dashboard if the viewer can
+// view it, and the email address of the viewer if it's shared with emails
+func (api *Api) findViewablePublicDashboard(c *models.ReqContext, accessToken string) (*PublicDashboard, *models.Dashboard, string, error) {
+	pubdash, dash, err := api.PublicDashboardService.FindPublicDashboardAndDashboardByAccessToken(c.Req.Context(), accessToken)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	email, err := api.PublicDashboardService.VerifyEmailSession(c.Req.Context(), pubdash, c.GetCookie(EmailSessionCookieName))
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	return pubdash, dash, email, nil
+}
+
+// emailSessionCookieOptions limits the session cookie of a recipient to the API of the public dashboard they verified
+func emailSessionCookieOptions(accessToken string) func() cookies.CookieOptions {
+	return func() cookies.CookieOptions {
+		options := cookies.NewCookieOptions()
+		options.Path = setting.AppSubUrl + "/api/public/dashboards/" + accessToken
+		return options
+	}
+}
@@ -6,8 +6,10 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
@@ -24,6 +26,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/datasources"
 	datasourcesService "github.com/grafana/grafana/pkg/services/datasources/service"
 	"github.com/grafana/grafana/pkg/services/featuremgmt"
+	"github.com/grafana/grafana/pkg/services/notifications"
 	"github.com/grafana/grafana/pkg/services/publicdashboards"
 	publicdashboardsStore "github.com/grafana/grafana/pkg/services/publicdashboards/database"
 	. "github.com/grafana/grafana/pkg/services/publicdashboards/models"
@@ -85,6 +88,8 @@ func TestAPIViewPublicDashboard(t *testing.T) {
 			service := publicdashboards.NewFakePublicDashboardService(t)
 			service.On("FindPublicDashboardAndDashboardByAccessToken", mock.Anything, mock.AnythingOfType("string")).
 				Return(&PublicDashboard{}, test.DashboardResult, test.Err).Maybe()
+			service.On("VerifyEmailSession", mock.Anything, mock.Anything, "").Return("", nil).Maybe()
+			service.On("RecordView", mock.Anything, mock.Anything, "").Return(nil).Maybe()
 
 			cfg := setting.NewCfg()
 			cfg.RBACEnabled = false
@@ -129,6 +134,111 @@ func TestAPIViewPublicDashboard(t *testing.T) {
 	}
 }
 
+func TestAPIViewPublicDashboardTemplateVariables(t *testing.T) {
+	dashboard := &models.Dashboard{Data: simplejson.NewFromAny(map[string]interface{}{
+		"templating": map[string]interface{}{
+			"list": []interface{}{
+				map[string]interface{}{"name": "env", "type": "query", "query": "label_values(env)", "current": map[string]interface{}{"value": "dev"}},
+			},
+		},
+	})}
+	pubdash := &PublicDashboard{TemplateVariables: TemplateVariables{{Name: "env", Values: []string{"prod", "dev"}}}}
+
+	service := publicdashboards.NewFakePublicDashboardService(t)
+	service.On("FindPublicDashboardAndDashboardByAccessToken", mock.Anything, validAccessToken).Return(pubdash, dashboard, nil)
+	service.On("VerifyEmailSession", mock.Anything, pubdash, "").Return("", nil)
+	service.On("RecordView", mock.Anything, pubdash, "").Return(nil)
+
+	cfg := setting.NewCfg()
+	cfg.RBACEnabled = false
+	testServer := setupTestServer(t, cfg, featuremgmt.WithFeatures(featuremgmt.FlagPublicDashboards), service, nil, anonymousUser)
+
+	response := callAPI(testServer, http.MethodGet, fmt.Sprintf("/api/public/dashboards/%s", validAccessToken), nil, t)
+	require.Equal(t, http.StatusOK, response.Code)
+
+	var dashResp dtos.DashboardFullWithMeta
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &dashResp))
+
+	env := dashResp.Dashboard.GetPath("templating", "list").GetIndex(0)
+	assert.Equal(t, "custom", env.Get("type").MustString())
+	assert.Equal(t, "prod,dev", env.Get("query").MustString())
+	assert.Equal(t, "prod", env.GetPath("current", "value").MustString())
+}
+
+func TestAPIEmailSharedPublicDashboard(t *testing.T) {
+	emailShare := &PublicDashboard{Share: EmailShareType, Recipients: Recipients{"viewer@example.com"}}
+	dashboard := &models.Dashboard{Data: simplejson.New()}
+
+	setup := func(t *testing.T, service *publicdashboards.FakePublicDashboardService) *web.Mux {
+		cfg := setting.NewCfg()
+		cfg.RBACEnabled = false
+		return setupTestServer(t, cfg, featuremgmt.WithFeatures(featuremgmt.FlagPublicDashboards), service, nil, anonymousUser)
+	}
+
+	t.Run("It returns 401 without a verified email address", func(t *testing.T) {
+		service := publicdashboards.NewFakePublicDashboardService(t)
+		service.On("FindPublicDashboardAndDashboardByAccessToken", mock.Anything, validAccessToken).Return(emailShare, dashboard, nil)
+		service.On("VerifyEmailSession", mock.Anything, emailShare, "").Return("", ErrEmailVerificationRequired.Errorf(""))
+
+		response := callAPI(setup(t, service), http.MethodGet, fmt.Sprintf("/api/public/dashboards/%s", validAccessToken), nil, t)
+		assert.Equal(t, http.StatusUnauthorized, response.Code)
+	})
+
+	t.Run("It returns the dashboard with a session cookie and records the view", func(t *testing.T) {
+		service := publicdashboards.NewFakePublicDashboardService(t)
+		service.On("FindPublicDashboardAndDashboardByAccessToken", mock.Anything, validAccessToken).Return(emailShare, dashboard, nil)
+		service.On("VerifyEmailSession", mock.Anything, emailShare, "session-token").Return("viewer@example.com", nil)
+		service.On("RecordView", mock.Anything, emailShare, "viewer@example.com").Return(nil).Once()
+
+		req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/api/public/dashboards/%s", validAccessToken), nil)
+		require.NoError(t, err)
+		req.AddCookie(&http.Cookie{Name: EmailSessionCookieName, Value: "session-token"})
+		response := httptest.NewRecorder()
+		setup(t, service).ServeHTTP(response, req)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+	})
+
+	t.Run("It sends a magic link", func(t *testing.T) {
+		service := publicdashboards.NewFakePublicDashboardService(t)
+		service.On("SendMagicLink", mock.Anything, validAccessToken, "viewer@example.com").Return(nil).Once()
+
+		response := callAPI(setup(t, service), http.MethodPost, fmt.Sprintf("/api/public/dashboards/%s/magic-link", validAccessToken), strings.NewReader(`{"email":"viewer@example.com"}`), t)
+		assert.Equal(t, http.StatusOK, response.Code)
+	})
+
+	t.Run("It sets the session cookie when verifying a magic link", func(t *testing.T) {
+		service := publicdashboards.NewFakePublicDashboardService(t)
+		service.On("VerifyMagicLink", mock.Anything, validAccessToken, "code").Return("session-token", time.Now().Add(time.Hour), nil)
+
+		response := callAPI(setup(t, service), http.MethodPost, fmt.Sprintf("/api/public/dashboards/%s/verify", validAccessToken), strings.NewReader(`{"code":"code"}`), t)
+		assert.Equal(t, http.StatusOK, response.Code)
+
+		cookies := response.Result().Cookies()
+		require.Len(t, cookies, 1)
+		assert.Equal(t, EmailSessionCookieName, cookies[0].Name)
+		assert.Equal(t, "session-token", cookies[0].Value)
+		assert.Equal(t, "/api/public/dashboards/"+validAccessToken, cookies[0].Path)
+		assert.True(t, cookies[0].HttpOnly)
+	})
+
+	t.Run("It returns 400 for an invalid magic link", func(t *testing.T) {
+		service := publicdashboards.NewFakePublicDashboardService(t)
+		service.On("VerifyMagicLink", mock.Anything, validAccessToken, "code").Return("", time.Time{}, ErrInvalidMagicLink.Errorf(""))
+
+		response := callAPI(setup(t, service), http.MethodPost, fmt.Sprintf("/api/public/dashboards/%s/verify", validAccessToken), strings.NewReader(`{"code":"code"}`), t)
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	})
+
+	t.Run("It doesn't use the magic link on GET", func(t *testing.T) {
+		service := publicdashboards.NewFakePublicDashboardService(t)
+
+		response := callAPI(setup(t, service), http.MethodGet, fmt.Sprintf("/api/public/dashboards/%s/verify?code=code", validAccessToken), nil, t)
+		assert.NotEqual(t, http.StatusOK, response.Code)
+		service.AssertNotCalled(t, "VerifyMagicLink", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
 // `/public/dashboards/:uid/query“ endpoint test
 func TestAPIQueryPublicDashboard(t *testing.T) {
 	mockedResponse := &backend.QueryDataResponse{
@@ -185,6 +295,9 @@ func TestAPIQueryPublicDashboard(t *testing.T) {
 
 	setup := func(enabled bool) (*web.Mux, *publicdashboards.FakePublicDashboardService) {
 		service := publicdashboards.NewFakePublicDashboardService(t)
+		service.On("FindPublicDashboardAndDashboardByAccessToken", mock.Anything, mock.AnythingOfType("string")).
+			Return(&PublicDashboard{}, &models.Dashboard{}, nil).Maybe()
+		service.On("VerifyEmailSession", mock.Anything, mock.Anything, "").Return("", nil).Maybe()
 		cfg := setting.NewCfg()
 		cfg.RBACEnabled = false
 
@@ -322,7 +435,7 @@ func TestIntegrationUnauthenticatedUserCanGetPubdashPanelQueryData(t *testing.T)
 	cfg := setting.NewCfg()
 	ac := acmock.New()
 	cfg.RBACEnabled = false
-	service := publicdashboardsService.ProvideService(cfg, store, qds, annotationsService, ac)
+	service := publicdashboardsService.ProvideService(cfg, store, qds, annotationsService, ac, notifications.MockNotificationService(), nil)
 	pubdash, err := service.Create(context.Background(), &user.SignedInUser{}, savePubDashboardCmd)
 	require.NoError(t, err)
 
@@ -414,6 +527,9 @@ func TestAPIGetAnnotations(t *testing.T) {
 			service := publicdashboards.NewFakePublicDashboardService(t)
 
 			if test.ExpectedServiceCalled {
+				service.On("FindPublicDashboardAndDashboardByAccessToken", mock.Anything, mock.AnythingOfType("string")).
+					Return(&PublicDashboard{}, &models.Dashboard{}, nil).Once()
+				service.On("VerifyEmailSession", mock.Anything, mock.Anything, "").Return("", nil).Once()
 				service.On("FindAnnotations", mock.Anything, mock.Anything, mock.AnythingOfType("string")).
 					Return(test.Annotations, test.ServiceError).Once()
 			}
@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/grafana/grafana/pkg/infra/db"
 	"github.com/grafana/grafana/pkg/infra/log"
@@ -21,6 +22,12 @@ type PublicDashboardStoreImpl struct {
 
 var LogPrefix = "publicdashboards.store"
 
+// dateTimeFormat is the format of the times in the raw SQL queries.
+const dateTimeFormat = "2006-01-02 15:04:05"
+
+// notExpiredSQL filters out the expired public dashboards.
+const notExpiredSQL = "(expires_at IS NULL OR expires_at > ?)"
+
 // Gives us a compile time error if our database does not adhere to contract of
 // the interface
 var _ publicdashboards.Store = (*PublicDashboardStoreImpl)(nil)
@@ -39,7 +46,7 @@ func (d *PublicDashboardStoreImpl) FindAll(ctx context.Context, orgId int64) ([]
 
 	err := d.sqlStore.WithDbSession(ctx, func(sess *db.Session) error {
 		sess.Table("dashboard_public").Select(
-			"dashboard_public.uid, dashboard_public.access_token, dashboard.uid as dashboard_uid, dashboard_public.is_enabled, dashboard.title").
+			"dashboard_public.uid, dashboard_public.access_token, dashboard.uid as dashboard_uid, dashboard_public.is_enabled, dashboard_public.share, dashboard_public.view_count, dashboard_public.expires_at, dashboard.title").
 			Join("LEFT", "dashboard", "dashboard.uid = dashboard_public.dashboard_uid AND dashboard.org_id = dashboard_public.org_id").
 			Where("dashboard_public.org_id = ?", orgId).
 			OrderBy(" is_enabled DESC, dashboard.title IS NULL, dashboard.title ASC")
@@ -155,13 +162,14 @@ func (d *PublicDashboardStoreImpl) FindByDashboardUid(ctx context.Context, orgId
 	return publicDashboard, nil
 }
 
-// ExistsEnabledByDashboardUid Responds true if there is an enabled public dashboard for a dashboard uid
+// ExistsEnabledByDashboardUid Responds true if there is an enabled public dashboard for a dashboard uid that hasn't
+// expired
 func (d *PublicDashboardStoreImpl) ExistsEnabledByDashboardUid(ctx context.Context, dashboardUid string) (bool, error) {
 	hasPublicDashboard := false
 	err := d.sqlStore.WithDbSession(ctx, func(dbSession *db.Session) error {
-		sql := "SELECT COUNT(*) FROM dashboard_public WHERE dashboard_uid=? AND is_enabled=true"
+		sql := "SELECT COUNT(*) FROM dashboard_public WHERE dashboard_uid=? AND is_enabled=true AND " + notExpiredSQL
 
-		result, err := dbSession.SQL(sql, dashboardUid).Count()
+		result, err := dbSession.SQL(sql, dashboardUid, nowUTC()).Count()
 		if err != nil {
 			return err
 		}
@@ -173,13 +181,14 @@ func (d *PublicDashboardStoreImpl) ExistsEnabledByDashboardUid(ctx context.Conte
 	return hasPublicDashboard, err
 }
 
-// ExistsEnabledByAccessToken Responds true if the accessToken exists and the public dashboard is enabled
+// ExistsEnabledByAccessToken Responds true if the accessToken exists and the public dashboard is enabled and hasn't
+// expired
 func (d *PublicDashboardStoreImpl) ExistsEnabledByAccessToken(ctx context.Context, accessToken string) (bool, error) {
 	hasPublicDashboard := false
 	err := d.sqlStore.WithDbSession(ctx, func(dbSession *db.Session) error {
-		sql := "SELECT COUNT(*) FROM dashboard_public WHERE access_token=? AND is_enabled=true"
+		sql := "SELECT COUNT(*) FROM dashboard_public WHERE access_token=? AND is_enabled=true AND " + notExpiredSQL
 
-		result, err := dbSession.SQL(sql, accessToken).Count()
+		result, err := dbSession.SQL(sql, accessToken, nowUTC()).Count()
 		if err != nil {
 			return err
 		}
@@ -191,13 +200,13 @@ func (d *PublicDashboardStoreImpl) ExistsEnabledByAccessToken(ctx context.Contex
 	return hasPublicDashboard, err
 }
 
-// GetOrgIdByAccessToken Returns the public dashboard OrgId if exists and is enabled.
+// GetOrgIdByAccessToken Returns the public dashboard OrgId if exists, is enabled and hasn't expired.
 func (d *PublicDashboardStoreImpl) GetOrgIdByAccessToken(ctx context.Context, accessToken string) (int64, error) {
 	var orgId int64
 	err := d.sqlStore.WithDbSession(ctx, func(dbSession *db.Session) error {
-		sql := "SELECT org_id FROM dashboard_public WHERE access_token=? AND is_enabled=true"
+		sql := "SELECT org_id FROM dashboard_public WHERE access_token=? AND is_enabled=true AND " + notExpiredSQL
 
-		_, err := dbSession.SQL(sql, accessToken).Get(&orgId)
+		_, err := dbSession.SQL(sql, accessToken, nowUTC()).Get(&orgId)
 		if err != nil {
 			return err
 		}
@@ -233,13 +242,32 @@ func (d *PublicDashboardStoreImpl) Update(ctx context.Context, cmd SavePublicDas
 			return err
 		}
 
-		sqlResult, err := sess.Exec("UPDATE dashboard_public SET is_enabled = ?, annotations_enabled = ?, time_selection_enabled = ?, time_settings = ?, updated_by = ?, updated_at = ? WHERE uid = ?",
+		recipientsJSON, err := cmd.PublicDashboard.Recipients.ToDB()
+		if err != nil {
+			return err
+		}
+
+		templateVariablesJSON, err := cmd.PublicDashboard.TemplateVariables.ToDB()
+		if err != nil {
+			return err
+		}
+
+		var expiresAt interface{}
+		if cmd.PublicDashboard.ExpiresAt != nil {
+			expiresAt = cmd.PublicDashboard.ExpiresAt.UTC().Format(dateTimeFormat)
+		}
+
+		sqlResult, err := sess.Exec("UPDATE dashboard_public SET is_enabled = ?, annotations_enabled = ?, time_selection_enabled = ?, time_settings = ?, share = ?, recipients = ?, expires_at = ?, template_variables = ?, updated_by = ?, updated_at = ? WHERE uid = ?",
 			cmd.PublicDashboard.IsEnabled,
 			cmd.PublicDashboard.AnnotationsEnabled,
 			cmd.PublicDashboard.TimeSelectionEnabled,
 			string(timeSettingsJSON),
+			cmd.PublicDashboard.Share,
+			string(recipientsJSON),
+			expiresAt,
+			string(templateVariablesJSON),
 			cmd.PublicDashboard.UpdatedBy,
-			cmd.PublicDashboard.UpdatedAt.UTC().Format("2006-01-02 15:04:05"),
+			cmd.PublicDashboard.UpdatedAt.UTC().Format(dateTimeFormat),
 			cmd.PublicDashboard.Uid)
 
 		if err != nil {
@@ -254,16 +282,180 @@ func (d *PublicDashboardStoreImpl) Update(ctx context.Context, cmd SavePublicDas
 	return affectedRows, err
 }
 
-// Deletes a public dashboard
+// Deletes a public dashboard with its magic links, sessions and audit log
 func (d *PublicDashboardStoreImpl) Delete(ctx context.Context, orgId int64, uid string) (int64, error) {
 	dashboard := &PublicDashboard{OrgId: orgId, Uid: uid}
 	var affectedRows int64
-	err := d.sqlStore.WithDbSession(ctx, func(sess *db.Session) error {
+	err := d.sqlStore.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
 		var err error
 		affectedRows, err = sess.Delete(dashboard)
+		if err != nil || affectedRows == 0 {
+			return err
+		}
+
+		for _, sql := range []string{
+			"DELETE FROM dashboard_public_magic_link WHERE public_dashboard_uid = ?",
+			"DELETE FROM dashboard_public_session WHERE public_dashboard_uid = ?",
+			"DELETE FROM dashboard_public_audit WHERE public_dashboard_uid = ?",
+		} {
+			if _, err := sess.Exec(sql, uid); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return affectedRows, err
+}
 
+// CreateMagicLink Saves a magic link and deletes the expired magic links of its public dashboard
+func (d *PublicDashboardStoreImpl) CreateMagicLink(ctx context.Context, link *MagicLink) error {
+	return d.sqlStore.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		_, err := sess.Exec("DELETE FROM dashboard_public_magic_link WHERE public_dashboard_uid = ? AND expires_at <= ?",
+			link.PublicDashboardUid, link.CreatedAt.UTC().Format(dateTimeFormat))
+		if err != nil {
+			return err
+		}
+
+		_, err = sess.Insert(link)
+		return err
+	})
+}
+
+// CountPendingMagicLinks Returns the number of magic links sent to an email address that haven't expired
+func (d *PublicDashboardStoreImpl) CountPendingMagicLinks(ctx context.Context, uid string, email string, now time.Time) (int64, error) {
+	var count int64
+	err := d.sqlStore.WithDbSession(ctx, func(sess *db.Session) error {
+		var err error
+		count, err = sess.Where("public_dashboard_uid = ? AND email = ? AND expires_at > ?", uid, email, now.UTC().Format(dateTimeFormat)).
+			Count(&MagicLink{})
 		return err
 	})
 
+	return count, err
+}
+
+// UseMagicLink Deletes a magic link and returns it, or nil if it doesn't exist or expired. A magic link can only be
+// used once, even by concurrent requests.
+func (d *PublicDashboardStoreImpl) UseMagicLink(ctx context.Context, uid string, codeHash string, now time.Time) (*MagicLink, error) {
+	var link *MagicLink
+	err := d.sqlStore.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		found := &MagicLink{}
+		has, err := sess.Where("public_dashboard_uid = ? AND code_hash = ?", uid, codeHash).Get(found)
+		if err != nil || !has {
+			return err
+		}
+
+		deleted, err := sess.Where("id = ?", found.Id).Delete(&MagicLink{})
+		if err != nil || deleted == 0 {
+			return err
+		}
+
+		if found.ExpiresAt.After(now) {
+			link = found
+		}
+		return nil
+	})
+
+	return link, err
+}
+
+// CreateEmailSession Saves a session and deletes the expired sessions of its public dashboard
+func (d *PublicDashboardStoreImpl) CreateEmailSession(ctx context.Context, session *EmailSession) error {
+	return d.sqlStore.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		_, err := sess.Exec("DELETE FROM dashboard_public_session WHERE public_dashboard_uid = ? AND expires_at <= ?",
+			session.PublicDashboardUid, session.CreatedAt.UTC().Format(dateTimeFormat))
+		if err != nil {
+			return err
+		}
+
+		_, err = sess.Insert(session)
+		return err
+	})
+}
+
+// FindEmailSession Returns a session that hasn't expired or nil if not found
+func (d *PublicDashboardStoreImpl) FindEmailSession(ctx context.Context, uid string, tokenHash string, now time.Time) (*EmailSession, error) {
+	var found bool
+	session := &EmailSession{}
+	err := d.sqlStore.WithDbSession(ctx, func(sess *db.Session) error {
+		var err error
+		found, err = sess.Where("public_dashboard_uid = ? AND token_hash = ? AND expires_at > ?", uid, tokenHash, now.UTC().Format(dateTimeFormat)).
+			Get(session)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, nil
+	}
+
+	return session, nil
+}
+
+// IncrementViewCount Counts a view of a public dashboard
+func (d *PublicDashboardStoreImpl) IncrementViewCount(ctx context.Context, uid string, viewedAt time.Time) error {
+	return d.sqlStore.WithDbSession(ctx, func(sess *db.Session) error {
+		_, err := sess.Exec("UPDATE dashboard_public SET view_count = view_count + 1, last_viewed_at = ? WHERE uid = ?",
+			viewedAt.UTC().Format(dateTimeFormat), uid)
+		return err
+	})
+}
+
+// CreateAuditEntry Saves an entry of the audit log of a public dashboard
+func (d *PublicDashboardStoreImpl) CreateAuditEntry(ctx context.Context, entry *AuditEntry) error {
+	return d.sqlStore.WithDbSession(ctx, func(sess *db.Session) error {
+		_, err := sess.Insert(entry)
+		return err
+	})
+}
+
+// FindAuditEntries Returns the audit log of a public dashboard, the most recent entries first
+func (d *PublicDashboardStoreImpl) FindAuditEntries(ctx context.Context, orgId int64, uid string, limit int) ([]AuditEntry, error) {
+	entries := make([]AuditEntry, 0)
+	err := d.sqlStore.WithDbSession(ctx, func(sess *db.Session) error {
+		return sess.Where("org_id = ? AND public_dashboard_uid = ?", orgId, uid).
+			Desc("created_at", "id").
+			Limit(limit).
+			Find(&entries)
+	})
+
+	return entries, err
+}
+
+// DeleteExpired Deletes the magic links and sessions that expired at a time and the audit entries created before another
+func (d *PublicDashboardStoreImpl) DeleteExpired(ctx context.Context, now time.Time, auditCreatedBefore time.Time) (int64, error) {
+	var affectedRows int64
+	err := d.sqlStore.WithDbSession(ctx, func(sess *db.Session) error {
+		for _, cmd := range []struct {
+			sql string
+			arg time.Time
+		}{
+			{"DELETE FROM dashboard_public_magic_link WHERE expires_at <= ?", now},
+			{"DELETE FROM dashboard_public_session WHERE expires_at <= ?", now},
+			{"DELETE FROM dashboard_public_audit WHERE created_at < ?", auditCreatedBefore},
+		} {
+			res, err := sess.Exec(cmd.sql, cmd.arg.UTC().Format(dateTimeFormat))
+			if err != nil {
+				return err
+			}
+			rows, err := res.RowsAffected()
+			if err != nil {
+				return err
+			}
+			affectedRows += rows
+		}
+
+		return nil
+	})
+
 	return affectedRows, err
 }
+
+func nowUTC() string {
+	return time.Now().UTC().Format(dateTimeFormat)
+}
@@ -666,6 +666,228 @@ func TestIntegrationDelete(t *testing.T) {
 	})
 }
 
+func TestIntegrationEmailShare(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+	var sqlStore db.DB
+	var cfg *setting.Cfg
+	var dashboardStore *dashboardsDB.DashboardStore
+	var publicdashboardStore *PublicDashboardStoreImpl
+	var savedDashboard *models.Dashboard
+	var savedPublicDashboard *PublicDashboard
+	var err error
+
+	ctx := context.Background()
+	now := time.Now()
+
+	setup := func() {
+		sqlStore, cfg = db.InitTestDBwithCfg(t)
+		dashboardStore, err = dashboardsDB.ProvideDashboardStore(sqlStore, cfg, featuremgmt.WithFeatures(), tagimpl.ProvideService(sqlStore, cfg), quotatest.New(false, nil))
+		require.NoError(t, err)
+		publicdashboardStore = ProvideStore(sqlStore)
+		savedDashboard = insertTestDashboard(t, dashboardStore, "testDashie", 1, 0, true)
+		savedPublicDashboard = insertPublicDashboard(t, publicdashboardStore, savedDashboard.Uid, savedDashboard.OrgId, true)
+	}
+
+	t.Run("Update saves the share settings", func(t *testing.T) {
+		setup()
+		expiresAt := now.Add(time.Hour).UTC().Round(time.Second)
+		savedPublicDashboard.Share = EmailShareType
+		savedPublicDashboard.Recipients = Recipients{"a@example.com", "b@example.com"}
+		savedPublicDashboard.ExpiresAt = &expiresAt
+		_, err := publicdashboardStore.Update(ctx, SavePublicDashboardCommand{PublicDashboard: *savedPublicDashboard})
+		require.NoError(t, err)
+
+		pubdash, err := publicdashboardStore.Find(ctx, savedPublicDashboard.Uid)
+		require.NoError(t, err)
+		assert.Equal(t, EmailShareType, pubdash.Share)
+		assert.Equal(t, Recipients{"a@example.com", "b@example.com"}, pubdash.Recipients)
+		require.NotNil(t, pubdash.ExpiresAt)
+		assert.True(t, expiresAt.Equal(*pubdash.ExpiresAt))
+	})
+
+	t.Run("Expired public dashboards aren't enabled", func(t *testing.T) {
+		setup()
+		expiresAt := now.Add(-time.Hour)
+		savedPublicDashboard.ExpiresAt = &expiresAt
+		_, err := publicdashboardStore.Update(ctx, SavePublicDashboardCommand{PublicDashboard: *savedPublicDashboard})
+		require.NoError(t, err)
+
+		exists, err := publicdashboardStore.ExistsEnabledByAccessToken(ctx, savedPublicDashboard.AccessToken)
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		exists, err = publicdashboardStore.ExistsEnabledByDashboardUid(ctx, savedDashboard.Uid)
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		orgId, err := publicdashboardStore.GetOrgIdByAccessToken(ctx, savedPublicDashboard.AccessToken)
+		require.NoError(t, err)
+		assert.Zero(t, orgId)
+	})
+
+	t.Run("Magic links can only be used once before they expire", func(t *testing.T) {
+		setup()
+		for _, link := range []*MagicLink{
+			{PublicDashboardUid: savedPublicDashboard.Uid, Email: "a@example.com", CodeHash: "valid", ExpiresAt: now.Add(time.Minute), CreatedAt: now},
+			{PublicDashboardUid: savedPublicDashboard.Uid, Email: "a@example.com", CodeHash: "expired", ExpiresAt: now.Add(-time.Minute), CreatedAt: now},
+		} {
+			require.NoError(t, publicdashboardStore.CreateMagicLink(ctx, link))
+		}
+
+		pending, err := publicdashboardStore.CountPendingMagicLinks(ctx, savedPublicDashboard.Uid, "a@example.com", now)
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, pending)
+
+		link, err := publicdashboardStore.UseMagicLink(ctx, savedPublicDashboard.Uid, "valid", now)
+		require.NoError(t, err)
+		require.NotNil(t, link)
+		assert.Equal(t, "a@example.com", link.Email)
+
+		link, err = publicdashboardStore.UseMagicLink(ctx, savedPublicDashboard.Uid, "valid", now)
+		require.NoError(t, err)
+		assert.Nil(t, link)
+
+		link, err = publicdashboardStore.UseMagicLink(ctx, savedPublicDashboard.Uid, "expired", now)
+		require.NoError(t, err)
+		assert.Nil(t, link)
+	})
+
+	t.Run("FindEmailSession ignores expired sessions", func(t *testing.T) {
+		setup()
+		require.NoError(t, publicdashboardStore.CreateEmailSession(ctx, &EmailSession{
+			PublicDashboardUid: savedPublicDashboard.Uid, Email: "a@example.com", TokenHash: "token", ExpiresAt: now.Add(time.Hour), CreatedAt: now,
+		}))
+
+		session, err := publicdashboardStore.FindEmailSession(ctx, savedPublicDashboard.Uid, "token", now)
+		require.NoError(t, err)
+		require.NotNil(t, session)
+		assert.Equal(t, "a@example.com", session.Email)
+
+		session, err = publicdashboardStore.FindEmailSession(ctx, savedPublicDashboard.Uid, "token", now.Add(2*time.Hour))
+		require.NoError(t, err)
+		assert.Nil(t, session)
+
+		session, err = publicdashboardStore.FindEmailSession(ctx, "another-uid", "token", now)
+		require.NoError(t, err)
+		assert.Nil(t, session)
+	})
+
+	t.Run("IncrementViewCount counts views", func(t *testing.T) {
+		setup()
+		require.NoError(t, publicdashboardStore.IncrementViewCount(ctx, savedPublicDashboard.Uid, now))
+		require.NoError(t, publicdashboardStore.IncrementViewCount(ctx, savedPublicDashboard.Uid, now))
+
+		pubdash, err := publicdashboardStore.Find(ctx, savedPublicDashboard.Uid)
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, pubdash.ViewCount)
+		assert.NotNil(t, pubdash.LastViewedAt)
+
+		list, err := publicdashboardStore.FindAll(ctx, savedPublicDashboard.OrgId)
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		assert.EqualValues(t, 2, list[0].ViewCount)
+	})
+
+	t.Run("FindAuditEntries returns the most recent entries first", func(t *testing.T) {
+		setup()
+		for i, action := range []string{AuditActionLinkRequested, AuditActionVerified, AuditActionView} {
+			require.NoError(t, publicdashboardStore.CreateAuditEntry(ctx, &AuditEntry{
+				OrgId:              savedPublicDashboard.OrgId,
+				PublicDashboardUid: savedPublicDashboard.Uid,
+				Action:             action,
+				Email:              "a@example.com",
+				CreatedAt:          now.Add(time.Duration(i) * time.Second),
+			}))
+		}
+
+		entries, err := publicdashboardStore.FindAuditEntries(ctx, savedPublicDashboard.OrgId, savedPublicDashboard.Uid, 2)
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.Equal(t, AuditActionView, entries[0].Action)
+		assert.Equal(t, AuditActionVerified, entries[1].Action)
+
+		entries, err = publicdashboardStore.FindAuditEntries(ctx, 2, savedPublicDashboard.Uid, 10)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("DeleteExpired removes the expired magic links and sessions and the old audit entries", func(t *testing.T) {
+		setup()
+		for _, link := range []*MagicLink{
+			{PublicDashboardUid: savedPublicDashboard.Uid, Email: "a@example.com", CodeHash: "valid", ExpiresAt: now.Add(time.Minute), CreatedAt: now},
+			{PublicDashboardUid: savedPublicDashboard.Uid, Email: "a@example.com", CodeHash: "expired", ExpiresAt: now.Add(-time.Minute), CreatedAt: now.Add(-time.Hour)},
+		} {
+			require.NoError(t, publicdashboardStore.CreateMagicLink(ctx, link))
+		}
+		for _, session := range []*EmailSession{
+			{PublicDashboardUid: savedPublicDashboard.Uid, Email: "a@example.com", TokenHash: "valid", ExpiresAt: now.Add(time.Hour), CreatedAt: now},
+			{PublicDashboardUid: savedPublicDashboard.Uid, Email: "a@example.com", TokenHash: "expired", ExpiresAt: now.Add(-time.Hour), CreatedAt: now.Add(-48 * time.Hour)},
+		} {
+			require.NoError(t, publicdashboardStore.CreateEmailSession(ctx, session))
+		}
+		for _, createdAt := range []time.Time{now, now.Add(-48 * time.Hour)} {
+			require.NoError(t, publicdashboardStore.CreateAuditEntry(ctx, &AuditEntry{OrgId: savedPublicDashboard.OrgId, PublicDashboardUid: savedPublicDashboard.Uid, Action: AuditActionView, CreatedAt: createdAt}))
+		}
+
+		affected, err := publicdashboardStore.DeleteExpired(ctx, now, now.Add(-24*time.Hour))
+		require.NoError(t, err)
+		assert.EqualValues(t, 3, affected)
+
+		pending, err := publicdashboardStore.CountPendingMagicLinks(ctx, savedPublicDashboard.Uid, "a@example.com", now.Add(-2*time.Hour))
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, pending)
+		session, err := publicdashboardStore.FindEmailSession(ctx, savedPublicDashboard.Uid, "valid", now)
+		require.NoError(t, err)
+		assert.NotNil(t, session)
+		entries, err := publicdashboardStore.FindAuditEntries(ctx, savedPublicDashboard.OrgId, savedPublicDashboard.Uid, 10)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+	})
+
+	t.Run("Delete removes the magic links, sessions and audit log", func(t *testing.T) {
+		setup()
+		require.NoError(t, publicdashboardStore.CreateMagicLink(ctx, &MagicLink{PublicDashboardUid: savedPublicDashboard.Uid, Email: "a@example.com", CodeHash: "code", ExpiresAt: now.Add(time.Minute), CreatedAt: now}))
+		require.NoError(t, publicdashboardStore.CreateEmailSession(ctx, &EmailSession{PublicDashboardUid: savedPublicDashboard.Uid, Email: "a@example.com", TokenHash: "token", ExpiresAt: now.Add(time.Hour), CreatedAt: now}))
+		require.NoError(t, publicdashboardStore.CreateAuditEntry(ctx, &AuditEntry{OrgId: savedPublicDashboard.OrgId, PublicDashboardUid: savedPublicDashboard.Uid, Action: AuditActionView, CreatedAt: now}))
+
+		_, err := publicdashboardStore.Delete(ctx, savedPublicDashboard.OrgId, savedPublicDashboard.Uid)
+		require.NoError(t, err)
+
+		link, err := publicdashboardStore.UseMagicLink(ctx, savedPublicDashboard.Uid, "code", now)
+		require.NoError(t, err)
+		assert.Nil(t, link)
+		session, err := publicdashboardStore.FindEmailSession(ctx, savedPublicDashboard.Uid, "token", now)
+		require.NoError(t, err)
+		assert.Nil(t, session)
+		entries, err := publicdashboardStore.FindAuditEntries(ctx, savedPublicDashboard.OrgId, savedPublicDashboard.Uid, 10)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+}
+
+func TestIntegrationTemplateVariables(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+	sqlStore, cfg := db.InitTestDBwithCfg(t)
+	dashboardStore, err := dashboardsDB.ProvideDashboardStore(sqlStore, cfg, featuremgmt.WithFeatures(), tagimpl.ProvideService(sqlStore, cfg), quotatest.New(false, nil))
+	require.NoError(t, err)
+	publicdashboardStore := ProvideStore(sqlStore)
+	savedDashboard := insertTestDashboard(t, dashboardStore, "testDashie", 1, 0, true)
+	savedPublicDashboard := insertPublicDashboard(t, publicdashboardStore, savedDashboard.Uid, savedDashboard.OrgId, true)
+	assert.Nil(t, savedPublicDashboard.TemplateVariables)
+
+	savedPublicDashboard.TemplateVariables = TemplateVariables{{Name: "env", Values: []string{"prod", "dev"}}}
+	_, err = publicdashboardStore.Update(context.Background(), SavePublicDashboardCommand{PublicDashboard: *savedPublicDashboard})
+	require.NoError(t, err)
+
+	pubdash, err := publicdashboardStore.Find(context.Background(), savedPublicDashboard.Uid)
+	require.NoError(t, err)
+	assert.Equal(t, savedPublicDashboard.TemplateVariables, pubdash.TemplateVariables)
+}
+
 // helper function to insert a dashboard
 func insertTestDashboard(t *testing.T, dashboardStore *dashboardsDB.DashboardStore, title string, orgId int64,
 	folderId int64, isFolder bool, tags ...interface{}) *models.Dashboard {
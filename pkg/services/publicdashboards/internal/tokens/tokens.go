@@ -1,6 +1,8 @@
 package tokens
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 
 	"github.com/google/uuid"
@@ -27,3 +29,15 @@ func IsValidAccessToken(token string) bool {
 func IsValidShortUID(uid string) bool {
 	return uid != "" && util.IsValidShortUID(uid)
 }
+
+// GenerateSecret generates a random secret, such as a magic link code or a session token, to send to a viewer. Only
+// the hash of a secret is stored.
+func GenerateSecret() (string, error) {
+	return util.GetRandomString(32)
+}
+
+// HashSecret returns the hash of a secret to store it
+func HashSecret(secret string) string {
+	hash := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(hash[:])
+}
@@ -4,6 +4,7 @@ import "github.com/grafana/grafana/pkg/util/errutil"
 
 var (
 	ErrInternalServerError = errutil.NewBase(errutil.StatusInternal, "publicdashboards.internalServerError", errutil.WithPublicMessage("Internal server error"))
+	ErrEmailNotConfigured  = errutil.NewBase(errutil.StatusInternal, "publicdashboards.emailNotConfigured", errutil.WithPublicMessage("Sending emails isn't configured"))
 
 	ErrPublicDashboardNotFound = errutil.NewBase(errutil.StatusNotFound, "publicdashboards.notFound", errutil.WithPublicMessage("Public dashboard not found"))
 	ErrDashboardNotFound       = errutil.NewBase(errutil.StatusNotFound, "publicdashboards.dashboardNotFound", errutil.WithPublicMessage("Dashboard not found"))
@@ -15,8 +16,16 @@ var (
 	ErrInvalidPanelId       = errutil.NewBase(errutil.StatusBadRequest, "publicdashboards.invalidPanelId", errutil.WithPublicMessage("Invalid panel id"))
 	ErrInvalidUid           = errutil.NewBase(errutil.StatusBadRequest, "publicdashboards.invalidUid", errutil.WithPublicMessage("Invalid Uid"))
 
-	ErrPublicDashboardIdentifierNotSet     = errutil.NewBase(errutil.StatusBadRequest, "publicdashboards.identifierNotSet", errutil.WithPublicMessage("No Uid for public dashboard specified"))
-	ErrPublicDashboardHasTemplateVariables = errutil.NewBase(errutil.StatusBadRequest, "publicdashboards.hasTemplateVariables", errutil.WithPublicMessage("Public dashboard has template variables"))
-	ErrInvalidInterval                     = errutil.NewBase(errutil.StatusBadRequest, "publicdashboards.invalidInterval", errutil.WithPublicMessage("intervalMS should be greater than 0"))
-	ErrInvalidMaxDataPoints                = errutil.NewBase(errutil.StatusBadRequest, "publicdashboards.maxDataPoints", errutil.WithPublicMessage("maxDataPoints should be greater than 0"))
+	ErrPublicDashboardIdentifierNotSet = errutil.NewBase(errutil.StatusBadRequest, "publicdashboards.identifierNotSet", errutil.WithPublicMessage("No Uid for public dashboard specified"))
+	ErrInvalidTemplateVariables        = errutil.NewBase(errutil.StatusBadRequest, "publicdashboards.invalidTemplateVariables", errutil.WithPublicMessage("Invalid template variables"))
+	ErrInvalidTemplateVariableValue    = errutil.NewBase(errutil.StatusBadRequest, "publicdashboards.invalidTemplateVariableValue", errutil.WithPublicMessage("Invalid template variable value"))
+	ErrInvalidInterval                 = errutil.NewBase(errutil.StatusBadRequest, "publicdashboards.invalidInterval", errutil.WithPublicMessage("intervalMS should be greater than 0"))
+	ErrInvalidMaxDataPoints            = errutil.NewBase(errutil.StatusBadRequest, "publicdashboards.maxDataPoints", errutil.WithPublicMessage("maxDataPoints should be greater than 0"))
+	ErrInvalidShareType                = errutil.NewBase(errutil.StatusBadRequest, "publicdashboards.invalidShareType", errutil.WithPublicMessage("Invalid share type"))
+	ErrInvalidRecipients               = errutil.NewBase(errutil.StatusBadRequest, "publicdashboards.invalidRecipients", errutil.WithPublicMessage("Invalid recipients"))
+	ErrInvalidExpiration               = errutil.NewBase(errutil.StatusBadRequest, "publicdashboards.invalidExpiration", errutil.WithPublicMessage("The expiration time must be in the future"))
+	ErrInvalidEmail                    = errutil.NewBase(errutil.StatusBadRequest, "publicdashboards.invalidEmail", errutil.WithPublicMessage("Invalid email address"))
+	ErrInvalidMagicLink                = errutil.NewBase(errutil.StatusBadRequest, "publicdashboards.invalidMagicLink", errutil.WithPublicMessage("The link is invalid or expired"))
+
+	ErrEmailVerificationRequired = errutil.NewBase(errutil.StatusUnauthorized, "publicdashboards.emailVerificationRequired", errutil.WithPublicMessage("Email verification required"))
 )
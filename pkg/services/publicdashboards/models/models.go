@@ -3,6 +3,7 @@ package models
 import (
 	"encoding/json"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/grafana/grafana/pkg/kinds/dashboard"
@@ -30,15 +31,41 @@ const QueryFailure = "failure"
 
 var QueryResultStatuses = []string{QuerySuccess, QueryFailure}
 
+// The share types of public dashboards. A public dashboard shared with emails can only be viewed by the recipients
+// who verified their email address with a magic link.
+const (
+	PublicShareType = "public"
+	EmailShareType  = "email"
+)
+
+// EmailSessionCookieName is the name of the cookie of the session of a recipient of a public dashboard shared with
+// emails.
+const EmailSessionCookieName = "grafana_public_dashboard_session"
+
+// The actions recorded in the audit log of public dashboards.
+const (
+	AuditActionView          = "view"
+	AuditActionLinkRequested = "link_requested"
+	AuditActionLinkDenied    = "link_denied"
+	AuditActionVerified      = "verified"
+)
+
 type PublicDashboard struct {
-	Uid                  string        `json:"uid" xorm:"pk uid"`
-	DashboardUid         string        `json:"dashboardUid" xorm:"dashboard_uid"`
-	OrgId                int64         `json:"-" xorm:"org_id"` // Don't ever marshal orgId to Json
-	TimeSettings         *TimeSettings `json:"timeSettings" xorm:"time_settings"`
-	IsEnabled            bool          `json:"isEnabled" xorm:"is_enabled"`
-	AccessToken          string        `json:"accessToken" xorm:"access_token"`
-	AnnotationsEnabled   bool          `json:"annotationsEnabled" xorm:"annotations_enabled"`
-	TimeSelectionEnabled bool          `json:"timeSelectionEnabled" xorm:"time_selection_enabled"`
+	Uid                  string            `json:"uid" xorm:"pk uid"`
+	DashboardUid         string            `json:"dashboardUid" xorm:"dashboard_uid"`
+	OrgId                int64             `json:"-" xorm:"org_id"` // Don't ever marshal orgId to Json
+	TimeSettings         *TimeSettings     `json:"timeSettings" xorm:"time_settings"`
+	IsEnabled            bool              `json:"isEnabled" xorm:"is_enabled"`
+	AccessToken          string            `json:"accessToken" xorm:"access_token"`
+	AnnotationsEnabled   bool              `json:"annotationsEnabled" xorm:"annotations_enabled"`
+	TimeSelectionEnabled bool              `json:"timeSelectionEnabled" xorm:"time_selection_enabled"`
+	Share                string            `json:"share" xorm:"share"`
+	Recipients           Recipients        `json:"recipients" xorm:"recipients"`
+	ExpiresAt            *time.Time        `json:"expiresAt,omitempty" xorm:"expires_at"`
+	TemplateVariables    TemplateVariables `json:"templateVariables" xorm:"template_variables"`
+
+	ViewCount    int64      `json:"viewCount" xorm:"view_count"`
+	LastViewedAt *time.Time `json:"lastViewedAt,omitempty" xorm:"last_viewed_at"`
 
 	CreatedBy int64 `json:"createdBy" xorm:"created_by"`
 	UpdatedBy int64 `json:"updatedBy" xorm:"updated_by"`
@@ -73,12 +100,102 @@ func (pd PublicDashboard) TableName() string {
 	return "dashboard_public"
 }
 
+// IsExpired returns true if the public dashboard expired at a time.
+func (pd PublicDashboard) IsExpired(now time.Time) bool {
+	return pd.ExpiresAt != nil && !now.Before(*pd.ExpiresAt)
+}
+
+// IsEmailShare returns true if the public dashboard can only be viewed by its recipients.
+func (pd PublicDashboard) IsEmailShare() bool {
+	return pd.Share == EmailShareType
+}
+
+// HasRecipient returns true if an email address is a recipient of the public dashboard.
+func (pd PublicDashboard) HasRecipient(email string) bool {
+	email = NormalizeEmail(email)
+	for _, recipient := range pd.Recipients {
+		if recipient == email {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeEmail returns an email address in the format of the recipients of public dashboards.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// Recipients are the email addresses of the recipients of a public dashboard.
+type Recipients []string
+
+func (r *Recipients) FromDB(data []byte) error {
+	if len(data) == 0 {
+		*r = nil
+		return nil
+	}
+	return json.Unmarshal(data, r)
+}
+
+func (r *Recipients) ToDB() ([]byte, error) {
+	if *r == nil {
+		return nil, nil
+	}
+	return json.Marshal(r)
+}
+
+// MagicLink is a one-time code sent to a recipient of a public dashboard to verify their email address.
+type MagicLink struct {
+	Id                 int64     `xorm:"pk autoincr 'id'"`
+	PublicDashboardUid string    `xorm:"public_dashboard_uid"`
+	Email              string    `xorm:"email"`
+	CodeHash           string    `xorm:"code_hash"`
+	ExpiresAt          time.Time `xorm:"expires_at"`
+	CreatedAt          time.Time `xorm:"created_at"`
+}
+
+func (MagicLink) TableName() string {
+	return "dashboard_public_magic_link"
+}
+
+// EmailSession is the session of a recipient of a public dashboard who verified their email address.
+type EmailSession struct {
+	Id                 int64     `xorm:"pk autoincr 'id'"`
+	PublicDashboardUid string    `xorm:"public_dashboard_uid"`
+	Email              string    `xorm:"email"`
+	TokenHash          string    `xorm:"token_hash"`
+	ExpiresAt          time.Time `xorm:"expires_at"`
+	CreatedAt          time.Time `xorm:"created_at"`
+}
+
+func (EmailSession) TableName() string {
+	return "dashboard_public_session"
+}
+
+// AuditEntry is an entry of the audit log of a public dashboard.
+type AuditEntry struct {
+	Id                 int64     `json:"id" xorm:"pk autoincr 'id'"`
+	OrgId              int64     `json:"-" xorm:"org_id"`
+	PublicDashboardUid string    `json:"publicDashboardUid" xorm:"public_dashboard_uid"`
+	Action             string    `json:"action" xorm:"action"`
+	Email              string    `json:"email,omitempty" xorm:"email"`
+	CreatedAt          time.Time `json:"createdAt" xorm:"created_at"`
+}
+
+func (AuditEntry) TableName() string {
+	return "dashboard_public_audit"
+}
+
 type PublicDashboardListResponse struct {
 	Uid          string `json:"uid" xorm:"uid"`
 	AccessToken  string `json:"accessToken" xorm:"access_token"`
 	Title        string `json:"title" xorm:"title"`
 	DashboardUid string `json:"dashboardUid" xorm:"dashboard_uid"`
 	IsEnabled    bool   `json:"isEnabled" xorm:"is_enabled"`
+	Share        string `json:"share" xorm:"share"`
+	ViewCount    int64  `json:"viewCount" xorm:"view_count"`
+
+	ExpiresAt *time.Time `json:"expiresAt,omitempty" xorm:"expires_at"`
 }
 
 type TimeSettings struct {
@@ -125,6 +242,16 @@ type SavePublicDashboardDTO struct {
 type PublicDashboardQueryDTO struct {
 	IntervalMs    int64
 	MaxDataPoints int64
+	// Variables are the values of the template variables selected by the viewer, by name
+	Variables map[string]string
+}
+
+type SendMagicLinkDTO struct {
+	Email string `json:"email"`
+}
+
+type VerifyMagicLinkDTO struct {
+	Code string `json:"code"`
 }
 
 type AnnotationsQueryDTO struct {
@@ -5,6 +5,7 @@ import (
 
 	"github.com/grafana/grafana/pkg/components/simplejson"
 	"github.com/grafana/grafana/pkg/models"
+	"github.com/grafana/grafana/pkg/services/dashboards/dashboardjson"
 	"github.com/grafana/grafana/pkg/services/publicdashboards/internal"
 	"github.com/stretchr/testify/assert"
 )
@@ -48,3 +49,68 @@ func TestBuildTimeSettings(t *testing.T) {
 		})
 	}
 }
+
+func TestTemplateVariables(t *testing.T) {
+	dashboard := &models.Dashboard{Data: simplejson.NewFromAny(map[string]interface{}{
+		"templating": map[string]interface{}{
+			"list": []interface{}{
+				map[string]interface{}{"name": "env", "label": "Environment", "type": "custom", "current": map[string]interface{}{"value": "dev"}},
+				map[string]interface{}{"name": "job", "type": "query", "query": "label_values(job)", "current": map[string]interface{}{"value": []interface{}{"api", "web"}}},
+				map[string]interface{}{"name": "all", "type": "query", "current": map[string]interface{}{"value": []interface{}{"$__all"}}, "options": []interface{}{
+					map[string]interface{}{"value": "$__all"}, map[string]interface{}{"value": "a"}, map[string]interface{}{"value": "b"},
+				}},
+				map[string]interface{}{"name": "allValue", "type": "query", "allValue": ".*", "current": map[string]interface{}{"value": "$__all"}},
+				map[string]interface{}{"name": "constant", "type": "constant", "query": "42"},
+				map[string]interface{}{"name": "filters", "type": "adhoc"},
+			},
+		},
+	})}
+	pubdash := PublicDashboard{TemplateVariables: TemplateVariables{{Name: "env", Values: []string{"prod", "staging"}}}}
+
+	t.Run("TemplateVariableValues", func(t *testing.T) {
+		expected := map[string]dashboardjson.VariableValue{
+			"env":      {Values: []string{"prod"}},
+			"job":      {Values: []string{"api", "web"}},
+			"all":      {Values: []string{"a", "b"}},
+			"allValue": {Values: []string{".*"}, Raw: true},
+			"constant": {Values: []string{"42"}},
+			"filters":  {Values: []string{""}},
+		}
+		assert.Equal(t, expected, pubdash.TemplateVariableValues(dashboard, nil))
+
+		expected["env"] = dashboardjson.VariableValue{Values: []string{"staging"}}
+		assert.Equal(t, expected, pubdash.TemplateVariableValues(dashboard, map[string]string{"env": "staging"}))
+	})
+
+	t.Run("BuildTemplateVariables", func(t *testing.T) {
+		variables := pubdash.BuildTemplateVariables(dashboard)
+		assert.Len(t, variables, 5)
+
+		env := variables[0].(map[string]interface{})
+		assert.Equal(t, "custom", env["type"])
+		assert.Equal(t, "Environment", env["label"])
+		assert.Equal(t, "prod,staging", env["query"])
+		assert.Equal(t, map[string]interface{}{"text": "prod", "value": "prod"}, env["current"])
+		assert.Len(t, env["options"], 2)
+
+		// viewers can't run the variable queries
+		job := variables[1].(map[string]interface{})
+		assert.Equal(t, "constant", job["type"])
+		assert.Equal(t, "api,web", job["query"])
+		assert.Equal(t, 2, job["hide"])
+	})
+
+	t.Run("TemplateVariables are stored as JSON", func(t *testing.T) {
+		data, err := pubdash.TemplateVariables.ToDB()
+		assert.NoError(t, err)
+
+		var stored TemplateVariables
+		assert.NoError(t, stored.FromDB(data))
+		assert.Equal(t, pubdash.TemplateVariables, stored)
+
+		var empty TemplateVariables
+		data, err = empty.ToDB()
+		assert.NoError(t, err)
+		assert.Nil(t, data)
+	})
+}
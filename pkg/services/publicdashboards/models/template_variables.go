@@ -0,0 +1,119 @@
+package models
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/grafana/grafana/pkg/models"
+	"github.com/grafana/grafana/pkg/services/dashboards/dashboardjson"
+)
+
+// TemplateVariable lists the values of a template variable of the dashboard that viewers of a public dashboard can
+// select. The first value is selected by default.
+type TemplateVariable struct {
+	Name   string   `json:"name"`
+	Values []string `json:"values"`
+}
+
+// TemplateVariables are the template variables of the dashboard that viewers of a public dashboard can change.
+type TemplateVariables []TemplateVariable
+
+// Find returns the template variable with a name, or nil if viewers can't change it.
+func (tv TemplateVariables) Find(name string) *TemplateVariable {
+	for i := range tv {
+		if tv[i].Name == name {
+			return &tv[i]
+		}
+	}
+	return nil
+}
+
+// HasValue returns true if a value can be selected for the template variable.
+func (v TemplateVariable) HasValue(value string) bool {
+	for _, allowed := range v.Values {
+		if allowed == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (tv *TemplateVariables) FromDB(data []byte) error {
+	if len(data) == 0 {
+		*tv = nil
+		return nil
+	}
+	return json.Unmarshal(data, tv)
+}
+
+func (tv *TemplateVariables) ToDB() ([]byte, error) {
+	if *tv == nil {
+		return nil, nil
+	}
+	return json.Marshal(tv)
+}
+
+// TemplateVariableValues returns the values to interpolate in the queries of a dashboard by template variable name.
+// The variables viewers can change have the selected value, or their first value by default, and the other
+// variables have the values saved with the dashboard. The selected values must have been validated.
+func (pd PublicDashboard) TemplateVariableValues(dashboard *models.Dashboard, selected map[string]string) map[string]dashboardjson.VariableValue {
+	values := make(map[string]dashboardjson.VariableValue)
+	for _, variable := range dashboardjson.Variables(dashboard.Data) {
+		name, _ := variable["name"].(string)
+		if name == "" {
+			continue
+		}
+
+		if allowed := pd.TemplateVariables.Find(name); allowed != nil && len(allowed.Values) > 0 {
+			if value, ok := selected[name]; ok {
+				values[name] = dashboardjson.VariableValue{Values: []string{value}}
+			} else {
+				values[name] = dashboardjson.VariableValue{Values: []string{allowed.Values[0]}}
+			}
+			continue
+		}
+
+		values[name] = dashboardjson.SavedValue(variable)
+	}
+	return values
+}
+
+// BuildTemplateVariables returns the template variables of a dashboard as viewers of the public dashboard see them.
+// Viewers can choose between the values allowed for the variables they can change, and the other variables are
+// hidden constants with the value saved with the dashboard, so that viewers never run variable queries.
+func (pd PublicDashboard) BuildTemplateVariables(dashboard *models.Dashboard) []interface{} {
+	values := pd.TemplateVariableValues(dashboard, nil)
+
+	variables := make([]interface{}, 0)
+	for _, variable := range dashboardjson.Variables(dashboard.Data) {
+		name, _ := variable["name"].(string)
+		if name == "" || variable["type"] == "adhoc" {
+			continue
+		}
+
+		value := values[name].String()
+		built := map[string]interface{}{
+			"name":    name,
+			"label":   variable["label"],
+			"current": map[string]interface{}{"text": value, "value": value},
+		}
+
+		if allowed := pd.TemplateVariables.Find(name); allowed != nil {
+			options := make([]interface{}, 0, len(allowed.Values))
+			for _, option := range allowed.Values {
+				options = append(options, map[string]interface{}{"text": option, "value": option, "selected": option == value})
+			}
+			built["type"] = "custom"
+			built["query"] = strings.Join(allowed.Values, ",")
+			built["options"] = options
+			built["hide"] = variable["hide"]
+		} else {
+			built["type"] = "constant"
+			built["query"] = value
+			built["hide"] = 2
+		}
+
+		variables = append(variables, built)
+	}
+	return variables
+}
@@ -17,6 +17,8 @@ import (
 
 	testing "testing"
 
+	time "time"
+
 	user "github.com/grafana/grafana/pkg/services/user"
 )
 
@@ -62,6 +64,27 @@ func (_m *FakePublicDashboardService) Delete(ctx context.Context, orgId int64, u
 	return r0
 }
 
+// DeleteExpired provides a mock function with given fields: ctx
+func (_m *FakePublicDashboardService) DeleteExpired(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ExistsEnabledByAccessToken provides a mock function with given fields: ctx, accessToken
 func (_m *FakePublicDashboardService) ExistsEnabledByAccessToken(ctx context.Context, accessToken string) (bool, error) {
 	ret := _m.Called(ctx, accessToken)
@@ -150,6 +173,29 @@ func (_m *FakePublicDashboardService) FindAnnotations(ctx context.Context, reqDT
 	return r0, r1
 }
 
+// FindAuditEntries provides a mock function with given fields: ctx, orgId, uid, limit
+func (_m *FakePublicDashboardService) FindAuditEntries(ctx context.Context, orgId int64, uid string, limit int) ([]models.AuditEntry, error) {
+	ret := _m.Called(ctx, orgId, uid, limit)
+
+	var r0 []models.AuditEntry
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, int) []models.AuditEntry); ok {
+		r0 = rf(ctx, orgId, uid, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AuditEntry)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string, int) error); ok {
+		r1 = rf(ctx, orgId, uid, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindByDashboardUid provides a mock function with given fields: ctx, orgId, dashboardUid
 func (_m *FakePublicDashboardService) FindByDashboardUid(ctx context.Context, orgId int64, dashboardUid string) (*models.PublicDashboard, error) {
 	ret := _m.Called(ctx, orgId, dashboardUid)
@@ -335,6 +381,34 @@ func (_m *FakePublicDashboardService) NewPublicDashboardUid(ctx context.Context)
 	return r0, r1
 }
 
+// RecordView provides a mock function with given fields: ctx, publicDashboard, email
+func (_m *FakePublicDashboardService) RecordView(ctx context.Context, publicDashboard *models.PublicDashboard, email string) error {
+	ret := _m.Called(ctx, publicDashboard, email)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PublicDashboard, string) error); ok {
+		r0 = rf(ctx, publicDashboard, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SendMagicLink provides a mock function with given fields: ctx, accessToken, email
+func (_m *FakePublicDashboardService) SendMagicLink(ctx context.Context, accessToken string, email string) error {
+	ret := _m.Called(ctx, accessToken, email)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, accessToken, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: ctx, u, dto
 func (_m *FakePublicDashboardService) Update(ctx context.Context, u *user.SignedInUser, dto *models.SavePublicDashboardDTO) (*models.PublicDashboard, error) {
 	ret := _m.Called(ctx, u, dto)
@@ -358,6 +432,55 @@ func (_m *FakePublicDashboardService) Update(ctx context.Context, u *user.Signed
 	return r0, r1
 }
 
+// VerifyEmailSession provides a mock function with given fields: ctx, publicDashboard, sessionToken
+func (_m *FakePublicDashboardService) VerifyEmailSession(ctx context.Context, publicDashboard *models.PublicDashboard, sessionToken string) (string, error) {
+	ret := _m.Called(ctx, publicDashboard, sessionToken)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PublicDashboard, string) string); ok {
+		r0 = rf(ctx, publicDashboard, sessionToken)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.PublicDashboard, string) error); ok {
+		r1 = rf(ctx, publicDashboard, sessionToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyMagicLink provides a mock function with given fields: ctx, accessToken, code
+func (_m *FakePublicDashboardService) VerifyMagicLink(ctx context.Context, accessToken string, code string) (string, time.Time, error) {
+	ret := _m.Called(ctx, accessToken, code)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, accessToken, code)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 time.Time
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) time.Time); ok {
+		r1 = rf(ctx, accessToken, code)
+	} else {
+		r1 = ret.Get(1).(time.Time)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
+		r2 = rf(ctx, accessToken, code)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // NewFakePublicDashboardService creates a new instance of FakePublicDashboardService. It also registers the testing.TB interface on the mock and a cleanup function to assert the mocks expectations.
 func NewFakePublicDashboardService(t testing.TB) *FakePublicDashboardService {
 	mock := &FakePublicDashboardService{}
@@ -11,6 +11,8 @@ import (
 	pkgmodels "github.com/grafana/grafana/pkg/models"
 
 	testing "testing"
+
+	time "time"
 )
 
 // FakePublicDashboardStore is an autogenerated mock type for the Store type
@@ -18,6 +20,27 @@ type FakePublicDashboardStore struct {
 	mock.Mock
 }
 
+// CountPendingMagicLinks provides a mock function with given fields: ctx, uid, email, now
+func (_m *FakePublicDashboardStore) CountPendingMagicLinks(ctx context.Context, uid string, email string, now time.Time) (int64, error) {
+	ret := _m.Called(ctx, uid, email, now)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) int64); ok {
+		r0 = rf(ctx, uid, email, now)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time) error); ok {
+		r1 = rf(ctx, uid, email, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: ctx, cmd
 func (_m *FakePublicDashboardStore) Create(ctx context.Context, cmd models.SavePublicDashboardCommand) (int64, error) {
 	ret := _m.Called(ctx, cmd)
@@ -39,6 +62,48 @@ func (_m *FakePublicDashboardStore) Create(ctx context.Context, cmd models.SaveP
 	return r0, r1
 }
 
+// CreateAuditEntry provides a mock function with given fields: ctx, entry
+func (_m *FakePublicDashboardStore) CreateAuditEntry(ctx context.Context, entry *models.AuditEntry) error {
+	ret := _m.Called(ctx, entry)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.AuditEntry) error); ok {
+		r0 = rf(ctx, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateEmailSession provides a mock function with given fields: ctx, session
+func (_m *FakePublicDashboardStore) CreateEmailSession(ctx context.Context, session *models.EmailSession) error {
+	ret := _m.Called(ctx, session)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.EmailSession) error); ok {
+		r0 = rf(ctx, session)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateMagicLink provides a mock function with given fields: ctx, link
+func (_m *FakePublicDashboardStore) CreateMagicLink(ctx context.Context, link *models.MagicLink) error {
+	ret := _m.Called(ctx, link)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.MagicLink) error); ok {
+		r0 = rf(ctx, link)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Delete provides a mock function with given fields: ctx, orgId, uid
 func (_m *FakePublicDashboardStore) Delete(ctx context.Context, orgId int64, uid string) (int64, error) {
 	ret := _m.Called(ctx, orgId, uid)
@@ -60,6 +125,27 @@ func (_m *FakePublicDashboardStore) Delete(ctx context.Context, orgId int64, uid
 	return r0, r1
 }
 
+// DeleteExpired provides a mock function with given fields: ctx, now, auditCreatedBefore
+func (_m *FakePublicDashboardStore) DeleteExpired(ctx context.Context, now time.Time, auditCreatedBefore time.Time) (int64, error) {
+	ret := _m.Called(ctx, now, auditCreatedBefore)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) int64); ok {
+		r0 = rf(ctx, now, auditCreatedBefore)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, now, auditCreatedBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ExistsEnabledByAccessToken provides a mock function with given fields: ctx, accessToken
 func (_m *FakePublicDashboardStore) ExistsEnabledByAccessToken(ctx context.Context, accessToken string) (bool, error) {
 	ret := _m.Called(ctx, accessToken)
@@ -148,6 +234,29 @@ func (_m *FakePublicDashboardStore) FindAll(ctx context.Context, orgId int64) ([
 	return r0, r1
 }
 
+// FindAuditEntries provides a mock function with given fields: ctx, orgId, uid, limit
+func (_m *FakePublicDashboardStore) FindAuditEntries(ctx context.Context, orgId int64, uid string, limit int) ([]models.AuditEntry, error) {
+	ret := _m.Called(ctx, orgId, uid, limit)
+
+	var r0 []models.AuditEntry
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, int) []models.AuditEntry); ok {
+		r0 = rf(ctx, orgId, uid, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AuditEntry)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string, int) error); ok {
+		r1 = rf(ctx, orgId, uid, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindByAccessToken provides a mock function with given fields: ctx, accessToken
 func (_m *FakePublicDashboardStore) FindByAccessToken(ctx context.Context, accessToken string) (*models.PublicDashboard, error) {
 	ret := _m.Called(ctx, accessToken)
@@ -217,6 +326,29 @@ func (_m *FakePublicDashboardStore) FindDashboard(ctx context.Context, orgId int
 	return r0, r1
 }
 
+// FindEmailSession provides a mock function with given fields: ctx, uid, tokenHash, now
+func (_m *FakePublicDashboardStore) FindEmailSession(ctx context.Context, uid string, tokenHash string, now time.Time) (*models.EmailSession, error) {
+	ret := _m.Called(ctx, uid, tokenHash, now)
+
+	var r0 *models.EmailSession
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) *models.EmailSession); ok {
+		r0 = rf(ctx, uid, tokenHash, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.EmailSession)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time) error); ok {
+		r1 = rf(ctx, uid, tokenHash, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetOrgIdByAccessToken provides a mock function with given fields: ctx, accessToken
 func (_m *FakePublicDashboardStore) GetOrgIdByAccessToken(ctx context.Context, accessToken string) (int64, error) {
 	ret := _m.Called(ctx, accessToken)
@@ -238,6 +370,20 @@ func (_m *FakePublicDashboardStore) GetOrgIdByAccessToken(ctx context.Context, a
 	return r0, r1
 }
 
+// IncrementViewCount provides a mock function with given fields: ctx, uid, viewedAt
+func (_m *FakePublicDashboardStore) IncrementViewCount(ctx context.Context, uid string, viewedAt time.Time) error {
+	ret := _m.Called(ctx, uid, viewedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, uid, viewedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: ctx, cmd
 func (_m *FakePublicDashboardStore) Update(ctx context.Context, cmd models.SavePublicDashboardCommand) (int64, error) {
 	ret := _m.Called(ctx, cmd)
@@ -259,6 +405,29 @@ func (_m *FakePublicDashboardStore) Update(ctx context.Context, cmd models.SaveP
 	return r0, r1
 }
 
+// UseMagicLink provides a mock function with given fields: ctx, uid, codeHash, now
+func (_m *FakePublicDashboardStore) UseMagicLink(ctx context.Context, uid string, codeHash string, now time.Time) (*models.MagicLink, error) {
+	ret := _m.Called(ctx, uid, codeHash, now)
+
+	var r0 *models.MagicLink
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) *models.MagicLink); ok {
+		r0 = rf(ctx, uid, codeHash, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.MagicLink)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time) error); ok {
+		r1 = rf(ctx, uid, codeHash, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewFakePublicDashboardStore creates a new instance of FakePublicDashboardStore. It also registers the testing.TB interface on the mock and a cleanup function to assert the mocks expectations.
 func NewFakePublicDashboardStore(t testing.TB) *FakePublicDashboardStore {
 	mock := &FakePublicDashboardStore{}
@@ -2,6 +2,7 @@ package publicdashboards
 
 import (
 	"context"
+	"time"
 
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
 	"github.com/grafana/grafana/pkg/api/dtos"
@@ -32,6 +33,13 @@ type Service interface {
 
 	ExistsEnabledByAccessToken(ctx context.Context, accessToken string) (bool, error)
 	ExistsEnabledByDashboardUid(ctx context.Context, dashboardUid string) (bool, error)
+
+	SendMagicLink(ctx context.Context, accessToken string, email string) error
+	VerifyMagicLink(ctx context.Context, accessToken string, code string) (string, time.Time, error)
+	VerifyEmailSession(ctx context.Context, publicDashboard *PublicDashboard, sessionToken string) (string, error)
+	RecordView(ctx context.Context, publicDashboard *PublicDashboard, email string) error
+	FindAuditEntries(ctx context.Context, orgId int64, uid string, limit int) ([]AuditEntry, error)
+	DeleteExpired(ctx context.Context) (int64, error)
 }
 
 //go:generate mockery --name Store --structname FakePublicDashboardStore --inpackage --filename public_dashboard_store_mock.go
@@ -48,4 +56,14 @@ type Store interface {
 	GetOrgIdByAccessToken(ctx context.Context, accessToken string) (int64, error)
 	ExistsEnabledByAccessToken(ctx context.Context, accessToken string) (bool, error)
 	ExistsEnabledByDashboardUid(ctx context.Context, dashboardUid string) (bool, error)
+
+	CreateMagicLink(ctx context.Context, link *MagicLink) error
+	CountPendingMagicLinks(ctx context.Context, uid string, email string, now time.Time) (int64, error)
+	UseMagicLink(ctx context.Context, uid string, codeHash string, now time.Time) (*MagicLink, error)
+	CreateEmailSession(ctx context.Context, session *EmailSession) error
+	FindEmailSession(ctx context.Context, uid string, tokenHash string, now time.Time) (*EmailSession, error)
+	IncrementViewCount(ctx context.Context, uid string, viewedAt time.Time) error
+	CreateAuditEntry(ctx context.Context, entry *AuditEntry) error
+	FindAuditEntries(ctx context.Context, orgId int64, uid string, limit int) ([]AuditEntry, error)
+	DeleteExpired(ctx context.Context, now time.Time, auditCreatedBefore time.Time) (int64, error)
 }
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/grafana/grafana/pkg/models"
+	"github.com/grafana/grafana/pkg/services/publicdashboards/internal/tokens"
+	. "github.com/grafana/grafana/pkg/services/publicdashboards/models"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+const (
+	magicLinkEmailTemplate = "public_dashboard_magic_link"
+
+	// MagicLinkLifetime is how long a magic link can be used.
+	MagicLinkLifetime = 15 * time.Minute
+	// EmailSessionLifetime is how long a recipient can view a public dashboard after verifying their email address.
+	EmailSessionLifetime = 24 * time.Hour
+	// AuditRetention is how long the entries of the audit logs of public dashboards are kept.
+	AuditRetention = 90 * 24 * time.Hour
+	// maxPendingMagicLinks is the maximum number of magic links of a recipient that haven't expired, to limit the
+	// emails sent to an address.
+	maxPendingMagicLinks = 3
+)
+
+// SendMagicLink emails a magic link to a recipient of a public dashboard shared with emails. Nothing is sent to the
+// email addresses that aren't recipients of the public dashboard, but no error is returned either so that the
+// recipients can't be guessed.
+func (pd *PublicDashboardServiceImpl) SendMagicLink(ctx context.Context, accessToken string, email string) error {
+	email = NormalizeEmail(email)
+	if !util.IsEmail(email) {
+		return ErrInvalidEmail.Errorf("SendMagicLink: invalid email address")
+	}
+
+	pubdash, dash, err := pd.FindPublicDashboardAndDashboardByAccessToken(ctx, accessToken)
+	if err != nil {
+		return err
+	}
+
+	if !pubdash.IsEmailShare() {
+		return ErrBadRequest.Errorf("SendMagicLink: public dashboard isn't shared with emails")
+	}
+
+	if !pd.cfg.Smtp.Enabled {
+		return ErrEmailNotConfigured.Errorf("SendMagicLink: SMTP is disabled")
+	}
+
+	if !pubdash.HasRecipient(email) {
+		pd.log.Info("Magic link requested by an email address that isn't a recipient", "publicDashboardUid", pubdash.Uid)
+		return pd.createAuditEntry(ctx, pubdash, AuditActionLinkDenied, email)
+	}
+
+	now := time.Now()
+	pending, err := pd.store.CountPendingMagicLinks(ctx, pubdash.Uid, email, now)
+	if err != nil {
+		return ErrInternalServerError.Errorf("SendMagicLink: failed to count the magic links: %w", err)
+	}
+	if pending >= maxPendingMagicLinks {
+		pd.log.Info("Too many magic links requested by a recipient", "publicDashboardUid", pubdash.Uid)
+		return nil
+	}
+
+	code, err := tokens.GenerateSecret()
+	if err != nil {
+		return ErrInternalServerError.Errorf("SendMagicLink: failed to generate a code: %w", err)
+	}
+
+	err = pd.store.CreateMagicLink(ctx, &MagicLink{
+		PublicDashboardUid: pubdash.Uid,
+		Email:              email,
+		CodeHash:           tokens.HashSecret(code),
+		ExpiresAt:          now.Add(MagicLinkLifetime),
+		CreatedAt:          now,
+	})
+	if err != nil {
+		return ErrInternalServerError.Errorf("SendMagicLink: failed to save the magic link: %w", err)
+	}
+
+	err = pd.emailSender.SendEmailCommandHandler(ctx, &models.SendEmailCommand{
+		To:       []string{email},
+		Template: magicLinkEmailTemplate,
+		Data: map[string]interface{}{
+			"Title":          dash.Title,
+			"Link":           setting.ToAbsUrl("public-dashboards/" + accessToken + "/verify?code=" + url.QueryEscape(code)),
+			"ExpiresMinutes": int(MagicLinkLifetime.Minutes()),
+		},
+	})
+	if err != nil {
+		return ErrInternalServerError.Errorf("SendMagicLink: failed to send the magic link: %w", err)
+	}
+
+	return pd.createAuditEntry(ctx, pubdash, AuditActionLinkRequested, email)
+}
+
+// VerifyMagicLink uses the code of a magic link and starts a session for its recipient. It returns the token of the
+// session and its expiration time.
+func (pd *PublicDashboardServiceImpl) VerifyMagicLink(ctx context.Context, accessToken string, code string) (string, time.Time, error) {
+	pubdash, _, err := pd.FindPublicDashboardAndDashboardByAccessToken(ctx, accessToken)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	now := time.Now()
+	link, err := pd.store.UseMagicLink(ctx, pubdash.Uid, tokens.HashSecret(code), now)
+	if err != nil {
+		return "", time.Time{}, ErrInternalServerError.Errorf("VerifyMagicLink: failed to use the magic link: %w", err)
+	}
+
+	// the recipient may have been removed after the link was sent
+	if link == nil || !pubdash.IsEmailShare() || !pubdash.HasRecipient(link.Email) {
+		return "", time.Time{}, ErrInvalidMagicLink.Errorf("VerifyMagicLink: invalid or expired magic link for public dashboard %s", pubdash.Uid)
+	}
+
+	token, err := tokens.GenerateSecret()
+	if err != nil {
+		return "", time.Time{}, ErrInternalServerError.Errorf("VerifyMagicLink: failed to generate a session token: %w", err)
+	}
+
+	expiresAt := now.Add(EmailSessionLifetime)
+	if pubdash.ExpiresAt != nil && pubdash.ExpiresAt.Before(expiresAt) {
+		expiresAt = *pubdash.ExpiresAt
+	}
+
+	err = pd.store.CreateEmailSession(ctx, &EmailSession{
+		PublicDashboardUid: pubdash.Uid,
+		Email:              link.Email,
+		TokenHash:          tokens.HashSecret(token),
+		ExpiresAt:          expiresAt,
+		CreatedAt:          now,
+	})
+	if err != nil {
+		return "", time.Time{}, ErrInternalServerError.Errorf("VerifyMagicLink: failed to save the session: %w", err)
+	}
+
+	if err := pd.createAuditEntry(ctx, pubdash, AuditActionVerified, link.Email); err != nil {
+		return "", time.Time{}, err
+	}
+
+	return token, expiresAt, nil
+}
+
+// VerifyEmailSession returns the email address of the viewer of a public dashboard shared with emails, or an error if
+// the viewer didn't verify their email address. It returns an empty email address for the other public dashboards.
+func (pd *PublicDashboardServiceImpl) VerifyEmailSession(ctx context.Context, publicDashboard *PublicDashboard, sessionToken string) (string, error) {
+	if !publicDashboard.IsEmailShare() {
+		return "", nil
+	}
+
+	if sessionToken == "" {
+		return "", ErrEmailVerificationRequired.Errorf("VerifyEmailSession: no session for public dashboard %s", publicDashboard.Uid)
+	}
+
+	session, err := pd.store.FindEmailSession(ctx, publicDashboard.Uid, tokens.HashSecret(sessionToken), time.Now())
+	if err != nil {
+		return "", ErrInternalServerError.Errorf("VerifyEmailSession: failed to find the session: %w", err)
+	}
+
+	// the recipient may have been removed after the session started
+	if session == nil || !publicDashboard.HasRecipient(session.Email) {
+		return "", ErrEmailVerificationRequired.Errorf("VerifyEmailSession: invalid or expired session for public dashboard %s", publicDashboard.Uid)
+	}
+
+	return session.Email, nil
+}
+
+// RecordView counts a view of a public dashboard. The views of the recipients of a public dashboard shared with emails
+// are also recorded in its audit log, the anonymous views are only counted.
+func (pd *PublicDashboardServiceImpl) RecordView(ctx context.Context, publicDashboard *PublicDashboard, email string) error {
+	if err := pd.store.IncrementViewCount(ctx, publicDashboard.Uid, time.Now()); err != nil {
+		return ErrInternalServerError.Errorf("RecordView: failed to count the view: %w", err)
+	}
+
+	if !publicDashboard.IsEmailShare() {
+		return nil
+	}
+
+	return pd.createAuditEntry(ctx, publicDashboard, AuditActionView, email)
+}
+
+// DeleteExpired deletes the expired magic links and sessions of the public dashboards, and the entries of their audit
+// logs older than AuditRetention. It returns the number of deleted rows.
+func (pd *PublicDashboardServiceImpl) DeleteExpired(ctx context.Context) (int64, error) {
+	now := time.Now()
+	affectedRows, err := pd.store.DeleteExpired(ctx, now, now.Add(-AuditRetention))
+	if err != nil {
+		return 0, ErrInternalServerError.Errorf("DeleteExpired: failed to delete the expired data of public dashboards: %w", err)
+	}
+
+	return affectedRows, nil
+}
+
+// FindAuditEntries returns the audit log of a public dashboard, the most recent entries first
+func (pd *PublicDashboardServiceImpl) FindAuditEntries(ctx context.Context, orgId int64, uid string, limit int) ([]AuditEntry, error) {
+	entries, err := pd.store.FindAuditEntries(ctx, orgId, uid, limit)
+	if err != nil {
+		return nil, ErrInternalServerError.Errorf("FindAuditEntries: failed to find the audit log of public dashboard %s: %w", uid, err)
+	}
+
+	return entries, nil
+}
+
+func (pd *PublicDashboardServiceImpl) createAuditEntry(ctx context.Context, publicDashboard *PublicDashboard, action string, email string) error {
+	err := pd.store.CreateAuditEntry(ctx, &AuditEntry{
+		OrgId:              publicDashboard.OrgId,
+		PublicDashboardUid: publicDashboard.Uid,
+		Action:             action,
+		Email:              email,
+		CreatedAt:          time.Now(),
+	})
+	if err != nil {
+		return ErrInternalServerError.Errorf("createAuditEntry: failed to record %s for public dashboard %s: %w", action, publicDashboard.Uid, err)
+	}
+
+	return nil
+}
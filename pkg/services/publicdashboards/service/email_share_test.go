@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/infra/log"
+	dashboardsDB "github.com/grafana/grafana/pkg/services/dashboards/database"
+	"github.com/grafana/grafana/pkg/services/featuremgmt"
+	"github.com/grafana/grafana/pkg/services/notifications"
+	"github.com/grafana/grafana/pkg/services/publicdashboards/database"
+	. "github.com/grafana/grafana/pkg/services/publicdashboards/models"
+	"github.com/grafana/grafana/pkg/services/quota/quotatest"
+	"github.com/grafana/grafana/pkg/services/tag/tagimpl"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailShare(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T) (*PublicDashboardServiceImpl, *notifications.NotificationServiceMock, *PublicDashboard) {
+		sqlStore := db.InitTestDB(t)
+		dashboardStore, err := dashboardsDB.ProvideDashboardStore(sqlStore, sqlStore.Cfg, featuremgmt.WithFeatures(), tagimpl.ProvideService(sqlStore, sqlStore.Cfg), quotatest.New(false, nil))
+		require.NoError(t, err)
+		dashboard := insertTestDashboard(t, dashboardStore, "testDashie", 1, 0, true, []map[string]interface{}{}, nil)
+
+		cfg := setting.NewCfg()
+		cfg.Smtp.Enabled = true
+		emailSender := notifications.MockNotificationService()
+		service := &PublicDashboardServiceImpl{
+			log:         log.New("test.logger"),
+			cfg:         cfg,
+			store:       database.ProvideStore(sqlStore),
+			emailSender: emailSender,
+		}
+
+		dto := &SavePublicDashboardDTO{
+			DashboardUid: dashboard.Uid,
+			OrgId:        dashboard.OrgId,
+			UserId:       7,
+			PublicDashboard: &PublicDashboard{
+				IsEnabled:    true,
+				TimeSettings: timeSettings,
+				Share:        EmailShareType,
+				Recipients:   Recipients{" Viewer@Example.com", "viewer@example.com", "other@example.com"},
+			},
+		}
+		pubdash, err := service.Create(ctx, SignedInUser, dto)
+		require.NoError(t, err)
+
+		return service, emailSender, pubdash
+	}
+
+	magicLinkCode := func(t *testing.T, emailSender *notifications.NotificationServiceMock) string {
+		link, err := url.Parse(emailSender.Email.Data["Link"].(string))
+		require.NoError(t, err)
+		code := link.Query().Get("code")
+		require.NotEmpty(t, code)
+		return code
+	}
+
+	t.Run("Create normalizes the recipients", func(t *testing.T) {
+		_, _, pubdash := setup(t)
+		assert.Equal(t, EmailShareType, pubdash.Share)
+		assert.Equal(t, Recipients{"viewer@example.com", "other@example.com"}, pubdash.Recipients)
+	})
+
+	t.Run("Recipients can verify their email address with a magic link", func(t *testing.T) {
+		service, emailSender, pubdash := setup(t)
+
+		err := service.SendMagicLink(ctx, pubdash.AccessToken, "VIEWER@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"viewer@example.com"}, emailSender.Email.To)
+		assert.Equal(t, magicLinkEmailTemplate, emailSender.Email.Template)
+		assert.Equal(t, "testDashie", emailSender.Email.Data["Title"])
+
+		token, expiresAt, err := service.VerifyMagicLink(ctx, pubdash.AccessToken, magicLinkCode(t, emailSender))
+		require.NoError(t, err)
+		assert.NotEmpty(t, token)
+		assert.WithinDuration(t, time.Now().Add(EmailSessionLifetime), expiresAt, time.Minute)
+
+		email, err := service.VerifyEmailSession(ctx, pubdash, token)
+		require.NoError(t, err)
+		assert.Equal(t, "viewer@example.com", email)
+
+		entries, err := service.FindAuditEntries(ctx, pubdash.OrgId, pubdash.Uid, 10)
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.ElementsMatch(t, []string{AuditActionLinkRequested, AuditActionVerified}, []string{entries[0].Action, entries[1].Action})
+	})
+
+	t.Run("Magic links can only be used once", func(t *testing.T) {
+		service, emailSender, pubdash := setup(t)
+
+		require.NoError(t, service.SendMagicLink(ctx, pubdash.AccessToken, "viewer@example.com"))
+		code := magicLinkCode(t, emailSender)
+
+		_, _, err := service.VerifyMagicLink(ctx, pubdash.AccessToken, code)
+		require.NoError(t, err)
+
+		_, _, err = service.VerifyMagicLink(ctx, pubdash.AccessToken, code)
+		assert.ErrorIs(t, err, ErrInvalidMagicLink)
+	})
+
+	t.Run("No magic link is sent to the email addresses that aren't recipients", func(t *testing.T) {
+		service, emailSender, pubdash := setup(t)
+
+		err := service.SendMagicLink(ctx, pubdash.AccessToken, "stranger@example.com")
+		require.NoError(t, err)
+		assert.Empty(t, emailSender.Email.To)
+
+		entries, err := service.FindAuditEntries(ctx, pubdash.OrgId, pubdash.Uid, 10)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, AuditActionLinkDenied, entries[0].Action)
+		assert.Equal(t, "stranger@example.com", entries[0].Email)
+	})
+
+	t.Run("Magic links are rate limited", func(t *testing.T) {
+		service, emailSender, pubdash := setup(t)
+
+		for i := 0; i < maxPendingMagicLinks; i++ {
+			require.NoError(t, service.SendMagicLink(ctx, pubdash.AccessToken, "viewer@example.com"))
+		}
+		emailSender.Email.To = nil
+
+		require.NoError(t, service.SendMagicLink(ctx, pubdash.AccessToken, "viewer@example.com"))
+		assert.Empty(t, emailSender.Email.To)
+	})
+
+	t.Run("SendMagicLink fails when SMTP is disabled", func(t *testing.T) {
+		service, _, pubdash := setup(t)
+		service.cfg.Smtp.Enabled = false
+
+		err := service.SendMagicLink(ctx, pubdash.AccessToken, "viewer@example.com")
+		assert.ErrorIs(t, err, ErrEmailNotConfigured)
+	})
+
+	t.Run("SendMagicLink fails for an invalid email address", func(t *testing.T) {
+		service, _, pubdash := setup(t)
+
+		err := service.SendMagicLink(ctx, pubdash.AccessToken, "not an email")
+		assert.ErrorIs(t, err, ErrInvalidEmail)
+	})
+
+	t.Run("Sessions end when the recipient is removed", func(t *testing.T) {
+		service, emailSender, pubdash := setup(t)
+
+		require.NoError(t, service.SendMagicLink(ctx, pubdash.AccessToken, "viewer@example.com"))
+		token, _, err := service.VerifyMagicLink(ctx, pubdash.AccessToken, magicLinkCode(t, emailSender))
+		require.NoError(t, err)
+
+		pubdash.Recipients = Recipients{"other@example.com"}
+		_, err = service.VerifyEmailSession(ctx, pubdash, token)
+		assert.ErrorIs(t, err, ErrEmailVerificationRequired)
+	})
+
+	t.Run("VerifyEmailSession requires a session for email shares only", func(t *testing.T) {
+		service, _, pubdash := setup(t)
+
+		_, err := service.VerifyEmailSession(ctx, pubdash, "")
+		assert.ErrorIs(t, err, ErrEmailVerificationRequired)
+
+		_, err = service.VerifyEmailSession(ctx, pubdash, "unknown")
+		assert.ErrorIs(t, err, ErrEmailVerificationRequired)
+
+		email, err := service.VerifyEmailSession(ctx, &PublicDashboard{Share: PublicShareType}, "")
+		require.NoError(t, err)
+		assert.Empty(t, email)
+	})
+
+	t.Run("RecordView counts the views", func(t *testing.T) {
+		service, _, pubdash := setup(t)
+
+		require.NoError(t, service.RecordView(ctx, pubdash, "viewer@example.com"))
+
+		updated, err := service.FindByDashboardUid(ctx, pubdash.OrgId, pubdash.DashboardUid)
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, updated.ViewCount)
+
+		entries, err := service.FindAuditEntries(ctx, pubdash.OrgId, pubdash.Uid, 10)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, AuditActionView, entries[0].Action)
+	})
+
+	t.Run("RecordView only counts the anonymous views", func(t *testing.T) {
+		service, _, pubdash := setup(t)
+		pubdash.Share = PublicShareType
+
+		require.NoError(t, service.RecordView(ctx, pubdash, ""))
+
+		updated, err := service.FindByDashboardUid(ctx, pubdash.OrgId, pubdash.DashboardUid)
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, updated.ViewCount)
+
+		entries, err := service.FindAuditEntries(ctx, pubdash.OrgId, pubdash.Uid, 10)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("Expired public dashboards can't be viewed", func(t *testing.T) {
+		service, _, pubdash := setup(t)
+
+		expiresAt := time.Now().Add(-time.Minute)
+		pubdash.ExpiresAt = &expiresAt
+		_, err := service.store.Update(ctx, SavePublicDashboardCommand{PublicDashboard: *pubdash})
+		require.NoError(t, err)
+
+		_, _, err = service.FindPublicDashboardAndDashboardByAccessToken(ctx, pubdash.AccessToken)
+		assert.ErrorIs(t, err, ErrPublicDashboardNotFound)
+	})
+}
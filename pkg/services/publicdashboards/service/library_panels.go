@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+
+	"github.com/grafana/grafana/pkg/models"
+	"github.com/grafana/grafana/pkg/services/libraryelements"
+	. "github.com/grafana/grafana/pkg/services/publicdashboards/models"
+)
+
+// resolveLibraryPanels replaces the library panels of a dashboard with their models. Viewers of public dashboards
+// can't read library panels, so the panels must be resolved before the dashboard is sent to them or queried.
+func (pd *PublicDashboardServiceImpl) resolveLibraryPanels(ctx context.Context, dash *models.Dashboard) error {
+	if err := libraryelements.ResolveLibraryPanels(ctx, pd.libraryElements, dash.Id, dash.Data); err != nil {
+		return ErrInternalServerError.Errorf("resolveLibraryPanels: failed to resolve the library panels of dashboard %s: %w", dash.Uid, err)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/models"
+	"github.com/grafana/grafana/pkg/services/libraryelements"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeLibraryElementService struct {
+	libraryelements.Service
+	elements map[string]libraryelements.LibraryElementDTO
+	calls    int
+}
+
+func (f *fakeLibraryElementService) GetElementsForDashboard(_ context.Context, _ int64) (map[string]libraryelements.LibraryElementDTO, error) {
+	f.calls++
+	return f.elements, nil
+}
+
+func TestResolveLibraryPanels(t *testing.T) {
+	model, err := json.Marshal(map[string]interface{}{
+		"id":           99,
+		"type":         "timeseries",
+		"title":        "Library panel",
+		"gridPos":      map[string]interface{}{"x": 0, "y": 0, "w": 1, "h": 1},
+		"libraryPanel": map[string]interface{}{"uid": "lib1"},
+		"targets":      []interface{}{map[string]interface{}{"refId": "A", "expr": "up"}},
+	})
+	require.NoError(t, err)
+
+	libraryElements := &fakeLibraryElementService{elements: map[string]libraryelements.LibraryElementDTO{
+		"lib1": {UID: "lib1", Model: model},
+	}}
+	service := &PublicDashboardServiceImpl{log: log.New("test.logger"), libraryElements: libraryElements}
+
+	t.Run("replaces library panels with their model", func(t *testing.T) {
+		gridPos := map[string]interface{}{"x": 12, "y": 8, "w": 12, "h": 8}
+		dashboard := models.NewDashboardFromJson(simplejson.NewFromAny(map[string]interface{}{
+			"panels": []interface{}{
+				map[string]interface{}{"id": 1, "gridPos": gridPos, "libraryPanel": map[string]interface{}{"uid": "lib1", "name": "Library panel"}},
+				map[string]interface{}{"id": 2, "type": "stat", "targets": []interface{}{}},
+				map[string]interface{}{"id": 3, "type": "row", "collapsed": true, "panels": []interface{}{
+					map[string]interface{}{"id": 4, "gridPos": gridPos, "libraryPanel": map[string]interface{}{"uid": "lib1"}},
+				}},
+				map[string]interface{}{"id": 5, "libraryPanel": map[string]interface{}{"uid": "missing"}},
+			},
+		}))
+
+		require.NoError(t, service.resolveLibraryPanels(context.Background(), dashboard))
+
+		panels := dashboard.Data.Get("panels")
+		resolved := panels.GetIndex(0)
+		assert.Equal(t, 1, resolved.Get("id").MustInt())
+		assert.Equal(t, "timeseries", resolved.Get("type").MustString())
+		assert.Equal(t, "up", resolved.Get("targets").GetIndex(0).Get("expr").MustString())
+		assert.Equal(t, gridPos, resolved.Get("gridPos").MustMap())
+		_, hasLibraryPanel := resolved.CheckGet("libraryPanel")
+		assert.False(t, hasLibraryPanel)
+
+		assert.Equal(t, "stat", panels.GetIndex(1).Get("type").MustString())
+
+		nested := panels.GetIndex(2).Get("panels").GetIndex(0)
+		assert.Equal(t, 4, nested.Get("id").MustInt())
+		assert.Equal(t, "timeseries", nested.Get("type").MustString())
+
+		assert.Equal(t, "missing", panels.GetIndex(3).GetPath("libraryPanel", "uid").MustString())
+
+		queries := groupQueriesByPanelId(dashboard.Data)
+		require.Len(t, queries[1], 1)
+		assert.Equal(t, "up", queries[1][0].Get("expr").MustString())
+		require.Len(t, queries[4], 1)
+	})
+
+	t.Run("doesn't load library panels when the dashboard has none", func(t *testing.T) {
+		libraryElements.calls = 0
+		dashboard := models.NewDashboardFromJson(simplejson.NewFromAny(map[string]interface{}{
+			"panels": []interface{}{map[string]interface{}{"id": 1, "type": "stat"}},
+		}))
+
+		require.NoError(t, service.resolveLibraryPanels(context.Background(), dashboard))
+		assert.Zero(t, libraryElements.calls)
+	})
+}
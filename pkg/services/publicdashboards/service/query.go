@@ -10,6 +10,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
 	"github.com/grafana/grafana/pkg/services/annotations"
 	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/dashboards/dashboardjson"
 	"github.com/grafana/grafana/pkg/services/datasources"
 	"github.com/grafana/grafana/pkg/services/publicdashboards/models"
 	"github.com/grafana/grafana/pkg/services/publicdashboards/validation"
@@ -104,6 +105,11 @@ func (pd *PublicDashboardServiceImpl) GetMetricRequest(ctx context.Context, dash
 		return dtos.MetricRequest{}, err
 	}
 
+	err = validation.ValidateQueryTemplateVariables(publicDashboard.TemplateVariables, queryDto.Variables)
+	if err != nil {
+		return dtos.MetricRequest{}, err
+	}
+
 	metricReqDTO, err := pd.buildMetricRequest(
 		ctx,
 		dashboard,
@@ -162,7 +168,9 @@ func (pd *PublicDashboardServiceImpl) buildMetricRequest(ctx context.Context, da
 
 	// determine safe resolution to query data at
 	safeInterval, safeResolution := pd.getSafeIntervalAndMaxDataPoints(reqDTO, ts)
+	variables := publicDashboard.TemplateVariableValues(dashboard, reqDTO.Variables)
 	for i := range queries {
+		queries[i] = dashboardjson.InterpolateVariables(queries[i], variables)
 		queries[i].Set("intervalMs", safeInterval)
 		queries[i].Set("maxDataPoints", safeResolution)
 	}
@@ -211,8 +219,7 @@ func getUniqueDashboardDatasourceUids(dashboard *simplejson.Json) []string {
 	var datasourceUids []string
 	exists := map[string]bool{}
 
-	for _, panelObj := range dashboard.Get("panels").MustArray() {
-		panel := simplejson.NewFromAny(panelObj)
+	for _, panel := range dashboardjson.Panels(dashboard) {
 		uid := getDataSourceUidFromJson(panel)
 
 		// if uid is for a mixed datasource, get the datasource uids from the targets
@@ -239,8 +246,7 @@ func getUniqueDashboardDatasourceUids(dashboard *simplejson.Json) []string {
 func groupQueriesByPanelId(dashboard *simplejson.Json) map[int64][]*simplejson.Json {
 	result := make(map[int64][]*simplejson.Json)
 
-	for _, panelObj := range dashboard.Get("panels").MustArray() {
-		panel := simplejson.NewFromAny(panelObj)
+	for _, panel := range dashboardjson.Panels(dashboard) {
 
 		var panelQueries []*simplejson.Json
 
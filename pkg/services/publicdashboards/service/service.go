@@ -11,6 +11,8 @@ import (
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
 	"github.com/grafana/grafana/pkg/services/annotations"
 	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/libraryelements"
+	"github.com/grafana/grafana/pkg/services/notifications"
 	"github.com/grafana/grafana/pkg/services/publicdashboards"
 	"github.com/grafana/grafana/pkg/services/publicdashboards/internal/tokens"
 	. "github.com/grafana/grafana/pkg/services/publicdashboards/models"
@@ -33,6 +35,8 @@ type PublicDashboardServiceImpl struct {
 	QueryDataService   *query.Service
 	AnnotationsRepo    annotations.Repository
 	ac                 accesscontrol.AccessControl
+	emailSender        notifications.EmailSender
+	libraryElements    libraryelements.Service
 }
 
 var LogPrefix = "publicdashboards.service"
@@ -49,6 +53,8 @@ func ProvideService(
 	qds *query.Service,
 	anno annotations.Repository,
 	ac accesscontrol.AccessControl,
+	emailSender notifications.EmailSender,
+	libraryElements libraryelements.Service,
 ) *PublicDashboardServiceImpl {
 	return &PublicDashboardServiceImpl{
 		log:                log.New(LogPrefix),
@@ -58,6 +64,8 @@ func ProvideService(
 		QueryDataService:   qds,
 		AnnotationsRepo:    anno,
 		ac:                 ac,
+		emailSender:        emailSender,
+		libraryElements:    libraryElements,
 	}
 }
 
@@ -90,6 +98,10 @@ func (pd *PublicDashboardServiceImpl) FindPublicDashboardAndDashboardByAccessTok
 		return nil, nil, ErrPublicDashboardNotFound.Errorf("FindPublicDashboardAndDashboardByAccessToken: Public dashboard is disabled accessToken: %s", accessToken)
 	}
 
+	if pubdash.IsExpired(time.Now()) {
+		return nil, nil, ErrPublicDashboardNotFound.Errorf("FindPublicDashboardAndDashboardByAccessToken: Public dashboard expired accessToken: %s", accessToken)
+	}
+
 	dash, err := pd.store.FindDashboard(ctx, pubdash.OrgId, pubdash.DashboardUid)
 	if err != nil {
 		return nil, nil, err
@@ -99,6 +111,10 @@ func (pd *PublicDashboardServiceImpl) FindPublicDashboardAndDashboardByAccessTok
 		return nil, nil, ErrPublicDashboardNotFound.Errorf("FindPublicDashboardAndDashboardByAccessToken: Dashboard not found accessToken: %s", accessToken)
 	}
 
+	if err := pd.resolveLibraryPanels(ctx, dash); err != nil {
+		return nil, nil, err
+	}
+
 	return pubdash, dash, nil
 }
 
@@ -129,6 +145,8 @@ func (pd *PublicDashboardServiceImpl) Create(ctx context.Context, u *user.Signed
 		dto.PublicDashboard.TimeSettings = &TimeSettings{}
 	}
 
+	setShareDefaults(dto.PublicDashboard)
+
 	// validate fields
 	err = validation.ValidatePublicDashboard(dto, dashboard)
 	if err != nil {
@@ -162,6 +180,10 @@ func (pd *PublicDashboardServiceImpl) Create(ctx context.Context, u *user.Signed
 			IsEnabled:          dto.PublicDashboard.IsEnabled,
 			AnnotationsEnabled: dto.PublicDashboard.AnnotationsEnabled,
 			TimeSettings:       dto.PublicDashboard.TimeSettings,
+			Share:              dto.PublicDashboard.Share,
+			Recipients:         dto.PublicDashboard.Recipients,
+			ExpiresAt:          dto.PublicDashboard.ExpiresAt,
+			TemplateVariables:  dto.PublicDashboard.TemplateVariables,
 			CreatedBy:          dto.UserId,
 			CreatedAt:          time.Now(),
 			AccessToken:        accessToken,
@@ -203,6 +225,8 @@ func (pd *PublicDashboardServiceImpl) Update(ctx context.Context, u *user.Signed
 		dto.PublicDashboard.TimeSettings = &TimeSettings{}
 	}
 
+	setShareDefaults(dto.PublicDashboard)
+
 	// get existing public dashboard if exists
 	existingPubdash, err := pd.store.Find(ctx, dto.PublicDashboard.Uid)
 	if err != nil {
@@ -225,6 +249,10 @@ func (pd *PublicDashboardServiceImpl) Update(ctx context.Context, u *user.Signed
 			AnnotationsEnabled:   dto.PublicDashboard.AnnotationsEnabled,
 			TimeSelectionEnabled: dto.PublicDashboard.TimeSelectionEnabled,
 			TimeSettings:         dto.PublicDashboard.TimeSettings,
+			Share:                dto.PublicDashboard.Share,
+			Recipients:           dto.PublicDashboard.Recipients,
+			ExpiresAt:            dto.PublicDashboard.ExpiresAt,
+			TemplateVariables:    dto.PublicDashboard.TemplateVariables,
 			UpdatedBy:            dto.UserId,
 			UpdatedAt:            time.Now(),
 		},
@@ -378,6 +406,25 @@ func (pd *PublicDashboardServiceImpl) filterDashboardsByPermissions(ctx context.
 	return result, nil
 }
 
+// setShareDefaults shares public dashboards with anyone by default and removes the duplicate recipients
+func setShareDefaults(pubdash *PublicDashboard) {
+	if pubdash.Share == "" {
+		pubdash.Share = PublicShareType
+	}
+
+	recipients := make(Recipients, 0, len(pubdash.Recipients))
+	seen := make(map[string]struct{}, len(pubdash.Recipients))
+	for _, recipient := range pubdash.Recipients {
+		recipient = NormalizeEmail(recipient)
+		if _, ok := seen[recipient]; ok || recipient == "" {
+			continue
+		}
+		seen[recipient] = struct{}{}
+		recipients = append(recipients, recipient)
+	}
+	pubdash.Recipients = recipients
+}
+
 // Checks to see if PublicDashboard.ExistsEnabledByDashboardUid is true on create or changed on update
 func publicDashboardIsEnabledChanged(existingPubdash *PublicDashboard, newPubdash *PublicDashboard) bool {
 	// creating dashboard, enabled true
@@ -204,13 +204,13 @@ func TestCreatePublicDashboard(t *testing.T) {
 		assert.Equal(t, defaultPubdashTimeSettings, pubdash.TimeSettings)
 	})
 
-	t.Run("Validate pubdash whose dashboard has template variables returns error", func(t *testing.T) {
+	t.Run("Create public dashboard whose dashboard has template variables", func(t *testing.T) {
 		sqlStore := db.InitTestDB(t)
 		quotaService := quotatest.New(false, nil)
 		dashboardStore, err := dashboardsDB.ProvideDashboardStore(sqlStore, sqlStore.Cfg, featuremgmt.WithFeatures(), tagimpl.ProvideService(sqlStore, sqlStore.Cfg), quotaService)
 		require.NoError(t, err)
 		publicdashboardStore := database.ProvideStore(sqlStore)
-		templateVars := make([]map[string]interface{}, 1)
+		templateVars := []map[string]interface{}{{"name": "env", "type": "custom"}}
 		dashboard := insertTestDashboard(t, dashboardStore, "testDashie", 1, 0, true, templateVars, nil)
 
 		service := &PublicDashboardServiceImpl{
@@ -223,14 +223,21 @@ func TestCreatePublicDashboard(t *testing.T) {
 			OrgId:        dashboard.OrgId,
 			UserId:       7,
 			PublicDashboard: &PublicDashboard{
-				IsEnabled:    true,
-				DashboardUid: "NOTTHESAME",
-				OrgId:        9999999,
+				IsEnabled:         true,
+				DashboardUid:      "NOTTHESAME",
+				OrgId:             9999999,
+				TemplateVariables: TemplateVariables{{Name: "env", Values: []string{"prod", "dev"}}},
 			},
 		}
 
-		_, err = service.Create(context.Background(), SignedInUser, dto)
-		require.Error(t, err)
+		pubdash, err := service.Create(context.Background(), SignedInUser, dto)
+		require.NoError(t, err)
+		assert.Equal(t, dto.PublicDashboard.TemplateVariables, pubdash.TemplateVariables)
+
+		dto.PublicDashboard.TemplateVariables = TemplateVariables{{Name: "unknown", Values: []string{"prod"}}}
+		dto.PublicDashboard.Uid = pubdash.Uid
+		_, err = service.Update(context.Background(), SignedInUser, dto)
+		require.ErrorIs(t, err, ErrInvalidTemplateVariables)
 	})
 
 	t.Run("Throws an error when pubdash with generated access token already exists", func(t *testing.T) {
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/models"
+	. "github.com/grafana/grafana/pkg/services/publicdashboards/models"
+	"github.com/grafana/grafana/pkg/tsdb/intervalv2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetMetricRequestWithTemplateVariables(t *testing.T) {
+	dashboard := models.NewDashboardFromJson(simplejson.NewFromAny(map[string]interface{}{
+		"templating": map[string]interface{}{
+			"list": []interface{}{
+				map[string]interface{}{"name": "env", "type": "custom", "current": map[string]interface{}{"value": "dev"}},
+				map[string]interface{}{"name": "job", "type": "query", "current": map[string]interface{}{"value": []interface{}{"api", "web"}}},
+			},
+		},
+		"panels": []interface{}{
+			map[string]interface{}{
+				"id":         1,
+				"datasource": map[string]interface{}{"uid": "ds1"},
+				"targets": []interface{}{map[string]interface{}{
+					"refId":      "A",
+					"datasource": map[string]interface{}{"uid": "ds1", "type": "prometheus"},
+					"expr":       `up{env="$env", job=~"$job"}`,
+				}},
+			},
+		},
+	}))
+	publicDashboard := &PublicDashboard{TemplateVariables: TemplateVariables{{Name: "env", Values: []string{"prod", "staging"}}}}
+	service := &PublicDashboardServiceImpl{log: log.New("test.logger"), intervalCalculator: intervalv2.NewCalculator()}
+
+	t.Run("uses the first allowed value by default", func(t *testing.T) {
+		req, err := service.GetMetricRequest(context.Background(), dashboard, publicDashboard, 1, PublicDashboardQueryDTO{})
+		require.NoError(t, err)
+		require.Len(t, req.Queries, 1)
+		assert.Equal(t, `up{env="prod", job=~"(api|web)"}`, req.Queries[0].Get("expr").MustString())
+	})
+
+	t.Run("uses the selected value", func(t *testing.T) {
+		req, err := service.GetMetricRequest(context.Background(), dashboard, publicDashboard, 1, PublicDashboardQueryDTO{Variables: map[string]string{"env": "staging"}})
+		require.NoError(t, err)
+		require.Len(t, req.Queries, 1)
+		assert.Equal(t, `up{env="staging", job=~"(api|web)"}`, req.Queries[0].Get("expr").MustString())
+	})
+
+	t.Run("rejects values that aren't allowed", func(t *testing.T) {
+		_, err := service.GetMetricRequest(context.Background(), dashboard, publicDashboard, 1, PublicDashboardQueryDTO{Variables: map[string]string{"env": `prod"} or vector(1) #`}})
+		require.ErrorIs(t, err, ErrInvalidTemplateVariableValue)
+	})
+
+	t.Run("rejects variables viewers can't change", func(t *testing.T) {
+		_, err := service.GetMetricRequest(context.Background(), dashboard, publicDashboard, 1, PublicDashboardQueryDTO{Variables: map[string]string{"job": "api"}})
+		require.ErrorIs(t, err, ErrInvalidTemplateVariableValue)
+	})
+}
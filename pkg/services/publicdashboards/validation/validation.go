@@ -1,22 +1,123 @@
 package validation
 
 import (
+	"time"
+	"unicode"
+
 	"github.com/grafana/grafana/pkg/models"
 	. "github.com/grafana/grafana/pkg/services/publicdashboards/models"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+const (
+	// MaxRecipients is the maximum number of recipients of a public dashboard shared with emails.
+	MaxRecipients = 100
+	// MaxTemplateVariableValues is the maximum number of values viewers can select for a template variable.
+	MaxTemplateVariableValues = 100
+	// MaxTemplateVariableValueLength is the maximum length of a value of a template variable.
+	MaxTemplateVariableValueLength = 256
 )
 
+// unsupportedVariableTypes are the types of template variables that viewers can't change, because their values
+// aren't interpolated in queries.
+var unsupportedVariableTypes = map[string]bool{
+	"adhoc":      true,
+	"datasource": true,
+}
+
 func ValidatePublicDashboard(dto *SavePublicDashboardDTO, dashboard *models.Dashboard) error {
-	if hasTemplateVariables(dashboard) {
-		return ErrPublicDashboardHasTemplateVariables.Errorf("ValidateSavePublicDashboard: public dashboard has template variables")
+	if dto.PublicDashboard == nil {
+		return nil
+	}
+
+	if err := validateTemplateVariables(dto.PublicDashboard.TemplateVariables, dashboard); err != nil {
+		return err
+	}
+
+	return validateShare(dto.PublicDashboard, time.Now())
+}
+
+// validateTemplateVariables checks that the values viewers can select are for template variables of the dashboard.
+// The values are interpolated in the queries of the dashboard, so they can't contain control characters.
+func validateTemplateVariables(variables TemplateVariables, dashboard *models.Dashboard) error {
+	types := dashboardVariableTypes(dashboard)
+	names := make(map[string]bool, len(variables))
+
+	for _, variable := range variables {
+		variableType, ok := types[variable.Name]
+		if !ok {
+			return ErrInvalidTemplateVariables.Errorf("ValidateSavePublicDashboard: the dashboard has no template variable %q", variable.Name)
+		}
+		if unsupportedVariableTypes[variableType] {
+			return ErrInvalidTemplateVariables.Errorf("ValidateSavePublicDashboard: template variable %q of type %s isn't supported", variable.Name, variableType)
+		}
+		if names[variable.Name] {
+			return ErrInvalidTemplateVariables.Errorf("ValidateSavePublicDashboard: duplicate template variable %q", variable.Name)
+		}
+		names[variable.Name] = true
+
+		if len(variable.Values) == 0 || len(variable.Values) > MaxTemplateVariableValues {
+			return ErrInvalidTemplateVariables.Errorf("ValidateSavePublicDashboard: template variable %q must have between 1 and %d values", variable.Name, MaxTemplateVariableValues)
+		}
+		for _, value := range variable.Values {
+			if !isValidTemplateVariableValue(value) {
+				return ErrInvalidTemplateVariables.Errorf("ValidateSavePublicDashboard: invalid value %q for template variable %q", value, variable.Name)
+			}
+		}
 	}
 
 	return nil
 }
 
-func hasTemplateVariables(dashboard *models.Dashboard) bool {
-	templateVariables := dashboard.Data.Get("templating").Get("list").MustArray()
+func isValidTemplateVariableValue(value string) bool {
+	if len(value) > MaxTemplateVariableValueLength {
+		return false
+	}
+	for _, r := range value {
+		if unicode.IsControl(r) {
+			return false
+		}
+	}
+	return true
+}
 
-	return len(templateVariables) > 0
+// dashboardVariableTypes returns the types of the template variables of a dashboard by name
+func dashboardVariableTypes(dashboard *models.Dashboard) map[string]string {
+	types := make(map[string]string)
+	for _, variable := range dashboard.Data.Get("templating").Get("list").MustArray() {
+		variable, ok := variable.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := variable["name"].(string)
+		variableType, _ := variable["type"].(string)
+		types[name] = variableType
+	}
+	return types
+}
+
+func validateShare(pd *PublicDashboard, now time.Time) error {
+	switch pd.Share {
+	case PublicShareType:
+	case EmailShareType:
+		if len(pd.Recipients) == 0 || len(pd.Recipients) > MaxRecipients {
+			return ErrInvalidRecipients.Errorf("ValidateSavePublicDashboard: a public dashboard shared with emails must have between 1 and %d recipients", MaxRecipients)
+		}
+	default:
+		return ErrInvalidShareType.Errorf("ValidateSavePublicDashboard: invalid share type %q", pd.Share)
+	}
+
+	for _, recipient := range pd.Recipients {
+		if !util.IsEmail(recipient) {
+			return ErrInvalidRecipients.Errorf("ValidateSavePublicDashboard: invalid email address %q", recipient)
+		}
+	}
+
+	if pd.IsExpired(now) {
+		return ErrInvalidExpiration.Errorf("ValidateSavePublicDashboard: expiration time %s is in the past", pd.ExpiresAt)
+	}
+
+	return nil
 }
 
 func ValidateQueryPublicDashboardRequest(req PublicDashboardQueryDTO) error {
@@ -30,3 +131,19 @@ func ValidateQueryPublicDashboardRequest(req PublicDashboardQueryDTO) error {
 
 	return nil
 }
+
+// ValidateQueryTemplateVariables checks that viewers only select values allowed by the owner of the public
+// dashboard, so that no other text is interpolated in its queries.
+func ValidateQueryTemplateVariables(variables TemplateVariables, selected map[string]string) error {
+	for name, value := range selected {
+		variable := variables.Find(name)
+		if variable == nil {
+			return ErrInvalidTemplateVariableValue.Errorf("ValidateQueryTemplateVariables: template variable %q can't be changed", name)
+		}
+		if !variable.HasValue(value) {
+			return ErrInvalidTemplateVariableValue.Errorf("ValidateQueryTemplateVariables: value %q isn't allowed for template variable %q", value, name)
+		}
+	}
+
+	return nil
+}
@@ -1,7 +1,9 @@
 package validation
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/grafana/grafana/pkg/components/simplejson"
 	"github.com/grafana/grafana/pkg/models"
@@ -10,7 +12,7 @@ import (
 )
 
 func TestValidatePublicDashboard(t *testing.T) {
-	t.Run("Returns validation error when dashboard has template variables", func(t *testing.T) {
+	t.Run("Returns no validation error when dashboard has template variables", func(t *testing.T) {
 		templateVars := []byte(`{
 			"templating": {
 				 "list": [
@@ -22,10 +24,10 @@ func TestValidatePublicDashboard(t *testing.T) {
 		}`)
 		dashboardData, _ := simplejson.NewJson(templateVars)
 		dashboard := models.NewDashboardFromJson(dashboardData)
-		dto := &SavePublicDashboardDTO{DashboardUid: "abc123", OrgId: 1, UserId: 1, PublicDashboard: nil}
+		dto := &SavePublicDashboardDTO{DashboardUid: "abc123", OrgId: 1, UserId: 1, PublicDashboard: &PublicDashboard{Share: PublicShareType}}
 
 		err := ValidatePublicDashboard(dto, dashboard)
-		require.ErrorContains(t, err, ErrPublicDashboardHasTemplateVariables.Error())
+		require.NoError(t, err)
 	})
 
 	t.Run("Returns no validation error when dashboard has no template variables", func(t *testing.T) {
@@ -41,4 +43,94 @@ func TestValidatePublicDashboard(t *testing.T) {
 		err := ValidatePublicDashboard(dto, dashboard)
 		require.NoError(t, err)
 	})
+
+	t.Run("Validates the share settings", func(t *testing.T) {
+		dashboard := models.NewDashboardFromJson(simplejson.New())
+		past := time.Now().Add(-time.Hour)
+		future := time.Now().Add(time.Hour)
+		tooManyRecipients := make(Recipients, MaxRecipients+1)
+		for i := range tooManyRecipients {
+			tooManyRecipients[i] = fmt.Sprintf("viewer%d@example.com", i)
+		}
+
+		testCases := []struct {
+			name            string
+			publicDashboard *PublicDashboard
+			err             error
+		}{
+			{name: "public share", publicDashboard: &PublicDashboard{Share: PublicShareType}},
+			{name: "email share", publicDashboard: &PublicDashboard{Share: EmailShareType, Recipients: Recipients{"viewer@example.com"}, ExpiresAt: &future}},
+			{name: "unknown share type", publicDashboard: &PublicDashboard{Share: "unknown"}, err: ErrInvalidShareType},
+			{name: "email share without recipients", publicDashboard: &PublicDashboard{Share: EmailShareType}, err: ErrInvalidRecipients},
+			{name: "email share with too many recipients", publicDashboard: &PublicDashboard{Share: EmailShareType, Recipients: tooManyRecipients}, err: ErrInvalidRecipients},
+			{name: "invalid recipient", publicDashboard: &PublicDashboard{Share: EmailShareType, Recipients: Recipients{"viewer"}}, err: ErrInvalidRecipients},
+			{name: "expiration time in the past", publicDashboard: &PublicDashboard{Share: PublicShareType, ExpiresAt: &past}, err: ErrInvalidExpiration},
+		}
+
+		for _, tt := range testCases {
+			t.Run(tt.name, func(t *testing.T) {
+				dto := &SavePublicDashboardDTO{DashboardUid: "abc123", OrgId: 1, UserId: 1, PublicDashboard: tt.publicDashboard}
+
+				err := ValidatePublicDashboard(dto, dashboard)
+				if tt.err == nil {
+					require.NoError(t, err)
+				} else {
+					require.ErrorIs(t, err, tt.err)
+				}
+			})
+		}
+	})
+
+	t.Run("Validates the template variables viewers can change", func(t *testing.T) {
+		dashboardData, _ := simplejson.NewJson([]byte(`{
+			"templating": {
+				"list": [
+					{"name": "env", "type": "custom"},
+					{"name": "host", "type": "query"},
+					{"name": "ds", "type": "datasource"},
+					{"name": "filters", "type": "adhoc"}
+				]
+			}
+		}`))
+		dashboard := models.NewDashboardFromJson(dashboardData)
+		tooManyValues := make([]string, MaxTemplateVariableValues+1)
+
+		testCases := []struct {
+			name      string
+			variables TemplateVariables
+			err       error
+		}{
+			{name: "no variables"},
+			{name: "valid variables", variables: TemplateVariables{{Name: "env", Values: []string{"prod", "dev"}}, {Name: "host", Values: []string{"a"}}}},
+			{name: "unknown variable", variables: TemplateVariables{{Name: "unknown", Values: []string{"a"}}}, err: ErrInvalidTemplateVariables},
+			{name: "datasource variable", variables: TemplateVariables{{Name: "ds", Values: []string{"a"}}}, err: ErrInvalidTemplateVariables},
+			{name: "ad hoc variable", variables: TemplateVariables{{Name: "filters", Values: []string{"a"}}}, err: ErrInvalidTemplateVariables},
+			{name: "duplicate variable", variables: TemplateVariables{{Name: "env", Values: []string{"a"}}, {Name: "env", Values: []string{"b"}}}, err: ErrInvalidTemplateVariables},
+			{name: "no values", variables: TemplateVariables{{Name: "env"}}, err: ErrInvalidTemplateVariables},
+			{name: "too many values", variables: TemplateVariables{{Name: "env", Values: tooManyValues}}, err: ErrInvalidTemplateVariables},
+			{name: "value with control characters", variables: TemplateVariables{{Name: "env", Values: []string{"prod\n"}}}, err: ErrInvalidTemplateVariables},
+		}
+
+		for _, tt := range testCases {
+			t.Run(tt.name, func(t *testing.T) {
+				dto := &SavePublicDashboardDTO{DashboardUid: "abc123", OrgId: 1, UserId: 1, PublicDashboard: &PublicDashboard{Share: PublicShareType, TemplateVariables: tt.variables}}
+
+				err := ValidatePublicDashboard(dto, dashboard)
+				if tt.err == nil {
+					require.NoError(t, err)
+				} else {
+					require.ErrorIs(t, err, tt.err)
+				}
+			})
+		}
+	})
+}
+
+func TestValidateQueryTemplateVariables(t *testing.T) {
+	variables := TemplateVariables{{Name: "env", Values: []string{"prod", "dev"}}}
+
+	require.NoError(t, ValidateQueryTemplateVariables(variables, nil))
+	require.NoError(t, ValidateQueryTemplateVariables(variables, map[string]string{"env": "dev"}))
+	require.ErrorIs(t, ValidateQueryTemplateVariables(variables, map[string]string{"env": "staging"}), ErrInvalidTemplateVariableValue)
+	require.ErrorIs(t, ValidateQueryTemplateVariables(variables, map[string]string{"host": "a"}), ErrInvalidTemplateVariableValue)
 }
@@ -2,7 +2,6 @@ package searchV2
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"time"
 
@@ -64,12 +63,12 @@ var (
 type StandardSearchService struct {
 	registry.BackgroundService
 
-	cfg         *setting.Cfg
-	sql         db.DB
-	auth        FutureAuthService // eventually injected from elsewhere
-	ac          accesscontrol.Service
-	orgService  org.Service
-	userService user.Service
+	cfg        *setting.Cfg
+	sql        db.DB
+	auth       FutureAuthService // eventually injected from elsewhere
+	ac         accesscontrol.Service
+	orgService org.Service
+	users      *SignedInUserResolver
 
 	logger         log.Logger
 	dashboardIndex *searchIndex
@@ -87,6 +86,7 @@ func ProvideService(cfg *setting.Cfg, sql db.DB, entityEventStore store.EntityEv
 	ac accesscontrol.Service, tracer tracing.Tracer, features featuremgmt.FeatureToggles, orgService org.Service,
 	userService user.Service, queries querylibrary.Service) SearchService {
 	extender := &NoopExtender{}
+	logger := log.New("searchV2")
 	s := &StandardSearchService{
 		cfg: cfg,
 		sql: sql,
@@ -104,13 +104,13 @@ func ProvideService(cfg *setting.Cfg, sql db.DB, entityEventStore store.EntityEv
 			features,
 			cfg.Search,
 		),
-		logger:      log.New("searchV2"),
-		extender:    extender,
-		reIndexCh:   make(chan struct{}, 1),
-		orgService:  orgService,
-		userService: userService,
-		queries:     queries,
-		features:    features,
+		logger:     logger,
+		extender:   extender,
+		reIndexCh:  make(chan struct{}, 1),
+		orgService: orgService,
+		users:      NewSignedInUserResolver(cfg, ac, orgService, userService, logger),
+		queries:    queries,
+		features:   features,
 	}
 	return s
 }
@@ -148,72 +148,10 @@ func (s *StandardSearchService) RegisterDashboardIndexExtender(ext DashboardInde
 	s.dashboardIndex.extender = ext.GetDocumentExtender()
 }
 
-func (s *StandardSearchService) getUser(ctx context.Context, backendUser *backend.User, orgId int64) (*user.SignedInUser, error) {
-	// TODO: get user & user's permissions from the request context
-
-	var usr *user.SignedInUser
-	if s.cfg.AnonymousEnabled && backendUser.Email == "" && backendUser.Login == "" {
-		getOrg := org.GetOrgByNameQuery{Name: s.cfg.AnonymousOrgName}
-		orga, err := s.orgService.GetByName(ctx, &getOrg)
-		if err != nil {
-			s.logger.Error("Anonymous access organization error.", "org_name", s.cfg.AnonymousOrgName, "error", err)
-			return nil, err
-		}
-
-		usr = &user.SignedInUser{
-			OrgID:       orga.ID,
-			OrgName:     orga.Name,
-			OrgRole:     org.RoleType(s.cfg.AnonymousOrgRole),
-			IsAnonymous: true,
-		}
-	} else {
-		getSignedInUserQuery := &user.GetSignedInUserQuery{
-			Login: backendUser.Login,
-			Email: backendUser.Email,
-			OrgID: orgId,
-		}
-		var err error
-		usr, err = s.userService.GetSignedInUser(ctx, getSignedInUserQuery)
-		if err != nil {
-			s.logger.Error("Error while retrieving user", "error", err, "email", backendUser.Email, "login", getSignedInUserQuery.Login)
-			return nil, errors.New("auth error")
-		}
-
-		if usr == nil {
-			s.logger.Error("No user found", "email", backendUser.Email)
-			return nil, errors.New("auth error")
-		}
-	}
-
-	if s.ac.IsDisabled() {
-		return usr, nil
-	}
-
-	if usr.Permissions == nil {
-		usr.Permissions = make(map[int64]map[string][]string)
-	}
-
-	if _, ok := usr.Permissions[orgId]; ok {
-		// permissions as part of the `s.sql.GetSignedInUser` query - return early
-		return usr, nil
-	}
-
-	// TODO: ensure this is cached
-	permissions, err := s.ac.GetUserPermissions(ctx, usr,
-		accesscontrol.Options{ReloadCache: false})
-	if err != nil {
-		s.logger.Error("failed to retrieve user permissions", "error", err, "email", backendUser.Email)
-		return nil, errors.New("auth error")
-	}
-
-	usr.Permissions[orgId] = accesscontrol.GroupScopesByAction(permissions)
-	return usr, nil
-}
-
 func (s *StandardSearchService) DoDashboardQuery(ctx context.Context, user *backend.User, orgID int64, q DashboardQuery) *backend.DataResponse {
 	start := time.Now()
 
-	signedInUser, err := s.getUser(ctx, user, orgID)
+	signedInUser, err := s.users.SignedInUser(ctx, user, orgID)
 
 	if err != nil {
 		dashboardSearchFailureRequestsCounter.With(prometheus.Labels{
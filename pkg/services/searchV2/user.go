@@ -0,0 +1,98 @@
+package searchV2
+
+import (
+	"context"
+	"errors"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/org"
+	"github.com/grafana/grafana/pkg/services/user"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+// SignedInUserResolver returns the signed in user of the backend requests of the Grafana datasource, such as the
+// search and annotations queries, with their permissions.
+type SignedInUserResolver struct {
+	cfg         *setting.Cfg
+	ac          accesscontrol.Service
+	orgService  org.Service
+	userService user.Service
+	logger      log.Logger
+}
+
+func NewSignedInUserResolver(cfg *setting.Cfg, ac accesscontrol.Service, orgService org.Service, userService user.Service, logger log.Logger) *SignedInUserResolver {
+	return &SignedInUserResolver{
+		cfg:         cfg,
+		ac:          ac,
+		orgService:  orgService,
+		userService: userService,
+		logger:      logger,
+	}
+}
+
+// SignedInUser returns the user of a backend request with their permissions in an organization. A request without
+// email and login is made by an anonymous user when anonymous access is enabled.
+func (r *SignedInUserResolver) SignedInUser(ctx context.Context, backendUser *backend.User, orgId int64) (*user.SignedInUser, error) {
+	// TODO: get user & user's permissions from the request context
+
+	var usr *user.SignedInUser
+	if r.cfg.AnonymousEnabled && backendUser.Email == "" && backendUser.Login == "" {
+		getOrg := org.GetOrgByNameQuery{Name: r.cfg.AnonymousOrgName}
+		orga, err := r.orgService.GetByName(ctx, &getOrg)
+		if err != nil {
+			r.logger.Error("Anonymous access organization error.", "org_name", r.cfg.AnonymousOrgName, "error", err)
+			return nil, err
+		}
+
+		usr = &user.SignedInUser{
+			OrgID:       orga.ID,
+			OrgName:     orga.Name,
+			OrgRole:     org.RoleType(r.cfg.AnonymousOrgRole),
+			IsAnonymous: true,
+		}
+	} else {
+		getSignedInUserQuery := &user.GetSignedInUserQuery{
+			Login: backendUser.Login,
+			Email: backendUser.Email,
+			OrgID: orgId,
+		}
+		var err error
+		usr, err = r.userService.GetSignedInUser(ctx, getSignedInUserQuery)
+		if err != nil {
+			r.logger.Error("Error while retrieving user", "error", err, "email", backendUser.Email, "login", getSignedInUserQuery.Login)
+			return nil, errors.New("auth error")
+		}
+
+		if usr == nil {
+			r.logger.Error("No user found", "email", backendUser.Email)
+			return nil, errors.New("auth error")
+		}
+	}
+
+	if r.ac.IsDisabled() {
+		return usr, nil
+	}
+
+	if usr.Permissions == nil {
+		usr.Permissions = make(map[int64]map[string][]string)
+	}
+
+	if _, ok := usr.Permissions[orgId]; ok {
+		// permissions as part of the `s.sql.GetSignedInUser` query - return early
+		return usr, nil
+	}
+
+	// TODO: ensure this is cached
+	permissions, err := r.ac.GetUserPermissions(ctx, usr,
+		accesscontrol.Options{ReloadCache: false})
+	if err != nil {
+		r.logger.Error("failed to retrieve user permissions", "error", err, "email", backendUser.Email)
+		return nil, errors.New("auth error")
+	}
+
+	usr.Permissions[orgId] = accesscontrol.GroupScopesByAction(permissions)
+	return usr, nil
+}
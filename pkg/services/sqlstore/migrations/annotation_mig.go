@@ -182,6 +182,19 @@ func addAnnotationMig(mg *Migrator) {
 	mg.AddMigration("Increase tags column to length 4096", NewRawSQLMigration("").
 		Postgres("ALTER TABLE annotation ALTER COLUMN tags TYPE VARCHAR(4096);").
 		Mysql("ALTER TABLE annotation MODIFY tags VARCHAR(4096);"))
+
+	//
+	// Incident annotations
+	//
+	mg.AddMigration("Add severity column to annotation table", NewAddColumnMigration(table, &Column{
+		Name: "severity", Type: DB_NVarchar, Length: 25, Nullable: true,
+	}))
+	mg.AddMigration("Add links column to annotation table", NewAddColumnMigration(table, &Column{
+		Name: "links", Type: DB_Text, Nullable: true,
+	}))
+	mg.AddMigration("Add owner_id column to annotation table", NewAddColumnMigration(table, &Column{
+		Name: "owner_id", Type: DB_BigInt, Nullable: true,
+	}))
 }
 
 type AddMakeRegionSingleRowMigration struct {
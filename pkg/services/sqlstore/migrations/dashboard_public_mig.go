@@ -84,4 +84,102 @@ func addPublicDashboardMigration(mg *Migrator) {
 
 	mg.AddMigration("delete orphaned public dashboards", NewRawSQLMigration(
 		"DELETE FROM dashboard_public WHERE dashboard_uid NOT IN (SELECT uid FROM dashboard)"))
+
+	mg.AddMigration("add share column", NewAddColumnMigration(dashboardPublicCfgV2, &Column{
+		Name:     "share",
+		Type:     DB_NVarchar,
+		Length:   32,
+		Nullable: false,
+		Default:  "'public'",
+	}))
+
+	mg.AddMigration("add recipients column", NewAddColumnMigration(dashboardPublicCfgV2, &Column{
+		Name:     "recipients",
+		Type:     DB_Text,
+		Nullable: true,
+	}))
+
+	mg.AddMigration("add expires_at column", NewAddColumnMigration(dashboardPublicCfgV2, &Column{
+		Name:     "expires_at",
+		Type:     DB_DateTime,
+		Nullable: true,
+	}))
+
+	mg.AddMigration("add view_count column", NewAddColumnMigration(dashboardPublicCfgV2, &Column{
+		Name:     "view_count",
+		Type:     DB_BigInt,
+		Nullable: false,
+		Default:  "0",
+	}))
+
+	mg.AddMigration("add last_viewed_at column", NewAddColumnMigration(dashboardPublicCfgV2, &Column{
+		Name:     "last_viewed_at",
+		Type:     DB_DateTime,
+		Nullable: true,
+	}))
+
+	addPublicDashboardEmailShareMigrations(mg)
+}
+
+func addPublicDashboardEmailShareMigrations(mg *Migrator) {
+	magicLinkV1 := Table{
+		Name: "dashboard_public_magic_link",
+		Columns: []*Column{
+			{Name: "id", Type: DB_BigInt, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "public_dashboard_uid", Type: DB_NVarchar, Length: 40, Nullable: false},
+			{Name: "email", Type: DB_NVarchar, Length: 190, Nullable: false},
+			{Name: "code_hash", Type: DB_NVarchar, Length: 64, Nullable: false},
+			{Name: "expires_at", Type: DB_DateTime, Nullable: false},
+			{Name: "created_at", Type: DB_DateTime, Nullable: false},
+		},
+		Indices: []*Index{
+			{Cols: []string{"code_hash"}, Type: UniqueIndex},
+			{Cols: []string{"public_dashboard_uid", "email"}},
+		},
+	}
+
+	mg.AddMigration("create dashboard public magic link table v1", NewAddTableMigration(magicLinkV1))
+	addTableIndicesMigrations(mg, "v1", magicLinkV1)
+
+	sessionV1 := Table{
+		Name: "dashboard_public_session",
+		Columns: []*Column{
+			{Name: "id", Type: DB_BigInt, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "public_dashboard_uid", Type: DB_NVarchar, Length: 40, Nullable: false},
+			{Name: "email", Type: DB_NVarchar, Length: 190, Nullable: false},
+			{Name: "token_hash", Type: DB_NVarchar, Length: 64, Nullable: false},
+			{Name: "expires_at", Type: DB_DateTime, Nullable: false},
+			{Name: "created_at", Type: DB_DateTime, Nullable: false},
+		},
+		Indices: []*Index{
+			{Cols: []string{"token_hash"}, Type: UniqueIndex},
+			{Cols: []string{"public_dashboard_uid"}},
+		},
+	}
+
+	mg.AddMigration("create dashboard public session table v1", NewAddTableMigration(sessionV1))
+	addTableIndicesMigrations(mg, "v1", sessionV1)
+
+	auditV1 := Table{
+		Name: "dashboard_public_audit",
+		Columns: []*Column{
+			{Name: "id", Type: DB_BigInt, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "org_id", Type: DB_BigInt, Nullable: false},
+			{Name: "public_dashboard_uid", Type: DB_NVarchar, Length: 40, Nullable: false},
+			{Name: "action", Type: DB_NVarchar, Length: 32, Nullable: false},
+			{Name: "email", Type: DB_NVarchar, Length: 190, Nullable: true},
+			{Name: "created_at", Type: DB_DateTime, Nullable: false},
+		},
+		Indices: []*Index{
+			{Cols: []string{"org_id", "public_dashboard_uid", "created_at"}},
+		},
+	}
+
+	mg.AddMigration("create dashboard public audit table v1", NewAddTableMigration(auditV1))
+	addTableIndicesMigrations(mg, "v1", auditV1)
+
+	// the audit entries are deleted after a retention period
+	mg.AddMigration("add index dashboard_public_audit.created_at", NewAddIndexMigration(auditV1, &Index{
+		Cols: []string{"created_at"},
+	}))
 }
@@ -71,4 +71,52 @@ func addDashboardSnapshotMigrations(mg *Migrator) {
 
 	mg.AddMigration("Change dashboard_encrypted column to MEDIUMBLOB", NewRawSQLMigration("").
 		Mysql("ALTER TABLE dashboard_snapshot MODIFY dashboard_encrypted MEDIUMBLOB;"))
+
+	mg.AddMigration("Add schedule_id column to dashboard_snapshot table", NewAddColumnMigration(snapshotV5, &Column{
+		Name: "schedule_id", Type: DB_BigInt, Nullable: true, Default: "0",
+	}))
+	mg.AddMigration("Add index for org_id_schedule_id in dashboard_snapshot table", NewAddIndexMigration(snapshotV5, &Index{
+		Cols: []string{"org_id", "schedule_id"}, Type: IndexType,
+	}))
+	mg.AddMigration("Add dashboard_uid column to dashboard_snapshot table", NewAddColumnMigration(snapshotV5, &Column{
+		Name: "dashboard_uid", Type: DB_NVarchar, Length: 40, Nullable: true,
+	}))
+	mg.AddMigration("Add index for org_id_dashboard_uid in dashboard_snapshot table", NewAddIndexMigration(snapshotV5, &Index{
+		Cols: []string{"org_id", "dashboard_uid"}, Type: IndexType,
+	}))
+
+	addDashboardSnapshotScheduleMigrations(mg)
+}
+
+func addDashboardSnapshotScheduleMigrations(mg *Migrator) {
+	scheduleV1 := Table{
+		Name: "dashboard_snapshot_schedule",
+		Columns: []*Column{
+			{Name: "id", Type: DB_BigInt, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "uid", Type: DB_NVarchar, Length: 40, Nullable: false},
+			{Name: "org_id", Type: DB_BigInt, Nullable: false},
+			{Name: "dashboard_uid", Type: DB_NVarchar, Length: 40, Nullable: false},
+			{Name: "user_id", Type: DB_BigInt, Nullable: false},
+			{Name: "name", Type: DB_NVarchar, Length: 255, Nullable: false},
+			{Name: "cron", Type: DB_NVarchar, Length: 255, Nullable: false},
+			{Name: "timezone", Type: DB_NVarchar, Length: 255, Nullable: false},
+			{Name: "time_from", Type: DB_NVarchar, Length: 255, Nullable: false},
+			{Name: "time_to", Type: DB_NVarchar, Length: 255, Nullable: false},
+			{Name: "expires", Type: DB_BigInt, Nullable: false},
+			{Name: "enabled", Type: DB_Bool, Nullable: false},
+			{Name: "next_run", Type: DB_DateTime, Nullable: false},
+			{Name: "last_run", Type: DB_DateTime, Nullable: true},
+			{Name: "last_error", Type: DB_Text, Nullable: true},
+			{Name: "created", Type: DB_DateTime, Nullable: false},
+			{Name: "updated", Type: DB_DateTime, Nullable: false},
+		},
+		Indices: []*Index{
+			{Cols: []string{"org_id", "uid"}, Type: UniqueIndex},
+			{Cols: []string{"org_id", "dashboard_uid"}},
+			{Cols: []string{"enabled", "next_run"}},
+		},
+	}
+
+	mg.AddMigration("create dashboard_snapshot_schedule table v1", NewAddTableMigration(scheduleV1))
+	addTableIndicesMigrations(mg, "v1", scheduleV1)
 }
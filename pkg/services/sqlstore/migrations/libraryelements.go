@@ -59,3 +59,31 @@ func addLibraryElementsMigrations(mg *migrator.Migrator) {
 		{Name: "description", Type: migrator.DB_NVarchar, Length: 2048, Nullable: false},
 	}))
 }
+
+// addLibraryElementVersionMigrations defines database migrations for the versions of library elements.
+func addLibraryElementVersionMigrations(mg *migrator.Migrator) {
+	libraryElementVersionV1 := migrator.Table{
+		Name: "library_element_version",
+		Columns: []*migrator.Column{
+			{Name: "id", Type: migrator.DB_BigInt, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "element_id", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "version", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "restored_from", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "name", Type: migrator.DB_NVarchar, Length: 150, Nullable: false},
+			{Name: "model", Type: migrator.DB_Text, Nullable: false},
+			{Name: "created", Type: migrator.DB_DateTime, Nullable: false},
+			{Name: "created_by", Type: migrator.DB_BigInt, Nullable: false},
+		},
+		Indices: []*migrator.Index{
+			{Cols: []string{"element_id", "version"}, Type: migrator.UniqueIndex},
+		},
+	}
+
+	mg.AddMigration("create library_element_version table v1", migrator.NewAddTableMigration(libraryElementVersionV1))
+	mg.AddMigration("add unique index library_element_version element_id-version", migrator.NewAddIndexMigration(libraryElementVersionV1, libraryElementVersionV1.Indices[0]))
+
+	// The current version of the existing library elements is the first version in their history.
+	copyLibraryElementsSQL := "INSERT INTO library_element_version (element_id, version, restored_from, name, model, created, created_by)" +
+		" SELECT id, version, 0, name, model, updated, updated_by FROM library_element"
+	mg.AddMigration("copy library_element to library_element_version", migrator.NewRawSQLMigration(copyLibraryElementsSQL))
+}
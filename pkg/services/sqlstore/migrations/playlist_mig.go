@@ -52,6 +52,25 @@ func addPlaylistUIDMigration(mg *Migrator) {
 	}))
 }
 
+// addPlaylistItemSettingsMigration adds the time range, refresh and kiosk mode a playlist item
+// overrides when it's shown.
+func addPlaylistItemSettingsMigration(mg *Migrator) {
+	playlistItemV2 := Table{Name: "playlist_item"}
+
+	mg.AddMigration("Add time_from column to playlist_item", NewAddColumnMigration(playlistItemV2, &Column{
+		Name: "time_from", Type: DB_NVarchar, Length: 255, Nullable: false, Default: "''",
+	}))
+	mg.AddMigration("Add time_to column to playlist_item", NewAddColumnMigration(playlistItemV2, &Column{
+		Name: "time_to", Type: DB_NVarchar, Length: 255, Nullable: false, Default: "''",
+	}))
+	mg.AddMigration("Add refresh column to playlist_item", NewAddColumnMigration(playlistItemV2, &Column{
+		Name: "refresh", Type: DB_NVarchar, Length: 32, Nullable: false, Default: "''",
+	}))
+	mg.AddMigration("Add kiosk column to playlist_item", NewAddColumnMigration(playlistItemV2, &Column{
+		Name: "kiosk", Type: DB_NVarchar, Length: 32, Nullable: false, Default: "''",
+	}))
+}
+
 func playlistV2() Table {
 	return Table{
 		Name: "playlist",
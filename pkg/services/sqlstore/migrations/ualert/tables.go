@@ -35,6 +35,12 @@ func AddTablesMigrations(mg *migrator.Migrator) {
 	AddAlertImageMigrations(mg)
 
 	AddAlertmanagerConfigHistoryMigrations(mg)
+
+	AddNotificationDeliveryMigrations(mg)
+
+	AddSilenceTemplateMigrations(mg)
+
+	AddSchedulerMemberMigrations(mg)
 }
 
 // AddAlertDefinitionMigrations should not be modified.
@@ -259,6 +265,15 @@ func AddAlertRuleMigrations(mg *migrator.Migrator, defaultIntervalSeconds int64)
 			Default:  "1",
 		},
 	))
+
+	mg.AddMigration("add notification_settings column to alert_rule", migrator.NewAddColumnMigration(
+		migrator.Table{Name: "alert_rule"},
+		&migrator.Column{
+			Name:     "notification_settings",
+			Type:     migrator.DB_Text,
+			Nullable: true,
+		},
+	))
 }
 
 func AddAlertRuleVersionMigrations(mg *migrator.Migrator) {
@@ -312,6 +327,15 @@ func AddAlertRuleVersionMigrations(mg *migrator.Migrator) {
 			Default:  "1",
 		},
 	))
+
+	mg.AddMigration("add notification_settings column to alert_rule_version", migrator.NewAddColumnMigration(
+		migrator.Table{Name: "alert_rule_version"},
+		&migrator.Column{
+			Name:     "notification_settings",
+			Type:     migrator.DB_Text,
+			Nullable: true,
+		},
+	))
 }
 
 func AddAlertmanagerConfigMigrations(mg *migrator.Migrator) {
@@ -432,3 +456,95 @@ func AddAlertImageMigrations(mg *migrator.Migrator) {
 		Postgres("ALTER TABLE alert_image ALTER COLUMN url TYPE VARCHAR(2048);").
 		Mysql("ALTER TABLE alert_image MODIFY url VARCHAR(2048) NOT NULL;"))
 }
+
+func AddNotificationDeliveryMigrations(mg *migrator.Migrator) {
+	deliveryTable := migrator.Table{
+		Name: "alert_notification_delivery",
+		Columns: []*migrator.Column{
+			{Name: "id", Type: migrator.DB_BigInt, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "org_id", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "receiver", Type: migrator.DB_NVarchar, Length: DefaultFieldMaxLength, Nullable: false},
+			{Name: "integration", Type: migrator.DB_NVarchar, Length: DefaultFieldMaxLength, Nullable: false},
+			{Name: "integration_index", Type: migrator.DB_Int, Nullable: false},
+			{Name: "integration_uid", Type: migrator.DB_NVarchar, Length: DefaultFieldMaxLength, Nullable: false},
+			{Name: "group_key", Type: migrator.DB_Text, Nullable: false},
+			{Name: "fingerprints", Type: migrator.DB_Text, Nullable: false},
+			{Name: "status", Type: migrator.DB_NVarchar, Length: 20, Nullable: false},
+			{Name: "status_code", Type: migrator.DB_Int, Nullable: false},
+			{Name: "duration", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "error", Type: migrator.DB_Text, Nullable: false},
+			{Name: "retry", Type: migrator.DB_Bool, Nullable: false},
+			{Name: "created_at", Type: migrator.DB_DateTime, Nullable: false},
+		},
+		Indices: []*migrator.Index{
+			{Cols: []string{"org_id", "created_at"}},
+		},
+	}
+
+	mg.AddMigration("create alert_notification_delivery table", migrator.NewAddTableMigration(deliveryTable))
+	mg.AddMigration("add index on org_id and created_at to alert_notification_delivery table", migrator.NewAddIndexMigration(deliveryTable, deliveryTable.Indices[0]))
+
+	retryTable := migrator.Table{
+		Name: "alert_notification_retry",
+		Columns: []*migrator.Column{
+			{Name: "id", Type: migrator.DB_BigInt, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "org_id", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "receiver", Type: migrator.DB_NVarchar, Length: DefaultFieldMaxLength, Nullable: false},
+			{Name: "integration", Type: migrator.DB_NVarchar, Length: DefaultFieldMaxLength, Nullable: false},
+			{Name: "integration_index", Type: migrator.DB_Int, Nullable: false},
+			{Name: "group_key", Type: migrator.DB_Text, Nullable: false},
+			{Name: "group_labels", Type: migrator.DB_Text, Nullable: false},
+			{Name: "alerts", Type: migrator.DB_MediumText, Nullable: false},
+			{Name: "attempts", Type: migrator.DB_Int, Nullable: false},
+			{Name: "last_error", Type: migrator.DB_Text, Nullable: false},
+			{Name: "next_attempt_at", Type: migrator.DB_DateTime, Nullable: false},
+			{Name: "created_at", Type: migrator.DB_DateTime, Nullable: false},
+		},
+		Indices: []*migrator.Index{
+			{Cols: []string{"org_id", "next_attempt_at"}},
+		},
+	}
+
+	mg.AddMigration("create alert_notification_retry table", migrator.NewAddTableMigration(retryTable))
+	mg.AddMigration("add index on org_id and next_attempt_at to alert_notification_retry table", migrator.NewAddIndexMigration(retryTable, retryTable.Indices[0]))
+}
+
+func AddSilenceTemplateMigrations(mg *migrator.Migrator) {
+	silenceTemplateTable := migrator.Table{
+		Name: "alert_silence_template",
+		Columns: []*migrator.Column{
+			{Name: "id", Type: migrator.DB_BigInt, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "org_id", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "uid", Type: migrator.DB_NVarchar, Length: 40, Nullable: false},
+			{Name: "name", Type: migrator.DB_NVarchar, Length: DefaultFieldMaxLength, Nullable: false},
+			{Name: "comment", Type: migrator.DB_Text, Nullable: false},
+			{Name: "matchers", Type: migrator.DB_Text, Nullable: false},
+			{Name: "updated", Type: migrator.DB_DateTime, Nullable: false},
+		},
+		Indices: []*migrator.Index{
+			{Cols: []string{"org_id", "uid"}, Type: migrator.UniqueIndex},
+			{Cols: []string{"org_id", "name"}, Type: migrator.UniqueIndex},
+		},
+	}
+
+	mg.AddMigration("create alert_silence_template table", migrator.NewAddTableMigration(silenceTemplateTable))
+	mg.AddMigration("add unique index on org_id and uid to alert_silence_template table", migrator.NewAddIndexMigration(silenceTemplateTable, silenceTemplateTable.Indices[0]))
+	mg.AddMigration("add unique index on org_id and name to alert_silence_template table", migrator.NewAddIndexMigration(silenceTemplateTable, silenceTemplateTable.Indices[1]))
+}
+
+func AddSchedulerMemberMigrations(mg *migrator.Migrator) {
+	memberTable := migrator.Table{
+		Name: "alert_scheduler_member",
+		Columns: []*migrator.Column{
+			{Name: "id", Type: migrator.DB_BigInt, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "instance_id", Type: migrator.DB_NVarchar, Length: DefaultFieldMaxLength, Nullable: false},
+			{Name: "last_heartbeat", Type: migrator.DB_BigInt, Nullable: false},
+		},
+		Indices: []*migrator.Index{
+			{Cols: []string{"instance_id"}, Type: migrator.UniqueIndex},
+		},
+	}
+
+	mg.AddMigration("create alert_scheduler_member table", migrator.NewAddTableMigration(memberTable))
+	mg.AddMigration("add unique index on instance_id to alert_scheduler_member table", migrator.NewAddIndexMigration(memberTable, memberTable.Indices[0]))
+}
@@ -44,6 +44,12 @@ type GetUserStarsQuery struct {
 	UserID int64 `xorm:"user_id"`
 }
 
+// GetTeamStarsQuery gets the dashboards starred by any member of a team.
+type GetTeamStarsQuery struct {
+	TeamID int64
+	OrgID  int64
+}
+
 type IsStarredByUserQuery struct {
 	UserID      int64 `xorm:"user_id"`
 	DashboardID int64 `xorm:"dashboard_id"`
@@ -60,3 +60,19 @@ func (s *sqlxStore) List(ctx context.Context, query *star.GetUserStarsQuery) (*s
 
 	return &star.GetUserStarsResult{UserStars: userStars}, err
 }
+
+func (s *sqlxStore) ListByTeam(ctx context.Context, query *star.GetTeamStarsQuery) (*star.GetUserStarsResult, error) {
+	userStars := make(map[int64]bool)
+	var stars = make([]star.Star, 0)
+	err := s.sess.Select(ctx, &stars, `SELECT star.* FROM star
+		INNER JOIN team_member ON team_member.user_id = star.user_id
+		WHERE team_member.team_id = ? AND team_member.org_id = ?`, query.TeamID, query.OrgID)
+	if err != nil {
+		return nil, err
+	}
+	for _, star := range stars {
+		userStars[star.DashboardID] = true
+	}
+
+	return &star.GetUserStarsResult{UserStars: userStars}, err
+}
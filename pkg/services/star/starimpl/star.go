@@ -50,6 +50,13 @@ func (s *Service) GetByUser(ctx context.Context, cmd *star.GetUserStarsQuery) (*
 	return s.store.List(ctx, cmd)
 }
 
+func (s *Service) GetByTeam(ctx context.Context, query *star.GetTeamStarsQuery) (*star.GetUserStarsResult, error) {
+	if query.TeamID == 0 || query.OrgID == 0 {
+		return nil, star.ErrCommandValidationFailed
+	}
+	return s.store.ListByTeam(ctx, query)
+}
+
 func (s *Service) DeleteByUser(ctx context.Context, userID int64) error {
 	return s.store.DeleteByUser(ctx, userID)
 }
@@ -12,4 +12,5 @@ type store interface {
 	Delete(context.Context, *star.UnstarDashboardCommand) error
 	DeleteByUser(context.Context, int64) error
 	List(context.Context, *star.GetUserStarsQuery) (*star.GetUserStarsResult, error)
+	ListByTeam(context.Context, *star.GetTeamStarsQuery) (*star.GetUserStarsResult, error)
 }
@@ -3,6 +3,7 @@ package starimpl
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -87,5 +88,27 @@ func testIntegrationUserStarsDataAccess(t *testing.T, fn getStore) {
 			require.NoError(t, err)
 			require.Equal(t, 1, len(res.UserStars))
 		})
+
+		t.Run("ListByTeam should return the stars of the members of the team", func(t *testing.T) {
+			err := ss.WithDbSession(context.Background(), func(sess *db.Session) error {
+				_, err := sess.Exec("INSERT INTO team_member (org_id, team_id, user_id, created, updated) VALUES (?, ?, ?, ?, ?), (?, ?, ?, ?, ?)",
+					1, 3, 20, time.Now(), time.Now(), 2, 3, 21, time.Now(), time.Now())
+				return err
+			})
+			require.NoError(t, err)
+			for _, cmd := range []star.StarDashboardCommand{
+				{DashboardID: 30, UserID: 20},
+				{DashboardID: 31, UserID: 20},
+				{DashboardID: 32, UserID: 21},
+				{DashboardID: 33, UserID: 22},
+			} {
+				cmd := cmd
+				require.NoError(t, starStore.Insert(context.Background(), &cmd))
+			}
+
+			res, err := starStore.ListByTeam(context.Background(), &star.GetTeamStarsQuery{TeamID: 3, OrgID: 1})
+			require.NoError(t, err)
+			require.Equal(t, map[int64]bool{30: true, 31: true}, res.UserStars)
+		})
 	})
 }
@@ -67,3 +67,20 @@ func (s *sqlStore) List(ctx context.Context, query *star.GetUserStarsQuery) (*st
 	})
 	return &star.GetUserStarsResult{UserStars: userStars}, err
 }
+
+func (s *sqlStore) ListByTeam(ctx context.Context, query *star.GetTeamStarsQuery) (*star.GetUserStarsResult, error) {
+	userStars := make(map[int64]bool)
+	err := s.db.WithDbSession(ctx, func(dbSession *db.Session) error {
+		var stars = make([]star.Star, 0)
+		err := dbSession.Table("star").
+			Join("INNER", "team_member", "team_member.user_id = star.user_id").
+			Where("team_member.team_id = ? AND team_member.org_id = ?", query.TeamID, query.OrgID).
+			Cols("star.id", "star.user_id", "star.dashboard_id").
+			Find(&stars)
+		for _, star := range stars {
+			userStars[star.DashboardID] = true
+		}
+		return err
+	})
+	return &star.GetUserStarsResult{UserStars: userStars}, err
+}
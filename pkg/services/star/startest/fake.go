@@ -10,6 +10,7 @@ type FakeStarService struct {
 	ExpectedStars     *star.Star
 	ExpectedError     error
 	ExpectedUserStars *star.GetUserStarsResult
+	ExpectedTeamStars *star.GetUserStarsResult
 }
 
 func NewStarServiceFake() *FakeStarService {
@@ -35,3 +36,7 @@ func (f *FakeStarService) DeleteByUser(ctx context.Context, userID int64) error
 func (f *FakeStarService) GetByUser(ctx context.Context, query *star.GetUserStarsQuery) (*star.GetUserStarsResult, error) {
 	return f.ExpectedUserStars, f.ExpectedError
 }
+
+func (f *FakeStarService) GetByTeam(ctx context.Context, query *star.GetTeamStarsQuery) (*star.GetUserStarsResult, error) {
+	return f.ExpectedTeamStars, f.ExpectedError
+}
@@ -11,6 +11,7 @@ type FakeService struct {
 	ExpectedTeamDTO     *models.TeamDTO
 	ExpectedTeamsByUser []*models.TeamDTO
 	ExpectedMembers     []*models.TeamMemberDTO
+	ExpectedIsMember    bool
 	ExpectedError       error
 }
 
@@ -53,7 +54,7 @@ func (s *FakeService) UpdateTeamMember(ctx context.Context, cmd *models.UpdateTe
 }
 
 func (s *FakeService) IsTeamMember(orgId int64, teamId int64, userId int64) (bool, error) {
-	return false, s.ExpectedError
+	return s.ExpectedIsMember, s.ExpectedError
 }
 
 func (s *FakeService) RemoveTeamMember(ctx context.Context, cmd *models.RemoveTeamMemberCommand) error {
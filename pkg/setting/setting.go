@@ -293,7 +293,8 @@ type Cfg struct {
 	MetricsGrafanaEnvironmentInfo    map[string]string
 
 	// Dashboards
-	DefaultHomeDashboardPath string
+	DefaultHomeDashboardPath   string
+	LibraryPanelVersionsToKeep int
 
 	// Auth
 	LoginCookieName              string
@@ -394,6 +395,7 @@ type Cfg struct {
 	AlertingAnnotationCleanupSetting   AnnotationCleanupSettings
 	DashboardAnnotationCleanupSettings AnnotationCleanupSettings
 	APIAnnotationCleanupSettings       AnnotationCleanupSettings
+	AnnotationExternalStores           []AnnotationStoreSettings
 
 	// Sentry config
 	Sentry Sentry
@@ -406,6 +408,9 @@ type Cfg struct {
 
 	// Snapshots
 	SnapshotPublicMode bool
+	// SnapshotArchiveKey is the key that encrypts and signs the snapshot archives, it must be the same on the
+	// instances that exchange archives.
+	SnapshotArchiveKey string
 
 	ErrTemplateName string
 
@@ -668,7 +673,7 @@ func (cfg *Cfg) readAnnotationSettings() error {
 	cfg.DashboardAnnotationCleanupSettings = newAnnotationCleanupSettings(dashboardAnnotation, "max_age")
 	cfg.APIAnnotationCleanupSettings = newAnnotationCleanupSettings(apiIAnnotation, "max_age")
 
-	return nil
+	return cfg.readAnnotationStoreSettings()
 }
 
 func (cfg *Cfg) readExpressionsSettings() {
@@ -987,6 +992,7 @@ func (cfg *Cfg) Load(args CommandLineArgs) error {
 	MinRefreshInterval = valueAsString(dashboards, "min_refresh_interval", "5s")
 
 	cfg.DefaultHomeDashboardPath = dashboards.Key("default_home_dashboard_path").MustString("")
+	cfg.LibraryPanelVersionsToKeep = dashboards.Key("library_panel_versions_to_keep").MustInt(20)
 
 	if err := readUserSettings(iniFile, cfg); err != nil {
 		return err
@@ -1647,6 +1653,7 @@ func readSnapshotsSettings(cfg *Cfg, iniFile *ini.File) error {
 	ExternalEnabled = snapshots.Key("external_enabled").MustBool(true)
 	SnapShotRemoveExpired = snapshots.Key("snapshot_remove_expired").MustBool(true)
 	cfg.SnapshotPublicMode = snapshots.Key("public_mode").MustBool(false)
+	cfg.SnapshotArchiveKey = valueAsString(snapshots, "archive_key", "")
 
 	return nil
 }
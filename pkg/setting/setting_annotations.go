@@ -0,0 +1,77 @@
+package setting
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana/pkg/util"
+)
+
+const (
+	AnnotationStoreTypeLoki = "loki"
+
+	AnnotationRouteTypeAlert      = "alert"
+	AnnotationRouteTypeAnnotation = "annotation"
+)
+
+// AnnotationStoreSettings configures an external store of annotations. The annotations that match the routes of
+// the store are written to it instead of the SQL database, and the annotations it stores are read with the
+// annotations of the SQL database.
+type AnnotationStoreSettings struct {
+	Name              string
+	Type              string
+	URL               *url.URL
+	TenantID          string
+	BasicAuthUser     string
+	BasicAuthPassword string
+	Timeout           time.Duration
+	// RouteTags are the tags of the annotations written to the store. An annotation is written to the store if it
+	// has any of the tags.
+	RouteTags []string
+	// RouteTypes are the types of the annotations written to the store, alert or annotation.
+	RouteTypes []string
+}
+
+func (cfg *Cfg) readAnnotationStoreSettings() error {
+	section := cfg.Raw.Section("annotations")
+	names := util.SplitString(section.Key("external_stores").MustString(""))
+
+	cfg.AnnotationExternalStores = make([]AnnotationStoreSettings, 0, len(names))
+	for _, name := range names {
+		storeSection := cfg.Raw.Section("annotations.store." + name)
+		store := AnnotationStoreSettings{
+			Name:              name,
+			Type:              storeSection.Key("type").MustString(""),
+			TenantID:          storeSection.Key("tenant_id").MustString(""),
+			BasicAuthUser:     storeSection.Key("basic_auth_user").MustString(""),
+			BasicAuthPassword: storeSection.Key("basic_auth_password").MustString(""),
+			Timeout:           storeSection.Key("timeout").MustDuration(10 * time.Second),
+			RouteTags:         util.SplitString(storeSection.Key("route_tags").MustString("")),
+			RouteTypes:        util.SplitString(storeSection.Key("route_types").MustString("")),
+		}
+
+		if store.Type != AnnotationStoreTypeLoki {
+			return fmt.Errorf("[annotations.store.%s] unsupported type %q, the supported types are: %s", name, store.Type, AnnotationStoreTypeLoki)
+		}
+		rawURL := storeSection.Key("url").MustString("")
+		if rawURL == "" {
+			return fmt.Errorf("[annotations.store.%s] url is required", name)
+		}
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return fmt.Errorf("[annotations.store.%s] invalid url: %w", name, err)
+		}
+		store.URL = u
+		for _, t := range store.RouteTypes {
+			if t != AnnotationRouteTypeAlert && t != AnnotationRouteTypeAnnotation {
+				return fmt.Errorf("[annotations.store.%s] unsupported route type %q, the supported types are: %s", name, t,
+					strings.Join([]string{AnnotationRouteTypeAlert, AnnotationRouteTypeAnnotation}, ", "))
+			}
+		}
+
+		cfg.AnnotationExternalStores = append(cfg.AnnotationExternalStores, store)
+	}
+	return nil
+}
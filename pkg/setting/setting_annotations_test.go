@@ -0,0 +1,63 @@
+package setting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/ini.v1"
+)
+
+func TestAnnotationStoreSettings(t *testing.T) {
+	load := func(t *testing.T, config string) (*Cfg, error) {
+		t.Helper()
+
+		f, err := ini.Load([]byte(config))
+		require.NoError(t, err)
+		cfg := NewCfg()
+		cfg.Raw = f
+		return cfg, cfg.readAnnotationStoreSettings()
+	}
+
+	t.Run("without external stores", func(t *testing.T) {
+		cfg, err := load(t, "")
+		require.NoError(t, err)
+		require.Empty(t, cfg.AnnotationExternalStores)
+	})
+
+	t.Run("with an external store", func(t *testing.T) {
+		cfg, err := load(t, `
+[annotations]
+external_stores = deploys
+
+[annotations.store.deploys]
+type = loki
+url = http://localhost:3100
+tenant_id = 1
+route_tags = deploy, release:prod
+route_types = annotation
+`)
+		require.NoError(t, err)
+		require.Len(t, cfg.AnnotationExternalStores, 1)
+
+		store := cfg.AnnotationExternalStores[0]
+		require.Equal(t, "deploys", store.Name)
+		require.Equal(t, AnnotationStoreTypeLoki, store.Type)
+		require.Equal(t, "http://localhost:3100", store.URL.String())
+		require.Equal(t, "1", store.TenantID)
+		require.Equal(t, 10*time.Second, store.Timeout)
+		require.Equal(t, []string{"deploy", "release:prod"}, store.RouteTags)
+		require.Equal(t, []string{AnnotationRouteTypeAnnotation}, store.RouteTypes)
+	})
+
+	t.Run("with invalid external stores", func(t *testing.T) {
+		for config, expected := range map[string]string{
+			"[annotations]\nexternal_stores = a\n[annotations.store.a]\nurl = http://localhost":                               "unsupported type",
+			"[annotations]\nexternal_stores = a\n[annotations.store.a]\ntype = loki":                                          "url is required",
+			"[annotations]\nexternal_stores = a\n[annotations.store.a]\ntype = loki\nurl = http://localhost\nroute_types = b": "unsupported route type",
+		} {
+			_, err := load(t, config)
+			require.ErrorContains(t, err, expected)
+		}
+	})
+}
@@ -20,6 +20,8 @@ const (
 	alertmanagerDefaultPeerTimeout        = 15 * time.Second
 	alertmanagerDefaultGossipInterval     = cluster.DefaultGossipInterval
 	alertmanagerDefaultPushPullInterval   = cluster.DefaultPushPullInterval
+	alertmanagerDefaultRedisPrefix        = "alertmanager"
+	schedulerDefaultMemberTimeout         = time.Minute
 	alertmanagerDefaultConfigPollInterval = time.Minute
 	// To start, the alertmanager needs at least one route defined.
 	// TODO: we should move this to Grafana settings and define this as the default.
@@ -69,6 +71,11 @@ type UnifiedAlertingSettings struct {
 	HAPeerTimeout                  time.Duration
 	HAGossipInterval               time.Duration
 	HAPushPullInterval             time.Duration
+	HARedisEnabled                 bool
+	HARedisPrefix                  string
+	HARuleShardingEnabled          bool
+	HAInstanceID                   string
+	HARuleShardingMemberTimeout    time.Duration
 	MaxAttempts                    int64
 	MinInterval                    time.Duration
 	EvaluationTimeout              time.Duration
@@ -212,6 +219,8 @@ func (cfg *Cfg) ReadUnifiedAlertingSettings(iniFile *ini.File) error {
 			uaCfg.HAPeers = append(uaCfg.HAPeers, peer)
 		}
 	}
+	uaCfg.HARedisEnabled = ua.Key("ha_redis_enabled").MustBool(false)
+	uaCfg.HARedisPrefix = ua.Key("ha_redis_prefix").MustString(alertmanagerDefaultRedisPrefix)
 
 	// TODO load from ini file
 	uaCfg.DefaultConfiguration = alertmanagerDefaultConfiguration
@@ -272,6 +281,19 @@ func (cfg *Cfg) ReadUnifiedAlertingSettings(iniFile *ini.File) error {
 	}
 	uaCfg.MinInterval = uaMinInterval
 
+	uaCfg.HARuleShardingEnabled = ua.Key("ha_rule_sharding_enabled").MustBool(false)
+	uaCfg.HAInstanceID = ua.Key("ha_instance_id").MustString(InstanceName)
+	if uaCfg.HAInstanceID == "" {
+		uaCfg.HAInstanceID = util.GenerateShortUID()
+	}
+	uaCfg.HARuleShardingMemberTimeout, err = gtime.ParseDuration(valueAsString(ua, "ha_rule_sharding_member_timeout", schedulerDefaultMemberTimeout.String()))
+	if err != nil {
+		return err
+	}
+	if uaCfg.HARuleShardingEnabled && uaCfg.HARuleShardingMemberTimeout < 2*uaCfg.BaseInterval {
+		return fmt.Errorf("value of setting 'ha_rule_sharding_member_timeout' should be at least twice the base interval (%v)", uaCfg.BaseInterval)
+	}
+
 	uaCfg.DefaultRuleEvaluationInterval = DefaultRuleEvaluationInterval
 	if uaMinInterval > uaCfg.DefaultRuleEvaluationInterval {
 		uaCfg.DefaultRuleEvaluationInterval = uaMinInterval
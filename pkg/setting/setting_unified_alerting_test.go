@@ -1,6 +1,7 @@
 package setting
 
 import (
+	"fmt"
 	"math/rand"
 	"strconv"
 	"testing"
@@ -146,6 +147,29 @@ func TestUnifiedAlertingSettings(t *testing.T) {
 				require.Equal(t, 120*time.Second, cfg.UnifiedAlerting.DefaultRuleEvaluationInterval)
 			},
 		},
+		{
+			desc: "when rule sharding is enabled, it should read the instance ID and the member timeout",
+			unifiedAlertingOptions: map[string]string{
+				"ha_rule_sharding_enabled":        "true",
+				"ha_instance_id":                  "grafana-1",
+				"ha_rule_sharding_member_timeout": "2m",
+			},
+			verifyCfg: func(t *testing.T, cfg Cfg) {
+				require.True(t, cfg.UnifiedAlerting.HARuleShardingEnabled)
+				require.Equal(t, "grafana-1", cfg.UnifiedAlerting.HAInstanceID)
+				require.Equal(t, 2*time.Minute, cfg.UnifiedAlerting.HARuleShardingMemberTimeout)
+			},
+		},
+		{
+			desc: "when rule sharding is disabled, the member timeout is not validated",
+			unifiedAlertingOptions: map[string]string{
+				"ha_rule_sharding_member_timeout": "5s",
+			},
+			verifyCfg: func(t *testing.T, cfg Cfg) {
+				require.False(t, cfg.UnifiedAlerting.HARuleShardingEnabled)
+				require.Equal(t, 5*time.Second, cfg.UnifiedAlerting.HARuleShardingMemberTimeout)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -172,6 +196,21 @@ func TestUnifiedAlertingSettings(t *testing.T) {
 	}
 }
 
+func TestHARuleShardingMemberTimeout(t *testing.T) {
+	f := ini.Empty()
+	cfg := NewCfg()
+	cfg.IsFeatureToggleEnabled = func(key string) bool { return false }
+	unifiedAlertingSec, err := f.NewSection("unified_alerting")
+	require.NoError(t, err)
+	_, err = unifiedAlertingSec.NewKey("ha_rule_sharding_enabled", "true")
+	require.NoError(t, err)
+	_, err = unifiedAlertingSec.NewKey("ha_rule_sharding_member_timeout", "15s")
+	require.NoError(t, err)
+
+	err = cfg.ReadUnifiedAlertingSettings(f)
+	require.EqualError(t, err, fmt.Sprintf("value of setting 'ha_rule_sharding_member_timeout' should be at least twice the base interval (%v)", SchedulerBaseInterval))
+}
+
 func TestMinInterval(t *testing.T) {
 	randPredicate := func(predicate func(dur time.Duration) bool) *time.Duration {
 		for {
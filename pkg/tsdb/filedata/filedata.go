@@ -0,0 +1,205 @@
+package filedata
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/datasource"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/instancemgmt"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/org"
+	"github.com/grafana/grafana/pkg/services/store"
+	"github.com/grafana/grafana/pkg/services/user"
+)
+
+var logger = log.New("tsdb.filedata")
+
+// defaultRoot is used when the datasource does not configure a folder. Files uploaded
+// through the storage API and the disk, SQL and git roots all live under it.
+const defaultRoot = store.RootContent
+
+var (
+	errMissingPath = errors.New("query has no file path")
+	errInvalidPath = errors.New("file path must be inside the datasource folder")
+)
+
+type Service struct {
+	im    instancemgmt.InstanceManager
+	store store.StorageService
+}
+
+var (
+	_ backend.QueryDataHandler   = (*Service)(nil)
+	_ backend.CheckHealthHandler = (*Service)(nil)
+)
+
+func ProvideService(storageService store.StorageService) *Service {
+	return &Service{
+		im:    datasource.NewInstanceManager(newInstanceSettings()),
+		store: storageService,
+	}
+}
+
+type datasourceInfo struct {
+	// Root is the storage folder that query paths are relative to, for example `content/datasets`.
+	Root string
+}
+
+type jsonData struct {
+	Path string `json:"path"`
+}
+
+func newInstanceSettings() datasource.InstanceFactoryFunc {
+	return func(settings backend.DataSourceInstanceSettings) (instancemgmt.Instance, error) {
+		var jd jsonData
+		if len(settings.JSONData) > 0 {
+			if err := json.Unmarshal(settings.JSONData, &jd); err != nil {
+				return nil, fmt.Errorf("failed to parse settings: %w", err)
+			}
+		}
+
+		root := strings.Trim(path.Clean("/"+jd.Path), "/")
+		if root == "" {
+			root = defaultRoot
+		}
+
+		return &datasourceInfo{Root: root}, nil
+	}
+}
+
+func (s *Service) QueryData(ctx context.Context, req *backend.QueryDataRequest) (*backend.QueryDataResponse, error) {
+	result := backend.NewQueryDataResponse()
+
+	dsInfo, err := s.getDSInfo(req.PluginContext)
+	if err != nil {
+		return result, err
+	}
+
+	u := storageUser(req.PluginContext)
+	for _, query := range req.Queries {
+		result.Responses[query.RefID] = s.executeQuery(ctx, u, dsInfo, query)
+	}
+
+	return result, nil
+}
+
+func (s *Service) executeQuery(ctx context.Context, u *user.SignedInUser, dsInfo *datasourceInfo, query backend.DataQuery) backend.DataResponse {
+	model, err := parseQueryModel(query.JSON)
+	if err != nil {
+		return backend.DataResponse{Error: err}
+	}
+
+	filePath, err := resolvePath(dsInfo.Root, model.Path)
+	if err != nil {
+		return backend.DataResponse{Error: err}
+	}
+
+	format := model.Format
+	if format == formatAuto {
+		format, err = formatFromExtension(filePath)
+		if err != nil {
+			return backend.DataResponse{Error: err}
+		}
+	}
+
+	file, err := s.store.Read(ctx, u, filePath)
+	if err != nil {
+		logger.FromContext(ctx).Warn("Failed to read file", "path", filePath, "error", err)
+		return backend.DataResponse{Error: fmt.Errorf("failed to read %s: %w", model.Path, err)}
+	}
+	if file == nil {
+		return backend.DataResponse{Error: fmt.Errorf("file not found: %s", model.Path)}
+	}
+
+	frame, err := readFrame(format, path.Base(filePath), file.Contents)
+	if err != nil {
+		return backend.DataResponse{Error: err}
+	}
+
+	timeField := detectTimeField(frame, model.TimeField)
+	if timeField >= 0 && !model.DisableTimeFilter {
+		frame = filterTimeRange(frame, timeField, query.TimeRange)
+	}
+
+	frame.RefID = query.RefID
+	frame.SetMeta(&data.FrameMeta{ExecutedQueryString: filePath})
+
+	return backend.DataResponse{Frames: data.Frames{frame}}
+}
+
+// resolvePath joins the query path to the datasource folder, and makes sure
+// the result does not escape it.
+func resolvePath(root string, filePath string) (string, error) {
+	if strings.TrimSpace(filePath) == "" {
+		return "", errMissingPath
+	}
+
+	resolved := path.Join(root, path.Clean("/"+filePath))
+	if !strings.HasPrefix(resolved, root+"/") {
+		return "", errInvalidPath
+	}
+	return resolved, nil
+}
+
+// storageUser is the identity used to read from the storage service. Reads are done as
+// a viewer of the datasource organization, so only folders that every member of the
+// organization may read can back a datasource.
+func storageUser(pluginCtx backend.PluginContext) *user.SignedInUser {
+	u := &user.SignedInUser{
+		OrgID:   pluginCtx.OrgID,
+		OrgRole: org.RoleViewer,
+	}
+	if pluginCtx.User != nil {
+		u.Login = pluginCtx.User.Login
+		u.Email = pluginCtx.User.Email
+		u.Name = pluginCtx.User.Name
+	}
+	return u
+}
+
+func (s *Service) CheckHealth(ctx context.Context, req *backend.CheckHealthRequest) (*backend.CheckHealthResult, error) {
+	dsInfo, err := s.getDSInfo(req.PluginContext)
+	if err != nil {
+		return nil, err
+	}
+
+	listing, err := s.store.List(ctx, storageUser(req.PluginContext), dsInfo.Root)
+	if err != nil {
+		return &backend.CheckHealthResult{
+			Status:  backend.HealthStatusError,
+			Message: fmt.Sprintf("Failed to list %s: %s", dsInfo.Root, err.Error()),
+		}, nil
+	}
+	if listing == nil {
+		return &backend.CheckHealthResult{
+			Status:  backend.HealthStatusError,
+			Message: fmt.Sprintf("Folder %s not found", dsInfo.Root),
+		}, nil
+	}
+
+	return &backend.CheckHealthResult{
+		Status:  backend.HealthStatusOk,
+		Message: fmt.Sprintf("Data source is working. %d entries found in %s", listing.Rows(), dsInfo.Root),
+	}, nil
+}
+
+func (s *Service) getDSInfo(pluginCtx backend.PluginContext) (*datasourceInfo, error) {
+	i, err := s.im.Get(pluginCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	instance, ok := i.(*datasourceInfo)
+	if !ok {
+		return nil, fmt.Errorf("failed to cast datasource info")
+	}
+
+	return instance, nil
+}
@@ -0,0 +1,232 @@
+package filedata
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/v8/parquet"
+	"github.com/apache/arrow/go/v8/parquet/file"
+	"github.com/apache/arrow/go/v8/parquet/schema"
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/instancemgmt"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/filestorage"
+	"github.com/grafana/grafana/pkg/services/store"
+	"github.com/grafana/grafana/pkg/services/user"
+)
+
+func TestQueryData(t *testing.T) {
+	files := map[string][]byte{
+		"content/datasets/deploys.csv": []byte("time,service,duration,ok\n" +
+			"2022-11-01T10:00:00Z,api,12.5,true\n" +
+			"2022-11-01T11:00:00Z,web,,false\n" +
+			"2022-11-03T11:00:00Z,api,3,true\n"),
+		"content/datasets/events.ndjson": []byte(`{"ts": "2022-11-01T10:00:00Z", "count": 3, "tags": ["a"]}
+{"ts": "2022-11-01T12:00:00Z", "count": 4, "level": "warn"}
+`),
+		"content/datasets/metrics.parquet": writeTestParquet(t),
+	}
+	fakeStore := &fakeStorageService{files: files}
+	service := &Service{
+		im:    fakeInstanceManager{dsInfo: &datasourceInfo{Root: "content/datasets"}},
+		store: fakeStore,
+	}
+	timeRange := backend.TimeRange{
+		From: time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2022, 11, 2, 0, 0, 0, 0, time.UTC),
+	}
+
+	query := func(t *testing.T, json string) backend.DataResponse {
+		t.Helper()
+		resp, err := service.QueryData(context.Background(), &backend.QueryDataRequest{
+			PluginContext: backend.PluginContext{OrgID: 2},
+			Queries:       []backend.DataQuery{{RefID: "A", TimeRange: timeRange, JSON: []byte(json)}},
+		})
+		require.NoError(t, err)
+		return resp.Responses["A"]
+	}
+
+	t.Run("CSV with inferred types and time filter", func(t *testing.T) {
+		res := query(t, `{"path": "deploys.csv"}`)
+		require.NoError(t, res.Error)
+		require.Len(t, res.Frames, 1)
+
+		frame := res.Frames[0]
+		require.Equal(t, "A", frame.RefID)
+		require.Equal(t, "content/datasets/deploys.csv", frame.Meta.ExecutedQueryString)
+		require.Equal(t, 2, frame.Rows())
+		require.Equal(t, data.FieldTypeNullableTime, frame.Fields[0].Type())
+		require.Equal(t, data.FieldTypeNullableString, frame.Fields[1].Type())
+		require.Equal(t, data.FieldTypeNullableFloat64, frame.Fields[2].Type())
+		require.Equal(t, data.FieldTypeNullableBool, frame.Fields[3].Type())
+		require.Nil(t, frame.Fields[2].At(1))
+
+		require.Equal(t, int64(2), fakeStore.lastUser.OrgID)
+	})
+
+	t.Run("CSV without time filter", func(t *testing.T) {
+		res := query(t, `{"path": "deploys.csv", "disableTimeFilter": true}`)
+		require.NoError(t, res.Error)
+		require.Equal(t, 3, res.Frames[0].Rows())
+	})
+
+	t.Run("NDJSON with columns from all lines", func(t *testing.T) {
+		res := query(t, `{"path": "events.ndjson"}`)
+		require.NoError(t, res.Error)
+
+		frame := res.Frames[0]
+		require.Equal(t, 2, frame.Rows())
+		names := make([]string, 0, len(frame.Fields))
+		for _, field := range frame.Fields {
+			names = append(names, field.Name)
+		}
+		require.Equal(t, []string{"count", "tags", "ts", "level"}, names)
+		require.Equal(t, data.FieldTypeNullableInt64, frame.Fields[0].Type())
+		tags := `["a"]`
+		require.Equal(t, &tags, frame.Fields[1].At(0))
+		require.Nil(t, frame.Fields[3].At(0))
+	})
+
+	t.Run("Parquet with a timestamp column", func(t *testing.T) {
+		res := query(t, `{"path": "metrics.parquet", "timeField": "ts"}`)
+		require.NoError(t, res.Error)
+
+		frame := res.Frames[0]
+		require.Equal(t, 2, frame.Rows())
+		require.Equal(t, data.FieldTypeNullableTime, frame.Fields[0].Type())
+		require.Equal(t, data.FieldTypeNullableString, frame.Fields[1].Type())
+		require.Equal(t, data.FieldTypeNullableFloat64, frame.Fields[2].Type())
+
+		ts := time.Date(2022, 11, 1, 10, 0, 0, 0, time.UTC)
+		host := "b"
+		require.Equal(t, &ts, frame.Fields[0].At(0))
+		require.Equal(t, &host, frame.Fields[1].At(1))
+		require.Nil(t, frame.Fields[2].At(1))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		require.ErrorIs(t, query(t, `{"path": ""}`).Error, errMissingPath)
+		require.ErrorIs(t, query(t, `{"path": "/"}`).Error, errInvalidPath)
+		require.ErrorContains(t, query(t, `{"path": "missing.csv"}`).Error, "file not found")
+		require.ErrorContains(t, query(t, `{"path": "deploys.txt"}`).Error, "unsupported file type")
+		require.ErrorContains(t, query(t, `{"path": "deploys.csv", "format": "xml"}`).Error, "unsupported format")
+	})
+}
+
+func TestResolvePath(t *testing.T) {
+	resolved, err := resolvePath("content/datasets", "/2022/../deploys.csv")
+	require.NoError(t, err)
+	require.Equal(t, "content/datasets/deploys.csv", resolved)
+
+	// cleaning the path keeps it inside the root
+	resolved, err = resolvePath("content/datasets", "../../../etc/passwd")
+	require.NoError(t, err)
+	require.Equal(t, "content/datasets/etc/passwd", resolved)
+
+	_, err = resolvePath("content/datasets", "/")
+	require.ErrorIs(t, err, errInvalidPath)
+}
+
+func TestCheckHealth(t *testing.T) {
+	listing := &store.StorageListFrame{Frame: data.NewFrame("", data.NewField("name", nil, []string{"a.csv", "b.csv"}))}
+
+	service := &Service{
+		im:    fakeInstanceManager{dsInfo: &datasourceInfo{Root: "content/datasets"}},
+		store: &fakeStorageService{listing: listing},
+	}
+	res, err := service.CheckHealth(context.Background(), &backend.CheckHealthRequest{})
+	require.NoError(t, err)
+	require.Equal(t, backend.HealthStatusOk, res.Status)
+	require.Equal(t, "Data source is working. 2 entries found in content/datasets", res.Message)
+
+	service.store = &fakeStorageService{}
+	res, err = service.CheckHealth(context.Background(), &backend.CheckHealthRequest{})
+	require.NoError(t, err)
+	require.Equal(t, backend.HealthStatusError, res.Status)
+}
+
+// writeTestParquet writes a file with a required timestamp, a required string
+// and an optional double column.
+func writeTestParquet(t *testing.T) []byte {
+	t.Helper()
+
+	tsNode, err := schema.NewPrimitiveNodeLogical("ts", parquet.Repetitions.Required,
+		schema.NewTimestampLogicalType(true, schema.TimeUnitMillis), parquet.Types.Int64, 0, -1)
+	require.NoError(t, err)
+	hostNode, err := schema.NewPrimitiveNodeLogical("host", parquet.Repetitions.Required,
+		schema.StringLogicalType{}, parquet.Types.ByteArray, 0, -1)
+	require.NoError(t, err)
+	valueNode, err := schema.NewPrimitiveNode("value", parquet.Repetitions.Optional, parquet.Types.Double, -1, -1)
+	require.NoError(t, err)
+	root, err := schema.NewGroupNode("schema", parquet.Repetitions.Required, schema.FieldList{tsNode, hostNode, valueNode}, -1)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	writer := file.NewParquetWriter(&buf, root)
+	rg := writer.AppendRowGroup()
+
+	day := time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC)
+	cw, err := rg.NextColumn()
+	require.NoError(t, err)
+	_, err = cw.(*file.Int64ColumnChunkWriter).WriteBatch([]int64{
+		day.Add(10 * time.Hour).UnixMilli(),
+		day.Add(11 * time.Hour).UnixMilli(),
+		day.Add(50 * time.Hour).UnixMilli(),
+	}, nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, cw.Close())
+
+	cw, err = rg.NextColumn()
+	require.NoError(t, err)
+	_, err = cw.(*file.ByteArrayColumnChunkWriter).WriteBatch([]parquet.ByteArray{
+		parquet.ByteArray("a"), parquet.ByteArray("b"), parquet.ByteArray("c"),
+	}, nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, cw.Close())
+
+	cw, err = rg.NextColumn()
+	require.NoError(t, err)
+	_, err = cw.(*file.Float64ColumnChunkWriter).WriteBatch([]float64{1.5, 3}, []int16{1, 0, 1}, nil)
+	require.NoError(t, err)
+	require.NoError(t, cw.Close())
+
+	require.NoError(t, rg.Close())
+	require.NoError(t, writer.Close())
+	return buf.Bytes()
+}
+
+type fakeStorageService struct {
+	store.StorageService
+	files    map[string][]byte
+	listing  *store.StorageListFrame
+	lastUser *user.SignedInUser
+}
+
+func (s *fakeStorageService) Read(_ context.Context, u *user.SignedInUser, path string) (*filestorage.File, error) {
+	s.lastUser = u
+	contents, ok := s.files[path]
+	if !ok {
+		return nil, nil
+	}
+	return &filestorage.File{Contents: contents}, nil
+}
+
+func (s *fakeStorageService) List(_ context.Context, _ *user.SignedInUser, _ string) (*store.StorageListFrame, error) {
+	return s.listing, nil
+}
+
+type fakeInstanceManager struct {
+	dsInfo *datasourceInfo
+}
+
+func (m fakeInstanceManager) Get(_ backend.PluginContext) (instancemgmt.Instance, error) {
+	return m.dsInfo, nil
+}
+
+func (m fakeInstanceManager) Do(_ backend.PluginContext, _ instancemgmt.InstanceCallbackFunc) error {
+	return nil
+}
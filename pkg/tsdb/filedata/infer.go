@@ -0,0 +1,156 @@
+package filedata
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+// timeLayouts are the string formats that are recognized as times.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02",
+}
+
+// inferField picks the most specific type that fits all non-null values: boolean,
+// integer, float, time and finally string. Values are either strings, as read from
+// CSV files, or decoded JSON values.
+func inferField(name string, values []interface{}) *data.Field {
+	switch {
+	case allValues(values, isBool):
+		vector := make([]*bool, len(values))
+		for i, v := range values {
+			if b, ok := toBool(v); ok {
+				vector[i] = &b
+			}
+		}
+		return data.NewField(name, nil, vector)
+	case allValues(values, isInt):
+		vector := make([]*int64, len(values))
+		for i, v := range values {
+			if n, ok := toInt(v); ok {
+				vector[i] = &n
+			}
+		}
+		return data.NewField(name, nil, vector)
+	case allValues(values, isFloat):
+		vector := make([]*float64, len(values))
+		for i, v := range values {
+			if f, ok := toFloat(v); ok {
+				vector[i] = &f
+			}
+		}
+		return data.NewField(name, nil, vector)
+	case allValues(values, isTime):
+		vector := make([]*time.Time, len(values))
+		for i, v := range values {
+			if t, ok := toTime(v); ok {
+				vector[i] = &t
+			}
+		}
+		return data.NewField(name, nil, vector)
+	default:
+		vector := make([]*string, len(values))
+		for i, v := range values {
+			if s, ok := toString(v); ok {
+				vector[i] = &s
+			}
+		}
+		return data.NewField(name, nil, vector)
+	}
+}
+
+// allValues reports whether all non-null values match, and at least one value is not null.
+func allValues(values []interface{}, match func(interface{}) bool) bool {
+	found := false
+	for _, v := range values {
+		if v == nil {
+			continue
+		}
+		if !match(v) {
+			return false
+		}
+		found = true
+	}
+	return found
+}
+
+func isBool(v interface{}) bool  { _, ok := toBool(v); return ok }
+func isInt(v interface{}) bool   { _, ok := toInt(v); return ok }
+func isFloat(v interface{}) bool { _, ok := toFloat(v); return ok }
+func isTime(v interface{}) bool  { _, ok := toTime(v); return ok }
+
+func toBool(v interface{}) (bool, bool) {
+	switch value := v.(type) {
+	case bool:
+		return value, true
+	case string:
+		switch strings.ToLower(value) {
+		case "true":
+			return true, true
+		case "false":
+			return false, true
+		}
+	}
+	return false, false
+}
+
+func toInt(v interface{}) (int64, bool) {
+	switch value := v.(type) {
+	case float64:
+		if value == float64(int64(value)) {
+			return int64(value), true
+		}
+	case string:
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return n, true
+		}
+	}
+	return 0, false
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch value := v.(type) {
+	case float64:
+		return value, true
+	case string:
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f, true
+		}
+	}
+	return 0, false
+}
+
+func toTime(v interface{}) (time.Time, bool) {
+	value, ok := v.(string)
+	if !ok {
+		return time.Time{}, false
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// toString returns strings as they are, other values are JSON encoded.
+func toString(v interface{}) (string, bool) {
+	switch value := v.(type) {
+	case nil:
+		return "", false
+	case string:
+		return value, true
+	default:
+		b, err := json.Marshal(value)
+		if err != nil {
+			return "", false
+		}
+		return string(b), true
+	}
+}
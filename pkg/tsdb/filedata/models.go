@@ -0,0 +1,57 @@
+package filedata
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+)
+
+type fileFormat string
+
+const (
+	formatAuto    fileFormat = ""
+	formatCSV     fileFormat = "csv"
+	formatNDJSON  fileFormat = "ndjson"
+	formatParquet fileFormat = "parquet"
+)
+
+type queryModel struct {
+	// Path of the file, relative to the datasource folder.
+	Path string `json:"path"`
+	// Format is detected from the file extension when empty.
+	Format fileFormat `json:"format"`
+	// TimeField names the column used for time range filtering. When empty,
+	// the first time column is used.
+	TimeField string `json:"timeField"`
+	// DisableTimeFilter returns all rows regardless of the query time range.
+	DisableTimeFilter bool `json:"disableTimeFilter"`
+}
+
+func parseQueryModel(raw json.RawMessage) (*queryModel, error) {
+	model := &queryModel{}
+	if err := json.Unmarshal(raw, model); err != nil {
+		return nil, fmt.Errorf("failed to parse query: %w", err)
+	}
+
+	switch model.Format {
+	case formatAuto, formatCSV, formatNDJSON, formatParquet:
+	default:
+		return nil, fmt.Errorf("unsupported format: %s", model.Format)
+	}
+
+	return model, nil
+}
+
+func formatFromExtension(filePath string) (fileFormat, error) {
+	switch strings.ToLower(path.Ext(filePath)) {
+	case ".csv":
+		return formatCSV, nil
+	case ".ndjson", ".jsonl":
+		return formatNDJSON, nil
+	case ".parquet":
+		return formatParquet, nil
+	default:
+		return formatAuto, fmt.Errorf("unsupported file type: %s", path.Base(filePath))
+	}
+}
@@ -0,0 +1,183 @@
+package filedata
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/apache/arrow/go/v8/parquet"
+	"github.com/apache/arrow/go/v8/parquet/file"
+	"github.com/apache/arrow/go/v8/parquet/schema"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+// parquetBatchSize is the number of values read from a column chunk at a time.
+const parquetBatchSize = 1024
+
+// readParquet reads the flat columns of a Parquet file. Nested and repeated
+// columns are not supported. Optional columns become nullable fields.
+func readParquet(name string, contents []byte) (*data.Frame, error) {
+	reader, err := file.NewParquetReader(bytes.NewReader(contents))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open Parquet file: %w", err)
+	}
+	defer func() { _ = reader.Close() }()
+
+	sc := reader.MetaData().Schema
+	frame := data.NewFrame(name)
+	for i := 0; i < sc.NumColumns(); i++ {
+		col := sc.Column(i)
+		if col.MaxRepetitionLevel() > 0 || len(col.ColumnPath()) > 1 {
+			return nil, fmt.Errorf("column %q: nested and repeated columns are not supported", col.Path())
+		}
+
+		field, err := newParquetField(col)
+		if err != nil {
+			return nil, err
+		}
+		frame.Fields = append(frame.Fields, field)
+	}
+
+	for rg := 0; rg < reader.NumRowGroups(); rg++ {
+		rowGroup := reader.RowGroup(rg)
+		for i, field := range frame.Fields {
+			// RowGroupReader.Column panics when the pages can't be read, so the
+			// column reader is built from its page reader instead.
+			pages, err := rowGroup.GetColumnPageReader(i)
+			if err != nil {
+				return nil, fmt.Errorf("column %q: %w", field.Name, err)
+			}
+			chunk := file.NewColumnReader(sc.Column(i), pages, memory.DefaultAllocator)
+			if err := readParquetColumn(chunk, sc.Column(i), field); err != nil {
+				return nil, fmt.Errorf("column %q: %w", field.Name, err)
+			}
+		}
+	}
+
+	return frame, nil
+}
+
+// newParquetField returns an empty field with the type the column is read as.
+func newParquetField(col *schema.Column) (*data.Field, error) {
+	name := col.Name()
+	switch col.PhysicalType() {
+	case parquet.Types.Boolean:
+		return data.NewField(name, nil, []*bool{}), nil
+	case parquet.Types.Int32:
+		if _, ok := col.LogicalType().(schema.DateLogicalType); ok {
+			return data.NewField(name, nil, []*time.Time{}), nil
+		}
+		return data.NewField(name, nil, []*int64{}), nil
+	case parquet.Types.Int64:
+		if _, ok := col.LogicalType().(*schema.TimestampLogicalType); ok {
+			return data.NewField(name, nil, []*time.Time{}), nil
+		}
+		return data.NewField(name, nil, []*int64{}), nil
+	case parquet.Types.Int96:
+		return data.NewField(name, nil, []*time.Time{}), nil
+	case parquet.Types.Float, parquet.Types.Double:
+		return data.NewField(name, nil, []*float64{}), nil
+	case parquet.Types.ByteArray:
+		return data.NewField(name, nil, []*string{}), nil
+	default:
+		return nil, fmt.Errorf("column %q: unsupported type %s", name, col.PhysicalType())
+	}
+}
+
+func readParquetColumn(chunk file.ColumnChunkReader, col *schema.Column, field *data.Field) error {
+	maxDefLvl := col.MaxDefinitionLevel()
+
+	switch r := chunk.(type) {
+	case *file.BooleanColumnChunkReader:
+		return readColumnValues(r.ReadBatch, maxDefLvl, func(v *bool) { field.Append(v) })
+	case *file.Int32ColumnChunkReader:
+		if _, ok := col.LogicalType().(schema.DateLogicalType); ok {
+			return readColumnValues(r.ReadBatch, maxDefLvl, func(v *int32) {
+				field.Append(mapValue(v, func(days int32) time.Time {
+					return time.Unix(int64(days)*24*60*60, 0).UTC()
+				}))
+			})
+		}
+		return readColumnValues(r.ReadBatch, maxDefLvl, func(v *int32) {
+			field.Append(mapValue(v, func(n int32) int64 { return int64(n) }))
+		})
+	case *file.Int64ColumnChunkReader:
+		if ts, ok := col.LogicalType().(*schema.TimestampLogicalType); ok {
+			unit := ts.TimeUnit()
+			return readColumnValues(r.ReadBatch, maxDefLvl, func(v *int64) {
+				field.Append(mapValue(v, func(n int64) time.Time { return timestampToTime(n, unit) }))
+			})
+		}
+		return readColumnValues(r.ReadBatch, maxDefLvl, func(v *int64) { field.Append(v) })
+	case *file.Int96ColumnChunkReader:
+		// Int96 is the legacy timestamp encoding used by Impala and older Spark versions
+		return readColumnValues(r.ReadBatch, maxDefLvl, func(v *parquet.Int96) {
+			field.Append(mapValue(v, func(n parquet.Int96) time.Time { return n.ToTime().UTC() }))
+		})
+	case *file.Float32ColumnChunkReader:
+		return readColumnValues(r.ReadBatch, maxDefLvl, func(v *float32) {
+			field.Append(mapValue(v, func(f float32) float64 { return float64(f) }))
+		})
+	case *file.Float64ColumnChunkReader:
+		return readColumnValues(r.ReadBatch, maxDefLvl, func(v *float64) { field.Append(v) })
+	case *file.ByteArrayColumnChunkReader:
+		return readColumnValues(r.ReadBatch, maxDefLvl, func(v *parquet.ByteArray) {
+			field.Append(mapValue(v, func(b parquet.ByteArray) string { return string(b) }))
+		})
+	default:
+		return fmt.Errorf("unsupported column reader %T", chunk)
+	}
+}
+
+type readBatchFunc[T any] func(batchSize int64, values []T, defLvls, repLvls []int16) (int64, int, error)
+
+// readColumnValues reads all values of a column chunk and calls appendValue for
+// each row, with nil for null values. Values are only returned for the rows
+// that are defined, so they are matched up with the definition levels.
+func readColumnValues[T any](readBatch readBatchFunc[T], maxDefLvl int16, appendValue func(*T)) error {
+	values := make([]T, parquetBatchSize)
+	defLvls := make([]int16, parquetBatchSize)
+	for {
+		total, read, err := readBatch(parquetBatchSize, values, defLvls, nil)
+		if err != nil {
+			return err
+		}
+		if total == 0 {
+			return nil
+		}
+
+		next := 0
+		for i := int64(0); i < total; i++ {
+			if maxDefLvl > 0 && defLvls[i] < maxDefLvl {
+				appendValue(nil)
+				continue
+			}
+			if next >= read {
+				return fmt.Errorf("column has fewer values than rows")
+			}
+			v := values[next]
+			appendValue(&v)
+			next++
+		}
+	}
+}
+
+func mapValue[T, R any](v *T, convert func(T) R) *R {
+	if v == nil {
+		return nil
+	}
+	r := convert(*v)
+	return &r
+}
+
+func timestampToTime(n int64, unit schema.TimeUnitType) time.Time {
+	switch unit {
+	case schema.TimeUnitMillis:
+		return time.UnixMilli(n).UTC()
+	case schema.TimeUnitMicros:
+		return time.UnixMicro(n).UTC()
+	default:
+		return time.Unix(0, n).UTC()
+	}
+}
@@ -0,0 +1,123 @@
+package filedata
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+func readFrame(format fileFormat, name string, contents []byte) (*data.Frame, error) {
+	switch format {
+	case formatCSV:
+		return readCSV(name, contents)
+	case formatNDJSON:
+		return readNDJSON(name, contents)
+	case formatParquet:
+		return readParquet(name, contents)
+	default:
+		return nil, fmt.Errorf("unsupported format: %s", format)
+	}
+}
+
+// readCSV reads a CSV file with a header line. Empty cells are read as nulls.
+func readCSV(name string, contents []byte) (*data.Frame, error) {
+	reader := csv.NewReader(bytes.NewReader(contents))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header line: %w", err)
+	}
+
+	columns := make([][]interface{}, len(header))
+	rows := 0
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read line: %w", err)
+		}
+		if len(record) > len(header) {
+			return nil, fmt.Errorf("line %d has %d values, but the header has %d", rows+2, len(record), len(header))
+		}
+
+		for i := range header {
+			var value interface{}
+			if i < len(record) {
+				if v := strings.TrimSpace(record[i]); v != "" {
+					value = v
+				}
+			}
+			columns[i] = append(columns[i], value)
+		}
+		rows++
+	}
+
+	frame := data.NewFrame(name)
+	for i, column := range columns {
+		if column == nil {
+			column = []interface{}{}
+		}
+		frame.Fields = append(frame.Fields, inferField(strings.TrimSpace(header[i]), column))
+	}
+	return frame, nil
+}
+
+// readNDJSON reads a file with one JSON object per line. The columns are the
+// keys of all objects, in the order they are first seen; keys first seen on
+// the same line are sorted.
+func readNDJSON(name string, contents []byte) (*data.Frame, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	scanner.Buffer(make([]byte, 64*1024), len(contents)+1)
+
+	var names []string
+	columns := map[string][]interface{}{}
+	rows := 0
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var object map[string]interface{}
+		if err := json.Unmarshal(line, &object); err != nil {
+			return nil, fmt.Errorf("line %d is not a JSON object: %w", rows+1, err)
+		}
+
+		var newKeys []string
+		for key := range object {
+			if _, ok := columns[key]; !ok {
+				newKeys = append(newKeys, key)
+			}
+		}
+		sort.Strings(newKeys)
+		for _, key := range newKeys {
+			names = append(names, key)
+			columns[key] = make([]interface{}, rows)
+		}
+		for _, key := range names {
+			columns[key] = append(columns[key], object[key])
+		}
+		rows++
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	frame := data.NewFrame(name)
+	for _, key := range names {
+		frame.Fields = append(frame.Fields, inferField(key, columns[key]))
+	}
+	return frame, nil
+}
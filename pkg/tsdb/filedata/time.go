@@ -0,0 +1,46 @@
+package filedata
+
+import (
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+// detectTimeField returns the index of the named time field, or of the first
+// time field when no name is given. It returns -1 when there is no such field.
+func detectTimeField(frame *data.Frame, name string) int {
+	for i, field := range frame.Fields {
+		if name != "" && field.Name != name {
+			continue
+		}
+		switch field.Type() {
+		case data.FieldTypeTime, data.FieldTypeNullableTime:
+			return i
+		}
+	}
+	return -1
+}
+
+// filterTimeRange returns a copy of the frame with the rows whose time is
+// inside the time range. Rows with a null time are dropped.
+func filterTimeRange(frame *data.Frame, timeField int, timeRange backend.TimeRange) *data.Frame {
+	field := frame.Fields[timeField]
+
+	filtered := frame.EmptyCopy()
+	for row := 0; row < field.Len(); row++ {
+		t, ok := field.ConcreteAt(row)
+		if !ok {
+			continue
+		}
+		if !inTimeRange(t.(time.Time), timeRange) {
+			continue
+		}
+		filtered.AppendRow(frame.RowCopy(row)...)
+	}
+	return filtered
+}
+
+func inTimeRange(t time.Time, timeRange backend.TimeRange) bool {
+	return !t.Before(timeRange.From) && !t.After(timeRange.To)
+}
@@ -0,0 +1,79 @@
+package grafanads
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/services/annotations"
+)
+
+// maxAnnotationBuckets is the maximum number of time buckets of an annotations query, the interval of the query is
+// increased to stay below it.
+const maxAnnotationBuckets = 1000
+
+func (s *Service) doAnnotationsQuery(ctx context.Context, req *backend.QueryDataRequest, query backend.DataQuery) backend.DataResponse {
+	m := requestModel{}
+	if err := json.Unmarshal(query.JSON, &m); err != nil {
+		return backend.DataResponse{Error: err}
+	}
+
+	if req.PluginContext.User == nil {
+		return backend.DataResponse{Error: errors.New("auth error")}
+	}
+	signedInUser, err := s.users.SignedInUser(ctx, req.PluginContext.User, req.PluginContext.OrgID)
+	if err != nil {
+		return backend.DataResponse{Error: err}
+	}
+
+	from, to := query.TimeRange.From.UnixMilli(), query.TimeRange.To.UnixMilli()
+	buckets, err := s.annotationsRepo.Aggregate(ctx, &annotations.AggregateQuery{
+		ItemQuery: annotations.ItemQuery{
+			OrgId:        req.PluginContext.OrgID,
+			From:         from,
+			To:           to,
+			DashboardId:  m.Annotations.DashboardID,
+			Type:         m.Annotations.Type,
+			Tags:         m.Annotations.Tags,
+			MatchAny:     m.Annotations.MatchAny,
+			Text:         m.Annotations.Text,
+			TagRegex:     m.Annotations.TagRegex,
+			SignedInUser: signedInUser,
+		},
+		Interval: annotationsInterval(query.Interval, from, to),
+	})
+	if err != nil {
+		return backend.DataResponse{Error: err}
+	}
+
+	times := make([]time.Time, 0, len(buckets))
+	tags := make([]string, 0, len(buckets))
+	counts := make([]int64, 0, len(buckets))
+	for _, bucket := range buckets {
+		times = append(times, time.UnixMilli(bucket.Time))
+		tags = append(tags, bucket.Tag)
+		counts = append(counts, bucket.Count)
+	}
+	frame := data.NewFrame("annotations",
+		data.NewField("time", nil, times),
+		data.NewField("tag", nil, tags),
+		data.NewField("count", nil, counts),
+	).SetMeta(&data.FrameMeta{Type: data.FrameTypeTimeSeriesLong})
+	return backend.DataResponse{Frames: data.Frames{frame}}
+}
+
+// annotationsInterval returns the interval of the time buckets of an annotations query in milliseconds.
+func annotationsInterval(interval time.Duration, from int64, to int64) int64 {
+	ms := interval.Milliseconds()
+	if ms < 1 {
+		ms = 1
+	}
+	if min := (to - from + maxAnnotationBuckets - 1) / maxAnnotationBuckets; ms < min {
+		ms = min
+	}
+	return ms
+}
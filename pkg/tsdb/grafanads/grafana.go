@@ -11,9 +11,14 @@ import (
 	"github.com/grafana/grafana-plugin-sdk-go/data"
 	"github.com/grafana/grafana/pkg/components/simplejson"
 	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/annotations"
 	"github.com/grafana/grafana/pkg/services/datasources"
+	"github.com/grafana/grafana/pkg/services/org"
 	"github.com/grafana/grafana/pkg/services/searchV2"
 	"github.com/grafana/grafana/pkg/services/store"
+	"github.com/grafana/grafana/pkg/services/user"
+	"github.com/grafana/grafana/pkg/setting"
 	"github.com/grafana/grafana/pkg/tsdb/testdatasource"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -50,15 +55,21 @@ var (
 	)
 )
 
-func ProvideService(search searchV2.SearchService, store store.StorageService) *Service {
-	return newService(search, store)
+func ProvideService(cfg *setting.Cfg, search searchV2.SearchService, store store.StorageService, annotationsRepo annotations.Repository,
+	userService user.Service, orgService org.Service, ac accesscontrol.Service) *Service {
+	return newService(cfg, search, store, annotationsRepo, userService, orgService, ac)
 }
 
-func newService(search searchV2.SearchService, store store.StorageService) *Service {
+func newService(cfg *setting.Cfg, search searchV2.SearchService, store store.StorageService, annotationsRepo annotations.Repository,
+	userService user.Service, orgService org.Service, ac accesscontrol.Service) *Service {
+	logger := log.New("grafanads")
 	s := &Service{
-		search: search,
-		store:  store,
-		log:    log.New("grafanads"),
+		cfg:             cfg,
+		search:          search,
+		store:           store,
+		annotationsRepo: annotationsRepo,
+		log:             logger,
+		users:           searchV2.NewSignedInUserResolver(cfg, ac, orgService, userService, logger),
 	}
 
 	return s
@@ -66,9 +77,12 @@ func newService(search searchV2.SearchService, store store.StorageService) *Serv
 
 // Service exists regardless of user settings
 type Service struct {
-	search searchV2.SearchService
-	store  store.StorageService
-	log    log.Logger
+	cfg             *setting.Cfg
+	search          searchV2.SearchService
+	store           store.StorageService
+	annotationsRepo annotations.Repository
+	log             log.Logger
+	users           *searchV2.SignedInUserResolver
 }
 
 func DataSourceModel(orgId int64) *datasources.DataSource {
@@ -96,6 +110,8 @@ func (s *Service) QueryData(ctx context.Context, req *backend.QueryDataRequest)
 			response.Responses[q.RefID] = s.doReadQuery(ctx, q)
 		case queryTypeSearch:
 			response.Responses[q.RefID] = s.doSearchQuery(ctx, req, q)
+		case queryTypeAnnotations:
+			response.Responses[q.RefID] = s.doAnnotationsQuery(ctx, req, q)
 		default:
 			response.Responses[q.RefID] = backend.DataResponse{
 				Error: fmt.Errorf("unknown query type"),
@@ -197,6 +213,7 @@ func (s *Service) doSearchQuery(ctx context.Context, req *backend.QueryDataReque
 }
 
 type requestModel struct {
-	QueryType string                  `json:"queryType"`
-	Search    searchV2.DashboardQuery `json:"search,omitempty"`
+	QueryType   string                  `json:"queryType"`
+	Search      searchV2.DashboardQuery `json:"search,omitempty"`
+	Annotations annotationsQueryModel   `json:"annotations,omitempty"`
 }
@@ -14,6 +14,9 @@ const (
 	// currently only .csv files are supported,
 	// other file types will eventually be supported (parquet, etc)
 	queryTypeRead = "read"
+
+	// queryTypeAnnotations returns the number of annotations per time bucket and tag
+	queryTypeAnnotations = "annotations"
 )
 
 type listQueryModel struct {
@@ -22,3 +25,12 @@ type listQueryModel struct {
 type readQueryModel struct {
 	Path string `json:"path"`
 }
+
+type annotationsQueryModel struct {
+	Text        string   `json:"text,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	MatchAny    bool     `json:"matchAny,omitempty"`
+	TagRegex    string   `json:"tagRegex,omitempty"`
+	Type        string   `json:"type,omitempty"`
+	DashboardID int64    `json:"dashboardId,omitempty"`
+}
@@ -0,0 +1,193 @@
+package jsonapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+// buildFrame extracts every configured field from the document. All fields
+// must select the same number of values, each value becomes one row.
+func buildFrame(name string, doc interface{}, fields []fieldSettings) (*data.Frame, error) {
+	frame := data.NewFrame(name)
+	rows := -1
+
+	for _, settings := range fields {
+		path, err := compileJSONPath(settings.JSONPath)
+		if err != nil {
+			return nil, err
+		}
+
+		values := path.evaluate(doc)
+		if rows >= 0 && len(values) != rows {
+			return nil, fmt.Errorf("field %q has %d values, expected %d", fieldName(settings), len(values), rows)
+		}
+		rows = len(values)
+
+		field, err := newField(settings, values)
+		if err != nil {
+			return nil, err
+		}
+		frame.Fields = append(frame.Fields, field)
+	}
+
+	return frame, nil
+}
+
+func fieldName(settings fieldSettings) string {
+	if settings.Name != "" {
+		return settings.Name
+	}
+	return settings.JSONPath
+}
+
+func newField(settings fieldSettings, values []interface{}) (*data.Field, error) {
+	typ := settings.Type
+	if typ == fieldTypeAuto {
+		typ = detectFieldType(values)
+	}
+
+	name := fieldName(settings)
+	switch typ {
+	case fieldTypeNumber:
+		vector := make([]*float64, len(values))
+		for i, value := range values {
+			v, err := toNumber(value)
+			if err != nil {
+				return nil, fmt.Errorf("field %q: %w", name, err)
+			}
+			vector[i] = v
+		}
+		return data.NewField(name, nil, vector), nil
+	case fieldTypeBoolean:
+		vector := make([]*bool, len(values))
+		for i, value := range values {
+			v, err := toBoolean(value)
+			if err != nil {
+				return nil, fmt.Errorf("field %q: %w", name, err)
+			}
+			vector[i] = v
+		}
+		return data.NewField(name, nil, vector), nil
+	case fieldTypeTime:
+		vector := make([]*time.Time, len(values))
+		for i, value := range values {
+			v, err := toTime(value)
+			if err != nil {
+				return nil, fmt.Errorf("field %q: %w", name, err)
+			}
+			vector[i] = v
+		}
+		return data.NewField(name, nil, vector), nil
+	default:
+		vector := make([]*string, len(values))
+		for i, value := range values {
+			v, err := toString(value)
+			if err != nil {
+				return nil, fmt.Errorf("field %q: %w", name, err)
+			}
+			vector[i] = v
+		}
+		return data.NewField(name, nil, vector), nil
+	}
+}
+
+// detectFieldType picks the field type from the first non-null value. Strings
+// are treated as times when they are RFC 3339 timestamps.
+func detectFieldType(values []interface{}) fieldType {
+	for _, value := range values {
+		switch v := value.(type) {
+		case nil:
+			continue
+		case float64:
+			return fieldTypeNumber
+		case bool:
+			return fieldTypeBoolean
+		case string:
+			if _, err := time.Parse(time.RFC3339Nano, v); err == nil {
+				return fieldTypeTime
+			}
+			return fieldTypeString
+		default:
+			return fieldTypeString
+		}
+	}
+	return fieldTypeString
+}
+
+func toNumber(value interface{}) (*float64, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case float64:
+		return &v, nil
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", v)
+		}
+		return &f, nil
+	default:
+		return nil, fmt.Errorf("unexpected value of type %T for a number", value)
+	}
+}
+
+func toBoolean(value interface{}) (*bool, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case bool:
+		return &v, nil
+	case string:
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a boolean", v)
+		}
+		return &b, nil
+	default:
+		return nil, fmt.Errorf("unexpected value of type %T for a boolean", value)
+	}
+}
+
+// toTime converts numbers as epoch milliseconds, and strings as RFC 3339
+// timestamps or epoch milliseconds.
+func toTime(value interface{}) (*time.Time, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case float64:
+		t := time.UnixMilli(int64(v)).UTC()
+		return &t, nil
+	case string:
+		if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+			return &t, nil
+		}
+		if ms, err := strconv.ParseInt(v, 10, 64); err == nil {
+			t := time.UnixMilli(ms).UTC()
+			return &t, nil
+		}
+		return nil, fmt.Errorf("%q is not a time", v)
+	default:
+		return nil, fmt.Errorf("unexpected value of type %T for a time", value)
+	}
+}
+
+// toString returns strings as they are, other values are JSON encoded.
+func toString(value interface{}) (*string, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return &v, nil
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		s := string(b)
+		return &s, nil
+	}
+}
@@ -0,0 +1,39 @@
+package jsonapi
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+)
+
+// interpolateTimeRange replaces the time range variables in the URL path,
+// query parameters and body of a query. The variables use the same names
+// and formats as the dashboard variables of the frontend:
+//
+//	$__from, ${__from}                  epoch milliseconds
+//	${__from:date:seconds}              epoch seconds
+//	${__from:date:iso}                  RFC 3339
+//
+// and the same for $__to.
+func interpolateTimeRange(text string, timeRange backend.TimeRange) string {
+	if !strings.Contains(text, "__from") && !strings.Contains(text, "__to") {
+		return text
+	}
+
+	replacer := strings.NewReplacer(timeRangeReplacements("__from", timeRange.From)...)
+	text = replacer.Replace(text)
+	replacer = strings.NewReplacer(timeRangeReplacements("__to", timeRange.To)...)
+	return replacer.Replace(text)
+}
+
+func timeRangeReplacements(name string, t time.Time) []string {
+	ms := strconv.FormatInt(t.UnixMilli(), 10)
+	return []string{
+		"${" + name + ":date:seconds}", strconv.FormatInt(t.Unix(), 10),
+		"${" + name + ":date:iso}", t.UTC().Format(time.RFC3339),
+		"${" + name + "}", ms,
+		"$" + name, ms,
+	}
+}
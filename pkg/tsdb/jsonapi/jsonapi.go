@@ -0,0 +1,317 @@
+package jsonapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/datasource"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/instancemgmt"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/infra/httpclient"
+	"github.com/grafana/grafana/pkg/infra/log"
+)
+
+var logger = log.New("tsdb.jsonapi")
+
+// maxResponseSize is the largest response body the datasource will read.
+const maxResponseSize = 32 * 1024 * 1024
+
+type Service struct {
+	im instancemgmt.InstanceManager
+}
+
+var (
+	_ backend.QueryDataHandler   = (*Service)(nil)
+	_ backend.CheckHealthHandler = (*Service)(nil)
+)
+
+func ProvideService(httpClientProvider httpclient.Provider) *Service {
+	return &Service{
+		im: datasource.NewInstanceManager(newInstanceSettings(httpClientProvider)),
+	}
+}
+
+type datasourceInfo struct {
+	HTTPClient *http.Client
+	URL        string
+	// HealthCheckPath is requested by CheckHealth, relative to URL.
+	HealthCheckPath string
+	// ConfiguredHeaders are the canonical names of the custom headers of the datasource, which queries can't set.
+	ConfiguredHeaders map[string]bool
+}
+
+// allowedHeaders are the standard headers queries can set. The other standard headers carry credentials, such as
+// Authorization and Cookie, or change how the request is routed, such as Host, so queries can't set them.
+var allowedHeaders = map[string]bool{
+	"Accept":              true,
+	"Accept-Language":     true,
+	"Cache-Control":       true,
+	"Content-Type":        true,
+	"If-Match":            true,
+	"If-Modified-Since":   true,
+	"If-None-Match":       true,
+	"If-Unmodified-Since": true,
+	"Pragma":              true,
+}
+
+// reservedHeaderPrefixes are the prefixes of the custom headers queries can't set, because Grafana and proxies use
+// them to identify the user and the origin of the request.
+var reservedHeaderPrefixes = []string{"X-Grafana-", "X-Forwarded-", "X-Real-Ip", "X-Id-Token", "X-Original-", "X-Proxy-"}
+
+// queryCanSetHeader returns whether queries can set a header: one of the allowed standard headers, or a custom
+// X- header that isn't reserved. The name is canonical.
+func queryCanSetHeader(name string) bool {
+	if allowedHeaders[name] {
+		return true
+	}
+	if !strings.HasPrefix(name, "X-") {
+		return false
+	}
+	for _, prefix := range reservedHeaderPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+type jsonData struct {
+	HealthCheckPath string `json:"healthCheckPath"`
+}
+
+func newInstanceSettings(httpClientProvider httpclient.Provider) datasource.InstanceFactoryFunc {
+	return func(settings backend.DataSourceInstanceSettings) (instancemgmt.Instance, error) {
+		// basic auth, custom headers, TLS client certificates and the other
+		// authentication options are applied by the HTTP client middlewares.
+		opts, err := settings.HTTPClientOptions()
+		if err != nil {
+			return nil, err
+		}
+
+		client, err := httpClientProvider.New(opts)
+		if err != nil {
+			return nil, err
+		}
+
+		var jd jsonData
+		if len(settings.JSONData) > 0 {
+			if err := json.Unmarshal(settings.JSONData, &jd); err != nil {
+				return nil, fmt.Errorf("failed to parse settings: %w", err)
+			}
+		}
+
+		configuredHeaders := make(map[string]bool, len(opts.Headers))
+		for name := range opts.Headers {
+			configuredHeaders[http.CanonicalHeaderKey(name)] = true
+		}
+
+		return &datasourceInfo{
+			HTTPClient:        client,
+			URL:               settings.URL,
+			HealthCheckPath:   jd.HealthCheckPath,
+			ConfiguredHeaders: configuredHeaders,
+		}, nil
+	}
+}
+
+func (s *Service) QueryData(ctx context.Context, req *backend.QueryDataRequest) (*backend.QueryDataResponse, error) {
+	result := backend.NewQueryDataResponse()
+
+	dsInfo, err := s.getDSInfo(req.PluginContext)
+	if err != nil {
+		return result, err
+	}
+
+	logger := logger.FromContext(ctx)
+	for _, query := range req.Queries {
+		result.Responses[query.RefID] = executeQuery(ctx, logger, dsInfo, query, req.Headers)
+	}
+
+	return result, nil
+}
+
+func executeQuery(ctx context.Context, logger log.Logger, dsInfo *datasourceInfo, query backend.DataQuery, headers map[string]string) backend.DataResponse {
+	model, err := parseQueryModel(query.JSON)
+	if err != nil {
+		return backend.DataResponse{Error: err}
+	}
+
+	req, err := createRequest(ctx, dsInfo, model, query.TimeRange, headers)
+	if err != nil {
+		return backend.DataResponse{Error: err}
+	}
+
+	body, err := doRequest(logger, dsInfo.HTTPClient, req)
+	if err != nil {
+		return backend.DataResponse{Error: err}
+	}
+
+	var doc interface{}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return backend.DataResponse{Error: fmt.Errorf("response is not valid JSON: %w", err)}
+	}
+
+	frame, err := buildFrame(query.RefID, doc, model.Fields)
+	if err != nil {
+		return backend.DataResponse{Error: err}
+	}
+	frame.SetMeta(&data.FrameMeta{ExecutedQueryString: req.Method + " " + req.URL.String()})
+
+	return backend.DataResponse{Frames: data.Frames{frame}}
+}
+
+func createRequest(ctx context.Context, dsInfo *datasourceInfo, model *queryModel, timeRange backend.TimeRange, headers map[string]string) (*http.Request, error) {
+	u, err := url.Parse(dsInfo.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	if model.Path != "" {
+		if u.Path, err = joinPath(u.Path, interpolateTimeRange(model.Path, timeRange)); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(model.Params) > 0 {
+		params := u.Query()
+		for _, param := range model.Params {
+			params.Add(param.Key, interpolateTimeRange(param.Value, timeRange))
+		}
+		u.RawQuery = params.Encode()
+	}
+
+	var body io.Reader
+	if model.Method == http.MethodPost && model.Body != "" {
+		body = strings.NewReader(interpolateTimeRange(model.Body, timeRange))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, model.Method, u.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for _, header := range model.Headers {
+		name := http.CanonicalHeaderKey(header.Key)
+		if dsInfo.ConfiguredHeaders[name] {
+			return nil, fmt.Errorf("header %s is set by the datasource and can't be set by the query", name)
+		}
+		if !queryCanSetHeader(name) {
+			return nil, fmt.Errorf("header %s can't be set by the query", name)
+		}
+		req.Header.Set(name, header.Value)
+	}
+
+	// forwarded when OAuth pass-through is enabled for the datasource
+	for _, name := range []string{"Authorization", "X-ID-Token"} {
+		if value, ok := headers[name]; ok && value != "" {
+			req.Header.Set(name, value)
+		}
+	}
+
+	return req, nil
+}
+
+// joinPath joins a path to the base path of the datasource URL. The path can't resolve outside the base path, so
+// queries only reach the API the datasource is configured for.
+func joinPath(base string, p string) (string, error) {
+	base = path.Clean("/" + base)
+	joined := path.Join(base, p)
+	if joined != base && !strings.HasPrefix(joined, strings.TrimSuffix(base, "/")+"/") {
+		return "", fmt.Errorf("path %q is outside the URL of the datasource", p)
+	}
+	return joined, nil
+}
+
+func doRequest(logger log.Logger, client *http.Client, req *http.Request) ([]byte, error) {
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			logger.Warn("Failed to close response body", "err", err)
+		}
+	}()
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, maxResponseSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(body) > maxResponseSize {
+		return nil, fmt.Errorf("response is larger than %d bytes", maxResponseSize)
+	}
+
+	if res.StatusCode/100 != 2 {
+		logger.Info("Request failed", "status", res.Status, "url", req.URL.String())
+		return nil, fmt.Errorf("request failed, status: %s", res.Status)
+	}
+
+	return body, nil
+}
+
+func (s *Service) CheckHealth(ctx context.Context, req *backend.CheckHealthRequest) (*backend.CheckHealthResult, error) {
+	logger := logger.FromContext(ctx)
+
+	dsInfo, err := s.getDSInfo(req.PluginContext)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := url.Parse(dsInfo.URL)
+	if err != nil {
+		return &backend.CheckHealthResult{
+			Status:  backend.HealthStatusError,
+			Message: fmt.Sprintf("Invalid URL: %s", err.Error()),
+		}, nil
+	}
+	if dsInfo.HealthCheckPath != "" {
+		if u.Path, err = joinPath(u.Path, dsInfo.HealthCheckPath); err != nil {
+			return &backend.CheckHealthResult{
+				Status:  backend.HealthStatusError,
+				Message: err.Error(),
+			}, nil
+		}
+	}
+
+	healthReq, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := doRequest(logger, dsInfo.HTTPClient, healthReq); err != nil {
+		return &backend.CheckHealthResult{
+			Status:  backend.HealthStatusError,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &backend.CheckHealthResult{
+		Status:  backend.HealthStatusOk,
+		Message: "Data source is working",
+	}, nil
+}
+
+func (s *Service) getDSInfo(pluginCtx backend.PluginContext) (*datasourceInfo, error) {
+	i, err := s.im.Get(pluginCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	instance, ok := i.(*datasourceInfo)
+	if !ok {
+		return nil, fmt.Errorf("failed to cast datasource info")
+	}
+
+	return instance, nil
+}
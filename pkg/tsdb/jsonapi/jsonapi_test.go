@@ -0,0 +1,250 @@
+package jsonapi
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/instancemgmt"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryData(t *testing.T) {
+	var lastRequest *http.Request
+	var lastBody string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastRequest = r
+		body, _ := io.ReadAll(r.Body)
+		lastBody = string(body)
+
+		switch r.URL.Path {
+		case "/api/deployments":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{
+				"items": [
+					{ "time": "2022-11-01T10:00:00Z", "service": "api", "duration": 12.5, "ok": true },
+					{ "time": "2022-11-01T11:00:00Z", "service": "web", "duration": null, "ok": false }
+				]
+			}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	service := &Service{im: fakeInstanceManager{dsInfo: &datasourceInfo{HTTPClient: srv.Client(), URL: srv.URL + "/api"}}}
+	timeRange := backend.TimeRange{
+		From: time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2022, 11, 2, 0, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("GET request with typed fields", func(t *testing.T) {
+		resp, err := service.QueryData(context.Background(), &backend.QueryDataRequest{
+			Queries: []backend.DataQuery{{
+				RefID:     "A",
+				TimeRange: timeRange,
+				JSON: []byte(`{
+					"path": "deployments",
+					"params": [{ "key": "from", "value": "${__from:date:iso}" }, { "key": "to", "value": "$__to" }],
+					"headers": [{ "key": "X-Team", "value": "platform" }],
+					"fields": [
+						{ "name": "time", "jsonPath": "$.items[*].time" },
+						{ "name": "service", "jsonPath": "$.items[*].service", "type": "string" },
+						{ "name": "duration", "jsonPath": "$.items[*].duration" },
+						{ "name": "ok", "jsonPath": "$..ok" }
+					]
+				}`),
+			}},
+		})
+		require.NoError(t, err)
+
+		require.Equal(t, http.MethodGet, lastRequest.Method)
+		require.Equal(t, "2022-11-01T00:00:00Z", lastRequest.URL.Query().Get("from"))
+		require.Equal(t, "1667347200000", lastRequest.URL.Query().Get("to"))
+		require.Equal(t, "platform", lastRequest.Header.Get("X-Team"))
+
+		res := resp.Responses["A"]
+		require.NoError(t, res.Error)
+		require.Len(t, res.Frames, 1)
+
+		frame := res.Frames[0]
+		require.Len(t, frame.Fields, 4)
+		require.Equal(t, 2, frame.Rows())
+
+		firstTime := time.Date(2022, 11, 1, 10, 0, 0, 0, time.UTC)
+		require.Equal(t, &firstTime, frame.Fields[0].At(0))
+		service := "web"
+		require.Equal(t, &service, frame.Fields[1].At(1))
+		duration := 12.5
+		require.Equal(t, &duration, frame.Fields[2].At(0))
+		require.Nil(t, frame.Fields[2].At(1))
+		ok := false
+		require.Equal(t, &ok, frame.Fields[3].At(1))
+	})
+
+	t.Run("POST request interpolates the body", func(t *testing.T) {
+		resp, err := service.QueryData(context.Background(), &backend.QueryDataRequest{
+			Queries: []backend.DataQuery{{
+				RefID:     "A",
+				TimeRange: timeRange,
+				JSON: []byte(`{
+					"method": "POST",
+					"path": "deployments",
+					"body": "{\"from\": ${__from:date:seconds}, \"to\": ${__to}}",
+					"fields": [{ "jsonPath": "$.items[*].service" }]
+				}`),
+			}},
+		})
+		require.NoError(t, err)
+		require.NoError(t, resp.Responses["A"].Error)
+
+		require.Equal(t, http.MethodPost, lastRequest.Method)
+		require.Equal(t, "application/json", lastRequest.Header.Get("Content-Type"))
+		require.Equal(t, `{"from": 1667260800, "to": 1667347200000}`, lastBody)
+	})
+
+	t.Run("fields with different lengths return an error", func(t *testing.T) {
+		resp, err := service.QueryData(context.Background(), &backend.QueryDataRequest{
+			Queries: []backend.DataQuery{{
+				RefID: "A",
+				JSON: []byte(`{
+					"path": "deployments",
+					"fields": [{ "jsonPath": "$.items[*].service" }, { "jsonPath": "$.items[0].service" }]
+				}`),
+			}},
+		})
+		require.NoError(t, err)
+		require.Error(t, resp.Responses["A"].Error)
+	})
+
+	t.Run("failed requests return an error", func(t *testing.T) {
+		resp, err := service.QueryData(context.Background(), &backend.QueryDataRequest{
+			Queries: []backend.DataQuery{{
+				RefID: "A",
+				JSON:  []byte(`{"path": "missing", "fields": [{ "jsonPath": "$" }]}`),
+			}},
+		})
+		require.NoError(t, err)
+		require.EqualError(t, resp.Responses["A"].Error, "request failed, status: 404 Not Found")
+	})
+
+	t.Run("invalid queries return an error", func(t *testing.T) {
+		resp, err := service.QueryData(context.Background(), &backend.QueryDataRequest{
+			Queries: []backend.DataQuery{
+				{RefID: "A", JSON: []byte(`{"method": "DELETE", "fields": [{ "jsonPath": "$" }]}`)},
+				{RefID: "B", JSON: []byte(`{"fields": []}`)},
+				{RefID: "C", JSON: []byte(`{"fields": [{ "jsonPath": "$", "type": "duration" }]}`)},
+			},
+		})
+		require.NoError(t, err)
+		require.Error(t, resp.Responses["A"].Error)
+		require.Error(t, resp.Responses["B"].Error)
+		require.Error(t, resp.Responses["C"].Error)
+	})
+
+	t.Run("paths outside the URL of the datasource are rejected", func(t *testing.T) {
+		lastRequest = nil
+		resp, err := service.QueryData(context.Background(), &backend.QueryDataRequest{
+			Queries: []backend.DataQuery{
+				{RefID: "A", JSON: []byte(`{"path": "../admin", "fields": [{ "jsonPath": "$" }]}`)},
+				{RefID: "B", JSON: []byte(`{"path": "deployments/../../admin", "fields": [{ "jsonPath": "$" }]}`)},
+				{RefID: "C", JSON: []byte(`{"path": "/../api-other", "fields": [{ "jsonPath": "$" }]}`)},
+			},
+		})
+		require.NoError(t, err)
+		for _, refID := range []string{"A", "B", "C"} {
+			require.ErrorContains(t, resp.Responses[refID].Error, "outside the URL of the datasource", refID)
+		}
+		require.Nil(t, lastRequest)
+	})
+
+	t.Run("headers that carry credentials or are set by the datasource can't be set by the query", func(t *testing.T) {
+		withHeaders := &Service{im: fakeInstanceManager{dsInfo: &datasourceInfo{
+			HTTPClient:        srv.Client(),
+			URL:               srv.URL + "/api",
+			ConfiguredHeaders: map[string]bool{"X-Api-Key": true},
+		}}}
+		lastRequest = nil
+		resp, err := withHeaders.QueryData(context.Background(), &backend.QueryDataRequest{
+			Queries: []backend.DataQuery{
+				{RefID: "A", JSON: []byte(`{"path": "deployments", "headers": [{ "key": "authorization", "value": "Bearer other" }], "fields": [{ "jsonPath": "$" }]}`)},
+				{RefID: "B", JSON: []byte(`{"path": "deployments", "headers": [{ "key": "x-api-key", "value": "other" }], "fields": [{ "jsonPath": "$" }]}`)},
+				{RefID: "C", JSON: []byte(`{"path": "deployments", "headers": [{ "key": "cookie", "value": "grafana_session=other" }], "fields": [{ "jsonPath": "$" }]}`)},
+				{RefID: "D", JSON: []byte(`{"path": "deployments", "headers": [{ "key": "Host", "value": "internal" }], "fields": [{ "jsonPath": "$" }]}`)},
+				{RefID: "E", JSON: []byte(`{"path": "deployments", "headers": [{ "key": "x-grafana-org-id", "value": "2" }], "fields": [{ "jsonPath": "$" }]}`)},
+				{RefID: "F", JSON: []byte(`{"path": "deployments", "headers": [{ "key": "Proxy-Authorization", "value": "Basic other" }], "fields": [{ "jsonPath": "$" }]}`)},
+			},
+		})
+		require.NoError(t, err)
+		require.ErrorContains(t, resp.Responses["A"].Error, "header Authorization can't be set by the query")
+		require.ErrorContains(t, resp.Responses["B"].Error, "header X-Api-Key is set by the datasource")
+		require.ErrorContains(t, resp.Responses["C"].Error, "header Cookie can't be set by the query")
+		require.ErrorContains(t, resp.Responses["D"].Error, "header Host can't be set by the query")
+		require.ErrorContains(t, resp.Responses["E"].Error, "header X-Grafana-Org-Id can't be set by the query")
+		require.ErrorContains(t, resp.Responses["F"].Error, "header Proxy-Authorization can't be set by the query")
+		require.Nil(t, lastRequest)
+	})
+
+	t.Run("health check", func(t *testing.T) {
+		healthy := &Service{im: fakeInstanceManager{dsInfo: &datasourceInfo{HTTPClient: srv.Client(), URL: srv.URL + "/api", HealthCheckPath: "deployments"}}}
+		res, err := healthy.CheckHealth(context.Background(), &backend.CheckHealthRequest{})
+		require.NoError(t, err)
+		require.Equal(t, backend.HealthStatusOk, res.Status)
+
+		res, err = service.CheckHealth(context.Background(), &backend.CheckHealthRequest{})
+		require.NoError(t, err)
+		require.Equal(t, backend.HealthStatusError, res.Status)
+	})
+}
+
+func TestJSONPath(t *testing.T) {
+	doc := map[string]interface{}{
+		"a": map[string]interface{}{
+			"b": []interface{}{
+				map[string]interface{}{"c": 1.0},
+				map[string]interface{}{"c": 2.0},
+			},
+			"d e": "x",
+		},
+	}
+
+	tests := []struct {
+		path     string
+		expected []interface{}
+	}{
+		{path: "$.a.b[*].c", expected: []interface{}{1.0, 2.0}},
+		{path: "$.a.b[-1].c", expected: []interface{}{2.0}},
+		{path: "$['a']['d e']", expected: []interface{}{"x"}},
+		{path: "$..c", expected: []interface{}{1.0, 2.0}},
+		{path: "$.a.missing", expected: nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			path, err := compileJSONPath(test.path)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, path.evaluate(doc))
+		})
+	}
+
+	for _, invalid := range []string{"a.b", "$.a[", "$.a[?(@.c > 1)]", "$."} {
+		_, err := compileJSONPath(invalid)
+		require.Error(t, err, invalid)
+	}
+}
+
+type fakeInstanceManager struct {
+	dsInfo *datasourceInfo
+}
+
+func (m fakeInstanceManager) Get(_ backend.PluginContext) (instancemgmt.Instance, error) {
+	return m.dsInfo, nil
+}
+
+func (m fakeInstanceManager) Do(_ backend.PluginContext, _ instancemgmt.InstanceCallbackFunc) error {
+	return nil
+}
@@ -0,0 +1,170 @@
+package jsonapi
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// jsonPath is a compiled JSONPath expression. The supported subset is:
+//
+//	$             the root object
+//	.name         a child member
+//	['name']      a child member, for names that are not identifiers
+//	[n]           an array element, negative indexes count from the end
+//	.* and [*]    all members or elements
+//	..name        a member at any depth
+//
+// Filter and script expressions are not supported.
+type jsonPath []pathStep
+
+type pathStep struct {
+	name      string
+	index     int
+	isIndex   bool
+	wildcard  bool
+	recursive bool
+}
+
+func compileJSONPath(expr string) (jsonPath, error) {
+	if !strings.HasPrefix(expr, "$") {
+		return nil, fmt.Errorf("invalid JSONPath %q: must start with $", expr)
+	}
+
+	var steps jsonPath
+	rest := expr[1:]
+	for len(rest) > 0 {
+		var step pathStep
+		switch {
+		case strings.HasPrefix(rest, ".."):
+			step.recursive = true
+			rest = rest[2:]
+			name, remaining := readName(rest)
+			if name == "" {
+				return nil, fmt.Errorf("invalid JSONPath %q: missing name after ..", expr)
+			}
+			step.name, step.wildcard = name, name == "*"
+			rest = remaining
+		case strings.HasPrefix(rest, "."):
+			name, remaining := readName(rest[1:])
+			if name == "" {
+				return nil, fmt.Errorf("invalid JSONPath %q: missing name after .", expr)
+			}
+			step.name, step.wildcard = name, name == "*"
+			rest = remaining
+		case strings.HasPrefix(rest, "["):
+			end := strings.Index(rest, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("invalid JSONPath %q: missing ]", expr)
+			}
+			selector := strings.TrimSpace(rest[1:end])
+			rest = rest[end+1:]
+			switch {
+			case selector == "*":
+				step.wildcard = true
+			case len(selector) >= 2 && (selector[0] == '\'' || selector[0] == '"') && selector[len(selector)-1] == selector[0]:
+				step.name = selector[1 : len(selector)-1]
+			default:
+				index, err := strconv.Atoi(selector)
+				if err != nil {
+					return nil, fmt.Errorf("invalid JSONPath %q: unsupported selector [%s]", expr, selector)
+				}
+				step.index, step.isIndex = index, true
+			}
+		default:
+			return nil, fmt.Errorf("invalid JSONPath %q: unexpected %q", expr, rest)
+		}
+		steps = append(steps, step)
+	}
+
+	return steps, nil
+}
+
+func readName(s string) (string, string) {
+	end := strings.IndexAny(s, ".[")
+	if end < 0 {
+		return s, ""
+	}
+	return s[:end], s[end:]
+}
+
+// evaluate returns all values in the document that match the path.
+func (p jsonPath) evaluate(doc interface{}) []interface{} {
+	current := []interface{}{doc}
+	for _, step := range p {
+		var next []interface{}
+		for _, value := range current {
+			if step.recursive {
+				for _, descendant := range descendants(value) {
+					next = append(next, step.apply(descendant)...)
+				}
+				continue
+			}
+			next = append(next, step.apply(value)...)
+		}
+		current = next
+	}
+	return current
+}
+
+func (s pathStep) apply(value interface{}) []interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if s.wildcard {
+			return mapValues(v)
+		}
+		if s.isIndex {
+			return nil
+		}
+		if child, ok := v[s.name]; ok {
+			return []interface{}{child}
+		}
+	case []interface{}:
+		if s.wildcard {
+			return v
+		}
+		if s.isIndex {
+			index := s.index
+			if index < 0 {
+				index += len(v)
+			}
+			if index >= 0 && index < len(v) {
+				return []interface{}{v[index]}
+			}
+		}
+	}
+	return nil
+}
+
+// descendants returns the value and all values nested in it, depth first.
+func descendants(value interface{}) []interface{} {
+	result := []interface{}{value}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, child := range mapValues(v) {
+			result = append(result, descendants(child)...)
+		}
+	case []interface{}:
+		for _, child := range v {
+			result = append(result, descendants(child)...)
+		}
+	}
+	return result
+}
+
+// mapValues returns the values of an object sorted by key, so that
+// wildcards give the same order on every evaluation.
+func mapValues(m map[string]interface{}) []interface{} {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	values := make([]interface{}, 0, len(keys))
+	for _, k := range keys {
+		values = append(values, m[k])
+	}
+	return values
+}
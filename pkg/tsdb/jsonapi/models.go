@@ -0,0 +1,74 @@
+package jsonapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type fieldType string
+
+const (
+	fieldTypeAuto    fieldType = ""
+	fieldTypeString  fieldType = "string"
+	fieldTypeNumber  fieldType = "number"
+	fieldTypeBoolean fieldType = "boolean"
+	fieldTypeTime    fieldType = "time"
+)
+
+type queryModel struct {
+	// Method is either GET or POST, defaults to GET.
+	Method string `json:"method"`
+	// Path is appended to the datasource URL, and can't resolve outside of it.
+	Path   string         `json:"path"`
+	Params []keyValuePair `json:"params"`
+	// Headers are limited to a few standard headers and custom X- headers, and can't replace the custom headers of
+	// the datasource.
+	Headers []keyValuePair  `json:"headers"`
+	Body    string          `json:"body"`
+	Fields  []fieldSettings `json:"fields"`
+}
+
+type keyValuePair struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type fieldSettings struct {
+	Name     string    `json:"name"`
+	JSONPath string    `json:"jsonPath"`
+	Type     fieldType `json:"type"`
+}
+
+func parseQueryModel(raw json.RawMessage) (*queryModel, error) {
+	model := &queryModel{}
+	if err := json.Unmarshal(raw, model); err != nil {
+		return nil, fmt.Errorf("failed to parse query: %w", err)
+	}
+
+	model.Method = strings.ToUpper(model.Method)
+	switch model.Method {
+	case "":
+		model.Method = http.MethodGet
+	case http.MethodGet, http.MethodPost:
+	default:
+		return nil, fmt.Errorf("unsupported method: %s", model.Method)
+	}
+
+	if len(model.Fields) == 0 {
+		return nil, fmt.Errorf("query has no fields")
+	}
+	for i, field := range model.Fields {
+		if field.JSONPath == "" {
+			return nil, fmt.Errorf("field %d has no JSONPath", i)
+		}
+		switch field.Type {
+		case fieldTypeAuto, fieldTypeString, fieldTypeNumber, fieldTypeBoolean, fieldTypeTime:
+		default:
+			return nil, fmt.Errorf("field %d has an unsupported type: %s", i, field.Type)
+		}
+	}
+
+	return model, nil
+}
@@ -7,7 +7,6 @@ import (
 	"net/http"
 	"net/textproto"
 	"regexp"
-	"strings"
 	"sync"
 
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
@@ -145,18 +144,15 @@ func getHeadersForCallResource(headers map[string][]string) map[string]string {
 }
 
 func callResource(ctx context.Context, req *backend.CallResourceRequest, sender backend.CallResourceResponseSender, dsInfo *datasourceInfo, plog log.Logger) error {
-	url := req.URL
-
 	// a very basic is-this-url-valid check
 	if req.Method != "GET" {
 		return fmt.Errorf("invalid resource method: %s", req.Method)
 	}
-	if (!strings.HasPrefix(url, "labels?")) &&
-		(!strings.HasPrefix(url, "label/")) && // the `/label/$label_name/values` form
-		(!strings.HasPrefix(url, "series?")) {
-		return fmt.Errorf("invalid resource URL: %s", url)
+
+	lokiURL, err := parseResourceURL(req.URL)
+	if err != nil {
+		return err
 	}
-	lokiURL := fmt.Sprintf("/loki/api/v1/%s", url)
 
 	api := newLokiAPI(dsInfo.HTTPClient, dsInfo.URL, plog, getHeadersForCallResource(req.Headers))
 	encodedBytes, err := api.RawQuery(ctx, lokiURL)
@@ -171,6 +167,13 @@ func callResource(ctx context.Context, req *backend.CallResourceRequest, sender
 	if encodedBytes.Encoding != "" {
 		respHeaders["content-encoding"] = []string{encodedBytes.Encoding}
 	}
+	etag := setResourceCacheHeaders(respHeaders, encodedBytes.Body)
+	if resourceNotModified(req.Headers, etag) {
+		return sender.Send(&backend.CallResourceResponse{
+			Status:  http.StatusNotModified,
+			Headers: respHeaders,
+		})
+	}
 	return sender.Send(&backend.CallResourceResponse{
 		Status:  http.StatusOK,
 		Headers: respHeaders,
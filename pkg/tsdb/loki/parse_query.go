@@ -56,7 +56,7 @@ func parseQueryType(jsonValue string) (QueryType, error) {
 	switch jsonValue {
 	case "instant":
 		return QueryTypeInstant, nil
-	case "range":
+	case "range", queryTypeLogVolume:
 		return QueryTypeRange, nil
 	case "":
 		// there are older queries stored in alerting that did not have queryType,
@@ -115,6 +115,12 @@ func parseQuery(queryContext *backend.QueryDataRequest) ([]*lokiQuery, error) {
 			return nil, err
 		}
 
+		volumeQuery := model.VolumeQuery
+		if model.QueryType == queryTypeLogVolume {
+			expr = makeLogVolumeExpr(expr, step)
+			volumeQuery = true
+		}
+
 		qs = append(qs, &lokiQuery{
 			Expr:         expr,
 			QueryType:    queryType,
@@ -125,9 +131,15 @@ func parseQuery(queryContext *backend.QueryDataRequest) ([]*lokiQuery, error) {
 			Start:        start,
 			End:          end,
 			RefID:        query.RefID,
-			VolumeQuery:  model.VolumeQuery,
+			VolumeQuery:  volumeQuery,
 		})
 	}
 
 	return qs, nil
 }
+
+// makeLogVolumeExpr wraps a logs-query into a metric-query that counts
+// the log lines per level, using the query step as the range.
+func makeLogVolumeExpr(expr string, step time.Duration) string {
+	return fmt.Sprintf("sum by (level) (count_over_time(%s[%dms]))", expr, step.Milliseconds())
+}
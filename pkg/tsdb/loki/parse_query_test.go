@@ -59,3 +59,30 @@ func TestParseQuery(t *testing.T) {
 		require.Equal(t, "go_goroutines 2s 2000 50s 50 50000", interpolateVariables(expr, interval, timeRange))
 	})
 }
+
+func TestParseLogVolumeQuery(t *testing.T) {
+	queryContext := &backend.QueryDataRequest{
+		Queries: []backend.DataQuery{
+			{
+				JSON: []byte(`
+				{
+					"expr": "{job=\"grafana\"} |= \"error\"",
+					"queryType": "logVolume",
+					"refId": "A"
+				}`,
+				),
+				TimeRange: backend.TimeRange{
+					From: time.Now().Add(-3000 * time.Second),
+					To:   time.Now(),
+				},
+				Interval:      time.Second * 15,
+				MaxDataPoints: 200,
+			},
+		},
+	}
+	models, err := parseQuery(queryContext)
+	require.NoError(t, err)
+	require.Equal(t, QueryTypeRange, models[0].QueryType)
+	require.True(t, models[0].VolumeQuery)
+	require.Equal(t, `sum by (level) (count_over_time({job="grafana"} |= "error"[15000ms]))`, models[0].Expr)
+}
@@ -0,0 +1,141 @@
+package loki
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/textproto"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// the base interval used to align the time range of metadata lookups.
+// aligning start and end means that lookups issued a few seconds apart
+// end up with the same URL, which makes them cacheable.
+const resourceLookupInterval = time.Minute
+
+var labelNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+type resourceRoute struct {
+	// the path relative to /loki/api/v1
+	path string
+	// the query parameters that are forwarded to Loki
+	params []string
+}
+
+// parseResourceURL validates a CallResource URL like `labels?start=1&end=2`,
+// `label/job/values?start=1&end=2` or `series?match[]={job="a"}`, and
+// returns the path and query-string that should be sent to Loki.
+func parseResourceURL(resourceURL string) (string, error) {
+	u, err := url.Parse(resourceURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid resource URL: %s", resourceURL)
+	}
+
+	route, err := getResourceRoute(u.Path)
+	if err != nil {
+		return "", err
+	}
+
+	query := u.Query()
+	qs := url.Values{}
+	for _, name := range route.params {
+		if values, ok := query[name]; ok {
+			qs[name] = values
+		}
+	}
+
+	if err := alignResourceTimeRange(qs); err != nil {
+		return "", err
+	}
+
+	lokiURL := url.URL{
+		Path:     "/loki/api/v1/" + route.path,
+		RawQuery: qs.Encode(),
+	}
+	return lokiURL.String(), nil
+}
+
+func getResourceRoute(resourcePath string) (resourceRoute, error) {
+	switch {
+	case resourcePath == "labels":
+		return resourceRoute{path: "labels", params: []string{"start", "end"}}, nil
+	case resourcePath == "series":
+		return resourceRoute{path: "series", params: []string{"match[]", "start", "end"}}, nil
+	case strings.HasPrefix(resourcePath, "label/") && strings.HasSuffix(resourcePath, "/values"):
+		// the `/label/$label_name/values` form
+		labelName := strings.TrimSuffix(strings.TrimPrefix(resourcePath, "label/"), "/values")
+		if !labelNameRegex.MatchString(labelName) {
+			return resourceRoute{}, fmt.Errorf("invalid label name: %s", labelName)
+		}
+		return resourceRoute{path: "label/" + labelName + "/values", params: []string{"start", "end", "query"}}, nil
+	default:
+		return resourceRoute{}, fmt.Errorf("invalid resource URL: %s", resourcePath)
+	}
+}
+
+// alignResourceTimeRange rounds the start down and the end up to a multiple of
+// the step that a range-query would use for the same time range.
+func alignResourceTimeRange(qs url.Values) error {
+	if qs.Get("start") == "" || qs.Get("end") == "" {
+		return nil
+	}
+
+	start, err := strconv.ParseInt(qs.Get("start"), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid start: %s", qs.Get("start"))
+	}
+	end, err := strconv.ParseInt(qs.Get("end"), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid end: %s", qs.Get("end"))
+	}
+	if end < start {
+		return fmt.Errorf("end (%d) is before start (%d)", end, start)
+	}
+
+	step := calculateStep(resourceLookupInterval, time.Duration(end-start), 1).Nanoseconds()
+
+	alignedStart := start - start%step
+	alignedEnd := end
+	if rest := end % step; rest != 0 {
+		alignedEnd = end - rest + step
+	}
+
+	qs.Set("start", strconv.FormatInt(alignedStart, 10))
+	qs.Set("end", strconv.FormatInt(alignedEnd, 10))
+	return nil
+}
+
+// setResourceCacheHeaders lets the browser cache the response of a lookup for as
+// long as its time range is aligned to the same URL. The response depends on the
+// credentials of the user, so it's only cached by the browser. It returns the
+// ETag of the response.
+func setResourceCacheHeaders(headers map[string][]string, body []byte) string {
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	headers["cache-control"] = []string{fmt.Sprintf("private, max-age=%d", int(resourceLookupInterval.Seconds()))}
+	headers["etag"] = []string{etag}
+	return etag
+}
+
+// resourceNotModified returns whether the browser already has the response with
+// the given ETag, from the If-None-Match header of the request.
+func resourceNotModified(headers map[string][]string, etag string) bool {
+	for name, values := range headers {
+		if textproto.CanonicalMIMEHeaderKey(name) != "If-None-Match" {
+			continue
+		}
+		for _, value := range values {
+			for _, candidate := range strings.Split(value, ",") {
+				candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+				if candidate == etag || candidate == "*" {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
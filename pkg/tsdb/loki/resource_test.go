@@ -0,0 +1,89 @@
+package loki
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+)
+
+func TestParseResourceURL(t *testing.T) {
+	t.Run("labels", func(t *testing.T) {
+		lokiURL, err := parseResourceURL("labels?start=1000&end=2000&foo=bar")
+		require.NoError(t, err)
+		require.Equal(t, "/loki/api/v1/labels?end=60000000000&start=0", lokiURL)
+	})
+
+	t.Run("label values", func(t *testing.T) {
+		lokiURL, err := parseResourceURL(`label/job/values?query={app="a"}`)
+		require.NoError(t, err)
+		require.Equal(t, "/loki/api/v1/label/job/values?query=%7Bapp%3D%22a%22%7D", lokiURL)
+	})
+
+	t.Run("label values with an invalid label name", func(t *testing.T) {
+		_, err := parseResourceURL("label/../../config/values")
+		require.Error(t, err)
+	})
+
+	t.Run("series with multiple matchers", func(t *testing.T) {
+		lokiURL, err := parseResourceURL(`series?match[]={job="a"}&match[]={job="b"}`)
+		require.NoError(t, err)
+		require.Equal(t, "/loki/api/v1/series?match%5B%5D=%7Bjob%3D%22a%22%7D&match%5B%5D=%7Bjob%3D%22b%22%7D", lokiURL)
+	})
+
+	t.Run("unknown endpoints are rejected", func(t *testing.T) {
+		_, err := parseResourceURL("query_range?query=up")
+		require.Error(t, err)
+	})
+
+	t.Run("invalid time range is rejected", func(t *testing.T) {
+		_, err := parseResourceURL("labels?start=2000&end=1000")
+		require.Error(t, err)
+
+		_, err = parseResourceURL("labels?start=now&end=1000")
+		require.Error(t, err)
+	})
+
+	t.Run("time range is aligned to the step", func(t *testing.T) {
+		// a 30-day range gives a step of 235637ms, larger than the minute base interval
+		lokiURL, err := parseResourceURL("labels?start=1670000000123456789&end=1672592000123456789")
+		require.NoError(t, err)
+		require.Equal(t, "/loki/api/v1/labels?end=1672592191201000000&start=1669999948564000000", lokiURL)
+	})
+}
+
+func TestCallResourceCaching(t *testing.T) {
+	dsInfo := makeMockedDsInfoForOauth([]byte(`{"status":"success","data":["job"]}`), func(*http.Request) {})
+	call := func(t *testing.T, headers map[string][]string) *backend.CallResourceResponse {
+		t.Helper()
+		sender := &mockedCallResourceResponseSenderForOauth{}
+		req := &backend.CallResourceRequest{Method: "GET", URL: "labels?start=1000&end=2000", Headers: headers}
+		require.NoError(t, callResource(context.Background(), req, sender, &dsInfo, log.New("test")))
+		return sender.Response
+	}
+
+	t.Run("responses are cached by the browser", func(t *testing.T) {
+		resp := call(t, map[string][]string{})
+		require.Equal(t, http.StatusOK, resp.Status)
+		require.Equal(t, []string{"private, max-age=60"}, resp.Headers["cache-control"])
+		require.Len(t, resp.Headers["etag"], 1)
+		require.Regexp(t, `^"[0-9a-f]{32}"$`, resp.Headers["etag"][0])
+	})
+
+	t.Run("unchanged responses are not sent again", func(t *testing.T) {
+		etag := call(t, map[string][]string{}).Headers["etag"][0]
+
+		resp := call(t, map[string][]string{"if-none-match": {etag}})
+		require.Equal(t, http.StatusNotModified, resp.Status)
+		require.Empty(t, resp.Body)
+		require.Equal(t, []string{etag}, resp.Headers["etag"])
+
+		resp = call(t, map[string][]string{"If-None-Match": {`"other"`}})
+		require.Equal(t, http.StatusOK, resp.Status)
+		require.NotEmpty(t, resp.Body)
+	})
+}
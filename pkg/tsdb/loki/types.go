@@ -9,6 +9,11 @@ const (
 	QueryTypeInstant QueryType = "instant"
 )
 
+// queryTypeLogVolume is a query-type that only exists in the query-model.
+// the expression is a logs-query, which we turn into a range-query
+// that counts the log lines per level, for the log-volume histogram.
+const queryTypeLogVolume = "logVolume"
+
 type Direction string
 
 const (
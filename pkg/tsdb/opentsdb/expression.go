@@ -0,0 +1,163 @@
+package opentsdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/infra/log"
+)
+
+// expressionQueryType is the query mode that sends the query to OpenTSDB's /api/query/exp endpoint.
+const expressionQueryType = "expression"
+
+func isExpressionQuery(query backend.DataQuery) bool {
+	model, err := simplejson.NewJson(query.JSON)
+	if err != nil {
+		return false
+	}
+	return model.Get("queryType").MustString() == expressionQueryType
+}
+
+// buildExpression builds the body for the /api/query/exp endpoint from the query model. The
+// "expression" object of the model holds the filters, metrics, expressions and outputs, the time
+// section is filled in from the query time range.
+func (s *Service) buildExpression(query backend.DataQuery) (map[string]interface{}, error) {
+	model, err := simplejson.NewJson(query.JSON)
+	if err != nil {
+		return nil, err
+	}
+
+	expression := model.Get("expression").MustMap()
+	if len(expression) == 0 {
+		return nil, fmt.Errorf("expression query %s has no expression", query.RefID)
+	}
+
+	timeSection := model.GetPath("expression", "time").MustMap(map[string]interface{}{})
+	timeSection["start"] = strconv.FormatInt(query.TimeRange.From.UnixNano()/int64(time.Millisecond), 10)
+	timeSection["end"] = strconv.FormatInt(query.TimeRange.To.UnixNano()/int64(time.Millisecond), 10)
+	if _, ok := timeSection["aggregator"]; !ok {
+		timeSection["aggregator"] = "sum"
+	}
+	expression["time"] = timeSection
+
+	return expression, nil
+}
+
+func (s *Service) executeExpressionQuery(ctx context.Context, logger log.Logger, dsInfo *datasourceInfo, query backend.DataQuery) backend.DataResponse {
+	expression, err := s.buildExpression(query)
+	if err != nil {
+		return backend.DataResponse{Error: err}
+	}
+
+	request, err := s.createExpressionRequest(ctx, logger, dsInfo, expression)
+	if err != nil {
+		return backend.DataResponse{Error: err}
+	}
+
+	res, err := dsInfo.HTTPClient.Do(request)
+	if err != nil {
+		return backend.DataResponse{Error: err}
+	}
+
+	frames, err := s.parseExpressionResponse(logger, res)
+	if err != nil {
+		return backend.DataResponse{Error: err}
+	}
+
+	return backend.DataResponse{Frames: frames}
+}
+
+func (s *Service) createExpressionRequest(ctx context.Context, logger log.Logger, dsInfo *datasourceInfo, expression map[string]interface{}) (*http.Request, error) {
+	u, err := url.Parse(dsInfo.URL)
+	if err != nil {
+		return nil, err
+	}
+	u.Path = path.Join(u.Path, "api/query/exp")
+
+	postData, err := json.Marshal(expression)
+	if err != nil {
+		logger.Info("Failed marshaling data", "error", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), strings.NewReader(string(postData)))
+	if err != nil {
+		logger.Info("Failed to create request", "error", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	return req, nil
+}
+
+// parseExpressionResponse converts the outputs of an expression query into frames. Every output
+// holds rows of [timestamp, value1, value2, ...], and the meta section describes the series of each
+// value column; index 0 is the timestamp column.
+func (s *Service) parseExpressionResponse(logger log.Logger, res *http.Response) (data.Frames, error) {
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			logger.Warn("Failed to close response body", "err", err)
+		}
+	}()
+
+	if res.StatusCode/100 != 2 {
+		logger.Info("Request failed", "status", res.Status, "body", string(body))
+		return nil, fmt.Errorf("request failed, status: %s", res.Status)
+	}
+
+	var responseData OpenTsdbExpressionResponse
+	if err := json.Unmarshal(body, &responseData); err != nil {
+		logger.Info("Failed to unmarshal opentsdb expression response", "error", err, "status", res.Status, "body", string(body))
+		return nil, err
+	}
+
+	frames := data.Frames{}
+	for _, output := range responseData.Outputs {
+		name := output.Alias
+		if name == "" {
+			name = output.ID
+		}
+
+		for _, meta := range output.Meta {
+			// The first column is the timestamp
+			if meta.Index == 0 {
+				continue
+			}
+			if meta.Index < 1 {
+				return nil, fmt.Errorf("invalid meta index %d in output %s", meta.Index, output.ID)
+			}
+
+			timeVector := make([]time.Time, 0, len(output.DataPoints))
+			values := make([]float64, 0, len(output.DataPoints))
+			for _, row := range output.DataPoints {
+				if len(row) <= meta.Index {
+					return nil, fmt.Errorf("invalid data point in output %s", output.ID)
+				}
+				timeVector = append(timeVector, time.UnixMilli(int64(row[0])).UTC())
+				values = append(values, row[meta.Index])
+			}
+
+			frames = append(frames, data.NewFrame(name,
+				data.NewField("time", nil, timeVector),
+				data.NewField("value", meta.CommonTags, values)))
+		}
+	}
+
+	return frames, nil
+}
@@ -0,0 +1,92 @@
+package opentsdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+)
+
+func (s *Service) CheckHealth(ctx context.Context, req *backend.CheckHealthRequest) (*backend.CheckHealthResult, error) {
+	logger := logger.FromContext(ctx)
+
+	dsInfo, err := s.getDSInfo(req.PluginContext)
+	if err != nil {
+		return getHealthCheckMessage(logger, "error getting datasource info", err)
+	}
+
+	version, err := s.detectVersion(ctx, logger, dsInfo)
+	if err != nil {
+		return getHealthCheckMessage(logger, "error detecting OpenTSDB version", err)
+	}
+
+	return getHealthCheckMessage(logger, fmt.Sprintf("OpenTSDB version %s", version.Version), nil)
+}
+
+// detectVersion fetches the server version through the /api/version endpoint,
+// which is available in OpenTSDB 2.0 and later.
+func (s *Service) detectVersion(ctx context.Context, logger log.Logger, dsInfo *datasourceInfo) (*OpenTsdbVersion, error) {
+	u, err := url.Parse(dsInfo.URL)
+	if err != nil {
+		return nil, err
+	}
+	u.Path = path.Join(u.Path, "api/version")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := dsInfo.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			logger.Warn("Failed to close response body", "err", err)
+		}
+	}()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode/100 != 2 {
+		logger.Info("Request failed", "status", res.Status, "body", string(body))
+		return nil, fmt.Errorf("request failed, status: %s", res.Status)
+	}
+
+	var version OpenTsdbVersion
+	if err := json.Unmarshal(body, &version); err != nil {
+		return nil, fmt.Errorf("failed to parse version response: %w", err)
+	}
+	if version.Version == "" {
+		return nil, fmt.Errorf("version response did not contain a version")
+	}
+
+	return &version, nil
+}
+
+func getHealthCheckMessage(logger log.Logger, message string, err error) (*backend.CheckHealthResult, error) {
+	if err == nil {
+		return &backend.CheckHealthResult{
+			Status:  backend.HealthStatusOk,
+			Message: fmt.Sprintf("Data source is working. %s", message),
+		}, nil
+	}
+
+	logger.Warn("Error performing OpenTSDB healthcheck", "err", err.Error())
+
+	return &backend.CheckHealthResult{
+		Status:  backend.HealthStatusError,
+		Message: fmt.Sprintf("%s: %s", message, err.Error()),
+	}, nil
+}
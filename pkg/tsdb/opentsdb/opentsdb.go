@@ -15,6 +15,7 @@ import (
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
 	"github.com/grafana/grafana-plugin-sdk-go/backend/datasource"
 	"github.com/grafana/grafana-plugin-sdk-go/backend/instancemgmt"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/resource/httpadapter"
 	"github.com/grafana/grafana-plugin-sdk-go/data"
 
 	"github.com/grafana/grafana/pkg/components/simplejson"
@@ -27,12 +28,24 @@ var logger = log.New("tsdb.opentsdb")
 
 type Service struct {
 	im instancemgmt.InstanceManager
+
+	resourceHandler backend.CallResourceHandler
 }
 
+var (
+	_ backend.QueryDataHandler    = (*Service)(nil)
+	_ backend.CallResourceHandler = (*Service)(nil)
+	_ backend.CheckHealthHandler  = (*Service)(nil)
+)
+
 func ProvideService(httpClientProvider httpclient.Provider) *Service {
-	return &Service{
+	s := &Service{
 		im: datasource.NewInstanceManager(newInstanceSettings(httpClientProvider)),
 	}
+
+	s.resourceHandler = httpadapter.New(s.newResourceMux())
+
+	return s
 }
 
 type datasourceInfo struct {
@@ -63,19 +76,54 @@ func newInstanceSettings(httpClientProvider httpclient.Provider) datasource.Inst
 	}
 }
 
+func (s *Service) CallResource(ctx context.Context, req *backend.CallResourceRequest, sender backend.CallResourceResponseSender) error {
+	return s.resourceHandler.CallResource(ctx, req, sender)
+}
+
 func (s *Service) QueryData(ctx context.Context, req *backend.QueryDataRequest) (*backend.QueryDataResponse, error) {
 	var tsdbQuery OpenTsdbQuery
 
 	logger := logger.FromContext(ctx)
 
-	q := req.Queries[0]
+	dsInfo, err := s.getDSInfo(req.PluginContext)
+	if err != nil {
+		return nil, err
+	}
+
+	// Expression queries are sent one by one to /api/query/exp, the remaining
+	// metric queries are batched into a single /api/query request.
+	expressionResponses := make(backend.Responses)
+	metricQueries := make([]backend.DataQuery, 0, len(req.Queries))
+	for _, query := range req.Queries {
+		if isExpressionQuery(query) {
+			expressionResponses[query.RefID] = s.executeExpressionQuery(ctx, logger, dsInfo, query)
+			continue
+		}
+		metricQueries = append(metricQueries, query)
+	}
+
+	if len(metricQueries) == 0 {
+		return &backend.QueryDataResponse{Responses: expressionResponses}, nil
+	}
+
+	q := metricQueries[0]
 
 	tsdbQuery.Start = q.TimeRange.From.UnixNano() / int64(time.Millisecond)
 	tsdbQuery.End = q.TimeRange.To.UnixNano() / int64(time.Millisecond)
 
-	for _, query := range req.Queries {
+	refIDs := make([]string, 0, len(metricQueries))
+	for _, query := range metricQueries {
 		metric := s.buildMetric(query)
 		tsdbQuery.Queries = append(tsdbQuery.Queries, metric)
+		refIDs = append(refIDs, query.RefID)
+	}
+
+	// The metric results are returned as "A", which can be the RefID of an expression query, so
+	// with expression queries each result is returned with the RefID of its own query instead.
+	if len(expressionResponses) > 0 {
+		tsdbQuery.ShowQuery = true
+	} else {
+		refIDs = nil
 	}
 
 	// TODO: Don't use global variable
@@ -83,11 +131,6 @@ func (s *Service) QueryData(ctx context.Context, req *backend.QueryDataRequest)
 		logger.Debug("OpenTsdb request", "params", tsdbQuery)
 	}
 
-	dsInfo, err := s.getDSInfo(req.PluginContext)
-	if err != nil {
-		return nil, err
-	}
-
 	request, err := s.createRequest(ctx, logger, dsInfo, tsdbQuery)
 	if err != nil {
 		return &backend.QueryDataResponse{}, err
@@ -98,11 +141,15 @@ func (s *Service) QueryData(ctx context.Context, req *backend.QueryDataRequest)
 		return &backend.QueryDataResponse{}, err
 	}
 
-	result, err := s.parseResponse(logger, res)
+	result, err := s.parseResponse(logger, res, refIDs)
 	if err != nil {
 		return &backend.QueryDataResponse{}, err
 	}
 
+	for refID, response := range expressionResponses {
+		result.Responses[refID] = response
+	}
+
 	return result, nil
 }
 
@@ -129,7 +176,9 @@ func (s *Service) createRequest(ctx context.Context, logger log.Logger, dsInfo *
 	return req, nil
 }
 
-func (s *Service) parseResponse(logger log.Logger, res *http.Response) (*backend.QueryDataResponse, error) {
+// parseResponse returns the results as "A", or under the RefIDs of their queries when they are given and
+// the response has the query of each result.
+func (s *Service) parseResponse(logger log.Logger, res *http.Response, refIDs []string) (*backend.QueryDataResponse, error) {
 	resp := backend.NewQueryDataResponse()
 
 	body, err := io.ReadAll(res.Body)
@@ -154,7 +203,7 @@ func (s *Service) parseResponse(logger log.Logger, res *http.Response) (*backend
 		return nil, err
 	}
 
-	frames := data.Frames{}
+	frames := map[string]data.Frames{"A": {}}
 	for _, val := range responseData {
 		timeVector := make([]time.Time, 0, len(val.DataPoints))
 		values := make([]float64, 0, len(val.DataPoints))
@@ -170,13 +219,30 @@ func (s *Service) parseResponse(logger log.Logger, res *http.Response) (*backend
 			timeVector = append(timeVector, time.Unix(timestamp, 0).UTC())
 			values = append(values, value)
 		}
-		frames = append(frames, data.NewFrame(name,
+		refID := "A"
+		if refIDs != nil {
+			if val.Query == nil || val.Query.Index < 0 || val.Query.Index >= len(refIDs) {
+				return nil, fmt.Errorf("opentsdb returned a result without a valid query index")
+			}
+			refID = refIDs[val.Query.Index]
+		}
+		frames[refID] = append(frames[refID], data.NewFrame(name,
 			data.NewField("time", nil, timeVector),
 			data.NewField("value", tags, values)))
 	}
-	result := resp.Responses["A"]
-	result.Frames = frames
-	resp.Responses["A"] = result
+	if refIDs != nil {
+		delete(frames, "A")
+		for _, refID := range refIDs {
+			if _, ok := frames[refID]; !ok {
+				frames[refID] = data.Frames{}
+			}
+		}
+	}
+	for refID, f := range frames {
+		result := resp.Responses[refID]
+		result.Frames = f
+		resp.Responses[refID] = result
+	}
 	return resp, nil
 }
 
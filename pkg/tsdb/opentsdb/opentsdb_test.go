@@ -4,12 +4,15 @@ import (
 	"context"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/instancemgmt"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/resource/httpadapter"
 	"github.com/grafana/grafana-plugin-sdk-go/data"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -33,7 +36,7 @@ func TestOpenTsdbExecutor(t *testing.T) {
 	t.Run("Parse response should handle invalid JSON", func(t *testing.T) {
 		response := `{ invalid }`
 
-		result, err := service.parseResponse(logger, &http.Response{Body: io.NopCloser(strings.NewReader(response))})
+		result, err := service.parseResponse(logger, &http.Response{Body: io.NopCloser(strings.NewReader(response))}, nil)
 		require.Nil(t, result)
 		require.Error(t, err)
 	})
@@ -63,7 +66,7 @@ func TestOpenTsdbExecutor(t *testing.T) {
 
 		resp := http.Response{Body: io.NopCloser(strings.NewReader(response))}
 		resp.StatusCode = 200
-		result, err := service.parseResponse(logger, &resp)
+		result, err := service.parseResponse(logger, &resp, nil)
 		require.NoError(t, err)
 
 		frame := result.Responses["A"]
@@ -242,3 +245,187 @@ func TestOpenTsdbExecutor(t *testing.T) {
 		require.Equal(t, float64(60), metricRateOptions["resetValue"])
 	})
 }
+
+func TestOpenTsdbExpressionQuery(t *testing.T) {
+	service := &Service{}
+
+	t.Run("Build expression fills in the time range", func(t *testing.T) {
+		query := backend.DataQuery{
+			RefID: "A",
+			TimeRange: backend.TimeRange{
+				From: time.Unix(1431561600, 0),
+				To:   time.Unix(1431565200, 0),
+			},
+			JSON: []byte(`
+					{
+						"queryType": "expression",
+						"expression": {
+							"time": { "downsampler": { "interval": "1m", "aggregator": "avg" } },
+							"metrics": [{ "id": "a", "metric": "sys.cpu.user" }],
+							"expressions": [{ "id": "e", "expr": "a * 2" }]
+						}
+					}`,
+			),
+		}
+
+		require.True(t, isExpressionQuery(query))
+
+		expression, err := service.buildExpression(query)
+		require.NoError(t, err)
+
+		timeSection := expression["time"].(map[string]interface{})
+		require.Equal(t, "1431561600000", timeSection["start"])
+		require.Equal(t, "1431565200000", timeSection["end"])
+		require.Equal(t, "sum", timeSection["aggregator"])
+		require.NotNil(t, timeSection["downsampler"])
+		require.Len(t, expression["metrics"], 1)
+	})
+
+	t.Run("Build expression without expression should fail", func(t *testing.T) {
+		_, err := service.buildExpression(backend.DataQuery{RefID: "A", JSON: []byte(`{"queryType": "expression"}`)})
+		require.Error(t, err)
+	})
+
+	t.Run("Parse expression response", func(t *testing.T) {
+		response := `
+		{
+			"outputs": [
+				{
+					"id": "e",
+					"alias": "double",
+					"dps": [[1431561600000, 1.0, 2.0], [1431561660000, 3.0, 4.0]],
+					"meta": [
+						{ "index": 0, "metrics": ["timestamp"] },
+						{ "index": 1, "metrics": ["sys.cpu.user"], "commonTags": { "host": "web01" } },
+						{ "index": 2, "metrics": ["sys.cpu.user"], "commonTags": { "host": "web02" } }
+					]
+				}
+			]
+		}`
+
+		resp := http.Response{Body: io.NopCloser(strings.NewReader(response)), StatusCode: 200}
+		frames, err := service.parseExpressionResponse(logger, &resp)
+		require.NoError(t, err)
+		require.Len(t, frames, 2)
+
+		times := []time.Time{
+			time.Date(2015, 5, 14, 0, 0, 0, 0, time.UTC),
+			time.Date(2015, 5, 14, 0, 1, 0, 0, time.UTC),
+		}
+		testFrame := data.NewFrame("double",
+			data.NewField("time", nil, times),
+			data.NewField("value", map[string]string{"host": "web02"}, []float64{2, 4}),
+		)
+		if diff := cmp.Diff(testFrame, frames[1], data.FrameTestCompareOptions()...); diff != "" {
+			t.Errorf("Result mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Parse expression response should reject a negative index", func(t *testing.T) {
+		response := `{"outputs": [{"id": "e", "dps": [[1431561600000, 1.0]], "meta": [{ "index": -1 }]}]}`
+
+		resp := http.Response{Body: io.NopCloser(strings.NewReader(response)), StatusCode: 200}
+		_, err := service.parseExpressionResponse(logger, &resp)
+		require.Error(t, err)
+	})
+
+	t.Run("Parse response should use the RefIDs of the queries", func(t *testing.T) {
+		response := `
+		[
+			{ "metric": "first", "dps": { "1405544146": 1.0 }, "query": { "index": 0 } },
+			{ "metric": "second", "dps": { "1405544146": 2.0 }, "query": { "index": 1 } }
+		]`
+
+		resp := http.Response{Body: io.NopCloser(strings.NewReader(response)), StatusCode: 200}
+		result, err := service.parseResponse(logger, &resp, []string{"B", "C"})
+		require.NoError(t, err)
+
+		require.NotContains(t, result.Responses, "A")
+		require.Len(t, result.Responses["B"].Frames, 1)
+		require.Equal(t, "first", result.Responses["B"].Frames[0].Name)
+		require.Len(t, result.Responses["C"].Frames, 1)
+		require.Equal(t, "second", result.Responses["C"].Frames[0].Name)
+	})
+}
+
+func TestOpenTsdbResources(t *testing.T) {
+	var lastRequest *http.Request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastRequest = r
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/version":
+			_, _ = w.Write([]byte(`{"version": "2.4.0", "short_revision": "abc"}`))
+		case "/api/suggest":
+			_, _ = w.Write([]byte(`["cpu.user", "cpu.system"]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	dsInfo := &datasourceInfo{HTTPClient: srv.Client(), URL: srv.URL}
+	service := &Service{im: fakeInstanceManager{dsInfo: dsInfo}}
+	service.resourceHandler = httpadapter.New(service.newResourceMux())
+
+	t.Run("Suggest forwards known parameters only", func(t *testing.T) {
+		sender := &fakeSender{}
+		err := service.CallResource(context.Background(), &backend.CallResourceRequest{
+			Method: http.MethodGet,
+			Path:   "api/suggest",
+			URL:    "api/suggest?type=metrics&q=cpu&max=10&foo=bar",
+		}, sender)
+		require.NoError(t, err)
+
+		require.Equal(t, http.StatusOK, sender.resp.Status)
+		require.JSONEq(t, `["cpu.user", "cpu.system"]`, string(sender.resp.Body))
+		require.Equal(t, "/api/suggest", lastRequest.URL.Path)
+		require.Equal(t, "max=10&q=cpu&type=metrics", lastRequest.URL.RawQuery)
+	})
+
+	t.Run("Non-GET requests are rejected", func(t *testing.T) {
+		sender := &fakeSender{}
+		err := service.CallResource(context.Background(), &backend.CallResourceRequest{
+			Method: http.MethodPost,
+			Path:   "api/suggest",
+			URL:    "api/suggest",
+		}, sender)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusMethodNotAllowed, sender.resp.Status)
+	})
+
+	t.Run("Health check reports the version", func(t *testing.T) {
+		res, err := service.CheckHealth(context.Background(), &backend.CheckHealthRequest{})
+		require.NoError(t, err)
+		require.Equal(t, backend.HealthStatusOk, res.Status)
+		require.Contains(t, res.Message, "2.4.0")
+	})
+
+	t.Run("Health check fails when the server is unreachable", func(t *testing.T) {
+		broken := &Service{im: fakeInstanceManager{dsInfo: &datasourceInfo{HTTPClient: srv.Client(), URL: srv.URL + "/missing"}}}
+		res, err := broken.CheckHealth(context.Background(), &backend.CheckHealthRequest{})
+		require.NoError(t, err)
+		require.Equal(t, backend.HealthStatusError, res.Status)
+	})
+}
+
+type fakeInstanceManager struct {
+	dsInfo *datasourceInfo
+}
+
+func (m fakeInstanceManager) Get(_ backend.PluginContext) (instancemgmt.Instance, error) {
+	return m.dsInfo, nil
+}
+
+func (m fakeInstanceManager) Do(_ backend.PluginContext, _ instancemgmt.InstanceCallbackFunc) error {
+	return nil
+}
+
+type fakeSender struct {
+	resp *backend.CallResourceResponse
+}
+
+func (s *fakeSender) Send(resp *backend.CallResourceResponse) error {
+	s.resp = resp
+	return nil
+}
@@ -0,0 +1,117 @@
+package opentsdb
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend/resource/httpadapter"
+)
+
+// resourceRoute describes an OpenTSDB HTTP API endpoint that is proxied by the backend,
+// together with the query parameters that are forwarded to it.
+type resourceRoute struct {
+	path   string
+	params []string
+}
+
+var resourceRoutes = []resourceRoute{
+	{path: "api/suggest", params: []string{"type", "q", "max"}},
+	{path: "api/search/lookup", params: []string{"m", "limit", "useMeta"}},
+	{path: "api/aggregators"},
+	{path: "api/config/filters"},
+	{path: "api/version"},
+}
+
+func (s *Service) newResourceMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	for _, route := range resourceRoutes {
+		mux.HandleFunc("/"+route.path, s.handleResourceReq(route))
+	}
+	return mux
+}
+
+func (s *Service) handleResourceReq(route resourceRoute) func(rw http.ResponseWriter, req *http.Request) {
+	return func(rw http.ResponseWriter, req *http.Request) {
+		logger := logger.FromContext(req.Context())
+		logger.Debug("Received resource call", "url", req.URL.String(), "method", req.Method)
+
+		if req.Method != http.MethodGet {
+			writeResponse(rw, http.StatusMethodNotAllowed, fmt.Sprintf("invalid resource method: %s", req.Method))
+			return
+		}
+
+		dsInfo, err := s.getDSInfo(httpadapter.PluginConfigFromContext(req.Context()))
+		if err != nil {
+			writeResponse(rw, http.StatusInternalServerError, fmt.Sprintf("unexpected error %v", err))
+			return
+		}
+
+		u, err := buildResourceURL(dsInfo.URL, route, req.URL.Query())
+		if err != nil {
+			writeResponse(rw, http.StatusBadRequest, fmt.Sprintf("unexpected error %v", err))
+			return
+		}
+
+		upstreamReq, err := http.NewRequestWithContext(req.Context(), http.MethodGet, u, nil)
+		if err != nil {
+			writeResponse(rw, http.StatusInternalServerError, fmt.Sprintf("failed to create request: %v", err))
+			return
+		}
+
+		res, err := dsInfo.HTTPClient.Do(upstreamReq)
+		if err != nil {
+			writeResponse(rw, http.StatusBadGateway, fmt.Sprintf("request failed: %v", err))
+			return
+		}
+		defer func() {
+			if err := res.Body.Close(); err != nil {
+				logger.Warn("Failed to close response body", "err", err)
+			}
+		}()
+
+		body, err := io.ReadAll(res.Body)
+		if err != nil {
+			writeResponse(rw, http.StatusInternalServerError, fmt.Sprintf("failed to read response: %v", err))
+			return
+		}
+
+		if contentType := res.Header.Get("Content-Type"); contentType != "" {
+			rw.Header().Set("Content-Type", contentType)
+		}
+		writeResponseBytes(rw, res.StatusCode, body)
+	}
+}
+
+// buildResourceURL builds the upstream URL for a resource route. Only the query parameters
+// known to the route are forwarded, so the resource API can't be used to reach arbitrary endpoints.
+func buildResourceURL(baseURL string, route resourceRoute, query url.Values) (string, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return "", err
+	}
+	u.Path = path.Join(u.Path, route.path)
+
+	params := url.Values{}
+	for _, name := range route.params {
+		if value := query.Get(name); value != "" {
+			params.Set(name, value)
+		}
+	}
+	u.RawQuery = params.Encode()
+
+	return u.String(), nil
+}
+
+func writeResponseBytes(rw http.ResponseWriter, code int, msg []byte) {
+	rw.WriteHeader(code)
+	if _, err := rw.Write(msg); err != nil {
+		logger.Error("Unable to write HTTP response", "error", err)
+	}
+}
+
+func writeResponse(rw http.ResponseWriter, code int, msg string) {
+	writeResponseBytes(rw, code, []byte(msg))
+}
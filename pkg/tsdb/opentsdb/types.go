@@ -1,13 +1,42 @@
 package opentsdb
 
 type OpenTsdbQuery struct {
-	Start   int64                    `json:"start"`
-	End     int64                    `json:"end"`
-	Queries []map[string]interface{} `json:"queries"`
+	Start     int64                    `json:"start"`
+	End       int64                    `json:"end"`
+	Queries   []map[string]interface{} `json:"queries"`
+	ShowQuery bool                     `json:"showQuery,omitempty"`
 }
 
 type OpenTsdbResponse struct {
-	Metric     string             `json:"metric"`
-	Tags       map[string]string  `json:"tags"`
-	DataPoints map[string]float64 `json:"dps"`
+	Metric     string                 `json:"metric"`
+	Tags       map[string]string      `json:"tags"`
+	DataPoints map[string]float64     `json:"dps"`
+	Query      *OpenTsdbResponseQuery `json:"query,omitempty"`
+}
+
+// OpenTsdbResponseQuery is the query of a result, returned when the request sets showQuery
+type OpenTsdbResponseQuery struct {
+	Index int `json:"index"`
+}
+
+type OpenTsdbVersion struct {
+	Version       string `json:"version"`
+	ShortRevision string `json:"short_revision"`
+}
+
+type OpenTsdbExpressionResponse struct {
+	Outputs []OpenTsdbExpressionOutput `json:"outputs"`
+}
+
+type OpenTsdbExpressionOutput struct {
+	ID         string                   `json:"id"`
+	Alias      string                   `json:"alias"`
+	DataPoints [][]float64              `json:"dps"`
+	Meta       []OpenTsdbExpressionMeta `json:"meta"`
+}
+
+type OpenTsdbExpressionMeta struct {
+	Index      int               `json:"index"`
+	Metrics    []string          `json:"metrics"`
+	CommonTags map[string]string `json:"commonTags"`
 }
@@ -42,6 +42,9 @@ type QueryData struct {
 	URL                string
 	TimeInterval       string
 	enableWideSeries   bool
+	// chunkInterval splits range queries over longer time ranges into chunks, when set.
+	chunkInterval    time.Duration
+	chunkConcurrency int
 }
 
 func New(
@@ -62,6 +65,11 @@ func New(
 		return nil, err
 	}
 
+	chunkInterval, chunkConcurrency, err := getQueryChunkSettings(jsonData)
+	if err != nil {
+		return nil, err
+	}
+
 	promClient := client.NewClient(httpClient, httpMethod, settings.URL)
 
 	return &QueryData{
@@ -73,9 +81,41 @@ func New(
 		ID:                 settings.ID,
 		URL:                settings.URL,
 		enableWideSeries:   features.IsEnabled(featuremgmt.FlagPrometheusWideSeries),
+		chunkInterval:      chunkInterval,
+		chunkConcurrency:   chunkConcurrency,
 	}, nil
 }
 
+// getQueryChunkSettings reads the optional `queryChunkInterval`, like `1d`, and
+// `queryChunkConcurrency` settings. The concurrency is capped at maxChunkConcurrency.
+func getQueryChunkSettings(jsonData map[string]interface{}) (time.Duration, int, error) {
+	var interval time.Duration
+	chunkInterval, err := maputil.GetStringOptional(jsonData, "queryChunkInterval")
+	if err != nil {
+		return 0, 0, err
+	}
+	if chunkInterval != "" {
+		interval, err = intervalv2.ParseIntervalStringToTimeDuration(chunkInterval)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid queryChunkInterval: %w", err)
+		}
+	}
+
+	concurrency := defaultChunkConcurrency
+	if value, ok := jsonData["queryChunkConcurrency"]; ok && value != nil {
+		n, ok := value.(float64)
+		if !ok || n < 1 {
+			return 0, 0, fmt.Errorf("queryChunkConcurrency must be a positive number")
+		}
+		concurrency = int(n)
+		if concurrency > maxChunkConcurrency {
+			concurrency = maxChunkConcurrency
+		}
+	}
+
+	return interval, concurrency, nil
+}
+
 func (s *QueryData) Execute(ctx context.Context, req *backend.QueryDataRequest) (*backend.QueryDataResponse, error) {
 	fromAlert := req.Headers["FromAlert"] == "true"
 	result := backend.QueryDataResponse{
@@ -123,7 +163,7 @@ func (s *QueryData) fetch(ctx context.Context, client *client.Client, q *models.
 	}
 
 	if q.RangeQuery {
-		res, err := s.rangeQuery(traceCtx, client, q, headers)
+		res, err := s.splitRangeQuery(traceCtx, client, q, headers)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,241 @@
+package querydata
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/grafana/grafana/pkg/tsdb/prometheus/client"
+	"github.com/grafana/grafana/pkg/tsdb/prometheus/models"
+)
+
+// defaultChunkConcurrency is the number of chunks of a split query that are
+// requested at the same time, when the datasource does not configure it.
+const defaultChunkConcurrency = 4
+
+// maxChunkConcurrency is the maximum number of chunks of a split query that are
+// requested at the same time, whatever the datasource configures.
+const maxChunkConcurrency = 16
+
+type chunkResult struct {
+	query    *models.Query
+	response *backend.DataResponse
+	err      error
+}
+
+// splitRangeQuery runs a range query as a series of shorter range queries and
+// merges the results. Chunks that fail are reported as notices on the merged
+// frames, the query only fails when all chunks fail.
+func (s *QueryData) splitRangeQuery(ctx context.Context, c *client.Client, q *models.Query, headers map[string]string) (*backend.DataResponse, error) {
+	chunks := splitQuery(q, s.chunkInterval)
+	if len(chunks) < 2 {
+		return s.rangeQuery(ctx, c, q, headers)
+	}
+
+	s.log.FromContext(ctx).Debug("Splitting range query", "query", q.Expr, "chunks", len(chunks))
+
+	results := make([]chunkResult, len(chunks))
+	var g errgroup.Group
+	g.SetLimit(s.chunkConcurrency)
+	for i, chunk := range chunks {
+		i, chunk := i, chunk
+		g.Go(func() error {
+			res, err := s.rangeQuery(ctx, c, chunk, headers)
+			if err == nil && res.Error != nil {
+				err = res.Error
+			}
+			results[i] = chunkResult{query: chunk, response: res, err: err}
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	return mergeChunkResults(q, results), nil
+}
+
+// splitQuery divides the time range of a query into chunks that are a multiple of the step
+// and at most as long as interval, rounded up to a whole step. The chunks are aligned the
+// same way as the whole query, so together they evaluate the query at the same timestamps.
+func splitQuery(q *models.Query, interval time.Duration) []*models.Query {
+	if interval <= 0 || q.Step <= 0 {
+		return []*models.Query{q}
+	}
+
+	tr := q.TimeRange()
+	steps := int64(interval / q.Step)
+	if interval%q.Step != 0 {
+		steps++
+	}
+	chunkLength := time.Duration(steps) * q.Step
+
+	var chunks []*models.Query
+	for start := tr.Start; !start.After(tr.End); {
+		end := start.Add(chunkLength - q.Step)
+		if end.After(tr.End) {
+			end = tr.End
+		}
+
+		chunk := *q
+		chunk.Start, chunk.End = start, end
+		chunks = append(chunks, &chunk)
+
+		start = end.Add(q.Step)
+	}
+	return chunks
+}
+
+// mergeChunkResults appends the frames of all chunks, in time order, to the frames
+// of the first chunk that returned the same series.
+func mergeChunkResults(q *models.Query, results []chunkResult) *backend.DataResponse {
+	var frames data.Frames
+	var emptyFrame *data.Frame
+	var notices []data.Notice
+	// mismatches are the frames of chunks that can't be merged with the same series of other chunks
+	var mismatches []data.Notice
+	var firstErr error
+
+	for _, result := range results {
+		if result.err != nil {
+			if firstErr == nil {
+				firstErr = result.err
+			}
+			tr := result.query.TimeRange()
+			notices = append(notices, data.Notice{
+				Severity: data.NoticeSeverityWarning,
+				Text: fmt.Sprintf("Partial result: the query for %s to %s failed: %s",
+					tr.Start.UTC().Format(time.RFC3339), tr.End.UTC().Format(time.RFC3339), result.err.Error()),
+			})
+			continue
+		}
+
+		for _, frame := range result.response.Frames {
+			if len(frame.Fields) == 0 {
+				if emptyFrame == nil {
+					emptyFrame = frame
+				}
+				continue
+			}
+			var err error
+			if frames, err = appendChunkFrame(frames, frame); err != nil {
+				tr := result.query.TimeRange()
+				mismatches = append(mismatches, data.Notice{
+					Severity: data.NoticeSeverityWarning,
+					Text: fmt.Sprintf("Partial result: the values of the query for %s to %s were left out: %s",
+						tr.Start.UTC().Format(time.RFC3339), tr.End.UTC().Format(time.RFC3339), err.Error()),
+				})
+			}
+		}
+	}
+
+	if len(notices) == len(results) {
+		return &backend.DataResponse{Error: firstErr}
+	}
+
+	if len(frames) == 0 {
+		if emptyFrame == nil {
+			emptyFrame = data.NewFrame("")
+			addMetadataToMultiFrame(q, emptyFrame)
+		}
+		frames = data.Frames{emptyFrame}
+	}
+	if notices = append(notices, mismatches...); len(notices) > 0 {
+		if frames[0].Meta == nil {
+			frames[0].Meta = &data.FrameMeta{}
+		}
+		frames[0].Meta.Notices = append(frames[0].Meta.Notices, notices...)
+	}
+
+	return &backend.DataResponse{Frames: frames}
+}
+
+// appendChunkFrame appends the values of a frame to the frame of the same series. A field that is nullable in
+// one chunk and not in another is merged as nullable, the frame of a series whose field types differ otherwise
+// is not merged and an error is returned.
+func appendChunkFrame(frames data.Frames, frame *data.Frame) (data.Frames, error) {
+	key := chunkFrameKey(frame)
+	for _, existing := range frames {
+		if chunkFrameKey(existing) != key {
+			continue
+		}
+		for i, field := range frame.Fields {
+			if existing.Fields[i].Type().NonNullableType() != field.Type().NonNullableType() {
+				return frames, fmt.Errorf("field %s of series %s is %s, but %s in the other chunks",
+					field.Name, frame.Name, field.Type().ItemTypeString(), existing.Fields[i].Type().ItemTypeString())
+			}
+		}
+		for i, field := range frame.Fields {
+			if field.Nullable() && !existing.Fields[i].Nullable() {
+				existing.Fields[i] = nullableField(existing.Fields[i])
+			}
+			appendFieldValues(existing.Fields[i], field)
+		}
+		if frame.Meta != nil && len(frame.Meta.Notices) > 0 {
+			if existing.Meta == nil {
+				existing.Meta = &data.FrameMeta{}
+			}
+			existing.Meta.Notices = appendMissingNotices(existing.Meta.Notices, frame.Meta.Notices)
+		}
+		return frames, nil
+	}
+	return append(frames, frame), nil
+}
+
+// nullableField returns a nullable copy of a field.
+func nullableField(field *data.Field) *data.Field {
+	nullable := data.NewFieldFromFieldType(field.Type().NullableType(), field.Len())
+	nullable.Name, nullable.Labels, nullable.Config = field.Name, field.Labels, field.Config
+	for row := 0; row < field.Len(); row++ {
+		nullable.SetConcrete(row, field.At(row))
+	}
+	return nullable
+}
+
+// appendFieldValues appends the values of a field to a field of the same type, or of its nullable type.
+func appendFieldValues(to *data.Field, from *data.Field) {
+	for row := 0; row < from.Len(); row++ {
+		if to.Nullable() && !from.Nullable() {
+			to.Extend(1)
+			to.SetConcrete(to.Len()-1, from.At(row))
+			continue
+		}
+		to.Append(from.At(row))
+	}
+}
+
+// chunkFrameKey identifies the series in a frame, so that the frames of
+// different chunks for the same series can be found. The types of the fields
+// are left out, so that the frames of a series whose types differ between
+// chunks are found too.
+func chunkFrameKey(frame *data.Frame) string {
+	var sb strings.Builder
+	sb.WriteString(frame.Name)
+	for _, field := range frame.Fields {
+		sb.WriteString("\x00")
+		sb.WriteString(field.Name)
+		sb.WriteString("\x00")
+		sb.WriteString(field.Labels.String())
+	}
+	return sb.String()
+}
+
+// appendMissingNotices keeps warnings that Prometheus returns for every chunk from being repeated.
+func appendMissingNotices(notices []data.Notice, others []data.Notice) []data.Notice {
+	for _, other := range others {
+		found := false
+		for _, notice := range notices {
+			if notice.Severity == other.Severity && notice.Text == other.Text {
+				found = true
+				break
+			}
+		}
+		if !found {
+			notices = append(notices, other)
+		}
+	}
+	return notices
+}
@@ -0,0 +1,69 @@
+package querydata
+
+import (
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/tsdb/prometheus/models"
+)
+
+func TestMergeChunkResults(t *testing.T) {
+	from := time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC)
+	q := &models.Query{Step: time.Minute, Start: from, End: from.Add(3 * time.Minute)}
+	chunk := func(start time.Time, values *data.Field) chunkResult {
+		values.Name = "Value"
+		values.Labels = data.Labels{"job": "a"}
+		frame := data.NewFrame("up", data.NewField("Time", nil, []time.Time{start, start.Add(time.Minute)}), values)
+		return chunkResult{
+			query:    &models.Query{Step: time.Minute, Start: start, End: start.Add(time.Minute)},
+			response: &backend.DataResponse{Frames: data.Frames{frame}},
+		}
+	}
+	one := 1.0
+
+	t.Run("nullable and non nullable fields are merged as nullable", func(t *testing.T) {
+		res := mergeChunkResults(q, []chunkResult{
+			chunk(from, data.NewField("", nil, []float64{1, 2})),
+			chunk(from.Add(2*time.Minute), data.NewField("", nil, []*float64{nil, &one})),
+		})
+
+		require.NoError(t, res.Error)
+		require.Len(t, res.Frames, 1)
+		values := res.Frames[0].Fields[1]
+		require.Equal(t, data.FieldTypeNullableFloat64, values.Type())
+		require.Equal(t, 4, values.Len())
+		require.Equal(t, 2.0, *values.At(1).(*float64))
+		require.Nil(t, values.At(2))
+		require.Equal(t, 1.0, *values.At(3).(*float64))
+		require.Equal(t, data.Labels{"job": "a"}, values.Labels)
+		require.Nil(t, res.Frames[0].Meta)
+	})
+
+	t.Run("fields of different types are reported", func(t *testing.T) {
+		res := mergeChunkResults(q, []chunkResult{
+			chunk(from, data.NewField("", nil, []float64{1, 2})),
+			chunk(from.Add(2*time.Minute), data.NewField("", nil, []string{"a", "b"})),
+		})
+
+		require.NoError(t, res.Error)
+		require.Len(t, res.Frames, 1)
+		require.Equal(t, 2, res.Frames[0].Rows())
+		require.Len(t, res.Frames[0].Meta.Notices, 1)
+		require.Equal(t, data.NoticeSeverityWarning, res.Frames[0].Meta.Notices[0].Severity)
+		require.Contains(t, res.Frames[0].Meta.Notices[0].Text, "field Value of series up is string, but float64 in the other chunks")
+	})
+}
+
+func TestGetQueryChunkSettings(t *testing.T) {
+	_, concurrency, err := getQueryChunkSettings(map[string]interface{}{"queryChunkConcurrency": float64(1000)})
+	require.NoError(t, err)
+	require.Equal(t, maxChunkConcurrency, concurrency)
+
+	_, concurrency, err = getQueryChunkSettings(map[string]interface{}{})
+	require.NoError(t, err)
+	require.Equal(t, defaultChunkConcurrency, concurrency)
+}
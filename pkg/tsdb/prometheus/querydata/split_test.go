@@ -0,0 +1,159 @@
+package querydata_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log/logtest"
+	"github.com/grafana/grafana/pkg/infra/tracing"
+	"github.com/grafana/grafana/pkg/tsdb/prometheus/models"
+	"github.com/grafana/grafana/pkg/tsdb/prometheus/querydata"
+)
+
+func TestPrometheus_splitRangeQuery(t *testing.T) {
+	from := time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(10 * time.Hour)
+
+	t.Run("chunks are merged into one frame per series", func(t *testing.T) {
+		rt := &chunkRoundTripper{}
+		res := executeSplitQuery(t, rt, `{"queryChunkInterval": "3h"}`, from, to)
+		require.NoError(t, res.Error)
+
+		require.Equal(t, []int64{0, 10800, 21600, 32400}, rt.sortedStarts())
+		require.Len(t, res.Frames, 2)
+		for _, frame := range res.Frames {
+			require.Equal(t, 601, frame.Rows())
+			require.Equal(t, from, frame.Fields[0].At(0))
+			require.Equal(t, to, frame.Fields[0].At(600))
+			require.Nil(t, frame.Meta.Notices)
+		}
+		require.Equal(t, "a", res.Frames[0].Fields[1].Labels["job"])
+		require.Equal(t, "b", res.Frames[1].Fields[1].Labels["job"])
+	})
+
+	t.Run("short queries are not split", func(t *testing.T) {
+		rt := &chunkRoundTripper{}
+		res := executeSplitQuery(t, rt, `{"queryChunkInterval": "1d"}`, from, to)
+		require.NoError(t, res.Error)
+		require.Equal(t, []int64{0}, rt.sortedStarts())
+		require.Equal(t, 601, res.Frames[0].Rows())
+	})
+
+	t.Run("failed chunks return partial results with a notice", func(t *testing.T) {
+		rt := &chunkRoundTripper{failStart: map[int64]bool{from.Add(3 * time.Hour).Unix(): true}}
+		res := executeSplitQuery(t, rt, `{"queryChunkInterval": "3h", "queryChunkConcurrency": 1}`, from, to)
+		require.NoError(t, res.Error)
+
+		require.Len(t, res.Frames, 2)
+		require.Equal(t, 601-180, res.Frames[0].Rows())
+		require.Len(t, res.Frames[0].Meta.Notices, 1)
+		require.Equal(t, data.NoticeSeverityWarning, res.Frames[0].Meta.Notices[0].Severity)
+		require.Contains(t, res.Frames[0].Meta.Notices[0].Text, "2022-11-01T03:00:00Z to 2022-11-01T05:59:00Z failed")
+	})
+
+	t.Run("the query fails when all chunks fail", func(t *testing.T) {
+		rt := &chunkRoundTripper{failStart: map[int64]bool{}}
+		for start := from; start.Before(to); start = start.Add(3 * time.Hour) {
+			rt.failStart[start.Unix()] = true
+		}
+		res := executeSplitQuery(t, rt, `{"queryChunkInterval": "3h"}`, from, to)
+		require.Error(t, res.Error)
+		require.Empty(t, res.Frames)
+	})
+
+	t.Run("invalid settings", func(t *testing.T) {
+		_, err := querydata.New(&http.Client{}, &fakeFeatureToggles{}, tracing.InitializeTracerForTest(), backend.DataSourceInstanceSettings{
+			URL:      "http://localhost:9090",
+			JSONData: json.RawMessage(`{"queryChunkInterval": "3h", "queryChunkConcurrency": 0}`),
+		}, &logtest.Fake{})
+		require.Error(t, err)
+	})
+}
+
+func executeSplitQuery(t *testing.T, rt *chunkRoundTripper, jsonData string, from, to time.Time) backend.DataResponse {
+	t.Helper()
+
+	settings := backend.DataSourceInstanceSettings{
+		URL:      "http://localhost:9090",
+		JSONData: json.RawMessage(jsonData),
+	}
+	queryData, err := querydata.New(&http.Client{Transport: rt}, &fakeFeatureToggles{}, tracing.InitializeTracerForTest(), settings, &logtest.Fake{})
+	require.NoError(t, err)
+
+	b, err := json.Marshal(&models.QueryModel{Expr: "up", Interval: "1m", RangeQuery: true})
+	require.NoError(t, err)
+
+	res, err := queryData.Execute(context.Background(), &backend.QueryDataRequest{
+		Queries: []backend.DataQuery{{
+			RefID:         "A",
+			MaxDataPoints: 1000,
+			TimeRange:     backend.TimeRange{From: from, To: to},
+			JSON:          b,
+		}},
+	})
+	require.NoError(t, err)
+	return res.Responses["A"]
+}
+
+// chunkRoundTripper answers range queries with a value at every step for two series.
+type chunkRoundTripper struct {
+	mu        sync.Mutex
+	starts    []int64
+	failStart map[int64]bool
+}
+
+func (rt *chunkRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := req.ParseForm(); err != nil {
+		return nil, err
+	}
+	start, _ := strconv.ParseInt(req.Form.Get("start"), 10, 64)
+	end, _ := strconv.ParseInt(req.Form.Get("end"), 10, 64)
+	step, _ := strconv.ParseInt(req.Form.Get("step"), 10, 64)
+
+	rt.mu.Lock()
+	rt.starts = append(rt.starts, start-time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC).Unix())
+	rt.mu.Unlock()
+
+	if rt.failStart[start] {
+		return &http.Response{
+			StatusCode: http.StatusServiceUnavailable,
+			Body:       io.NopCloser(strings.NewReader(`{"status":"error","errorType":"timeout","error":"query timed out"}`)),
+		}, nil
+	}
+
+	var values []string
+	for ts := start; ts <= end; ts += step {
+		values = append(values, fmt.Sprintf(`[%d,"1"]`, ts))
+	}
+	series := strings.Join(values, ",")
+	body := fmt.Sprintf(`{"status":"success","data":{"resultType":"matrix","result":[`+
+		`{"metric":{"job":"a"},"values":[%s]},{"metric":{"job":"b"},"values":[%s]}]}}`, series, series)
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewReader([]byte(body))),
+	}, nil
+}
+
+func (rt *chunkRoundTripper) sortedStarts() []int64 {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	starts := append([]int64{}, rt.starts...)
+	sort.Slice(starts, func(i, j int) bool { return starts[i] < starts[j] })
+	return starts
+}
@@ -0,0 +1,43 @@
+import { render, screen, waitFor } from '@testing-library/react';
+import userEvent from '@testing-library/user-event';
+import React from 'react';
+
+import { backendSrv } from 'app/core/services/backend_srv';
+
+import { PublicDashboardEmailPrompt, testIds } from './PublicDashboardEmailPrompt';
+
+jest.mock('@grafana/runtime', () => ({
+  ...jest.requireActual('@grafana/runtime'),
+  getBackendSrv: () => backendSrv,
+}));
+
+describe('PublicDashboardEmailPrompt', () => {
+  beforeEach(() => {
+    jest.clearAllMocks();
+  });
+
+  it('sends a link to the email address', async () => {
+    const postMock = jest.spyOn(backendSrv, 'post').mockResolvedValue({});
+    render(<PublicDashboardEmailPrompt accessToken="abc" />);
+
+    await userEvent.type(screen.getByTestId(testIds.email), 'viewer@example.com');
+    await userEvent.click(screen.getByTestId(testIds.submit));
+
+    await waitFor(() => expect(screen.getByText('Check your email')).toBeInTheDocument());
+    expect(postMock).toHaveBeenCalledWith(
+      '/api/public/dashboards/abc/magic-link',
+      { email: 'viewer@example.com' },
+      { showErrorAlert: false }
+    );
+  });
+
+  it('shows the error of the request', async () => {
+    jest.spyOn(backendSrv, 'post').mockRejectedValue({ status: 400, data: { message: 'Invalid email address' } });
+    render(<PublicDashboardEmailPrompt accessToken="abc" />);
+
+    await userEvent.type(screen.getByTestId(testIds.email), 'viewer@example.com');
+    await userEvent.click(screen.getByTestId(testIds.submit));
+
+    await waitFor(() => expect(screen.getByText('Invalid email address')).toBeInTheDocument());
+  });
+});
@@ -0,0 +1,83 @@
+import { css } from '@emotion/css';
+import React, { FormEvent, useState } from 'react';
+
+import { GrafanaTheme2 } from '@grafana/data';
+import { getBackendSrv, isFetchError } from '@grafana/runtime';
+import { Alert, Button, Field, Input, useStyles2 } from '@grafana/ui';
+
+export interface Props {
+  accessToken: string;
+}
+
+/**
+ * Asks a viewer of a public dashboard shared with email addresses for their address, to send them a link that
+ * verifies it.
+ */
+export const PublicDashboardEmailPrompt = ({ accessToken }: Props) => {
+  const styles = useStyles2(getStyles);
+  const [email, setEmail] = useState('');
+  const [isSending, setIsSending] = useState(false);
+  const [sent, setSent] = useState(false);
+  const [error, setError] = useState<string>();
+
+  const onSubmit = async (event: FormEvent) => {
+    event.preventDefault();
+    setIsSending(true);
+    setError(undefined);
+    try {
+      await getBackendSrv().post(
+        `/api/public/dashboards/${accessToken}/magic-link`,
+        { email },
+        { showErrorAlert: false }
+      );
+      setSent(true);
+    } catch (err) {
+      setError((isFetchError(err) && err.data?.message) || 'Failed to send the link');
+    } finally {
+      setIsSending(false);
+    }
+  };
+
+  return (
+    <div className={styles.container} data-testid={testIds.container}>
+      <h2>This dashboard is shared with specific people</h2>
+      {sent ? (
+        <Alert severity="success" title="Check your email">
+          If {email} can view this dashboard, we sent it a link to open it. The link expires in 15 minutes.
+        </Alert>
+      ) : (
+        <form onSubmit={onSubmit}>
+          <p>Enter your email address to receive a link to view it.</p>
+          {error && <Alert severity="error" title={error} />}
+          <Field label="Email address">
+            <Input
+              data-testid={testIds.email}
+              type="email"
+              required
+              placeholder="name@example.com"
+              value={email}
+              onChange={(e) => setEmail(e.currentTarget.value)}
+            />
+          </Field>
+          <Button type="submit" disabled={isSending || email === ''} data-testid={testIds.submit}>
+            Send link
+          </Button>
+        </form>
+      )}
+    </div>
+  );
+};
+
+const getStyles = (theme: GrafanaTheme2) => ({
+  container: css`
+    max-width: 480px;
+    margin: ${theme.spacing(8, 'auto')};
+    padding: ${theme.spacing(0, 2)};
+  `,
+});
+
+export const testIds = {
+  container: 'public-dashboard-email-prompt',
+  email: 'public-dashboard-email-prompt-email',
+  submit: 'public-dashboard-email-prompt-submit',
+};
@@ -1,21 +1,48 @@
 import { css } from '@emotion/css';
 import React from 'react';
 
-import { GrafanaTheme2 } from '@grafana/data/src';
+import { dateTime, GrafanaTheme2 } from '@grafana/data/src';
 import { selectors as e2eSelectors } from '@grafana/e2e-selectors/src';
 import { reportInteraction } from '@grafana/runtime/src';
-import { FieldSet, Label, Switch, TimeRangeInput, useStyles2, VerticalGroup } from '@grafana/ui/src';
+import {
+  DateTimePicker,
+  FieldSet,
+  Label,
+  RadioButtonGroup,
+  Switch,
+  TextArea,
+  TimeRangeInput,
+  useStyles2,
+  VerticalGroup,
+} from '@grafana/ui/src';
 import { Layout } from '@grafana/ui/src/components/Layout/Layout';
 import { DashboardModel } from 'app/features/dashboard/state';
 import { useIsDesktop } from 'app/features/dashboard/utils/screen';
 import { getTimeRange } from 'app/features/dashboard/utils/timeRange';
 
+import { PublicDashboardShareType } from './SharePublicDashboardUtils';
+
+const shareTypeOptions: Array<{ label: string; value: PublicDashboardShareType; description: string }> = [
+  { label: 'Anyone with the link', value: 'public', description: 'Anyone with the link can view the dashboard' },
+  {
+    label: 'Only specified people',
+    value: 'email',
+    description: 'Only the people with the email addresses below can view the dashboard, after verifying their address',
+  },
+];
+
 export const Configuration = ({
   isAnnotationsEnabled,
   disabled,
   isPubDashEnabled,
   onToggleEnabled,
   onToggleAnnotations,
+  shareType,
+  onChangeShareType,
+  recipients,
+  onChangeRecipients,
+  expiresAt,
+  onChangeExpiresAt,
   dashboard,
 }: {
   isAnnotationsEnabled: boolean;
@@ -23,6 +50,12 @@ export const Configuration = ({
   isPubDashEnabled?: boolean;
   onToggleEnabled: () => void;
   onToggleAnnotations: () => void;
+  shareType: PublicDashboardShareType;
+  onChangeShareType: (shareType: PublicDashboardShareType) => void;
+  recipients: string;
+  onChangeRecipients: (recipients: string) => void;
+  expiresAt?: string;
+  onChangeExpiresAt: (expiresAt?: string) => void;
   dashboard: DashboardModel;
 }) => {
   const selectors = e2eSelectors.pages.ShareDashboardModal.PublicDashboard;
@@ -53,6 +86,48 @@ export const Configuration = ({
               }}
             />
           </Layout>
+          <Layout orientation={isDesktop ? 0 : 1} spacing="xs" justify="space-between">
+            <Label description="Who can view the public dashboard">Access</Label>
+            <div data-testid={selectors.ShareTypeRadio}>
+              <RadioButtonGroup
+                options={shareTypeOptions}
+                value={shareType}
+                disabled={disabled}
+                onChange={onChangeShareType}
+              />
+            </div>
+          </Layout>
+          {shareType === 'email' && (
+            <VerticalGroup spacing="xs">
+              <Label description="The email addresses of the people who can view the public dashboard, one per line. They need SMTP to be configured to receive their link.">
+                Recipients
+              </Label>
+              <TextArea
+                data-testid={selectors.RecipientsTextArea}
+                className={styles.recipients}
+                rows={4}
+                placeholder="name@example.com"
+                value={recipients}
+                onChange={(e) => onChangeRecipients(e.currentTarget.value)}
+              />
+            </VerticalGroup>
+          )}
+          <Layout orientation={isDesktop ? 0 : 1} spacing="xs" justify="space-between">
+            <Label description="The public dashboard stops working at this time">Expires</Label>
+            <Layout spacing="sm">
+              <Switch
+                data-testid={selectors.ExpiresSwitch}
+                value={!!expiresAt}
+                onChange={() => onChangeExpiresAt(expiresAt ? undefined : dateTime().add(7, 'd').toISOString())}
+              />
+              {expiresAt && (
+                <DateTimePicker
+                  date={dateTime(expiresAt)}
+                  onChange={(date) => onChangeExpiresAt(date.toISOString())}
+                />
+              )}
+            </Layout>
+          </Layout>
           <Layout orientation={isDesktop ? 0 : 1} spacing="xs" justify="space-between">
             <Label description="Configures whether current dashboard can be available publicly">Enabled</Label>
             <Switch
@@ -77,4 +152,7 @@ const getStyles = (theme: GrafanaTheme2) => ({
   dashboardConfig: css`
     margin: ${theme.spacing(0, 0, 3, 0)};
   `,
+  recipients: css`
+    width: 100%;
+  `,
 });
@@ -32,7 +32,9 @@ import {
   dashboardHasTemplateVariables,
   generatePublicDashboardUrl,
   getUnsupportedDashboardDatasources,
+  parseRecipients,
   publicDashboardPersisted,
+  PublicDashboardShareType,
 } from 'app/features/dashboard/components/ShareModal/SharePublicDashboard/SharePublicDashboardUtils';
 import { ShareModalTabProps } from 'app/features/dashboard/components/ShareModal/types';
 import { useIsDesktop } from 'app/features/dashboard/utils/screen';
@@ -78,6 +80,9 @@ export const SharePublicDashboard = (props: Props) => {
     wasTouched: false,
   });
   const [annotationsEnabled, setAnnotationsEnabled] = useState(false);
+  const [shareType, setShareType] = useState<PublicDashboardShareType>('public');
+  const [recipients, setRecipients] = useState('');
+  const [expiresAt, setExpiresAt] = useState<string | undefined>();
 
   useEffect(() => {
     const eventSubs = new Subscription();
@@ -95,6 +100,9 @@ export const SharePublicDashboard = (props: Props) => {
         usage: true,
       });
       setAnnotationsEnabled(!!publicDashboard?.annotationsEnabled);
+      setShareType(publicDashboard?.share ?? 'public');
+      setRecipients((publicDashboard?.recipients ?? []).join('\n'));
+      setExpiresAt(publicDashboard?.expiresAt);
     }
 
     setEnabledSwitch((prevState) => ({ ...prevState, isEnabled: !!publicDashboard?.isEnabled }));
@@ -111,7 +119,8 @@ export const SharePublicDashboard = (props: Props) => {
       isLoading ||
       isFetching ||
       isGetError ||
-      (!publicDashboardPersisted(publicDashboard) && !enabledSwitch.wasTouched),
+      (!publicDashboardPersisted(publicDashboard) && !enabledSwitch.wasTouched) ||
+      (shareType === 'email' && parseRecipients(recipients).length === 0),
     [
       hasWritePermissions,
       acknowledged,
@@ -121,6 +130,8 @@ export const SharePublicDashboard = (props: Props) => {
       enabledSwitch,
       publicDashboard,
       isFetching,
+      shareType,
+      recipients,
     ]
   );
   const isDeleteDisabled = isLoading || isFetching || isGetError;
@@ -130,7 +141,14 @@ export const SharePublicDashboard = (props: Props) => {
 
     const req = {
       dashboard: props.dashboard,
-      payload: { ...publicDashboard!, isEnabled: enabledSwitch.isEnabled, annotationsEnabled },
+      payload: {
+        ...publicDashboard!,
+        isEnabled: enabledSwitch.isEnabled,
+        annotationsEnabled,
+        share: shareType,
+        recipients: shareType === 'email' ? parseRecipients(recipients) : [],
+        expiresAt,
+      },
     };
 
     // create or update based on whether we have existing uid
@@ -208,6 +226,12 @@ export const SharePublicDashboard = (props: Props) => {
                 setEnabledSwitch((prevState) => ({ isEnabled: !prevState.isEnabled, wasTouched: true }))
               }
               onToggleAnnotations={() => setAnnotationsEnabled((prevState) => !prevState)}
+              shareType={shareType}
+              onChangeShareType={setShareType}
+              recipients={recipients}
+              onChangeRecipients={setRecipients}
+              expiresAt={expiresAt}
+              onChangeExpiresAt={setExpiresAt}
             />
             {publicDashboardPersisted(publicDashboard) && enabledSwitch.isEnabled && (
               <Field label="Link URL" className={styles.publicUrl}>
@@ -6,6 +6,8 @@ import { PanelModel } from '../../../state';
 
 import { supportedDatasources } from './SupportedPubdashDatasources';
 
+export type PublicDashboardShareType = 'public' | 'email';
+
 export interface PublicDashboard {
   accessToken?: string;
   annotationsEnabled: boolean;
@@ -13,6 +15,10 @@ export interface PublicDashboard {
   uid: string;
   dashboardUid: string;
   timeSettings?: object;
+  share?: PublicDashboardShareType;
+  recipients?: string[];
+  expiresAt?: string;
+  viewCount?: number;
 }
 
 export interface DashboardResponse {
@@ -35,6 +41,16 @@ export const publicDashboardPersisted = (publicDashboard?: PublicDashboard): boo
   return publicDashboard?.uid !== '' && publicDashboard?.uid !== undefined;
 };
 
+/**
+ * Parse the recipients of a public dashboard shared with emails, separated by commas, spaces or new lines.
+ */
+export const parseRecipients = (recipients: string): string[] => {
+  return recipients
+    .split(/[\s,;]+/)
+    .map((email) => email.trim())
+    .filter((email) => email !== '');
+};
+
 /**
  * Get unique datasource names from all panels that are not currently supported by public dashboards.
  */
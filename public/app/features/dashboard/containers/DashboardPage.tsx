@@ -28,6 +28,7 @@ import { DashboardPrompt } from '../components/DashboardPrompt/DashboardPrompt';
 import { DashboardSettings } from '../components/DashboardSettings';
 import { PanelInspector } from '../components/Inspector/PanelInspector';
 import { PanelEditor } from '../components/PanelEditor/PanelEditor';
+import { PublicDashboardEmailPrompt } from '../components/PublicDashboardEmailPrompt/PublicDashboardEmailPrompt';
 import { PublicDashboardFooter } from '../components/PublicDashboardFooter/PublicDashboardsFooter';
 import { SubMenu } from '../components/SubMenu/SubMenu';
 import { DashboardGrid } from '../dashgrid/DashboardGrid';
@@ -349,6 +350,10 @@ export class UnthemedDashboardPage extends PureComponent<Props, State> {
       return <DashboardLoading initPhase={this.props.initPhase} />;
     }
 
+    if (isPublic && dashboard.meta.publicDashboardEmailRequired) {
+      return <PublicDashboardEmailPrompt accessToken={this.props.match.params.accessToken!} />;
+    }
+
     const inspectPanel = this.getInspectPanel();
     const showSubMenu = !editPanel && !kioskMode && !this.props.queryParams.editview;
 
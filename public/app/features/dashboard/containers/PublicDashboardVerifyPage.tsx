@@ -0,0 +1,65 @@
+import { css } from '@emotion/css';
+import React, { useState } from 'react';
+
+import { GrafanaTheme2 } from '@grafana/data';
+import { getBackendSrv, isFetchError, locationService } from '@grafana/runtime';
+import { Alert, Button, useStyles2 } from '@grafana/ui';
+
+import { GrafanaRouteComponentProps } from '../../../core/navigation/types';
+
+export type Props = GrafanaRouteComponentProps<{ accessToken: string }, { code?: string }>;
+
+/**
+ * Opened by the magic link sent to a recipient of a public dashboard shared with email addresses. The code of the
+ * link is only used when the recipient confirms, so that the clients that open the links of emails don't use it.
+ */
+const PublicDashboardVerifyPage = ({ match, queryParams }: Props) => {
+  const styles = useStyles2(getStyles);
+  const { accessToken } = match.params;
+  const [isVerifying, setIsVerifying] = useState(false);
+  const [error, setError] = useState<string>();
+
+  const onVerify = async () => {
+    setIsVerifying(true);
+    setError(undefined);
+    try {
+      await getBackendSrv().post(
+        `/api/public/dashboards/${accessToken}/verify`,
+        { code: queryParams.code },
+        { showErrorAlert: false }
+      );
+      locationService.push(`/public-dashboards/${accessToken}`);
+    } catch (err) {
+      setError((isFetchError(err) && err.data?.message) || 'Failed to verify your email address');
+      setIsVerifying(false);
+    }
+  };
+
+  return (
+    <div className={styles.container}>
+      <h2>View the shared dashboard</h2>
+      {error && (
+        <Alert severity="error" title={error}>
+          The link may have expired or already been used. Open the dashboard to request a new one.
+        </Alert>
+      )}
+      <Button onClick={onVerify} disabled={isVerifying || !queryParams.code} data-testid={testIds.verify}>
+        Open dashboard
+      </Button>
+    </div>
+  );
+};
+
+const getStyles = (theme: GrafanaTheme2) => ({
+  container: css`
+    max-width: 480px;
+    margin: ${theme.spacing(8, 'auto')};
+    padding: ${theme.spacing(0, 2)};
+  `,
+});
+
+export const testIds = {
+  verify: 'public-dashboard-verify',
+};
+
+export default PublicDashboardVerifyPage;
@@ -17,6 +17,16 @@ export const getPublicDashboardRoutes = (): RouteDescriptor[] => {
             )
         ),
       },
+      {
+        path: '/public-dashboards/:accessToken/verify',
+        routeName: DashboardRoutes.Public,
+        component: SafeDynamicImport(
+          () =>
+            import(
+              /* webpackChunkName: "PublicDashboardVerifyPage" */ '../../features/dashboard/containers/PublicDashboardVerifyPage'
+            )
+        ),
+      },
       {
         path: '/public-dashboards/:accessToken',
         pageClass: 'page-dashboard',
@@ -51,7 +51,12 @@ export class DashboardLoaderSrv {
         .then((result: any) => {
           return result;
         })
-        .catch(() => {
+        .catch((err) => {
+          // the viewers of a public dashboard shared with email addresses are asked for theirs
+          if (err?.data?.messageId === 'publicdashboards.emailVerificationRequired') {
+            const result = this._dashboardLoadFailed('Email verification required', true);
+            return { ...result, meta: { ...result.meta, publicDashboardEmailRequired: true } };
+          }
           return this._dashboardLoadFailed('Public Dashboard Not found', true);
         });
     } else {
@@ -23,6 +23,12 @@ const backendSrv = {
 jest.mock('@grafana/runtime', () => ({
   ...jest.requireActual('@grafana/runtime'),
   getBackendSrv: () => backendSrv,
+  getTemplateSrv: () => ({
+    getVariables: () => [
+      { name: 'env', type: 'custom', current: { text: 'prod', value: 'prod' } },
+      { name: 'job', type: 'constant', query: 'api' },
+    ],
+  }),
   getDataSourceSrv: () => {
     return {
       getInstanceSettings: (ref?: DataSourceRef) => ({ type: ref?.type ?? '?', uid: ref?.uid ?? '?' }),
@@ -96,6 +102,7 @@ describe('PublicDashboardDatasource', () => {
     expect(mock.lastCall[0].url).toEqual(
       `/api/public/dashboards/${publicDashboardAccessToken}/panels/${panelId}/query`
     );
+    expect(mock.lastCall[0].data).toEqual({ intervalMs: 5000, maxDataPoints: 10, variables: { env: 'prod' } });
   });
 
   test('returns public datasource uid when datasource passed in is null', () => {
@@ -11,7 +11,7 @@ import {
   DataSourceRef,
   toDataFrame,
 } from '@grafana/data';
-import { BackendDataSourceResponse, getBackendSrv, toDataQueryResponse } from '@grafana/runtime';
+import { BackendDataSourceResponse, getBackendSrv, getTemplateSrv, toDataQueryResponse } from '@grafana/runtime';
 
 import { GrafanaQueryType } from '../../../plugins/datasource/grafana/types';
 import { MIXED_DATASOURCE_NAME } from '../../../plugins/datasource/mixed/MixedDataSource';
@@ -76,6 +76,22 @@ export class PublicDashboardDataSource extends DataSourceApi<DataQuery, DataSour
     return interval ?? DEFAULT_INTERVAL;
   }
 
+  /**
+   * Get the values of the template variables viewers can change. The other variables are constants the backend
+   * interpolates.
+   */
+  private static getVariables(): Record<string, string> {
+    const variables: Record<string, string> = {};
+    for (const variable of getTemplateSrv().getVariables()) {
+      if (variable.type === 'custom') {
+        const value = variable.current.value;
+        variables[variable.name] = Array.isArray(value) ? value[0] : value;
+      }
+    }
+
+    return variables;
+  }
+
   /**
    * Ideally final -- any other implementation may not work as expected
    */
@@ -100,7 +116,7 @@ export class PublicDashboardDataSource extends DataSourceApi<DataQuery, DataSour
 
     // Its a datasource query
     else {
-      const body = { intervalMs, maxDataPoints };
+      const body = { intervalMs, maxDataPoints, variables: PublicDashboardDataSource.getVariables() };
 
       return getBackendSrv()
         .fetch<BackendDataSourceResponse>({
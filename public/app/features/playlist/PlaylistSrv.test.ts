@@ -4,10 +4,8 @@ import configureMockStore from 'redux-mock-store';
 import { locationService } from '@grafana/runtime';
 import { setStore } from 'app/store/store';
 
-import { DashboardQueryResult } from '../search/service';
-
 import { PlaylistSrv } from './PlaylistSrv';
-import { Playlist, PlaylistItem } from './types';
+import { Playlist, PlaylistDashboard } from './types';
 
 jest.mock('./api', () => ({
   getPlaylist: jest.fn().mockReturnValue({
@@ -18,13 +16,11 @@ jest.mock('./api', () => ({
       { type: 'dashboard_by_uid', value: 'bbb' },
     ],
   } as Playlist),
-  loadDashboards: (items: PlaylistItem[]) => {
-    return Promise.resolve(
-      items.map((v) => ({
-        ...v, // same item with dashboard URLs filled in
-        dashboards: [{ url: `/url/to/${v.value}` } as unknown as DashboardQueryResult],
-      }))
-    );
+  loadPlaylistDashboards: () => {
+    return Promise.resolve([
+      { url: '/url/to/aaa' },
+      { url: '/url/to/bbb', timeFrom: 'now-1h', refresh: '1m', kiosk: 'tv' },
+    ] as PlaylistDashboard[]);
   },
 }));
 
@@ -137,4 +133,18 @@ describe('PlaylistSrv', () => {
     expect((srv as any).validPlaylistUrl).toBe('/url/to/bbb');
     expect(srv.isPlaying).toBe(true);
   });
+
+  it('applies the settings overridden by the playlist items', async () => {
+    await srv.start('foo');
+
+    expect(locationService.getSearchObject()).toEqual({});
+
+    srv.next();
+
+    expect(locationService.getSearchObject()).toEqual({ from: 'now-1h', to: 'now', refresh: '1m', kiosk: 'tv' });
+
+    srv.next();
+
+    expect(locationService.getSearchObject()).toEqual({});
+  });
 });
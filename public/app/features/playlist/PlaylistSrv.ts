@@ -1,10 +1,11 @@
 import { Location } from 'history';
 import { pickBy } from 'lodash';
 
-import { locationUtil, urlUtil, rangeUtil } from '@grafana/data';
+import { locationUtil, urlUtil, rangeUtil, UrlQueryValue } from '@grafana/data';
 import { locationService } from '@grafana/runtime';
 
-import { getPlaylist, loadDashboards } from './api';
+import { getPlaylist, loadPlaylistDashboards } from './api';
+import { PlaylistDashboard } from './types';
 
 export const queryParamsToPreserve: { [key: string]: boolean } = {
   kiosk: true,
@@ -14,12 +15,13 @@ export const queryParamsToPreserve: { [key: string]: boolean } = {
 
 export class PlaylistSrv {
   private nextTimeoutId: ReturnType<typeof setTimeout> | undefined;
-  private urls: string[] = []; // the URLs we need to load
+  private dashboards: PlaylistDashboard[] = []; // the dashboards we need to load
   private index = 0;
   private declare interval: number;
   private declare startUrl: string;
   private numberOfLoops = 0;
   private declare validPlaylistUrl: string;
+  private declare startKiosk: UrlQueryValue; // the kiosk mode the playlist was started in
   private locationListenerUnsub?: () => void;
 
   isPlaying = false;
@@ -31,7 +33,7 @@ export class PlaylistSrv {
   next() {
     clearTimeout(this.nextTimeoutId);
 
-    const playedAllDashboards = this.index > this.urls.length - 1;
+    const playedAllDashboards = this.index > this.dashboards.length - 1;
     if (playedAllDashboards) {
       this.numberOfLoops++;
 
@@ -44,10 +46,26 @@ export class PlaylistSrv {
       this.index = 0;
     }
 
-    const url = this.urls[this.index];
+    const dashboard = this.dashboards[this.index];
     const queryParams = locationService.getSearchObject();
     const filteredParams = pickBy(queryParams, (value: unknown, key: string) => queryParamsToPreserve[key]);
-    const nextDashboardUrl = locationUtil.stripBaseFromUrl(url);
+    const nextDashboardUrl = locationUtil.stripBaseFromUrl(dashboard.url);
+
+    // Settings overridden by the playlist item of the dashboard
+    if (dashboard.timeFrom) {
+      filteredParams.from = dashboard.timeFrom;
+      filteredParams.to = dashboard.timeTo ?? 'now';
+    }
+    if (dashboard.refresh) {
+      filteredParams.refresh = dashboard.refresh;
+    }
+    if (dashboard.kiosk) {
+      filteredParams.kiosk = dashboard.kiosk === 'tv' ? 'tv' : true;
+    } else if (this.startKiosk !== undefined) {
+      filteredParams.kiosk = this.startKiosk;
+    } else {
+      delete filteredParams.kiosk;
+    }
 
     this.index++;
     this.validPlaylistUrl = nextDashboardUrl;
@@ -72,13 +90,13 @@ export class PlaylistSrv {
     this.stop();
 
     this.startUrl = window.location.href;
+    this.startKiosk = locationService.getSearchObject().kiosk;
     this.index = 0;
     this.isPlaying = true;
 
     // setup location tracking
     this.locationListenerUnsub = locationService.getHistory().listen(this.locationUpdated);
 
-    const urls: string[] = [];
     let playlist = await getPlaylist(playlistUid);
     if (!playlist.items?.length) {
       // alert
@@ -86,20 +104,12 @@ export class PlaylistSrv {
     }
     this.interval = rangeUtil.intervalToMs(playlist.interval);
 
-    const items = await loadDashboards(playlist.items);
-    for (const item of items) {
-      if (item.dashboards) {
-        for (const dash of item.dashboards) {
-          urls.push(dash.url);
-        }
-      }
-    }
-
-    if (!urls.length) {
+    const dashboards = await loadPlaylistDashboards(playlistUid);
+    if (!dashboards.length) {
       // alert... not found, etc
       return;
     }
-    this.urls = urls;
+    this.dashboards = dashboards;
     this.isPlaying = true;
     this.next();
     return;
@@ -30,7 +30,10 @@ export const PlaylistTableRows = ({ items, onDelete }: Props) => {
     const info: ReactNode[] = [];
 
     const first = item.dashboards?.[0];
-    if (!item.dashboards) {
+    if (item.type === 'dashboard_by_search') {
+      icon = 'search';
+      info.push(<span key="info">Search: {item.value}</span>);
+    } else if (!item.dashboards) {
       info.push(<Spinner key="spinner" />);
     } else if (item.type === 'dashboard_by_tag') {
       info.push(<TagBadge key={item.value} label={item.value} removeIcon={false} count={0} />);
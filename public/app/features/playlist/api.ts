@@ -10,7 +10,7 @@ import { dispatch } from 'app/store/store';
 
 import { DashboardQueryResult, getGrafanaSearcher, SearchQuery } from '../search/service';
 
-import { Playlist, PlaylistItem } from './types';
+import { Playlist, PlaylistDashboard, PlaylistItem } from './types';
 
 export async function createPlaylist(playlist: Playlist) {
   await withErrorHandling(() => getBackendSrv().post('/api/playlists', playlist));
@@ -45,6 +45,11 @@ export async function getAllPlaylist(): Promise<Playlist[]> {
   return getBackendSrv().get<Playlist[]>('/api/playlists/');
 }
 
+/** Returns the dashboards of a playlist in order, as resolved by the server */
+export async function loadPlaylistDashboards(uid: string): Promise<PlaylistDashboard[]> {
+  return getBackendSrv().get<PlaylistDashboard[]>(`/api/playlists/${uid}/dashboards`);
+}
+
 async function withErrorHandling(apiCall: () => Promise<void>, message = 'Playlist saved') {
   try {
     await apiCall();
@@ -63,6 +68,13 @@ export async function loadDashboards(items: PlaylistItem[]): Promise<PlaylistIte
     return [];
   }
 
+  // Search items can only be resolved by the server, when the playlist is played
+  if (items.some((item) => item.type === 'dashboard_by_search')) {
+    const loaded = await loadDashboards(items.filter((item) => item.type !== 'dashboard_by_search'));
+    let i = 0;
+    return items.map((item) => (item.type === 'dashboard_by_search' ? { ...item, dashboards: [] } : loaded[i++]));
+  }
+
   const targets: GrafanaQuery[] = [];
   for (const item of items) {
     const query: SearchQuery = {
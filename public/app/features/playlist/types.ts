@@ -22,3 +22,17 @@ export interface PlaylistItem extends PlaylistItemFromSchema {
   // Loaded in the frontend
   dashboards?: DashboardQueryResult[];
 }
+
+/** A dashboard of a playlist, resolved by the server with the settings overridden by its playlist item */
+export interface PlaylistDashboard {
+  id: number;
+  uid: string;
+  slug: string;
+  title: string;
+  url: string;
+  order: number;
+  timeFrom?: string;
+  timeTo?: string;
+  refresh?: string;
+  kiosk?: PlaylistItem['kiosk'];
+}
@@ -43,6 +43,10 @@ const phlarePlugin = async () =>
   await import(/* webpackChunkName: "phlarePlugin" */ 'app/plugins/datasource/phlare/module');
 const parcaPlugin = async () =>
   await import(/* webpackChunkName: "parcaPlugin" */ 'app/plugins/datasource/parca/module');
+const jsonApiPlugin = async () =>
+  await import(/* webpackChunkName: "jsonApiPlugin" */ 'app/plugins/datasource/jsonapi/module');
+const fileDataPlugin = async () =>
+  await import(/* webpackChunkName: "fileDataPlugin" */ 'app/plugins/datasource/filedata/module');
 
 import * as alertGroupsPanel from 'app/plugins/panel/alertGroups/module';
 import * as alertListPanel from 'app/plugins/panel/alertlist/module';
@@ -105,6 +109,8 @@ const builtInPlugins: any = {
   'app/plugins/datasource/alertmanager/module': alertmanagerPlugin,
   'app/plugins/datasource/phlare/module': phlarePlugin,
   'app/plugins/datasource/parca/module': parcaPlugin,
+  'app/plugins/datasource/jsonapi/module': jsonApiPlugin,
+  'app/plugins/datasource/filedata/module': fileDataPlugin,
 
   'app/plugins/panel/text/module': textPanel,
   'app/plugins/panel/timeseries/module': timeseriesPanel,
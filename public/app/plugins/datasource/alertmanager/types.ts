@@ -150,6 +150,8 @@ export type AlertmanagerConfig = {
   mute_time_intervals?: MuteTimeInterval[];
   /** { [name]: provenance } */
   muteTimeProvenances?: Record<string, string>;
+  /** { [uid]: provenance } */
+  inhibitRuleProvenances?: Record<string, string>;
 };
 
 export type Matcher = {
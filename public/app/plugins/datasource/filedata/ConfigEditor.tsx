@@ -0,0 +1,37 @@
+import React from 'react';
+
+import { DataSourcePluginOptionsEditorProps } from '@grafana/data';
+import { InlineField, Input } from '@grafana/ui';
+
+import { FileDataOptions } from './types';
+
+interface Props extends DataSourcePluginOptionsEditorProps<FileDataOptions> {}
+
+export const ConfigEditor = (props: Props) => {
+  const { options, onOptionsChange } = props;
+
+  return (
+    <>
+      <h3 className="page-heading">Storage</h3>
+      <div className="gf-form-group">
+        <InlineField
+          label="Folder"
+          labelWidth={13}
+          tooltip="Storage folder that query paths are relative to. Every viewer of the organization must be able to read it"
+        >
+          <Input
+            className="width-20"
+            value={options.jsonData.path ?? ''}
+            placeholder="content"
+            onChange={(event) =>
+              onOptionsChange({
+                ...options,
+                jsonData: { ...options.jsonData, path: event.currentTarget.value },
+              })
+            }
+          />
+        </InlineField>
+      </div>
+    </>
+  );
+};
@@ -0,0 +1,69 @@
+import { defaults } from 'lodash';
+import React from 'react';
+
+import { QueryEditorProps, SelectableValue } from '@grafana/data';
+import { InlineField, InlineFieldRow, InlineSwitch, Input, Select } from '@grafana/ui';
+
+import { FileDataSource } from './datasource';
+import { defaultQuery, FileDataOptions, FileDataQuery, FileFormat } from './types';
+
+type Props = QueryEditorProps<FileDataSource, FileDataQuery, FileDataOptions>;
+
+const formatOptions: Array<SelectableValue<FileFormat>> = [
+  { label: 'Auto', value: '', description: 'Detected from the file extension' },
+  { label: 'CSV', value: 'csv' },
+  { label: 'NDJSON', value: 'ndjson' },
+  { label: 'Parquet', value: 'parquet' },
+];
+
+export const QueryEditor = (props: Props) => {
+  const { onChange, onRunQuery } = props;
+  const query = defaults(props.query, defaultQuery);
+
+  const update = (changes: Partial<FileDataQuery>, run = false) => {
+    onChange({ ...query, ...changes });
+    if (run) {
+      onRunQuery();
+    }
+  };
+
+  return (
+    <>
+      <InlineFieldRow>
+        <InlineField label="Path" labelWidth={14} grow tooltip="Relative to the folder configured for the data source">
+          <Input
+            value={query.path ?? ''}
+            placeholder="datasets/metrics.csv"
+            onChange={(e) => update({ path: e.currentTarget.value })}
+            onBlur={onRunQuery}
+          />
+        </InlineField>
+        <InlineField label="Format">
+          <Select
+            width={16}
+            options={formatOptions}
+            value={query.format ?? ''}
+            onChange={(option) => update({ format: option.value }, true)}
+          />
+        </InlineField>
+      </InlineFieldRow>
+      <InlineFieldRow>
+        <InlineField label="Time field" labelWidth={14} tooltip="Defaults to the first time column">
+          <Input
+            width={24}
+            value={query.timeField ?? ''}
+            placeholder="auto"
+            onChange={(e) => update({ timeField: e.currentTarget.value })}
+            onBlur={onRunQuery}
+          />
+        </InlineField>
+        <InlineField label="Filter by time range" tooltip="Only return rows inside the dashboard time range">
+          <InlineSwitch
+            value={!query.disableTimeFilter}
+            onChange={(e) => update({ disableTimeFilter: !e.currentTarget.checked }, true)}
+          />
+        </InlineField>
+      </InlineFieldRow>
+    </>
+  );
+};
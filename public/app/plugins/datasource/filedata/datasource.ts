@@ -0,0 +1,21 @@
+import { DataSourceInstanceSettings, ScopedVars } from '@grafana/data';
+import { DataSourceWithBackend, getTemplateSrv } from '@grafana/runtime';
+
+import { FileDataOptions, FileDataQuery } from './types';
+
+export class FileDataSource extends DataSourceWithBackend<FileDataQuery, FileDataOptions> {
+  constructor(instanceSettings: DataSourceInstanceSettings<FileDataOptions>) {
+    super(instanceSettings);
+  }
+
+  filterQuery(query: FileDataQuery): boolean {
+    return !query.hide && Boolean(query.path);
+  }
+
+  applyTemplateVariables(query: FileDataQuery, scopedVars: ScopedVars): FileDataQuery {
+    return {
+      ...query,
+      path: query.path ? getTemplateSrv().replace(query.path, scopedVars) : query.path,
+    };
+  }
+}
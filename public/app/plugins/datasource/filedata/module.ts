@@ -0,0 +1,10 @@
+import { DataSourcePlugin } from '@grafana/data';
+
+import { ConfigEditor } from './ConfigEditor';
+import { QueryEditor } from './QueryEditor';
+import { FileDataSource } from './datasource';
+import { FileDataOptions, FileDataQuery } from './types';
+
+export const plugin = new DataSourcePlugin<FileDataSource, FileDataQuery, FileDataOptions>(FileDataSource)
+  .setConfigEditor(ConfigEditor)
+  .setQueryEditor(QueryEditor);
@@ -0,0 +1,22 @@
+import { DataQuery, DataSourceJsonData } from '@grafana/data';
+
+export type FileFormat = '' | 'csv' | 'ndjson' | 'parquet';
+
+export interface FileDataQuery extends DataQuery {
+  // relative to the folder configured for the data source
+  path?: string;
+  format?: FileFormat;
+  timeField?: string;
+  disableTimeFilter?: boolean;
+}
+
+export const defaultQuery: Partial<FileDataQuery> = {
+  format: '',
+};
+
+/**
+ * These are options configured for each DataSource instance.
+ */
+export interface FileDataOptions extends DataSourceJsonData {
+  path?: string;
+}
@@ -0,0 +1,44 @@
+import React from 'react';
+
+import { DataSourcePluginOptionsEditorProps } from '@grafana/data';
+import { DataSourceHttpSettings, InlineField, Input } from '@grafana/ui';
+
+import { JsonApiOptions } from './types';
+
+interface Props extends DataSourcePluginOptionsEditorProps<JsonApiOptions> {}
+
+export const ConfigEditor = (props: Props) => {
+  const { options, onOptionsChange } = props;
+
+  return (
+    <>
+      <DataSourceHttpSettings
+        defaultUrl="http://localhost:8080"
+        dataSourceConfig={options}
+        showAccessOptions={false}
+        onChange={onOptionsChange}
+      />
+
+      <h3 className="page-heading">Health check</h3>
+      <div className="gf-form-group">
+        <InlineField
+          label="Path"
+          labelWidth={13}
+          tooltip="Path relative to the URL that is requested when testing the data source. Any 2xx response is healthy"
+        >
+          <Input
+            className="width-20"
+            value={options.jsonData.healthCheckPath ?? ''}
+            placeholder="/health"
+            onChange={(event) =>
+              onOptionsChange({
+                ...options,
+                jsonData: { ...options.jsonData, healthCheckPath: event.currentTarget.value },
+              })
+            }
+          />
+        </InlineField>
+      </div>
+    </>
+  );
+};
@@ -0,0 +1,175 @@
+import { defaults } from 'lodash';
+import React from 'react';
+
+import { QueryEditorProps, SelectableValue } from '@grafana/data';
+import { Button, InlineField, InlineFieldRow, Input, RadioButtonGroup, Select, TextArea } from '@grafana/ui';
+
+import { JsonApiDataSource } from './datasource';
+import { defaultQuery, JsonApiField, JsonApiFieldType, JsonApiOptions, JsonApiQuery, KeyValue } from './types';
+
+type Props = QueryEditorProps<JsonApiDataSource, JsonApiQuery, JsonApiOptions>;
+
+const methodOptions: Array<SelectableValue<'GET' | 'POST'>> = [
+  { label: 'GET', value: 'GET' },
+  { label: 'POST', value: 'POST' },
+];
+
+const fieldTypeOptions: Array<SelectableValue<JsonApiFieldType>> = [
+  { label: 'Auto', value: '' },
+  { label: 'String', value: 'string' },
+  { label: 'Number', value: 'number' },
+  { label: 'Boolean', value: 'boolean' },
+  { label: 'Time', value: 'time' },
+];
+
+export const QueryEditor = (props: Props) => {
+  const { onChange, onRunQuery } = props;
+  const query = defaults(props.query, defaultQuery);
+
+  const update = (changes: Partial<JsonApiQuery>, run = false) => {
+    onChange({ ...query, ...changes });
+    if (run) {
+      onRunQuery();
+    }
+  };
+
+  const updateField = (index: number, changes: Partial<JsonApiField>) => {
+    const fields = [...query.fields];
+    fields[index] = { ...fields[index], ...changes };
+    update({ fields });
+  };
+
+  return (
+    <>
+      <InlineFieldRow>
+        <InlineField label="Method" labelWidth={14}>
+          <RadioButtonGroup
+            options={methodOptions}
+            value={query.method}
+            onChange={(method) => update({ method }, true)}
+          />
+        </InlineField>
+        <InlineField
+          label="Path"
+          labelWidth={10}
+          grow
+          tooltip="Appended to the data source URL, and can't go above it with ../. Supports $__from and $__to."
+        >
+          <Input
+            value={query.path ?? ''}
+            placeholder="/api/items"
+            onChange={(e) => update({ path: e.currentTarget.value })}
+            onBlur={onRunQuery}
+          />
+        </InlineField>
+      </InlineFieldRow>
+
+      <KeyValueEditor label="Params" values={query.params ?? []} onChange={(params) => update({ params }, true)} />
+      <KeyValueEditor label="Headers" values={query.headers ?? []} onChange={(headers) => update({ headers }, true)} />
+
+      {query.method === 'POST' && (
+        <InlineFieldRow>
+          <InlineField label="Body" labelWidth={14} grow tooltip="Sent as JSON. Supports $__from and $__to.">
+            <TextArea
+              value={query.body ?? ''}
+              rows={4}
+              onChange={(e) => update({ body: e.currentTarget.value })}
+              onBlur={onRunQuery}
+            />
+          </InlineField>
+        </InlineFieldRow>
+      )}
+
+      {query.fields.map((field, index) => (
+        <InlineFieldRow key={index}>
+          <InlineField label="JSONPath" labelWidth={14} grow>
+            <Input
+              value={field.jsonPath}
+              placeholder="$.items[*].value"
+              onChange={(e) => updateField(index, { jsonPath: e.currentTarget.value })}
+              onBlur={onRunQuery}
+            />
+          </InlineField>
+          <InlineField label="Name">
+            <Input
+              width={20}
+              value={field.name ?? ''}
+              onChange={(e) => updateField(index, { name: e.currentTarget.value })}
+              onBlur={onRunQuery}
+            />
+          </InlineField>
+          <InlineField label="Type">
+            <Select
+              width={14}
+              options={fieldTypeOptions}
+              value={field.type ?? ''}
+              onChange={(option) => {
+                updateField(index, { type: option.value });
+                onRunQuery();
+              }}
+            />
+          </InlineField>
+          <Button
+            variant="secondary"
+            icon="trash-alt"
+            aria-label="Remove field"
+            disabled={query.fields.length === 1}
+            onClick={() => update({ fields: query.fields.filter((_, i) => i !== index) }, true)}
+          />
+        </InlineFieldRow>
+      ))}
+      <Button variant="secondary" icon="plus" onClick={() => update({ fields: [...query.fields, { jsonPath: '' }] })}>
+        Add field
+      </Button>
+    </>
+  );
+};
+
+interface KeyValueEditorProps {
+  label: string;
+  values: KeyValue[];
+  onChange: (values: KeyValue[]) => void;
+}
+
+const KeyValueEditor = ({ label, values, onChange }: KeyValueEditorProps) => {
+  const updateAt = (index: number, changes: Partial<KeyValue>) => {
+    const next = [...values];
+    next[index] = { ...next[index], ...changes };
+    onChange(next);
+  };
+
+  return (
+    <>
+      {values.map((value, index) => (
+        <InlineFieldRow key={index}>
+          <InlineField label={label} labelWidth={14}>
+            <Input
+              width={24}
+              value={value.key}
+              placeholder="key"
+              onChange={(e) => updateAt(index, { key: e.currentTarget.value })}
+            />
+          </InlineField>
+          <InlineField grow>
+            <Input
+              value={value.value}
+              placeholder="value"
+              onChange={(e) => updateAt(index, { value: e.currentTarget.value })}
+            />
+          </InlineField>
+          <Button
+            variant="secondary"
+            icon="trash-alt"
+            aria-label={`Remove ${label.toLowerCase()}`}
+            onClick={() => onChange(values.filter((_, i) => i !== index))}
+          />
+        </InlineFieldRow>
+      ))}
+      <InlineFieldRow>
+        <Button variant="secondary" size="sm" icon="plus" onClick={() => onChange([...values, { key: '', value: '' }])}>
+          {label}
+        </Button>
+      </InlineFieldRow>
+    </>
+  );
+};
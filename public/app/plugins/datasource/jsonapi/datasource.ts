@@ -0,0 +1,29 @@
+import { DataSourceInstanceSettings, ScopedVars } from '@grafana/data';
+import { DataSourceWithBackend, getTemplateSrv } from '@grafana/runtime';
+
+import { JsonApiOptions, JsonApiQuery } from './types';
+
+export class JsonApiDataSource extends DataSourceWithBackend<JsonApiQuery, JsonApiOptions> {
+  constructor(instanceSettings: DataSourceInstanceSettings<JsonApiOptions>) {
+    super(instanceSettings);
+  }
+
+  filterQuery(query: JsonApiQuery): boolean {
+    return !query.hide && Boolean(query.fields?.some((field) => field.jsonPath));
+  }
+
+  // $__from and $__to are also interpolated by the backend, so the same query works in alert rules.
+  applyTemplateVariables(query: JsonApiQuery, scopedVars: ScopedVars): JsonApiQuery {
+    const templateSrv = getTemplateSrv();
+    const replace = (value?: string) => (value ? templateSrv.replace(value, scopedVars) : value);
+
+    return {
+      ...query,
+      path: replace(query.path),
+      body: replace(query.body),
+      params: query.params?.map((param) => ({ ...param, value: replace(param.value) ?? '' })),
+      headers: query.headers?.map((header) => ({ ...header, value: replace(header.value) ?? '' })),
+      fields: query.fields.map((field) => ({ ...field, jsonPath: replace(field.jsonPath) ?? '' })),
+    };
+  }
+}
@@ -0,0 +1,10 @@
+import { DataSourcePlugin } from '@grafana/data';
+
+import { ConfigEditor } from './ConfigEditor';
+import { QueryEditor } from './QueryEditor';
+import { JsonApiDataSource } from './datasource';
+import { JsonApiOptions, JsonApiQuery } from './types';
+
+export const plugin = new DataSourcePlugin<JsonApiDataSource, JsonApiQuery, JsonApiOptions>(JsonApiDataSource)
+  .setConfigEditor(ConfigEditor)
+  .setQueryEditor(QueryEditor);
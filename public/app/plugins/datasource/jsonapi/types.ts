@@ -0,0 +1,35 @@
+import { DataQuery, DataSourceJsonData } from '@grafana/data';
+
+export type JsonApiFieldType = '' | 'string' | 'number' | 'boolean' | 'time';
+
+export interface JsonApiField {
+  name?: string;
+  jsonPath: string;
+  type?: JsonApiFieldType;
+}
+
+export interface KeyValue {
+  key: string;
+  value: string;
+}
+
+export interface JsonApiQuery extends DataQuery {
+  method?: 'GET' | 'POST';
+  path?: string;
+  params?: KeyValue[];
+  headers?: KeyValue[];
+  body?: string;
+  fields: JsonApiField[];
+}
+
+export const defaultQuery: Partial<JsonApiQuery> = {
+  method: 'GET',
+  fields: [{ jsonPath: '$' }],
+};
+
+/**
+ * These are options configured for each DataSource instance.
+ */
+export interface JsonApiOptions extends DataSourceJsonData {
+  healthCheckPath?: string;
+}
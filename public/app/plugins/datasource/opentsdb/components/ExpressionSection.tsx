@@ -0,0 +1,63 @@
+import React, { useState } from 'react';
+
+import { InlineFormLabel, TextArea } from '@grafana/ui';
+
+import { OpenTsdbExpression, OpenTsdbQuery } from '../types';
+
+export interface ExpressionSectionProps {
+  query: OpenTsdbQuery;
+  onChange: (query: OpenTsdbQuery) => void;
+  onRunQuery: () => void;
+}
+
+export const defaultExpression: OpenTsdbExpression = {
+  time: { aggregator: 'sum' },
+  metrics: [{ id: 'a', metric: '' }],
+  expressions: [{ id: 'e', expr: 'a' }],
+};
+
+export function ExpressionSection({ query, onChange, onRunQuery }: ExpressionSectionProps) {
+  const [value, setValue] = useState(JSON.stringify(query.expression ?? defaultExpression, null, 2));
+  const [error, setError] = useState<string | undefined>();
+
+  const onBlur = () => {
+    let expression: OpenTsdbExpression;
+    try {
+      expression = JSON.parse(value);
+    } catch (e) {
+      setError('The expression is not valid JSON');
+      return;
+    }
+    setError(undefined);
+    onChange({ ...query, expression });
+    onRunQuery();
+  };
+
+  return (
+    <div className="gf-form-inline" data-testid={testIds.section}>
+      <div className="gf-form gf-form--grow">
+        <InlineFormLabel
+          className="query-keyword"
+          width={8}
+          tooltip="The body of a /api/query/exp request. The start and end of the time section are set from the time range."
+        >
+          Expression
+        </InlineFormLabel>
+        <TextArea
+          data-testid={testIds.expression}
+          rows={10}
+          value={value}
+          invalid={!!error}
+          onChange={(e) => setValue(e.currentTarget.value)}
+          onBlur={onBlur}
+        />
+      </div>
+      {error && <div className="gf-form-label text-warning">{error}</div>}
+    </div>
+  );
+}
+
+export const testIds = {
+  section: 'opentsdb-expression',
+  expression: 'opentsdb-expression-input',
+};
@@ -4,16 +4,17 @@ import React from 'react';
 import OpenTsDatasource from '../datasource';
 import { OpenTsdbQuery } from '../types';
 
+import { testIds as expressionTestIds } from './ExpressionSection';
 import { OpenTsdbQueryEditor, OpenTsdbQueryEditorProps, testIds } from './OpenTsdbQueryEditor';
 
-const setup = (propOverrides?: Object) => {
+const setup = (propOverrides?: Object, tsdbVersion = 1) => {
   const getAggregators = jest.fn().mockResolvedValue([]);
   const getFilterTypes = jest.fn().mockResolvedValue([]);
 
   const datasourceMock: unknown = {
     getAggregators,
     getFilterTypes,
-    tsdbVersion: 1,
+    tsdbVersion,
   };
 
   const datasource: OpenTsDatasource = datasourceMock as OpenTsDatasource;
@@ -36,4 +37,15 @@ describe('OpenTsdbQueryEditor', () => {
     setup();
     expect(screen.getByTestId(testIds.editor)).toBeInTheDocument();
   });
+
+  it('should render the expression of an expression query', () => {
+    const query: OpenTsdbQuery = {
+      refId: 'A',
+      queryType: 'expression',
+      expression: { metrics: [{ id: 'a', metric: 'cpu' }], expressions: [{ id: 'e', expr: 'a * 2' }] },
+    };
+    setup({ query }, 3);
+    expect(screen.getByTestId(expressionTestIds.section)).toBeInTheDocument();
+    expect(screen.getByTestId(expressionTestIds.expression)).toHaveValue(JSON.stringify(query.expression, null, 2));
+  });
 });
@@ -2,12 +2,13 @@ import { css } from '@emotion/css';
 import React, { useState } from 'react';
 
 import { GrafanaTheme2, QueryEditorProps, textUtil } from '@grafana/data';
-import { useStyles2 } from '@grafana/ui';
+import { RadioButtonGroup, useStyles2 } from '@grafana/ui';
 
 import OpenTsDatasource from '../datasource';
-import { OpenTsdbOptions, OpenTsdbQuery } from '../types';
+import { OpenTsdbOptions, OpenTsdbQuery, OpenTsdbQueryType } from '../types';
 
 import { DownSample } from './DownSample';
+import { defaultExpression, ExpressionSection } from './ExpressionSection';
 import { FilterSection } from './FilterSection';
 import { MetricSection } from './MetricSection';
 import { RateSection } from './RateSection';
@@ -50,6 +51,11 @@ export function OpenTsdbQueryEditor({
 
   const tsdbVersion: number = datasource.tsdbVersion;
 
+  const queryTypes: Array<{ label: string; value: OpenTsdbQueryType }> = [
+    { label: 'Metric', value: 'metric' },
+    { label: 'Expression', value: 'expression' },
+  ];
+
   if (!query.aggregator) {
     query.aggregator = 'sum';
   }
@@ -102,6 +108,31 @@ export function OpenTsdbQueryEditor({
   return (
     <div className={styles.container} data-testid={testIds.editor}>
       <div className={styles.visualEditor}>
+        {tsdbVersion >= 3 && (
+          <div className="gf-form">
+            <RadioButtonGroup
+              size="sm"
+              options={queryTypes}
+              value={query.queryType ?? 'metric'}
+              onChange={(queryType) => {
+                onChange({ ...query, queryType, expression: query.expression ?? defaultExpression });
+                onRunQuery();
+              }}
+            />
+          </div>
+        )}
+        {query.queryType === 'expression' ? (
+          <ExpressionSection query={query} onChange={onChange} onRunQuery={onRunQuery} />
+        ) : (
+          renderMetricQuery()
+        )}
+      </div>
+    </div>
+  );
+
+  function renderMetricQuery() {
+    return (
+      <>
         <MetricSection
           query={query}
           onChange={onChange}
@@ -136,9 +167,9 @@ export function OpenTsdbQueryEditor({
           tsdbVersion={tsdbVersion}
         />
         <RateSection query={query} onChange={onChange} onRunQuery={onRunQuery} tsdbVersion={tsdbVersion} />
-      </div>
-    </div>
-  );
+      </>
+    );
+  }
 }
 
 function getStyles(theme: GrafanaTheme2) {
@@ -13,26 +13,25 @@ import {
   map as _map,
   toPairs,
 } from 'lodash';
-import { lastValueFrom, merge, Observable, of } from 'rxjs';
+import { from, lastValueFrom, merge, Observable, of } from 'rxjs';
 import { catchError, map } from 'rxjs/operators';
 
 import {
   AnnotationEvent,
   DataQueryRequest,
   DataQueryResponse,
-  DataSourceApi,
   dateMath,
   ScopedVars,
   toDataFrame,
 } from '@grafana/data';
-import { FetchResponse, getBackendSrv } from '@grafana/runtime';
+import { DataSourceWithBackend, FetchResponse, getBackendSrv } from '@grafana/runtime';
 import { getTemplateSrv, TemplateSrv } from 'app/features/templating/template_srv';
 
 import { AnnotationEditor } from './components/AnnotationEditor';
 import { prepareAnnotation } from './migrations';
 import { OpenTsdbFilter, OpenTsdbOptions, OpenTsdbQuery } from './types';
 
-export default class OpenTsDatasource extends DataSourceApi<OpenTsdbQuery, OpenTsdbOptions> {
+export default class OpenTsDatasource extends DataSourceWithBackend<OpenTsdbQuery, OpenTsdbOptions> {
   type: any;
   url: any;
   name: any;
@@ -94,6 +93,17 @@ export default class OpenTsDatasource extends DataSourceApi<OpenTsdbQuery, OpenT
       return merge(...streams);
     }
 
+    // Expression queries are only supported by the backend
+    const expressionTargets = options.targets.filter((target) => target.queryType === 'expression' && !target.hide);
+    if (expressionTargets.length > 0) {
+      const metricTargets = options.targets.filter((target) => target.queryType !== 'expression');
+      const expressionStream = super.query({ ...options, targets: expressionTargets });
+      if (!metricTargets.some((target) => target.metric && !target.hide)) {
+        return expressionStream;
+      }
+      return merge(expressionStream, this.query({ ...options, targets: metricTargets }));
+    }
+
     const start = this.convertToTSDBTime(options.range.raw.from, false, options.timezone);
     const end = this.convertToTSDBTime(options.range.raw.to, true, options.timezone);
     const qs: any[] = [];
@@ -316,19 +326,12 @@ export default class OpenTsDatasource extends DataSourceApi<OpenTsdbQuery, OpenT
     );
   }
 
+  // The lookups are served by the backend, which sends them to OpenTSDB with the data source authentication
   _get(
     relativeUrl: string,
     params?: { type?: string; q?: string; max?: number; m?: any; limit?: number }
-  ): Observable<FetchResponse> {
-    const options = {
-      method: 'GET',
-      url: this.url + relativeUrl,
-      params: params,
-    };
-
-    this._addCredentialOptions(options);
-
-    return getBackendSrv().fetch(options);
+  ): Observable<{ data: any }> {
+    return from(this.getResource(relativeUrl.replace(/^\//, ''), params)).pipe(map((data) => ({ data })));
   }
 
   _addCredentialOptions(options: any) {
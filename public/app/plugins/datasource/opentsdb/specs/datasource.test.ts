@@ -28,7 +28,7 @@ describe('opentsdb', () => {
     const fetchMock = jest.spyOn(backendSrv, 'fetch');
     fetchMock.mockImplementation(() => of(createFetchResponse(data)));
 
-    const instanceSettings = { url: '', jsonData: { tsdbVersion: 1 } };
+    const instanceSettings = { id: 1, url: '', jsonData: { tsdbVersion: 1 } };
     const replace = jest.fn((value) => value);
     const templateSrv: any = {
       replace,
@@ -46,7 +46,7 @@ describe('opentsdb', () => {
       const results = await ds.metricFindQuery('metrics(pew)');
 
       expect(fetchMock).toHaveBeenCalledTimes(1);
-      expect(fetchMock.mock.calls[0][0].url).toBe('/api/suggest');
+      expect(fetchMock.mock.calls[0][0].url).toBe('/api/datasources/1/resources/api/suggest');
       expect(fetchMock.mock.calls[0][0].params?.type).toBe('metrics');
       expect(fetchMock.mock.calls[0][0].params?.q).toBe('pew');
       expect(results).not.toBe(null);
@@ -58,7 +58,7 @@ describe('opentsdb', () => {
       const results = await ds.metricFindQuery('tag_names(cpu)');
 
       expect(fetchMock).toHaveBeenCalledTimes(1);
-      expect(fetchMock.mock.calls[0][0].url).toBe('/api/search/lookup');
+      expect(fetchMock.mock.calls[0][0].url).toBe('/api/datasources/1/resources/api/search/lookup');
       expect(fetchMock.mock.calls[0][0].params?.m).toBe('cpu');
       expect(results).not.toBe(null);
     });
@@ -69,7 +69,7 @@ describe('opentsdb', () => {
       const results = await ds.metricFindQuery('tag_values(cpu, hostname)');
 
       expect(fetchMock).toHaveBeenCalledTimes(1);
-      expect(fetchMock.mock.calls[0][0].url).toBe('/api/search/lookup');
+      expect(fetchMock.mock.calls[0][0].url).toBe('/api/datasources/1/resources/api/search/lookup');
       expect(fetchMock.mock.calls[0][0].params?.m).toBe('cpu{hostname=*}');
       expect(results).not.toBe(null);
     });
@@ -80,7 +80,7 @@ describe('opentsdb', () => {
       const results = await ds.metricFindQuery('tag_values(cpu, hostname, env=$env)');
 
       expect(fetchMock).toHaveBeenCalledTimes(1);
-      expect(fetchMock.mock.calls[0][0].url).toBe('/api/search/lookup');
+      expect(fetchMock.mock.calls[0][0].url).toBe('/api/datasources/1/resources/api/search/lookup');
       expect(fetchMock.mock.calls[0][0].params?.m).toBe('cpu{hostname=*,env=$env}');
       expect(results).not.toBe(null);
     });
@@ -91,7 +91,7 @@ describe('opentsdb', () => {
       const results = await ds.metricFindQuery('tag_values(cpu, hostname, env=$env, region=$region)');
 
       expect(fetchMock).toHaveBeenCalledTimes(1);
-      expect(fetchMock.mock.calls[0][0].url).toBe('/api/search/lookup');
+      expect(fetchMock.mock.calls[0][0].url).toBe('/api/datasources/1/resources/api/search/lookup');
       expect(fetchMock.mock.calls[0][0].params?.m).toBe('cpu{hostname=*,env=$env,region=$region}');
       expect(results).not.toBe(null);
     });
@@ -102,7 +102,7 @@ describe('opentsdb', () => {
       const results = await ds.metricFindQuery('suggest_tagk(foo)');
 
       expect(fetchMock).toHaveBeenCalledTimes(1);
-      expect(fetchMock.mock.calls[0][0].url).toBe('/api/suggest');
+      expect(fetchMock.mock.calls[0][0].url).toBe('/api/datasources/1/resources/api/suggest');
       expect(fetchMock.mock.calls[0][0].params?.type).toBe('tagk');
       expect(fetchMock.mock.calls[0][0].params?.q).toBe('foo');
       expect(results).not.toBe(null);
@@ -114,7 +114,7 @@ describe('opentsdb', () => {
       const results = await ds.metricFindQuery('suggest_tagv(bar)');
 
       expect(fetchMock).toHaveBeenCalledTimes(1);
-      expect(fetchMock.mock.calls[0][0].url).toBe('/api/suggest');
+      expect(fetchMock.mock.calls[0][0].url).toBe('/api/datasources/1/resources/api/suggest');
       expect(fetchMock.mock.calls[0][0].params?.type).toBe('tagv');
       expect(fetchMock.mock.calls[0][0].params?.q).toBe('bar');
       expect(results).not.toBe(null);
@@ -1,6 +1,13 @@
 import { DataQuery, DataSourceJsonData } from '@grafana/data';
 
+export type OpenTsdbQueryType = 'metric' | 'expression';
+
 export interface OpenTsdbQuery extends DataQuery {
+  // the metric queries are sent to /api/query, the expression queries to /api/query/exp through the backend
+  queryType?: OpenTsdbQueryType;
+  // the body of the expression query, without the start and end of the time section
+  expression?: OpenTsdbExpression;
+
   // migrating to react
   // metrics section
   metric?: string;
@@ -39,6 +46,17 @@ export interface OpenTsdbOptions extends DataSourceJsonData {
   lookupLimit: number;
 }
 
+export type OpenTsdbExpression = {
+  time?: {
+    aggregator?: string;
+    downsampler?: { interval: string; aggregator: string; fillPolicy?: { policy: string } };
+  };
+  filters?: Array<{ id: string; tags: Array<{ type: string; tagk: string; filter: string; groupBy: boolean }> }>;
+  metrics: Array<{ id: string; metric: string; filter?: string }>;
+  expressions: Array<{ id: string; expr: string }>;
+  outputs?: Array<{ id: string; alias?: string }>;
+};
+
 export type LegacyAnnotation = {
   fromAnnotations?: boolean;
   isGlobal?: boolean;
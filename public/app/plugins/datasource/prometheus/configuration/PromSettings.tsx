@@ -184,6 +184,26 @@ export const PromSettings = (props: Props) => {
             />
           </div>
         </div>
+        {/* Query split interval */}
+        <div className="gf-form-inline">
+          <div className="gf-form">
+            <FormField
+              label="Query split interval"
+              labelWidth={13}
+              inputEl={
+                <Input
+                  className="width-6"
+                  value={options.jsonData.queryChunkInterval}
+                  onChange={onChangeHandler('queryChunkInterval', options, onOptionsChange)}
+                  spellCheck={false}
+                  placeholder="1d"
+                  validationEvents={promSettingsValidationEvents}
+                />
+              }
+              tooltip="Split range queries over longer time ranges into queries of this length that run concurrently. If some of them fail, the panel shows partial results. Leave empty to disable splitting."
+            />
+          </div>
+        </div>
         {/* HTTP Method */}
         <div className="gf-form">
           <InlineFormLabel
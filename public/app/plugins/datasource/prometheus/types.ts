@@ -27,6 +27,8 @@ export interface PromQuery extends DataQuery {
 export interface PromOptions extends DataSourceJsonData {
   timeInterval?: string;
   queryTimeout?: string;
+  queryChunkInterval?: string;
+  queryChunkConcurrency?: number;
   httpMethod?: string;
   directUrl?: string;
   customQueryParameters?: string;
@@ -44,6 +44,7 @@ export interface DashboardMeta {
   publicDashboardAccessToken?: string;
   publicDashboardUid?: string;
   publicDashboardEnabled?: boolean;
+  publicDashboardEmailRequired?: boolean;
   hasPublicDashboard?: boolean;
   dashboardNotFound?: boolean;
 }